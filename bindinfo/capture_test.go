@@ -1022,3 +1022,73 @@ func TestCaptureHints(t *testing.T) {
 		require.Equal(t, res[0][9], sqlDigestWithDB.String())
 	}
 }
+
+func TestEvolveCapturedBindings(t *testing.T) {
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = true
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+	}()
+
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("insert into t values (1,1,1), (2,2,2), (3,3,3), (4,4,4), (5,5,5)")
+	tk.MustExec("analyze table t")
+	utilCleanBindingEnv(tk, dom)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("SET GLOBAL tidb_capture_plan_baselines = on")
+	defer func() {
+		tk.MustExec("SET GLOBAL tidb_capture_plan_baselines = off")
+	}()
+	tk.MustExec("select * from t where a > 1 and b > 1")
+	tk.MustExec("select * from t where a > 1 and b > 1")
+	tk.MustExec("admin capture bindings")
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, bindinfo.Capture, rows[0][8])
+
+	// The alternative plans are only generated when the evolution is enabled globally.
+	tk.MustExec("admin evolve bindings")
+	require.Len(t, tk.MustQuery("show global bindings").Rows(), 1)
+
+	tk.MustExec("SET GLOBAL tidb_evolve_plan_baselines = on")
+	defer func() {
+		tk.MustExec("SET GLOBAL tidb_evolve_plan_baselines = off")
+	}()
+	tk.MustExec("admin evolve bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Greater(t, len(rows), 1)
+	for i := 0; i < len(rows); i++ {
+		tk.MustExec("admin evolve bindings")
+	}
+	rows = tk.MustQuery("show global bindings").Rows()
+	enabled := 0
+	for _, row := range rows {
+		require.Equal(t, "select * from `test` . `t` where `a` > ? and `b` > ?", row[0])
+		status := row[3].(string)
+		require.True(t, status == bindinfo.Enabled || status == bindinfo.Rejected, status)
+		if status == bindinfo.Enabled {
+			enabled++
+		}
+	}
+	// Only the accepted plan is kept enabled.
+	require.Equal(t, 1, enabled)
+
+	// Each pending verify binding is verified once on a snapshot.
+	tk.MustQuery("select count(*) from mysql.tidb_evolve_plan_history").Check(testkit.Rows(fmt.Sprintf("%d", len(rows)-1)))
+	tk.MustQuery("select count(*) from mysql.tidb_evolve_plan_history where status in ('accepted', 'rejected') and snapshot_ts > 0 and bind_sql like 'SELECT /*+ %'").
+		Check(testkit.Rows(fmt.Sprintf("%d", len(rows)-1)))
+	tk.MustQuery("select @@tidb_snapshot").Check(testkit.Rows(""))
+
+	// The bindings created by users are never evolved automatically.
+	utilCleanBindingEnv(tk, dom)
+	tk.MustExec("create global binding for select * from t where a > 1 and b > 1 using select * from t use index(idx_a) where a > 1 and b > 1")
+	tk.MustExec("admin evolve bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, bindinfo.Manual, rows[0][8])
+}
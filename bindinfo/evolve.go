@@ -0,0 +1,327 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/hint"
+	"github.com/pingcap/tidb/util/logutil"
+	utilparser "github.com/pingcap/tidb/util/parser"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// maxEvolveCandidates is the max number of the alternative plans generated for a binding in one round.
+	maxEvolveCandidates = 3
+	// maxEvolveNthPlan is the max parameter of the nth_plan hint used to generate the alternative plans.
+	maxEvolveNthPlan = 10
+
+	// The status of the verification in mysql.tidb_evolve_plan_history.
+	evolveStatusAccepted = "accepted"
+	evolveStatusRejected = "rejected"
+	evolveStatusFailed   = "failed"
+)
+
+// isAutoBinding checks whether the binding is created by TiDB automatically.
+func isAutoBinding(binding *Binding) bool {
+	return binding.Source == Capture || binding.Source == Evolve
+}
+
+// getOneRecordToEvolve returns a BindRecord whose enabled bindings are all created automatically, and
+// whose alternative plans haven't been generated in the last nextVerifyDuration.
+func (h *BindHandle) getOneRecordToEvolve() *BindRecord {
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	h.evolvedRecords.Lock()
+	defer h.evolvedRecords.Unlock()
+	for _, bindRecord := range cache.GetAllBindRecords() {
+		binding := bindRecord.FindEnabledBinding()
+		if binding == nil {
+			continue
+		}
+		allAuto := true
+		for i := range bindRecord.Bindings {
+			if bindRecord.Bindings[i].IsBindingEnabled() && !isAutoBinding(&bindRecord.Bindings[i]) {
+				allAuto = false
+				break
+			}
+		}
+		if !allAuto {
+			continue
+		}
+		if lastTime, ok := h.evolvedRecords.m[binding.SQLDigest]; ok && time.Since(lastTime) < nextVerifyDuration {
+			continue
+		}
+		return bindRecord
+	}
+	return nil
+}
+
+// generateEvolveTasks generates the alternative plans of the enabled binding, which are the plan chosen by the
+// optimizer without bindings and the plans forced by the nth_plan hint. The plans different from the existing
+// bindings are added as the pending verify bindings.
+func (h *BindHandle) generateEvolveTasks(sctx sessionctx.Context, record *BindRecord) error {
+	baseline := record.FindEnabledBinding()
+	h.evolvedRecords.Lock()
+	h.evolvedRecords.m[baseline.SQLDigest] = time.Now()
+	h.evolvedRecords.Unlock()
+
+	stmt, err := parser.New().ParseOneStmt(baseline.BindSQL, baseline.Charset, baseline.Collation)
+	if err != nil {
+		return err
+	}
+	// Only the queries can be verified on a snapshot.
+	if _, ok := stmt.(*ast.SelectStmt); !ok {
+		return nil
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	generated := make(map[string]struct{}, maxEvolveCandidates)
+	for n := 0; n <= maxEvolveNthPlan && len(generated) < maxEvolveCandidates; n++ {
+		var sql string
+		if n == 0 {
+			hint.BindHint(stmt, &hint.HintsSet{})
+			sql = utilparser.RestoreWithDefaultDB(stmt, record.Db, "")
+		} else {
+			sql = GenerateBindSQL(ctx, stmt, fmt.Sprintf("nth_plan(%d)", n), true, record.Db)
+		}
+		if sql == "" {
+			return nil
+		}
+		planHint, err := getHintsForSQL(sctx, sql)
+		if err != nil {
+			return err
+		}
+		planHint = removeNthPlanHint(planHint)
+		bindSQL := GenerateBindSQL(ctx, stmt, planHint, true, record.Db)
+		if bindSQL == "" {
+			continue
+		}
+		candidate := &BindRecord{
+			OriginalSQL: record.OriginalSQL,
+			Db:          record.Db,
+			Bindings: []Binding{{
+				BindSQL:   bindSQL,
+				Status:    PendingVerify,
+				Charset:   baseline.Charset,
+				Collation: baseline.Collation,
+				Source:    Evolve,
+				SQLDigest: baseline.SQLDigest,
+			}},
+		}
+		if err = candidate.prepareHints(nil); err != nil {
+			return err
+		}
+		id := candidate.Bindings[0].ID
+		if _, ok := generated[id]; ok || record.FindBinding(id) != nil {
+			continue
+		}
+		generated[id] = struct{}{}
+		// We don't need to pass the `sctx` because the BindSQL has been validated already.
+		if err = h.AddBindRecord(nil, candidate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// removeNthPlanHint removes the nth_plan hint, which is used to generate the plan, from the hints of the plan.
+func removeNthPlanHint(planHint string) string {
+	hints, _ := parser.ParseHint(planHint, mysql.ModeNone, parser.Pos{})
+	result := make([]*ast.TableOptimizerHint, 0, len(hints))
+	for _, tblHint := range hints {
+		if tblHint.HintName.L != "nth_plan" {
+			result = append(result, tblHint)
+		}
+	}
+	return hint.RestoreOptimizerHints(result)
+}
+
+// planExecStats is the execution statistics of a plan in the verification.
+type planExecStats struct {
+	// duration is -1 if the plan isn't finished in time.
+	duration  time.Duration
+	totalKeys int64
+}
+
+func (s *planExecStats) timeout() bool {
+	return s.duration < 0
+}
+
+// execTimeArg returns the execution time in seconds for mysql.tidb_evolve_plan_history, nil means timeout.
+func (s *planExecStats) execTimeArg() interface{} {
+	if s.timeout() {
+		return nil
+	}
+	return s.duration.Seconds()
+}
+
+func runPlanWithTimeout(ctx context.Context, sctx sessionctx.Context, sql string, maxTime time.Duration) (planExecStats, error) {
+	ctx, cancelFunc := context.WithCancel(ctx)
+	timer := time.NewTimer(maxTime)
+	defer timer.Stop()
+	resultChan := make(chan error)
+	startTime := time.Now()
+	go runSQL(ctx, sctx, sql, resultChan)
+	select {
+	case err := <-resultChan:
+		cancelFunc()
+		if err != nil {
+			return planExecStats{}, err
+		}
+		stats := planExecStats{duration: time.Since(startTime)}
+		if scanDetail := sctx.GetSessionVars().StmtCtx.GetExecDetails().ScanDetail; scanDetail != nil {
+			stats.totalKeys = scanDetail.TotalKeys
+		}
+		return stats, nil
+	case <-timer.C:
+		cancelFunc()
+		logutil.BgLogger().Debug("plan verification timed out", zap.String("category", "sql-bind"), zap.Duration("timeElapsed", time.Since(startTime)), zap.String("query", sql))
+	}
+	<-resultChan
+	return planExecStats{duration: -1}, nil
+}
+
+// runEvolveTask runs the plan chosen with the current bindings and the plan of the pending verify binding.
+// Both of them read the same snapshot, so the verification never writes and their stats are comparable.
+func runEvolveTask(sctx sessionctx.Context, db, sql string, maxTime time.Duration) (snapshotTS uint64, current, verify planExecStats, err error) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	exec := sctx.(sqlexec.SQLExecutor)
+	if db != "" {
+		if _, err = exec.ExecuteInternal(ctx, "use %n", db); err != nil {
+			return
+		}
+	}
+	ver, err := sctx.GetStore().CurrentVersion(kv.GlobalTxnScope)
+	if err != nil {
+		return
+	}
+	snapshotTS = ver.Ver
+	if _, err = exec.ExecuteInternal(ctx, "set @@tidb_snapshot = %?", strconv.FormatUint(snapshotTS, 10)); err != nil {
+		return
+	}
+	defer func() {
+		_, err1 := exec.ExecuteInternal(ctx, "set @@tidb_snapshot = ''")
+		if err == nil {
+			err = err1
+		}
+	}()
+	sctx.GetSessionVars().UsePlanBaselines = true
+	current, err = runPlanWithTimeout(ctx, sctx, sql, maxTime)
+	if err != nil {
+		return
+	}
+	// If the current plan timeouts, it is hard to decide the timeout for verify plan.
+	// Currently we simply accept the verify plan if it could run successfully within maxTime.
+	if !current.timeout() {
+		maxTime = time.Duration(float64(current.duration) * verifyTimeoutFactor)
+	}
+	sctx.GetSessionVars().UsePlanBaselines = false
+	verify, err = runPlanWithTimeout(ctx, sctx, sql, maxTime)
+	return
+}
+
+// acceptVerifiedPlan decides whether the pending verify plan performs better than the current plan.
+func acceptVerifiedPlan(current, verify planExecStats) bool {
+	if verify.timeout() {
+		return false
+	}
+	if current.timeout() || float64(verify.duration)*acceptFactor <= float64(current.duration) {
+		return true
+	}
+	// The execution time of the short queries is easily affected by the noise, so the verify plan is
+	// also accepted if it is not slower and scans much fewer keys than the current plan.
+	return verify.duration <= current.duration && float64(verify.totalKeys)*acceptFactor <= float64(current.totalKeys)
+}
+
+func (h *BindHandle) verifyEvolveTask(sctx sessionctx.Context, originalSQL, db string, binding Binding, maxTime time.Duration) error {
+	startTime := time.Now()
+	snapshotTS, current, verify, err := runEvolveTask(sctx, db, binding.BindSQL, maxTime)
+	// If we just return the error to the caller, this job will be retried again and again and cause endless logs,
+	// since it is still in the bind record. Now we just drop it and if it is actually retryable,
+	// we will hope for that we can capture this evolve task again.
+	if err != nil {
+		recordEvolveHistory(sctx, originalSQL, db, &binding, snapshotTS, current, verify, evolveStatusFailed, err.Error(), startTime)
+		_, err = h.DropBindRecord(originalSQL, db, &binding)
+		return err
+	}
+	status := evolveStatusAccepted
+	if acceptVerifiedPlan(current, verify) {
+		binding.Status = Enabled
+	} else {
+		status = evolveStatusRejected
+		binding.Status = Rejected
+		digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
+		logutil.BgLogger().Debug("new plan rejected", zap.String("category", "sql-bind"),
+			zap.Duration("currentPlanTime", current.duration),
+			zap.Duration("verifyPlanTime", verify.duration),
+			zap.String("digestText", digestText),
+		)
+	}
+	recordEvolveHistory(sctx, originalSQL, db, &binding, snapshotTS, current, verify, status, "", startTime)
+	// We don't need to pass the `sctx` because the BindSQL has been validated already.
+	err = h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
+	if err != nil || binding.Status != Enabled {
+		return err
+	}
+	return h.rejectSupersededBindings(originalSQL, db, &binding)
+}
+
+// rejectSupersededBindings marks the other enabled bindings created automatically as rejected, so the accepted
+// plan is always used by the optimizer. They can be verified again after nextVerifyDuration like other rejected
+// bindings. The bindings created by users are never changed.
+func (h *BindHandle) rejectSupersededBindings(originalSQL, db string, accepted *Binding) error {
+	record := h.GetBindRecord(parser.DigestNormalized(originalSQL).String(), originalSQL, db)
+	if record == nil {
+		return nil
+	}
+	for _, binding := range record.Bindings {
+		if !binding.IsBindingEnabled() || !isAutoBinding(&binding) || binding.isSame(accepted) {
+			continue
+		}
+		binding.Status = Rejected
+		if err := h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// recordEvolveHistory writes the result of the verification into mysql.tidb_evolve_plan_history.
+func recordEvolveHistory(sctx sessionctx.Context, originalSQL, db string, binding *Binding, snapshotTS uint64,
+	current, verify planExecStats, status, message string, startTime time.Time) {
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		`INSERT INTO mysql.tidb_evolve_plan_history (original_sql, default_db, sql_digest, bind_sql, snapshot_ts,
+			current_exec_time, current_total_keys, verify_exec_time, verify_total_keys, status, message, start_time, end_time)
+			VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		originalSQL, db, binding.SQLDigest, binding.BindSQL, snapshotTS,
+		current.execTimeArg(), current.totalKeys, verify.execTimeArg(), verify.totalKeys, status, message,
+		types.NewTime(types.FromGoTime(startTime), mysql.TypeTimestamp, 3).String(),
+		types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3).String(),
+	)
+	if err != nil {
+		logutil.BgLogger().Warn("record the history of the baseline evolution failed", zap.String("category", "sql-bind"), zap.Error(err))
+	}
+}
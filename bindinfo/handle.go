@@ -86,6 +86,13 @@ type BindHandle struct {
 
 	// pendingVerifyBindRecordMap indicates the pending verify bind records that found during query.
 	pendingVerifyBindRecordMap tmpBindRecordMap
+
+	// evolvedRecords records when the alternative plans of the automatically created bindings are generated
+	// by the baseline evolution, the key is the SQL digest of the bindings.
+	evolvedRecords struct {
+		sync.Mutex
+		m map[string]time.Time
+	}
 }
 
 // Lease influences the duration of loading bind info and handling invalid bind.
@@ -129,6 +136,9 @@ func (h *BindHandle) Reset(ctx sessionctx.Context) {
 		// BindSQL has already been validated when coming here, so we use nil sctx parameter.
 		return h.AddBindRecord(nil, record)
 	}
+	h.evolvedRecords.Lock()
+	h.evolvedRecords.m = make(map[string]time.Time)
+	h.evolvedRecords.Unlock()
 	variable.RegisterStatistics(h)
 }
 
@@ -579,6 +589,10 @@ func (h *BindHandle) GCBindRecord() (err error) {
 	updateTime := time.Now().Add(-(10 * Lease))
 	updateTimeStr := types.NewTime(types.FromGoTime(updateTime), mysql.TypeTimestamp, 3).String()
 	_, err = exec.ExecuteInternal(ctx, `DELETE FROM mysql.bind_info WHERE status = 'deleted' and update_time < %?`, updateTimeStr)
+	if err != nil {
+		return err
+	}
+	_, err = exec.ExecuteInternal(ctx, "DELETE FROM mysql.tidb_evolve_plan_history WHERE start_time < CURDATE() - INTERVAL 90 DAY")
 	return err
 }
 
@@ -1078,26 +1092,39 @@ func (h *BindHandle) SaveEvolveTasksToStore() {
 	h.pendingVerifyBindRecordMap.flushToStore()
 }
 
-func getEvolveParameters(sctx sessionctx.Context) (time.Duration, time.Time, time.Time, error) {
+// evolveParameters are the global variables which control the baseline evolution.
+type evolveParameters struct {
+	// enabled indicates whether the alternative plans of the automatically created bindings are generated.
+	enabled   bool
+	maxTime   time.Duration
+	startTime time.Time
+	endTime   time.Time
+}
+
+func getEvolveParameters(sctx sessionctx.Context) (*evolveParameters, error) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
 	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(
 		ctx,
 		nil,
-		"SELECT variable_name, variable_value FROM mysql.global_variables WHERE variable_name IN (%?, %?, %?)",
+		"SELECT variable_name, variable_value FROM mysql.global_variables WHERE variable_name IN (%?, %?, %?, %?)",
+		variable.TiDBEvolvePlanBaselines,
 		variable.TiDBEvolvePlanTaskMaxTime,
 		variable.TiDBEvolvePlanTaskStartTime,
 		variable.TiDBEvolvePlanTaskEndTime,
 	)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return nil, err
 	}
+	params := &evolveParameters{enabled: variable.DefTiDBEvolvePlanBaselines}
 	maxTime, startTimeStr, endTimeStr := int64(variable.DefTiDBEvolvePlanTaskMaxTime), variable.DefTiDBEvolvePlanTaskStartTime, variable.DefAutoAnalyzeEndTime
 	for _, row := range rows {
 		switch row.GetString(0) {
+		case variable.TiDBEvolvePlanBaselines:
+			params.enabled = variable.TiDBOptOn(row.GetString(1))
 		case variable.TiDBEvolvePlanTaskMaxTime:
 			maxTime, err = strconv.ParseInt(row.GetString(1), 10, 64)
 			if err != nil {
-				return 0, time.Time{}, time.Time{}, err
+				return nil, err
 			}
 		case variable.TiDBEvolvePlanTaskStartTime:
 			startTimeStr = row.GetString(1)
@@ -1105,15 +1132,16 @@ func getEvolveParameters(sctx sessionctx.Context) (time.Duration, time.Time, tim
 			endTimeStr = row.GetString(1)
 		}
 	}
-	startTime, err := time.ParseInLocation(variable.FullDayTimeFormat, startTimeStr, time.UTC)
+	params.startTime, err = time.ParseInLocation(variable.FullDayTimeFormat, startTimeStr, time.UTC)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return nil, err
 	}
-	endTime, err := time.ParseInLocation(variable.FullDayTimeFormat, endTimeStr, time.UTC)
+	params.endTime, err = time.ParseInLocation(variable.FullDayTimeFormat, endTimeStr, time.UTC)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return nil, err
 	}
-	return time.Duration(maxTime) * time.Second, startTime, endTime, nil
+	params.maxTime = time.Duration(maxTime) * time.Second
+	return params, nil
 }
 
 const (
@@ -1152,35 +1180,6 @@ func (h *BindHandle) getOnePendingVerifyJob() (originalSQL, db string, binding B
 	return "", "", Binding{}
 }
 
-func (*BindHandle) getRunningDuration(sctx sessionctx.Context, db, sql string, maxTime time.Duration) (time.Duration, error) {
-	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
-	if db != "" {
-		_, err := sctx.(sqlexec.SQLExecutor).ExecuteInternal(ctx, "use %n", db)
-		if err != nil {
-			return 0, err
-		}
-	}
-	ctx, cancelFunc := context.WithCancel(ctx)
-	timer := time.NewTimer(maxTime)
-	defer timer.Stop()
-	resultChan := make(chan error)
-	startTime := time.Now()
-	go runSQL(ctx, sctx, sql, resultChan)
-	select {
-	case err := <-resultChan:
-		cancelFunc()
-		if err != nil {
-			return 0, err
-		}
-		return time.Since(startTime), nil
-	case <-timer.C:
-		cancelFunc()
-		logutil.BgLogger().Debug("plan verification timed out", zap.String("category", "sql-bind"), zap.Duration("timeElapsed", time.Since(startTime)), zap.String("query", sql))
-	}
-	<-resultChan
-	return -1, nil
-}
-
 func runSQL(ctx context.Context, sctx sessionctx.Context, sql string, resultChan chan<- error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -1211,51 +1210,35 @@ func runSQL(ctx context.Context, sctx sessionctx.Context, sql string, resultChan
 
 // HandleEvolvePlanTask tries to evolve one plan task.
 // It only processes one task at a time because we want each task to use the latest parameters.
+// If there is no pending verify binding, the alternative plans of an automatically created binding
+// are generated as the pending verify bindings when tidb_evolve_plan_baselines is enabled globally.
 func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve bool) error {
 	originalSQL, db, binding := h.getOnePendingVerifyJob()
+	var recordToEvolve *BindRecord
 	if originalSQL == "" {
-		return nil
+		if recordToEvolve = h.getOneRecordToEvolve(); recordToEvolve == nil {
+			return nil
+		}
 	}
-	maxTime, startTime, endTime, err := getEvolveParameters(sctx)
+	params, err := getEvolveParameters(sctx)
 	if err != nil {
 		return err
 	}
-	if maxTime == 0 || (!timeutil.WithinDayTimePeriod(startTime, endTime, time.Now()) && !adminEvolve) {
+	if params.maxTime == 0 || (!timeutil.WithinDayTimePeriod(params.startTime, params.endTime, time.Now()) && !adminEvolve) {
 		return nil
 	}
-	sctx.GetSessionVars().UsePlanBaselines = true
-	currentPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
-	// If we just return the error to the caller, this job will be retried again and again and cause endless logs,
-	// since it is still in the bind record. Now we just drop it and if it is actually retryable,
-	// we will hope for that we can capture this evolve task again.
-	if err != nil {
-		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
-	}
-	// If the accepted plan timeouts, it is hard to decide the timeout for verify plan.
-	// Currently we simply mark the verify plan as `using` if it could run successfully within maxTime.
-	if currentPlanTime > 0 {
-		maxTime = time.Duration(float64(currentPlanTime) * verifyTimeoutFactor)
-	}
-	sctx.GetSessionVars().UsePlanBaselines = false
-	verifyPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
-	if err != nil {
-		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
-	}
-	if verifyPlanTime == -1 || (float64(verifyPlanTime)*acceptFactor > float64(currentPlanTime)) {
-		binding.Status = Rejected
-		digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
-		logutil.BgLogger().Debug("new plan rejected", zap.String("category", "sql-bind"),
-			zap.Duration("currentPlanTime", currentPlanTime),
-			zap.Duration("verifyPlanTime", verifyPlanTime),
-			zap.String("digestText", digestText),
-		)
-	} else {
-		binding.Status = Enabled
+	if recordToEvolve != nil {
+		if !params.enabled {
+			return nil
+		}
+		if err = h.generateEvolveTasks(sctx, recordToEvolve); err != nil {
+			return err
+		}
+		if originalSQL, db, binding = h.getOnePendingVerifyJob(); originalSQL == "" {
+			return nil
+		}
 	}
-	// We don't need to pass the `sctx` because the BindSQL has been validated already.
-	return h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
+	return h.verifyEvolveTask(sctx, originalSQL, db, binding, params.maxTime)
 }
 
 // Clear resets the bind handle. It is only used for test.
@@ -1266,6 +1249,9 @@ func (h *BindHandle) Clear() {
 	h.bindInfo.Unlock()
 	h.invalidBindRecordMap.Store(make(map[string]*bindRecordUpdate))
 	h.pendingVerifyBindRecordMap.Store(make(map[string]*bindRecordUpdate))
+	h.evolvedRecords.Lock()
+	h.evolvedRecords.m = make(map[string]time.Time)
+	h.evolvedRecords.Unlock()
 }
 
 // FlushBindings flushes the BindRecord in temp maps to storage and loads them into cache.
@@ -23,6 +23,7 @@ import (
 	"net/http"
 	"os"
 	"os/exec"
+	"sort"
 	"strings"
 	"time"
 
@@ -46,6 +47,7 @@ var (
 	record           bool
 	create           bool
 	collationDisable bool
+	cascades         bool
 )
 
 func init() {
@@ -55,6 +57,7 @@ func init() {
 	flag.BoolVar(&record, "record", false, "record the test output in the result file")
 	flag.BoolVar(&create, "create", false, "create and import data into table, and save json file of stats")
 	flag.BoolVar(&collationDisable, "collation-disable", false, "run collation related-test with new-collation disabled")
+	flag.BoolVar(&cascades, "cascades", false, "check the query results of the cascades planner are the same as the default planner")
 }
 
 var mdb *sql.DB
@@ -510,36 +513,16 @@ func (t *tester) executeStmt(query string) error {
 		}
 		t.buf.WriteString("\n")
 
-		values := make([][]byte, len(cols))
-		scanArgs := make([]interface{}, len(values))
-		for i := range values {
-			scanArgs[i] = &values[i]
+		results, err := scanRows(rows, len(cols))
+		if err != nil {
+			return errors.Trace(err)
 		}
-
-		for rows.Next() {
-			err = rows.Scan(scanArgs...)
-			if err != nil {
-				return errors.Trace(err)
-			}
-
-			var value string
-			for i, col := range values {
-				// Here we can check if the value is nil (NULL value)
-				if col == nil {
-					value = "NULL"
-				} else {
-					value = string(col)
-				}
-				t.buf.WriteString(value)
-				if i < len(values)-1 {
-					t.buf.WriteString("\t")
-				}
-			}
+		for _, row := range results {
+			t.buf.WriteString(row)
 			t.buf.WriteString("\n")
 		}
-
-		if err = rows.Err(); err != nil {
-			return errors.Trace(err)
+		if cascades && isResultQuery(query) {
+			return errors.Trace(t.checkCascadesResults(query, results))
 		}
 	} else {
 		// TODO: rows affected and last insert id
@@ -551,6 +534,71 @@ func (t *tester) executeStmt(query string) error {
 	return nil
 }
 
+// scanRows reads all the rows, the values of a row are joined by tabs.
+func scanRows(rows *sql.Rows, colCnt int) ([]string, error) {
+	values := make([][]byte, colCnt)
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	var results []string
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return nil, err
+		}
+
+		var row strings.Builder
+		for i, col := range values {
+			// Here we can check if the value is nil (NULL value)
+			if col == nil {
+				row.WriteString("NULL")
+			} else {
+				row.Write(col)
+			}
+			if i < len(values)-1 {
+				row.WriteString("\t")
+			}
+		}
+		results = append(results, row.String())
+	}
+	return results, rows.Err()
+}
+
+// checkCascadesResults runs the query by the cascades planner in the same transaction,
+// and checks the results are the same as the ones of the default planner.
+func (t *tester) checkCascadesResults(query string, expected []string) (err error) {
+	if _, err = t.tx.Exec("set @@tidb_enable_cascades_planner = 1"); err != nil {
+		return err
+	}
+	defer func() {
+		if _, err1 := t.tx.Exec("set @@tidb_enable_cascades_planner = 0"); err == nil {
+			err = err1
+		}
+	}()
+	rows, err := t.tx.Query(query)
+	if err != nil {
+		return errors.Errorf("run \"%v\" by the cascades planner err %v", query, err)
+	}
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	results, err := scanRows(rows, len(cols))
+	if err != nil {
+		return err
+	}
+	expected = append([]string(nil), expected...)
+	sort.Strings(expected)
+	sort.Strings(results)
+	if strings.Join(expected, "\n") != strings.Join(results, "\n") {
+		//nolint: all_revive,revive
+		return errors.Errorf("run \"%v\" by the cascades planner err, we need:\n%s\nbut got:\n%s\n",
+			query, strings.Join(expected, "\n"), strings.Join(results, "\n"))
+	}
+	return nil
+}
+
 func (t *tester) openResult() error {
 	if record || create {
 		return nil
@@ -793,6 +841,23 @@ func trimSQL(sql string) string {
 	return strings.TrimLeft(sql, "( ")
 }
 
+var resultQueryStmtTable = []string{"select", "with"}
+
+// isResultQuery checks if a sql statement is a query whose results can be compared between the planners.
+// The rows of the queries with LIMIT but without ORDER BY are nondeterministic, so they are skipped.
+func isResultQuery(sql string) bool {
+	sqlText := strings.ToLower(trimSQL(sql))
+	if strings.Contains(sqlText, "limit") && !strings.Contains(sqlText, "order by") {
+		return false
+	}
+	for _, key := range resultQueryStmtTable {
+		if strings.HasPrefix(sqlText, key) {
+			return true
+		}
+	}
+	return false
+}
+
 // isQuery checks if a sql statement is a query statement.
 func isQuery(sql string) bool {
 	sqlText := strings.ToLower(trimSQL(sql))
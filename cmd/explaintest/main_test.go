@@ -49,3 +49,22 @@ func TestTrimSQL(t *testing.T) {
 		}
 	}
 }
+
+func TestIsResultQuery(t *testing.T) {
+	tbl := []struct {
+		sql string
+		ok  bool
+	}{
+		{"/*comment*/ select 1;", true},
+		{"with cte as (select 1) select * from cte;", true},
+		{"explain select 1;", false},
+		{"show tables;", false},
+		{"select * from t limit 1;", false},
+		{"select * from t order by a limit 1;", true},
+	}
+	for _, tb := range tbl {
+		if isResultQuery(tb.sql) != tb.ok {
+			t.Fatalf("%s", tb.sql)
+		}
+	}
+}
@@ -29,6 +29,7 @@ create=0
 create_case=""
 stats="s"
 collation_opt=2
+cascades="false"
 
 set -eu
 trap 'set +e; PIDS=$(jobs -p); [ -n "$PIDS" ] && kill -9 $PIDS' EXIT
@@ -69,6 +70,9 @@ function help_message()
 
     -p <portgenerator-path>: Use port generator in <portgenerator-path> for generating port numbers.
 
+    -e <y|Y|n|N>: \"y\" or \"Y\" for checking the query results of the cascades planner are the same as the default planner.
+                  \"n\" or \"N\" for not to check [default].
+
 "
 }
 
@@ -115,7 +119,7 @@ function extract_stats()
     unzip -qq s.zip
 }
 
-while getopts "t:s:r:b:d:c:i:h:p" opt; do
+while getopts "t:s:r:b:d:c:i:e:h:p" opt; do
     case $opt in
         t)
             tests="$OPTARG"
@@ -136,7 +140,7 @@ while getopts "t:s:r:b:d:c:i:h:p" opt; do
                     build=0
                     ;;
                 *)
-                    help_messge 1>&2
+                    help_message 1>&2
                     exit 1
                     ;;
             esac
@@ -150,7 +154,7 @@ while getopts "t:s:r:b:d:c:i:h:p" opt; do
                     collation_opt=0
                     ;;
                 *)
-                    help_messge 1>&2
+                    help_message 1>&2
                     exit 1
                     ;;
             esac
@@ -169,6 +173,20 @@ while getopts "t:s:r:b:d:c:i:h:p" opt; do
         p)  
             portgenerator="$OPTARG"
             ;;
+        e)
+            case $OPTARG in
+                y|Y)
+                    cascades="true"
+                    ;;
+                n|N)
+                    cascades="false"
+                    ;;
+                *)
+                    help_message 1>&2
+                    exit 1
+                    ;;
+            esac
+            ;;
         *)
             help_message 1>&2
             exit 1
@@ -287,7 +305,7 @@ function run_explain_test()
       else
           echo "run explain test cases($coll_msg): $tests"
       fi
-      $explain_test -port "$port" -status "$status" --collation-disable=$coll_disabled --log-level=error $tests
+      $explain_test -port "$port" -status "$status" --collation-disable=$coll_disabled --cascades=$cascades --log-level=error $tests
     fi
 }
 
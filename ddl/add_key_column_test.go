@@ -0,0 +1,134 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/ddl/util/callback"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/testkit/external"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddKeyColumn(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec(`insert into t values (1, "1"), (2, "2"), (3, "3")`)
+
+	// The existing rows get the auto-increment values.
+	tk.MustExec("alter table t add column id int auto_increment primary key first")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select count(distinct id), min(id) > 0 from t").Check(testkit.Rows("3 1"))
+	tk.MustExec(`insert into t (a, b) values (4, "4")`)
+	tk.MustQuery("select count(distinct id), min(id) > 0 from t").Check(testkit.Rows("4 1"))
+	tblInfo := external.GetTableByName(t, tk, "test", "t").Meta()
+	require.Equal(t, "id", tblInfo.Columns[0].Name.L)
+	require.True(t, mysql.HasPriKeyFlag(tblInfo.Columns[0].GetFlag()))
+	require.True(t, mysql.HasAutoIncrementFlag(tblInfo.Columns[0].GetFlag()))
+	require.NotNil(t, tblInfo.GetPrimaryKey())
+	tk.MustGetErrCode("alter table t add column c int primary key", errno.ErrMultiplePriKey)
+	tk.MustGetErrCode("alter table t add column c int auto_increment unique", errno.ErrWrongAutoKey)
+
+	// A duplicate value rolls the job back.
+	tk.MustGetErrCode("alter table t add column c int default 1 unique", errno.ErrDupEntry)
+	tblInfo = external.GetTableByName(t, tk, "test", "t").Meta()
+	require.Nil(t, model.FindColumnInfo(tblInfo.Columns, "c"))
+	require.Len(t, tblInfo.Indices, 1)
+	tk.MustExec("admin check table t")
+
+	// NULL values don't conflict.
+	tk.MustExec("alter table t add column c int unique")
+	tk.MustExec("update t set c = a")
+	tk.MustGetErrCode("insert into t (a, c) values (5, 1)", errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+	tblInfo = external.GetTableByName(t, tk, "test", "t").Meta()
+	require.Len(t, tblInfo.Indices, 2)
+	require.True(t, tblInfo.Indices[1].Unique)
+	require.True(t, mysql.HasUniKeyFlag(tblInfo.Columns[3].GetFlag()))
+
+	// Unsupported cases.
+	tk.MustExec("create table t1 (a int)")
+	tk.MustGetErrCode("alter table t1 add column id int auto_increment", errno.ErrWrongAutoKey)
+	tk.MustGetErrCode("alter table t1 add column id varchar(10) auto_increment key", errno.ErrWrongFieldSpec)
+	tk.MustGetErrCode("alter table t1 add column id int auto_increment default 1 key", errno.ErrInvalidDefault)
+	tk.MustGetErrCode("alter table t1 add column id int key, add column id2 int", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t1 add column id int primary key clustered", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table tp (a int) partition by hash (a) partitions 2")
+	tk.MustGetErrCode("alter table tp add column id int unique", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestAddKeyColumnConcurrentDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "AddKeyColumnConcurrentDML"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	tk.MustExec("create table t (a int, b int, key (b))")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4)")
+
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &callback.TestDDLCallback{Do: dom}
+	dom.DDL().SetHook(hook)
+
+	wait := make(chan bool)
+	defer close(wait)
+	states := []model.SchemaState{model.StateWriteOnly, model.StateWriteReorganization}
+	prevState := model.StateNone
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionAddColumn || job.SchemaState == prevState {
+			return
+		}
+		for _, state := range states {
+			if job.SchemaState == state {
+				prevState = job.SchemaState
+				<-wait
+				<-wait
+			}
+		}
+	}
+	alterErr := make(chan error, 1)
+	go backgroundExec(store, schemaName, "alter table t add column id bigint auto_increment unique", alterErr)
+
+	// write only
+	wait <- true
+	tk.MustExec("insert into t values (5, 5)")
+	tk.MustExec("update t set b = 12 where a = 2")
+	tk.MustExec("delete from t where a = 3")
+	tk.MustExec("admin check table t")
+	wait <- true
+	// write reorganization, before the values are filled
+	wait <- true
+	tk.MustExec("insert into t values (6, 6)")
+	tk.MustExec("update t set b = 44 where a = 4")
+	tk.MustExec("admin check table t")
+	wait <- true
+	require.NoError(t, <-alterErr)
+
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a, b from t order by a").Check(testkit.Rows("1 1", "2 12", "4 44", "5 5", "6 6"))
+	tk.MustQuery("select count(distinct id), min(id) > 0 from t").Check(testkit.Rows("5 1"))
+	tk.MustQuery("select count(*) from t use index (id) where id > 0").Check(testkit.Rows("5"))
+	tk.MustExec("insert into t (a, b) values (7, 7)")
+	tk.MustQuery("select count(distinct id) from t").Check(testkit.Rows("6"))
+}
@@ -57,6 +57,8 @@ const (
 	typeCleanUpIndexWorker     backfillerType = 2
 	typeAddIndexMergeTmpWorker backfillerType = 3
 	typeReorgPartitionWorker   backfillerType = 4
+	typeRebuildTableWorker     backfillerType = 5
+	typeAddColumnWorker        backfillerType = 6
 )
 
 func (bT backfillerType) String() string {
@@ -71,6 +73,10 @@ func (bT backfillerType) String() string {
 		return "merge temporary index"
 	case typeReorgPartitionWorker:
 		return "reorganize partition"
+	case typeRebuildTableWorker:
+		return "rebuild table"
+	case typeAddColumnWorker:
+		return "add column"
 	default:
 		return "unknown"
 	}
@@ -81,6 +87,8 @@ func (bT backfillerType) String() string {
 // 2: modify-column-type
 // 3: clean-up global index
 // 4: reorganize partition
+// 5: rebuild table
+// 6: add auto-increment column
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
@@ -139,6 +147,8 @@ type backfillTaskContext struct {
 	warnings      map[errors.ErrorID]*terror.Error
 	warningsCount map[errors.ErrorID]int64
 	finishTS      uint64
+	// writtenBytes is the size of the data written by the batch, which is limited by the max write speed of the job.
+	writtenBytes int
 }
 
 type backfillCtx struct {
@@ -294,12 +304,23 @@ func (w *backfillWorker) handleBackfillTask(d *ddlCtx, task *reorgBackfillTask,
 			result.err = err
 			return result
 		}
+		// The job may be only allowed to run in a time window.
+		err = d.waitRunWindow(jobID)
+		if err != nil {
+			result.err = err
+			return result
+		}
 
 		taskCtx, err := bf.BackfillData(handleRange)
 		if err != nil {
 			result.err = err
 			return result
 		}
+		err = rc.waitWriteQuota(w.ctx, taskCtx.writtenBytes)
+		if err != nil {
+			result.err = err
+			return result
+		}
 
 		bf.AddMetricInfo(float64(taskCtx.addedCount))
 		mergeBackfillCtxToResult(&taskCtx, result)
@@ -380,7 +401,7 @@ func (w *backfillWorker) run(d *ddlCtx, bf backfiller, job *model.Job) {
 		})
 
 		// Change the batch size dynamically.
-		w.GetCtx().batchCnt = int(variable.GetDDLReorgBatchSize())
+		w.GetCtx().batchCnt = d.getReorgCtx(job.ID).getBatchSize()
 		result := w.handleBackfillTask(d, task, bf)
 		w.resultCh <- result
 		if result.err != nil {
@@ -163,8 +163,8 @@ func initSessCtx(sessCtx sessionctx.Context, sqlMode mysql.SQLMode, tzLocation *
 	return nil
 }
 
-func (*txnBackfillScheduler) expectedWorkerSize() (size int) {
-	workerCnt := int(variable.GetDDLReorgWorkerCounter())
+func (b *txnBackfillScheduler) expectedWorkerSize() (size int) {
+	workerCnt := b.reorgInfo.d.getReorgCtx(b.reorgInfo.Job.ID).getWorkerCount()
 	return mathutil.Min(workerCnt, maxBackfillWorkerSize)
 }
 
@@ -222,6 +222,14 @@ func (b *txnBackfillScheduler) adjustWorkerSize() error {
 			}
 			runner = newBackfillWorker(jc.ddlJobCtx, partWorker)
 			worker = partWorker
+		case typeRebuildTableWorker:
+			rebuildWorker := newRebuildTableWorker(sessCtx, i, b.tbl, b.decodeColMap, reorgInfo, jc)
+			runner = newBackfillWorker(jc.ddlJobCtx, rebuildWorker)
+			worker = rebuildWorker
+		case typeAddColumnWorker:
+			addColWorker := newAddColumnWorker(sessCtx, i, b.tbl, b.decodeColMap, reorgInfo, jc)
+			runner = newBackfillWorker(jc.ddlJobCtx, addColWorker)
+			worker = addColWorker
 		default:
 			return errors.New("unknown backfill type")
 		}
@@ -439,8 +447,8 @@ func (b *ingestBackfillScheduler) createCopReqSenderPool() (*copReqSenderPool, e
 	return newCopReqSenderPool(b.ctx, copCtx, sessCtx.GetStore(), b.taskCh, b.sessPool, b.checkpointMgr), nil
 }
 
-func (*ingestBackfillScheduler) expectedWorkerSize() (readerSize int, writerSize int) {
-	workerCnt := int(variable.GetDDLReorgWorkerCounter())
+func (b *ingestBackfillScheduler) expectedWorkerSize() (readerSize int, writerSize int) {
+	workerCnt := b.reorgInfo.d.getReorgCtx(b.reorgInfo.Job.ID).getWorkerCount()
 	readerSize = mathutil.Min(workerCnt/2, maxBackfillWorkerSize)
 	readerSize = mathutil.Max(readerSize, 1)
 	writerSize = mathutil.Min(workerCnt/2+2, maxBackfillWorkerSize)
@@ -464,13 +472,20 @@ func (w *addIndexIngestWorker) HandleTask(rs idxRecResult) {
 	}
 	if !w.distribute {
 		err := w.d.isReorgRunnable(w.jobID, false)
+		if err == nil {
+			// The job may be only allowed to run in a time window.
+			err = w.d.waitRunWindow(w.jobID)
+		}
 		if err != nil {
 			result.err = err
 			w.resultCh <- result
 			return
 		}
 	}
-	count, nextKey, err := w.WriteLocal(&rs)
+	count, nextKey, writtenBytes, err := w.WriteLocal(&rs)
+	if err == nil && !w.distribute {
+		err = w.d.getReorgCtx(w.jobID).waitWriteQuota(w.ctx, writtenBytes)
+	}
 	if err != nil {
 		result.err = err
 		w.resultCh <- result
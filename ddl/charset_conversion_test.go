@@ -0,0 +1,66 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestConvertCharsetWithData(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// The legacy latin1 bytes are decoded as cp1252.
+	tk.MustExec("create table t (id int primary key, a varchar(10), key i(a)) charset latin1")
+	tk.MustExec("insert into t values (1, 0xe9), (2, 0x80), (3, 'abc')")
+	tk.MustExec("alter table t convert to charset utf8mb4")
+	tk.MustQuery("select id, hex(a) from t order by id").Check(testkit.Rows("1 C3A9", "2 E282AC", "3 616263"))
+	tk.MustQuery("select id from t use index(i) where a = 'é'").Check(testkit.Rows("1"))
+	tk.MustExec("admin check table t")
+
+	// The values which can't be represented in the new charset fail the conversion.
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a varchar(10), b varchar(10), key i(a)) charset utf8mb4")
+	tk.MustExec("insert into t values ('中文', 'a'), ('😀', 'b')")
+	tk.MustGetErrCode("alter table t convert to charset gbk", errno.ErrTruncatedWrongValueForField)
+	tk.MustGetErrCode("alter table t modify column a varchar(10) charset gbk", errno.ErrTruncatedWrongValueForField)
+	tk.MustQuery("select a from t use index(i) order by a").Check(testkit.Rows("中文", "😀"))
+	tk.MustExec("admin check table t")
+
+	// The dry run only reports the rows which can't be converted.
+	tk.MustExec("set @@tidb_ddl_charset_conversion_dry_run = 1")
+	tk.MustExec("alter table t convert to charset gbk")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1366 Incorrect string value '\\xF0\\x9F\\x98\\x80' for column 'a' at row 2"))
+	tk.MustExec("alter table t modify column a varchar(10) charset gbk")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1366 Incorrect string value '\\xF0\\x9F\\x98\\x80' for column 'a' at row 2"))
+	tk.MustGetErrCode("alter table t modify column a varchar(10) charset gbk, modify column b varchar(10) charset gbk", errno.ErrUnsupportedDDLOperation)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` varchar(10) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL,\n" +
+		"  KEY `i` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("set @@tidb_ddl_charset_conversion_dry_run = 0")
+
+	tk.MustExec("delete from t where b = 'b'")
+	tk.MustExec("alter table t convert to charset gbk")
+	tk.MustQuery("select a from t use index(i)").Check(testkit.Rows("中文"))
+	tk.MustExec("admin check table t")
+}
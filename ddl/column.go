@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
@@ -47,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/logutil"
 	decoder "github.com/pingcap/tidb/util/rowDecoder"
@@ -104,7 +106,7 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 	return tblInfo, columnInfo, col, pos, false, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(d, t, job)
@@ -130,6 +132,9 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		}
 		return ver, errors.Trace(err)
 	}
+	if isKeyColumn(colFromArgs) {
+		return w.onAddKeyColumn(d, t, job, tblInfo, columnInfo, colFromArgs, pos)
+	}
 	if columnInfo == nil {
 		columnInfo = InitAndAddColumnToTable(tblInfo, colFromArgs)
 		logutil.BgLogger().Info("run add column job", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Reflect("columnInfo", *columnInfo))
@@ -268,6 +273,7 @@ func onDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error)
 		colInfo.State = model.StateDeleteOnly
 		tblInfo.MoveColumnInfo(colInfo.Offset, len(tblInfo.Columns)-1)
 		if len(idxInfos) > 0 {
+			replaceRewrittenIndices(tblInfo, idxInfos, colInfo.Name.L)
 			newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 			for _, idx := range tblInfo.Indices {
 				if !indexInfoContains(idx.ID, idxInfos) {
@@ -341,6 +347,10 @@ func checkDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (*model.TableInfo,
 		job.State = model.JobStateCancelled
 		return nil, nil, nil, false, errors.Trace(err)
 	}
+	if err = isColumnCanDropWithIndex(colName.L, tblInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return nil, nil, nil, false, errors.Trace(err)
+	}
 	if err = checkDropColumnWithForeignKeyConstraintInOwner(d, t, job, tblInfo, colName.L); err != nil {
 		return nil, nil, nil, false, errors.Trace(err)
 	}
@@ -348,6 +358,8 @@ func checkDropColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (*model.TableInfo,
 		return nil, nil, nil, false, errors.Trace(err)
 	}
 	idxInfos := listIndicesWithColumn(colName.L, tblInfo.Indices)
+	// The rewritten indexes replace the composite indexes covering the column.
+	idxInfos = append(idxInfos, listIndicesToRewrite(colName.L, tblInfo.Indices)...)
 	return tblInfo, colInfo, idxInfos, false, nil
 }
 
@@ -362,7 +374,10 @@ func onSetDefaultValue(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ er
 	return updateColumnDefaultValue(d, t, job, newCol, &newCol.Name)
 }
 
-func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
+func needChangeColumnData(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	if needTranscodeColumnData(tblInfo, oldCol, newCol) {
+		return true
+	}
 	toUnsigned := mysql.HasUnsignedFlag(newCol.GetFlag())
 	originUnsigned := mysql.HasUnsignedFlag(oldCol.GetFlag())
 	needTruncationOrToggleSign := func() bool {
@@ -419,6 +434,43 @@ func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
 	return true
 }
 
+// canTranscodeCharset returns whether the column type change can convert the values between the charsets.
+func canTranscodeCharset(from, to string) bool {
+	isTranscodable := func(chs string) bool {
+		switch chs {
+		case charset.CharsetUTF8, charset.CharsetUTF8MB4, charset.CharsetLatin1, charset.CharsetGBK, charset.CharsetASCII:
+			return true
+		}
+		return false
+	}
+	return isTranscodable(from) && isTranscodable(to)
+}
+
+// needTranscodeColumnData returns whether changing the charset or the collation of a string column
+// needs to reorg the data. The values are transcoded to the new charset, and the indexes are rebuilt
+// with the new collation.
+func needTranscodeColumnData(tblInfo *model.TableInfo, oldCol, newCol *model.ColumnInfo) bool {
+	if !types.IsNonBinaryStr(&oldCol.FieldType) || !types.IsNonBinaryStr(&newCol.FieldType) ||
+		!canTranscodeCharset(oldCol.GetCharset(), newCol.GetCharset()) {
+		return false
+	}
+	from, to := oldCol.GetCharset(), newCol.GetCharset()
+	switch {
+	case from == to || from == charset.CharsetASCII:
+	case from == charset.CharsetLatin1:
+		// The latin1 values may be written by latin1 clients, which need to be decoded.
+		// The primary key column can't be reorged, its values are kept as they are like before.
+		if !mysql.HasPriKeyFlag(oldCol.GetFlag()) || to != charset.CharsetUTF8MB4 {
+			return true
+		}
+	case to == charset.CharsetGBK || to == charset.CharsetASCII || (from == charset.CharsetUTF8MB4 && to == charset.CharsetUTF8):
+		// The values need to be checked whether they can be represented in the new charset.
+		return true
+	}
+	return collate.NewCollationEnabled() && !collate.CompatibleCollate(oldCol.GetCollate(), newCol.GetCollate()) &&
+		isColumnWithIndex(oldCol.Name.L, tblInfo.Indices)
+}
+
 // TODO: it is used for plugins. so change plugin's using and remove it.
 func convertBetweenCharAndVarchar(oldCol, newCol byte) bool {
 	return types.ConvertBetweenCharAndVarchar(oldCol, newCol)
@@ -516,7 +568,7 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 
 	if job.IsRollingback() {
 		// For those column-type-change jobs which don't reorg the data.
-		if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+		if !needChangeColumnData(tblInfo, oldCol, modifyInfo.newCol) {
 			return rollbackModifyColumnJob(d, t, tblInfo, job, modifyInfo.newCol, oldCol, modifyInfo.modifyColumnTp)
 		}
 		// For those column-type-change jobs which reorg the data.
@@ -547,7 +599,7 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 		return ver, errors.Trace(err)
 	}
 
-	if !needChangeColumnData(oldCol, modifyInfo.newCol) {
+	if !needChangeColumnData(tblInfo, oldCol, modifyInfo.newCol) {
 		return w.doModifyColumn(d, t, job, dbInfo, tblInfo, modifyInfo.newCol, oldCol, modifyInfo.pos)
 	}
 
@@ -1069,7 +1121,9 @@ func (w *worker) updatePhysicalTableRow(t table.Table, reorgInfo *reorgInfo) err
 				return dbterror.ErrCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
 			}
 			workType := typeReorgPartitionWorker
-			if reorgInfo.Job.Type != model.ActionReorganizePartition {
+			if reorgInfo.Job.Type != model.ActionReorganizePartition &&
+				reorgInfo.Job.Type != model.ActionAlterTablePartitioning &&
+				reorgInfo.Job.Type != model.ActionRemovePartitioning {
 				// workType = typeUpdateColumnWorker
 				// TODO: Support Modify Column on partitioned table
 				// https://github.com/pingcap/tidb/issues/38297
@@ -1496,6 +1550,7 @@ func (w *updateColumnWorker) BackfillData(handleRange reorgBackfillTask) (taskCt
 
 		// Collect the warnings.
 		taskCtx.warnings, taskCtx.warningsCount = warningsMap, warningsCountMap
+		taskCtx.writtenBytes = txn.Size()
 
 		return nil
 	})
@@ -1816,19 +1871,94 @@ func isColumnWithIndex(colName string, indices []*model.IndexInfo) bool {
 	return false
 }
 
-func isColumnCanDropWithIndex(colName string, indices []*model.IndexInfo) error {
+// isColumnCanDropWithIndex checks the indices covering the column can be dropped together with it.
+// A composite index or primary key is rewritten without the column before the column is dropped,
+// see `rewriteIndicesForDropColumn`. A clustered primary key can't be rewritten.
+func isColumnCanDropWithIndex(colName string, tblInfo *model.TableInfo) error {
+	if isColumnCoveredByClusteredIndex(colName, tblInfo) {
+		return dbterror.ErrCantDropColWithClusteredPK.GenWithStackByArgs(colName)
+	}
+	for _, indexInfo := range listIndicesToRewrite(colName, tblInfo.Indices) {
+		if findRewrittenIndex(colName, tblInfo, indexInfo) == nil {
+			return dbterror.ErrCantDropColWithIndex.GenWithStack("can't drop column %s with composite index covered or Primary Key covered now", colName)
+		}
+	}
+	return nil
+}
+
+func isColumnCoveredByClusteredIndex(colName string, tblInfo *model.TableInfo) bool {
+	if !tblInfo.IsCommonHandle {
+		return false
+	}
+	i, _ := model.FindIndexColumnByName(tblInfo.GetPrimaryKey().Columns, colName)
+	return i != -1
+}
+
+// listIndicesToRewrite lists the composite indices covering the column, they are rewritten without the column.
+func listIndicesToRewrite(colName string, indices []*model.IndexInfo) []*model.IndexInfo {
+	ret := make([]*model.IndexInfo, 0)
 	for _, indexInfo := range indices {
-		if indexInfo.Primary || len(indexInfo.Columns) > 1 {
-			for _, col := range indexInfo.Columns {
-				if col.Name.L == colName {
-					return dbterror.ErrCantDropColWithIndex.GenWithStack("can't drop column %s with composite index covered or Primary Key covered now", colName)
-				}
+		if len(indexInfo.Columns) <= 1 || strings.HasPrefix(indexInfo.Name.O, changingIndexPrefix) {
+			continue
+		}
+		if i, _ := model.FindIndexColumnByName(indexInfo.Columns, colName); i != -1 {
+			ret = append(ret, indexInfo)
+		}
+	}
+	return ret
+}
+
+// findRewrittenIndex finds the index that replaces indexInfo after the column is dropped.
+func findRewrittenIndex(colName string, tblInfo *model.TableInfo, indexInfo *model.IndexInfo) *model.IndexInfo {
+	for _, idx := range tblInfo.Indices {
+		if !strings.HasPrefix(idx.Name.O, changingIndexPrefix) || !strings.EqualFold(getChangingIndexOriginName(idx), indexInfo.Name.O) ||
+			len(idx.Columns) != len(indexInfo.Columns)-1 {
+			continue
+		}
+		remains := make([]*model.IndexColumn, 0, len(idx.Columns))
+		for _, col := range indexInfo.Columns {
+			if col.Name.L != colName {
+				remains = append(remains, col)
+			}
+		}
+		matched := true
+		for i, col := range idx.Columns {
+			if col.Name.L != remains[i].Name.L || col.Length != remains[i].Length {
+				matched = false
+				break
 			}
 		}
+		if matched {
+			return idx
+		}
 	}
 	return nil
 }
 
+// replaceRewrittenIndices makes the rewritten indices take the names and the positions of the dropping ones.
+func replaceRewrittenIndices(tblInfo *model.TableInfo, droppingIdxInfos []*model.IndexInfo, colName string) {
+	for _, indexInfo := range droppingIdxInfos {
+		newIdxInfo := findRewrittenIndex(colName, tblInfo, indexInfo)
+		if newIdxInfo == nil {
+			continue
+		}
+		DropIndexColumnFlag(tblInfo, indexInfo)
+		var oldOffset, newOffset int
+		for i, idx := range tblInfo.Indices {
+			switch idx.ID {
+			case indexInfo.ID:
+				oldOffset = i
+			case newIdxInfo.ID:
+				newOffset = i
+			}
+		}
+		tblInfo.Indices[oldOffset], tblInfo.Indices[newOffset] = newIdxInfo, indexInfo
+		newIdxInfo.Name = indexInfo.Name
+		newIdxInfo.Primary = indexInfo.Primary
+		AddIndexColumnFlag(tblInfo, newIdxInfo)
+	}
+}
+
 func listIndicesWithColumn(colName string, indices []*model.IndexInfo) []*model.IndexInfo {
 	ret := make([]*model.IndexInfo, 0)
 	for _, indexInfo := range indices {
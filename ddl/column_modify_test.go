@@ -186,14 +186,13 @@ AddLoop:
 	tk.MustExec("alter table test_on_update_e add column c2 year not null;")
 	tk.MustQuery("select c2 from test_on_update_e").Check(testkit.Rows("0"))
 
-	// test add unsupported constraint
-	tk.MustExec("create table t_add_unsupported_constraint (a int);")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int AUTO_INCREMENT;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint AUTO_INCREMENT when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int KEY;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint PRIMARY KEY when altering 'test.t_add_unsupported_constraint'")
-	err = tk.ExecToErr("ALTER TABLE t_add_unsupported_constraint ADD id int UNIQUE;")
-	require.EqualError(t, err, "[ddl:8200]unsupported add column 'id' constraint UNIQUE KEY when altering 'test.t_add_unsupported_constraint'")
+	// test add column with constraint
+	tk.MustExec("create table t_add_constraint (a int);")
+	tk.MustGetErrCode("ALTER TABLE t_add_constraint ADD id int AUTO_INCREMENT;", errno.ErrWrongAutoKey)
+	tk.MustExec("ALTER TABLE t_add_constraint ADD id int KEY;")
+	tk.MustExec("ALTER TABLE t_add_constraint ADD id2 int UNIQUE;")
+	tk.MustGetErrCode("ALTER TABLE t_add_constraint ADD id3 int KEY;", errno.ErrMultiplePriKey)
+	tk.MustQuery("select count(*) from information_schema.tidb_indexes where table_name = 't_add_constraint'").Check(testkit.Rows("2"))
 
 	// ===========
 	// DROP COLUMN
@@ -797,14 +797,17 @@ func TestChangingTableCharset(t *testing.T) {
 
 	tk.MustExec("drop table if exists t")
 	tk.MustExec("create table t(a varchar(20), key i(a)) charset=latin1")
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_unicode_ci", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_general_ci", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t convert to charset utf8 collate utf8_bin", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("insert into t values ('a'), ('B')")
+	tk.MustExec("alter table t convert to charset utf8 collate utf8_unicode_ci")
+	checkCharset(charset.CharsetUTF8, "utf8_unicode_ci")
+	tk.MustQuery("select a from t use index(i) where a = 'b'").Check(testkit.Rows("B"))
+	tk.MustExec("admin check table t")
 
-	// Test when column charset can not convert to the target charset.
+	// Test when column charset is ascii.
 	tk.MustExec("drop table t;")
 	tk.MustExec("create table t(a varchar(10) character set ascii) charset utf8mb4")
-	tk.MustGetErrCode("alter table t convert to charset utf8mb4;", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t convert to charset utf8mb4;")
+	checkCharset(charset.CharsetUTF8MB4, charset.CollationUTF8MB4)
 
 	tk.MustExec("drop table t;")
 	tk.MustExec("create table t(a varchar(10) character set utf8) charset utf8")
@@ -1872,21 +1875,44 @@ func TestAlterTableAddUniqueOnPartionRangeColumn(t *testing.T) {
 	tk.MustGetErrCode("alter table t add unique index idx_b(b)", errno.ErrUniqueKeyNeedAllFieldsInPf)
 }
 
-func TestFulltextIndexIgnore(t *testing.T) {
+func TestFulltextIndex(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test")
 	tk.MustExec("drop table if exists t_ft")
 	defer tk.MustExec("drop table if exists t_ft")
-	// Make sure that creating and altering to add a fulltext key gives the correct warning
-	assertWarningExec(tk, t, "create table t_ft (a text, fulltext key (a))", dbterror.ErrTableCantHandleFt)
-	assertWarningExec(tk, t, "alter table t_ft add fulltext key (a)", dbterror.ErrTableCantHandleFt)
-
-	// Make sure table t_ft still has no indexes even after it was created and altered
-	r := tk.MustQuery("show index from t_ft")
-	require.Len(t, r.Rows(), 0)
-	r = tk.MustQuery("select * from information_schema.statistics where table_schema='test' and table_name='t_ft'")
-	require.Len(t, r.Rows(), 0)
+	tk.MustExec("create table t_ft (id int primary key, a text, b varchar(255), c blob, d varchar(255) collate utf8mb4_general_ci, fulltext key (a))")
+	tk.MustExec("insert into t_ft values (1, 'The quick brown fox', 'jumps over the lazy dog', null, null)")
+	tk.MustExec("alter table t_ft add fulltext index ft_ab(a, b) with parser ngram")
+	tk.MustQuery("show create table t_ft").Check(testkit.Rows("t_ft CREATE TABLE `t_ft` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` text DEFAULT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  `c` blob DEFAULT NULL,\n" +
+		"  `d` varchar(255) COLLATE utf8mb4_general_ci DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `a` (`a`),\n" +
+		"  FULLTEXT KEY `ft_ab` (`a`,`b`) /*!50100 WITH PARSER `ngram` */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select key_name, index_type from information_schema.statistics where table_schema='test' and table_name='t_ft' and key_name != 'PRIMARY'").
+		Sort().Check(testkit.Rows("a FULLTEXT", "ft_ab FULLTEXT"))
+	tk.MustExec("insert into t_ft values (2, 'Distributed SQL database', null, null, null)")
+	tk.MustExec("update t_ft set a = 'The slow brown fox' where id = 1")
+	tk.MustExec("admin check table t_ft")
+
+	tk.MustGetErrCode("alter table t_ft add fulltext index (c)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (id)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (a, d)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (a(10))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("alter table t_ft add fulltext index ((lower(b)))", errno.ErrFulltextFunctionalIndex)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (e)", errno.ErrKeyColumnDoesNotExits)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (b) with parser mecab", errno.ErrPluginIsNotLoaded)
+	tk.MustGetErrCode("alter table t_ft drop column b", errno.ErrDependentByFunctionalIndex)
+
+	tk.MustExec("alter table t_ft drop index ft_ab")
+	tk.MustExec("alter table t_ft drop column b")
+	tk.MustQuery("select key_name from information_schema.statistics where table_schema='test' and table_name='t_ft' and key_name != 'PRIMARY'").
+		Check(testkit.Rows("a"))
 }
 
 func TestTreatOldVersionUTF8AsUTF8MB4(t *testing.T) {
@@ -2842,12 +2868,53 @@ func TestDropColumnWithCompositeIndex(t *testing.T) {
 	defer tk.MustExec("drop table if exists t_drop_column_with_comp_idx")
 	tk.MustExec("create index idx_bc on t_drop_column_with_comp_idx(b, c)")
 	tk.MustExec("create index idx_b on t_drop_column_with_comp_idx(b)")
-	tk.MustGetErrMsg("alter table t_drop_column_with_comp_idx drop column b", "[ddl:8200]can't drop column b with composite index covered or Primary Key covered now")
-	tk.MustQuery(query).Check(testkit.Rows("idx_b YES", "idx_bc YES"))
+	tk.MustExec("create index idx_abc on t_drop_column_with_comp_idx(a, b, c)")
 	tk.MustExec("alter table t_drop_column_with_comp_idx alter index idx_bc invisible")
-	tk.MustExec("alter table t_drop_column_with_comp_idx alter index idx_b invisible")
-	tk.MustGetErrMsg("alter table t_drop_column_with_comp_idx drop column b", "[ddl:8200]can't drop column b with composite index covered or Primary Key covered now")
-	tk.MustQuery(query).Check(testkit.Rows("idx_b NO", "idx_bc NO"))
+	tk.MustQuery(query).Check(testkit.Rows("idx_abc YES", "idx_b YES", "idx_bc NO"))
+	tk.MustExec("insert into t_drop_column_with_comp_idx values (1, 1, 1), (2, 2, 2), (3, 3, 3)")
+	// The composite indexes are rewritten without the column.
+	tk.MustExec("alter table t_drop_column_with_comp_idx drop column b")
+	tk.MustQuery(query).Check(testkit.Rows("idx_abc YES", "idx_bc NO"))
+	tk.MustExec("admin check table t_drop_column_with_comp_idx")
+	tk.MustQuery("select a from t_drop_column_with_comp_idx use index (idx_abc) where a > 1 and c < 3").Check(testkit.Rows("2"))
+	tk.MustQuery("show create table t_drop_column_with_comp_idx").Check(testkit.Rows("" +
+		"t_drop_column_with_comp_idx CREATE TABLE `t_drop_column_with_comp_idx` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `idx_bc` (`c`) /*!80000 INVISIBLE */,\n" +
+		"  KEY `idx_abc` (`a`,`c`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// A duplicate value of the rewritten unique index rolls the job back.
+	tk.MustExec("create table t_drop_column_with_uk(a int, b int, unique key uk(a, b))")
+	tk.MustExec("insert into t_drop_column_with_uk values (1, 1), (1, 2)")
+	tk.MustGetErrCode("alter table t_drop_column_with_uk drop column b", errno.ErrDupEntry)
+	tk.MustQuery(queryIndexOnTable("drop_composite_index_test", "t_drop_column_with_uk")).Check(testkit.Rows("uk YES"))
+	tk.MustQuery("select * from t_drop_column_with_uk order by b").Check(testkit.Rows("1 1", "1 2"))
+	tk.MustExec("admin check table t_drop_column_with_uk")
+
+	// The non-clustered primary key is rewritten, the clustered one isn't supported.
+	tk.MustExec("create table t_drop_column_with_pk(a int, b int, c int, primary key(a, b) nonclustered)")
+	tk.MustExec("insert into t_drop_column_with_pk values (1, 1, 1), (2, 1, 2)")
+	tk.MustExec("alter table t_drop_column_with_pk drop column b")
+	tk.MustExec("admin check table t_drop_column_with_pk")
+	tk.MustGetErrCode("insert into t_drop_column_with_pk values (1, 3)", errno.ErrDupEntry)
+	tk.MustQuery("show create table t_drop_column_with_pk").Check(testkit.Rows("" +
+		"t_drop_column_with_pk CREATE TABLE `t_drop_column_with_pk` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("create table t_drop_column_with_clustered_pk(a int, b int, c int, primary key(a, b) clustered)")
+	tk.MustExec("create index idx_bc on t_drop_column_with_clustered_pk(b, c)")
+	tk.MustExec("insert into t_drop_column_with_clustered_pk values (1, 1, 1)")
+	tk.MustGetErrMsg("alter table t_drop_column_with_clustered_pk drop column b", "[ddl:8200]Unsupported drop column b covered by the clustered primary key")
+	tk.MustGetErrMsg("alter table t_drop_column_with_clustered_pk drop column c, drop column b", "[ddl:8200]Unsupported drop column b covered by the clustered primary key")
+	tk.MustQuery(queryIndexOnTable("drop_composite_index_test", "t_drop_column_with_clustered_pk")).Check(testkit.Rows("PRIMARY YES", "idx_bc YES"))
+	// The columns not covered by the clustered primary key can still be dropped with their indexes rewritten.
+	tk.MustExec("alter table t_drop_column_with_clustered_pk drop column c")
+	tk.MustQuery(queryIndexOnTable("drop_composite_index_test", "t_drop_column_with_clustered_pk")).Check(testkit.Rows("PRIMARY YES", "idx_bc YES"))
+	tk.MustExec("admin check table t_drop_column_with_clustered_pk")
 }
 
 func TestDropColumnWithIndex(t *testing.T) {
@@ -2877,7 +2944,8 @@ func TestDropColumnWithAutoInc(t *testing.T) {
 	tk.MustExec("drop table t")
 
 	tk.MustExec("create table t(a int auto_increment, b int, key(a, b))")
-	tk.MustGetErrCode("alter table t drop column b", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t drop column b")
+	tk.MustQuery(query).Check(testkit.Rows("a YES"))
 }
 
 func TestDropColumnWithMultiIndex(t *testing.T) {
@@ -3361,8 +3429,8 @@ func TestCreateTemporaryTable(t *testing.T) {
 	tk.MustGetErrCode("create table t(id int) on commit delete rows", errno.ErrParse)
 	tk.MustGetErrCode("create table t(id int) on commit preserve rows", errno.ErrParse)
 
-	// Not support yet.
-	tk.MustGetErrCode("create global temporary table t (id int) on commit preserve rows", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create global temporary table t (id int) on commit preserve rows")
+	tk.MustExec("drop table t")
 
 	// Engine type can be anyone, see https://github.com/pingcap/tidb/issues/28541.
 	tk.MustExec("drop table if exists tengine")
@@ -3851,11 +3851,16 @@ func TestPartitionErrorCode(t *testing.T) {
 		);`)
 	tk.MustGetDBError("alter table t_part coalesce partition 4;", dbterror.ErrCoalesceOnlyOnHashPartition)
 
-	tk.MustGetErrCode("alter table t_part check partition p0, p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part rebuild partition p0,p1;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part remove partitioning;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part repair partition p1;", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("insert into t_part values (1), (11)")
+	tk.MustExec("alter table t_part check partition p0, p1;")
+	tk.MustExec("alter table t_part optimize partition p0,p1;")
+	tk.MustExec("alter table t_part rebuild partition p0,p1;")
+	tk.MustExec("alter table t_part repair partition p1;")
+	tk.MustGetErrCode("alter table t_part rebuild partition p2;", errno.ErrUnknownPartition)
+	tk.MustGetErrCode("alter table t_part check partition p0, p1, add column b int;", errno.ErrUnsupportedDDLOperation)
+	tk.MustQuery("select * from t_part").Sort().Check(testkit.Rows("1", "11"))
+	tk.MustExec("alter table t_part remove partitioning;")
+	tk.MustGetErrCode("alter table t_part remove partitioning;", errno.ErrPartitionMgmtOnNonpartitioned)
 
 	// Reduce the impact on DML when executing partition DDL
 	tk1 := testkit.NewTestKit(t, store)
@@ -4286,7 +4291,7 @@ func TestConstAndTimezoneDepent2(t *testing.T) {
 	);`)
 }
 
-func TestUnsupportedPartitionManagementDDLs(t *testing.T) {
+func TestAlterTablePartitionBy(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("use test;")
@@ -4300,8 +4305,10 @@ func TestUnsupportedPartitionManagementDDLs(t *testing.T) {
 		);
 	`)
 
-	_, err := tk.Exec("alter table test_1465 partition by hash(a)")
-	require.Regexp(t, ".*alter table partition is unsupported", err.Error())
+	tk.MustExec("insert into test_1465 values (1), (11), (21)")
+	tk.MustExec("alter table test_1465 partition by hash(a) partitions 2")
+	tk.MustQuery("select a from test_1465 partition (p1)").Sort().Check(testkit.Rows("1", "11", "21"))
+	tk.MustExec("admin check table test_1465")
 }
 
 func TestCommitWhenSchemaChange(t *testing.T) {
@@ -4941,15 +4948,38 @@ func TestCreateAndAlterIntervalPartition(t *testing.T) {
 			" PARTITION `P_LT_90` VALUES LESS THAN (90),\n" +
 			" PARTITION `P_MAXVALUE` VALUES LESS THAN (MAXVALUE))"))
 
-	err = tk.ExecToErr("alter table ipt merge first partition less than (60)")
+	tk.MustExec("alter table ipt merge first partition less than (60)")
+	tk.MustQuery("select count(*) from ipt partition (P_LT_60)").Check(testkit.Rows("12"))
+	err = tk.ExecToErr("alter table ipt merge first partition less than (65)")
 	require.Error(t, err)
-	require.Equal(t, "[ddl:8200]Unsupported MERGE FIRST PARTITION", err.Error())
-
-	err = tk.ExecToErr("alter table ipt split maxvalue partition less than (140)")
+	require.Equal(t, "[ddl:8200]Unsupported INTERVAL: expr (65) not matching FIRST + n INTERVALs (60 + n * 10)", err.Error())
+	err = tk.ExecToErr("alter table ipt merge first partition less than (60)")
 	require.Error(t, err)
-	require.Equal(t, "[ddl:8200]Unsupported SPLIT LAST PARTITION", err.Error())
+	require.Equal(t, "[ddl:8200]Unsupported MERGE FIRST PARTITION, given value does not generate a list of partitions to be merged", err.Error())
 
+	tk.MustExec("alter table ipt split maxvalue partition less than (140)")
+	tk.MustQuery("select count(*) from ipt partition (P_LT_110)").Check(testkit.Rows("3"))
+	tk.MustQuery("select count(*) from ipt partition (P_MAXVALUE)").Check(testkit.Rows("0"))
+	tk.MustQuery("SHOW CREATE TABLE ipt").Check(testkit.Rows(
+		"ipt CREATE TABLE `ipt` (\n" +
+			"  `id` bigint(20) unsigned NOT NULL,\n" +
+			"  `val` varchar(255) DEFAULT NULL,\n" +
+			"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+			"  KEY `val` (`val`)\n" +
+			") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+			"PARTITION BY RANGE (`id`)\n" +
+			"(PARTITION `P_LT_60` VALUES LESS THAN (60),\n" +
+			" PARTITION `P_LT_70` VALUES LESS THAN (70),\n" +
+			" PARTITION `P_LT_80` VALUES LESS THAN (80),\n" +
+			" PARTITION `P_LT_90` VALUES LESS THAN (90),\n" +
+			" PARTITION `P_LT_100` VALUES LESS THAN (100),\n" +
+			" PARTITION `P_LT_110` VALUES LESS THAN (110),\n" +
+			" PARTITION `P_LT_120` VALUES LESS THAN (120),\n" +
+			" PARTITION `P_LT_130` VALUES LESS THAN (130),\n" +
+			" PARTITION `P_LT_140` VALUES LESS THAN (140),\n" +
+			" PARTITION `P_MAXVALUE` VALUES LESS THAN (MAXVALUE))"))
 	tk.MustQuery("select count(*) from ipt").Check(testkit.Rows("27"))
+	tk.MustExec("admin check table ipt")
 
 	tk.MustExec("create table idpt (id date primary key nonclustered, val varchar(255), key (val)) partition by range COLUMNS (id) INTERVAL (1 week) FIRST PARTITION LESS THAN ('2022-02-01') LAST PARTITION LESS THAN ('2022-03-29') NULL PARTITION MAXVALUE PARTITION")
 	tk.MustQuery("SHOW CREATE TABLE idpt").Check(testkit.Rows(
@@ -5032,13 +5062,13 @@ func TestCreateAndAlterIntervalPartition(t *testing.T) {
 	tk.MustExec("create table t2 (id bigint unsigned primary key, val varchar(255), key (val)) partition by range (id) INTERVAL (10) FIRST PARTITION LESS THAN (10) LAST PARTITION LESS THAN (90)")
 	tk.MustExec("alter table t2 first partition less than (20)")
 	tk.MustExec("alter table t2 LAST partition less than (110)")
-	err = tk.ExecToErr("alter table t2 merge first partition less than (60)")
+	err = tk.ExecToErr("alter table t2 merge first partition less than (20)")
 	require.Error(t, err)
-	require.Equal(t, "[ddl:8200]Unsupported MERGE FIRST PARTITION", err.Error())
+	require.Equal(t, "[ddl:8200]Unsupported MERGE FIRST PARTITION, given value does not generate a list of partitions to be merged", err.Error())
 
 	err = tk.ExecToErr("alter table t2 split maxvalue partition less than (140)")
 	require.Error(t, err)
-	require.Equal(t, "[ddl:8200]Unsupported SPLIT LAST PARTITION", err.Error())
+	require.Equal(t, "[ddl:8200]Unsupported SPLIT MAXVALUE PARTITION without a MAXVALUE partition", err.Error())
 
 	tk.MustQuery("show create table t2").Check(testkit.Rows(
 		"t2 CREATE TABLE `t2` (\n" +
@@ -69,6 +69,7 @@ import (
 	atomicutil "go.uber.org/atomic"
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
+	"golang.org/x/time/rate"
 )
 
 const (
@@ -169,6 +170,7 @@ type DDL interface {
 	DropSchema(ctx sessionctx.Context, stmt *ast.DropDatabaseStmt) error
 	CreateTable(ctx sessionctx.Context, stmt *ast.CreateTableStmt) error
 	CreateView(ctx sessionctx.Context, stmt *ast.CreateViewStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
 	DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error)
 	RecoverTable(ctx sessionctx.Context, recoverInfo *RecoverInfo) (err error)
 	RecoverSchema(ctx sessionctx.Context, recoverSchemaInfo *RecoverSchemaInfo) error
@@ -192,6 +194,10 @@ type DDL interface {
 	AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceGroupStmt) error
 	AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResourceGroupStmt) error
 	DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGroupStmt) error
+	CreateProcedure(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error
+	DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
 	FlashbackCluster(ctx sessionctx.Context, flashbackTS uint64) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
@@ -526,6 +532,7 @@ func (dc *ddlCtx) newReorgCtx(jobID int64, rowCount int64) *reorgCtx {
 	}
 	rc := &reorgCtx{}
 	rc.doneCh = make(chan error, 1)
+	rc.params.writeLimiter = rate.NewLimiter(rate.Inf, 0)
 	// initial reorgCtx
 	rc.setRowCount(rowCount)
 	rc.mu.warnings = make(map[errors.ErrorID]*terror.Error)
@@ -977,7 +984,8 @@ func getIntervalFromPolicy(policy []time.Duration, i int) (time.Duration, bool)
 func getJobCheckInterval(job *model.Job, i int) (time.Duration, bool) {
 	switch job.Type {
 	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn,
-		model.ActionReorganizePartition:
+		model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning, model.ActionRebuildTable:
 		return getIntervalFromPolicy(slowDDLIntervalPolicy, i)
 	case model.ActionCreateTable, model.ActionCreateSchema:
 		return getIntervalFromPolicy(fastDDLIntervalPolicy, i)
@@ -1036,6 +1044,9 @@ func (d *ddl) DoDDLJob(ctx sessionctx.Context, job *model.Job) error {
 		// Instead, we merge all the jobs into one pending job.
 		return appendToSubJobs(mci, job)
 	}
+	if err := checkReorgOnSessionScopedTempTable(d.infoCache.GetLatest(), job); err != nil {
+		return errors.Trace(err)
+	}
 	// Get a global job ID and put the DDL job in the queue.
 	setDDLJobQuery(ctx, job)
 	task := &limitJobTask{job, make(chan error), nil}
@@ -1622,6 +1633,65 @@ func ResumeJobsBySystem(se sessionctx.Context, ids []int64) (errs []error, err e
 	return processJobs(resumePausedJob, se, ids, model.AdminCommandBySystem)
 }
 
+// AlterJobParams are the parameters of a DDL job changed by `ADMIN ALTER DDL JOBS`.
+// The nil parameters are not changed.
+type AlterJobParams struct {
+	Concurrency   *int
+	BatchSize     *int
+	MaxWriteSpeed *int64
+	// RunWindow replaces the run window of the job if SetRunWindow is true, nil means all day.
+	RunWindow    *model.DDLRunWindow
+	SetRunWindow bool
+}
+
+// AlterJob changes the parameters of a DDL job which reorganizes data according to user command.
+// The parameters are saved in the reorg meta of the job, so they survive DDL owner changes,
+// and the running job picks them up in its next round.
+func AlterJob(se sessionctx.Context, id int64, params *AlterJobParams) error {
+	alterJob := func(_ *sess.Session, job *model.Job, _ model.AdminCommandOperator) error {
+		return alterJobParams(job, params)
+	}
+	jobErrs, err := processJobs(alterJob, se, []int64{id}, model.AdminCommandByEndUser)
+	if err != nil {
+		return err
+	}
+	return jobErrs[0]
+}
+
+func alterJobParams(job *model.Job, params *AlterJobParams) error {
+	switch job.Type {
+	case model.ActionAddIndex, model.ActionAddPrimaryKey, model.ActionModifyColumn, model.ActionAddColumn,
+		model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning,
+		model.ActionRebuildTable, model.ActionMultiSchemaChange:
+	default:
+		return dbterror.ErrCannotAlterDDLJob.GenWithStackByArgs(job.ID,
+			fmt.Sprintf("job type [%s] doesn't reorganize data", job.Type))
+	}
+	if job.IsFinished() || job.IsSynced() || job.IsRollingback() {
+		return dbterror.ErrCannotAlterDDLJob.GenWithStackByArgs(job.ID,
+			fmt.Sprintf("job state [%s]", job.State))
+	}
+	if job.ReorgMeta == nil {
+		return dbterror.ErrCannotAlterDDLJob.GenWithStackByArgs(job.ID, "job has no reorg meta")
+	}
+	if job.ReorgMeta.IsDistReorg {
+		return dbterror.ErrCannotAlterDDLJob.GenWithStackByArgs(job.ID, "job runs in the distributed framework")
+	}
+	if params.Concurrency != nil {
+		job.ReorgMeta.Concurrency = *params.Concurrency
+	}
+	if params.BatchSize != nil {
+		job.ReorgMeta.BatchSize = *params.BatchSize
+	}
+	if params.MaxWriteSpeed != nil {
+		job.ReorgMeta.MaxWriteSpeed = *params.MaxWriteSpeed
+	}
+	if params.SetRunWindow {
+		job.ReorgMeta.RunWindow = params.RunWindow
+	}
+	return nil
+}
+
 // pprocessAllJobs processes all the jobs in the job table, 100 jobs at a time in case of high memory usage.
 func processAllJobs(process func(*sess.Session, *model.Job, model.AdminCommandOperator) (err error),
 	se sessionctx.Context, byWho model.AdminCommandOperator) (map[int64]error, error) {
@@ -76,6 +76,12 @@ func ResolveAlterAlgorithm(alterSpec *ast.AlterTableSpec, specify ast.AlgorithmT
 	// For now, TiDB only support inplace algorithm and instant algorithm.
 	case ast.AlterTableAddConstraint:
 		return getProperAlgorithm(specify, inplaceAlgorithm)
+	case ast.AlterTableForce:
+		// FORCE copies the rows into a new table, that is what ALGORITHM=COPY asks for.
+		if specify == ast.AlgorithmTypeCopy {
+			return specify, nil
+		}
+		return getProperAlgorithm(specify, inplaceAlgorithm)
 	default:
 		return getProperAlgorithm(specify, instantAlgorithm)
 	}
@@ -42,6 +42,7 @@ import (
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
@@ -62,6 +63,7 @@ import (
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/domainutil"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mathutil"
@@ -723,7 +725,7 @@ func setColumnFlagWithConstraint(colMap map[string]*table.Column, v *ast.Constra
 				}
 			}
 		}
-	case ast.ConstraintKey, ast.ConstraintIndex:
+	case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintSpatial:
 		for i, key := range v.Keys {
 			if key.Expr != nil {
 				continue
@@ -1899,8 +1901,13 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintFulltext {
+			if constr.Keys, constr.Option, err = BuildFullTextIndexOption(tbInfo.Columns, constr.Keys, constr.Option); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
 		// Build hidden columns if necessary.
-		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
+		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), constr.Option, tbInfo, tblColumns)
 		if err != nil {
 			return nil, err
 		}
@@ -1961,13 +1968,9 @@ func BuildTableInfo(
 			}
 		}
 
-		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt.GenWithStackByArgs())
-			continue
-		}
-
 		var (
 			indexName       = constr.Name
+			indexOption     = constr.Option
 			primary, unique bool
 		)
 
@@ -1979,6 +1982,10 @@ func BuildTableInfo(
 			indexName = mysql.PrimaryKeyName
 		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
 			unique = true
+		case ast.ConstraintSpatial:
+			if indexOption, err = BuildSpatialIndexOption(tbInfo.Columns, constr.Keys, constr.Option); err != nil {
+				return nil, errors.Trace(err)
+			}
 		}
 
 		// check constraint
@@ -2053,7 +2060,7 @@ func BuildTableInfo(
 			unique,
 			false,
 			constr.Keys,
-			constr.Option,
+			indexOption,
 			model.StatePublic,
 		)
 		if err != nil {
@@ -2222,7 +2229,7 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			if err := checkPartitionFuncType(ctx, s.Partition.Expr, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
-			if err := checkPartitioningKeysConstraints(ctx, s, tbInfo); err != nil {
+			if err := checkPartitioningKeysConstraints(ctx, s.Partition, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -2278,9 +2285,7 @@ func BuildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 		return nil, dbterror.ErrWrongObject.GenWithStackByArgs(ident.Schema, referTblInfo.Name, "BASE TABLE")
 	}
 	tblInfo := *referTblInfo
-	if err := setTemporaryType(ctx, &tblInfo, s); err != nil {
-		return nil, errors.Trace(err)
-	}
+	setTemporaryType(&tblInfo, s)
 	// Check non-public column and adjust column offset.
 	newColumns := referTblInfo.Cols()
 	newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
@@ -2405,9 +2410,7 @@ func BuildTableInfoWithStmt(ctx sessionctx.Context, s *ast.CreateTableStmt, dbCh
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	if err = setTemporaryType(ctx, tbInfo, s); err != nil {
-		return nil, errors.Trace(err)
-	}
+	setTemporaryType(tbInfo, s)
 
 	if err = setTableAutoRandomBits(ctx, tbInfo, colDefs); err != nil {
 		return nil, errors.Trace(err)
@@ -2502,18 +2505,30 @@ func (d *ddl) CreateTable(ctx sessionctx.Context, s *ast.CreateTableStmt) (err e
 	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
 }
 
-func setTemporaryType(_ sessionctx.Context, tbInfo *model.TableInfo, s *ast.CreateTableStmt) error {
+func setTemporaryType(tbInfo *model.TableInfo, s *ast.CreateTableStmt) {
 	switch s.TemporaryKeyword {
 	case ast.TemporaryGlobal:
 		tbInfo.TempTableType = model.TempTableGlobal
-		// "create global temporary table ... on commit preserve rows"
-		if !s.OnCommitDelete {
-			return errors.Trace(dbterror.ErrUnsupportedOnCommitPreserve)
-		}
+		// "create global temporary table ... on commit preserve rows" keeps the rows until the session ends.
+		tbInfo.OnCommitPreserveRows = !s.OnCommitDelete
 	case ast.TemporaryLocal:
 		tbInfo.TempTableType = model.TempTableLocal
+		tbInfo.OnCommitPreserveRows = false
 	default:
 		tbInfo.TempTableType = model.TempTableNone
+		tbInfo.OnCommitPreserveRows = false
+	}
+}
+
+// checkReorgOnSessionScopedTempTable checks whether the job reorganizes a global temporary table created
+// with ON COMMIT PRESERVE ROWS. The rows of such a table are kept in the sessions, so they can't be reorganized.
+func checkReorgOnSessionScopedTempTable(is infoschema.InfoSchema, job *model.Job) error {
+	if !job.MayNeedReorg() {
+		return nil
+	}
+	tbl, ok := is.TableByID(job.TableID)
+	if ok && tbl.Meta().TempTableType == model.TempTableGlobal && tbl.Meta().HasSessionScopedRows() {
+		return dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs(job.Type.String())
 	}
 	return nil
 }
@@ -2954,6 +2969,49 @@ func (d *ddl) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt) (err err
 	return d.CreateTableWithInfo(ctx, s.ViewName.Schema, tbInfo, onExist)
 }
 
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	tbInfo, err := BuildMaterializedViewTableInfo(ctx, s, schema.Charset, schema.Collate)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	onExist := OnExistError
+	if s.IfNotExists {
+		onExist = OnExistIgnore
+	}
+
+	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+}
+
+// BuildMaterializedViewTableInfo builds the TableInfo of the table storing the result of a materialized view. The
+// column types and the MaterializedViewInfo of the ast.CreateMaterializedViewStmt are filled by the planner.
+func BuildMaterializedViewTableInfo(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt, dbCharset, dbCollate string) (*model.TableInfo, error) {
+	if s.Info == nil || len(s.ColumnTypes) != len(s.Cols) {
+		return nil, errors.Errorf("the materialized view %s isn't planned", s.ViewName.Name.O)
+	}
+	stmt := &ast.CreateTableStmt{Table: s.ViewName, Cols: make([]*ast.ColumnDef, 0, len(s.Cols))}
+	for i, col := range s.Cols {
+		stmt.Cols = append(stmt.Cols, &ast.ColumnDef{
+			Name: &ast.ColumnName{Name: col},
+			Tp:   s.ColumnTypes[i],
+		})
+	}
+	tbInfo, err := BuildTableInfoWithStmt(ctx, stmt, dbCharset, dbCollate, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err = checkTableInfoValidWithStmt(ctx, tbInfo, stmt); err != nil {
+		return nil, err
+	}
+	tbInfo.MaterializedView = s.Info
+	return tbInfo, nil
+}
+
 // BuildViewInfo builds a ViewInfo structure from an ast.CreateViewStmt.
 func BuildViewInfo(_ sessionctx.Context, s *ast.CreateViewStmt) (*model.ViewInfo, error) {
 	// Always Use `format.RestoreNameBackQuotes` to restore `SELECT` statement despite the `ANSI_QUOTES` SQL Mode is enabled or not.
@@ -3493,6 +3551,10 @@ func ResolveAlterTableSpec(ctx sessionctx.Context, specs []*ast.AlterTableSpec)
 			validSpecs = append(validSpecs, spec)
 		}
 	}
+	// A lone "ALTER TABLE t ALGORITHM=COPY" rebuilds the table like FORCE.
+	if len(validSpecs) == 0 && algorithm == ast.AlgorithmTypeCopy {
+		validSpecs = append(validSpecs, &ast.AlterTableSpec{Tp: ast.AlterTableForce})
+	}
 
 	// Verify whether the algorithm is supported.
 	for _, spec := range validSpecs {
@@ -3549,6 +3611,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 	if isMultiSchemaChanges(validSpecs) && (sctx.GetSessionVars().EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load()) {
 		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
 	}
+	if needRebuildTable(tb.Meta(), validSpecs) {
+		return d.RebuildTable(sctx, ident, validSpecs)
+	}
 	// set name for anonymous foreign key.
 	maxForeignKeyID := tb.Meta().MaxForeignKeyID
 	for _, spec := range validSpecs {
@@ -3571,21 +3636,22 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
-		case ast.AlterTableReorganizePartition:
+		case ast.AlterTableReorganizePartition, ast.AlterTableReorganizeFirstPartition,
+			ast.AlterTableReorganizeLastPartition:
 			err = d.ReorganizePartitions(sctx, ident, spec)
-		case ast.AlterTableReorganizeFirstPartition:
-			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("MERGE FIRST PARTITION")
-		case ast.AlterTableReorganizeLastPartition:
-			err = dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("SPLIT LAST PARTITION")
 		case ast.AlterTableCheckPartitions:
+			// CHECK PARTITION is handled by the executor, it only ends up here
+			// when combined with other alter table specifications.
 			err = errors.Trace(dbterror.ErrUnsupportedCheckPartition)
-		case ast.AlterTableRebuildPartition:
-			err = errors.Trace(dbterror.ErrUnsupportedRebuildPartition)
-		case ast.AlterTableOptimizePartition:
-			err = errors.Trace(dbterror.ErrUnsupportedOptimizePartition)
+		case ast.AlterTableRebuildPartition, ast.AlterTableOptimizePartition:
+			err = d.RebuildPartitions(sctx, ident, spec)
+		case ast.AlterTableForce:
+			// A lone FORCE is handled by RebuildTable.
+			err = dbterror.ErrRunMultiSchemaChanges.FastGenByArgs(model.ActionRebuildTable.String())
 		case ast.AlterTableRemovePartitioning:
-			err = errors.Trace(dbterror.ErrUnsupportedRemovePartition)
+			err = d.RemovePartitioning(sctx, ident, spec)
 		case ast.AlterTableRepairPartition:
+			// Like CHECK PARTITION, REPAIR PARTITION is handled by the executor.
 			err = errors.Trace(dbterror.ErrUnsupportedRepairPartition)
 		case ast.AlterTableDropColumn:
 			err = d.DropColumn(sctx, ident, spec)
@@ -3634,8 +3700,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 				err = d.CreateForeignKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, spec.Constraint.Refer)
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("the switch of check constraint is off"))
@@ -3661,8 +3731,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 			isAlterTable := true
 			err = d.renameTable(sctx, ident, newIdent, isAlterTable)
 		case ast.AlterTablePartition:
-			// Prevent silent succeed if user executes ALTER TABLE x PARTITION BY ...
-			err = errors.New("alter table partition is unsupported")
+			err = d.AlterTablePartitioning(sctx, ident, spec)
 		case ast.AlterTableOption:
 			var placementPolicyRef *model.PolicyRefInfo
 			for i, opt := range spec.Options {
@@ -3913,12 +3982,6 @@ func (d *ddl) getSchemaAndTableByIdent(ctx sessionctx.Context, tableIdent ast.Id
 func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	for _, constraint := range col.Options {
 		switch constraint.Tp {
-		case ast.ColumnOptionAutoIncrement:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint AUTO_INCREMENT when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
-		case ast.ColumnOptionPrimaryKey:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint PRIMARY KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
-		case ast.ColumnOptionUniqKey:
-			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' constraint UNIQUE KEY when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
 		case ast.ColumnOptionAutoRandom:
 			errMsg := fmt.Sprintf(autoid.AutoRandomAlterAddColumn, col.Name, ti.Schema, ti.Name)
 			return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(errMsg)
@@ -3928,6 +3991,49 @@ func checkUnsupportedColumnConstraint(col *ast.ColumnDef, ti ast.Ident) error {
 	return nil
 }
 
+// checkAddKeyColumn checks the column with the AUTO_INCREMENT, PRIMARY KEY or UNIQUE option can be added to the table.
+func checkAddKeyColumn(ctx sessionctx.Context, ti ast.Ident, t table.Table, col *table.Column, specNewColumn *ast.ColumnDef) error {
+	tblInfo := t.Meta()
+	if ctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+		return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' with a key or AUTO_INCREMENT in a multi-schema change when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
+	}
+	if tblInfo.Partition != nil {
+		return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' with a key or AUTO_INCREMENT when altering the partitioned table '%s.%s'", col.Name, ti.Schema, ti.Name)
+	}
+	isPK := mysql.HasPriKeyFlag(col.GetFlag())
+	for _, op := range specNewColumn.Options {
+		if op.Tp == ast.ColumnOptionPrimaryKey && op.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
+			return dbterror.ErrUnsupportedAddColumn.GenWithStack("unsupported add column '%s' with a clustered primary key when altering '%s.%s'", col.Name, ti.Schema, ti.Name)
+		}
+	}
+	if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		if !isKeyColumn(col.ColumnInfo) {
+			return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+		}
+		if tblInfo.GetAutoIncrementColInfo() != nil || tblInfo.ContainsAutoRandomBits() {
+			return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+		}
+		switch col.GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
+			mysql.TypeFloat, mysql.TypeDouble:
+		default:
+			return types.ErrWrongFieldSpec.GenWithStackByArgs(col.Name.O)
+		}
+		// Not support auto id with default value.
+		if col.GetDefaultValue() != nil {
+			return dbterror.ErrInvalidDefaultValue.GenWithStackByArgs(col.Name)
+		}
+	}
+	if isPK && (tblInfo.PKIsHandle || tblInfo.IsCommonHandle || tables.FindPrimaryIndex(tblInfo) != nil) {
+		return infoschema.ErrMultiplePriKey
+	}
+	allTableColumns := make([]*model.ColumnInfo, 0, len(tblInfo.Columns)+1)
+	allTableColumns = append(allTableColumns, tblInfo.Columns...)
+	allTableColumns = append(allTableColumns, col.ColumnInfo)
+	_, err := BuildColumnKeyInfo(t, allTableColumns, col.Name, isPK, model.StateNone)
+	return errors.Trace(err)
+}
+
 func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, spec *ast.AlterTableSpec, t table.Table, specNewColumn *ast.ColumnDef) (*table.Column, error) {
 	err := checkUnsupportedColumnConstraint(specNewColumn, ti)
 	if err != nil {
@@ -4074,6 +4180,20 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		BinlogInfo: &model.HistoryInfo{},
 		Args:       []interface{}{col, spec.Position, 0, spec.IfNotExists},
 	}
+	// The column with a key or AUTO_INCREMENT backfills the data in the same job.
+	if isKeyColumn(col.ColumnInfo) || mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		if err = checkAddKeyColumn(ctx, ti, t, col, specNewColumn); err != nil {
+			return errors.Trace(err)
+		}
+		tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+		job.ReorgMeta = &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		}
+		job.CtxVars = []interface{}{true}
+	}
 
 	err = d.DoDDLJob(ctx, job)
 	err = d.callHookOnChanged(job, err)
@@ -4239,6 +4359,159 @@ func getReplacedPartitionIDs(names []model.CIStr, pi *model.PartitionInfo) (firs
 	return firstPartIdx, lastPartIdx, idMap, nil
 }
 
+// AlterTablePartitioning changes the partitioning of a table, or partitions a non-partitioned table,
+// by reorganizing all its data into a new set of partitions.
+func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	if len(meta.ForeignKeys) > 0 {
+		return errors.Trace(infoschema.ErrForeignKeyOnPartitioned)
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	if referredFKs := is.GetTableReferredForeignKeys(schema.Name.L, meta.Name.L); len(referredFKs) > 0 {
+		return errors.Trace(infoschema.ErrForeignKeyOnPartitioned)
+	}
+	piOld := meta.GetPartitionInfo()
+	var partNames []model.CIStr
+	if piOld != nil {
+		partNames = make([]model.CIStr, 0, len(piOld.Definitions))
+		for i := range piOld.Definitions {
+			partNames = append(partNames, piOld.Definitions[i].Name)
+		}
+	} else {
+		piOld = getPartitionInfoTypeNone(meta)
+		partNames = []model.CIStr{piOld.Definitions[0].Name}
+	}
+
+	newMeta := meta.Clone()
+	newMeta.Partition = nil
+	if err = buildTablePartitionInfo(ctx, spec.Partition, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	newPartInfo := newMeta.Partition
+	if newPartInfo == nil {
+		// The partitioning is not supported or disabled, a warning is already appended.
+		return nil
+	}
+	if err = checkPartitionDefinitionConstraints(ctx, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkPartitionFuncType(ctx, spec.Partition.Expr, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkPartitioningKeysConstraints(ctx, spec.Partition, newMeta); err != nil {
+		return errors.Trace(err)
+	}
+	for _, idx := range newMeta.Indices {
+		if idx.Global {
+			return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("PARTITION BY with global indexes"))
+		}
+	}
+	if err = d.assignPartitionIDs(newPartInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, newPartInfo); err != nil {
+		return errors.Trace(err)
+	}
+	// The table gets a new ID when the DDL is done, since the data of a
+	// non-partitioned table is stored under the current table ID and will be removed.
+	newIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newPartInfo.NewTableID = newIDs[0]
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		TableName:  meta.Name.L,
+		Type:       model.ActionAlterTablePartitioning,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, newPartInfo},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+	}
+
+	// No preSplitAndScatter here, it will be done by the worker in onReorganizePartition instead.
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if err == nil {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("The statistics of new partitions will be outdated after reorganizing partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	}
+	return errors.Trace(err)
+}
+
+// RemovePartitioning removes the partitioning of a table, by reorganizing all its partitions
+// into a single non-partitioned table.
+func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, _ *ast.AlterTableSpec) error {
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	if hasGlobalIndex(meta) {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REMOVE PARTITIONING with global indexes"))
+	}
+	partNames := make([]model.CIStr, 0, len(pi.Definitions))
+	for i := range pi.Definitions {
+		partNames = append(partNames, pi.Definitions[i].Name)
+	}
+	// All partitions are collapsed into a single partition, whose ID becomes the new table ID.
+	newPartInfo := &model.PartitionInfo{
+		Type:   model.PartitionTypeNone,
+		Enable: true,
+		Num:    1,
+		Definitions: []model.PartitionDefinition{{
+			Name:    model.NewCIStr("CollapsedPartitions"),
+			Comment: "Intermediate partition during ALTER TABLE ... REMOVE PARTITIONING",
+		}},
+	}
+	if err = d.assignPartitionIDs(newPartInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	newPartInfo.NewTableID = newPartInfo.Definitions[0].ID
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		TableName:  meta.Name.L,
+		Type:       model.ActionRemovePartitioning,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{partNames, newPartInfo},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+	}
+
+	// No preSplitAndScatter here, it will be done by the worker in onReorganizePartition instead.
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	if err == nil {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("The statistics of the table will be outdated after removing partitioning. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	}
+	return errors.Trace(err)
+}
+
 // ReorganizePartitions reorganize one set of partitions to a new set of partitions.
 func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
@@ -4261,6 +4534,12 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	default:
 		return errors.Trace(dbterror.ErrUnsupportedReorganizePartition)
 	}
+	isIntervalSyntax := spec.Tp == ast.AlterTableReorganizeFirstPartition || spec.Tp == ast.AlterTableReorganizeLastPartition
+	if isIntervalSyntax {
+		if err = buildReorganizeIntervalPartitionDefs(ctx, meta, spec); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	firstPartIdx, lastPartIdx, idMap, err := getReplacedPartitionIDs(spec.PartitionNames, pi)
 	if err != nil {
 		return errors.Trace(err)
@@ -4275,6 +4554,25 @@ func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec
 	if err = checkReorgPartitionDefs(ctx, meta, partInfo, firstPartIdx, lastPartIdx, idMap); err != nil {
 		return errors.Trace(err)
 	}
+	if isIntervalSyntax {
+		query, ok := ctx.Value(sessionctx.QueryString).(string)
+		if ok {
+			sqlMode := ctx.GetSessionVars().SQLMode
+			partNames := make([]string, 0, len(spec.PartitionNames))
+			for i := range spec.PartitionNames {
+				partNames = append(partNames, stringutil.Escape(spec.PartitionNames[i].O, sqlMode))
+			}
+			var buf bytes.Buffer
+			AppendPartitionDefs(partInfo, &buf, sqlMode)
+
+			syntacticSugar := spec.Partition.PartitionMethod.OriginalText()
+			syntacticStart := spec.Partition.PartitionMethod.OriginTextPosition()
+			newQuery := query[:syntacticStart] + "REORGANIZE PARTITION " + strings.Join(partNames, ", ") +
+				" INTO (" + buf.String() + ")" + query[syntacticStart+len(syntacticSugar):]
+			defer ctx.SetValue(sessionctx.QueryString, query)
+			ctx.SetValue(sessionctx.QueryString, newQuery)
+		}
+	}
 	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
 		return errors.Trace(err)
 	}
@@ -4359,6 +4657,246 @@ func checkReorgPartitionDefs(ctx sessionctx.Context, tblInfo *model.TableInfo, p
 	return nil
 }
 
+// RebuildPartitions rebuilds the data and indexes of the given partitions, one partition at a time.
+// Each partition is reorganized into a copy of itself with a new partition ID, so the rows and
+// index entries are rewritten online through the reorganize partition framework.
+// It is used by both ALTER TABLE ... REBUILD PARTITION and ALTER TABLE ... OPTIMIZE PARTITION.
+func (d *ddl) RebuildPartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	_, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	if hasGlobalIndex(meta) {
+		return errors.Trace(dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("REBUILD PARTITION with global indexes"))
+	}
+	partNames := spec.PartitionNames
+	if spec.OnAllPartitions {
+		partNames = make([]model.CIStr, 0, len(pi.Definitions))
+		for i := range pi.Definitions {
+			partNames = append(partNames, pi.Definitions[i].Name)
+		}
+	}
+	seen := make(map[string]struct{}, len(partNames))
+	for _, name := range partNames {
+		if pi.FindPartitionDefinitionByName(name.L) == -1 {
+			return errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.O, meta.Name.O))
+		}
+		if _, ok := seen[name.L]; ok {
+			return errors.Trace(dbterror.ErrSameNamePartition.GenWithStackByArgs(name.O))
+		}
+		seen[name.L] = struct{}{}
+	}
+
+	for _, name := range partNames {
+		if err = d.rebuildPartition(ctx, ident, name); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(partNames) > 0 {
+		ctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("The statistics of related partitions will be outdated after rebuilding partitions. Please use 'ANALYZE TABLE' statement if you want to update it now"))
+	}
+	return nil
+}
+
+// rebuildPartition reorganizes a single partition into an identical partition with a new ID.
+func (d *ddl) rebuildPartition(ctx sessionctx.Context, ident ast.Ident, name model.CIStr) error {
+	// The table is fetched again for every partition, since the previous rebuild changed its partition IDs.
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.FastGenByArgs(ident.Schema, ident.Name))
+	}
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return dbterror.ErrPartitionMgmtOnNonpartitioned
+	}
+	idx := pi.FindPartitionDefinitionByName(name.L)
+	if idx == -1 {
+		return errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.O, meta.Name.O))
+	}
+	partInfo := &model.PartitionInfo{
+		Type:        pi.Type,
+		Expr:        pi.Expr,
+		Columns:     pi.Columns,
+		Enable:      pi.Enable,
+		Num:         1,
+		Definitions: []model.PartitionDefinition{pi.Definitions[idx].Clone()},
+	}
+	if err = d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err = handlePartitionPlacement(ctx, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		TableName:  meta.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{[]model.CIStr{pi.Definitions[idx].Name}, partInfo},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// needRebuildTable checks whether the alter table specifications are done by RebuildTable:
+// a lone FORCE, or the primary key changes that change the clustered index of the table.
+func needRebuildTable(tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) bool {
+	var force, dropPK, addClustered bool
+	for _, spec := range specs {
+		switch {
+		case spec.Tp == ast.AlterTableForce:
+			force = true
+		case spec.Tp == ast.AlterTableDropPrimaryKey:
+			dropPK = true
+		case spec.Tp == ast.AlterTableAddConstraint && spec.Constraint.Tp == ast.ConstraintPrimaryKey:
+			option := spec.Constraint.Option
+			addClustered = addClustered || (option != nil && option.PrimaryKeyTp == model.PrimaryKeyTypeClustered)
+		default:
+			return false
+		}
+	}
+	if force {
+		return true
+	}
+	if dropPK {
+		return tblInfo.HasClusteredIndex() || addClustered
+	}
+	// Adding a primary key to a table that already has one is rejected by CreatePrimaryKey.
+	return addClustered && !tblInfo.PKIsHandle && tables.FindPrimaryIndex(tblInfo) == nil
+}
+
+// RebuildTable copies the rows of the table into a new physical table, for ALTER TABLE ... FORCE
+// and for the primary key changes that change the clustered index of the table.
+// The specifications are checked by needRebuildTable.
+func (d *ddl) RebuildTable(ctx sessionctx.Context, ident ast.Ident, specs []*ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := t.Meta()
+
+	var (
+		dropPK bool
+		addPK  *ast.Constraint
+	)
+	hasPK := tblInfo.PKIsHandle || tables.FindPrimaryIndex(tblInfo) != nil
+	for _, spec := range specs {
+		switch spec.Tp {
+		case ast.AlterTableDropPrimaryKey:
+			if dropPK {
+				return dbterror.ErrCantDropFieldOrKey.GenWithStackByArgs(mysql.PrimaryKeyName)
+			}
+			if !hasPK {
+				err = dbterror.ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", mysql.PrimaryKeyName)
+				if spec.IfExists {
+					ctx.GetSessionVars().StmtCtx.AppendNote(err)
+					continue
+				}
+				return err
+			}
+			dropPK = true
+		case ast.AlterTableAddConstraint:
+			if addPK != nil || (hasPK && !dropPK) {
+				return infoschema.ErrMultiplePriKey
+			}
+			addPK = spec.Constraint
+		}
+	}
+	force := !dropPK && addPK == nil
+
+	if tblInfo.GetPartitionInfo() != nil {
+		if force {
+			// The partitions are rebuilt one by one.
+			return d.RebuildPartitions(ctx, ident, &ast.AlterTableSpec{Tp: ast.AlterTableRebuildPartition, OnAllPartitions: true})
+		}
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("changing the clustered index of a partitioned table")
+	}
+	if tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs("rebuild table")
+	}
+	if tblInfo.TiFlashReplica != nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs("rebuilding a table with TiFlash replicas")
+	}
+	if dropPK && tblInfo.ContainsAutoRandomBits() {
+		return dbterror.ErrInvalidAutoRandom.GenWithStackByArgs(autoid.AutoRandomAlterErrMsg)
+	}
+	if !ctx.GetSessionVars().InRestrictedSQL && ctx.GetSessionVars().PrimaryKeyRequired && dropPK && addPK == nil {
+		return infoschema.ErrTableWithoutPrimaryKey
+	}
+
+	var (
+		pkSpecs  []*ast.IndexPartSpecification
+		pkOption *ast.IndexOption
+	)
+	if addPK != nil {
+		// Primary keys cannot include expression index parts, see CreatePrimaryKey.
+		for _, idxPart := range addPK.Keys {
+			if idxPart.Expr != nil {
+				return dbterror.ErrFunctionalIndexPrimaryKey
+			}
+		}
+		pkSpecs, pkOption = addPK.Keys, addPK.Option
+		if pkOption != nil {
+			if _, err = validateCommentLength(ctx.GetSessionVars(), mysql.PrimaryKeyName, &pkOption.Comment, dbterror.ErrTooLongIndexComment); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	// Check before the job is put to the queue, the new table ID does not matter here.
+	newTblInfo, _, err := buildRebuildTableInfo(tblInfo.Clone(), 0, dropPK, pkSpecs, pkOption)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkPrimaryKeyNeededInForeignKey(is, schema.Name.L, newTblInfo); err != nil {
+		return err
+	}
+
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tzName, tzOffset := ddlutil.GetTimeZone(ctx)
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionRebuildTable,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{genIDs[0], dropPK, pkSpecs, pkOption},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+			Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+		},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 // CoalescePartitions coalesce partitions can be used with a table that is partitioned by hash or key to reduce the number of partitions by number.
 func (d *ddl) CoalescePartitions(sctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	is := d.infoCache.GetLatest()
@@ -4831,12 +5369,69 @@ func (d *ddl) DropColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTa
 		BinlogInfo:  &model.HistoryInfo{},
 		Args:        []interface{}{colName, spec.IfExists},
 	}
+	if idxInfos := listIndicesToRewrite(colName.L, t.Meta().Indices); len(idxInfos) > 0 {
+		return d.dropColumnWithIndicesRewritten(ctx, ti, schema, t, job, colName, idxInfos)
+	}
 
 	err = d.DoDDLJob(ctx, job)
 	err = d.callHookOnChanged(job, err)
 	return errors.Trace(err)
 }
 
+// dropColumnWithIndicesRewritten drops the column covered by composite indices in a multi-schema change job.
+// The indices are added again without the column first, then the drop column sub-job replaces the original
+// indices with them.
+func (d *ddl) dropColumnWithIndicesRewritten(ctx sessionctx.Context, ti ast.Ident, schema *model.DBInfo, t table.Table,
+	dropColumnJob *model.Job, colName model.CIStr, idxInfos []*model.IndexInfo) error {
+	sessVars := ctx.GetSessionVars()
+	if sessVars.EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load() {
+		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
+	}
+	standalone := sessVars.StmtCtx.MultiSchemaInfo == nil
+	if standalone {
+		sessVars.StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+	}
+	tblInfo := t.Meta()
+	for _, idxInfo := range idxInfos {
+		// The other specifications can't change the rewritten index.
+		sessVars.StmtCtx.MultiSchemaInfo.AlterIndexes = append(sessVars.StmtCtx.MultiSchemaInfo.AlterIndexes, idxInfo.Name)
+
+		indexPartSpecifications := make([]*ast.IndexPartSpecification, 0, len(idxInfo.Columns)-1)
+		for _, col := range idxInfo.Columns {
+			if col.Name.L != colName.L {
+				indexPartSpecifications = append(indexPartSpecifications,
+					&ast.IndexPartSpecification{Column: &ast.ColumnName{Name: col.Name}, Length: col.Length})
+			}
+		}
+		indexOption := &ast.IndexOption{Comment: idxInfo.Comment, Tp: idxInfo.Tp}
+		if idxInfo.Invisible {
+			indexOption.Visibility = ast.IndexVisibilityInvisible
+		}
+		// The rewritten primary key is added as a unique index, and becomes the primary key when it replaces the original one.
+		indexName := model.NewCIStr(genChangingIndexUniqueName(tblInfo, idxInfo))
+		job := &model.Job{
+			SchemaID:   schema.ID,
+			TableID:    tblInfo.ID,
+			SchemaName: schema.Name.L,
+			TableName:  tblInfo.Name.L,
+			Type:       model.ActionAddIndex,
+			BinlogInfo: &model.HistoryInfo{},
+			Args: []interface{}{idxInfo.Unique || idxInfo.Primary, indexName, indexPartSpecifications, indexOption,
+				[]*model.ColumnInfo(nil), idxInfo.Global},
+		}
+		if err := d.DoDDLJob(ctx, job); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := d.DoDDLJob(ctx, dropColumnJob); err != nil {
+		return errors.Trace(err)
+	}
+	if !standalone {
+		return nil
+	}
+	return errors.Trace(d.MultiSchemaChange(ctx, ti))
+}
+
 func checkIsDroppableColumn(ctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo, t table.Table, spec *ast.AlterTableSpec) (isDrapable bool, err error) {
 	tblInfo := t.Meta()
 	// Check whether dropped column has existed.
@@ -4871,6 +5466,14 @@ func checkIsDroppableColumn(ctx sessionctx.Context, is infoschema.InfoSchema, sc
 	if col.IsPKHandleColumn(tblInfo) {
 		return false, dbterror.ErrUnsupportedPKHandle
 	}
+	if isColumnCoveredByClusteredIndex(colName.L, tblInfo) {
+		return false, dbterror.ErrCantDropColWithClusteredPK.GenWithStackByArgs(colName)
+	}
+	// The primary key only on the column is dropped together with it.
+	if pkInfo := tblInfo.GetPrimaryKey(); pkInfo != nil && len(pkInfo.Columns) == 1 && pkInfo.Columns[0].Name.L == colName.L &&
+		!ctx.GetSessionVars().InRestrictedSQL && ctx.GetSessionVars().PrimaryKeyRequired {
+		return false, infoschema.ErrTableWithoutPrimaryKey
+	}
 	if mysql.HasAutoIncrementFlag(col.GetFlag()) && !ctx.GetSessionVars().AllowRemoveAutoInc {
 		return false, dbterror.ErrCantDropColWithAutoInc
 	}
@@ -4962,6 +5565,11 @@ func checkModifyTypes(origin *types.FieldType, to *types.FieldType, needRewriteC
 	err = checkModifyCharsetAndCollation(to.GetCharset(), to.GetCollate(), origin.GetCharset(), origin.GetCollate(), needRewriteCollationData)
 
 	if err != nil {
+		// column type change transcodes the values and rebuilds the indexes with the new collation in the process of the reorg.
+		if (dbterror.ErrUnsupportedModifyCharset.Equal(err) || dbterror.ErrUnsupportedModifyCollation.Equal(err)) &&
+			canTranscodeCharset(origin.GetCharset(), to.GetCharset()) && !mysql.HasPriKeyFlag(origin.GetFlag()) {
+			return nil
+		}
 		if to.GetCharset() == charset.CharsetGBK || origin.GetCharset() == charset.CharsetGBK {
 			return errors.Trace(err)
 		}
@@ -5278,7 +5886,7 @@ func GetModifiableColumnJob(
 		}
 		return nil, errors.Trace(err)
 	}
-	needChangeColData := needChangeColumnData(col.ColumnInfo, newCol.ColumnInfo)
+	needChangeColData := needChangeColumnData(t.Meta(), col.ColumnInfo, newCol.ColumnInfo)
 	if needChangeColData {
 		if err = isGeneratedRelatedColumn(t.Meta(), newCol.ColumnInfo, col.ColumnInfo); err != nil {
 			return nil, errors.Trace(err)
@@ -5639,6 +6247,11 @@ func (d *ddl) ChangeColumn(ctx context.Context, sctx sessionctx.Context, ident a
 		}
 		return errors.Trace(err)
 	}
+	if sctx.GetSessionVars().DDLCharsetConversionDryRun {
+		if isDryRun, err := d.dryRunModifyColumnCharset(sctx, ident, job); isDryRun {
+			return errors.Trace(err)
+		}
+	}
 
 	err = d.DoDDLJob(sctx, job)
 	// column not exists, but if_exists flags is true, so we ignore this error.
@@ -5735,7 +6348,12 @@ func (d *ddl) ModifyColumn(ctx context.Context, sctx sessionctx.Context, ident a
 			sctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrColumnNotExists.GenWithStackByArgs(originalColName, ident.Name))
 			return nil
 		}
-		return errors.Trace(err)
+		return errors.Trace(err)
+	}
+	if sctx.GetSessionVars().DDLCharsetConversionDryRun {
+		if isDryRun, err := d.dryRunModifyColumnCharset(sctx, ident, job); isDryRun {
+			return errors.Trace(err)
+		}
 	}
 
 	err = d.DoDDLJob(sctx, job)
@@ -5910,11 +6528,151 @@ func (d *ddl) AlterTableCharsetAndCollate(ctx sessionctx.Context, ident ast.Iden
 		BinlogInfo: &model.HistoryInfo{},
 		Args:       []interface{}{toCharset, toCollate, needsOverwriteCols},
 	}
+	if needsOverwriteCols {
+		var modifyColumnJobs []*model.Job
+		modifyColumnJobs, err = buildTranscodeColumnJobs(ctx, is, schema, tb, toCharset, toCollate)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if ctx.GetSessionVars().DDLCharsetConversionDryRun {
+			return errors.Trace(dryRunCharsetConversion(ctx, tb, modifyColumnJobs))
+		}
+		if len(modifyColumnJobs) > 0 {
+			return d.convertTableCharsetWithData(ctx, ident, job, modifyColumnJobs)
+		}
+	}
 	err = d.DoDDLJob(ctx, job)
 	err = d.callHookOnChanged(job, err)
 	return errors.Trace(err)
 }
 
+// buildTranscodeColumnJobs builds the column type change jobs for the columns whose data need to be
+// converted when converting the table to the charset.
+func buildTranscodeColumnJobs(sctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo, t table.Table,
+	toCharset, toCollate string) ([]*model.Job, error) {
+	tblInfo := t.Meta()
+	var jobs []*model.Job
+	for _, col := range tblInfo.Columns {
+		if col.Hidden || !field_types.HasCharset(&col.FieldType) {
+			continue
+		}
+		newCol := col.Clone()
+		newCol.SetCharset(toCharset)
+		newCol.SetCollate(toCollate)
+		if !needChangeColumnData(tblInfo, col, newCol) {
+			continue
+		}
+		if tblInfo.Partition != nil {
+			return nil, dbterror.ErrUnsupportedModifyColumn.GenWithStackByArgs("table is partition table")
+		}
+		if err := isGeneratedRelatedColumn(tblInfo, newCol, col); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if err := checkColumnWithIndexConstraint(tblInfo, col, newCol); err != nil {
+			return nil, err
+		}
+		if err := checkModifyColumnWithForeignKeyConstraint(is, schema.Name.L, tblInfo, col, newCol); err != nil {
+			return nil, err
+		}
+		tzName, tzOffset := ddlutil.GetTimeZone(sctx)
+		jobs = append(jobs, &model.Job{
+			SchemaID:   schema.ID,
+			TableID:    tblInfo.ID,
+			SchemaName: schema.Name.L,
+			TableName:  tblInfo.Name.L,
+			Type:       model.ActionModifyColumn,
+			BinlogInfo: &model.HistoryInfo{},
+			ReorgMeta: &model.DDLReorgMeta{
+				SQLMode:       sctx.GetSessionVars().SQLMode,
+				Warnings:      make(map[errors.ErrorID]*terror.Error),
+				WarningsCount: make(map[errors.ErrorID]int64),
+				Location:      &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
+			},
+			CtxVars: []interface{}{true},
+			Args:    []interface{}{&newCol, col.Name, &ast.ColumnPosition{}, mysql.TypeUnspecified, uint64(0)},
+		})
+	}
+	return jobs, nil
+}
+
+// convertTableCharsetWithData converts the table to the charset with a multi-schema change. The column type
+// change jobs convert the data of the columns first, and then the table charset job changes the rest columns.
+func (d *ddl) convertTableCharsetWithData(ctx sessionctx.Context, ident ast.Ident, job *model.Job, modifyColumnJobs []*model.Job) error {
+	sessVars := ctx.GetSessionVars()
+	if sessVars.EnableRowLevelChecksum || variable.EnableRowLevelChecksum.Load() {
+		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when row level checksum is enabled")
+	}
+	standalone := sessVars.StmtCtx.MultiSchemaInfo == nil
+	if standalone {
+		sessVars.StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+	}
+	for _, modifyColumnJob := range append(modifyColumnJobs, job) {
+		if err := d.DoDDLJob(ctx, modifyColumnJob); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !standalone {
+		return nil
+	}
+	return errors.Trace(d.MultiSchemaChange(ctx, ident))
+}
+
+// dryRunModifyColumnCharset checks the data for the column type change job instead of running it,
+// if the job converts the charset of the column. It returns false if the job doesn't convert the charset.
+func (d *ddl) dryRunModifyColumnCharset(sctx sessionctx.Context, ident ast.Ident, job *model.Job) (bool, error) {
+	t, err := d.infoCache.GetLatest().TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return false, errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+	newCol := *job.Args[0].(**model.ColumnInfo)
+	oldCol := table.FindCol(t.Cols(), job.Args[1].(model.CIStr).L)
+	if oldCol == nil || !needTranscodeColumnData(t.Meta(), oldCol.ColumnInfo, newCol) {
+		return false, nil
+	}
+	return true, dryRunCharsetConversion(sctx, t, []*model.Job{job})
+}
+
+// dryRunCharsetConversion scans the table and reports the rows whose values can't be converted to the new
+// charsets of the column type change jobs as warnings.
+func dryRunCharsetConversion(sctx sessionctx.Context, t table.Table, modifyColumnJobs []*model.Job) error {
+	sc := sctx.GetSessionVars().StmtCtx
+	if sc.MultiSchemaInfo != nil {
+		return dbterror.ErrRunMultiSchemaChanges.GenWithStack("Unsupported multi schema change when %s is enabled", variable.TiDBDDLCharsetConversionDryRun)
+	}
+	if len(modifyColumnJobs) == 0 {
+		return nil
+	}
+	oldCols := make([]*table.Column, 0, len(modifyColumnJobs))
+	newCols := make([]*model.ColumnInfo, 0, len(modifyColumnJobs))
+	for _, job := range modifyColumnJobs {
+		oldCols = append(oldCols, table.FindCol(t.Cols(), job.Args[1].(model.CIStr).L))
+		newCols = append(newCols, *job.Args[0].(**model.ColumnInfo))
+	}
+	return tables.IterRecords(t, sctx, t.Cols(), func(h kv.Handle, rec []types.Datum, _ []*table.Column) (bool, error) {
+		for i, oldCol := range oldCols {
+			_, err := rec[oldCol.Offset].ConvertTo(sc, &newCols[i].FieldType)
+			if charset.ErrInvalidCharacterString.Equal(err) {
+				sc.AppendWarning(genCharsetConversionWarning(err, oldCol.Name, h))
+			}
+		}
+		return true, nil
+	})
+}
+
+// genCharsetConversionWarning generates the warning for the value which can't be converted to the new charset.
+func genCharsetConversionWarning(err error, colName model.CIStr, h kv.Handle) error {
+	var invalidStr strings.Builder
+	if inErr, ok := errors.Cause(err).(*terror.Error); ok && len(inErr.Args()) == 2 {
+		invalidStrHex, _ := inErr.Args()[1].(string)
+		for i := 0; i+1 < len(invalidStrHex); i += 2 {
+			invalidStr.WriteString("\\x")
+			invalidStr.WriteString(invalidStrHex[i : i+2])
+		}
+	}
+	return table.ErrTruncatedWrongValueForField.FastGen("Incorrect string value '%s' for column '%s' at row %s",
+		invalidStr.String(), colName.O, h.String())
+}
+
 func shouldModifyTiFlashReplica(tbReplicaInfo *model.TiFlashReplicaInfo, replicaInfo *ast.TiFlashReplicaSpec) bool {
 	if tbReplicaInfo != nil && tbReplicaInfo.Count == replicaInfo.Count &&
 		len(tbReplicaInfo.LocationLabels) == len(replicaInfo.Labels) {
@@ -6111,18 +6869,11 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if !ctx.GetSessionVars().EnableExtendedStats {
 		return errors.New("Extended statistics feature is not generally available now, and tidb_enable_extended_stats is OFF")
 	}
-	// Not support Cardinality and Dependency statistics type for now.
-	if stats.StatsType == ast.StatsTypeCardinality || stats.StatsType == ast.StatsTypeDependency {
-		return errors.New("Cardinality and Dependency statistics types are not supported now")
-	}
 	_, tbl, err := d.getSchemaAndTableByIdent(ctx, ident)
 	if err != nil {
 		return err
 	}
 	tblInfo := tbl.Meta()
-	if tblInfo.GetPartitionInfo() != nil {
-		return errors.New("Extended statistics on partitioned tables are not supported now")
-	}
 	colIDs := make([]int64, 0, 2)
 	colIDSet := make(map[int64]struct{}, 2)
 	// Check whether columns exist.
@@ -6144,7 +6895,7 @@ func (d *ddl) AlterTableAddStatistics(ctx sessionctx.Context, ident ast.Ident, s
 	if len(colIDs) != 2 && (stats.StatsType == ast.StatsTypeCorrelation || stats.StatsType == ast.StatsTypeDependency) {
 		return errors.New("Only support Correlation and Dependency statistics types on 2 columns")
 	}
-	if len(colIDs) < 1 && stats.StatsType == ast.StatsTypeCardinality {
+	if len(colIDs) < 2 && stats.StatsType == ast.StatsTypeCardinality {
 		return errors.New("Only support Cardinality statistics type on at least 2 columns")
 	}
 	// TODO: check whether covering index exists for cardinality / dependency types.
@@ -6166,7 +6917,18 @@ func (d *ddl) AlterTableDropStatistics(ctx sessionctx.Context, ident ast.Ident,
 	tblInfo := tbl.Meta()
 	// Call utilities of statistics.Handle to modify system tables instead of doing DML directly,
 	// because locking in Handle can guarantee the correctness of `version` in system tables.
-	return d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists)
+	if err = d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, tblInfo.ID, ifExists); err != nil {
+		return err
+	}
+	// The partition-level stats are collected by the definition on the partitioned table.
+	if pi := tblInfo.GetPartitionInfo(); pi != nil {
+		for _, def := range pi.Definitions {
+			if err = d.ddlCtx.statsHandle.MarkExtendedStatsDeleted(stats.StatsName, def.ID, true); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 // UpdateTableReplicaInfo updates the table flash replica infos.
@@ -6240,7 +7002,10 @@ func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCh
 	}
 
 	if err = checkModifyCharsetAndCollation(toCharset, toCollate, origCharset, origCollate, false); err != nil {
-		return doNothing, err
+		// The columns are converted by the column type change, so the default charset of the table can be changed together.
+		if !needsOverwriteCols || !dbterror.ErrUnsupportedModifyCharset.Equal(err) || !canTranscodeCharset(origCharset, toCharset) {
+			return doNothing, err
+		}
 	}
 	if !needsOverwriteCols {
 		// If we don't change the charset and collation of columns, skip the next checks.
@@ -6260,6 +7025,12 @@ func checkAlterTableCharset(tblInfo *model.TableInfo, dbInfo *model.DBInfo, toCh
 			continue
 		}
 		if err = checkModifyCharsetAndCollation(toCharset, toCollate, col.GetCharset(), col.GetCollate(), isColumnWithIndex(col.Name.L, tblInfo.Indices)); err != nil {
+			if dbterror.ErrUnsupportedModifyCharset.Equal(err) && canTranscodeCharset(col.GetCharset(), toCharset) &&
+				!mysql.HasPriKeyFlag(col.GetFlag()) {
+				// The column is converted by the column type change.
+				err = nil
+				continue
+			}
 			if strings.Contains(err.Error(), "Unsupported modifying collation") {
 				colErrMsg := "Unsupported converting collation of column '%s' from '%s' to '%s' when index is defined on it."
 				err = dbterror.ErrUnsupportedModifyCollation.GenWithStack(colErrMsg, col.Name.L, col.GetCollate(), toCollate)
@@ -6334,6 +7105,7 @@ const (
 	tableObject objectType = iota
 	viewObject
 	sequenceObject
+	materializedViewObject
 )
 
 // dropTableObject provides common logic to DROP TABLE/VIEW/SEQUENCE.
@@ -6370,6 +7142,14 @@ func (d *ddl) dropTableObject(
 	case viewObject:
 		dropExistErr = infoschema.ErrTableDropExists
 		jobType = model.ActionDropView
+	case materializedViewObject:
+		dropExistErr = infoschema.ErrTableDropExists
+		jobType = model.ActionDropTable
+		objectIdents := make([]ast.Ident, len(objects))
+		for i, tn := range objects {
+			objectIdents[i] = ast.Ident{Schema: tn.Schema, Name: tn.Name}
+		}
+		jobArgs = []interface{}{objectIdents, ctx.GetSessionVars().ForeignKeyChecks}
 	case sequenceObject:
 		dropExistErr = infoschema.ErrSequenceDropExists
 		jobType = model.ActionDropSequence
@@ -6404,6 +7184,9 @@ func (d *ddl) dropTableObject(
 				notExistTables = append(notExistTables, fullti.String())
 				continue
 			}
+			if tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "BASE TABLE")
+			}
 
 			tempTableType := tableInfo.Meta().TempTableType
 			if config.CheckTableBeforeDrop && tempTableType == model.TempTableNone {
@@ -6426,6 +7209,10 @@ func (d *ddl) dropTableObject(
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
 			}
+		case materializedViewObject:
+			if !tableInfo.Meta().IsMaterializedView() {
+				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "MATERIALIZED VIEW")
+			}
 		case sequenceObject:
 			if !tableInfo.Meta().IsSequence() {
 				err = dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "SEQUENCE")
@@ -6482,6 +7269,9 @@ func (d *ddl) dropTableObject(
 
 // DropTable will proceed even if some table in the list does not exists.
 func (d *ddl) DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
+	if stmt.IsMaterializedView {
+		return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, materializedViewObject)
+	}
 	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, tableObject)
 }
 
@@ -6498,6 +7288,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().IsView() || tb.Meta().IsSequence() {
 		return infoschema.ErrTableNotExists.GenWithStackByArgs(schema.Name.O, tb.Meta().Name.O)
 	}
+	if tb.Meta().IsMaterializedView() {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema.Name, tb.Meta().Name, "BASE TABLE")
+	}
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
@@ -6765,10 +7558,6 @@ func GetName4AnonymousIndex(t table.Table, colName model.CIStr, idxName model.CI
 
 func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) error {
-	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
-		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key is not supported. " +
-			"Please consider adding NONCLUSTERED primary key instead")
-	}
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
@@ -6784,6 +7573,10 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		t.Meta().PKIsHandle {
 		return infoschema.ErrMultiplePriKey
 	}
+	// A clustered primary key alone is added by RebuildTable.
+	if indexOption != nil && indexOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
+		return dbterror.ErrUnsupportedModifyPrimaryKey.GenWithStack("Adding clustered primary key together with other changes is not supported")
+	}
 
 	// Primary keys cannot include expression index parts. A primary key requires the generated column to be stored,
 	// but expression index parts are implemented as virtual generated columns, not stored generated columns.
@@ -6800,7 +7593,7 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, tblInfo.Columns, indexPartSpecifications)
+	indexColumns, _, err := buildIndexColumns(ctx, tblInfo.Columns, indexPartSpecifications, false)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -6859,6 +7652,7 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 func precheckBuildHiddenColumnInfo(
 	indexPartSpecifications []*ast.IndexPartSpecification,
 	indexName model.CIStr,
+	indexOption *ast.IndexOption,
 ) error {
 	for i, idxPart := range indexPartSpecifications {
 		if idxPart.Expr == nil {
@@ -6869,6 +7663,10 @@ func precheckBuildHiddenColumnInfo(
 			// TODO: Refine the error message.
 			return dbterror.ErrTooLongIdent.GenWithStackByArgs("hidden column")
 		}
+		if isFullTextIndex(indexOption) {
+			// The expression of a FULLTEXT index is built from the checked text columns rather than given by users.
+			continue
+		}
 		// TODO: Refine the error message.
 		if err := checkIllegalFn4Generated(indexName.L, typeIndex, idxPart.Expr); err != nil {
 			return errors.Trace(err)
@@ -6877,8 +7675,8 @@ func precheckBuildHiddenColumnInfo(
 	return nil
 }
 
-func buildHiddenColumnInfoWithCheck(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
-	if err := precheckBuildHiddenColumnInfo(indexPartSpecifications, indexName); err != nil {
+func buildHiddenColumnInfoWithCheck(ctx sessionctx.Context, indexPartSpecifications []*ast.IndexPartSpecification, indexName model.CIStr, indexOption *ast.IndexOption, tblInfo *model.TableInfo, existCols []*table.Column) ([]*model.ColumnInfo, error) {
+	if err := precheckBuildHiddenColumnInfo(indexPartSpecifications, indexName, indexOption); err != nil {
 		return nil, err
 	}
 	return BuildHiddenColumnInfo(ctx, indexPartSpecifications, indexName, tblInfo, existCols)
@@ -6983,17 +7781,94 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 	return nil
 }
 
+// BuildSpatialIndexOption checks the key parts of a SPATIAL index are geometry columns, and returns the index
+// option with the RTREE index type, which makes the index SPATIAL.
+func BuildSpatialIndexOption(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption) (*ast.IndexOption, error) {
+	for _, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, dbterror.ErrSpatialFunctionalIndex
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if col.GetType() != mysql.TypeGeometry {
+			return nil, dbterror.ErrSpatialMustHaveGeomCol
+		}
+	}
+	option := &ast.IndexOption{}
+	if indexOption != nil {
+		*option = *indexOption
+	}
+	option.Tp = model.IndexTypeRtree
+	return option, nil
+}
+
+// BuildFullTextIndexOption checks the key parts of a FULLTEXT index are text columns with the same collation, and
+// returns the key part concatenating the columns, which is indexed by a hidden column, and the index option with the
+// FULLTEXT index type.
+func BuildFullTextIndexOption(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification,
+	indexOption *ast.IndexOption) ([]*ast.IndexPartSpecification, *ast.IndexOption, error) {
+	args := make([]ast.ExprNode, 0, len(indexPartSpecifications)+1)
+	args = append(args, ast.NewValueExpr(" ", "", ""))
+	var collation string
+	for i, ip := range indexPartSpecifications {
+		if ip.Expr != nil {
+			return nil, nil, errors.Trace(dbterror.ErrFulltextFunctionalIndex)
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if !types.IsNonBinaryStr(&col.FieldType) {
+			return nil, nil, dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+		}
+		if i == 0 {
+			collation = col.GetCollate()
+		} else if col.GetCollate() != collation {
+			return nil, nil, dbterror.ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+		}
+		if ip.Length != types.UnspecifiedLength {
+			return nil, nil, errors.Trace(dbterror.ErrIncorrectPrefixKey)
+		}
+		args = append(args, &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: col.Name}})
+	}
+	option := &ast.IndexOption{}
+	if indexOption != nil {
+		*option = *indexOption
+	}
+	if !fulltext.IsSupportedParser(option.ParserName.L) {
+		return nil, nil, dbterror.ErrPluginIsNotLoaded.GenWithStackByArgs(option.ParserName.O)
+	}
+	option.Tp = model.IndexTypeFulltext
+	return []*ast.IndexPartSpecification{{
+		Expr:   &ast.FuncCallExpr{FnName: model.NewCIStr(ast.ConcatWS), Args: args},
+		Length: types.UnspecifiedLength,
+	}}, option, nil
+}
+
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if keyType == ast.IndexKeyTypeSpatial {
+		if indexOption, err = BuildSpatialIndexOption(t.Meta().Columns, indexPartSpecifications, indexOption); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if keyType == ast.IndexKeyTypeFullText {
+		// Deal with anonymous index by the first column, before the columns are replaced by the expression.
+		if len(indexName.L) == 0 {
+			indexName = GetName4AnonymousIndex(t, indexPartSpecifications[0].Column.Name, model.NewCIStr(""))
+		}
+		if indexPartSpecifications, indexOption, err = BuildFullTextIndexOption(t.Meta().Columns, indexPartSpecifications, indexOption); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
@@ -7030,7 +7905,7 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	tblInfo := t.Meta()
 
 	// Build hidden columns if necessary.
-	hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, indexPartSpecifications, indexName, t.Meta(), t.Cols())
+	hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, indexPartSpecifications, indexName, indexOption, t.Meta(), t.Cols())
 	if err != nil {
 		return err
 	}
@@ -7047,7 +7922,13 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	if isFullTextIndex(indexOption) {
+		indexColumns, err = buildFullTextIndexColumns(finalColumns, indexPartSpecifications)
+	} else {
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications,
+			isSpatialIndex(false, unique, indexOption))
+	}
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -7426,12 +8307,7 @@ func isDroppableColumn(tblInfo *model.TableInfo, colName model.CIStr) error {
 		return dbterror.ErrCantRemoveAllFields.GenWithStack("can't drop only column %s in table %s",
 			colName, tblInfo.Name)
 	}
-	// We only support dropping column with single-value none Primary Key index covered now.
-	err := isColumnCanDropWithIndex(colName.L, tblInfo.Indices)
-	if err != nil {
-		return err
-	}
-	err = IsColumnDroppableWithCheckConstraint(colName, tblInfo)
+	err := IsColumnDroppableWithCheckConstraint(colName, tblInfo)
 	if err != nil {
 		return err
 	}
@@ -7540,6 +8416,67 @@ func buildAddedPartitionDefs(ctx sessionctx.Context, meta *model.TableInfo, spec
 	return GeneratePartDefsFromInterval(ctx, spec.Tp, meta, spec.Partition)
 }
 
+// buildReorganizeIntervalPartitionDefs sets the partitions to reorganize and the new partition definitions
+// for MERGE FIRST PARTITION and SPLIT MAXVALUE PARTITION of an INTERVAL partitioned table.
+//   - MERGE FIRST PARTITION LESS THAN (expr): merges the partitions from the first one up to the one ending with expr
+//   - SPLIT MAXVALUE PARTITION LESS THAN (expr): adds the INTERVAL partitions up to expr before the MAXVALUE partition
+func buildReorganizeIntervalPartitionDefs(ctx sessionctx.Context, meta *model.TableInfo, spec *ast.AlterTableSpec) error {
+	syntax := "MERGE FIRST PARTITION"
+	if spec.Tp == ast.AlterTableReorganizeLastPartition {
+		syntax = "SPLIT MAXVALUE PARTITION"
+	}
+	partInterval := getPartitionIntervalFromTable(ctx, meta)
+	if partInterval == nil {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+			syntax + ", does not seem like an INTERVAL partitioned table")
+	}
+	if len(spec.PartitionNames) > 0 || len(spec.PartDefinitions) > 0 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+			syntax + ", partition names or definitions already given")
+	}
+	if spec.Tp == ast.AlterTableReorganizeLastPartition && !partInterval.MaxValPart {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(syntax + " without a MAXVALUE partition")
+	}
+	spec.Partition.Interval = partInterval
+	if err := GeneratePartDefsFromInterval(ctx, spec.Tp, meta, spec.Partition); err != nil {
+		return err
+	}
+	pi := meta.Partition
+	defs := spec.Partition.Definitions
+	if spec.Tp == ast.AlterTableReorganizeFirstPartition {
+		pNullOffset := 0
+		if partInterval.NullPart {
+			pNullOffset = 1
+		}
+		if len(defs) <= 1 || len(defs) >= len(pi.Definitions)-pNullOffset {
+			return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+				syntax + ", given value does not generate a list of partitions to be merged")
+		}
+		for i := range defs {
+			spec.PartitionNames = append(spec.PartitionNames, pi.Definitions[i+pNullOffset].Name)
+		}
+		// Keep the name of the last merged partition, since its range end is not changed.
+		spec.PartDefinitions = []*ast.PartitionDefinition{{
+			Name:   spec.PartitionNames[len(spec.PartitionNames)-1],
+			Clause: defs[len(defs)-1].Clause,
+		}}
+		return nil
+	}
+	if len(defs) == 0 {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(
+			syntax + ", given value does not generate any new partitions")
+	}
+	maxValPart := pi.Definitions[len(pi.Definitions)-1]
+	spec.PartitionNames = []model.CIStr{maxValPart.Name}
+	spec.PartDefinitions = append(defs, &ast.PartitionDefinition{
+		Name: maxValPart.Name,
+		Clause: &ast.PartitionDefinitionClauseLessThan{
+			Exprs: []ast.ExprNode{&ast.MaxValueExpr{}},
+		},
+	})
+	return nil
+}
+
 func checkAndGetColumnsTypeAndValuesMatch(ctx sessionctx.Context, colTypes []types.FieldType, exprs []ast.ExprNode) ([]string, error) {
 	// Validate() has already checked len(colNames) = len(exprs)
 	// create table ... partition by range columns (cols)
@@ -8734,3 +9671,192 @@ func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrN
 	err = d.callHookOnChanged(job, err)
 	return errors.Trace(err)
 }
+
+// CreateProcedure creates a stored procedure.
+func (d *ddl) CreateProcedure(ctx sessionctx.Context, stmt *ast.ProcedureInfo) error {
+	ident := ast.Ident{Schema: stmt.ProcedureName.Schema, Name: stmt.ProcedureName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	if _, ok := is.ProcedureByName(ident.Schema, ident.Name); ok {
+		err := infoschema.ErrProcedureExists.GenWithStackByArgs("PROCEDURE", ident.Name.O)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	vars := ctx.GetSessionVars()
+	definer := &auth.UserIdentity{}
+	if vars.User != nil {
+		definer.Username, definer.Hostname = vars.User.AuthUsername, vars.User.AuthHostname
+	}
+	sqlMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	charset, collation := vars.GetCharsetInfo()
+	procInfo := &model.ProcedureInfo{
+		Name:                ident.Name,
+		Definer:             definer,
+		SQLMode:             sqlMode,
+		CharacterSetClient:  charset,
+		CollationConnection: collation,
+		DBCollation:         schema.Collate,
+		Definition:          stmt.Text(),
+		Created:             time.Now(),
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	procInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    procInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  procInfo.Name.L,
+		Type:       model.ActionCreateProcedure,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{procInfo},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropProcedure drops a stored procedure.
+func (d *ddl) DropProcedure(ctx sessionctx.Context, stmt *ast.DropProcedureStmt) error {
+	ident := ast.Ident{Schema: stmt.ProcedureName.Schema, Name: stmt.ProcedureName.Name}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ident.Schema)
+	}
+	procInfo, ok := is.ProcedureByName(ident.Schema, ident.Name)
+	if !ok {
+		err := infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", ident.String())
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    procInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  procInfo.Name.L,
+		Type:       model.ActionDropProcedure,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{procInfo.Name},
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// CreateTrigger creates a trigger on a table.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	if stmt.TriggerName.Schema.L != stmt.Table.Schema.L {
+		return dbterror.ErrTrgInWrongSchema
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Table.Schema)
+	}
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return dbterror.ErrNoTriggersOnSystemSchema
+	}
+	tbl, err := is.TableByName(stmt.Table.Schema, stmt.Table.Name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(stmt.Table.Name.O)
+	}
+	if _, _, ok := is.TriggerByName(schema.Name, stmt.TriggerName.Name); ok {
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTriggerExists)
+			return nil
+		}
+		return infoschema.ErrTriggerExists
+	}
+
+	vars := ctx.GetSessionVars()
+	definer := &auth.UserIdentity{}
+	if vars.User != nil {
+		definer.Username, definer.Hostname = vars.User.AuthUsername, vars.User.AuthHostname
+	}
+	sqlMode, _ := vars.GetSystemVar(variable.SQLModeVar)
+	charset, collation := vars.GetCharsetInfo()
+	triggerInfo := &model.TriggerInfo{
+		Name:                stmt.TriggerName.Name,
+		Timing:              stmt.Timing,
+		Event:               stmt.Event,
+		Definer:             definer,
+		SQLMode:             sqlMode,
+		CharacterSetClient:  charset,
+		CollationConnection: collation,
+		DBCollation:         schema.Collate,
+		Definition:          stmt.Text(),
+		Statement:           stmt.Body.Text(),
+		Created:             time.Now(),
+	}
+	// Check the FOLLOWS or PRECEDES clause before running the job.
+	if _, err = triggerInsertOffset(tblInfo, triggerInfo, stmt.OrderType, stmt.OrderTrigger); err != nil {
+		return err
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	triggerInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionCreateTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{triggerInfo, stmt.OrderType, stmt.OrderTrigger},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a trigger.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.TriggerName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.TriggerName.Schema)
+	}
+	triggerInfo, tblInfo, ok := is.TriggerByName(schema.Name, stmt.TriggerName.Name)
+	if !ok {
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(infoschema.ErrTriggerNotExists)
+			return nil
+		}
+		return infoschema.ErrTriggerNotExists
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionDropTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{triggerInfo.Name},
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
@@ -151,11 +151,14 @@ func TestModifyColumn(t *testing.T) {
 		{"decimal(2,1)", "bigint", nil},
 		{"int", "varchar(10) character set gbk", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from binary to gbk")},
 		{"varchar(10) character set gbk", "int", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from gbk to binary")},
-		{"varchar(10) character set gbk", "varchar(10) character set utf8", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from gbk to utf8")},
-		{"varchar(10) character set gbk", "char(10) character set utf8", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from gbk to utf8")},
-		{"varchar(10) character set utf8", "char(10) character set gbk", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from utf8 to gbk")},
-		{"varchar(10) character set utf8", "varchar(10) character set gbk", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from utf8 to gbk")},
+		{"varchar(10) character set gbk", "varchar(10) character set utf8", nil},
+		{"varchar(10) character set gbk", "char(10) character set utf8", nil},
+		{"varchar(10) character set utf8", "char(10) character set gbk", nil},
+		{"varchar(10) character set utf8", "varchar(10) character set gbk", nil},
 		{"varchar(10) character set gbk", "varchar(255) character set gbk", nil},
+		{"varchar(10) character set latin1", "varchar(10) character set gbk", nil},
+		{"varchar(10) character set utf8mb4", "varchar(10) character set ascii", nil},
+		{"varchar(10) character set gbk", "varchar(10) character set binary", dbterror.ErrUnsupportedModifyCharset.GenWithStackByArgs("charset from gbk to binary")},
 	}
 	for _, tt := range tests {
 		ftA := colDefStrToFieldType(t, tt.origin, ctx)
@@ -545,8 +545,12 @@ func jobNeedGC(job *model.Job) bool {
 			model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 			model.ActionDropColumn, model.ActionModifyColumn,
 			model.ActionAddIndex, model.ActionAddPrimaryKey,
-			model.ActionReorganizePartition:
+			model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+			model.ActionRemovePartitioning, model.ActionRebuildTable:
 			return true
+		case model.ActionAddColumn:
+			// The key added together with the column is rolled back.
+			return job.State == model.JobStateRollbackDone
 		case model.ActionMultiSchemaChange:
 			for _, sub := range job.MultiSchemaInfo.SubJobs {
 				proxyJob := sub.ToProxyJob(job)
@@ -682,10 +686,13 @@ func (w *worker) unlockSeqNum(err error) {
 
 // DDLBackfillers contains the DDL need backfill step.
 var DDLBackfillers = map[model.ActionType]string{
-	model.ActionAddIndex:            "add_index",
-	model.ActionModifyColumn:        "modify_column",
-	model.ActionDropIndex:           "drop_index",
-	model.ActionReorganizePartition: "reorganize_partition",
+	model.ActionAddIndex:               "add_index",
+	model.ActionModifyColumn:           "modify_column",
+	model.ActionDropIndex:              "drop_index",
+	model.ActionReorganizePartition:    "reorganize_partition",
+	model.ActionAlterTablePartitioning: "alter_table_partitioning",
+	model.ActionRemovePartitioning:     "remove_partitioning",
+	model.ActionRebuildTable:           "rebuild_table",
 }
 
 func getDDLRequestSource(jobType model.ActionType) string {
@@ -1041,7 +1048,7 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 	case model.ActionExchangeTablePartition:
 		ver, err = w.onExchangeTablePartition(d, t, job)
 	case model.ActionAddColumn:
-		ver, err = onAddColumn(d, t, job)
+		ver, err = w.onAddColumn(d, t, job)
 	case model.ActionDropColumn:
 		ver, err = onDropColumn(d, t, job)
 	case model.ActionModifyColumn:
@@ -1116,6 +1123,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onAlterResourceGroup(d, t, job)
 	case model.ActionDropResourceGroup:
 		ver, err = onDropResourceGroup(d, t, job)
+	case model.ActionCreateProcedure:
+		ver, err = onCreateProcedure(d, t, job)
+	case model.ActionDropProcedure:
+		ver, err = onDropProcedure(d, t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(d, t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(d, t, job)
 	case model.ActionAlterCacheTable:
 		ver, err = onAlterCacheTable(d, t, job)
 	case model.ActionAlterNoCacheTable:
@@ -1124,8 +1139,11 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = w.onFlashbackCluster(d, t, job)
 	case model.ActionMultiSchemaChange:
 		ver, err = onMultiSchemaChange(w, d, t, job)
-	case model.ActionReorganizePartition:
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
 		ver, err = w.onReorganizePartition(d, t, job)
+	case model.ActionRebuildTable:
+		ver, err = w.onRebuildTable(d, t, job)
 	case model.ActionAlterTTLInfo:
 		ver, err = onTTLInfoChange(d, t, job)
 	case model.ActionAlterTTLRemove:
@@ -1316,6 +1334,13 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 				OldTableID:  tableInfos[i].ID,
 			}
 		}
+	case model.ActionRebuildTable:
+		diff.TableID = job.TableID
+		diff.OldTableID = job.TableID
+		if len(job.CtxVars) > 0 {
+			// The rebuilt table replaces the original table, which has the old table ID.
+			diff.OldTableID = job.CtxVars[0].(int64)
+		}
 	case model.ActionTruncateTable:
 		// Truncate table has two table ID, should be handled differently.
 		err = job.DecodeArgs(&diff.TableID)
@@ -1405,8 +1430,16 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 				diff.AffectedOpts = buildPlacementAffects(oldIDs, oldIDs)
 			}
 		}
-	case model.ActionReorganizePartition:
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
 		diff.TableID = job.TableID
+		if job.Type != model.ActionReorganizePartition {
+			// The table ID changes when the partitioning change is done.
+			diff.OldTableID = job.TableID
+			if len(job.CtxVars) > 2 {
+				diff.TableID = job.CtxVars[2].(int64)
+			}
+		}
 		if len(job.CtxVars) > 0 {
 			if droppedIDs, ok := job.CtxVars[0].([]int64); ok {
 				if addedIDs, ok := job.CtxVars[1].([]int64); ok {
@@ -313,7 +313,8 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 		endKey := tablecodec.EncodeTablePrefix(tableID + 1)
 		elemID := ea.allocForPhysicalID(tableID)
 		return doInsert(ctx, s, job.ID, elemID, startKey, endKey, now, fmt.Sprintf("table ID is %d", tableID))
-	case model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition:
+	case model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		var physicalTableIDs []int64
 		// partInfo is not used, but is set in ReorgPartition.
 		// Better to have an additional argument in job.DecodeArgs since it is ignored,
@@ -330,6 +331,27 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				return errors.Trace(err)
 			}
 		}
+	case model.ActionRebuildTable:
+		var physicalTableIDs []int64
+		var oldTableID, mappingIndexID int64
+		if err := job.DecodeArgs(&physicalTableIDs, &oldTableID, &mappingIndexID); err != nil {
+			return errors.Trace(err)
+		}
+		for _, physicalTableID := range physicalTableIDs {
+			startKey := tablecodec.EncodeTablePrefix(physicalTableID)
+			endKey := tablecodec.EncodeTablePrefix(physicalTableID + 1)
+			elemID := ea.allocForPhysicalID(physicalTableID)
+			if err := doInsert(ctx, s, job.ID, elemID, startKey, endKey, now, fmt.Sprintf("table ID is %d", physicalTableID)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		// The mapping index is left in the original table when the rebuild is rolled back.
+		if mappingIndexID != 0 {
+			startKey := tablecodec.EncodeTableIndexPrefix(oldTableID, mappingIndexID)
+			endKey := tablecodec.EncodeTableIndexPrefix(oldTableID, mappingIndexID+1)
+			elemID := ea.allocForIndexID(oldTableID, mappingIndexID)
+			return doInsert(ctx, s, job.ID, elemID, startKey, endKey, now, fmt.Sprintf("index ID is %d", mappingIndexID))
+		}
 	// ActionAddIndex, ActionAddPrimaryKey needs do it, because it needs to be rolled back when it's canceled.
 	case model.ActionAddIndex, model.ActionAddPrimaryKey:
 		var indexID int64
@@ -409,6 +431,17 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				}
 			}
 		}
+	case model.ActionAddColumn:
+		// The key added together with the column is rolled back.
+		var colName model.CIStr
+		var ifExists bool
+		var indexIDs []int64
+		if err := job.DecodeArgs(&colName, &ifExists, &indexIDs); err != nil {
+			return errors.Trace(err)
+		}
+		if len(indexIDs) > 0 {
+			return doBatchDeleteIndiceRange(ctx, s, job.ID, job.TableID, indexIDs, now, ea)
+		}
 	case model.ActionModifyColumn:
 		var indexIDs []int64
 		var partitionIDs []int64
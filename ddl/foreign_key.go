@@ -480,6 +480,43 @@ func checkIndexNeededInForeignKey(is infoschema.InfoSchema, dbName string, tbInf
 	return nil
 }
 
+// checkPrimaryKeyNeededInForeignKey checks the foreign keys of the table are still covered by an index
+// after the primary key of the table is changed by a table rebuild. tbInfo is the table info after the change.
+func checkPrimaryKeyNeededInForeignKey(is infoschema.InfoSchema, dbName string, tbInfo *model.TableInfo) error {
+	referredFKs := is.GetTableReferredForeignKeys(dbName, tbInfo.Name.L)
+	if len(tbInfo.ForeignKeys) == 0 && len(referredFKs) == 0 {
+		return nil
+	}
+	checkFn := func(cols []model.CIStr) error {
+		if tbInfo.PKIsHandle && len(cols) == 1 {
+			refColInfo := model.FindColumnInfo(tbInfo.Columns, cols[0].L)
+			if refColInfo != nil && mysql.HasPriKeyFlag(refColInfo.GetFlag()) {
+				return nil
+			}
+		}
+		for _, index := range tbInfo.Indices {
+			if model.IsIndexPrefixCovered(tbInfo, index, cols...) {
+				return nil
+			}
+		}
+		return dbterror.ErrDropIndexNeededInForeignKey.GenWithStackByArgs(mysql.PrimaryKeyName)
+	}
+	for _, fk := range tbInfo.ForeignKeys {
+		if fk.Version < model.FKVersion1 {
+			continue
+		}
+		if err := checkFn(fk.Cols); err != nil {
+			return err
+		}
+	}
+	for _, referredFK := range referredFKs {
+		if err := checkFn(referredFK.Cols); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func checkIndexNeededInForeignKeyInOwner(d *ddlCtx, t *meta.Meta, job *model.Job, dbName string, tbInfo *model.TableInfo, idxInfo *model.IndexInfo) error {
 	if !variable.EnableForeignKey.Load() {
 		return nil
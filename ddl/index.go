@@ -54,6 +54,7 @@ import (
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/generatedexpr"
 	"github.com/pingcap/tidb/util/logutil"
 	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/prometheus/client_golang/prometheus"
@@ -73,7 +74,7 @@ var (
 	telemetryAddIndexIngestUsage = metrics.TelemetryAddIndexIngestCnt
 )
 
-func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, bool, error) {
+func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification, isSpatial bool) ([]*model.IndexColumn, bool, error) {
 	// Build offsets.
 	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
 	var col *model.ColumnInfo
@@ -90,6 +91,11 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 		if err := checkIndexColumn(ctx, col, ip.Length); err != nil {
 			return nil, false, err
 		}
+		if col.FieldType.GetType() == mysql.TypeGeometry {
+			if err := checkSpatialIndexColumn(col, isSpatial, len(indexPartSpecifications)); err != nil {
+				return nil, false, err
+			}
+		}
 		if col.FieldType.IsArray() {
 			if mvIndex {
 				return nil, false, dbterror.ErrNotSupportedYet.GenWithStackByArgs("more than one multi-valued key part per index")
@@ -161,6 +167,60 @@ func checkIndexPrefixLength(columns []*model.ColumnInfo, idxColumns []*model.Ind
 	return nil
 }
 
+// checkSpatialIndexColumn checks the geometry column can be indexed. A geometry column can only be indexed by a
+// SPATIAL index, which has only one NOT NULL column.
+func checkSpatialIndexColumn(col *model.ColumnInfo, isSpatial bool, colCnt int) error {
+	if !isSpatial {
+		return errors.Trace(dbterror.ErrBlobKeyWithoutLength.GenWithStackByArgs(col.Name.O))
+	}
+	if colCnt > 1 {
+		return errors.Trace(dbterror.ErrTooManyKeyParts.GenWithStackByArgs(1))
+	}
+	if !mysql.HasNotNullFlag(col.GetFlag()) {
+		return errors.Trace(dbterror.ErrSpatialCantHaveNull)
+	}
+	return nil
+}
+
+// isSpatialIndex returns whether the index built with the option is a SPATIAL index.
+func isSpatialIndex(isPrimary, isUnique bool, indexOption *ast.IndexOption) bool {
+	return !isPrimary && !isUnique && indexOption != nil && indexOption.Tp == model.IndexTypeRtree
+}
+
+// isFullTextIndex returns whether the index built with the option is a FULLTEXT index.
+func isFullTextIndex(indexOption *ast.IndexOption) bool {
+	return indexOption != nil && indexOption.Tp == model.IndexTypeFulltext
+}
+
+// buildFullTextIndexColumns builds the index column of a FULLTEXT index, which is the hidden column concatenating the
+// text columns. The index stores the tokens rather than the text, so the length of the column isn't checked.
+func buildFullTextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	ip := indexPartSpecifications[0]
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
+// findFullTextColumns returns the text columns concatenated by the hidden column of a FULLTEXT index.
+func findFullTextColumns(hiddenCol *model.ColumnInfo) ([]model.CIStr, error) {
+	expr, err := generatedexpr.ParseExpression(hiddenCol.GeneratedExprString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	colNames := FindColumnNamesInExpr(expr)
+	cols := make([]model.CIStr, 0, len(colNames))
+	for _, colName := range colNames {
+		cols = append(cols, colName.Name)
+	}
+	return cols, nil
+}
+
 func checkIndexColumn(ctx sessionctx.Context, col *model.ColumnInfo, indexColumnLen int) error {
 	if col.GetFlen() == 0 && (types.IsTypeChar(col.FieldType.GetType()) || types.IsTypeVarchar(col.FieldType.GetType())) {
 		if col.Hidden {
@@ -253,6 +313,9 @@ func getIndexColumnLength(col *model.ColumnInfo, colLen int) (int, error) {
 		return calcBytesLengthForDecimal(length), nil
 	case mysql.TypeYear, mysql.TypeDate, mysql.TypeDuration, mysql.TypeDatetime, mysql.TypeTimestamp:
 		return mysql.DefaultLengthOfMysqlTypes[col.GetType()], nil
+	case mysql.TypeGeometry:
+		// A SPATIAL index has only the geometry column, whose value isn't limited by the index length.
+		return 0, nil
 	default:
 		return length, nil
 	}
@@ -279,7 +342,17 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
-	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+	var (
+		idxColumns []*model.IndexColumn
+		mvIndex    bool
+		err        error
+	)
+	if isFullTextIndex(indexOption) {
+		idxColumns, err = buildFullTextIndexColumns(allTableColumns, indexPartSpecifications)
+	} else {
+		idxColumns, mvIndex, err = buildIndexColumns(ctx, allTableColumns, indexPartSpecifications,
+			isSpatialIndex(isPrimary, isUnique, indexOption))
+	}
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -310,6 +383,12 @@ func BuildIndexInfo(
 		// Use btree as default index type.
 		idxInfo.Tp = model.IndexTypeBtree
 	}
+	if idxInfo.IsFullText() {
+		if idxInfo.FullTextColumns, err = findFullTextColumns(model.FindColumnInfo(allTableColumns, idxColumns[0].Name.L)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		idxInfo.FullTextParser = indexOption.ParserName.L
+	}
 
 	return idxInfo, nil
 }
@@ -1658,28 +1737,28 @@ func newAddIndexIngestWorker(ctx context.Context, t table.PhysicalTable, d *ddlC
 }
 
 // WriteLocal will write index records to lightning engine.
-func (w *addIndexIngestWorker) WriteLocal(rs *idxRecResult) (count int, nextKey kv.Key, err error) {
+func (w *addIndexIngestWorker) WriteLocal(rs *idxRecResult) (count int, nextKey kv.Key, writtenBytes int, err error) {
 	oprStartTime := time.Now()
 	copCtx := w.copReqSenderPool.copCtx
 	vars := w.sessCtx.GetSessionVars()
-	cnt, lastHandle, err := writeChunkToLocal(w.writer, w.index, copCtx, vars, rs.chunk)
+	cnt, lastHandle, writtenBytes, err := writeChunkToLocal(w.writer, w.index, copCtx, vars, rs.chunk)
 	if err != nil || cnt == 0 {
-		return 0, nil, err
+		return 0, nil, 0, err
 	}
 	w.metricCounter.Add(float64(cnt))
 	logSlowOperations(time.Since(oprStartTime), "writeChunkToLocal", 3000)
 	nextKey = tablecodec.EncodeRecordKey(w.tbl.RecordPrefix(), lastHandle)
-	return cnt, nextKey, nil
+	return cnt, nextKey, writtenBytes, nil
 }
 
 func writeChunkToLocal(writer ingest.Writer,
 	index table.Index, copCtx *copContext, vars *variable.SessionVars,
-	copChunk *chunk.Chunk) (int, kv.Handle, error) {
+	copChunk *chunk.Chunk) (int, kv.Handle, int, error) {
 	sCtx, writeBufs := vars.StmtCtx, vars.GetWriteStmtBufs()
 	iter := chunk.NewIterator4Chunk(copChunk)
 	idxDataBuf := make([]types.Datum, len(copCtx.idxColOutputOffsets))
 	handleDataBuf := make([]types.Datum, len(copCtx.handleOutputOffsets))
-	count := 0
+	count, writtenBytes := 0, 0
 	var lastHandle kv.Handle
 	unlock := writer.LockForWrite()
 	defer unlock()
@@ -1689,41 +1768,44 @@ func writeChunkToLocal(writer ingest.Writer,
 		handleDataBuf := extractDatumByOffsets(row, copCtx.handleOutputOffsets, copCtx.expColInfos, handleDataBuf)
 		handle, err := buildHandle(handleDataBuf, copCtx.tblInfo, copCtx.pkInfo, sCtx)
 		if err != nil {
-			return 0, nil, errors.Trace(err)
+			return 0, nil, 0, errors.Trace(err)
 		}
 		rsData := getRestoreData(copCtx.tblInfo, copCtx.idxInfo, copCtx.pkInfo, handleDataBuf)
-		err = writeOneKVToLocal(writer, index, sCtx, writeBufs, idxDataBuf, rsData, handle)
+		bytes, err := writeOneKVToLocal(writer, index, sCtx, writeBufs, idxDataBuf, rsData, handle)
 		if err != nil {
-			return 0, nil, errors.Trace(err)
+			return 0, nil, 0, errors.Trace(err)
 		}
 		count++
+		writtenBytes += bytes
 		lastHandle = handle
 	}
-	return count, lastHandle, nil
+	return count, lastHandle, writtenBytes, nil
 }
 
 func writeOneKVToLocal(writer ingest.Writer,
 	index table.Index, sCtx *stmtctx.StatementContext, writeBufs *variable.WriteStmtBufs,
-	idxDt, rsData []types.Datum, handle kv.Handle) error {
+	idxDt, rsData []types.Datum, handle kv.Handle) (int, error) {
+	writtenBytes := 0
 	iter := index.GenIndexKVIter(sCtx, idxDt, handle, rsData)
 	for iter.Valid() {
 		key, idxVal, _, err := iter.Next(writeBufs.IndexKeyBuf)
 		if err != nil {
-			return errors.Trace(err)
+			return 0, errors.Trace(err)
 		}
 		failpoint.Inject("mockLocalWriterPanic", func() {
 			panic("mock panic")
 		})
 		err = writer.WriteRow(key, idxVal, handle)
 		if err != nil {
-			return errors.Trace(err)
+			return 0, errors.Trace(err)
 		}
 		failpoint.Inject("mockLocalWriterError", func() {
-			failpoint.Return(errors.New("mock engine error"))
+			failpoint.Return(0, errors.New("mock engine error"))
 		})
+		writtenBytes += len(key) + len(idxVal)
 		writeBufs.IndexKeyBuf = key
 	}
-	return nil
+	return writtenBytes, nil
 }
 
 // BackfillData will backfill table index in a transaction. A lock corresponds to a rowKey if the value of rowKey is changed,
@@ -1787,6 +1869,7 @@ func (w *addIndexTxnWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx
 			taskCtx.addedCount++
 		}
 
+		taskCtx.writtenBytes = txn.Size()
 		return nil
 	})
 	logSlowOperations(time.Since(oprStartTime), "AddIndexBackfillData", 3000)
@@ -2118,6 +2201,7 @@ func (w *cleanUpIndexWorker) BackfillData(handleRange reorgBackfillTask) (taskCt
 			}
 			taskCtx.addedCount++
 		}
+		taskCtx.writtenBytes = txn.Size()
 		return nil
 	})
 	logSlowOperations(time.Since(oprStartTime), "cleanUpIndexBackfillDataInTxn", 3000)
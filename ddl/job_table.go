@@ -541,7 +541,7 @@ func job2UniqueIDs(job *model.Job, schema bool) string {
 		}
 		slices.Sort(s)
 		return strings.Join(s, ",")
-	case model.ActionTruncateTable:
+	case model.ActionTruncateTable, model.ActionRebuildTable:
 		return strconv.FormatInt(job.TableID, 10) + "," + strconv.FormatInt(job.Args[0].(int64), 10)
 	}
 	if schema {
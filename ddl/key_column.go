@@ -0,0 +1,456 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	sess "github.com/pingcap/tidb/ddl/internal/session"
+	ddlutil "github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	tidbutil "github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
+	"go.uber.org/zap"
+)
+
+// isKeyColumn checks whether the column is defined with the PRIMARY KEY or UNIQUE option.
+func isKeyColumn(col *model.ColumnInfo) bool {
+	return mysql.HasPriKeyFlag(col.GetFlag()) || mysql.HasUniKeyFlag(col.GetFlag())
+}
+
+// BuildColumnKeyInfo builds the index of the PRIMARY KEY or UNIQUE option of a column added by ALTER TABLE.
+// The ID of the index is not allocated.
+func BuildColumnKeyInfo(t table.Table, allTableColumns []*model.ColumnInfo, colName model.CIStr, isPK bool, state model.SchemaState) (*model.IndexInfo, error) {
+	indexName := model.NewCIStr(mysql.PrimaryKeyName)
+	if !isPK {
+		indexName = GetName4AnonymousIndex(t, colName, model.NewCIStr(""))
+	}
+	idxSpecs := []*ast.IndexPartSpecification{{Column: &ast.ColumnName{Name: colName}, Length: types.UnspecifiedLength}}
+	return BuildIndexInfo(nil, allTableColumns, indexName, isPK, true, false, idxSpecs, nil, state)
+}
+
+// findColumnKeyInfo finds the index added together with the column.
+func findColumnKeyInfo(tblInfo *model.TableInfo, colInfo *model.ColumnInfo) *model.IndexInfo {
+	for _, idx := range tblInfo.Indices {
+		if idx.State != model.StatePublic && len(idx.Columns) == 1 && idx.Columns[0].Name.L == colInfo.Name.L {
+			return idx
+		}
+	}
+	return nil
+}
+
+// checkAddKeyColumnToTable checks the key of the column can still be added when the job starts,
+// because the table may be changed by the jobs queued before it.
+func checkAddKeyColumnToTable(tblInfo *model.TableInfo, col *model.ColumnInfo) error {
+	if mysql.HasPriKeyFlag(col.GetFlag()) && (tblInfo.PKIsHandle || tblInfo.IsCommonHandle || tblInfo.GetPrimaryKey() != nil) {
+		return infoschema.ErrMultiplePriKey
+	}
+	if mysql.HasAutoIncrementFlag(col.GetFlag()) && (tblInfo.GetAutoIncrementColInfo() != nil || tblInfo.ContainsAutoRandomBits()) {
+		return autoid.ErrWrongAutoKey.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// onAddKeyColumn adds a column with the AUTO_INCREMENT, PRIMARY KEY or UNIQUE option. The states of the job are:
+//   - none -> delete only -> write only -> write reorganization: the same as adding a normal column.
+//     In write reorganization, the rows written before the column is writable get the auto-increment values.
+//   - Then the key is added in the states of delete only, write only and write reorganization, and its data is backfilled.
+//     A duplicate value rolls the job back.
+//   - Both the column and the key become public.
+//
+// The key is only added after the auto-increment values are filled, so it never sees the unfilled values.
+func (w *worker) onAddKeyColumn(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	columnInfo, colFromArgs *model.ColumnInfo, pos *ast.ColumnPosition) (ver int64, err error) {
+	if columnInfo == nil {
+		if err = checkAddKeyColumnToTable(tblInfo, colFromArgs); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		// The job arguments keep the key flags, they are set on the column when the key is public.
+		columnInfo = InitAndAddColumnToTable(tblInfo, colFromArgs.Clone())
+		columnInfo.DelFlag(mysql.PriKeyFlag | mysql.UniqueKeyFlag)
+		logutil.BgLogger().Info("run add key column job", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Reflect("columnInfo", *columnInfo))
+		if err = checkAddColumnTooManyColumns(len(tblInfo.Columns)); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+	}
+
+	originalState := columnInfo.State
+	switch columnInfo.State {
+	case model.StateNone:
+		// none -> delete only
+		columnInfo.State = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != columnInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateDeleteOnly
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		columnInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != columnInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.SchemaState = model.StateWriteOnly
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		columnInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != columnInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		return w.onAddColumnKey(d, t, job, tblInfo, columnInfo, mysql.HasPriKeyFlag(colFromArgs.GetFlag()), pos)
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("column", columnInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// onAddColumnKey fills the auto-increment values and adds the key of the column in write reorganization.
+func (w *worker) onAddColumnKey(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	columnInfo *model.ColumnInfo, isPK bool, pos *ast.ColumnPosition) (ver int64, err error) {
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	idxInfo := findColumnKeyInfo(tblInfo, columnInfo)
+	if idxInfo == nil {
+		if mysql.HasAutoIncrementFlag(columnInfo.GetFlag()) {
+			done, ver, err := w.doAddKeyColumnReorgWork(d, t, job, tbl, columnInfo, BuildElements(columnInfo, nil))
+			if !done {
+				return ver, err
+			}
+		}
+		idxInfo, err = BuildColumnKeyInfo(tbl, tblInfo.Columns, columnInfo.Name, isPK, model.StateDeleteOnly)
+		if err == nil {
+			idxInfo.ID = AllocateIndexID(tblInfo)
+			tblInfo.Indices = append(tblInfo.Indices, idxInfo)
+			err = checkTooManyIndexes(tblInfo.Indices)
+		}
+		if err != nil {
+			return convertAddKeyColumnJob2RollbackJob(d, t, job, tblInfo, columnInfo, err)
+		}
+		// Reset the snapshot version for the backfill of the key.
+		job.SnapshotVer = 0
+		return updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	}
+
+	switch idxInfo.State {
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		idxInfo.State = model.StateWriteOnly
+		return updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		idxInfo.State = model.StateWriteReorganization
+		return updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	case model.StateWriteReorganization:
+		done, ver, err := w.doAddKeyColumnReorgWork(d, t, job, tbl, columnInfo,
+			[]*meta.Element{{ID: idxInfo.ID, TypeKey: meta.IndexElementKey}})
+		if !done {
+			return ver, err
+		}
+		// reorganization -> public
+		offset, err := LocateOffsetToMove(columnInfo.Offset, pos, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		tblInfo.MoveColumnInfo(columnInfo.Offset, offset)
+		columnInfo.State = model.StatePublic
+		idxInfo.State = model.StatePublic
+		AddIndexColumnFlag(tblInfo, idxInfo)
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		asyncNotifyEvent(d, &ddlutil.Event{Tp: model.ActionAddColumn, TableInfo: tblInfo, ColumnInfos: []*model.ColumnInfo{columnInfo}})
+		return ver, nil
+	default:
+		return ver, dbterror.ErrInvalidDDLState.GenWithStackByArgs("index", idxInfo.State)
+	}
+}
+
+// doAddKeyColumnReorgWork fills the auto-increment values of the column, or backfills the key of the column,
+// according to the element.
+func (w *worker) doAddKeyColumnReorgWork(d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table,
+	columnInfo *model.ColumnInfo, elements []*meta.Element) (done bool, ver int64, err error) {
+	job.ReorgMeta.ReorgTp = model.ReorgTypeTxn
+	sctx, err1 := w.sessPool.Get()
+	if err1 != nil {
+		return false, ver, errors.Trace(err1)
+	}
+	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	physTbl, ok := tbl.(table.PhysicalTable)
+	if !ok {
+		return false, ver, dbterror.ErrCancelledDDLJob.GenWithStack("unexpected table type %T when adding column", tbl)
+	}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID), d, rh, job, dbInfo, tbl, elements, false)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+	err = w.runReorgJob(reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "doAddKeyColumnReorgWork",
+			func() {
+				reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("add column `%v` panic", columnInfo.Name)
+			}, false)
+		if bytes.Equal(reorgInfo.currElement.TypeKey, meta.IndexElementKey) {
+			return w.addTableIndex(tbl, reorgInfo)
+		}
+		return w.writePhysicalTableRecord(w.sessPool, physTbl, typeAddColumnWorker, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, ver, nil
+		}
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) || dbterror.ErrNotOwner.Equal(err) {
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.BgLogger().Warn("add column job failed, RemoveDDLReorgHandle failed, can't convert job to rollback", zap.String("category", "ddl"),
+				zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.BgLogger().Warn("add column job failed, convert job to rollback", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Error(err))
+		ver, err = convertAddKeyColumnJob2RollbackJob(d, t, job, tbl.Meta(), columnInfo, err)
+		return false, ver, err
+	}
+	return true, ver, nil
+}
+
+// prepareAddColumnRollback sets the column to delete only, and removes the key added together with it.
+// Then the rolling back job drops the column like a drop column job, and deletes the data of the key.
+func prepareAddColumnRollback(job *model.Job, tblInfo *model.TableInfo, columnInfo *model.ColumnInfo) {
+	columnInfo.State = model.StateDeleteOnly
+	job.SchemaState = model.StateDeleteOnly
+	job.Args = []interface{}{columnInfo.Name}
+	if idxInfo := findColumnKeyInfo(tblInfo, columnInfo); idxInfo != nil {
+		removeIndexInfo(tblInfo, idxInfo)
+		job.Args = append(job.Args, false /* ifExists */, []int64{idxInfo.ID})
+	}
+}
+
+func convertAddKeyColumnJob2RollbackJob(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	columnInfo *model.ColumnInfo, err error) (int64, error) {
+	prepareAddColumnRollback(job, tblInfo, columnInfo)
+	ver, err1 := updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err1 != nil {
+		return ver, errors.Trace(err1)
+	}
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(err)
+}
+
+// addColumnWorker fills the auto-increment values of the rows written before the column is writable.
+type addColumnWorker struct {
+	*backfillCtx
+	colInfo    *model.ColumnInfo
+	rowDecoder *decoder.RowDecoder
+	rowMap     map[int64]types.Datum
+	// The following attributes are used to reduce memory allocation.
+	rowRecords []*rowRecord
+}
+
+func newAddColumnWorker(sessCtx sessionctx.Context, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) *addColumnWorker {
+	var colInfo *model.ColumnInfo
+	for _, col := range t.WritableCols() {
+		if col.ID == reorgInfo.currElement.ID {
+			colInfo = col.ColumnInfo
+			break
+		}
+	}
+	return &addColumnWorker{
+		backfillCtx: newBackfillCtx(reorgInfo.d, id, sessCtx, reorgInfo.SchemaName, t, jc, "add_col_rate", false),
+		colInfo:     colInfo,
+		rowDecoder:  decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:      make(map[int64]types.Datum, len(decodeColMap)),
+	}
+}
+
+// BackfillData writes the auto-increment values of the rows in the handle range in a transaction.
+func (w *addColumnWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceType(context.Background(), w.jobContext.ddlJobSourceType())
+	errInTxn = kv.RunInNewTxn(ctx, w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, handleRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(handleRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(ctx, txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		for _, rowRecord := range rowRecords {
+			taskCtx.scanCount++
+			// A concurrent write of the row conflicts with the transaction.
+			if err = txn.Set(rowRecord.key, rowRecord.vals); err != nil {
+				return errors.Trace(err)
+			}
+			taskCtx.addedCount++
+		}
+		taskCtx.writtenBytes = txn.Size()
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "BackfillData", 3000)
+	return
+}
+
+// fetchRowColVals encodes the rows in the task range that need an auto-increment value, with the allocated values.
+func (w *addColumnWorker) fetchRowColVals(ctx context.Context, txn kv.Transaction, taskRange reorgBackfillTask) ([]*rowRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	err := iterateSnapshotKeys(w.jobContext, w.sessCtx.GetStore(), taskRange.priority, taskRange.physicalTable.RecordPrefix(),
+		txn.StartTS(), taskRange.startKey, taskRange.endKey, func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			if taskRange.endInclude {
+				taskDone = recordKey.Cmp(taskRange.endKey) > 0
+			} else {
+				taskDone = recordKey.Cmp(taskRange.endKey) >= 0
+			}
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			if err1 := w.getRowRecord(ctx, handle, recordKey, rawRow); err1 != nil {
+				return false, errors.Trace(err1)
+			}
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("txn fetches handle info", zap.String("category", "ddl"), zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return w.rowRecords, getNextHandleKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
+func (w *addColumnWorker) getRowRecord(ctx context.Context, handle kv.Handle, recordKey []byte, rawRow []byte) error {
+	defer func() {
+		for id := range w.rowMap {
+			delete(w.rowMap, id)
+		}
+	}()
+	sysTZ := w.sessCtx.GetSessionVars().StmtCtx.TimeZone
+	_, err := w.rowDecoder.DecodeTheExistedColumnMap(w.sessCtx, handle, rawRow, sysTZ, w.rowMap)
+	if err != nil {
+		return errors.Trace(dbterror.ErrCantDecodeRecord.GenWithStackByArgs("column", err))
+	}
+	if !isUnassignedAutoIncValue(w.rowMap[w.colInfo.ID]) {
+		// The value is allocated by an insert statement or by an earlier backfill.
+		return nil
+	}
+
+	id, err := table.AllocAutoIncrementValue(ctx, w.table, w.sessCtx)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var d types.Datum
+	d.SetAutoID(id, w.colInfo.GetFlag())
+	if w.rowMap[w.colInfo.ID], err = table.CastValue(w.sessCtx, d, w.colInfo, false, false); err != nil {
+		return errors.Trace(err)
+	}
+	newColumnIDs := make([]int64, 0, len(w.rowMap))
+	newRow := make([]types.Datum, 0, len(w.rowMap))
+	for _, col := range w.table.WritableCols() {
+		if col.IsGenerated() && !col.GeneratedStored {
+			continue
+		}
+		if val, ok := w.rowMap[col.ID]; ok {
+			newColumnIDs = append(newColumnIDs, col.ID)
+			newRow = append(newRow, val)
+		}
+	}
+	sctx, rd := w.sessCtx.GetSessionVars().StmtCtx, &w.sessCtx.GetSessionVars().RowEncoder
+	newRowVal, err := tablecodec.EncodeRow(sctx, newRow, newColumnIDs, nil, nil, rd)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.rowRecords = append(w.rowRecords, &rowRecord{key: recordKey, vals: newRowVal})
+	return nil
+}
+
+// isUnassignedAutoIncValue checks whether the value of the auto-increment column is not allocated yet.
+// Such a row has the origin default value 0, which is never allocated.
+func isUnassignedAutoIncValue(d types.Datum) bool {
+	switch d.Kind() {
+	case types.KindInt64:
+		return d.GetInt64() == 0
+	case types.KindUint64:
+		return d.GetUint64() == 0
+	case types.KindFloat32, types.KindFloat64:
+		return d.GetFloat64() == 0
+	default:
+		return d.IsNull()
+	}
+}
+
+func (w *addColumnWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (*addColumnWorker) String() string {
+	return typeAddColumnWorker.String()
+}
+
+func (w *addColumnWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
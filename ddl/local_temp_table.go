@@ -0,0 +1,200 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// BuildAlteredLocalTemporaryTableInfo applies the ALTER TABLE specs to a copy of the table info of a local
+// temporary table. Only adding and dropping columns and indexes are supported. The rows of a local temporary
+// table are kept in the session, so the schema changes are done at once instead of by DDL jobs:
+//   - The added columns are filled with their original default values when the rows are read.
+//   - The dropped columns are ignored when the rows are read.
+//   - The added indexes, and the indexes rebuilt without the dropped columns, get new index IDs,
+//     the caller needs to backfill them and clean up the indexes which no longer exist.
+func BuildAlteredLocalTemporaryTableInfo(ctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo,
+	tblInfo *model.TableInfo, specs []*ast.AlterTableSpec) (*model.TableInfo, error) {
+	validSpecs, err := ResolveAlterTableSpec(ctx, specs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	tblInfo = tblInfo.Clone()
+	for _, spec := range validSpecs {
+		switch spec.Tp {
+		case ast.AlterTableAddColumns:
+			err = addLocalTemporaryTableColumn(ctx, schema, tblInfo, spec)
+		case ast.AlterTableDropColumn:
+			err = dropLocalTemporaryTableColumn(ctx, is, schema, tblInfo, spec)
+		case ast.AlterTableAddConstraint:
+			err = addLocalTemporaryTableIndex(ctx, tblInfo, spec.Constraint, spec.IfNotExists)
+		case ast.AlterTableDropIndex:
+			err = dropLocalTemporaryTableIndex(ctx, tblInfo, model.NewCIStr(spec.Name), spec.IfExists)
+		case ast.AlterTableDropPrimaryKey:
+			err = dropLocalTemporaryTableIndex(ctx, tblInfo, model.NewCIStr(mysql.PrimaryKeyName), spec.IfExists)
+		default:
+			err = dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+		}
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return tblInfo, nil
+}
+
+func addLocalTemporaryTableColumn(ctx sessionctx.Context, schema *model.DBInfo, tblInfo *model.TableInfo, spec *ast.AlterTableSpec) error {
+	t := tables.MockTableFromMeta(tblInfo)
+	if err := checkAddColumnTooManyColumns(len(t.Cols()) + 1); err != nil {
+		return errors.Trace(err)
+	}
+	ident := ast.Ident{Schema: schema.Name, Name: tblInfo.Name}
+	col, err := checkAndCreateNewColumn(ctx, ident, schema, spec, t, spec.NewColumns[0])
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// Added column has existed and if_not_exists flag is true.
+	if col == nil {
+		return nil
+	}
+	// The values of such a column can't be derived from the original default value.
+	if isKeyColumn(col.ColumnInfo) || mysql.HasAutoIncrementFlag(col.GetFlag()) {
+		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ADD COLUMN with a key or AUTO_INCREMENT")
+	}
+	if err = CheckAfterPositionExists(tblInfo, spec.Position); err != nil {
+		return errors.Trace(err)
+	}
+
+	columnInfo := InitAndAddColumnToTable(tblInfo, col.ColumnInfo)
+	offset, err := LocateOffsetToMove(columnInfo.Offset, spec.Position, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tblInfo.MoveColumnInfo(columnInfo.Offset, offset)
+	columnInfo.State = model.StatePublic
+	return nil
+}
+
+func dropLocalTemporaryTableColumn(ctx sessionctx.Context, is infoschema.InfoSchema, schema *model.DBInfo,
+	tblInfo *model.TableInfo, spec *ast.AlterTableSpec) error {
+	isDroppable, err := checkIsDroppableColumn(ctx, is, schema, tables.MockTableFromMeta(tblInfo), spec)
+	if err != nil || !isDroppable {
+		return errors.Trace(err)
+	}
+
+	colName := spec.OldColumnName.Name
+	colInfo := tblInfo.FindPublicColumnByName(colName.L)
+	tblInfo.MoveColumnInfo(colInfo.Offset, len(tblInfo.Columns)-1)
+	tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
+
+	newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		i, _ := model.FindIndexColumnByName(idx.Columns, colName.L)
+		if i == -1 {
+			newIndices = append(newIndices, idx)
+			continue
+		}
+		if len(idx.Columns) == 1 {
+			continue
+		}
+		// The index is rebuilt without the column.
+		idx.Columns = append(idx.Columns[:i:i], idx.Columns[i+1:]...)
+		idx.ID = AllocateIndexID(tblInfo)
+		AddIndexColumnFlag(tblInfo, idx)
+		newIndices = append(newIndices, idx)
+	}
+	tblInfo.Indices = newIndices
+	return nil
+}
+
+func addLocalTemporaryTableIndex(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint, ifNotExists bool) error {
+	var unique bool
+	switch constr.Tp {
+	case ast.ConstraintKey, ast.ConstraintIndex:
+	case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
+		unique = true
+	default:
+		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+	}
+	for _, key := range constr.Keys {
+		if key.Expr != nil {
+			return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("expression index")
+		}
+	}
+
+	indexName := model.NewCIStr(constr.Name)
+	if len(indexName.L) == 0 {
+		indexName = GetName4AnonymousIndex(tables.MockTableFromMeta(tblInfo), constr.Keys[0].Column.Name, model.NewCIStr(""))
+	}
+	if tblInfo.FindIndexByName(indexName.L) != nil {
+		err := dbterror.ErrDupKeyName.GenWithStack("index already exist %s", indexName)
+		if ifNotExists || constr.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	indexInfo, err := BuildIndexInfo(ctx, tblInfo.Columns, indexName, false, unique, false,
+		constr.Keys, constr.Option, model.StatePublic)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if indexInfo.Tp == model.IndexTypeHypo {
+		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("hypothetical index")
+	}
+	// The values of the virtual generated columns are not stored in the rows.
+	for _, idxCol := range indexInfo.Columns {
+		if col := tblInfo.Columns[idxCol.Offset]; col.IsGenerated() && !col.GeneratedStored {
+			return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("index on virtual generated column")
+		}
+	}
+	indexInfo.ID = AllocateIndexID(tblInfo)
+	tblInfo.Indices = append(tblInfo.Indices, indexInfo)
+	AddIndexColumnFlag(tblInfo, indexInfo)
+	return nil
+}
+
+func dropLocalTemporaryTableIndex(ctx sessionctx.Context, tblInfo *model.TableInfo, indexName model.CIStr, ifExists bool) error {
+	indexInfo := tblInfo.FindIndexByName(indexName.L)
+	if _, err := CheckIsDropPrimaryKey(indexName, indexInfo, tables.MockTableFromMeta(tblInfo)); err != nil {
+		return err
+	}
+	if indexInfo == nil {
+		err := dbterror.ErrCantDropFieldOrKey.GenWithStack("index %s doesn't exist", indexName)
+		if ifExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	newIndices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		if idx.ID != indexInfo.ID {
+			newIndices = append(newIndices, idx)
+		}
+	}
+	tblInfo.Indices = newIndices
+	DropIndexColumnFlag(tblInfo, indexInfo)
+	RemoveDependentHiddenColumns(tblInfo, indexInfo)
+	return nil
+}
@@ -65,6 +65,7 @@ import (
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 )
 
 const (
@@ -957,6 +958,8 @@ func astIntValueExprFromStr(s string, unsigned bool) (ast.ExprNode, error) {
 //   - ALTER TABLE FIRST PARTITION (expr): Drops all partitions before the partition matching the expr (i.e. sets that partition as the new first partition)
 //     i.e. will return the partitions from old FIRST partition to (and including) new FIRST partition
 //   - ALTER TABLE LAST PARTITION (expr): Creates new partitions from (excluding) old LAST partition to (including) new LAST partition
+//   - ALTER TABLE MERGE FIRST PARTITION (expr): Like FIRST PARTITION, returns the partitions to be merged
+//   - ALTER TABLE SPLIT MAXVALUE PARTITION (expr): Like LAST PARTITION, returns the partitions to split from MAXVALUE
 //
 // partition definitions will be set on partitionOptions
 func GeneratePartDefsFromInterval(ctx sessionctx.Context, tp ast.AlterTableType, tbInfo *model.TableInfo, partitionOptions *ast.PartitionOptions) error {
@@ -988,10 +991,10 @@ func GeneratePartDefsFromInterval(ctx sessionctx.Context, tp ast.AlterTableType,
 		// CREATE TABLE
 		startExpr = *partitionOptions.Interval.FirstRangeEnd
 		lastExpr = *partitionOptions.Interval.LastRangeEnd
-	case ast.AlterTableDropFirstPartition:
+	case ast.AlterTableDropFirstPartition, ast.AlterTableReorganizeFirstPartition:
 		startExpr = *partitionOptions.Interval.FirstRangeEnd
 		lastExpr = partitionOptions.Expr
-	case ast.AlterTableAddLastPartition:
+	case ast.AlterTableAddLastPartition, ast.AlterTableReorganizeLastPartition:
 		startExpr = *partitionOptions.Interval.LastRangeEnd
 		lastExpr = partitionOptions.Expr
 	default:
@@ -1012,7 +1015,7 @@ func GeneratePartDefsFromInterval(ctx sessionctx.Context, tp ast.AlterTableType,
 			currExpr = startExpr
 			// TODO: adjust the startExpr and have an offset for interval to handle
 			// Month/Quarters with start partition on day 28/29/30
-			if tp == ast.AlterTableAddLastPartition {
+			if tp == ast.AlterTableAddLastPartition || tp == ast.AlterTableReorganizeLastPartition {
 				// ALTER TABLE LAST PARTITION ...
 				// Current LAST PARTITION/start already exists, skip to next partition
 				continue
@@ -1816,11 +1819,24 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	if err != nil {
 		return ver, errors.Trace(err)
 	}
-	if job.Type == model.ActionAddTablePartition || job.Type == model.ActionReorganizePartition {
+	if job.Type == model.ActionAddTablePartition || job.Type == model.ActionReorganizePartition ||
+		job.Type == model.ActionAlterTablePartitioning || job.Type == model.ActionRemovePartitioning {
 		// It is rollback from reorganize partition, just remove DroppingDefinitions from tableInfo
 		tblInfo.Partition.DroppingDefinitions = nil
 		// It is rollback from adding table partition, just remove addingDefinitions from tableInfo.
 		physicalTableIDs, pNames, rollbackBundles := rollbackAddingPartitionInfo(tblInfo)
+		if job.Type == model.ActionAlterTablePartitioning || job.Type == model.ActionRemovePartitioning {
+			// It is rollback from changing the partitioning, just restore the original partitioning.
+			pi := tblInfo.Partition
+			pi.DDLType = model.PartitionTypeNone
+			pi.DDLExpr = ""
+			pi.DDLColumns = nil
+			pi.NewTableID = 0
+			pi.DDLState = model.StateNone
+			if pi.Type == model.PartitionTypeNone {
+				tblInfo.Partition = nil
+			}
+		}
 		err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), rollbackBundles)
 		if err != nil {
 			job.State = model.JobStateCancelled
@@ -2524,6 +2540,14 @@ func checkReorgPartition(t *meta.Meta, job *model.Job) (*model.TableInfo, []mode
 		job.State = model.JobStateCancelled
 		return nil, nil, nil, nil, nil, errors.Trace(err)
 	}
+	if tblInfo.Partition == nil {
+		if job.Type != model.ActionAlterTablePartitioning {
+			job.State = model.JobStateCancelled
+			return nil, nil, nil, nil, nil, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+		}
+		// A non-partitioned table is handled as a single partition during the reorganization.
+		tblInfo.Partition = getPartitionInfoTypeNone(tblInfo)
+	}
 	addingDefs := tblInfo.Partition.AddingDefinitions
 	droppingDefs := tblInfo.Partition.DroppingDefinitions
 	if len(addingDefs) == 0 {
@@ -2535,6 +2559,21 @@ func checkReorgPartition(t *meta.Meta, job *model.Job) (*model.TableInfo, []mode
 	return tblInfo, partNames, partInfo, droppingDefs, addingDefs, nil
 }
 
+// getPartitionInfoTypeNone returns the partition info of a non-partitioned table,
+// which is handled as a single partition with the table ID during ALTER TABLE PARTITION BY.
+func getPartitionInfoTypeNone(tblInfo *model.TableInfo) *model.PartitionInfo {
+	return &model.PartitionInfo{
+		Type:   model.PartitionTypeNone,
+		Enable: true,
+		Definitions: []model.PartitionDefinition{{
+			ID:      tblInfo.ID,
+			Name:    model.NewCIStr("pFullTable"),
+			Comment: "Intermediate partition during ALTER TABLE ... PARTITION BY ...",
+		}},
+		Num: 1,
+	}
+}
+
 func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	// Handle the rolling back job
 	if job.IsRollingback() {
@@ -2586,10 +2625,23 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			job.State = model.JobStateCancelled
 			return ver, err
 		}
-		sctx := w.sess.Context
-		if err = checkReorgPartitionDefs(sctx, tblInfo, partInfo, firstPartIdx, lastPartIdx, idMap); err != nil {
-			job.State = model.JobStateCancelled
-			return ver, err
+		if job.Type == model.ActionReorganizePartition {
+			sctx := w.sess.Context
+			if err = checkReorgPartitionDefs(sctx, tblInfo, partInfo, firstPartIdx, lastPartIdx, idMap); err != nil {
+				job.State = model.JobStateCancelled
+				return ver, err
+			}
+		} else {
+			// All the partitions are replaced when changing the partitioning.
+			if len(idMap) != len(tblInfo.Partition.Definitions) {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(dbterror.ErrCancelledDDLJob.GenWithStack("the partitions of table %s have been changed", tblInfo.Name.O))
+			}
+			// The new partitioning is kept aside until the new partitions replace the old ones.
+			tblInfo.Partition.DDLType = partInfo.Type
+			tblInfo.Partition.DDLExpr = partInfo.Expr
+			tblInfo.Partition.DDLColumns = partInfo.Columns
+			tblInfo.Partition.NewTableID = partInfo.NewTableID
 		}
 
 		// move the adding definition into tableInfo.
@@ -2761,6 +2813,13 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 		// From now on, use the new definitions, but keep the Adding and Dropping for double write
 		tblInfo.Partition.Definitions = newDefs
 		tblInfo.Partition.Num = uint64(len(newDefs))
+		if job.Type != model.ActionReorganizePartition {
+			// Also use the new partitioning, and keep the old one for the DroppingDefinitions.
+			pi := tblInfo.Partition
+			pi.Type, pi.DDLType = pi.DDLType, pi.Type
+			pi.Expr, pi.DDLExpr = pi.DDLExpr, pi.Expr
+			pi.Columns, pi.DDLColumns = pi.DDLColumns, pi.Columns
+		}
 
 		// Now all the data copying is done, but we cannot simply remove the droppingDefinitions
 		// since they are a part of the normal Definitions that other nodes with
@@ -2787,6 +2846,18 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 		definitionsToAdd := tblInfo.Partition.AddingDefinitions
 		tblInfo.Partition.DroppingDefinitions = nil
 		tblInfo.Partition.AddingDefinitions = nil
+		if job.Type != model.ActionReorganizePartition {
+			oldTblID := tblInfo.ID
+			if err = changeTableIDForPartitioningChange(t, job, tblInfo); err != nil {
+				return ver, errors.Trace(err)
+			}
+			if tblInfo.ID != oldTblID && !slices.Contains(physicalTableIDs, oldTblID) {
+				// The old table ID may hold global index entries and placement rules.
+				physicalTableIDs = append(physicalTableIDs, oldTblID)
+			}
+			// used by updateSchemaVersion for the table ID change
+			job.CtxVars = append(job.CtxVars, tblInfo.ID)
+		}
 		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
 		failpoint.Inject("reorgPartWriteReorgSchemaVersionUpdateFail", func(val failpoint.Value) {
 			if val.(bool) {
@@ -2797,12 +2868,14 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 			return ver, errors.Trace(err)
 		}
 		job.SchemaState = model.StateNone
-		tblInfo.Partition.DDLState = model.StateNone
+		if tblInfo.Partition != nil {
+			tblInfo.Partition.DDLState = model.StateNone
+		}
 		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
 		// How to handle this?
 		// Seems to only trigger asynchronous update of statistics.
 		// Should it actually be synchronous?
-		asyncNotifyEvent(d, &util.Event{Tp: model.ActionReorganizePartition, TableInfo: tblInfo, PartInfo: &model.PartitionInfo{Definitions: definitionsToAdd}})
+		asyncNotifyEvent(d, &util.Event{Tp: job.Type, TableInfo: tblInfo, PartInfo: &model.PartitionInfo{Definitions: definitionsToAdd}})
 		// A background job will be created to delete old partition data.
 		job.Args = []interface{}{physicalTableIDs}
 
@@ -2813,6 +2886,60 @@ func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job)
 	return ver, errors.Trace(err)
 }
 
+// changeTableIDForPartitioningChange moves the table to the new table ID at the end of
+// ALTER TABLE PARTITION BY and REMOVE PARTITIONING, keeping its auto IDs.
+func changeTableIDForPartitioningChange(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) error {
+	pi := tblInfo.Partition
+	oldTblID := tblInfo.ID
+	tblInfo.ID = pi.NewTableID
+	if pi.Type == model.PartitionTypeNone {
+		// The single collapsed partition becomes the table.
+		tblInfo.Partition = nil
+		if tblInfo.TiFlashReplica != nil {
+			tblInfo.TiFlashReplica.Available = tblInfo.TiFlashReplica.IsPartitionAvailable(tblInfo.ID)
+			tblInfo.TiFlashReplica.AvailablePartitionIDs = nil
+		}
+	} else {
+		pi.DDLType = model.PartitionTypeNone
+		pi.DDLExpr = ""
+		pi.DDLColumns = nil
+		pi.NewTableID = 0
+		pi.DDLState = model.StateNone
+	}
+	return changeTableID(t, job, oldTblID, tblInfo)
+}
+
+// changeTableID replaces the table with the old table ID by tblInfo, which has a new table ID,
+// and moves the auto IDs and the placement rules to the new table ID.
+func changeTableID(t *meta.Meta, job *model.Job, oldTblID int64, tblInfo *model.TableInfo) error {
+	autoIDs, err := t.GetAutoIDAccessors(job.SchemaID, oldTblID).Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = t.DropTableOrView(job.SchemaID, oldTblID); err != nil {
+		return errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(job.SchemaID, oldTblID).Del(); err != nil {
+		return errors.Trace(err)
+	}
+	if err = t.CreateTableOrView(job.SchemaID, tblInfo); err != nil {
+		return errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(job.SchemaID, tblInfo.ID).Put(autoIDs); err != nil {
+		return errors.Trace(err)
+	}
+
+	bundles, err := placement.NewFullTableBundles(t, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = infosync.PutRuleBundlesWithDefaultRetry(context.TODO(), bundles); err != nil {
+		return errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	_, err = alterTableLabelRule(job.SchemaName, tblInfo, getIDs([]*model.TableInfo{tblInfo}))
+	return errors.Trace(err)
+}
+
 func doPartitionReorgWork(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table, physTblIDs []int64) (done bool, ver int64, err error) {
 	job.ReorgMeta.ReorgTp = model.ReorgTypeTxn
 	sctx, err1 := w.sessPool.Get()
@@ -2881,7 +3008,7 @@ func newReorgPartitionWorker(sessCtx sessionctx.Context, i int, t table.Physical
 	if pt == nil {
 		return nil, dbterror.ErrUnsupportedReorganizePartition.GenWithStackByArgs()
 	}
-	partColIDs := pt.GetPartitionColumnIDs()
+	partColIDs := reorgedTbl.GetPartitionColumnIDs()
 	writeColOffsetMap := make(map[int64]int, len(partColIDs))
 	maxOffset := 0
 	for _, col := range pt.Cols() {
@@ -2956,6 +3083,7 @@ func (w *reorgPartitionWorker) BackfillData(handleRange reorgBackfillTask) (task
 
 		// also add the index entries here? And make sure they are not added somewhere else
 
+		taskCtx.writtenBytes = txn.Size()
 		return nil
 	})
 	logSlowOperations(time.Since(oprStartTime), "BackfillData", 3000)
@@ -3080,7 +3208,6 @@ func (w *worker) reorgPartitionDataAndIndex(t table.Table, reorgInfo *reorgInfo)
 	if !bytes.Equal(reorgInfo.currElement.TypeKey, meta.IndexElementKey) {
 		// First run, have not yet started backfilling index data
 		// Restart with the first new partition.
-		// TODO: handle remove partitioning
 		reorgInfo.PhysicalTableID = firstNewPartitionID
 	} else {
 		// The job was interrupted and has been restarted,
@@ -3406,13 +3533,13 @@ func getPartitionRuleIDs(dbName string, table *model.TableInfo) []string {
 }
 
 // checkPartitioningKeysConstraints checks that the range partitioning key is included in the table constraint.
-func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTableStmt, tblInfo *model.TableInfo) error {
+func checkPartitioningKeysConstraints(sctx sessionctx.Context, partOptions *ast.PartitionOptions, tblInfo *model.TableInfo) error {
 	// Returns directly if there are no unique keys in the table.
 	if len(tblInfo.Indices) == 0 && !tblInfo.PKIsHandle {
 		return nil
 	}
 
-	partCols, err := getPartitionColSlices(sctx, tblInfo, s.Partition)
+	partCols, err := getPartitionColSlices(sctx, tblInfo, partOptions)
 	if err != nil {
 		return errors.Trace(err)
 	}
@@ -3843,7 +3970,8 @@ func writeColumnListToBuffer(partitionInfo *model.PartitionInfo, sqlMode mysql.S
 // AppendPartitionInfo is used in SHOW CREATE TABLE as well as generation the SQL syntax
 // for the PartitionInfo during validation of various DDL commands
 func AppendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer, sqlMode mysql.SQLMode) {
-	if partitionInfo == nil {
+	if partitionInfo == nil || partitionInfo.Type == model.PartitionTypeNone {
+		// PartitionTypeNone is a non-partitioned table having its partitioning changed.
 		return
 	}
 	// Since MySQL 5.1/5.5 is very old and TiDB aims for 5.7/8.0 compatibility, we will not
@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/testkit/external"
 	"github.com/pingcap/tidb/testkit/testutil"
 	"github.com/stretchr/testify/require"
 	"github.com/tikv/client-go/v2/testutils"
@@ -66,7 +67,11 @@ func TestPrimaryKey(t *testing.T) {
 	// Test add/drop primary key on a plain table.
 	tk.MustExec("drop table if exists t;")
 	tk.MustExec("create table t (a int, b varchar(10));")
-	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(a) clustered, add index(b);", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t add primary key(a) clustered;")
+	require.True(t, external.GetTableByName(t, tk, "test", "t").Meta().PKIsHandle)
+	tk.MustExec("alter table t drop primary key;")
+	require.False(t, external.GetTableByName(t, tk, "test", "t").Meta().HasClusteredIndex())
 	tk.MustExec("alter table t add primary key(a) nonclustered;")
 	tk.MustExec("alter table t drop primary key;")
 	tk.MustExec("alter table t add primary key(a) nonclustered;")
@@ -78,21 +83,23 @@ func TestPrimaryKey(t *testing.T) {
 	// Test add/drop primary key on a PKIsHandle table.
 	tk.MustExec("drop table if exists t;")
 	tk.MustExec("create table t (a int, b varchar(10), primary key(a) clustered);")
-	tk.MustGetErrCode("alter table t drop primary key;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a) nonclustered;", mysql.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a);", errno.ErrMultiplePriKey) // implicit nonclustered
-	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b) nonclustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b);", errno.ErrMultiplePriKey) // implicit nonclustered
+	tk.MustGetErrCode("alter table t drop primary key, add index(b);", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("alter table t drop primary key;")
+	require.False(t, external.GetTableByName(t, tk, "test", "t").Meta().PKIsHandle)
 
 	// Test add/drop primary key on a nonclustered primary key table.
 	tk.MustExec("drop table if exists t;")
 	tk.MustExec("create table t (a int, b varchar(10), primary key(a) nonclustered);")
-	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a) nonclustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a);", errno.ErrMultiplePriKey) // implicit nonclustered
-	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b) nonclustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b);", errno.ErrMultiplePriKey) // implicit nonclustered
 	tk.MustExec("alter table t drop primary key;")
@@ -100,13 +107,16 @@ func TestPrimaryKey(t *testing.T) {
 	// Test add/drop primary key on a CommonHandle key table.
 	tk.MustExec("drop table if exists t;")
 	tk.MustExec("create table t (a int, b varchar(10), primary key(b) clustered);")
-	tk.MustGetErrCode("alter table t drop primary key;", errno.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(a) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a) nonclustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(a);", errno.ErrMultiplePriKey) // implicit nonclustered
-	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add primary key(b) clustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b) nonclustered;", errno.ErrMultiplePriKey)
 	tk.MustGetErrCode("alter table t add primary key(b);", errno.ErrMultiplePriKey) // implicit nonclustered
+	tk.MustExec("alter table t drop primary key, add primary key(a) clustered;")
+	tbl := external.GetTableByName(t, tk, "test", "t")
+	require.True(t, tbl.Meta().PKIsHandle)
+	require.False(t, tbl.Meta().IsCommonHandle)
 
 	// Test add/drop primary key when the column&index name is `primary`.
 	tk.MustExec("drop table if exists t;")
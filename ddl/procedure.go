@@ -0,0 +1,84 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/model"
+)
+
+func onCreateProcedure(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	procInfo := &model.ProcedureInfo{}
+	if err := job.DecodeArgs(procInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	exist, err := t.GetProcedure(job.SchemaID, procInfo.Name.L)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if exist != nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrProcedureExists.GenWithStackByArgs("PROCEDURE", procInfo.Name.O)
+	}
+
+	// A procedure has no intermediate state, none -> public.
+	procInfo.State = model.StatePublic
+	if err = t.CreateProcedure(job.SchemaID, procInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, dbInfo)
+	return ver, nil
+}
+
+func onDropProcedure(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	dbInfo, err := checkSchemaExistAndCancelNotExistJob(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	exist, err := t.GetProcedure(job.SchemaID, name.L)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if exist == nil {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", job.SchemaName+"."+name.O)
+	}
+
+	if err = t.DropProcedure(job.SchemaID, name.L); err != nil {
+		return ver, errors.Trace(err)
+	}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, dbInfo)
+	return ver, nil
+}
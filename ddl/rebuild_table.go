@@ -0,0 +1,449 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	sess "github.com/pingcap/tidb/ddl/internal/session"
+	"github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	tidbutil "github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
+	"go.uber.org/zap"
+)
+
+// rebuildMappingIndexName is the name of the mapping index, see model.TableRebuildInfo.
+const rebuildMappingIndexName = "_tidb_rebuild_mapping"
+
+// onRebuildTable rebuilds the table into a new physical table for ALTER TABLE ... FORCE,
+// and for adding or dropping a clustered primary key. The states of the job are:
+//   - none -> delete only: the new table is added, the deletes are also applied to it.
+//   - delete only -> write only: all the writes are also applied to the new table.
+//   - write only -> write reorganization: the rows are copied to the new table. Then the new table
+//     replaces the original table, and gets its ID.
+//   - delete reorganization -> done: the writes are no longer applied to the original table.
+//     It is needed for the TiDB servers which still use the original table in the previous schema version.
+func (w *worker) onRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	if job.IsRollingback() {
+		return rollbackRebuildTable(d, t, job)
+	}
+	var (
+		newTableID int64
+		dropPK     bool
+		pkSpecs    []*ast.IndexPartSpecification
+		pkOption   *ast.IndexOption
+	)
+	if err := job.DecodeArgs(&newTableID, &dropPK, &pkSpecs, &pkOption); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	originalState := job.SchemaState
+	switch job.SchemaState {
+	case model.StateNone:
+		// none -> delete only
+		newTblInfo, mappingIdx, err := buildRebuildTableInfo(tblInfo, newTableID, dropPK, pkSpecs, pkOption)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		tblInfo.Rebuild = &model.TableRebuildInfo{
+			Table:        newTblInfo,
+			OldTableID:   tblInfo.ID,
+			MappingIndex: mappingIdx,
+			DDLState:     model.StateDeleteOnly,
+		}
+		job.SchemaState = model.StateDeleteOnly
+		metrics.GetBackfillProgressByLabel(metrics.LblRebuildTable, job.SchemaName, tblInfo.Name.String()).Set(0)
+		ver, err = updateVersionAndTableInfoWithCheck(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		tblInfo.Rebuild.DDLState = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		tblInfo.Rebuild.DDLState = model.StateWriteReorganization
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, originalState != job.SchemaState)
+	case model.StateWriteReorganization:
+		tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		var done bool
+		done, ver, err = doRebuildTableReorgWork(w, d, t, job, tbl)
+		if !done {
+			return ver, err
+		}
+		failpoint.Inject("rebuildTableBeforeSwitch", func(val failpoint.Value) {
+			if val.(bool) {
+				failpoint.Return(ver, errors.New("Injected error by rebuildTableBeforeSwitch"))
+			}
+		})
+
+		// All the rows are copied, the new table replaces the original table.
+		ri := tblInfo.Rebuild
+		originTblInfo := tblInfo.Clone()
+		originTblInfo.Rebuild = nil
+		newTblInfo := ri.Table
+		newTblInfo.State = model.StatePublic
+		for _, idx := range newTblInfo.Indices {
+			idx.State = model.StatePublic
+		}
+		newTblInfo.Rebuild = &model.TableRebuildInfo{
+			Table:        originTblInfo,
+			OldTableID:   ri.OldTableID,
+			MappingIndex: ri.MappingIndex,
+			DDLState:     model.StateDeleteReorganization,
+		}
+		if err = changeTableID(t, job, ri.OldTableID, newTblInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.TableID = newTblInfo.ID
+		// used by updateSchemaVersion for the table ID change
+		job.CtxVars = []interface{}{ri.OldTableID}
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(d, t, job, newTblInfo, true)
+	case model.StateDeleteReorganization:
+		oldTableID := tblInfo.Rebuild.OldTableID
+		tblInfo.Rebuild = nil
+		ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionRebuildTable, TableInfo: tblInfo})
+		// A background job will be created to delete the original table, including the mapping index.
+		job.Args = []interface{}{[]int64{oldTableID}, oldTableID, int64(0)}
+	default:
+		err = dbterror.ErrInvalidDDLState.GenWithStackByArgs("table", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// rollbackRebuildTable drops the new table and the mapping index of a table rebuild.
+func rollbackRebuildTable(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	ri := tblInfo.Rebuild
+	if ri == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrCancelledDDLJob
+	}
+	tblInfo.Rebuild = nil
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	var mappingIndexID int64
+	if ri.MappingIndex != nil {
+		mappingIndexID = ri.MappingIndex.ID
+	}
+	// A background job will be created to delete the new table and the mapping index.
+	job.Args = []interface{}{[]int64{ri.Table.ID}, ri.OldTableID, mappingIndexID}
+	return ver, nil
+}
+
+// buildRebuildTableInfo builds the info of the table rebuilt from tblInfo, with the new table ID and the primary key changes.
+// If only one of the tables has a clustered index, it also returns the mapping index and allocates its ID from tblInfo.
+func buildRebuildTableInfo(tblInfo *model.TableInfo, newTableID int64, dropPK bool,
+	pkSpecs []*ast.IndexPartSpecification, pkOption *ast.IndexOption) (*model.TableInfo, *model.IndexInfo, error) {
+	newTblInfo := tblInfo.Clone()
+	newTblInfo.ID = newTableID
+	newTblInfo.Rebuild = nil
+	if dropPK {
+		if newTblInfo.PKIsHandle {
+			newTblInfo.GetPkColInfo().DelFlag(mysql.PriKeyFlag)
+			newTblInfo.PKIsHandle = false
+		} else if pkInfo := tables.FindPrimaryIndex(newTblInfo); pkInfo != nil {
+			DropIndexColumnFlag(newTblInfo, pkInfo)
+			removeIndexInfo(newTblInfo, pkInfo)
+			newTblInfo.IsCommonHandle = false
+			newTblInfo.CommonHandleVersion = 0
+		}
+	}
+	if len(pkSpecs) > 0 {
+		lastCol, err := CheckPKOnGeneratedColumn(newTblInfo, pkSpecs)
+		if err != nil {
+			return nil, nil, err
+		}
+		pkInfo, err := BuildIndexInfo(nil, newTblInfo.Columns, model.NewCIStr(mysql.PrimaryKeyName),
+			true, true, false, pkSpecs, pkOption, model.StatePublic)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		for _, idxCol := range pkInfo.Columns {
+			newTblInfo.Columns[idxCol.Offset].AddFlag(mysql.PriKeyFlag | mysql.NotNullFlag)
+		}
+		clustered := pkOption != nil && pkOption.PrimaryKeyTp == model.PrimaryKeyTypeClustered
+		if clustered && isSingleIntPK(&ast.Constraint{Keys: pkSpecs}, lastCol) {
+			if pkInfo.Invisible {
+				return nil, nil, dbterror.ErrPKIndexCantBeInvisible
+			}
+			newTblInfo.PKIsHandle = true
+		} else {
+			pkInfo.ID = AllocateIndexID(newTblInfo)
+			newTblInfo.Indices = append(newTblInfo.Indices, pkInfo)
+			if clustered {
+				newTblInfo.IsCommonHandle = true
+				newTblInfo.CommonHandleVersion = 1
+			}
+			if err = checkInvisibleIndexOnPK(newTblInfo); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if newTblInfo.HasClusteredIndex() && newTblInfo.ShardRowIDBits > 0 {
+		return nil, nil, dbterror.ErrUnsupportedShardRowIDBits
+	}
+	// The new table is not public until it replaces the original table.
+	newTblInfo.State = model.StateWriteReorganization
+	for _, idx := range newTblInfo.Indices {
+		idx.State = model.StateWriteReorganization
+	}
+	if tblInfo.HasClusteredIndex() == newTblInfo.HasClusteredIndex() {
+		return newTblInfo, nil, nil
+	}
+
+	clusteredTbl := tblInfo
+	if !clusteredTbl.HasClusteredIndex() {
+		clusteredTbl = newTblInfo
+	}
+	var mappingCols []*model.IndexColumn
+	if clusteredTbl.PKIsHandle {
+		col := clusteredTbl.GetPkColInfo()
+		mappingCols = []*model.IndexColumn{{Name: col.Name, Offset: col.Offset, Length: types.UnspecifiedLength}}
+	} else {
+		for _, idxCol := range tables.FindPrimaryIndex(clusteredTbl).Columns {
+			// The mapping index keeps the whole values, a prefix of them may be ambiguous.
+			col := idxCol.Clone()
+			col.Length = types.UnspecifiedLength
+			mappingCols = append(mappingCols, col)
+		}
+	}
+	mappingIdx := &model.IndexInfo{
+		ID:      AllocateIndexID(tblInfo),
+		Name:    model.NewCIStr(rebuildMappingIndexName),
+		Columns: mappingCols,
+		Unique:  true,
+		State:   model.StateWriteReorganization,
+		Tp:      model.IndexTypeBtree,
+	}
+	newTblInfo.MaxIndexID = mathutil.Max(newTblInfo.MaxIndexID, tblInfo.MaxIndexID)
+	return newTblInfo, mappingIdx, nil
+}
+
+func doRebuildTableReorgWork(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table) (done bool, ver int64, err error) {
+	job.ReorgMeta.ReorgTp = model.ReorgTypeTxn
+	sctx, err1 := w.sessPool.Get()
+	if err1 != nil {
+		return false, ver, errors.Trace(err1)
+	}
+	defer w.sessPool.Put(sctx)
+	rh := newReorgHandler(sess.NewSession(sctx))
+	dbInfo, err := t.GetDatabase(job.SchemaID)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	physTbl, ok := tbl.(table.PhysicalTable)
+	if !ok {
+		return false, ver, dbterror.ErrCancelledDDLJob.GenWithStack("unexpected table type %T of the rebuilt table", tbl)
+	}
+	reorgInfo, err := getReorgInfo(d.jobContext(job.ID), d, rh, job, dbInfo, tbl, BuildElements(tbl.Meta().Columns[0], nil), false)
+	if err != nil || reorgInfo == nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+	err = w.runReorgJob(reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+		defer tidbutil.Recover(metrics.LabelDDL, "doRebuildTableReorgWork",
+			func() {
+				reorgErr = dbterror.ErrCancelledDDLJob.GenWithStack("rebuild table `%v` panic", tbl.Meta().Name)
+			}, false)
+		return w.writePhysicalTableRecord(w.sessPool, physTbl, typeRebuildTableWorker, reorgInfo)
+	})
+	if err != nil {
+		if dbterror.ErrPausedDDLJob.Equal(err) {
+			return false, ver, nil
+		}
+		if dbterror.ErrWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) || dbterror.ErrNotOwner.Equal(err) {
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := rh.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.BgLogger().Warn("rebuild table job failed, RemoveDDLReorgHandle failed, can't convert job to rollback", zap.String("category", "ddl"),
+				zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.BgLogger().Warn("rebuild table job failed, convert job to rollback", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Error(err))
+		job.State = model.JobStateRollingback
+		return false, ver, errors.Trace(err)
+	}
+	return true, ver, nil
+}
+
+type rebuildTableWorker struct {
+	*backfillCtx
+	rowDecoder  *decoder.RowDecoder
+	rowMap      map[int64]types.Datum
+	defaultVals []types.Datum
+	// The following attributes are used to reduce memory allocation.
+	handles []kv.Handle
+	rows    [][]types.Datum
+}
+
+func newRebuildTableWorker(sessCtx sessionctx.Context, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column, reorgInfo *reorgInfo, jc *JobContext) *rebuildTableWorker {
+	return &rebuildTableWorker{
+		backfillCtx: newBackfillCtx(reorgInfo.d, id, sessCtx, reorgInfo.SchemaName, t, jc, "rebuild_table_rate", false),
+		rowDecoder:  decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:      make(map[int64]types.Datum, len(decodeColMap)),
+		defaultVals: make([]types.Datum, len(t.Cols())),
+	}
+}
+
+// BackfillData copies the rows in the handle range to the new table in a transaction.
+func (w *rebuildTableWorker) BackfillData(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	ctx := kv.WithInternalSourceType(context.Background(), w.jobContext.ddlJobSourceType())
+	errInTxn = kv.RunInNewTxn(ctx, w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, handleRange.priority)
+		if tagger := w.GetCtx().getResourceGroupTaggerForTopSQL(handleRange.getJobID()); tagger != nil {
+			txn.SetOption(kv.ResourceGroupTagger, tagger)
+		}
+
+		nextKey, taskDone, err := w.fetchRows(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		for i, h := range w.handles {
+			taskCtx.scanCount++
+			added, err := tables.BackfillRebuildRecord(ctx, w.sessCtx, txn, w.table, h, w.rows[i])
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if added {
+				taskCtx.addedCount++
+			}
+		}
+		taskCtx.writtenBytes = txn.Size()
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "BackfillData", 3000)
+	return
+}
+
+// fetchRows decodes a batch of the rows in the task range, in the snapshot of the transaction.
+func (w *rebuildTableWorker) fetchRows(txn kv.Transaction, taskRange reorgBackfillTask) (kv.Key, bool, error) {
+	w.handles = w.handles[:0]
+	w.rows = w.rows[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	sysTZ := w.sessCtx.GetSessionVars().StmtCtx.TimeZone
+	cols := w.table.Cols()
+	var lastAccessedHandle kv.Key
+	err := iterateSnapshotKeys(w.jobContext, w.sessCtx.GetStore(), taskRange.priority, w.table.RecordPrefix(), txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			if taskRange.endInclude {
+				taskDone = recordKey.Cmp(taskRange.endKey) > 0
+			} else {
+				taskDone = recordKey.Cmp(taskRange.endKey) >= 0
+			}
+			if taskDone || len(w.handles) >= w.batchCnt {
+				return false, nil
+			}
+
+			for id := range w.rowMap {
+				delete(w.rowMap, id)
+			}
+			_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, sysTZ, w.rowMap)
+			if err != nil {
+				return false, errors.Trace(err)
+			}
+			row := make([]types.Datum, len(cols))
+			for _, col := range cols {
+				d, ok := w.rowMap[col.ID]
+				if !ok {
+					// The column is added after the row is written.
+					if d, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals); err != nil {
+						return false, errors.Trace(err)
+					}
+				}
+				row[col.Offset] = d
+			}
+			w.handles = append(w.handles, handle)
+			w.rows = append(w.rows, row)
+
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.handles) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("txn fetches handle info", zap.String("category", "ddl"), zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return getNextHandleKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
+func (w *rebuildTableWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+func (*rebuildTableWorker) String() string {
+	return typeRebuildTableWorker.String()
+}
+
+func (w *rebuildTableWorker) GetCtx() *backfillCtx {
+	return w.backfillCtx
+}
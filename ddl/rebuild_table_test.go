@@ -0,0 +1,162 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/ddl/util/callback"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/testkit/external"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebuildTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// FORCE and ALGORITHM=COPY copy the rows into a new table.
+	tk.MustExec("create table t (a int, b varchar(20), c int, key (b), unique key (c))")
+	tk.MustExec(`insert into t values (1, "1", 1), (2, "2", 2), (3, "3", null), (3, "3", null)`)
+	oldID := external.GetTableByName(t, tk, "test", "t").Meta().ID
+	tk.MustExec("alter table t force")
+	newID := external.GetTableByName(t, tk, "test", "t").Meta().ID
+	require.NotEqual(t, oldID, newID)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t use index (b) order by a").Check(testkit.Rows("1 1 1", "2 2 2", "3 3 <nil>", "3 3 <nil>"))
+	tk.MustExec("alter table t algorithm=copy")
+	require.NotEqual(t, newID, external.GetTableByName(t, tk, "test", "t").Meta().ID)
+	tk.MustExec("admin check table t")
+	tk.MustGetErrCode("alter table t force, add column d int", errno.ErrUnsupportedDDLOperation)
+	tk.MustQuery("select count(*) from information_schema.ddl_jobs where table_name = 't' and job_type = 'rebuild table'").Check(testkit.Rows("2"))
+
+	// A duplicate or NULL primary key rolls the job back.
+	tk.MustGetErrCode("alter table t add primary key (a) clustered", errno.ErrDupEntry)
+	tk.MustGetErrCode("alter table t add primary key (c) clustered", errno.ErrBadNull)
+	require.False(t, external.GetTableByName(t, tk, "test", "t").Meta().HasClusteredIndex())
+	tk.MustExec("admin check table t")
+	tk.MustExec("delete from t where c is null")
+
+	// Add a clustered primary key to a table without primary key.
+	tk.MustExec("alter table t add primary key (b) clustered")
+	tblInfo := external.GetTableByName(t, tk, "test", "t").Meta()
+	require.True(t, tblInfo.IsCommonHandle)
+	require.Nil(t, tblInfo.Rebuild)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t where b = '2'").Check(testkit.Rows("2 2 2"))
+	tk.MustGetErrCode(`insert into t values (4, "2", 4)`, errno.ErrDupEntry)
+	tk.MustQuery("show create table t").Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(20) NOT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  UNIQUE KEY `c` (`c`),\n" +
+		"  PRIMARY KEY (`b`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// Swap the clustered primary key.
+	tk.MustExec("alter table t drop primary key, add primary key (a) clustered")
+	tblInfo = external.GetTableByName(t, tk, "test", "t").Meta()
+	require.True(t, tblInfo.PKIsHandle)
+	require.False(t, tblInfo.IsCommonHandle)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t where a = 1").Check(testkit.Rows("1 1 1"))
+
+	// Drop the clustered primary key, the rows get a _tidb_rowid.
+	tk.MustExec("alter table t drop primary key")
+	require.False(t, external.GetTableByName(t, tk, "test", "t").Meta().HasClusteredIndex())
+	tk.MustExec("admin check table t")
+	tk.MustExec(`insert into t values (1, "1", 3)`)
+	tk.MustQuery("select a, b, c from t order by c").Check(testkit.Rows("1 1 1", "2 2 2", "1 1 3"))
+
+	// Unsupported tables.
+	tk.MustExec("create global temporary table tmp (a int) on commit delete rows")
+	tk.MustGetErrCode("alter table tmp force", errno.ErrOptOnTemporaryTable)
+	tk.MustExec("create table tp (a int, b int) partition by hash (a) partitions 2")
+	tk.MustExec("insert into tp values (1, 1), (2, 2)")
+	tk.MustExec("alter table tp force")
+	tk.MustExec("admin check table tp")
+	tk.MustGetErrCode("alter table tp add primary key (a) clustered", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table tr (a bigint auto_random primary key)")
+	tk.MustGetErrCode("alter table tr drop primary key", errno.ErrInvalidAutoRandom)
+}
+
+func TestRebuildTableConcurrentDML(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "RebuildTableConcurrentDML"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	tk.MustExec("create table t (a int, b varchar(20), c int, key (c))")
+	tk.MustExec(`insert into t values (1, "1", 1), (2, "2", 2), (3, "3", 3), (4, "4", 4)`)
+
+	dom := domain.GetDomain(tk.Session())
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &callback.TestDDLCallback{Do: dom}
+	dom.DDL().SetHook(hook)
+
+	wait := make(chan bool)
+	defer close(wait)
+	states := []model.SchemaState{model.StateWriteOnly, model.StateWriteReorganization, model.StateDeleteReorganization}
+	prevState := model.StateNone
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if job.Type != model.ActionRebuildTable || job.SchemaState == prevState {
+			return
+		}
+		for _, state := range states {
+			if job.SchemaState == state {
+				prevState = job.SchemaState
+				<-wait
+				<-wait
+			}
+		}
+	}
+	alterErr := make(chan error, 1)
+	go backgroundExec(store, schemaName, "alter table t add primary key (a) clustered", alterErr)
+
+	// write only
+	wait <- true
+	tk.MustExec(`insert into t values (5, "5", 5)`)
+	tk.MustExec(`update t set c = 12 where a = 2`)
+	tk.MustExec(`delete from t where a = 3`)
+	tk.MustExec("admin check table t")
+	wait <- true
+	// write reorganization, before the rows are copied
+	wait <- true
+	tk.MustExec(`insert into t values (6, "6", 6)`)
+	tk.MustExec(`update t set b = "44" where a = 4`)
+	tk.MustGetErrCode(`insert into t values (7, "7", 7), (8, "8", null), (8, "8b", 8)`, errno.ErrDupEntry)
+	tk.MustExec("admin check table t")
+	wait <- true
+	// delete reorganization, the new table is public
+	wait <- true
+	tk.MustExec(`delete from t where a = 1`)
+	tk.MustExec(`insert into t values (1, "1b", 1)`)
+	tk.MustExec("admin check table t")
+	wait <- true
+	require.NoError(t, <-alterErr)
+
+	require.True(t, external.GetTableByName(t, tk, schemaName, "t").Meta().PKIsHandle)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows(
+		"1 1b 1", "2 2 12", "4 44 4", "5 5 5", "6 6 6"))
+	tk.MustQuery("select a from t use index (c) where c > 4 order by c").Check(testkit.Rows("5", "6", "2"))
+}
@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
@@ -46,6 +47,7 @@ import (
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mathutil"
 	"github.com/pingcap/tidb/util/mock"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/sqlexec"
@@ -53,6 +55,7 @@ import (
 	clientv3 "go.etcd.io/etcd/client/v3"
 	atomicutil "go.uber.org/atomic"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 const rowCountEtcdPath = "distAddIndex"
@@ -75,6 +78,15 @@ type reorgCtx struct {
 		warningsCount map[errors.ErrorID]int64
 	}
 
+	// params are the job parameters set by `ADMIN ALTER DDL JOBS`, they are
+	// reloaded from the job in every DDL round, see updateJobParams.
+	params struct {
+		concurrency  atomicutil.Int32
+		batchSize    atomicutil.Int32
+		runWindow    atomicutil.Pointer[model.DDLRunWindow]
+		writeLimiter *rate.Limiter
+	}
+
 	references atomicutil.Int32
 }
 
@@ -109,6 +121,100 @@ func (rc *reorgCtx) isReorgPaused() bool {
 		int32(model.JobStatePausing) == atomic.LoadInt32((*int32)(&rc.jobState))
 }
 
+// updateJobParams makes the running backfill workers use the parameters in the reorg meta of the job.
+func (rc *reorgCtx) updateJobParams(reorgMeta *model.DDLReorgMeta) {
+	rc.params.concurrency.Store(int32(reorgMeta.Concurrency))
+	rc.params.batchSize.Store(int32(reorgMeta.BatchSize))
+	rc.params.runWindow.Store(reorgMeta.RunWindow)
+	limit, burst := rate.Inf, 0
+	if reorgMeta.MaxWriteSpeed > 0 {
+		limit, burst = rate.Limit(reorgMeta.MaxWriteSpeed), int(reorgMeta.MaxWriteSpeed)
+	}
+	if rc.params.writeLimiter.Limit() != limit || rc.params.writeLimiter.Burst() != burst {
+		rc.params.writeLimiter.SetLimit(limit)
+		rc.params.writeLimiter.SetBurst(burst)
+	}
+}
+
+// getWorkerCount gets the expected number of the backfill workers of the job.
+func (rc *reorgCtx) getWorkerCount() int {
+	if rc != nil {
+		if cnt := rc.params.concurrency.Load(); cnt > 0 {
+			return int(cnt)
+		}
+	}
+	return int(variable.GetDDLReorgWorkerCounter())
+}
+
+// getBatchSize gets the number of rows backfilled in a transaction of the job.
+func (rc *reorgCtx) getBatchSize() int {
+	if rc != nil {
+		if size := rc.params.batchSize.Load(); size > 0 {
+			return int(size)
+		}
+	}
+	return int(variable.GetDDLReorgBatchSize())
+}
+
+// waitWriteQuota blocks until the bytes are allowed to be written by the max write speed of the job.
+func (rc *reorgCtx) waitWriteQuota(ctx context.Context, bytes int) error {
+	if rc == nil {
+		return nil
+	}
+	limiter := rc.params.writeLimiter
+	for bytes > 0 && limiter.Limit() != rate.Inf {
+		// WaitN fails if n exceeds the burst, so the bytes are split into pieces.
+		n := mathutil.Min(bytes, limiter.Burst())
+		if n <= 0 {
+			return nil
+		}
+		if err := limiter.WaitN(ctx, n); err != nil {
+			return errors.Trace(err)
+		}
+		bytes -= n
+	}
+	return nil
+}
+
+// runWindowCheckInterval is the interval to check whether a job waiting for its run window can continue.
+var runWindowCheckInterval = time.Second
+
+// waitRunWindow blocks until the current time is in the run window of the job. It returns an error
+// if the job is cancelled or paused, or the DDL owner changes during waiting.
+func (dc *ddlCtx) waitRunWindow(jobID int64) error {
+	rc := dc.getReorgCtx(jobID)
+	if rc == nil {
+		return nil
+	}
+	logged := false
+	for {
+		window := rc.params.runWindow.Load()
+		if window == nil {
+			return nil
+		}
+		in, err := window.Contains(time.Now())
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if in {
+			return nil
+		}
+		if !logged {
+			logutil.BgLogger().Info("reorg is out of the run window, wait", zap.String("category", "ddl"),
+				zap.Int64("job ID", jobID), zap.Stringer("run window", window))
+			logged = true
+		}
+		if err := dc.isReorgRunnable(jobID, false); err != nil {
+			return err
+		}
+		select {
+		case <-dc.ctx.Done():
+			return dbterror.ErrInvalidWorker.GenWithStack("worker is closed")
+		case <-time.After(runWindowCheckInterval):
+		}
+	}
+}
+
 func (rc *reorgCtx) setRowCount(count int64) {
 	atomic.StoreInt64(&rc.rowCount, count)
 }
@@ -246,11 +352,16 @@ func (w *worker) runReorgJob(reorgInfo *reorgInfo, tblInfo *model.TableInfo,
 		}
 
 		rc = w.newReorgCtx(reorgInfo.Job.ID, reorgInfo.Job.GetRowCount())
+		rc.updateJobParams(job.ReorgMeta)
 		w.wg.Add(1)
 		go func() {
 			defer w.wg.Done()
 			rc.doneCh <- f()
 		}()
+	} else {
+		// The job is reloaded from the job table in every round, which may have
+		// new parameters set by `ADMIN ALTER DDL JOBS`.
+		rc.updateJobParams(job.ReorgMeta)
 	}
 
 	waitTimeout := defaultWaitReorgTimeout
@@ -398,8 +509,11 @@ func updateBackfillProgress(w *worker, reorgInfo *reorgInfo, tblInfo *model.Tabl
 		metrics.GetBackfillProgressByLabel(label, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
 	case model.ActionModifyColumn:
 		metrics.GetBackfillProgressByLabel(metrics.LblModifyColumn, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
-	case model.ActionReorganizePartition:
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
 		metrics.GetBackfillProgressByLabel(metrics.LblReorgPartition, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
+	case model.ActionRebuildTable:
+		metrics.GetBackfillProgressByLabel(metrics.LblRebuildTable, reorgInfo.SchemaName, tblInfo.Name.String()).Set(progress * 100)
 	}
 }
 
@@ -1374,3 +1374,173 @@ func TestReorgPartitionData(t *testing.T) {
 	tk.MustQuery(`select * from t`).Sort().Check(testkit.Rows("0 Zero value! 0 2022-02-30 00:00:00"))
 	tk.MustExec(`admin check table t`)
 }
+
+func TestRebuildPartition(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "RebuildPartition"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	getPartitionIDs := func(tableName string) []int64 {
+		tbl, err := domain.GetDomain(tk.Session()).InfoSchema().TableByName(model.NewCIStr(schemaName), model.NewCIStr(tableName))
+		require.NoError(t, err)
+		ids := make([]int64, 0, len(tbl.Meta().Partition.Definitions))
+		for _, def := range tbl.Meta().Partition.Definitions {
+			ids = append(ids, def.ID)
+		}
+		return ids
+	}
+
+	tk.MustExec(`create table t (a int PRIMARY KEY, b varchar(255), c int, key (b), key (c,b)) partition by range (a) (partition p0 values less than (10), partition p1 values less than (20), partition pMax values less than (MAXVALUE))`)
+	tk.MustExec(`insert into t values (1,"1",1), (12,"12",21), (23,"23",32), (34,"34",43)`)
+	oldIDs := getPartitionIDs("t")
+	tk.MustExec(`alter table t rebuild partition p1, pMax`)
+	newIDs := getPartitionIDs("t")
+	require.Equal(t, oldIDs[0], newIDs[0])
+	require.NotEqual(t, oldIDs[1], newIDs[1])
+	require.NotEqual(t, oldIDs[2], newIDs[2])
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select a from t partition (pMax)`).Sort().Check(testkit.Rows("23", "34"))
+	tk.MustQuery(`select * from t where b = "12"`).Check(testkit.Rows("12 12 21"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  KEY `c` (`c`,`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE (`a`)\n" +
+		"(PARTITION `p0` VALUES LESS THAN (10),\n" +
+		" PARTITION `p1` VALUES LESS THAN (20),\n" +
+		" PARTITION `pMax` VALUES LESS THAN (MAXVALUE))"))
+	tk.MustExec(`alter table t optimize partition all`)
+	newIDs = getPartitionIDs("t")
+	require.NotEqual(t, oldIDs[0], newIDs[0])
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("4"))
+	tk.MustGetErrCode(`alter table t rebuild partition p2`, errno.ErrUnknownPartition)
+	tk.MustGetErrCode(`alter table t rebuild partition p0, p0`, errno.ErrSameNamePartition)
+
+	tk.MustExec(`create table tl (a int, b int, key (b)) partition by list (a) (partition p0 values in (1,2,3), partition p1 values in (4,5,6))`)
+	tk.MustExec(`insert into tl values (1,1), (2,2), (5,5)`)
+	tk.MustExec(`alter table tl rebuild partition p0`)
+	tk.MustExec(`admin check table tl`)
+	tk.MustQuery(`select * from tl partition (p0)`).Sort().Check(testkit.Rows("1 1", "2 2"))
+
+	tk.MustExec(`create table th (a int, b int, key (b)) partition by hash (a) partitions 3`)
+	tk.MustExec(`insert into th values (1,1), (2,2), (3,3), (4,4), (5,5), (6,6)`)
+	oldIDs = getPartitionIDs("th")
+	tk.MustExec(`alter table th rebuild partition p1`)
+	newIDs = getPartitionIDs("th")
+	require.Equal(t, oldIDs[0], newIDs[0])
+	require.NotEqual(t, oldIDs[1], newIDs[1])
+	require.Equal(t, oldIDs[2], newIDs[2])
+	tk.MustExec(`admin check table th`)
+	tk.MustQuery(`select a from th partition (p1)`).Sort().Check(testkit.Rows("1", "4"))
+	tk.MustQuery(`select a from th where a = 4`).Check(testkit.Rows("4"))
+
+	tk.MustExec(`create table tn (a int)`)
+	tk.MustGetErrCode(`alter table tn rebuild partition all`, errno.ErrPartitionMgmtOnNonpartitioned)
+}
+
+func TestAlterTablePartitioning(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "AlterTablePartitioning"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	tk.MustExec(`create table t (a int unsigned PRIMARY KEY AUTO_INCREMENT, b varchar(255), c int, key (b), key (c,b))`)
+	tk.MustExec(`insert into t values (1,"1",1), (12,"12",21),(23,"23",32),(34,"34",43),(45,"45",54),(56,"56",65)`)
+	tk.MustGetErrCode(`alter table t remove partitioning`, errno.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode(`alter table t partition by hash(c) partitions 3`, errno.ErrUniqueKeyNeedAllFieldsInPf)
+
+	ctx := tk.Session()
+	is := domain.GetDomain(ctx).InfoSchema()
+	oldTbl, err := is.TableByName(model.NewCIStr(schemaName), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tk.MustExec(`alter table t partition by range (a) (partition p0 values less than (20), partition pMax values less than (MAXVALUE))`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select partition_name, partition_method, partition_expression from information_schema.partitions where table_schema = 'AlterTablePartitioning' and table_name = 't'`).Sort().Check(testkit.Rows(
+		"p0 RANGE `a`", "pMax RANGE `a`"))
+	tk.MustQuery(`select a from t partition (p0)`).Sort().Check(testkit.Rows("1", "12"))
+	tk.MustQuery(`select count(*) from t partition (pMax)`).Check(testkit.Rows("4"))
+	is = domain.GetDomain(ctx).InfoSchema()
+	tbl, err := is.TableByName(model.NewCIStr(schemaName), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.NotEqual(t, oldTbl.Meta().ID, tbl.Meta().ID)
+	noNewTablesAfter(t, tk, ctx, tbl)
+
+	tk.MustExec(`alter table t partition by key (a) partitions 3`)
+	tk.MustExec(`admin check table t`)
+	tk.MustQuery(`select * from t where a = 23`).Check(testkit.Rows("23 23 32"))
+	tk.MustQuery(`select a from t`).Sort().Check(testkit.Rows("1", "12", "23", "34", "45", "56"))
+	tk.MustQuery(`select partition_name, partition_method from information_schema.partitions where table_schema = 'AlterTablePartitioning' and table_name = 't'`).Sort().Check(testkit.Rows(
+		"p0 KEY", "p1 KEY", "p2 KEY"))
+
+	tk.MustExec(`alter table t remove partitioning`)
+	tk.MustExec(`admin check table t`)
+	tk.MustExec(`insert into t (b, c) values ("new", 1)`)
+	tk.MustQuery(`select a > 56 from t where b = "new"`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select count(*) from information_schema.partitions where table_schema = 'AlterTablePartitioning' and table_name = 't' and partition_name is not null`).Check(testkit.Rows("0"))
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("7"))
+	tk.MustGetErrCode(`alter table t remove partitioning`, errno.ErrPartitionMgmtOnNonpartitioned)
+	is = domain.GetDomain(ctx).InfoSchema()
+	tbl, err = is.TableByName(model.NewCIStr(schemaName), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.Nil(t, tbl.Meta().Partition)
+	noNewTablesAfter(t, tk, ctx, tbl)
+}
+
+func TestAlterTablePartitioningRollback(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	schemaName := "AlterTablePartitioningRollback"
+	tk.MustExec("create database " + schemaName)
+	tk.MustExec("use " + schemaName)
+	tk.MustExec(`create table t (a int unsigned PRIMARY KEY, b varchar(255), c int, key (b), key (c,b))`)
+	tk.MustExec(`insert into t values (1,"1",1), (12,"12",21),(23,"23",32),(34,"34",43),(45,"45",54),(56,"56",65)`)
+	tk.MustExec(`create table tp (a int unsigned PRIMARY KEY, b varchar(255), c int, key (b), key (c,b)) partition by hash(a) partitions 2`)
+	tk.MustExec(`insert into tp select * from t`)
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/ddl/reorgPartitionAfterDataCopy", `return(true)`))
+	defer func() {
+		err := failpoint.Disable("github.com/pingcap/tidb/ddl/reorgPartitionAfterDataCopy")
+		require.NoError(t, err)
+	}()
+	tk.MustExecToErr(`alter table t partition by range (a) (partition p0 values less than (20), partition pMax values less than (MAXVALUE))`)
+	tk.MustExecToErr(`alter table tp remove partitioning`)
+	tk.MustExec(`admin check table t`)
+	tk.MustExec(`admin check table tp`)
+	tk.MustQuery(`show create table t`).Check(testkit.Rows("" +
+		"t CREATE TABLE `t` (\n" +
+		"  `a` int(10) unsigned NOT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  KEY `c` (`c`,`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery(`show create table tp`).Check(testkit.Rows("" +
+		"tp CREATE TABLE `tp` (\n" +
+		"  `a` int(10) unsigned NOT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`a`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `b` (`b`),\n" +
+		"  KEY `c` (`c`,`b`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY HASH (`a`) PARTITIONS 2"))
+	tk.MustQuery(`select count(*) from t`).Check(testkit.Rows("6"))
+	tk.MustQuery(`select count(*) from tp`).Check(testkit.Rows("6"))
+
+	ctx := tk.Session()
+	is := domain.GetDomain(ctx).InfoSchema()
+	tbl, err := is.TableByName(model.NewCIStr(schemaName), model.NewCIStr("t"))
+	require.NoError(t, err)
+	require.Nil(t, tbl.Meta().Partition)
+	noNewTablesAfter(t, tk, ctx, tbl)
+	tbl, err = is.TableByName(model.NewCIStr(schemaName), model.NewCIStr("tp"))
+	require.NoError(t, err)
+	noNewTablesAfter(t, tk, ctx, tbl)
+}
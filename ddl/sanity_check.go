@@ -100,12 +100,23 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 		}
 		return len(physicalTableIDs) + 1, nil
 	case model.ActionDropTablePartition, model.ActionTruncateTablePartition,
-		model.ActionReorganizePartition:
+		model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return 0, errors.Trace(err)
 		}
 		return len(physicalTableIDs), nil
+	case model.ActionRebuildTable:
+		var physicalTableIDs []int64
+		var oldTableID, mappingIndexID int64
+		if err := job.DecodeArgs(&physicalTableIDs, &oldTableID, &mappingIndexID); err != nil {
+			return 0, errors.Trace(err)
+		}
+		if mappingIndexID != 0 {
+			return len(physicalTableIDs) + 1, nil
+		}
+		return len(physicalTableIDs), nil
 	case model.ActionAddIndex, model.ActionAddPrimaryKey:
 		var indexID int64
 		var ifExists bool
@@ -257,6 +257,21 @@ func (d *Checker) CreateView(ctx sessionctx.Context, stmt *ast.CreateViewStmt) e
 	return nil
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	err := d.realDDL.CreateMaterializedView(ctx, stmt)
+	if err != nil {
+		return err
+	}
+	err = d.tracker.CreateMaterializedView(ctx, stmt)
+	if err != nil {
+		panic(err)
+	}
+
+	d.checkTableInfo(ctx, stmt.ViewName.Schema, stmt.ViewName.Name)
+	return nil
+}
+
 // DropTable implements the DDL interface.
 func (d *Checker) DropTable(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
 	err = d.realDDL.DropTable(ctx, stmt)
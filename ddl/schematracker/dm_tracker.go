@@ -290,6 +290,25 @@ func (d SchemaTracker) CreateView(ctx sessionctx.Context, s *ast.CreateViewStmt)
 	return d.CreateTableWithInfo(ctx, s.ViewName.Schema, tbInfo, onExist)
 }
 
+// CreateMaterializedView implements the DDL interface.
+func (d SchemaTracker) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	schema := d.SchemaByName(s.ViewName.Schema)
+	if schema == nil {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	tbInfo, err := ddl.BuildMaterializedViewTableInfo(ctx, s, schema.Charset, schema.Collate)
+	if err != nil {
+		return err
+	}
+
+	onExist := ddl.OnExistError
+	if s.IfNotExists {
+		onExist = ddl.OnExistIgnore
+	}
+
+	return d.CreateTableWithInfo(ctx, schema.Name, tbInfo, onExist)
+}
+
 // DropTable implements the DDL interface.
 func (d SchemaTracker) DropTable(_ sessionctx.Context, stmt *ast.DropTableStmt) (err error) {
 	notExistTables := make([]string, 0, len(stmt.Tables))
@@ -391,11 +410,25 @@ func (d SchemaTracker) createIndex(
 	if err != nil {
 		return err
 	}
+	if keyType == ast.IndexKeyTypeSpatial {
+		if indexOption, err = ddl.BuildSpatialIndexOption(tblInfo.Columns, indexPartSpecifications, indexOption); err != nil {
+			return err
+		}
+	}
 
 	defer d.putTableIfNoError(err, ti.Schema, tblInfo)
 
 	t := tables.MockTableFromMeta(tblInfo)
 
+	if keyType == ast.IndexKeyTypeFullText {
+		if len(indexName.L) == 0 {
+			indexName = ddl.GetName4AnonymousIndex(t, indexPartSpecifications[0].Column.Name, model.NewCIStr(""))
+		}
+		if indexPartSpecifications, indexOption, err = ddl.BuildFullTextIndexOption(tblInfo.Columns, indexPartSpecifications, indexOption); err != nil {
+			return err
+		}
+	}
+
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -525,6 +558,17 @@ func (d SchemaTracker) addColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast
 	}
 	tblInfo.MoveColumnInfo(columnInfo.Offset, offset)
 	columnInfo.State = model.StatePublic
+
+	isPK := mysql.HasPriKeyFlag(columnInfo.GetFlag())
+	if isPK || mysql.HasUniKeyFlag(columnInfo.GetFlag()) {
+		indexInfo, err := ddl.BuildColumnKeyInfo(t, tblInfo.Columns, columnInfo.Name, isPK, model.StatePublic)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		indexInfo.ID = ddl.AllocateIndexID(tblInfo)
+		tblInfo.Indices = append(tblInfo.Indices, indexInfo)
+		ddl.AddIndexColumnFlag(tblInfo, indexInfo)
+	}
 	return nil
 }
 
@@ -943,10 +987,15 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
 				err = d.createIndex(sctx, ident, ast.IndexKeyTypeUnique, model.NewCIStr(constr.Name),
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
+			case ast.ConstraintFulltext:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintForeignKey,
-				ast.ConstraintFulltext,
 				ast.ConstraintCheck:
 			default:
 				// Nothing to do now.
@@ -1035,6 +1084,7 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			ast.AlterTableOptimizePartition,
 			ast.AlterTableRemovePartitioning,
 			ast.AlterTableRepairPartition,
+			ast.AlterTableForce,
 			ast.AlterTableTruncatePartition,
 			ast.AlterTableWriteable,
 			ast.AlterTableExchangePartition,
@@ -1193,6 +1243,26 @@ func (d SchemaTracker) BatchCreateTableWithInfo(ctx sessionctx.Context, schema m
 	return nil
 }
 
+// CreateProcedure implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateProcedure(_ sessionctx.Context, _ *ast.ProcedureInfo) error {
+	return nil
+}
+
+// DropProcedure implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropProcedure(_ sessionctx.Context, _ *ast.DropProcedureStmt) error {
+	return nil
+}
+
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
 // CreatePlacementPolicyWithInfo implements the DDL interface, it's no-op in DM's case.
 func (SchemaTracker) CreatePlacementPolicyWithInfo(_ sessionctx.Context, _ *model.PolicyInfo, _ ddl.OnExist) error {
 	return nil
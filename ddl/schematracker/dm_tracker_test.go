@@ -165,6 +165,22 @@ func TestFullTextIndex(t *testing.T) {
 	tracker := schematracker.NewSchemaTracker(2)
 	tracker.CreateTestDB()
 	execCreate(t, tracker, sql)
+
+	sql = "alter table test.t add column b varchar(255), add fulltext index ft_ab(a, b) with parser ngram"
+	execAlter(t, tracker, sql)
+
+	tblInfo := mustTableByName(t, tracker, "test", "t")
+	require.Equal(t, 2, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		require.True(t, idx.IsFullText())
+	}
+	expected := "CREATE TABLE `t` (\n" +
+		"  `a` text DEFAULT NULL,\n" +
+		"  `b` varchar(255) DEFAULT NULL,\n" +
+		"  FULLTEXT KEY `a` (`a`),\n" +
+		"  FULLTEXT KEY `ft_ab` (`a`,`b`) /*!50100 WITH PARSER `ngram` */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"
+	checkShowCreateTable(t, tblInfo, expected)
 }
 
 func checkShowCreateTable(t *testing.T, tblInfo *model.TableInfo, expected string) {
@@ -0,0 +1,138 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package adminpause
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/ddl/util/callback"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func getRunningJob(t *testing.T, tk *testkit.TestKit, jobID int64) *model.Job {
+	jobs, err := ddl.GetAllDDLJobs(tk.Session())
+	require.NoError(t, err)
+	for _, job := range jobs {
+		if job.ID == jobID {
+			return job
+		}
+	}
+	require.FailNow(t, "job not found", "job ID %d", jobID)
+	return nil
+}
+
+func TestAlterDDLJobParams(t *testing.T) {
+	dom, stmtKit, adminCommandKit := prepareDomain(t)
+	stmtKit.MustExec("create table t(id int, c int)")
+	for i := 0; i < 10; i++ {
+		stmtKit.MustExec(fmt.Sprintf("insert into t values (%d, %d)", i, i))
+	}
+	adminCommandKit.MustExec("set @@time_zone = '+00:00'")
+
+	// A run window which doesn't contain the current time.
+	startHour := (time.Now().UTC().Hour() + 2) % 24
+	runWindow := fmt.Sprintf("%02d:00-%02d:00", startHour, startHour+1)
+
+	var mu sync.Mutex
+	var jobID int64
+	var alterErr error
+	hook := &callback.TestDDLCallback{Do: dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		mu.Lock()
+		defer mu.Unlock()
+		if job.Type != model.ActionAddIndex || job.SchemaState != model.StateDeleteOnly || jobID != 0 {
+			return
+		}
+		jobID = job.ID
+		_, alterErr = adminCommandKit.Exec(fmt.Sprintf(
+			"admin alter ddl jobs %d thread = 2, batch_size = 64, max_write_speed = '1MiB', run_window = '%s'",
+			jobID, runWindow))
+	}
+	d := dom.DDL()
+	originalHook := d.GetHook()
+	defer d.SetHook(originalHook)
+	d.SetHook(hook.Clone())
+
+	done := make(chan error, 1)
+	go func() {
+		done <- stmtKit.ExecToErr("alter table t add index idx(c)")
+	}()
+
+	// The job waits for its run window in the reorganization.
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		if jobID == 0 {
+			return false
+		}
+		job := getRunningJob(t, adminCommandKit, jobID)
+		return job.SchemaState == model.StateWriteReorganization
+	}, 10*time.Second, 50*time.Millisecond)
+	mu.Lock()
+	require.NoError(t, alterErr)
+	mu.Unlock()
+	time.Sleep(time.Second)
+	job := getRunningJob(t, adminCommandKit, jobID)
+	require.Equal(t, model.StateWriteReorganization, job.SchemaState)
+	require.Equal(t, int64(0), job.GetRowCount())
+	require.Equal(t, 2, job.ReorgMeta.Concurrency)
+	require.Equal(t, 64, job.ReorgMeta.BatchSize)
+	require.Equal(t, int64(1024*1024), job.ReorgMeta.MaxWriteSpeed)
+	require.Equal(t, runWindow, job.ReorgMeta.RunWindow.String())
+	select {
+	case err := <-done:
+		require.FailNow(t, "the job should wait for its run window", "err: %v", err)
+	default:
+	}
+
+	// Invalid options don't change the job.
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d thread = 0", jobID), errno.ErrInvalidOptionVal)
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d batch_size = 'a'", jobID), errno.ErrInvalidOptionVal)
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d max_write_speed = 'fast'", jobID), errno.ErrInvalidOptionVal)
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d run_window = '01:00'", jobID), errno.ErrInvalidOptionVal)
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d thread = 1, thread = 2", jobID), errno.ErrDuplicateOption)
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d speed = 1", jobID), errno.ErrUnknownOption)
+	adminCommandKit.MustGetErrCode("admin alter ddl jobs 9999999 thread = 1", errno.ErrDDLJobNotFound)
+	job = getRunningJob(t, adminCommandKit, jobID)
+	require.Equal(t, 2, job.ReorgMeta.Concurrency)
+
+	// The job continues after the run window is removed.
+	adminCommandKit.MustExec(fmt.Sprintf("admin alter ddl jobs %d run_window = '', max_write_speed = 0", jobID))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(30 * time.Second):
+		require.FailNow(t, "the job should continue after the run window is removed")
+	}
+	stmtKit.MustExec("admin check table t")
+	stmtKit.MustQuery("select count(*) from t use index(idx)").Check(testkit.Rows("10"))
+
+	// The parameters are kept in the history job.
+	historyJob, err := ddl.GetHistoryJobByID(stmtKit.Session(), jobID)
+	require.NoError(t, err)
+	require.Equal(t, 2, historyJob.ReorgMeta.Concurrency)
+	require.Nil(t, historyJob.ReorgMeta.RunWindow)
+	require.Equal(t, int64(0), historyJob.ReorgMeta.MaxWriteSpeed)
+
+	// The finished job can't be altered.
+	adminCommandKit.MustGetErrCode(fmt.Sprintf("admin alter ddl jobs %d thread = 1", jobID), errno.ErrDDLJobNotFound)
+}
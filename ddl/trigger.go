@@ -0,0 +1,122 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	triggerInfo := &model.TriggerInfo{}
+	var (
+		orderType    ast.TriggerOrderType
+		orderTrigger model.CIStr
+	)
+	if err := job.DecodeArgs(triggerInfo, &orderType, &orderTrigger); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// The name of the trigger is unique in the schema.
+	tables, err := t.ListTables(job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	for _, tbl := range tables {
+		if findTrigger(tbl, triggerInfo.Name) >= 0 {
+			job.State = model.JobStateCancelled
+			return ver, infoschema.ErrTriggerExists
+		}
+	}
+	offset, err := triggerInsertOffset(tblInfo, triggerInfo, orderType, orderTrigger)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	// A trigger has no intermediate state, none -> public.
+	triggerInfo.State = model.StatePublic
+	tblInfo.Triggers = append(tblInfo.Triggers, nil)
+	copy(tblInfo.Triggers[offset+1:], tblInfo.Triggers[offset:])
+	tblInfo.Triggers[offset] = triggerInfo
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	offset := findTrigger(tblInfo, name)
+	if offset < 0 {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerNotExists
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers[:offset], tblInfo.Triggers[offset+1:]...)
+	if len(tblInfo.Triggers) == 0 {
+		tblInfo.Triggers = nil
+	}
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
+
+func findTrigger(tblInfo *model.TableInfo, name model.CIStr) int {
+	for i, trigger := range tblInfo.Triggers {
+		if trigger.Name.L == name.L {
+			return i
+		}
+	}
+	return -1
+}
+
+// triggerInsertOffset returns the offset in tblInfo.Triggers to insert the new trigger. Without the
+// FOLLOWS or PRECEDES clause, the trigger is fired after the existing ones with the same action time
+// and event.
+func triggerInsertOffset(tblInfo *model.TableInfo, triggerInfo *model.TriggerInfo, orderType ast.TriggerOrderType, orderTrigger model.CIStr) (int, error) {
+	if orderType == ast.TriggerOrderNone {
+		return len(tblInfo.Triggers), nil
+	}
+	offset := findTrigger(tblInfo, orderTrigger)
+	if offset < 0 || tblInfo.Triggers[offset].Timing != triggerInfo.Timing || tblInfo.Triggers[offset].Event != triggerInfo.Event {
+		return 0, dbterror.ErrReferencedTrgDoesNotExist.GenWithStackByArgs(orderTrigger.O)
+	}
+	if orderType == ast.TriggerOrderFollows {
+		offset++
+	}
+	return offset, nil
+}
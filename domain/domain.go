@@ -48,6 +48,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/domain/resourcegroup"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/eventscheduler"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/infoschema/perfschema"
 	"github.com/pingcap/tidb/keyspace"
@@ -161,9 +162,11 @@ type Domain struct {
 	planReplayerHandle       *planReplayerHandle
 	extractTaskHandle        *ExtractHandle
 	expiredTimeStamp4PC      types.Time
+	instancePlanCache        sessionctx.InstancePlanCache
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventScheduler           atomic.Pointer[eventscheduler.Scheduler]
 	runawayManager           *resourcegroup.RunawayManager
 	resourceGroupsController *rmclient.ResourceGroupsController
 
@@ -400,6 +403,11 @@ func (do *Domain) fetchSchemasWithTables(schemas []*model.DBInfo, m *meta.Meta,
 			}
 			di.Tables = append(di.Tables, tbl)
 		}
+		di.Procedures, err = m.ListProcedures(di.ID)
+		if err != nil {
+			done <- err
+			return
+		}
 	}
 	done <- nil
 }
@@ -496,6 +504,16 @@ func (do *Domain) SetExpiredTimeStamp4PC(time types.Time) {
 	do.expiredTimeStamp4PC = time
 }
 
+// InstancePlanCache gets the instance-level plan cache, which is shared by all sessions of the domain.
+func (do *Domain) InstancePlanCache() sessionctx.InstancePlanCache {
+	return do.instancePlanCache
+}
+
+// SetInstancePlanCache sets the instance-level plan cache. It should be called before the domain serves any session.
+func (do *Domain) SetInstancePlanCache(cache sessionctx.InstancePlanCache) {
+	do.instancePlanCache = cache
+}
+
 // DDL gets DDL from domain.
 func (do *Domain) DDL() ddl.DDL {
 	return do.ddl
@@ -993,6 +1011,13 @@ func (do *Domain) Close() {
 			logutil.BgLogger().Warn("fail to wait until the ttl job manager stop", zap.Error(err))
 		}
 	}
+	if eventScheduler := do.eventScheduler.Load(); eventScheduler != nil {
+		eventScheduler.Stop()
+	}
+	if do.instancePlanCache != nil {
+		// Release the memory tracked by the global memory tracker.
+		do.instancePlanCache.DeleteAll()
+	}
 	close(do.exit)
 	if do.etcdClient != nil {
 		terror.Log(errors.Trace(do.etcdClient.Close()))
@@ -3103,6 +3128,25 @@ func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
 }
 
+// StartEventScheduler starts the event scheduler, newExecutor creates the session to execute an event.
+func (do *Domain) StartEventScheduler(newExecutor func() (eventscheduler.EventExecutor, error)) {
+	var clusterID uint64
+	if pdCli := do.GetPDClient(); pdCli != nil {
+		clusterID = pdCli.GetClusterID(context.Background())
+	}
+	isOwner := func() bool {
+		return do.ddl.OwnerManager().IsOwner()
+	}
+	eventScheduler := eventscheduler.NewScheduler(clusterID, do.sysSessionPool, do.etcdClient, isOwner, newExecutor)
+	do.eventScheduler.Store(eventScheduler)
+	eventScheduler.Start()
+}
+
+// EventScheduler returns the event scheduler on this domain.
+func (do *Domain) EventScheduler() *eventscheduler.Scheduler {
+	return do.eventScheduler.Load()
+}
+
 // StopAutoAnalyze stops (*Domain).autoAnalyzeWorker to launch new auto analyze jobs.
 func (do *Domain) StopAutoAnalyze() {
 	do.stopAutoAnalyze.Store(true)
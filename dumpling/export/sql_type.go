@@ -45,7 +45,8 @@ func initColTypeRowReceiverMap() {
 	dataTypeBinArr := []string{
 		"BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "LONG",
 		"BINARY", "VARBINARY",
-		"BIT", "GEOMETRY",
+		"BIT", "GEOMETRY", "POINT", "LINESTRING", "POLYGON",
+		"MULTIPOINT", "MULTILINESTRING", "MULTIPOLYGON", "GEOMETRYCOLLECTION", "GEOMCOLLECTION",
 	}
 
 	for _, s := range dataTypeStringArr {
@@ -233,6 +233,11 @@ func TestSQLDataTypes(t *testing.T) {
 		{"CHAR", "char1", `'char1'`},
 		{"INT", 12345, `12345`},
 		{"BINARY", 1234, "x'31323334'"},
+		// The geometries are dumped in their stored format, a 4-byte SRID followed by the WKB.
+		{"GEOMETRY", "\x00\x00\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\x00\x40",
+			"x'000000000101000000000000000000f03f0000000000000040'"},
+		{"POINT", "\xe6\x10\x00\x00\x01\x01\x00\x00\x00\x00\x00\x00\x00\x00\x00\xf0\x3f\x00\x00\x00\x00\x00\x00\x00\x40",
+			"x'e61000000101000000000000000000f03f0000000000000040'"},
 	}
 
 	for _, datum := range data {
@@ -826,14 +826,18 @@ const (
 	ErrInvalidFieldSize                                      = 3013
 	ErrPasswordExpireAnonymousUser                           = 3016
 	ErrInvalidArgumentForLogarithm                           = 3020
+	ErrReferencedTrgDoesNotExist                             = 3022
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
 	ErrFieldInOrderNotSelect                                 = 3065
 	ErrAggregateInOrderNotSelect                             = 3066
 	ErrInvalidJSONData                                       = 3069
+	ErrInvalidGeoJSONUnspecified                             = 3074
 	ErrGeneratedColumnFunctionIsNotAllowed                   = 3102
 	ErrUnsupportedAlterInplaceOnVirtualColumn                = 3103
 	ErrWrongFKOptionForGeneratedColumn                       = 3104
@@ -892,10 +896,15 @@ const (
 	ErrWindowFunctionIgnoresFrame                            = 3599
 	ErrInvalidNumberOfArgs                                   = 3601
 	ErrFieldInGroupingNotGroupBy                             = 3602
+	ErrLongitudeOutOfRange                                   = 3616
+	ErrLatitudeOutOfRange                                    = 3617
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrNonPositiveRadius                                     = 3706
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
 	ErrForeignKeyNoColumnInParent                            = 3734
@@ -926,6 +935,7 @@ const (
 	ErrCheckConstraintDupName                                = 3822
 	ErrCheckConstraintClauseUsingFKReferActionColumn         = 3823
 	ErrDependentByFunctionalIndex                            = 3837
+	ErrInvalidJSONType                                       = 3853
 	ErrCannotConvertString                                   = 3854
 	ErrDependentByPartitionFunctional                        = 3855
 	ErrInvalidJSONValueForFuncIndex                          = 3903
@@ -933,9 +943,11 @@ const (
 	ErrFunctionalIndexDataIsTooLong                          = 3907
 	ErrFunctionalIndexNotApplicable                          = 3909
 	ErrDynamicPrivilegeNotRegistered                         = 3929
+	ErrJSONSchemaValidationErrorWithDetailedReport           = 3934
 	ErrConstraintNotFound                                    = 3940
 	ErUserAccessDeniedForUserAccountBlockedByPasswordLock    = 3955
 	ErrDependentByCheckConstraint                            = 3959
+	ErrMissingJSONValue                                      = 3966
 	ErrJSONInBooleanContext                                  = 3986
 	ErrTableWithoutPrimaryKey                                = 3750
 	// MariaDB errors.
@@ -1133,6 +1145,10 @@ const (
 	ErrCannotResumeDDLJob = 8261
 	ErrPausedDDLJob       = 8262
 
+	ErrMaterializedViewNotIncremental = 8263
+	ErrInvalidJSONSchema              = 8264
+	ErrCannotAlterDDLJob              = 8265
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
@@ -0,0 +1,159 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+const (
+	timerKeyPrefix = "/tidb/event/"
+	timerHookClass = "tidb.event"
+
+	// maxEventInterval is the max interval of the recurring events, it's the same as MySQL.
+	maxEventInterval = 1000000000 * time.Second
+)
+
+// EventInterval returns the interval of a recurring event which is scheduled EVERY value unit.
+func EventInterval(value int64, unit ast.TimeUnitType) (time.Duration, error) {
+	d, err := unit.Duration()
+	if err != nil || unit == ast.TimeUnitMicrosecond {
+		return 0, dbterror.ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("EVERY ... %s of event", unit.String()))
+	}
+	if value <= 0 || value > int64(maxEventInterval/d) {
+		return 0, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	return time.Duration(value) * d, nil
+}
+
+// EventInfo is the definition of an event, it's stored as the data of the timer of the event.
+type EventInfo struct {
+	Schema  string             `json:"schema"`
+	Name    string             `json:"name"`
+	Definer *auth.UserIdentity `json:"definer"`
+	// Definition is the CREATE EVENT or ALTER EVENT statement which defines the body of the event.
+	Definition          string `json:"definition"`
+	Body                string `json:"body"`
+	SQLMode             string `json:"sql_mode"`
+	TimeZone            string `json:"time_zone"`
+	CharacterSetClient  string `json:"character_set_client"`
+	CollationConnection string `json:"collation_connection"`
+	DBCollation         string `json:"db_collation"`
+	// ExecuteAt is set for the one-time events.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are set for the recurring events. Starts is always set for them,
+	// it's the creation time if the STARTS clause is not specified.
+	IntervalValue int64            `json:"interval_value"`
+	IntervalField ast.TimeUnitType `json:"interval_field"`
+	Starts        time.Time        `json:"starts"`
+	Ends          time.Time        `json:"ends"`
+	// Preserve is true if the event is kept after it's completed.
+	Preserve    bool      `json:"preserve"`
+	Comment     string    `json:"comment"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// IsOneTime returns whether the event is scheduled only once.
+func (e *EventInfo) IsOneTime() bool {
+	return !e.ExecuteAt.IsZero()
+}
+
+// Interval returns the interval of the recurring event.
+func (e *EventInfo) Interval() (time.Duration, error) {
+	return EventInterval(e.IntervalValue, e.IntervalField)
+}
+
+// schedule returns the schedule policy of the timer and the watermark to schedule the first execution of
+// the event at ExecuteAt or Starts. The watermark differs between schedules, so the hook can tell whether
+// the event is rescheduled during its execution.
+func (e *EventInfo) schedule() (timerapi.SchedPolicyType, string, time.Time, error) {
+	if e.IsOneTime() {
+		return timerapi.SchedEventAt, e.ExecuteAt.Format(time.RFC3339), e.ExecuteAt.Add(-time.Second), nil
+	}
+	interval, err := e.Interval()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return timerapi.SchedEventInterval, fmt.Sprintf("%ds", interval/time.Second), e.Starts.Add(-interval), nil
+}
+
+// Event is an event with its status.
+type Event struct {
+	*EventInfo
+	Enable bool
+	// LastExecuted is the start time of the last execution, it's zero if the event is never executed.
+	LastExecuted time.Time
+
+	timerID string
+}
+
+// eventSummary is stored as the summary data of the timer of the event.
+type eventSummary struct {
+	LastEventID  string    `json:"last_event_id"`
+	LastExecuted time.Time `json:"last_executed"`
+}
+
+func eventKey(schema, name string) string {
+	return schemaKeyPrefix(schema) + url.PathEscape(strings.ToLower(name))
+}
+
+func schemaKeyPrefix(schema string) string {
+	return timerKeyPrefix + url.PathEscape(strings.ToLower(schema)) + "/"
+}
+
+func decodeEventInfo(data []byte) (*EventInfo, error) {
+	info := &EventInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return info, nil
+}
+
+func newEvent(timer *timerapi.TimerRecord) (*Event, error) {
+	info, err := decodeEventInfo(timer.Data)
+	if err != nil {
+		return nil, err
+	}
+	ev := &Event{EventInfo: info, Enable: timer.Enable, timerID: timer.ID}
+	if len(timer.SummaryData) > 0 {
+		var summary eventSummary
+		if err = json.Unmarshal(timer.SummaryData, &summary); err != nil {
+			return nil, errors.Trace(err)
+		}
+		ev.LastExecuted = summary.LastExecuted
+	}
+	return ev, nil
+}
+
+// EventExecutor executes the body of the events, it's implemented by the session.
+type EventExecutor interface {
+	// ExecuteEvent executes the body of the event with the privileges of its definer.
+	ExecuteEvent(ctx context.Context, info *EventInfo) error
+	// Close closes the executor.
+	Close()
+}
@@ -0,0 +1,275 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+const (
+	eventStatusRunning = "running"
+	eventStatusSuccess = "success"
+	eventStatusFailed  = "failed"
+
+	defaultRetryInterval = 5 * time.Second
+)
+
+type eventHook struct {
+	s             *Scheduler
+	cli           timerapi.TimerClient
+	ctx           context.Context
+	cancel        func()
+	wg            sync.WaitGroup
+	retryInterval time.Duration
+}
+
+func newEventHook(s *Scheduler, cli timerapi.TimerClient) *eventHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventHook{
+		s:             s,
+		cli:           cli,
+		ctx:           ctx,
+		cancel:        cancel,
+		retryInterval: defaultRetryInterval,
+	}
+}
+
+func (*eventHook) Start() {}
+
+func (h *eventHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+// OnPreSchedEvent completes the recurring event instead of executing it if the next execution is after ENDS.
+func (h *eventHook) OnPreSchedEvent(ctx context.Context, event timerapi.TimerShedEvent) (r timerapi.PreSchedEventResult, err error) {
+	timer := event.Timer()
+	info, err := decodeEventInfo(timer.Data)
+	if err != nil {
+		logutil.BgLogger().Error("invalid event timer data",
+			zap.String("timerID", timer.ID),
+			zap.String("timerKey", timer.Key),
+			zap.ByteString("data", timer.Data),
+		)
+		r.Delay = time.Minute
+		return r, nil
+	}
+
+	if info.IsOneTime() || info.Ends.IsZero() {
+		return
+	}
+
+	policy, err := timer.CreateSchedEventPolicy()
+	if err != nil {
+		return
+	}
+	if next, ok := policy.NextEventTime(timer.Watermark); ok && !next.After(info.Ends) {
+		return
+	}
+	if err = h.completeEvent(ctx, timer, info); err != nil {
+		return
+	}
+	// The timer is disabled or deleted, it will not be triggered again.
+	r.Delay = time.Minute
+	return
+}
+
+// OnSchedEvent executes the event in the background, the timer event is closed after the execution.
+func (h *eventHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	if err := h.ctx.Err(); err != nil {
+		return err
+	}
+	h.wg.Add(1)
+	go h.runEvent(event.Timer(), event.EventID())
+	return nil
+}
+
+func (h *eventHook) runEvent(timer *timerapi.TimerRecord, eventID string) {
+	defer h.wg.Done()
+	logger := logutil.BgLogger().With(
+		zap.String("key", timer.Key),
+		zap.String("eventID", eventID),
+		zap.Time("eventStart", timer.EventStart),
+	)
+
+	info, err := decodeEventInfo(timer.Data)
+	if err != nil {
+		logger.Error("invalid event timer data", zap.ByteString("data", timer.Data))
+		h.retry(logger, "close timer event", func() error {
+			return h.cli.CloseTimerEvent(h.ctx, timer.ID, eventID, timerapi.WithSetWatermark(timer.EventStart))
+		})
+		return
+	}
+
+	var status string
+	if !h.retry(logger, "get event history", func() (err error) {
+		status, err = h.s.getEventStatus(h.ctx, eventID)
+		return err
+	}) {
+		return
+	}
+
+	switch status {
+	case "":
+		if !h.retry(logger, "insert event history", func() error {
+			return h.s.insertEventHistory(h.ctx, eventID, info)
+		}) {
+			return
+		}
+		logger.Info("start to execute event")
+		execErr := h.execute(info)
+		status, errMsg := eventStatusSuccess, ""
+		if execErr != nil {
+			logger.Warn("failed to execute event", zap.Error(execErr))
+			status, errMsg = eventStatusFailed, execErr.Error()
+		}
+		if !h.retry(logger, "update event history", func() error {
+			return h.s.finishEventHistory(h.ctx, eventID, status, errMsg)
+		}) {
+			return
+		}
+	case eventStatusRunning:
+		// The event is interrupted by the restart of the scheduler, it's not executed again.
+		logger.Warn("the execution of event is interrupted")
+		if !h.retry(logger, "update event history", func() error {
+			return h.s.finishEventHistory(h.ctx, eventID, eventStatusFailed, "interrupted")
+		}) {
+			return
+		}
+	}
+
+	h.retry(logger, "close timer event", func() error {
+		return h.closeEvent(timer.ID, eventID)
+	})
+}
+
+// execute executes the body of the event in a new session.
+func (h *eventHook) execute(info *EventInfo) (err error) {
+	util.WithRecovery(func() {
+		var exec EventExecutor
+		if exec, err = h.s.newExecutor(); err != nil {
+			return
+		}
+		defer exec.Close()
+		err = exec.ExecuteEvent(h.ctx, info)
+	}, func(r interface{}) {
+		if r != nil {
+			err = errors.Errorf("panic when executing event: %v", r)
+		}
+	})
+	return err
+}
+
+// closeEvent closes the timer event after the event is executed. The one-time event and the recurring
+// event whose next execution is after ENDS are completed.
+func (h *eventHook) closeEvent(timerID, eventID string) error {
+	timer, err := h.cli.GetTimerByID(h.ctx, timerID)
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		// The event is dropped.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if timer.EventID != eventID {
+		return nil
+	}
+
+	info, err := decodeEventInfo(timer.Data)
+	if err != nil {
+		return err
+	}
+	summary, err := json.Marshal(&eventSummary{LastEventID: eventID, LastExecuted: timer.EventStart})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	watermark := timer.Watermark
+	rescheduled := !watermark.Equal(timer.EventWatermark)
+	switch {
+	case rescheduled:
+	case info.IsOneTime():
+		// There is no more execution after the watermark reaches the time of the one-time event.
+		watermark = timer.EventStart
+	default:
+		// Move the watermark to the scheduled time of this execution, the executions missed are skipped.
+		interval, err := info.Interval()
+		if err != nil {
+			return err
+		}
+		watermark = timer.EventStart
+		if !timer.EventWatermark.IsZero() {
+			watermark = timer.EventWatermark.Add(interval)
+			if missed := int64(timer.EventStart.Sub(watermark) / interval); missed > 0 {
+				watermark = watermark.Add(time.Duration(missed) * interval)
+			}
+		}
+	}
+
+	complete := info.IsOneTime() && !rescheduled
+	if !info.IsOneTime() && !info.Ends.IsZero() {
+		interval, err := info.Interval()
+		if err != nil {
+			return err
+		}
+		complete = watermark.Add(interval).After(info.Ends)
+	}
+	if complete && (!info.Preserve || timer.Enable) {
+		if err = h.completeEvent(h.ctx, timer, info); err != nil {
+			return err
+		}
+		if !info.Preserve {
+			return nil
+		}
+	}
+	return h.cli.CloseTimerEvent(h.ctx, timerID, eventID, timerapi.WithSetWatermark(watermark), timerapi.WithSetSummaryData(summary))
+}
+
+// completeEvent drops the event, or disables it if ON COMPLETION PRESERVE is set.
+func (h *eventHook) completeEvent(ctx context.Context, timer *timerapi.TimerRecord, info *EventInfo) error {
+	logger := logutil.BgLogger().With(zap.String("key", timer.Key))
+	if info.Preserve {
+		logger.Info("disable the completed event")
+		return h.cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetEnable(false))
+	}
+	logger.Info("drop the completed event")
+	_, err := h.cli.DeleteTimer(ctx, timer.ID)
+	return err
+}
+
+// retry calls fn until it succeeds, it returns false if the hook is stopped.
+func (h *eventHook) retry(logger *zap.Logger, action string, fn func() error) bool {
+	for {
+		err := fn()
+		if err == nil {
+			return true
+		}
+		logger.Warn("failed to "+action+", retry later", zap.Error(err))
+		select {
+		case <-h.ctx.Done():
+			return false
+		case <-time.After(h.retryInterval):
+		}
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventscheduler
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	timerrt "github.com/pingcap/tidb/timer/runtime"
+	"github.com/pingcap/tidb/timer/tablestore"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	checkRuntimeInterval = time.Second
+	gcHistoryInterval    = time.Hour
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Scheduler manages the events, which are stored as the timers in mysql.tidb_timers, and runs the
+// timer runtime to execute them. The runtime only runs on the DDL owner when event_scheduler is ON.
+type Scheduler struct {
+	pool        sessionPool
+	store       *timerapi.TimerStore
+	cli         timerapi.TimerClient
+	isOwner     func() bool
+	newExecutor func() (EventExecutor, error)
+
+	rt     *timerrt.TimerGroupRuntime
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new Scheduler. The newExecutor creates the session to execute an event.
+func NewScheduler(clusterID uint64, pool sessionPool, etcd *clientv3.Client, isOwner func() bool,
+	newExecutor func() (EventExecutor, error)) *Scheduler {
+	store := tablestore.NewTableTimerStore(clusterID, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		pool:        pool,
+		store:       store,
+		cli:         timerapi.NewDefaultTimerClient(store),
+		isOwner:     isOwner,
+		newExecutor: newExecutor,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+// Start starts the scheduler.
+func (s *Scheduler) Start() {
+	s.wg.Add(1)
+	go s.loop()
+}
+
+// Stop stops the scheduler and waits for the running events.
+func (s *Scheduler) Stop() {
+	s.cancel()
+	s.wg.Wait()
+	s.store.Close()
+}
+
+func (s *Scheduler) loop() {
+	defer func() {
+		s.pause()
+		s.wg.Done()
+	}()
+
+	ticker := time.NewTicker(checkRuntimeInterval)
+	defer ticker.Stop()
+	gcTicker := time.NewTicker(gcHistoryInterval)
+	defer gcTicker.Stop()
+	for {
+		if s.isOwner() && variable.EnableEventScheduler.Load() {
+			s.resume()
+		} else {
+			s.pause()
+		}
+
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+		case <-gcTicker.C:
+			if s.isOwner() {
+				if _, err := s.execSQL(s.ctx, "DELETE FROM mysql.tidb_event_history WHERE start_time < CURDATE() - INTERVAL 90 DAY"); err != nil {
+					logutil.BgLogger().Warn("failed to gc event history", zap.Error(err))
+				}
+			}
+		}
+	}
+}
+
+func (s *Scheduler) resume() {
+	if s.rt != nil {
+		return
+	}
+
+	logutil.BgLogger().Info("start the event scheduler runtime")
+	s.rt = timerrt.NewTimerRuntimeBuilder("event", s.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(hookClass string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventHook(s, cli)
+		}).
+		Build()
+	s.rt.Start()
+}
+
+func (s *Scheduler) pause() {
+	if rt := s.rt; rt != nil {
+		logutil.BgLogger().Info("stop the event scheduler runtime")
+		s.rt = nil
+		rt.Stop()
+	}
+}
+
+// CreateEvent creates an event, the event is not scheduled if enable is false.
+func (s *Scheduler) CreateEvent(ctx context.Context, info *EventInfo, enable bool) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tp, expr, watermark, err := info.schedule()
+	if err != nil {
+		return err
+	}
+	_, err = s.cli.CreateTimer(ctx, timerapi.TimerSpec{
+		Key:             eventKey(info.Schema, info.Name),
+		Data:            data,
+		SchedPolicyType: tp,
+		SchedPolicyExpr: expr,
+		HookClass:       timerHookClass,
+		Watermark:       watermark,
+		Enable:          enable,
+	})
+	if kv.ErrKeyExists.Equal(err) {
+		return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(info.Name)
+	}
+	return err
+}
+
+// UpdateEvent updates the definition and the status of the event, the event is rescheduled if
+// reschedule is true.
+func (s *Scheduler) UpdateEvent(ctx context.Context, ev *Event, info *EventInfo, enable, reschedule bool) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	opts := []timerapi.UpdateTimerOption{timerapi.WithSetData(data), timerapi.WithSetEnable(enable)}
+	if reschedule {
+		tp, expr, watermark, err := info.schedule()
+		if err != nil {
+			return err
+		}
+		opts = append(opts, timerapi.WithSetSchedExpr(tp, expr), timerapi.WithSetWatermark(watermark))
+	}
+	err = s.cli.UpdateTimer(ctx, ev.timerID, opts...)
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(ev.Name)
+	}
+	return err
+}
+
+// DropEvent drops the event.
+func (s *Scheduler) DropEvent(ctx context.Context, ev *Event) error {
+	_, err := s.cli.DeleteTimer(ctx, ev.timerID)
+	return err
+}
+
+// DropSchemaEvents drops all the events of the schema.
+func (s *Scheduler) DropSchemaEvents(ctx context.Context, schema string) error {
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(schemaKeyPrefix(schema)))
+	if err != nil {
+		return err
+	}
+	for _, timer := range timers {
+		if _, err = s.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetEvent returns the event, it returns nil if the event does not exist.
+func (s *Scheduler) GetEvent(ctx context.Context, schema, name string) (*Event, error) {
+	timer, err := s.cli.GetTimerByKey(ctx, eventKey(schema, name))
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return newEvent(timer)
+}
+
+// ListEvents returns the events of the schema ordered by their names, it returns the events of all
+// schemas if schema is empty.
+func (s *Scheduler) ListEvents(ctx context.Context, schema string) ([]*Event, error) {
+	prefix := timerKeyPrefix
+	if schema != "" {
+		prefix = schemaKeyPrefix(schema)
+	}
+	timers, err := s.cli.GetTimers(ctx, timerapi.WithKeyPrefix(prefix))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(timers))
+	for _, timer := range timers {
+		ev, err := newEvent(timer)
+		if err != nil {
+			logutil.BgLogger().Warn("invalid event timer data", zap.String("timerKey", timer.Key), zap.Error(err))
+			continue
+		}
+		events = append(events, ev)
+	}
+	sort.Slice(events, func(i, j int) bool {
+		if events[i].Schema != events[j].Schema {
+			return events[i].Schema < events[j].Schema
+		}
+		return events[i].Name < events[j].Name
+	})
+	return events, nil
+}
+
+func (s *Scheduler) getEventStatus(ctx context.Context, eventID string) (string, error) {
+	rows, err := s.execSQL(ctx, "SELECT status FROM mysql.tidb_event_history WHERE event_id = %?", eventID)
+	if err != nil || len(rows) == 0 {
+		return "", err
+	}
+	return rows[0].GetString(0), nil
+}
+
+func (s *Scheduler) insertEventHistory(ctx context.Context, eventID string, info *EventInfo) error {
+	_, err := s.execSQL(ctx, "INSERT INTO mysql.tidb_event_history (event_id, event_schema, event_name, start_time, status) "+
+		"VALUES (%?, %?, %?, NOW(), %?)", eventID, info.Schema, info.Name, eventStatusRunning)
+	return err
+}
+
+func (s *Scheduler) finishEventHistory(ctx context.Context, eventID, status, errMsg string) error {
+	_, err := s.execSQL(ctx, "UPDATE mysql.tidb_event_history SET end_time = NOW(), status = %?, error_message = %? "+
+		"WHERE event_id = %?", status, errMsg, eventID)
+	return err
+}
+
+func (s *Scheduler) execSQL(ctx context.Context, sql string, args ...any) ([]chunk.Row, error) {
+	r, err := s.pool.Get()
+	if err != nil {
+		return nil, err
+	}
+	defer s.pool.Put(r)
+
+	exec, ok := r.(sqlexec.SQLExecutor)
+	if !ok {
+		return nil, errors.New("session is not the type of SQLExecutor")
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rs, err := exec.ExecuteInternal(ctx, sql, args...)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
@@ -0,0 +1,190 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/util/admin"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+// executeCheckPartitions executes "ALTER TABLE t CHECK PARTITION ...".
+// Like ADMIN CHECK TABLE, it compares the row data of the chosen partitions with every index.
+func (e *DDLExec) executeCheckPartitions(ctx context.Context, tn *ast.TableName, spec *ast.AlterTableSpec) error {
+	tbl, defs, err := e.getPartitionsForMaintenance(tn, spec)
+	if err != nil {
+		return err
+	}
+	tblInfo := tbl.Meta()
+	indices := getPartitionMaintenanceIndices(tblInfo)
+	idxNames := make([]string, 0, len(indices))
+	for _, idxInfo := range indices {
+		if idxInfo.MVIndex {
+			// A multi-valued index may have more entries than rows, so it is only checked by CheckRecordAndIndex.
+			continue
+		}
+		idxNames = append(idxNames, idxInfo.Name.O)
+	}
+	txn, err := e.Ctx().Txn(true)
+	if err != nil {
+		return err
+	}
+	for _, def := range defs {
+		_, _, err = admin.CheckPartitionIndicesCount(e.Ctx(), tn.Schema.O, tblInfo.Name.O, def.Name.O, idxNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		partition := tbl.GetPartition(def.ID)
+		for _, idxInfo := range indices {
+			idx := tables.NewIndex(def.ID, tblInfo, idxInfo)
+			if err = admin.CheckRecordAndIndex(ctx, e.Ctx(), txn, partition, idx); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+// executeRepairPartitions executes "ALTER TABLE t REPAIR PARTITION ...".
+// For every index of the chosen partitions, it removes the dangling index entries like
+// ADMIN CLEANUP INDEX and then adds the missing ones like ADMIN RECOVER INDEX.
+func (e *DDLExec) executeRepairPartitions(ctx context.Context, tn *ast.TableName, spec *ast.AlterTableSpec) error {
+	tbl, defs, err := e.getPartitionsForMaintenance(tn, spec)
+	if err != nil {
+		return err
+	}
+	indices := getPartitionMaintenanceIndices(tbl.Meta())
+	sc := e.Ctx().GetSessionVars().StmtCtx
+	for _, def := range defs {
+		partition := tbl.GetPartition(def.ID)
+		for _, idxInfo := range indices {
+			removedCnt, addedCnt, err := repairPartitionIndex(ctx, e.Ctx(), partition, idxInfo.Name.L)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if removedCnt == 0 && addedCnt == 0 {
+				continue
+			}
+			logutil.Logger(ctx).Info("repair partition index",
+				zap.String("table", tbl.Meta().Name.O), zap.String("partition", def.Name.O),
+				zap.String("index", idxInfo.Name.O), zap.Uint64("removedCnt", removedCnt), zap.Int64("addedCnt", addedCnt))
+			sc.AppendWarning(errors.Errorf("Partition %s, index %s: removed %d dangling and added %d missing index entries",
+				def.Name.O, idxInfo.Name.O, removedCnt, addedCnt))
+		}
+	}
+	return nil
+}
+
+// getPartitionsForMaintenance returns the table and the partitions named by a CHECK or REPAIR PARTITION specification.
+func (e *DDLExec) getPartitionsForMaintenance(tn *ast.TableName, spec *ast.AlterTableSpec) (table.PartitionedTable, []model.PartitionDefinition, error) {
+	tbl, err := domain.GetDomain(e.Ctx()).InfoSchema().TableByName(tn.Schema, tn.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	tblInfo := tbl.Meta()
+	pi := tblInfo.GetPartitionInfo()
+	pt, ok := tbl.(table.PartitionedTable)
+	if pi == nil || !ok {
+		return nil, nil, errors.Trace(dbterror.ErrPartitionMgmtOnNonpartitioned)
+	}
+	if spec.OnAllPartitions {
+		return pt, pi.Definitions, nil
+	}
+	defs := make([]model.PartitionDefinition, 0, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		idx := pi.FindPartitionDefinitionByName(name.L)
+		if idx == -1 {
+			return nil, nil, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(name.O, tblInfo.Name.O))
+		}
+		defs = append(defs, pi.Definitions[idx])
+	}
+	return pt, defs, nil
+}
+
+// getPartitionMaintenanceIndices returns the indexes that are checked or repaired per partition.
+func getPartitionMaintenanceIndices(tblInfo *model.TableInfo) []*model.IndexInfo {
+	indices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.State != model.StatePublic {
+			continue
+		}
+		// The clustered index is the row data itself, a global index does not belong to a single partition
+		// and a FULLTEXT index stores the tokens rather than the values of the columns.
+		if (tblInfo.IsCommonHandle && idxInfo.Primary) || idxInfo.Global || idxInfo.IsFullText() {
+			continue
+		}
+		indices = append(indices, idxInfo)
+	}
+	return indices
+}
+
+// repairPartitionIndex cleans up and recovers the index of a single partition.
+// It returns the number of removed dangling index entries and the number of added missing index entries.
+func repairPartitionIndex(ctx context.Context, sctx sessionctx.Context, partition table.PhysicalTable, idxName string) (uint64, int64, error) {
+	tblInfo := partition.Meta()
+	index := tables.GetWritableIndexByName(idxName, partition)
+	if index == nil {
+		return 0, 0, errors.Errorf("secondary index `%v` is not found in table `%v`", idxName, tblInfo.Name.O)
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+
+	cleanupExec := &CleanupIndexExec{
+		BaseExecutor: exec.NewBaseExecutor(sctx, nil, 0),
+		columns:      buildIdxColsConcatHandleCols(tblInfo, index.Meta(), false),
+		index:        index,
+		table:        partition,
+		physicalID:   partition.GetPhysicalID(),
+		batchSize:    20000,
+	}
+	cleanupExec.handleCols = buildHandleColsForExec(sc, tblInfo, index.Meta(), cleanupExec.columns)
+	if err := cleanupExec.init(); err != nil {
+		return 0, 0, err
+	}
+	if err := cleanupExec.cleanTableIndex(ctx); err != nil {
+		return 0, 0, err
+	}
+
+	var hasGenedCol bool
+	for _, iCol := range index.Meta().Columns {
+		if tblInfo.Columns[iCol.Offset].IsGenerated() {
+			hasGenedCol = true
+		}
+	}
+	recoverExec := &RecoverIndexExec{
+		BaseExecutor:     exec.NewBaseExecutor(sctx, nil, 0),
+		columns:          buildIdxColsConcatHandleCols(tblInfo, index.Meta(), hasGenedCol),
+		containsGenedCol: hasGenedCol,
+		index:            index,
+		table:            partition,
+		physicalID:       partition.GetPhysicalID(),
+	}
+	recoverExec.handleCols = buildHandleColsForExec(sc, tblInfo, index.Meta(), recoverExec.columns)
+	if err := recoverExec.Open(ctx); err != nil {
+		return cleanupExec.removeCnt, 0, err
+	}
+	addedCnt, _, err := recoverExec.backfillIndex(ctx)
+	return cleanupExec.removeCnt, addedCnt, err
+}
@@ -0,0 +1,663 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/planner"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mathutil"
+	stmtsummaryv2 "github.com/pingcap/tidb/util/stmtsummary/v2"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+const (
+	// defaultAdviseMaxIndexNumPerTable is the number of indexes advised for a table if MAX_IDXNUM PER_TABLE isn't set.
+	defaultAdviseMaxIndexNumPerTable = 3
+	// maxAdviseWorkloadSize is the max number of statements read from the statement summary.
+	maxAdviseWorkloadSize = 1000
+	// maxAdviseEqualColumns is the max number of equal condition columns used as the first column of
+	// a two-column candidate index.
+	maxAdviseEqualColumns = 3
+	// minAdviseCostReduction is the min ratio of cost reduction for a query to be seen as impacted by an index.
+	minAdviseCostReduction = 0.01
+)
+
+// AdviseIndexesExec advises indexes for a workload, built from `ADVISE INDEXES FOR`.
+// It enumerates candidate indexes from the columns used by the predicates, joins and sort items of the
+// workload, estimates their benefits with hypothetical indexes, and returns the best ones greedily.
+type AdviseIndexesExec struct {
+	exec.BaseExecutor
+
+	queries     []string
+	maxIndexNum *ast.MaxIndexNumClause
+	done        bool
+}
+
+// Next implements the Executor Next interface.
+func (e *AdviseIndexesExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+
+	perTable, perDB := uint64(defaultAdviseMaxIndexNumPerTable), uint64(math.MaxUint64)
+	if e.maxIndexNum != nil {
+		if e.maxIndexNum.PerTable == 0 || e.maxIndexNum.PerDB == 0 {
+			return errors.New("Index Advise: the maximum number of indexes should be greater than 0")
+		}
+		if e.maxIndexNum.PerTable != ast.UnspecifiedSize {
+			perTable = e.maxIndexNum.PerTable
+		}
+		perDB = e.maxIndexNum.PerDB
+	}
+	workload, err := e.collectWorkload()
+	if err != nil {
+		return err
+	}
+	advisor := &indexAdvisor{
+		sctx:       e.Ctx(),
+		workload:   workload,
+		perTable:   perTable,
+		perDB:      perDB,
+		indexNames: make(map[string]struct{}),
+	}
+	advices, err := advisor.advise(ctx)
+	if err != nil {
+		return err
+	}
+	sqlMode := e.Ctx().GetSessionVars().SQLMode
+	for _, advice := range advices {
+		quotedCols := make([]string, 0, len(advice.columns))
+		for _, col := range advice.columns {
+			quotedCols = append(quotedCols, stringutil.Escape(col.O, sqlMode))
+		}
+		req.AppendString(0, advice.db.O)
+		req.AppendString(1, advice.table.Name.O)
+		req.AppendString(2, advice.name.O)
+		req.AppendString(3, advice.columnNames())
+		req.AppendInt64(4, int64(advice.impacted))
+		req.AppendFloat64(5, advice.reduction)
+		req.AppendString(6, fmt.Sprintf("CREATE INDEX %s ON %s.%s(%s)", stringutil.Escape(advice.name.O, sqlMode),
+			stringutil.Escape(advice.db.O, sqlMode), stringutil.Escape(advice.table.Name.O, sqlMode), strings.Join(quotedCols, ", ")))
+	}
+	return nil
+}
+
+// adviseQuery is a statement of the workload to advise indexes for.
+type adviseQuery struct {
+	db     string
+	sql    string
+	weight float64
+	stmt   ast.StmtNode
+	// cost is the estimated cost of the query with the indexes chosen so far.
+	cost float64
+}
+
+// collectWorkload reads the workload from the given queries, or from the statement summary if no query is given.
+func (e *AdviseIndexesExec) collectWorkload() ([]*adviseQuery, error) {
+	sessVars := e.Ctx().GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(sessVars.SQLMode)
+	p.SetParserConfig(sessVars.BuildParserConfig())
+
+	if len(e.queries) > 0 {
+		charset, collation := sessVars.GetCharsetInfo()
+		workload := make([]*adviseQuery, 0, len(e.queries))
+		for _, sql := range e.queries {
+			stmt, err := p.ParseOneStmt(sql, charset, collation)
+			if err != nil {
+				return nil, err
+			}
+			if !isAdvisableStmt(stmt) {
+				return nil, errors.Errorf("Index Advise: only SELECT, UPDATE and DELETE statements are supported, but got '%s'", sql)
+			}
+			workload = append(workload, &adviseQuery{db: sessVars.CurrentDB, sql: sql, weight: 1, stmt: stmt})
+		}
+		return workload, nil
+	}
+
+	bindableStmts := stmtsummaryv2.GetMoreThanCntBindableStmt(0)
+	sort.SliceStable(bindableStmts, func(i, j int) bool {
+		return bindableStmts[i].ExecCount > bindableStmts[j].ExecCount
+	})
+	workload := make([]*adviseQuery, 0, len(bindableStmts))
+	for _, bindableStmt := range bindableStmts {
+		if len(workload) >= maxAdviseWorkloadSize {
+			break
+		}
+		stmt, err := p.ParseOneStmt(bindableStmt.Query, bindableStmt.Charset, bindableStmt.Collation)
+		// Prepared statements with parameter markers can't be optimized without their arguments.
+		if err != nil || !isAdvisableStmt(stmt) || hasParamMarker(stmt) {
+			continue
+		}
+		workload = append(workload, &adviseQuery{
+			db:     bindableStmt.Schema,
+			sql:    bindableStmt.Query,
+			weight: float64(mathutil.Max(bindableStmt.ExecCount, 1)),
+			stmt:   stmt,
+		})
+	}
+	return workload, nil
+}
+
+func isAdvisableStmt(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.SelectStmt, *ast.SetOprStmt, *ast.UpdateStmt, *ast.DeleteStmt:
+		return true
+	}
+	return false
+}
+
+func hasParamMarker(stmt ast.StmtNode) bool {
+	finder := &paramMarkerFinder{}
+	stmt.Accept(finder)
+	return finder.found
+}
+
+type paramMarkerFinder struct {
+	found bool
+}
+
+func (f *paramMarkerFinder) Enter(in ast.Node) (ast.Node, bool) {
+	if _, ok := in.(ast.ParamMarkerExpr); ok {
+		f.found = true
+	}
+	return in, f.found
+}
+
+func (*paramMarkerFinder) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// indexCandidate is a candidate index of the index advisor.
+type indexCandidate struct {
+	db      model.CIStr
+	table   *model.TableInfo
+	columns []model.CIStr
+	name    model.CIStr
+	info    *model.IndexInfo
+	// queries are the offsets of the workload queries which may use this index.
+	queries []int
+
+	impacted  int
+	reduction float64
+}
+
+func (c *indexCandidate) columnNames() string {
+	names := make([]string, 0, len(c.columns))
+	for _, col := range c.columns {
+		names = append(names, col.O)
+	}
+	return strings.Join(names, ",")
+}
+
+func (c *indexCandidate) key() string {
+	return c.db.L + "." + c.table.Name.L + "(" + strings.ToLower(c.columnNames()) + ")"
+}
+
+// indexAdvisor chooses indexes for a workload. The benefit of an index is estimated by the what-if
+// optimization: the index is added as a hypothetical index of the session, and the workload is
+// optimized again to see how much the plan cost is reduced.
+type indexAdvisor struct {
+	sctx     sessionctx.Context
+	is       infoschema.InfoSchema
+	workload []*adviseQuery
+	perTable uint64
+	perDB    uint64
+	// indexNames are the names of the candidates, in the format of "db.table.index".
+	indexNames map[string]struct{}
+}
+
+func (a *indexAdvisor) advise(ctx context.Context) ([]*indexCandidate, error) {
+	sessVars := a.sctx.GetSessionVars()
+	sc := sessVars.StmtCtx
+	// All the statements are optimized as the explained ones, so hypothetical indexes can be used
+	// and no subquery is executed. The warnings of the optimization are dropped.
+	originInExplain, originHints, originDB, originHypoIndexes := sc.InExplainStmt, sc.StmtHints, sessVars.CurrentDB, sessVars.HypoIndexes
+	originWarnings := append([]stmtctx.SQLWarn(nil), sc.GetWarnings()...)
+	var skipped []error
+	defer func() {
+		sc.InExplainStmt, sc.StmtHints, sessVars.CurrentDB, sessVars.HypoIndexes = originInExplain, originHints, originDB, originHypoIndexes
+		sc.SetWarnings(originWarnings)
+		for _, warn := range skipped {
+			sc.AppendWarning(warn)
+		}
+	}()
+	if v := a.sctx.Value(plannercore.PointPlanKey); v != nil {
+		a.sctx.ClearValue(plannercore.PointPlanKey)
+		defer a.sctx.SetValue(plannercore.PointPlanKey, v)
+	}
+	sc.InExplainStmt = true
+	sessVars.HypoIndexes = cloneHypoIndexes(originHypoIndexes)
+	a.is = sessiontxn.GetTxnManager(a.sctx).GetTxnInfoSchema()
+
+	// Get the costs of the workload with the current indexes.
+	workload := a.workload[:0]
+	for _, q := range a.workload {
+		sessVars.CurrentDB = q.db
+		if err := plannercore.Preprocess(ctx, a.sctx, q.stmt); err != nil {
+			skipped = append(skipped, errors.Errorf("Index Advise: skip the query '%s': %v", q.sql, err))
+			continue
+		}
+		cost, err := a.queryCost(ctx, q)
+		if err != nil {
+			skipped = append(skipped, errors.Errorf("Index Advise: skip the query '%s': %v", q.sql, err))
+			continue
+		}
+		q.cost = cost
+		workload = append(workload, q)
+	}
+	a.workload = workload
+	totalCost := 0.0
+	for _, q := range a.workload {
+		totalCost += q.weight * q.cost
+	}
+	if totalCost <= 0 {
+		return nil, nil
+	}
+
+	// Choose the indexes greedily, the one which reduces the cost of the workload most is chosen in each round.
+	var advices []*indexCandidate
+	candidates := a.enumerateCandidates()
+	tableIndexNum := make(map[string]uint64)
+	dbIndexNum := make(map[string]uint64)
+	for len(candidates) > 0 {
+		var best *indexCandidate
+		var bestCosts []float64
+		remained := candidates[:0]
+		for _, c := range candidates {
+			if tableIndexNum[c.db.L+"."+c.table.Name.L] >= a.perTable || dbIndexNum[c.db.L] >= a.perDB {
+				continue
+			}
+			costs, reduction, impacted, err := a.evaluate(ctx, c)
+			if err != nil {
+				return nil, err
+			}
+			if impacted == 0 {
+				continue
+			}
+			remained = append(remained, c)
+			if best == nil || reduction > best.reduction {
+				c.reduction, c.impacted = reduction, impacted
+				best, bestCosts = c, costs
+			}
+		}
+		if best == nil {
+			break
+		}
+		addHypoIndex(sessVars, best)
+		for i, offset := range best.queries {
+			a.workload[offset].cost = bestCosts[i]
+		}
+		best.reduction /= totalCost
+		advices = append(advices, best)
+		tableIndexNum[best.db.L+"."+best.table.Name.L]++
+		dbIndexNum[best.db.L]++
+
+		candidates = remained[:0]
+		for _, c := range remained {
+			if c != best && !a.isRedundant(c) {
+				candidates = append(candidates, c)
+			}
+		}
+	}
+	sort.SliceStable(advices, func(i, j int) bool {
+		return advices[i].reduction > advices[j].reduction
+	})
+	return advices, nil
+}
+
+// evaluate returns the costs of the queries of the candidate if it's added, and the weighted cost reduction
+// and the number of the impacted queries.
+func (a *indexAdvisor) evaluate(ctx context.Context, c *indexCandidate) (costs []float64, reduction float64, impacted int, err error) {
+	sessVars := a.sctx.GetSessionVars()
+	addHypoIndex(sessVars, c)
+	defer removeHypoIndex(sessVars, c)
+	costs = make([]float64, 0, len(c.queries))
+	for _, offset := range c.queries {
+		q := a.workload[offset]
+		sessVars.CurrentDB = q.db
+		cost, err := a.queryCost(ctx, q)
+		if err != nil {
+			return nil, 0, 0, err
+		}
+		costs = append(costs, cost)
+		if cost < q.cost*(1-minAdviseCostReduction) {
+			reduction += q.weight * (q.cost - cost)
+			impacted++
+		}
+	}
+	return costs, reduction, impacted, nil
+}
+
+func (a *indexAdvisor) queryCost(ctx context.Context, q *adviseQuery) (float64, error) {
+	p, _, err := planner.Optimize(ctx, a.sctx, q.stmt, a.is)
+	if err != nil {
+		return 0, err
+	}
+	var physicalPlan plannercore.PhysicalPlan
+	switch x := p.(type) {
+	case plannercore.PhysicalPlan:
+		physicalPlan = x
+	case *plannercore.Update:
+		physicalPlan = x.SelectPlan
+	case *plannercore.Delete:
+		physicalPlan = x.SelectPlan
+	}
+	if physicalPlan == nil {
+		return 0, errors.Errorf("unexpected plan %T", p)
+	}
+	return plannercore.GetPlanCost(physicalPlan, property.RootTaskType, plannercore.NewDefaultPlanCostOption())
+}
+
+// enumerateCandidates enumerates the candidate indexes of the workload. For each table, a single-column index
+// is built on every column used by the predicates, joins or sort items, and a two-column index is built on
+// every column pair whose first column is used by an equal condition.
+func (a *indexAdvisor) enumerateCandidates() []*indexCandidate {
+	var candidates []*indexCandidate
+	candidateByKey := make(map[string]*indexCandidate)
+	addCandidate := func(offset int, tbl *adviseTable, cols []model.CIStr) {
+		c := &indexCandidate{db: tbl.db, table: tbl.info, columns: cols}
+		if existing, ok := candidateByKey[c.key()]; ok {
+			if existing != nil && existing.queries[len(existing.queries)-1] != offset {
+				existing.queries = append(existing.queries, offset)
+			}
+			return
+		}
+		if a.isRedundant(c) || !a.buildIndexInfo(c) {
+			candidateByKey[c.key()] = nil
+			return
+		}
+		c.queries = []int{offset}
+		candidateByKey[c.key()] = c
+		candidates = append(candidates, c)
+	}
+	for offset, q := range a.workload {
+		collector := newIndexableColumnCollector()
+		q.stmt.Accept(collector)
+		for _, tbl := range collector.tables {
+			if tbl.info == nil {
+				continue
+			}
+			eqCols, cols := collector.columnsOf(tbl)
+			for _, col := range cols {
+				addCandidate(offset, tbl, []model.CIStr{col})
+			}
+			if len(eqCols) > maxAdviseEqualColumns {
+				eqCols = eqCols[:maxAdviseEqualColumns]
+			}
+			for _, eqCol := range eqCols {
+				for _, col := range cols {
+					if col.L != eqCol.L {
+						addCandidate(offset, tbl, []model.CIStr{eqCol, col})
+					}
+				}
+			}
+		}
+	}
+	return candidates
+}
+
+// isRedundant checks whether the columns of the candidate are the prefix of an existing index.
+func (a *indexAdvisor) isRedundant(c *indexCandidate) bool {
+	if c.table.PKIsHandle {
+		if pkCol := c.table.GetPkColInfo(); pkCol != nil && pkCol.Name.L == c.columns[0].L {
+			return true
+		}
+	}
+	isPrefix := func(idx *model.IndexInfo) bool {
+		if idx == c.info || len(idx.Columns) < len(c.columns) {
+			return false
+		}
+		for i, col := range c.columns {
+			if idx.Columns[i].Name.L != col.L || idx.Columns[i].Length != types.UnspecifiedLength {
+				return false
+			}
+		}
+		return true
+	}
+	for _, idx := range c.table.Indices {
+		if idx.State == model.StatePublic && !idx.Invisible && idx.Tp != model.IndexTypeFulltext && isPrefix(idx) {
+			return true
+		}
+	}
+	for _, idx := range a.sctx.GetSessionVars().HypoIndexes[c.db.L][c.table.Name.L] {
+		if isPrefix(idx) {
+			return true
+		}
+	}
+	return false
+}
+
+// buildIndexInfo builds the hypothetical index of the candidate, it returns false if the columns can't be indexed.
+func (a *indexAdvisor) buildIndexInfo(c *indexCandidate) bool {
+	names := make([]string, 0, len(c.columns)+1)
+	names = append(names, "idx")
+	specs := make([]*ast.IndexPartSpecification, 0, len(c.columns))
+	for _, col := range c.columns {
+		names = append(names, col.L)
+		specs = append(specs, &ast.IndexPartSpecification{Column: &ast.ColumnName{Name: col}, Length: types.UnspecifiedLength})
+	}
+	name := strings.Join(names, "_")
+	if len(name) > mysql.MaxIndexIdentifierLen-4 {
+		name = name[:mysql.MaxIndexIdentifierLen-4]
+	}
+	c.name = model.NewCIStr(name)
+	for i := 1; a.nameExists(c); i++ {
+		c.name = model.NewCIStr(fmt.Sprintf("%s_%d", name, i))
+	}
+	a.indexNames[c.db.L+"."+c.table.Name.L+"."+c.name.L] = struct{}{}
+	indexInfo, err := ddl.BuildIndexInfo(a.sctx, c.table.Columns, c.name, false, false, false, specs,
+		&ast.IndexOption{Tp: model.IndexTypeHypo}, model.StatePublic)
+	if err != nil {
+		return false
+	}
+	c.info = indexInfo
+	return true
+}
+
+func (a *indexAdvisor) nameExists(c *indexCandidate) bool {
+	if c.table.FindIndexByName(c.name.L) != nil {
+		return true
+	}
+	if _, ok := a.indexNames[c.db.L+"."+c.table.Name.L+"."+c.name.L]; ok {
+		return true
+	}
+	_, ok := a.sctx.GetSessionVars().HypoIndexes[c.db.L][c.table.Name.L][c.name.L]
+	return ok
+}
+
+func addHypoIndex(sessVars *variable.SessionVars, c *indexCandidate) {
+	if sessVars.HypoIndexes == nil {
+		sessVars.HypoIndexes = make(map[string]map[string]map[string]*model.IndexInfo)
+	}
+	if sessVars.HypoIndexes[c.db.L] == nil {
+		sessVars.HypoIndexes[c.db.L] = make(map[string]map[string]*model.IndexInfo)
+	}
+	if sessVars.HypoIndexes[c.db.L][c.table.Name.L] == nil {
+		sessVars.HypoIndexes[c.db.L][c.table.Name.L] = make(map[string]*model.IndexInfo)
+	}
+	sessVars.HypoIndexes[c.db.L][c.table.Name.L][c.name.L] = c.info
+}
+
+func removeHypoIndex(sessVars *variable.SessionVars, c *indexCandidate) {
+	delete(sessVars.HypoIndexes[c.db.L][c.table.Name.L], c.name.L)
+}
+
+func cloneHypoIndexes(hypoIndexes map[string]map[string]map[string]*model.IndexInfo) map[string]map[string]map[string]*model.IndexInfo {
+	if hypoIndexes == nil {
+		return nil
+	}
+	cloned := make(map[string]map[string]map[string]*model.IndexInfo, len(hypoIndexes))
+	for db, tables := range hypoIndexes {
+		cloned[db] = make(map[string]map[string]*model.IndexInfo, len(tables))
+		for tbl, indexes := range tables {
+			cloned[db][tbl] = make(map[string]*model.IndexInfo, len(indexes))
+			for name, idx := range indexes {
+				cloned[db][tbl][name] = idx
+			}
+		}
+	}
+	return cloned
+}
+
+// adviseTable is a table referenced by a query of the workload.
+type adviseTable struct {
+	db    model.CIStr
+	name  model.CIStr
+	alias model.CIStr
+	info  *model.TableInfo
+}
+
+// indexableColumnCollector collects the columns which may be used by an index from a statement, which are the
+// columns compared with other expressions, and the columns used by GROUP BY and ORDER BY.
+type indexableColumnCollector struct {
+	tables []*adviseTable
+	// eqCols are the columns used by equal conditions, they are also in cols.
+	eqCols []*ast.ColumnName
+	cols   []*ast.ColumnName
+}
+
+func newIndexableColumnCollector() *indexableColumnCollector {
+	return &indexableColumnCollector{}
+}
+
+// Enter implements Visitor interface.
+func (c *indexableColumnCollector) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.TableSource:
+		if tn, ok := x.Source.(*ast.TableName); ok {
+			c.addTable(tn, x.AsName)
+		}
+	case *ast.BinaryOperationExpr:
+		switch x.Op {
+		case opcode.EQ, opcode.NullEQ:
+			c.addColumn(x.L, true)
+			c.addColumn(x.R, true)
+		case opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+			c.addColumn(x.L, false)
+			c.addColumn(x.R, false)
+		}
+	case *ast.PatternInExpr:
+		c.addColumn(x.Expr, !x.Not && x.Sel == nil)
+	case *ast.IsNullExpr:
+		c.addColumn(x.Expr, !x.Not)
+	case *ast.BetweenExpr:
+		c.addColumn(x.Expr, false)
+	case *ast.PatternLikeOrIlikeExpr:
+		c.addColumn(x.Expr, false)
+	case *ast.ByItem:
+		c.addColumn(x.Expr, false)
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (*indexableColumnCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (c *indexableColumnCollector) addTable(tn *ast.TableName, alias model.CIStr) {
+	// Views, sequences, temporary tables and the tables of the memory and system databases are not advised.
+	if tn.TableInfo == nil || tn.TableInfo.IsView() || tn.TableInfo.IsSequence() ||
+		tn.TableInfo.TempTableType != model.TempTableNone || util.IsMemOrSysDB(tn.Schema.L) {
+		return
+	}
+	for _, tbl := range c.tables {
+		if tbl.info == tn.TableInfo && tbl.alias.L == alias.L {
+			return
+		}
+	}
+	c.tables = append(c.tables, &adviseTable{db: tn.Schema, name: tn.Name, alias: alias, info: tn.TableInfo})
+}
+
+func (c *indexableColumnCollector) addColumn(expr ast.ExprNode, isEq bool) {
+	colExpr, ok := expr.(*ast.ColumnNameExpr)
+	if !ok {
+		return
+	}
+	c.cols = append(c.cols, colExpr.Name)
+	if isEq {
+		c.eqCols = append(c.eqCols, colExpr.Name)
+	}
+}
+
+// columnsOf returns the columns of the table used by equal conditions and all the collected columns of it.
+func (c *indexableColumnCollector) columnsOf(tbl *adviseTable) (eqCols, cols []model.CIStr) {
+	eqCols = c.resolve(tbl, c.eqCols)
+	cols = c.resolve(tbl, c.cols)
+	return eqCols, cols
+}
+
+func (c *indexableColumnCollector) resolve(tbl *adviseTable, names []*ast.ColumnName) []model.CIStr {
+	var cols []model.CIStr
+	seen := make(map[string]struct{})
+	for _, name := range names {
+		if _, ok := seen[name.Name.L]; ok || !c.belongsTo(tbl, name) {
+			continue
+		}
+		seen[name.Name.L] = struct{}{}
+		col := model.FindColumnInfo(tbl.info.Columns, name.Name.L)
+		cols = append(cols, col.Name)
+	}
+	return cols
+}
+
+// belongsTo checks whether the column refers to the table. An unqualified column refers to the table if
+// no other table of the statement has a column of the same name.
+func (c *indexableColumnCollector) belongsTo(tbl *adviseTable, name *ast.ColumnName) bool {
+	if col := model.FindColumnInfo(tbl.info.Columns, name.Name.L); col == nil || col.Hidden {
+		return false
+	}
+	if name.Table.L != "" {
+		if name.Schema.L != "" && name.Schema.L != tbl.db.L {
+			return false
+		}
+		if tbl.alias.L != "" {
+			return name.Table.L == tbl.alias.L
+		}
+		return name.Table.L == tbl.name.L
+	}
+	for _, other := range c.tables {
+		if other != tbl && model.FindColumnInfo(other.info.Columns, name.Name.L) != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/docker/go-units"
+	"github.com/pingcap/tidb/ddl"
+	ddlutil "github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+const (
+	alterDDLJobThread        = "thread"
+	alterDDLJobBatchSize     = "batch_size"
+	alterDDLJobMaxWriteSpeed = "max_write_speed"
+	alterDDLJobRunWindow     = "run_window"
+)
+
+// AlterDDLJobExec changes the parameters of a DDL job, built from `ADMIN ALTER DDL JOBS`.
+type AlterDDLJobExec struct {
+	exec.BaseExecutor
+
+	jobID   int64
+	options []*plannercore.AlterDDLJobOpt
+}
+
+// Next implements the Executor Next interface.
+func (e *AlterDDLJobExec) Next(context.Context, *chunk.Chunk) error {
+	params, err := e.buildAlterJobParams()
+	if err != nil {
+		return err
+	}
+	// We want to use a global transaction to execute the admin command, so we don't use e.Ctx() here.
+	newSess, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	err = ddl.AlterJob(newSess, e.jobID, params)
+	e.ReleaseSysSession(kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL), newSess)
+	return err
+}
+
+func (e *AlterDDLJobExec) buildAlterJobParams() (*ddl.AlterJobParams, error) {
+	optAsString := func(opt *plannercore.AlterDDLJobOpt) (string, error) {
+		if opt.Value.GetType().GetType() != mysql.TypeVarString {
+			return "", exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		val, isNull, err := opt.Value.EvalString(e.Ctx(), chunk.Row{})
+		if err != nil || isNull {
+			return "", exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		return val, nil
+	}
+	optAsInt64 := func(opt *plannercore.AlterDDLJobOpt) (int64, error) {
+		// current parser takes integer and bool as mysql.TypeLonglong
+		if opt.Value.GetType().GetType() != mysql.TypeLonglong || mysql.HasIsBooleanFlag(opt.Value.GetType().GetFlag()) {
+			return 0, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		val, isNull, err := opt.Value.EvalInt(e.Ctx(), chunk.Row{})
+		if err != nil || isNull {
+			return 0, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		return val, nil
+	}
+
+	params := &ddl.AlterJobParams{}
+	specifiedOptions := make(map[string]struct{}, len(e.options))
+	for _, opt := range e.options {
+		if _, ok := specifiedOptions[opt.Name]; ok {
+			return nil, exeerrors.ErrDuplicateOption.FastGenByArgs(opt.Name)
+		}
+		specifiedOptions[opt.Name] = struct{}{}
+		switch opt.Name {
+		case alterDDLJobThread:
+			v, err := optAsInt64(opt)
+			if err != nil || v < 1 || v > variable.MaxConfigurableConcurrency {
+				return nil, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			concurrency := int(v)
+			params.Concurrency = &concurrency
+		case alterDDLJobBatchSize:
+			v, err := optAsInt64(opt)
+			if err != nil || v < int64(variable.MinDDLReorgBatchSize) || v > int64(variable.MaxDDLReorgBatchSize) {
+				return nil, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			batchSize := int(v)
+			params.BatchSize = &batchSize
+		case alterDDLJobMaxWriteSpeed:
+			// The speed is either a number of bytes or a string like '100MiB', 0 means no limit.
+			var speed int64
+			var err error
+			if opt.Value.GetType().GetType() == mysql.TypeVarString {
+				var v string
+				if v, err = optAsString(opt); err == nil {
+					speed, err = units.RAMInBytes(v)
+				}
+			} else {
+				speed, err = optAsInt64(opt)
+			}
+			if err != nil || speed < 0 {
+				return nil, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			params.MaxWriteSpeed = &speed
+		case alterDDLJobRunWindow:
+			// An empty string removes the run window.
+			v, err := optAsString(opt)
+			if err != nil {
+				return nil, err
+			}
+			params.SetRunWindow = true
+			if v != "" {
+				tzName, tzOffset := ddlutil.GetTimeZone(e.Ctx())
+				params.RunWindow, err = model.ParseDDLRunWindow(v, &model.TimeZoneLocation{Name: tzName, Offset: tzOffset})
+				if err != nil {
+					return nil, exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+				}
+			}
+		default:
+			return nil, exeerrors.ErrUnknownOption.FastGenByArgs(opt.Name)
+		}
+	}
+	return params, nil
+}
@@ -108,6 +108,13 @@ func (e *AnalyzeExec) handleGlobalStats(ctx context.Context, needGlobalStats boo
 							zap.Int64("histID", hg.ID), zap.Error(err), zap.Int64("tableID", tableID))
 					}
 				}
+				if globalStats.ExtStats != nil {
+					err = statsHandle.SaveExtendedStatsToStorage(globalStatsID.tableID, globalStats.ExtStats, false)
+					if err != nil {
+						logutil.Logger(ctx).Error("save global-level extended stats to storage failed", zap.String("info", job.JobInfo),
+							zap.Error(err), zap.Int64("tableID", tableID))
+					}
+				}
 				return err
 			}()
 			FinishAnalyzeMergeJob(e.Ctx(), job, mergeStatsErr)
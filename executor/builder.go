@@ -169,6 +169,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildCheckIndexRange(v)
 	case *plannercore.ChecksumTable:
 		return b.buildChecksumTable(v)
+	case *plannercore.AlterDDLJob:
+		return b.buildAlterDDLJob(v)
 	case *plannercore.ReloadExprPushdownBlacklist:
 		return b.buildReloadExprPushdownBlacklist(v)
 	case *plannercore.ReloadOptRuleBlacklist:
@@ -205,6 +207,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildUnlockStats(v)
 	case *plannercore.IndexAdvise:
 		return b.buildIndexAdvise(v)
+	case *plannercore.AdviseIndexes:
+		return b.buildAdviseIndexes(v)
 	case *plannercore.PlanReplayer:
 		return b.buildPlanReplayer(v)
 	case *plannercore.PhysicalLimit:
@@ -273,6 +277,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -710,6 +716,14 @@ func (b *executorBuilder) buildChecksumTable(v *plannercore.ChecksumTable) exec.
 	return e
 }
 
+func (b *executorBuilder) buildAlterDDLJob(v *plannercore.AlterDDLJob) exec.Executor {
+	return &AlterDDLJobExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, nil, v.ID()),
+		jobID:        v.JobID,
+		options:      v.Options,
+	}
+}
+
 func (b *executorBuilder) buildReloadExprPushdownBlacklist(_ *plannercore.ReloadExprPushdownBlacklist) exec.Executor {
 	base := exec.NewBaseExecutor(b.ctx, nil, 0)
 	return &ReloadExprPushdownBlacklistExec{base}
@@ -855,6 +869,7 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		Partition:             v.Partition,
 		Column:                v.Column,
 		IndexName:             v.IndexName,
+		Procedure:             v.Procedure,
 		ResourceGroupName:     model.NewCIStr(v.ResourceGroupName),
 		Flag:                  v.Flag,
 		Roles:                 v.Roles,
@@ -1093,6 +1108,14 @@ func (b *executorBuilder) buildIndexAdvise(v *plannercore.IndexAdvise) exec.Exec
 	return e
 }
 
+func (b *executorBuilder) buildAdviseIndexes(v *plannercore.AdviseIndexes) exec.Executor {
+	return &AdviseIndexesExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		queries:      v.Queries,
+		maxIndexNum:  v.MaxIndexNum,
+	}
+}
+
 func (b *executorBuilder) buildPlanReplayer(v *plannercore.PlanReplayer) exec.Executor {
 	if v.Load {
 		e := &PlanReplayerLoadExec{
@@ -1879,6 +1902,16 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		asName:       v.AsName,
+		expr:         v.Expr,
+		path:         v.Path,
+		columns:      v.Columns,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2049,6 +2082,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableTriggers),
+			strings.ToLower(infoschema.TableEvents),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2085,7 +2120,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableMemoryUsageOpsHistory),
 			strings.ToLower(infoschema.ClusterTableMemoryUsage),
 			strings.ToLower(infoschema.ClusterTableMemoryUsageOpsHistory),
-			strings.ToLower(infoschema.TableResourceGroups):
+			strings.ToLower(infoschema.TableResourceGroups),
+			strings.ToLower(infoschema.TableInstancePlanCacheStats),
+			strings.ToLower(infoschema.ClusterTableInstancePlanCacheStats):
 			return &MemTableReaderExec{
 				BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -3463,6 +3500,9 @@ func buildNoRangeTableReader(b *executorBuilder, v *plannercore.PhysicalTableRea
 		storeType:        v.StoreType,
 		batchCop:         v.ReadReqType == plannercore.BatchCop,
 	}
+	if ts.SampleInfo != nil {
+		e.systemSampler = newTableSystemSampler(b.ctx.GetStore(), ts.SampleInfo)
+	}
 	e.buildVirtualColumnInfo()
 	if containsLimit(dagReq.Executors) {
 		e.feedback = statistics.NewQueryFeedback(0, nil, 0, ts.Desc)
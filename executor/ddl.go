@@ -102,7 +102,7 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 			return e.createSessionTemporaryTable(s)
 		}
 	case *ast.DropTableStmt:
-		if s.IsView {
+		if s.IsView || s.IsMaterializedView {
 			break
 		}
 
@@ -157,13 +157,17 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateTable(x)
 	case *ast.CreateViewStmt:
 		err = e.executeCreateView(ctx, x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
 	case *ast.DropIndexStmt:
 		err = e.executeDropIndex(x)
 	case *ast.DropDatabaseStmt:
-		err = e.executeDropDatabase(x)
+		err = e.executeDropDatabase(ctx, x)
 	case *ast.DropTableStmt:
 		if x.IsView {
 			err = e.executeDropView(x)
+		} else if x.IsMaterializedView {
+			err = e.executeDropMaterializedView(ctx, x)
 		} else {
 			err = e.executeDropTable(x)
 			if err == nil {
@@ -204,6 +208,20 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreatePlacementPolicy(x)
 	case *ast.DropPlacementPolicyStmt:
 		err = e.executeDropPlacementPolicy(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
 	case *ast.AlterPlacementPolicyStmt:
 		err = e.executeAlterPlacementPolicy(x)
 	case *ast.CreateResourceGroupStmt:
@@ -296,6 +314,21 @@ func (e *DDLExec) createSessionTemporaryTable(s *ast.CreateTableStmt) error {
 	return nil
 }
 
+// alterLocalTemporaryTable changes the columns and indexes of a local temporary table in the session.
+func (e *DDLExec) alterLocalTemporaryTable(schema model.CIStr, tbl table.Table, specs []*ast.AlterTableSpec) error {
+	is := e.Ctx().GetInfoSchema().(infoschema.InfoSchema)
+	dbInfo, ok := is.SchemaByName(schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema.O)
+	}
+
+	tbInfo, err := ddl.BuildAlteredLocalTemporaryTableInfo(e.Ctx(), is, dbInfo, tbl.Meta(), specs)
+	if err != nil {
+		return err
+	}
+	return e.tempTableDDL.AlterLocalTemporaryTable(schema, tbl.Meta().Name, tbInfo)
+}
+
 func (e *DDLExec) executeCreateView(ctx context.Context, s *ast.CreateViewStmt) error {
 	ret := &core.PreprocessorReturn{}
 	err := core.Preprocess(ctx, e.Ctx(), s.Select, core.WithPreprocessorReturn(ret))
@@ -310,14 +343,28 @@ func (e *DDLExec) executeCreateView(ctx context.Context, s *ast.CreateViewStmt)
 }
 
 func (e *DDLExec) executeCreateIndex(s *ast.CreateIndexStmt) error {
-	if _, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
-		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("CREATE INDEX")
+	if tbl, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
+		constr := &ast.Constraint{
+			Tp:     ast.ConstraintIndex,
+			Name:   s.IndexName,
+			Keys:   s.IndexPartSpecifications,
+			Option: s.IndexOption,
+		}
+		switch s.KeyType {
+		case ast.IndexKeyTypeNone:
+		case ast.IndexKeyTypeUnique:
+			constr.Tp = ast.ConstraintUniq
+		default:
+			return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("CREATE INDEX")
+		}
+		spec := &ast.AlterTableSpec{Tp: ast.AlterTableAddConstraint, IfNotExists: s.IfNotExists, Constraint: constr}
+		return e.alterLocalTemporaryTable(s.Table.Schema, tbl, []*ast.AlterTableSpec{spec})
 	}
 
 	return domain.GetDomain(e.Ctx()).DDL().CreateIndex(e.Ctx(), s)
 }
 
-func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
+func (e *DDLExec) executeDropDatabase(ctx context.Context, s *ast.DropDatabaseStmt) error {
 	dbName := s.Name
 
 	// Protect important system table from been dropped by a mistake.
@@ -326,7 +373,17 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 		return errors.New("Drop 'mysql' database is forbidden")
 	}
 
+	mvIDs := schemaMaterializedViews(domain.GetDomain(e.Ctx()).InfoSchema(), dbName)
 	err := domain.GetDomain(e.Ctx()).DDL().DropSchema(e.Ctx(), s)
+	if err == nil {
+		// The events of the schema are dropped with it.
+		if scheduler := domain.GetDomain(e.Ctx()).EventScheduler(); scheduler != nil {
+			err = scheduler.DropSchemaEvents(ctx, dbName.O)
+		}
+	}
+	if err == nil {
+		err = e.cleanDroppedMaterializedViewLogs(ctx, mvIDs)
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
@@ -370,16 +427,28 @@ func (e *DDLExec) dropLocalTemporaryTables(localTempTables []*ast.TableName) err
 }
 
 func (e *DDLExec) executeDropIndex(s *ast.DropIndexStmt) error {
-	if _, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
-		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("DROP INDEX")
+	if tbl, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
+		if s.IsHypo {
+			return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("DROP INDEX")
+		}
+		spec := &ast.AlterTableSpec{Tp: ast.AlterTableDropIndex, Name: s.IndexName, IfExists: s.IfExists}
+		return e.alterLocalTemporaryTable(s.Table.Schema, tbl, []*ast.AlterTableSpec{spec})
 	}
 
 	return domain.GetDomain(e.Ctx()).DDL().DropIndex(e.Ctx(), s)
 }
 
 func (e *DDLExec) executeAlterTable(ctx context.Context, s *ast.AlterTableStmt) error {
-	if _, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
-		return dbterror.ErrUnsupportedLocalTempTableDDL.GenWithStackByArgs("ALTER TABLE")
+	if tbl, ok := e.getLocalTemporaryTable(s.Table.Schema, s.Table.Name); ok {
+		return e.alterLocalTemporaryTable(s.Table.Schema, tbl, s.Specs)
+	}
+	if len(s.Specs) == 1 {
+		switch s.Specs[0].Tp {
+		case ast.AlterTableCheckPartitions:
+			return e.executeCheckPartitions(ctx, s.Table, s.Specs[0])
+		case ast.AlterTableRepairPartition:
+			return e.executeRepairPartitions(ctx, s.Table, s.Specs[0])
+		}
 	}
 
 	return domain.GetDomain(e.Ctx()).DDL().AlterTable(ctx, e.Ctx(), s)
@@ -740,6 +809,22 @@ func (e *DDLExec) executeDropPlacementPolicy(s *ast.DropPlacementPolicyStmt) err
 	return domain.GetDomain(e.Ctx()).DDL().DropPlacementPolicy(e.Ctx(), s)
 }
 
+func (e *DDLExec) executeCreateProcedure(s *ast.ProcedureInfo) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateProcedure(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropProcedure(s *ast.DropProcedureStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropProcedure(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
 func (e *DDLExec) executeAlterPlacementPolicy(s *ast.AlterPlacementPolicyStmt) error {
 	return domain.GetDomain(e.Ctx()).DDL().AlterPlacementPolicy(e.Ctx(), s)
 }
@@ -0,0 +1,242 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/eventscheduler"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+func (e *DDLExec) eventScheduler() (*eventscheduler.Scheduler, error) {
+	scheduler := domain.GetDomain(e.Ctx()).EventScheduler()
+	if scheduler == nil {
+		return nil, errors.New("event scheduler is not initialized")
+	}
+	return scheduler, nil
+}
+
+func (e *DDLExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	schema, ok := e.is.SchemaByName(s.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	scheduler, err := e.eventScheduler()
+	if err != nil {
+		return err
+	}
+	ev, err := scheduler.GetEvent(ctx, schema.Name.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if ev != nil {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	info := &eventscheduler.EventInfo{
+		Schema:      schema.Name.O,
+		Name:        s.EventName.Name.O,
+		DBCollation: schema.Collate,
+		Preserve:    s.Completion == ast.EventCompletionPreserve,
+		Created:     now,
+		LastAltered: now,
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	e.setEventDefinition(info, s.Text(), s.Body.Text())
+	if err = e.setEventSchedule(info, s.Schedule, now); err != nil {
+		return err
+	}
+
+	enable := s.Status != ast.EventStatusDisable
+	if info.IsOneTime() && info.ExecuteAt.Before(now) {
+		if !info.Preserve {
+			// The event is dropped immediately after creation, so it's not created at all.
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast)
+			return nil
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		enable = false
+	}
+	return scheduler.CreateEvent(ctx, info, enable)
+}
+
+func (e *DDLExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	if _, ok := e.is.SchemaByName(s.EventName.Schema); !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	scheduler, err := e.eventScheduler()
+	if err != nil {
+		return err
+	}
+	ev, err := scheduler.GetEvent(ctx, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+	}
+
+	now := time.Now()
+	info := *ev.EventInfo
+	info.LastAltered = now
+	switch s.Completion {
+	case ast.EventCompletionPreserve:
+		info.Preserve = true
+	case ast.EventCompletionNotPreserve:
+		info.Preserve = false
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if s.Body != nil {
+		e.setEventDefinition(&info, s.Text(), s.Body.Text())
+	}
+	if s.Schedule != nil {
+		info.ExecuteAt, info.IntervalValue, info.IntervalField, info.Starts, info.Ends = time.Time{}, 0, 0, time.Time{}, time.Time{}
+		if err = e.setEventSchedule(&info, s.Schedule, now); err != nil {
+			return err
+		}
+	}
+
+	enable := ev.Enable
+	switch s.Status {
+	case ast.EventStatusEnable:
+		enable = true
+	case ast.EventStatusDisable:
+		enable = false
+	}
+	if info.IsOneTime() && info.ExecuteAt.Before(now) && (s.Schedule != nil || s.Status == ast.EventStatusEnable) {
+		// The one-time event can't be rescheduled to or re-enabled at a time in the past.
+		if !info.Preserve {
+			return exeerrors.ErrEventCannotAlterInThePast
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		enable = false
+	}
+	return scheduler.UpdateEvent(ctx, ev, &info, enable, s.Schedule != nil)
+}
+
+func (e *DDLExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	scheduler, err := e.eventScheduler()
+	if err != nil {
+		return err
+	}
+	ev, err := scheduler.GetEvent(ctx, s.EventName.Schema.O, s.EventName.Name.O)
+	if err != nil {
+		return err
+	}
+	if ev == nil {
+		err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(s.EventName.Name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	return scheduler.DropEvent(ctx, ev)
+}
+
+// setEventDefinition sets the body of the event, the body is executed with the definer, the SQL mode and
+// the time zone of the session that defines it.
+func (e *DDLExec) setEventDefinition(info *eventscheduler.EventInfo, definition, body string) {
+	vars := e.Ctx().GetSessionVars()
+	info.Definer = &auth.UserIdentity{}
+	if vars.User != nil {
+		info.Definer.Username, info.Definer.Hostname = vars.User.AuthUsername, vars.User.AuthHostname
+	}
+	info.SQLMode, _ = vars.GetSystemVar(variable.SQLModeVar)
+	info.TimeZone, _ = vars.GetSystemVar(variable.TimeZone)
+	info.CharacterSetClient, info.CollationConnection = vars.GetCharsetInfo()
+	info.Definition = definition
+	info.Body = body
+}
+
+func (e *DDLExec) setEventSchedule(info *eventscheduler.EventInfo, schedule *ast.EventSchedule, now time.Time) (err error) {
+	if schedule.At != nil {
+		info.ExecuteAt, err = e.evalEventTime(schedule.At)
+		return err
+	}
+
+	d, err := expression.EvalAstExpr(e.Ctx(), schedule.Every)
+	if err != nil {
+		return err
+	}
+	if d.IsNull() {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	if info.IntervalValue, err = d.ToInt64(e.Ctx().GetSessionVars().StmtCtx); err != nil {
+		return err
+	}
+	info.IntervalField = schedule.Unit
+	if _, err = info.Interval(); err != nil {
+		return err
+	}
+
+	info.Starts = now
+	if schedule.Starts != nil {
+		if info.Starts, err = e.evalEventTime(schedule.Starts); err != nil {
+			return err
+		}
+	}
+	if schedule.Ends != nil {
+		if info.Ends, err = e.evalEventTime(schedule.Ends); err != nil {
+			return err
+		}
+		if !info.Ends.After(info.Starts) {
+			return exeerrors.ErrEventEndsBeforeStarts
+		}
+	}
+	return nil
+}
+
+// evalEventTime evaluates the time of the AT, STARTS and ENDS clauses in the time zone of the session.
+func (e *DDLExec) evalEventTime(expr ast.ExprNode) (time.Time, error) {
+	d, err := expression.EvalAstExpr(e.Ctx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d.IsNull() {
+		return time.Time{}, types.ErrWrongValue2.GenWithStackByArgs(types.DateTimeStr, "NULL")
+	}
+	vars := e.Ctx().GetSessionVars()
+	t, err := d.ConvertTo(vars.StmtCtx, types.NewFieldType(mysql.TypeDatetime))
+	if err != nil {
+		return time.Time{}, err
+	}
+	if t.IsNull() || t.GetMysqlTime().IsZero() {
+		str, _ := d.ToString()
+		return time.Time{}, types.ErrWrongValue2.GenWithStackByArgs(types.DateTimeStr, str)
+	}
+	return t.GetMysqlTime().GoTime(vars.Location())
+}
@@ -19,8 +19,10 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/stmtsummary"
 	"github.com/stretchr/testify/require"
 )
 
@@ -66,6 +68,45 @@ func TestIndexAdvise(t *testing.T) {
 	require.Equal(t, uint64(5), ia.MaxIndexNum.PerDB)
 }
 
+func TestAdviseIndexes(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, c int, d varchar(64), key(c))")
+	tk.MustExec("create table t2(a int primary key, b int)")
+
+	tk.MustGetErrMsg("advise indexes for 'insert into t values (1, 1, 1, 1)'",
+		"Index Advise: only SELECT, UPDATE and DELETE statements are supported, but got 'insert into t values (1, 1, 1, 1)'")
+	tk.MustGetErrMsg("advise indexes for 'select * from t' max_idxnum per_table 0",
+		"Index Advise: the maximum number of indexes should be greater than 0")
+
+	// The columns of the existing indexes are not advised.
+	tk.MustQuery("advise indexes for 'select * from t where c = 1', 'select * from t2 where a = 1'").Check(testkit.Rows())
+
+	rows := tk.MustQuery("advise indexes for 'select * from t where a = 1 and b > 1', 'update t set d = 1 where a = 2'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, []interface{}{"test", "t", "idx_a_b", "a,b", "2"}, rows[0][:5])
+	require.Equal(t, "CREATE INDEX `idx_a_b` ON `test`.`t`(`a`, `b`)", rows[0][6])
+	// The hypothetical indexes of the advisor are not kept.
+	require.Nil(t, tk.Session().GetSessionVars().HypoIndexes["test"]["t"]["idx_a_b"])
+
+	rows = tk.MustQuery("advise indexes for 'select * from t where a = 1', 'select * from t2 where b = 1' max_idxnum per_db 1").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "idx_a", rows[0][2])
+
+	// A query which can't be optimized is skipped with a warning.
+	tk.MustQuery("advise indexes for 'select * from t3 where a = 1'").Check(testkit.Rows())
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 1)
+
+	// The workload is read from the statement summary.
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustQuery("select * from t where d = 'x'").Check(testkit.Rows())
+	tk.MustQuery("select * from t where d = 'y'").Check(testkit.Rows())
+	tk.MustQuery("advise indexes for workload").CheckAt([]int{0, 1, 2, 3, 4, 6},
+		testkit.Rows("test t idx_d d 1 CREATE INDEX `idx_d` ON `test`.`t`(`d`)"))
+}
+
 func TestIndexJoinProjPattern(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
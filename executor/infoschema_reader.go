@@ -36,6 +36,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/domain/resourcegroup"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/eventscheduler"
 	"github.com/pingcap/tidb/executor/internal/exec"
 	"github.com/pingcap/tidb/executor/internal/pdhelper"
 	"github.com/pingcap/tidb/expression"
@@ -71,6 +72,7 @@ import (
 	"github.com/pingcap/tidb/util/mathutil"
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/pdapi"
+	utilpc "github.com/pingcap/tidb/util/plancache"
 	"github.com/pingcap/tidb/util/resourcegrouptag"
 	"github.com/pingcap/tidb/util/sem"
 	"github.com/pingcap/tidb/util/servermemorylimit"
@@ -132,6 +134,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -192,6 +198,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForClusterMemoryUsageOpsHistory(sctx)
 		case infoschema.TableResourceGroups:
 			err = e.setDataFromResourceGroups()
+		case infoschema.TableInstancePlanCacheStats:
+			e.setDataForInstancePlanCacheStats(sctx)
+		case infoschema.ClusterTableInstancePlanCacheStats:
+			err = e.setDataForClusterInstancePlanCacheStats(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -408,6 +418,11 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				visible = "NO"
 			}
 
+			indexType := "BTREE"
+			if index.IsFullText() {
+				indexType = index.Tp.String()
+			}
+
 			colName := col.Name.O
 			var expression interface{}
 			expression = nil
@@ -431,7 +446,7 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				nil,                   // SUB_PART
 				nil,                   // PACKED
 				nullable,              // NULLABLE
-				"BTREE",               // INDEX_TYPE
+				indexType,             // INDEX_TYPE
 				"",                    // COMMENT
 				index.Comment,         // INDEX_COMMENT
 				visible,               // IS_VISIBLE
@@ -1146,6 +1161,138 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	loc := ctx.GetSessionVars().Location()
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			// ACTION_ORDER is the order of the trigger among the triggers with the same action time and event.
+			actionOrders := make(map[[2]int]int)
+			for _, trigger := range table.Triggers {
+				if trigger.State != model.StatePublic {
+					continue
+				}
+				key := [2]int{int(trigger.Timing), int(trigger.Event)}
+				actionOrders[key]++
+				created := types.NewTime(types.FromGoTime(trigger.Created.In(loc)), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,       // TRIGGER_CATALOG
+					schema.Name.O,               // TRIGGER_SCHEMA
+					trigger.Name.O,              // TRIGGER_NAME
+					trigger.Event.String(),      // EVENT_MANIPULATION
+					infoschema.CatalogVal,       // EVENT_OBJECT_CATALOG
+					schema.Name.O,               // EVENT_OBJECT_SCHEMA
+					table.Name.O,                // EVENT_OBJECT_TABLE
+					actionOrders[key],           // ACTION_ORDER
+					nil,                         // ACTION_CONDITION
+					trigger.Statement,           // ACTION_STATEMENT
+					"ROW",                       // ACTION_ORIENTATION
+					trigger.Timing.String(),     // ACTION_TIMING
+					nil,                         // ACTION_REFERENCE_OLD_TABLE
+					nil,                         // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                       // ACTION_REFERENCE_OLD_ROW
+					"NEW",                       // ACTION_REFERENCE_NEW_ROW
+					created,                     // CREATED
+					trigger.SQLMode,             // SQL_MODE
+					trigger.Definer.String(),    // DEFINER
+					trigger.CharacterSetClient,  // CHARACTER_SET_CLIENT
+					trigger.CollationConnection, // COLLATION_CONNECTION
+					trigger.DBCollation,         // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	scheduler := domain.GetDomain(sctx).EventScheduler()
+	if scheduler == nil {
+		return nil
+	}
+	events, err := scheduler.ListEvents(ctx, "")
+	if err != nil {
+		return err
+	}
+	is := sctx.GetInfoSchema().(infoschema.InfoSchema)
+	checker := privilege.GetPrivilegeManager(sctx)
+	loc := sctx.GetSessionVars().Location()
+	rows := make([][]types.Datum, 0, len(events))
+	for _, ev := range events {
+		if _, ok := is.SchemaByName(model.NewCIStr(ev.Schema)); !ok {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(ev.Schema), "", "", mysql.EventPriv) {
+			continue
+		}
+		rows = append(rows, eventRow(ev, loc))
+	}
+	e.rows = rows
+	return nil
+}
+
+// eventRow returns the row of the event in information_schema.EVENTS, the times are shown in the time zone
+// of the session.
+func eventRow(ev *eventscheduler.Event, loc *time.Location) []types.Datum {
+	toDatetime := func(t time.Time) interface{} {
+		if t.IsZero() {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+	}
+	eventType, intervalValue, intervalField := "ONE TIME", interface{}(nil), interface{}(nil)
+	if !ev.IsOneTime() {
+		eventType = "RECURRING"
+		intervalValue, intervalField = strconv.FormatInt(ev.IntervalValue, 10), ev.IntervalField.String()
+	}
+	status := "DISABLED"
+	if ev.Enable {
+		status = "ENABLED"
+	}
+	onCompletion := "NOT PRESERVE"
+	if ev.Preserve {
+		onCompletion = "PRESERVE"
+	}
+	definer := ""
+	if ev.Definer != nil {
+		definer = ev.Definer.String()
+	}
+	return types.MakeDatums(
+		infoschema.CatalogVal,       // EVENT_CATALOG
+		ev.Schema,                   // EVENT_SCHEMA
+		ev.Name,                     // EVENT_NAME
+		definer,                     // DEFINER
+		ev.TimeZone,                 // TIME_ZONE
+		"SQL",                       // EVENT_BODY
+		ev.Body,                     // EVENT_DEFINITION
+		eventType,                   // EVENT_TYPE
+		toDatetime(ev.ExecuteAt),    // EXECUTE_AT
+		intervalValue,               // INTERVAL_VALUE
+		intervalField,               // INTERVAL_FIELD
+		ev.SQLMode,                  // SQL_MODE
+		toDatetime(ev.Starts),       // STARTS
+		toDatetime(ev.Ends),         // ENDS
+		status,                      // STATUS
+		onCompletion,                // ON_COMPLETION
+		toDatetime(ev.Created),      // CREATED
+		toDatetime(ev.LastAltered),  // LAST_ALTERED
+		toDatetime(ev.LastExecuted), // LAST_EXECUTED
+		ev.Comment,                  // EVENT_COMMENT
+		0,                           // ORIGINATOR
+		ev.CharacterSetClient,       // CHARACTER_SET_CLIENT
+		ev.CollationConnection,      // COLLATION_CONNECTION
+		ev.DBCollation,              // DATABASE_COLLATION
+	)
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx sessionctx.Context) (err error) {
 	tikvStore, ok := ctx.GetStore().(helper.Storage)
 	if !ok {
@@ -2403,6 +2550,35 @@ func (e *memtableRetriever) setDataForClusterMemoryUsage(ctx sessionctx.Context)
 	return nil
 }
 
+func (e *memtableRetriever) setDataForInstancePlanCacheStats(ctx sessionctx.Context) {
+	var stats utilpc.InstancePlanCacheStats
+	if cache := domain.GetDomain(ctx).InstancePlanCache(); cache != nil {
+		stats = cache.Stats()
+	} else {
+		stats.MemLimit = variable.InstancePlanCacheMaxMemSize.Load()
+	}
+	e.rows = append(e.rows, types.MakeDatums(
+		stats.PlanNum,
+		stats.MemUsage,
+		stats.MemLimit,
+		stats.Hits,
+		stats.Misses,
+		stats.Puts,
+		stats.Evictions,
+		stats.EvictedMem,
+	))
+}
+
+func (e *memtableRetriever) setDataForClusterInstancePlanCacheStats(ctx sessionctx.Context) error {
+	e.setDataForInstancePlanCacheStats(ctx)
+	rows, err := infoschema.AppendHostInfoToRows(ctx, e.rows)
+	if err != nil {
+		return err
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataForMemoryUsageOpsHistory() error {
 	e.rows = servermemorylimit.GlobalMemoryOpsHistoryManager.GetRows()
 	return nil
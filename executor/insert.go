@@ -199,7 +199,7 @@ func (e *InsertExec) updateDupRow(ctx context.Context, idxInBatch int, txn kv.Tr
 
 	err = e.doDupRowUpdate(ctx, handle, oldRow, row.row, extraCols, e.OnDuplicate, idxInBatch)
 	if e.Ctx().GetSessionVars().StmtCtx.DupKeyAsWarning && (kv.ErrKeyExists.Equal(err) ||
-		table.ErrCheckConstraintViolated.Equal(err)) {
+		table.IsErrCheckConstraintViolated(err)) {
 		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(err)
 		return nil
 	}
@@ -426,11 +426,19 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
+	numCols := len(e.Table.Cols())
+	err := fireTriggers(ctx, e.Ctx(), e.Table, model.TriggerTimingBefore, model.TriggerEventUpdate, oldRow[:numCols], newData[:numCols])
 	if err != nil {
 		return err
 	}
-	return nil
+	_, err = updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
+	if err != nil {
+		return err
+	}
+	if err = logMaterializedViewChanges(e.Ctx(), e.Table, oldRow[:numCols], newData[:numCols]); err != nil {
+		return err
+	}
+	return fireTriggers(ctx, e.Ctx(), e.Table, model.TriggerTimingAfter, model.TriggerEventUpdate, oldRow[:numCols], newData[:numCols])
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
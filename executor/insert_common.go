@@ -690,6 +690,11 @@ func (e *InsertValues) fillRow(ctx context.Context, row []types.Datum, hasValue
 			}
 		}
 	}
+	// The BEFORE INSERT triggers may change the values, the generated columns are evaluated after them.
+	err := fireTriggers(ctx, e.Ctx(), e.Table, model.TriggerTimingBefore, model.TriggerEventInsert, nil, row[:len(e.Table.Cols())])
+	if err != nil {
+		return nil, err
+	}
 	tbl := e.Table.Meta()
 	// Handle exchange partition
 	if tbl.ExchangePartitionInfo != nil && tbl.ExchangePartitionInfo.ExchangePartitionFlag {
@@ -1302,7 +1307,7 @@ CheckAndInsert:
 		err = addRecord(ctx, rows[i])
 		if err != nil {
 			// throw warning when violate check constraint
-			if table.ErrCheckConstraintViolated.Equal(err) {
+			if table.IsErrCheckConstraintViolated(err) {
 				if !sc.InLoadDataStmt {
 					sc.AppendWarning(err)
 				}
@@ -1359,6 +1364,11 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	// The rows removed by REPLACE fire the DELETE triggers.
+	err = fireTriggers(ctx, e.Ctx(), r.t, model.TriggerTimingBefore, model.TriggerEventDelete, oldRow, nil)
+	if err != nil {
+		return false, err
+	}
 	err = r.t.RemoveRecord(e.Ctx(), handle, oldRow)
 	if err != nil {
 		return false, err
@@ -1367,6 +1377,14 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	err = logMaterializedViewChanges(e.Ctx(), r.t, oldRow, nil)
+	if err != nil {
+		return false, err
+	}
+	err = fireTriggers(ctx, e.Ctx(), r.t, model.TriggerTimingAfter, model.TriggerEventDelete, oldRow, nil)
+	if err != nil {
+		return false, err
+	}
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
@@ -1425,7 +1443,10 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 			}
 		}
 	}
-	return nil
+	if err = logMaterializedViewChanges(e.Ctx(), e.Table, nil, row[:len(e.Table.Cols())]); err != nil {
+		return err
+	}
+	return fireTriggers(ctx, e.Ctx(), e.Table, model.TriggerTimingAfter, model.TriggerEventInsert, nil, row[:len(e.Table.Cols())])
 }
 
 // CreateSession will be assigned by session package.
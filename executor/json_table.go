@@ -0,0 +1,333 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+// JSONTableExec implements the JSON_TABLE table function. It evaluates the document when it is
+// opened, so it can be reopened by Apply for every outer row when the document references the
+// preceding tables, and generates the rows lazily in Next.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	asName  model.CIStr
+	expr    expression.Expression
+	path    types.JSONPathExpression
+	columns []*plannercore.JSONTableColumn
+
+	// convertCtx is a strict statement context used to convert the JSON values to the column
+	// types, so the conversion errors are reported instead of being truncated into warnings.
+	convertCtx *stmtctx.StatementContext
+	// row is the row being generated, and buffer holds the generated rows of a Next call by
+	// column, so the rows are written into the chunk column by column.
+	row    []types.Datum
+	buffer [][]types.Datum
+	// levels is the stack of the nesting levels being iterated, the first one is the top level.
+	levels []*jsonTableLevel
+}
+
+// jsonTableLevel is the state of a nesting level of the columns. The rows of a matched value
+// are generated by iterating the nested paths of the level one by one.
+type jsonTableLevel struct {
+	columns []*plannercore.JSONTableColumn
+	values  []types.BinaryJSON
+	// valueIdx is the index of the next value, so the current value is values[valueIdx-1].
+	valueIdx int
+	// colIdx is the index of the next column to look for the nested paths of the current value,
+	// it's -1 if the next value should be generated.
+	colIdx int
+	// generated reports whether the nested paths of the current value have generated rows.
+	generated bool
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.convertCtx = &stmtctx.StatementContext{TimeZone: e.Ctx().GetSessionVars().Location()}
+	if e.row == nil {
+		e.row = make([]types.Datum, e.Schema().Len())
+		e.buffer = make([][]types.Datum, e.Schema().Len())
+	}
+	e.levels = e.levels[:0]
+
+	doc, isNull, err := e.evalDocument()
+	if err != nil || isNull {
+		return err
+	}
+	if values := extractJSONTableValues(doc, e.path); len(values) > 0 {
+		e.levels = append(e.levels, &jsonTableLevel{columns: e.columns, values: values, colIdx: -1})
+	}
+	return nil
+}
+
+func (e *JSONTableExec) evalDocument() (types.BinaryJSON, bool, error) {
+	if e.expr.GetType().EvalType() == types.ETJson {
+		return e.expr.EvalJSON(e.Ctx(), chunk.Row{})
+	}
+	str, isNull, err := e.expr.EvalString(e.Ctx(), chunk.Row{})
+	if err != nil || isNull {
+		return types.BinaryJSON{}, isNull, err
+	}
+	doc, err := types.ParseBinaryJSONFromString(str)
+	return doc, false, err
+}
+
+// extractJSONTableValues returns all the values matched by the path.
+func extractJSONTableValues(doc types.BinaryJSON, path types.JSONPathExpression) []types.BinaryJSON {
+	ret, found := doc.Extract([]types.JSONPathExpression{path})
+	if !found {
+		return nil
+	}
+	if !path.CouldMatchMultipleValues() {
+		return []types.BinaryJSON{ret}
+	}
+	values := make([]types.BinaryJSON, 0, ret.GetElemCount())
+	for i := 0; i < ret.GetElemCount(); i++ {
+		values = append(values, ret.ArrayGetElem(i))
+	}
+	return values
+}
+
+// nextRow generates the next row into e.row, it returns false if there are no more rows. For
+// every matched value of a level, the columns of the level are filled and then the rows of its
+// nested paths are generated. Sibling nested paths are not joined to each other: when the rows
+// of one of them are generated, the columns of the others are NULL. A value generates one row
+// with the NULL nested columns if its nested paths don't generate any row.
+func (e *JSONTableExec) nextRow() (bool, error) {
+	for len(e.levels) > 0 {
+		level := e.levels[len(e.levels)-1]
+		if level.colIdx < 0 {
+			if level.valueIdx >= len(level.values) {
+				e.levels = e.levels[:len(e.levels)-1]
+				if len(e.levels) > 0 {
+					parent := e.levels[len(e.levels)-1]
+					e.setNestedNull(parent.columns[parent.colIdx-1])
+				}
+				continue
+			}
+			level.valueIdx++
+			hasNested, err := e.fillColumns(level.columns, level.values[level.valueIdx-1], level.valueIdx)
+			if err != nil {
+				return false, err
+			}
+			if !hasNested {
+				return true, nil
+			}
+			level.colIdx, level.generated = 0, false
+			continue
+		}
+		if level.colIdx >= len(level.columns) {
+			level.colIdx = -1
+			if !level.generated {
+				return true, nil
+			}
+			continue
+		}
+		col := level.columns[level.colIdx]
+		level.colIdx++
+		if col.Tp != ast.JSONTableColumnNested {
+			continue
+		}
+		if values := extractJSONTableValues(level.values[level.valueIdx-1], col.Path); len(values) > 0 {
+			level.generated = true
+			e.levels = append(e.levels, &jsonTableLevel{columns: col.Nested, values: values, colIdx: -1})
+		}
+	}
+	return false, nil
+}
+
+// fillColumns fills the columns of a nesting level with the matched JSON value, the nested
+// columns are set to NULL. It returns whether the level has nested paths.
+func (e *JSONTableExec) fillColumns(columns []*plannercore.JSONTableColumn, value types.BinaryJSON, ordinality int) (hasNested bool, err error) {
+	for _, col := range columns {
+		switch col.Tp {
+		case ast.JSONTableColumnNested:
+			hasNested = true
+			e.setNestedNull(col)
+		case ast.JSONTableColumnOrdinality:
+			e.row[col.Offset].SetUint64(uint64(ordinality))
+		case ast.JSONTableColumnExists:
+			_, found := value.Extract([]types.JSONPathExpression{col.Path})
+			exists := types.NewIntDatum(0)
+			if found {
+				exists = types.NewIntDatum(1)
+			}
+			d, err := exists.ConvertTo(e.convertCtx, col.RetType)
+			if err != nil {
+				return false, err
+			}
+			e.row[col.Offset] = d
+		case ast.JSONTableColumnPath:
+			d, err := e.evalPathColumn(col, value)
+			if err != nil {
+				return false, err
+			}
+			e.row[col.Offset] = d
+		}
+	}
+	return hasNested, nil
+}
+
+func (e *JSONTableExec) setNestedNull(nested *plannercore.JSONTableColumn) {
+	for _, col := range nested.Nested {
+		if col.Tp == ast.JSONTableColumnNested {
+			e.setNestedNull(col)
+			continue
+		}
+		e.row[col.Offset].SetNull()
+	}
+}
+
+func (e *JSONTableExec) evalPathColumn(col *plannercore.JSONTableColumn, value types.BinaryJSON) (types.Datum, error) {
+	values := extractJSONTableValues(value, col.Path)
+	if len(values) == 0 {
+		switch col.OnEmpty.Tp {
+		case ast.JSONTableOnResponseError:
+			return types.Datum{}, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O)
+		case ast.JSONTableOnResponseDefault:
+			return e.convertJSONValue(col, col.OnEmpty.Default)
+		}
+		return types.Datum{}, nil
+	}
+	var (
+		d   types.Datum
+		err error
+	)
+	if len(values) > 1 {
+		err = types.ErrInvalidJSONPathMultipleSelection
+	} else {
+		d, err = e.convertJSONValue(col, values[0])
+	}
+	if err == nil {
+		return d, nil
+	}
+	switch col.OnError.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return e.convertJSONValue(col, col.OnError.Default)
+	}
+	return types.Datum{}, nil
+}
+
+// convertJSONValue converts the JSON value to the type of the column.
+func (e *JSONTableExec) convertJSONValue(col *plannercore.JSONTableColumn, value types.BinaryJSON) (types.Datum, error) {
+	if col.RetType.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	var d types.Datum
+	switch value.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.asName.O)
+	case types.JSONTypeCodeLiteral:
+		if value.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		d = types.NewJSONDatum(value)
+	case types.JSONTypeCodeString:
+		d = types.NewStringDatum(string(value.GetString()))
+	default:
+		d = types.NewJSONDatum(value)
+	}
+	return d.ConvertTo(e.convertCtx, col.RetType)
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	for i := range e.buffer {
+		e.buffer[i] = e.buffer[i][:0]
+	}
+	for n := 0; n < req.RequiredRows(); n++ {
+		ok, err := e.nextRow()
+		if err != nil {
+			return err
+		}
+		if !ok {
+			break
+		}
+		for i := range e.row {
+			e.buffer[i] = append(e.buffer[i], e.row[i])
+		}
+	}
+	for i, col := range e.Schema().Columns {
+		appendDatumsToColumn(req.Column(i), col.RetType, e.buffer[i])
+	}
+	return nil
+}
+
+// appendDatumsToColumn appends the datums of the type to the column.
+func appendDatumsToColumn(col *chunk.Column, tp *types.FieldType, datums []types.Datum) {
+	for i := range datums {
+		d := &datums[i]
+		if d.IsNull() {
+			col.AppendNull()
+			continue
+		}
+		switch tp.EvalType() {
+		case types.ETInt:
+			if tp.GetType() == mysql.TypeBit {
+				col.AppendBytes(d.GetBytes())
+			} else {
+				col.AppendInt64(d.GetInt64())
+			}
+		case types.ETReal:
+			if tp.GetType() == mysql.TypeFloat {
+				col.AppendFloat32(d.GetFloat32())
+			} else {
+				col.AppendFloat64(d.GetFloat64())
+			}
+		case types.ETDecimal:
+			col.AppendMyDecimal(d.GetMysqlDecimal())
+		case types.ETDatetime, types.ETTimestamp:
+			col.AppendTime(d.GetMysqlTime())
+		case types.ETDuration:
+			col.AppendDuration(d.GetMysqlDuration())
+		case types.ETJson:
+			col.AppendJSON(d.GetMysqlJSON())
+		default:
+			switch tp.GetType() {
+			case mysql.TypeEnum:
+				col.AppendEnum(d.GetMysqlEnum())
+			case mysql.TypeSet:
+				col.AppendSet(d.GetMysqlSet())
+			default:
+				col.AppendBytes(d.GetBytes())
+			}
+		}
+	}
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.levels = e.levels[:0]
+	return errors.Trace(e.BaseExecutor.Close())
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"b": "z"}]', '$[*]' columns (
+		id for ordinality, a int path '$.a', b varchar(10) path '$.b', has_a int exists path '$.a')) as jt`).
+		Check(testkit.Rows("1 1 x 1", "2 2 <nil> 1", "3 <nil> z 0"))
+
+	// The document can be a JSON value or a string, NULL produces no rows.
+	tk.MustQuery(`select * from json_table(cast('[1, 2]' as json), '$[*]' columns (a int path '$')) as jt`).
+		Check(testkit.Rows("1", "2"))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+	tk.MustQuery(`select * from json_table('{"a": [1, 2]}', '$' columns (a json path '$.a', b json path '$.b')) as jt`).
+		Check(testkit.Rows("[1, 2] <nil>"))
+
+	// ON EMPTY and ON ERROR.
+	tk.MustQuery(`select * from json_table('[{"a": "x"}, {}]', '$[*]' columns (
+		a int path '$.a' default '-1' on empty default '-2' on error)) as jt`).Check(testkit.Rows("-2", "-1"))
+	tk.MustQuery(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a')) as jt`).Check(testkit.Rows("<nil>"))
+	tk.MustGetErrMsg(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`,
+		"[executor:3665]Missing value for JSON_TABLE column 'a'")
+	tk.MustGetErrMsg(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) as jt`,
+		"[executor:3666]Can't store an array or an object in the scalar column 'a' of JSON_TABLE 'jt'.")
+	tk.MustGetErrCode(`select * from json_table('[{"a": "x"}]', '$[*]' columns (a int path '$.a' error on error)) as jt`, 1292)
+
+	// Nested paths, sibling nested paths produce their rows separately.
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [11, 111]}, {"a": 2, "b": [22]}, {"a": 3}]', '$[*]' columns (
+		a int path '$.a', nested path '$.b[*]' columns (id for ordinality, b int path '$'))) as jt`).
+		Check(testkit.Rows("1 1 11", "1 2 111", "2 1 22", "3 <nil> <nil>"))
+	tk.MustQuery(`select * from json_table('{"a": [1, 2], "b": [3]}', '$' columns (
+		nested path '$.a[*]' columns (a int path '$'), nested path '$.b[*]' columns (b int path '$'))) as jt`).
+		Check(testkit.Rows("1 <nil>", "2 <nil>", "<nil> 3"))
+	tk.MustQuery(`select * from json_table('[{"d": 1.5, "t": "2023-01-02", "c": [{"f": 0.5, "e": [1, 2]}, {"f": 2}]}, {"d": 2}]', '$[*]' columns (
+		d decimal(5, 2) path '$.d', t date path '$.t',
+		nested path '$.c[*]' columns (f double path '$.f', nested path '$.e[*]' columns (e float path '$')))) as jt`).
+		Check(testkit.Rows("1.50 2023-01-02 0.5 1", "1.50 2023-01-02 0.5 2", "1.50 2023-01-02 2 <nil>", "2.00 <nil> <nil> <nil>"))
+
+	// JSON_TABLE can reference the columns of the preceding tables.
+	tk.MustExec("create table t (id int, j json)")
+	tk.MustExec(`insert into t values (1, '[1, 2]'), (2, '[3]'), (3, '[]'), (4, null)`)
+	tk.MustQuery(`select t.id, jt.a from t, json_table(t.j, '$[*]' columns (a int path '$')) as jt order by t.id, jt.a`).
+		Check(testkit.Rows("1 1", "1 2", "2 3"))
+	tk.MustQuery(`select t.id, jt.a from t join json_table(t.j, '$[*]' columns (a int path '$')) as jt on jt.a > 1 order by t.id, jt.a`).
+		Check(testkit.Rows("1 2", "2 3"))
+	tk.MustQuery(`select t.id, jt.a from t left join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true order by t.id, jt.a`).
+		Check(testkit.Rows("1 1", "1 2", "2 3", "3 <nil>", "4 <nil>"))
+	tk.MustQuery(`select t.id, sum(jt.a) from t, json_table(t.j, '$[*]' columns (a int path '$')) as jt group by t.id order by t.id`).
+		Check(testkit.Rows("1 3", "2 3"))
+	tk.MustQuery(`select id from t where exists (select 1 from json_table(t.j, '$[*]' columns (a int path '$')) as jt where jt.a = 3)`).
+		Check(testkit.Rows("2"))
+	// The preceding tables are not visible on the right side of RIGHT JOIN.
+	tk.MustGetErrCode(`select * from t right join json_table(t.j, '$[*]' columns (a int path '$')) as jt on true`, 1054)
+
+	// Many rows are returned in more than one chunk.
+	tk.Session().GetSessionVars().MaxChunkSize = 32
+	elems := make([]string, 0, 100)
+	for i := 0; i < 100; i++ {
+		elems = append(elems, fmt.Sprintf("%d", i))
+	}
+	tk.MustQuery(fmt.Sprintf(`select count(*), sum(a) from json_table('[%s]', '$[*]' columns (a int path '$')) as jt`, strings.Join(elems, ","))).
+		Check(testkit.Rows("100 4950"))
+	// The rows are generated lazily, so LIMIT stops the generation early.
+	tk.MustQuery(fmt.Sprintf(`select a from json_table('[%s]', '$[*]' columns (a int path '$')) as jt limit 3`, strings.Join(elems, ","))).
+		Check(testkit.Rows("0", "1", "2"))
+}
+
+func TestJSONTableError(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$', a int path '$')) as jt`, 1060)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[' columns (a int path '$')) as jt`, 3143)
+	tk.MustGetErrCode(`select * from json_table('[1]', '$[*]' columns (a int path '$' default 'x' on empty)) as jt`, 3140)
+	tk.MustGetErrCode(`select * from json_table(1, '$[*]' columns (a int path '$')) as jt`, 3146)
+	tk.MustGetErrCode(`select * from json_table('[1', '$[*]' columns (a int path '$')) as jt`, 3140)
+	tk.MustGetErrMsg(`select * from json_table(unknown.j, '$[*]' columns (a int path '$')) as jt`,
+		"[planner:1054]Unknown column 'unknown.j' in 'a table function argument'")
+}
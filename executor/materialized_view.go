@@ -0,0 +1,431 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/mathutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+const (
+	// materializedViewLogTable is the table logging the keys of the changed rows of the base tables.
+	materializedViewLogTable = "tidb_mview_log"
+	// materializedViewRefreshBatchSize is the max number of keys recomputed by a statement in the incremental refresh.
+	materializedViewRefreshBatchSize = 256
+)
+
+// logMaterializedViewChanges logs the keys of a changed row for the incremental materialized views reading the
+// table, the views recompute the rows of the logged keys when they're refreshed. The oldRow is nil for the inserted
+// rows and the newRow is nil for the deleted rows. The logs are written in the transaction changing the row.
+func logMaterializedViewChanges(sctx sessionctx.Context, tbl table.Table, oldRow, newRow []types.Datum) error {
+	tblInfo := tbl.Meta()
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	mvIDs := is.GetTableMaterializedViews(tblInfo.ID)
+	if len(mvIDs) == 0 {
+		return nil
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	var logTbl table.Table
+	for _, mvID := range mvIDs {
+		mv, ok := is.TableByID(mvID)
+		if !ok || !mv.Meta().MaterializedView.Incremental {
+			continue
+		}
+		for i, base := range mv.Meta().MaterializedView.BaseTables {
+			if base.ID != tblInfo.ID {
+				continue
+			}
+			if logTbl == nil {
+				var err error
+				logTbl, err = is.TableByName(model.NewCIStr(mysql.SystemDB), model.NewCIStr(materializedViewLogTable))
+				if err != nil {
+					return err
+				}
+			}
+			var keys [][]byte
+			for _, row := range [][]types.Datum{oldRow, newRow} {
+				if row == nil {
+					continue
+				}
+				key, err := materializedViewRowKey(sc, tblInfo, base, row)
+				if err != nil {
+					return err
+				}
+				if len(keys) == 0 || !bytes.Equal(keys[0], key) {
+					keys = append(keys, key)
+				}
+			}
+			for _, key := range keys {
+				id, err := table.AllocAutoIncrementValue(context.Background(), logTbl, sctx)
+				if err != nil {
+					return err
+				}
+				_, err = logTbl.AddRecord(sctx, []types.Datum{types.NewIntDatum(mvID), types.NewIntDatum(id), types.NewIntDatum(int64(i)), types.NewBytesDatum(key)})
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// materializedViewRowKey encodes the values of the key columns of a row.
+func materializedViewRowKey(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, base *model.MaterializedViewBaseTable, row []types.Datum) ([]byte, error) {
+	datums := make([]types.Datum, 0, len(base.KeyColumns))
+	for _, key := range base.KeyColumns {
+		col := model.FindColumnInfo(tblInfo.Columns, key.L)
+		if col == nil {
+			return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(key.O, tblInfo.Name.O)
+		}
+		datums = append(datums, row[col.Offset])
+	}
+	return codec.EncodeValue(sc, nil, datums...)
+}
+
+// executeCreateMaterializedView creates a materialized view and fills it with the result of the query.
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	dom := domain.GetDomain(e.Ctx())
+	if s.IfNotExists && dom.InfoSchema().TableExists(s.ViewName.Schema, s.ViewName.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: s.ViewName.Schema, Name: s.ViewName.Name})
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	if err := dom.DDL().CreateMaterializedView(e.Ctx(), s); err != nil {
+		return err
+	}
+	is := dom.InfoSchema()
+	tbl, err := is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, se)
+	return refreshMaterializedView(ctx, se, is, s.ViewName.Schema, tbl.Meta(), ast.RefreshMaterializedViewComplete)
+}
+
+// executeDropMaterializedView drops the materialized views and their logs.
+func (e *DDLExec) executeDropMaterializedView(ctx context.Context, s *ast.DropTableStmt) error {
+	dom := domain.GetDomain(e.Ctx())
+	is := dom.InfoSchema()
+	mvIDs := make([]int64, 0, len(s.Tables))
+	for _, tn := range s.Tables {
+		if tbl, err := is.TableByName(tn.Schema, tn.Name); err == nil && tbl.Meta().IsMaterializedView() {
+			mvIDs = append(mvIDs, tbl.Meta().ID)
+		}
+	}
+	err := dom.DDL().DropTable(e.Ctx(), s)
+	// Some of the views may be dropped even if an error is returned.
+	if cleanErr := e.cleanDroppedMaterializedViewLogs(ctx, mvIDs); err == nil {
+		err = cleanErr
+	}
+	return err
+}
+
+// schemaMaterializedViews returns the IDs of the materialized views in the schema.
+func schemaMaterializedViews(is infoschema.InfoSchema, schema model.CIStr) []int64 {
+	var mvIDs []int64
+	for _, tbl := range is.SchemaTables(schema) {
+		if tbl.Meta().IsMaterializedView() {
+			mvIDs = append(mvIDs, tbl.Meta().ID)
+		}
+	}
+	return mvIDs
+}
+
+// cleanDroppedMaterializedViewLogs deletes the logs of the materialized views which don't exist anymore.
+func (e *DDLExec) cleanDroppedMaterializedViewLogs(ctx context.Context, mvIDs []int64) error {
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	dropped := mvIDs[:0]
+	for _, id := range mvIDs {
+		if _, ok := is.TableByID(id); !ok {
+			dropped = append(dropped, id)
+		}
+	}
+	if len(dropped) == 0 {
+		return nil
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, se)
+	for _, id := range dropped {
+		if _, err := execMaterializedViewSQL(ctx, se, "DELETE FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, materializedViewLogTable, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// executeRefreshMaterializedView refreshes the data of a materialized view.
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	is := domain.GetDomain(e.Ctx()).InfoSchema()
+	tbl, err := is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if err != nil {
+		return err
+	}
+	if !tbl.Meta().IsMaterializedView() {
+		return exeerrors.ErrWrongObject.GenWithStackByArgs(s.ViewName.Schema.O, s.ViewName.Name.O, "MATERIALIZED VIEW")
+	}
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	defer e.ReleaseSysSession(ctx, se)
+	return refreshMaterializedView(ctx, se, is, s.ViewName.Schema, tbl.Meta(), s.Mode)
+}
+
+// refreshMaterializedView refreshes a materialized view in a system session. The view is refreshed incrementally if
+// it's possible and the mode isn't COMPLETE, or the whole result of the query is recomputed. The refresh runs in an
+// optimistic transaction, so only the logs read by the refresh are consumed.
+func refreshMaterializedView(ctx context.Context, se sessionctx.Context, is infoschema.InfoSchema, schema model.CIStr,
+	mvInfo *model.TableInfo, mode ast.RefreshMaterializedViewMode) (err error) {
+	info := mvInfo.MaterializedView
+	if mode == ast.RefreshMaterializedViewIncremental && !info.Incremental {
+		return exeerrors.ErrMaterializedViewNotIncremental.GenWithStackByArgs(mvInfo.Name.O)
+	}
+	// The query is always executed with the sql_mode the view is created with.
+	sqlMode, err := mysql.GetSQLMode(info.SQLMode)
+	if err != nil {
+		return err
+	}
+	sessVars := se.GetSessionVars()
+	originSQLMode := sessVars.SQLMode
+	sessVars.SQLMode = sqlMode
+	defer func() {
+		sessVars.SQLMode = originSQLMode
+	}()
+
+	if _, err = execMaterializedViewSQL(ctx, se, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err == nil {
+			_, err = execMaterializedViewSQL(ctx, se, "COMMIT")
+			return
+		}
+		_, rollbackErr := execMaterializedViewSQL(ctx, se, "ROLLBACK")
+		terror.Log(rollbackErr)
+	}()
+	if info.Incremental && mode != ast.RefreshMaterializedViewComplete {
+		if baseTables, ok := materializedViewBaseTables(is, info); ok {
+			return refreshMaterializedViewIncrementally(ctx, se, schema, mvInfo, baseTables)
+		}
+	}
+	return refreshMaterializedViewCompletely(ctx, se, schema, mvInfo)
+}
+
+// materializedViewBaseTables returns the base tables of an incremental view. It returns false if a base table is
+// dropped, truncated or renamed, or a key column is dropped, then the logs may be lost and the view can't be refreshed
+// incrementally.
+func materializedViewBaseTables(is infoschema.InfoSchema, info *model.MaterializedViewInfo) ([]*model.TableInfo, bool) {
+	tblInfos := make([]*model.TableInfo, 0, len(info.BaseTables))
+	for _, base := range info.BaseTables {
+		tbl, ok := is.TableByID(base.ID)
+		if !ok || tbl.Meta().Name.L != base.Name.L {
+			return nil, false
+		}
+		db, ok := is.SchemaByTable(tbl.Meta())
+		if !ok || db.Name.L != base.Schema.L {
+			return nil, false
+		}
+		for _, key := range base.KeyColumns {
+			if model.FindColumnInfo(tbl.Meta().Columns, key.L) == nil {
+				return nil, false
+			}
+		}
+		tblInfos = append(tblInfos, tbl.Meta())
+	}
+	return tblInfos, true
+}
+
+func refreshMaterializedViewCompletely(ctx context.Context, se sessionctx.Context, schema model.CIStr, mvInfo *model.TableInfo) error {
+	if _, err := execMaterializedViewSQL(ctx, se, "DELETE FROM %n.%n", schema.O, mvInfo.Name.O); err != nil {
+		return err
+	}
+	var sb strings.Builder
+	sqlexec.MustFormatSQL(&sb, "INSERT INTO %n.%n ", schema.O, mvInfo.Name.O)
+	// The query may contain '%', so it's not formatted.
+	sb.WriteString(mvInfo.MaterializedView.Definition)
+	if _, err := execMaterializedViewSQL(ctx, se, sb.String()); err != nil {
+		return err
+	}
+	_, err := execMaterializedViewSQL(ctx, se, "DELETE FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, materializedViewLogTable, mvInfo.ID)
+	return err
+}
+
+// refreshMaterializedViewIncrementally recomputes the view rows of the logged keys. The rows are deleted from the
+// view, and the rows of the query filtered by the keys are inserted again.
+func refreshMaterializedViewIncrementally(ctx context.Context, se sessionctx.Context, schema model.CIStr, mvInfo *model.TableInfo, tblInfos []*model.TableInfo) error {
+	info := mvInfo.MaterializedView
+	rows, err := execMaterializedViewSQL(ctx, se, "SELECT base_idx, row_key FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, materializedViewLogTable, mvInfo.ID)
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+	seen := make(map[string]struct{}, len(rows))
+	conds := make([]string, 0, len(rows))
+	for _, row := range rows {
+		baseIdx, key := int(row.GetInt64(0)), row.GetBytes(1)
+		if baseIdx >= len(info.BaseTables) {
+			continue
+		}
+		dedupKey := fmt.Sprintf("%d:%s", baseIdx, key)
+		if _, ok := seen[dedupKey]; ok {
+			continue
+		}
+		seen[dedupKey] = struct{}{}
+		cond, err := materializedViewKeyCondition(se, info.BaseTables[baseIdx], tblInfos[baseIdx], key)
+		if err != nil {
+			return err
+		}
+		conds = append(conds, cond)
+	}
+
+	var sb strings.Builder
+	for len(conds) > 0 {
+		n := mathutil.Min(len(conds), materializedViewRefreshBatchSize)
+		cond := strings.Join(conds[:n], " OR ")
+		conds = conds[n:]
+
+		sb.Reset()
+		sqlexec.MustFormatSQL(&sb, "DELETE FROM %n.%n WHERE ", schema.O, mvInfo.Name.O)
+		sb.WriteString(cond)
+		if _, err := execMaterializedViewSQL(ctx, se, sb.String()); err != nil {
+			return err
+		}
+
+		sb.Reset()
+		sqlexec.MustFormatSQL(&sb, "INSERT INTO %n.%n WITH `src` (", schema.O, mvInfo.Name.O)
+		for i, col := range mvInfo.Columns {
+			if i > 0 {
+				sb.WriteString(", ")
+			}
+			sqlexec.MustFormatSQL(&sb, "%n", col.Name.O)
+		}
+		sb.WriteString(") AS (")
+		sb.WriteString(info.Definition)
+		sb.WriteString(") SELECT * FROM `src` WHERE ")
+		sb.WriteString(cond)
+		if _, err := execMaterializedViewSQL(ctx, se, sb.String()); err != nil {
+			return err
+		}
+	}
+	_, err = execMaterializedViewSQL(ctx, se, "DELETE FROM %n.%n WHERE mview_id = %?", mysql.SystemDB, materializedViewLogTable, mvInfo.ID)
+	return err
+}
+
+// materializedViewKeyCondition decodes a logged key and builds the condition matching the view rows of the key.
+func materializedViewKeyCondition(se sessionctx.Context, base *model.MaterializedViewBaseTable, tblInfo *model.TableInfo, key []byte) (string, error) {
+	datums, err := codec.Decode(key, len(base.KeyColumns))
+	if err != nil {
+		return "", err
+	}
+	if len(datums) != len(base.KeyColumns) {
+		return "", errors.Errorf("invalid key of the table %s in the materialized view log", tblInfo.Name.O)
+	}
+	var sb strings.Builder
+	sb.WriteString("(")
+	for i, keyCol := range base.KeyColumns {
+		col := model.FindColumnInfo(tblInfo.Columns, keyCol.L)
+		d, err := tablecodec.Unflatten(datums[i], &col.FieldType, se.GetSessionVars().Location())
+		if err != nil {
+			return "", err
+		}
+		arg, err := materializedViewSQLArg(d)
+		if err != nil {
+			return "", err
+		}
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sqlexec.MustFormatSQL(&sb, "%n <=> %?", base.ViewColumns[i].O, arg)
+	}
+	sb.WriteString(")")
+	return sb.String(), nil
+}
+
+// materializedViewSQLArg converts a key value to the argument of sqlexec.EscapeSQL.
+func materializedViewSQLArg(d types.Datum) (interface{}, error) {
+	switch d.Kind() {
+	case types.KindNull:
+		return nil, nil
+	case types.KindInt64:
+		return d.GetInt64(), nil
+	case types.KindUint64:
+		return d.GetUint64(), nil
+	case types.KindBytes:
+		return d.GetBytes(), nil
+	case types.KindString:
+		if d.Collation() == charset.CollationBin {
+			return d.GetBytes(), nil
+		}
+		return d.GetString(), nil
+	}
+	return d.ToString()
+}
+
+// execMaterializedViewSQL executes a SQL in the system session and returns the rows.
+func execMaterializedViewSQL(ctx context.Context, se sessionctx.Context, sql string, args ...interface{}) ([]chunk.Row, error) {
+	rs, err := se.(sqlexec.SQLExecutor).ExecuteInternal(ctx, sql, args...)
+	if rs == nil || err != nil {
+		return nil, err
+	}
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, se.GetSessionVars().MaxChunkSize)
+}
+
+// fetchShowCreateTable4MaterializedView builds the CREATE MATERIALIZED VIEW statement of a materialized view.
+func fetchShowCreateTable4MaterializedView(ctx sessionctx.Context, tb *model.TableInfo, buf *bytes.Buffer) {
+	sqlMode := ctx.GetSessionVars().SQLMode
+	fmt.Fprintf(buf, "CREATE MATERIALIZED VIEW %s (", stringutil.Escape(tb.Name.O, sqlMode))
+	for i, col := range tb.Columns {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		buf.WriteString(stringutil.Escape(col.Name.O, sqlMode))
+	}
+	fmt.Fprintf(buf, ") AS %s", tb.MaterializedView.Definition)
+}
@@ -16,12 +16,15 @@ package executor
 
 import (
 	"context"
+	"encoding/binary"
+	"hash/crc32"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/executor/internal/exec"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
+	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
@@ -196,22 +199,36 @@ func (s *tableRegionSampler) splitTableRanges() ([]kv.KeyRange, error) {
 }
 
 func splitIntoMultiRanges(store kv.Storage, startKey, endKey kv.Key) ([]kv.KeyRange, error) {
+	ranges, _, err := splitRangeByRegions(store, startKey, endKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(ranges) == 0 {
+		return nil, errors.Trace(errors.Errorf("no regions found"))
+	}
+	return ranges, nil
+}
+
+// splitRangeByRegions splits the key range by the regions, and returns the start keys of the regions as well.
+func splitRangeByRegions(store kv.Storage, startKey, endKey kv.Key) ([]kv.KeyRange, []kv.Key, error) {
 	kvRange := kv.KeyRange{StartKey: startKey, EndKey: endKey}
 
 	s, ok := store.(tikv.Storage)
 	if !ok {
-		return []kv.KeyRange{kvRange}, nil
+		return []kv.KeyRange{kvRange}, []kv.Key{startKey}, nil
 	}
 
 	maxSleep := 10000 // ms
 	bo := tikv.NewBackofferWithVars(context.Background(), maxSleep, nil)
 	regions, err := s.GetRegionCache().LoadRegionsInKeyRange(bo, startKey, endKey)
 	if err != nil {
-		return nil, errors.Trace(err)
+		return nil, nil, errors.Trace(err)
 	}
 	var ranges = make([]kv.KeyRange, 0, len(regions))
+	var regionStartKeys = make([]kv.Key, 0, len(regions))
 	for _, r := range regions {
 		start, end := r.StartKey(), r.EndKey()
+		regionStartKeys = append(regionStartKeys, start)
 		if kv.Key(start).Cmp(startKey) < 0 {
 			start = startKey
 		}
@@ -220,10 +237,67 @@ func splitIntoMultiRanges(store kv.Storage, startKey, endKey kv.Key) ([]kv.KeyRa
 		}
 		ranges = append(ranges, kv.KeyRange{StartKey: start, EndKey: end})
 	}
-	if len(ranges) == 0 {
-		return nil, errors.Trace(errors.Errorf("no regions found"))
+	return ranges, regionStartKeys, nil
+}
+
+// tableSystemSampler implements the SYSTEM sampling method for the TableReaderExecutor. The regions are
+// the sampling blocks, and a region is sampled if the crc32 hash of the seed and its start key is below the
+// threshold of the sample percentage. So the same regions are sampled for the same seed as long as the regions
+// are not changed. Only the key ranges in the sampled regions are sent to the coprocessor.
+type tableSystemSampler struct {
+	store     kv.Storage
+	seed      int64
+	threshold uint64
+}
+
+func newTableSystemSampler(store kv.Storage, sampleInfo *plannercore.TableSampleInfo) *tableSystemSampler {
+	return &tableSystemSampler{
+		store:     store,
+		seed:      sampleInfo.Seed,
+		threshold: sampleInfo.HashThreshold(),
 	}
-	return ranges, nil
+}
+
+func (s *tableSystemSampler) isSampled(regionStartKey kv.Key) bool {
+	var seed [8]byte
+	binary.BigEndian.PutUint64(seed[:], uint64(s.seed))
+	hash := crc32.Update(crc32.ChecksumIEEE(seed[:]), crc32.IEEETable, regionStartKey)
+	return uint64(hash) < s.threshold
+}
+
+func (s *tableSystemSampler) sampleRanges(ranges []kv.KeyRange) ([]kv.KeyRange, error) {
+	sampled := make([]kv.KeyRange, 0, len(ranges))
+	for _, r := range ranges {
+		regionRanges, regionStartKeys, err := splitRangeByRegions(s.store, r.StartKey, r.EndKey)
+		if err != nil {
+			return nil, err
+		}
+		for i, regionRange := range regionRanges {
+			if s.isSampled(regionStartKeys[i]) {
+				sampled = append(sampled, regionRange)
+			}
+		}
+	}
+	return sampled, nil
+}
+
+func (s *tableSystemSampler) sampleKeyRanges(keyRanges *kv.KeyRanges) (*kv.KeyRanges, error) {
+	sampled := make([][]kv.KeyRange, 0, keyRanges.PartitionNum())
+	err := keyRanges.ForEachPartitionWithErr(func(ranges []kv.KeyRange, _ []int) error {
+		sampledRanges, err := s.sampleRanges(ranges)
+		if err != nil {
+			return err
+		}
+		sampled = append(sampled, sampledRanges)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sampled) == 1 {
+		return kv.NewNonParitionedKeyRanges(sampled[0]), nil
+	}
+	return kv.NewPartitionedKeyRanges(sampled), nil
 }
 
 func sortRanges(ranges []kv.KeyRange, isDesc bool) {
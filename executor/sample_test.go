@@ -16,6 +16,8 @@ package executor_test
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync/atomic"
 	"testing"
 
@@ -159,7 +161,10 @@ func TestTableSampleInvalid(t *testing.T) {
 	tk.MustGetErrCode("select * from information_schema.tables tablesample regions();", errno.ErrInvalidTableSample)
 
 	tk.MustGetErrCode("select a from t tablesample system();", errno.ErrInvalidTableSample)
-	tk.MustGetErrCode("select a from t tablesample bernoulli(10 percent);", errno.ErrInvalidTableSample)
+	tk.MustGetErrCode("select a from t tablesample bernoulli(10 rows);", errno.ErrInvalidTableSample)
+	tk.MustGetErrCode("select a from t tablesample bernoulli(101);", errno.ErrInvalidTableSample)
+	tk.MustGetErrCode("select a from t tablesample system(-1);", errno.ErrInvalidTableSample)
+	tk.MustGetErrCode("select a from t tablesample (10);", errno.ErrInvalidTableSample)
 	tk.MustGetErrCode("select a from t as t1 tablesample regions(), t as t2 tablesample system();", errno.ErrInvalidTableSample)
 	tk.MustGetErrCode("select a from t tablesample ();", errno.ErrInvalidTableSample)
 }
@@ -269,6 +274,97 @@ func TestTableSampleNotSupportedPlanWarning(t *testing.T) {
 	tk.MustQuery("show warnings;").Check(testkit.Rows("Warning 8128 Invalid TABLESAMPLE: plan not supported"))
 }
 
+func insertSampleTestRows(tk *testkit.TestKit, table string, count int) {
+	values := make([]string, 0, count)
+	for i := 0; i < count; i++ {
+		values = append(values, fmt.Sprintf("(%d)", i))
+	}
+	tk.MustExec(fmt.Sprintf("insert into %s(a) values %s;", table, strings.Join(values, ", ")))
+}
+
+func TestTableSampleBernoulli(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := createSampleTestkit(t, store)
+	tk.MustExec("create table t (a int primary key, b int);")
+	tk.MustExec("create table t1 (a int, b int);")
+	tk.MustExec("create table t2 (a varchar(10) primary key clustered, b int);")
+	for _, tbl := range []string{"t", "t1", "t2"} {
+		insertSampleTestRows(tk, tbl, 1000)
+		tk.MustQuery(fmt.Sprintf("select count(*) from %s tablesample bernoulli(0);", tbl)).Check(testkit.Rows("0"))
+		tk.MustQuery(fmt.Sprintf("select count(*) from %s tablesample bernoulli(100);", tbl)).Check(testkit.Rows("1000"))
+
+		query := fmt.Sprintf("select a from %s tablesample bernoulli(10) repeatable(42) order by a;", tbl)
+		rows := tk.MustQuery(query).Rows()
+		require.Greater(t, len(rows), 50)
+		require.Less(t, len(rows), 150)
+		// The sample is deterministic for the same seed.
+		tk.MustQuery(query).Check(rows)
+		otherRows := tk.MustQuery(fmt.Sprintf("select a from %s tablesample bernoulli(10) repeatable(43) order by a;", tbl)).Rows()
+		require.NotEqual(t, rows, otherRows)
+		// The sample of a bigger percentage contains the sample of a smaller one.
+		tk.MustQuery(fmt.Sprintf("select count(*) from (%s) s where a not in (select a from %s tablesample bernoulli(20) repeatable(42));",
+			strings.TrimSuffix(query, ";"), tbl)).Check(testkit.Rows("0"))
+	}
+
+	// The sampling is pushed down to the coprocessor.
+	rows := tk.MustQuery("explain format = 'brief' select * from t tablesample bernoulli(10) repeatable(42) where b > 1;").Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, "cop[tikv]", rows[1][2])
+	require.Contains(t, rows[1][4], "lt(crc32(concat_ws(")
+	require.Contains(t, rows[1][4], "429496729)")
+	require.Contains(t, rows[1][4], "gt(test_table_sample.t.b, 1)")
+
+	// The rows in the transaction are sampled in the same way.
+	query := "select a from t tablesample bernoulli(10) repeatable(42) order by a;"
+	rows = tk.MustQuery(query).Rows()
+	tk.MustExec("begin;")
+	tk.MustExec("update t set b = a;")
+	tk.MustQuery(query).Check(rows)
+	tk.MustExec("rollback;")
+}
+
+func TestTableSampleSystem(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := createSampleTestkit(t, store)
+	tk.MustExec("create table t (a int primary key, b int, key(b));")
+	tk.MustQuery("split table t between (0) and (1000) regions 10;").Check(testkit.Rows("9 1"))
+	insertSampleTestRows(tk, "t", 1000)
+	tk.MustQuery("select count(*) from t tablesample system(0);").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t tablesample system(100);").Check(testkit.Rows("1000"))
+
+	// The regions are sampled, so each region is either fully sampled or not.
+	query := "select a from t tablesample system(50) repeatable(7) order by a;"
+	rows := tk.MustQuery(query).Rows()
+	require.Zero(t, len(rows)%100)
+	regions := make(map[int]struct{})
+	below := 0
+	for _, row := range rows {
+		a, err := strconv.Atoi(row[0].(string))
+		require.NoError(t, err)
+		regions[a/100] = struct{}{}
+		if a < 500 {
+			below++
+		}
+	}
+	require.Len(t, regions, len(rows)/100)
+	// The sample is deterministic for the same seed.
+	tk.MustQuery(query).Check(rows)
+	tk.MustQuery("select count(*) from t tablesample system(50) repeatable(7) where a < 500;").Check(
+		testkit.Rows(strconv.Itoa(below)))
+
+	// The sampled regions are read by the coprocessor, and no index or point get is used.
+	rows = tk.MustQuery("explain format = 'brief' select b from t tablesample system(50) repeatable(7) where b = 1;").Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, "cop[tikv]", rows[2][2])
+	require.Contains(t, rows[2][4], "sample:system(50), seed:7")
+	require.False(t, tk.HasPlan("select * from t tablesample system(100) where a = 1;", "Point_Get"))
+	tk.MustQuery("select * from t tablesample system(100) where a = 1;").Check(testkit.Rows("1 <nil>"))
+
+	tk.MustExec("create global temporary table tmp (a int primary key) on commit delete rows;")
+	tk.MustGetErrCode("select * from tmp tablesample system(10);", errno.ErrInvalidTableSample)
+	tk.MustQuery("select * from tmp tablesample bernoulli(10);").Check(testkit.Rows())
+}
+
 func TestMaxChunkSize(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := createSampleTestkit(t, store)
@@ -96,6 +96,7 @@ type ShowExec struct {
 	Partition         model.CIStr          // Used for showing partition
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         model.CIStr          // Used for show table regions.
+	Procedure         *ast.TableName       // Used for showing create procedure.
 	ResourceGroupName model.CIStr          // Used for showing resource group
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	Roles             []*auth.RoleIdentity // Used for show grants.
@@ -190,6 +191,8 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateUser(ctx)
 	case ast.ShowCreateView:
 		return e.fetchShowCreateView()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure()
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
 	case ast.ShowCreatePlacementPolicy:
@@ -229,7 +232,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -978,6 +981,10 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		ConstructResultOfShowCreateSequence(ctx, tableInfo, buf)
 		return nil
 	}
+	if tableInfo.IsMaterializedView() {
+		fetchShowCreateTable4MaterializedView(ctx, tableInfo, buf)
+		return nil
+	}
 
 	tblCharset := tableInfo.Charset
 	if len(tblCharset) == 0 {
@@ -1142,6 +1149,11 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.Tp == model.IndexTypeRtree && tableInfo.Columns[idxInfo.Columns[0].Offset].GetType() == mysql.TypeGeometry {
+			// Only a SPATIAL index can be built on a geometry column.
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsFullText() {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1149,6 +1161,13 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		cols := make([]string, 0, len(idxInfo.Columns))
 		var colInfo string
 		for _, c := range idxInfo.Columns {
+			if idxInfo.IsFullText() {
+				// The hidden column of a FULLTEXT index is shown as the text columns it concatenates.
+				for _, ftCol := range idxInfo.FullTextColumns {
+					cols = append(cols, stringutil.Escape(ftCol.O, sqlMode))
+				}
+				break
+			}
 			if tableInfo.Columns[c.Offset].Hidden {
 				colInfo = fmt.Sprintf("(%s)", tableInfo.Columns[c.Offset].GeneratedExprString)
 			} else {
@@ -1160,6 +1179,9 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.FullTextParser != "" {
+			fmt.Fprintf(buf, " /*!50100 WITH PARSER %s */", stringutil.Escape(idxInfo.FullTextParser, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1291,7 +1313,11 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 	}
 
 	if tableInfo.TempTableType == model.TempTableGlobal {
-		fmt.Fprintf(buf, " ON COMMIT DELETE ROWS")
+		if tableInfo.OnCommitPreserveRows {
+			fmt.Fprintf(buf, " ON COMMIT PRESERVE ROWS")
+		} else {
+			fmt.Fprintf(buf, " ON COMMIT DELETE ROWS")
+		}
 	}
 
 	if tableInfo.PlacementPolicyRef != nil {
@@ -1832,11 +1858,167 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
+func (e *ShowExec) fetchShowTriggers() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	if !e.is.SchemaExists(e.DBName) {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	var (
+		fieldPatternsLike collate.WildcardPattern
+		fieldFilter       string
+	)
+	if e.Extractor != nil {
+		fieldFilter = e.Extractor.Field()
+		fieldPatternsLike = e.Extractor.FieldPatternLike()
+	}
+	activeRoles := e.Ctx().GetSessionVars().ActiveRoles
+	tables := e.is.SchemaTables(e.DBName)
+	slices.SortFunc(tables, func(i, j table.Table) bool {
+		return i.Meta().Name.L < j.Meta().Name.L
+	})
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		} else if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, tblInfo.Name.O, "", mysql.TriggerPriv) {
+			continue
+		} else if fieldFilter != "" && tblInfo.Name.L != fieldFilter {
+			continue
+		} else if fieldPatternsLike != nil && !fieldPatternsLike.DoMatch(tblInfo.Name.L) {
+			continue
+		}
+		for _, trigger := range tblInfo.Triggers {
+			if trigger.State != model.StatePublic {
+				continue
+			}
+			e.appendRow([]interface{}{
+				trigger.Name.O,
+				trigger.Event.String(),
+				tblInfo.Name.O,
+				trigger.Statement,
+				trigger.Timing.String(),
+				types.NewTime(types.FromGoTime(trigger.Created.In(e.Ctx().GetSessionVars().Location())), mysql.TypeDatetime, 2),
+				trigger.SQLMode,
+				trigger.Definer.String(),
+				trigger.CharacterSetClient,
+				trigger.CollationConnection,
+				trigger.DBCollation,
+			})
+		}
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && e.Ctx().GetSessionVars().User != nil {
+		if !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.O) {
+			return e.dbAccessDenied()
+		}
+	}
+	if !e.is.SchemaExists(e.DBName) {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	scheduler := domain.GetDomain(e.Ctx()).EventScheduler()
+	if scheduler == nil {
+		return nil
+	}
+	if checker != nil && !checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, e.DBName.L, "", "", mysql.EventPriv) {
+		return nil
+	}
+	var (
+		fieldPatternsLike collate.WildcardPattern
+		fieldFilter       string
+	)
+	if e.Extractor != nil {
+		fieldFilter = e.Extractor.Field()
+		fieldPatternsLike = e.Extractor.FieldPatternLike()
+	}
+	events, err := scheduler.ListEvents(ctx, e.DBName.O)
+	if err != nil {
+		return err
+	}
+	loc := e.Ctx().GetSessionVars().Location()
+	for _, ev := range events {
+		name := strings.ToLower(ev.Name)
+		if fieldFilter != "" && name != fieldFilter {
+			continue
+		} else if fieldPatternsLike != nil && !fieldPatternsLike.DoMatch(name) {
+			continue
+		}
+		// The columns are a subset of the columns of information_schema.EVENTS.
+		row := eventRow(ev, loc)
+		e.result.AppendRow(chunk.MutRowFromDatums([]types.Datum{
+			row[1],  // Db
+			row[2],  // Name
+			row[4],  // Time zone
+			row[3],  // Definer
+			row[7],  // Type
+			row[8],  // Execute At
+			row[9],  // Interval Value
+			row[10], // Interval Field
+			row[12], // Starts
+			row[13], // Ends
+			row[14], // Status
+			row[20], // Originator
+			row[21], // character_set_client
+			row[22], // collation_connection
+			row[23], // Database Collation
+		}).ToRow())
+	}
+	return nil
+}
+
+func (e *ShowExec) fetchShowProcedureStatus() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	dbs := e.is.AllSchemaNames()
+	slices.Sort(dbs)
+	for _, db := range dbs {
+		if checker != nil && !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, db) {
+			continue
+		}
+		for _, proc := range e.is.SchemaProcedures(model.NewCIStr(db)) {
+			created := types.NewTime(types.FromGoTime(proc.Created.In(e.Ctx().GetSessionVars().Location())), mysql.TypeDatetime, 0)
+			e.appendRow([]interface{}{
+				db,
+				proc.Name.O,
+				"PROCEDURE",
+				proc.Definer.String(),
+				created,
+				created,
+				"DEFINER",
+				"",
+				proc.CharacterSetClient,
+				proc.CollationConnection,
+				proc.DBCollation,
+			})
+		}
+	}
 	return nil
 }
 
-func (*ShowExec) fetchShowProcedureStatus() error {
+func (e *ShowExec) fetchShowCreateProcedure() error {
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if checker != nil && !checker.DBIsVisible(e.Ctx().GetSessionVars().ActiveRoles, e.Procedure.Schema.O) {
+		return infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", e.Procedure.Schema.O+"."+e.Procedure.Name.O)
+	}
+	proc, ok := e.is.ProcedureByName(e.Procedure.Schema, e.Procedure.Name)
+	if !ok {
+		return infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", e.Procedure.Schema.O+"."+e.Procedure.Name.O)
+	}
+	e.appendRow([]interface{}{
+		proc.Name.O,
+		proc.SQLMode,
+		proc.Definition,
+		proc.CharacterSetClient,
+		proc.CollationConnection,
+		proc.DBCollation,
+	})
 	return nil
 }
 
@@ -88,7 +88,7 @@ func (e *ShowExec) appendTableForStatsExtended(dbName string, tbl *model.TableIn
 			statsVal = item.StringVals
 		case ast.StatsTypeCardinality:
 			statsType = "cardinality"
-			statsVal = item.StringVals
+			statsVal = fmt.Sprintf("%f", item.ScalarVals)
 		}
 		e.appendRow([]interface{}{
 			dbName,
@@ -190,6 +190,8 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeAdmin(x)
 	case *ast.SetResourceGroupStmt:
 		err = e.executeSetResourceGroupName(x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2706,6 +2708,10 @@ func (e *SimpleExec) autoNewTxn() bool {
 	// Administrative statements. TODO: ANALYZE TABLE, CACHE INDEX, CHECK TABLE, FLUSH, LOAD INDEX INTO CACHE, OPTIMIZE TABLE, REPAIR TABLE, RESET (but not RESET PERSIST).
 	case *ast.FlushStmt:
 		return true
+	// The refresh of a materialized view runs in its own transaction, the changes of the current transaction are
+	// committed first so that they're visible to the refresh.
+	case *ast.RefreshMaterializedViewStmt:
+		return true
 	}
 	return false
 }
@@ -2798,6 +2804,9 @@ func (e *SimpleExec) executeAdminFlushPlanCache(s *ast.AdminStmt) error {
 		// Record the timestamp. When other sessions want to use the plan cache,
 		// it will check the timestamp first to decide whether the plan cache should be flushed.
 		domain.GetDomain(e.Ctx()).SetExpiredTimeStamp4PC(now)
+		if instanceCache := domain.GetDomain(e.Ctx()).InstancePlanCache(); instanceCache != nil {
+			instanceCache.DeleteAll()
+		}
 	}
 	return nil
 }
@@ -124,6 +124,8 @@ type TableReaderExecutor struct {
 	virtualColumnRetFieldTypes []*types.FieldType
 	// batchCop indicates whether use super batch coprocessor request, only works for TiFlash engine.
 	batchCop bool
+	// systemSampler samples the regions to read for the TABLESAMPLE SYSTEM clause.
+	systemSampler *tableSystemSampler
 
 	// If dummy flag is set, this is not a real TableReader, it just provides the KV ranges for UnionScan.
 	// Used by the temporary table, cached table.
@@ -359,6 +361,13 @@ func (e *TableReaderExecutor) buildKVReqSeparately(ctx context.Context, ranges [
 	if err != nil {
 		return nil, err
 	}
+	if e.systemSampler != nil {
+		for i := range kvRanges {
+			if kvRanges[i], err = e.systemSampler.sampleRanges(kvRanges[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
 	kvReqs := make([]*kv.Request, 0, len(kvRanges))
 	for i, kvRange := range kvRanges {
 		e.kvRanges = append(e.kvRanges, kvRange...)
@@ -474,7 +483,14 @@ func (e *TableReaderExecutor) buildKVReq(ctx context.Context, ranges []*ranger.R
 		SetClosestReplicaReadAdjuster(newClosestReadAdjuster(e.Ctx(), &reqBuilder.Request, e.netDataSize)).
 		SetPaging(e.paging).
 		SetConnID(e.Ctx().GetSessionVars().ConnectionID)
-	return reqBuilder.Build()
+	kvReq, err := reqBuilder.Build()
+	if err != nil {
+		return nil, err
+	}
+	if e.systemSampler != nil {
+		kvReq.KeyRanges, err = e.systemSampler.sampleKeyRanges(kvReq.KeyRanges)
+	}
+	return kvReq, err
 }
 
 func buildVirtualColumnIndex(schema *expression.Schema, columns []*model.ColumnInfo) []int {
@@ -773,6 +773,60 @@ func TestAdminCleanupIndexForPartitionTable(t *testing.T) {
 	}
 }
 
+func TestAlterTableCheckAndRepairPartition(t *testing.T) {
+	store, domain := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists admin_test")
+	tk.MustExec(`create table admin_test (c1 int, c2 int, c3 int, primary key (c2), index c3(c3)) partition by range (c2) (
+		partition p0 values less than (5),
+		partition p1 values less than (10),
+		partition p2 values less than (maxvalue))`)
+	tk.MustExec("insert admin_test (c1, c2, c3) values (0, 0, 0), (1, 1, 1), (6, 6, 6), (11, 11, 11), (12, 12, 12)")
+	tk.MustExec("alter table admin_test check partition all")
+	tk.MustExec("alter table admin_test check partition p0, p2")
+	tk.MustGetErrCode("alter table admin_test check partition p3", mysql.ErrUnknownPartition)
+	tk.MustExec("create table admin_test_np (a int, index(a))")
+	tk.MustGetErrCode("alter table admin_test_np check partition all", mysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table admin_test_np repair partition all", mysql.ErrPartitionMgmtOnNonpartitioned)
+
+	tbl, err := domain.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("admin_test"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	pi := tblInfo.GetPartitionInfo()
+	idxInfo := tblInfo.FindIndexByName("c3")
+	ctx := mock.NewContext()
+	sc := ctx.GetSessionVars().StmtCtx
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	// Add a dangling index entry to p1 and remove the index entry of a row in p2.
+	_, err = tables.NewIndex(pi.Definitions[1].ID, tblInfo, idxInfo).Create(ctx, txn, types.MakeDatums(7), kv.IntHandle(7), nil)
+	require.NoError(t, err)
+	err = tables.NewIndex(pi.Definitions[2].ID, tblInfo, idxInfo).Delete(sc, txn, types.MakeDatums(12), kv.IntHandle(12))
+	require.NoError(t, err)
+	require.NoError(t, txn.Commit(context.Background()))
+
+	tk.MustExec("alter table admin_test check partition p0")
+	require.Error(t, tk.ExecToErr("alter table admin_test check partition p1"))
+	require.Error(t, tk.ExecToErr("alter table admin_test check partition p2"))
+	require.Error(t, tk.ExecToErr("alter table admin_test check partition all"))
+	tk.MustQuery("select count(*) from admin_test partition(p1) use index(c3)").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from admin_test partition(p2) use index(c3)").Check(testkit.Rows("1"))
+
+	tk.MustExec("alter table admin_test repair partition p0")
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	require.Error(t, tk.ExecToErr("alter table admin_test check partition all"))
+	tk.MustExec("alter table admin_test repair partition all")
+	tk.MustQuery("show warnings").Check(testkit.Rows(
+		"Warning 1105 Partition p1, index c3: removed 1 dangling and added 0 missing index entries",
+		"Warning 1105 Partition p2, index c3: removed 0 dangling and added 1 missing index entries"))
+	tk.MustExec("alter table admin_test check partition all")
+	tk.MustExec("admin check table admin_test")
+	tk.MustQuery("select count(*) from admin_test partition(p1) use index(c3)").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from admin_test partition(p2) use index(c3)").Check(testkit.Rows("2"))
+}
+
 func TestAdminCleanupIndexPKNotHandle(t *testing.T) {
 	store, domain := testkit.CreateMockStoreAndDomain(t)
 
@@ -798,7 +798,7 @@ func TestAutoIDConstraint(t *testing.T) {
 		}
 	}
 
-	// alter table add auto id column is not supported, but cover it here to prevent regression
+	// alter table add auto id column without a key is not supported, but cover it here to prevent regression
 	tk.MustExec("create table tt1 (id int)")
 	tk.MustExecToErr("alter table tt1 add column (c int auto_increment)")
 
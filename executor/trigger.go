@@ -0,0 +1,155 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/planner"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"golang.org/x/exp/slices"
+)
+
+// TriggerExecutor executes the body of a trigger, it's implemented by the session.
+type TriggerExecutor interface {
+	// ExecuteTrigger executes the trigger for a row of the table. The oldRow is nil for the INSERT
+	// triggers and the newRow is nil for the DELETE triggers, the BEFORE triggers may change the newRow.
+	ExecuteTrigger(ctx context.Context, tbl table.Table, trigger *model.TriggerInfo, oldRow, newRow []types.Datum) error
+}
+
+// fireTriggers fires the triggers of the table with the action time and event in order. It's called for
+// every changed row, and the statements of the triggers run in the same transaction and statement
+// context as the statement that fires them.
+func fireTriggers(ctx context.Context, sctx sessionctx.Context, tbl table.Table, timing model.TriggerTiming,
+	event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	sc := sctx.GetSessionVars().StmtCtx
+	tblInfo := tbl.Meta()
+	if slices.Contains(sc.TriggerTableIDs, tblInfo.ID) {
+		// A trigger can't change the table that is used by the statement which fires it.
+		return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tblInfo.Name.O)
+	}
+	if len(tblInfo.Triggers) == 0 {
+		return nil
+	}
+	te, ok := sctx.(TriggerExecutor)
+	if !ok {
+		return nil
+	}
+	for _, trigger := range tblInfo.Triggers {
+		if trigger.State != model.StatePublic || trigger.Timing != timing || trigger.Event != event {
+			continue
+		}
+		sc.TriggerTableIDs = append(sc.TriggerTableIDs, tblInfo.ID)
+		err := te.ExecuteTrigger(ctx, tbl, trigger, oldRow, newRow)
+		sc.TriggerTableIDs = sc.TriggerTableIDs[:len(sc.TriggerTableIDs)-1]
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ExecTriggerStmt executes a statement in the body of a trigger. Unlike ExecuteStmt of the session, the
+// statement context and the transaction context are not reset, which is the same as the foreign key
+// cascades. It returns a record set if the statement returns rows.
+func ExecTriggerStmt(ctx context.Context, sctx sessionctx.Context, stmt ast.StmtNode) (sqlexec.RecordSet, error) {
+	switch stmt.(type) {
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.SavepointStmt, *ast.ReleaseSavepointStmt:
+		return nil, exeerrors.ErrCommitNotAllowedInSfOrTrg
+	}
+	// The point plan and the hints of the statement that fires the trigger can't be used by this one.
+	if v := sctx.Value(plannercore.PointPlanKey); v != nil {
+		sctx.ClearValue(plannercore.PointPlanKey)
+		defer sctx.SetValue(plannercore.PointPlanKey, v)
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	originHints := sc.StmtHints
+	defer func() {
+		sc.StmtHints = originHints
+	}()
+
+	if err := plannercore.Preprocess(ctx, sctx, stmt); err != nil {
+		return nil, err
+	}
+	is := sessiontxn.GetTxnManager(sctx).GetTxnInfoSchema()
+	p, names, err := planner.Optimize(ctx, sctx, stmt, is)
+	if err != nil {
+		return nil, err
+	}
+	b := newExecutorBuilder(sctx, is, nil)
+	e := b.build(p)
+	if b.err != nil {
+		return nil, b.err
+	}
+	if err = e.Open(ctx); err != nil {
+		terror.Call(e.Close)
+		return nil, err
+	}
+	if e.Schema().Len() > 0 {
+		return &triggerRecordSet{
+			fields:   colNames2ResultFields(e.Schema(), names, sctx.GetSessionVars().CurrentDB),
+			executor: e,
+		}, nil
+	}
+	if err = Next(ctx, e, newFirstChunk(e)); err != nil {
+		terror.Call(e.Close)
+		return nil, err
+	}
+	if err = e.Close(); err != nil {
+		return nil, err
+	}
+	return nil, (&ExecStmt{Ctx: sctx}).handleForeignKeyTrigger(ctx, e, 1)
+}
+
+// triggerRecordSet is the result of a statement in the body of a trigger.
+type triggerRecordSet struct {
+	fields   []*ast.ResultField
+	executor exec.Executor
+}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (rs *triggerRecordSet) Fields() []*ast.ResultField {
+	return rs.fields
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (rs *triggerRecordSet) Next(ctx context.Context, req *chunk.Chunk) error {
+	return Next(ctx, rs.executor, req)
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (rs *triggerRecordSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	if alloc == nil {
+		return newFirstChunk(rs.executor)
+	}
+	base := rs.executor.Base()
+	return alloc.Alloc(base.RetFieldTypes(), base.InitCap(), base.MaxChunkSize())
+}
+
+// Close implements the sqlexec.RecordSet interface.
+func (rs *triggerRecordSet) Close() error {
+	return rs.executor.Close()
+}
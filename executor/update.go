@@ -195,6 +195,14 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		newTableData := newData[content.Start:content.End]
 		flags := bAssignFlag[content.Start:content.End]
 
+		// The BEFORE UPDATE triggers may change the new row, note that the generated columns are
+		// not evaluated again for the changes.
+		numCols := len(tbl.Cols())
+		err := fireTriggers(ctx, e.Ctx(), tbl, model.TriggerTimingBefore, model.TriggerEventUpdate, oldData[:numCols], newTableData[:numCols])
+		if err != nil {
+			return err
+		}
+
 		// Update row
 		fkChecks := e.fkChecks[content.TblID]
 		fkCascades := e.fkCascades[content.TblID]
@@ -206,11 +214,21 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 				memDelta += int64(handle.ExtraMemSize())
 			}
 			e.memTracker.Consume(memDelta)
+			if changed {
+				err = logMaterializedViewChanges(e.Ctx(), tbl, oldData[:numCols], newTableData[:numCols])
+				if err != nil {
+					return err
+				}
+			}
+			err = fireTriggers(ctx, e.Ctx(), tbl, model.TriggerTimingAfter, model.TriggerEventUpdate, oldData[:numCols], newTableData[:numCols])
+			if err != nil {
+				return err
+			}
 			continue
 		}
 
 		sc := e.Ctx().GetSessionVars().StmtCtx
-		if (kv.ErrKeyExists.Equal(err1) || table.ErrCheckConstraintViolated.Equal(err1)) && sc.DupKeyAsWarning {
+		if (kv.ErrKeyExists.Equal(err1) || table.IsErrCheckConstraintViolated(err1)) && sc.DupKeyAsWarning {
 			sc.AppendWarning(err1)
 			continue
 		}
@@ -372,6 +372,10 @@ func (b *baseBuiltinFunc) getCtx() sessionctx.Context {
 	return b.ctx
 }
 
+func (b *baseBuiltinFunc) setCtx(ctx sessionctx.Context) {
+	b.ctx = ctx
+}
+
 func (b *baseBuiltinFunc) cloneFrom(from *baseBuiltinFunc) {
 	b.args = make([]Expression, 0, len(b.args))
 	for _, arg := range from.args {
@@ -485,6 +489,8 @@ type builtinFunc interface {
 	equal(builtinFunc) bool
 	// getCtx returns this function's context.
 	getCtx() sessionctx.Context
+	// setCtx sets this function's context.
+	setCtx(ctx sessionctx.Context)
 	// getRetTp returns the return type of the built-in function.
 	getRetTp() *types.FieldType
 	// setPbCode sets pbCode for signature.
@@ -868,33 +874,68 @@ var funcs = map[string]functionClass{
 	ast.ValidatePasswordStrength: &validatePasswordStrengthFunctionClass{baseFunctionClass{ast.ValidatePasswordStrength, 1, 1}},
 
 	// json functions
-	ast.JSONType:          &jsonTypeFunctionClass{baseFunctionClass{ast.JSONType, 1, 1}},
-	ast.JSONExtract:       &jsonExtractFunctionClass{baseFunctionClass{ast.JSONExtract, 2, -1}},
-	ast.JSONUnquote:       &jsonUnquoteFunctionClass{baseFunctionClass{ast.JSONUnquote, 1, 1}},
-	ast.JSONSet:           &jsonSetFunctionClass{baseFunctionClass{ast.JSONSet, 3, -1}},
-	ast.JSONInsert:        &jsonInsertFunctionClass{baseFunctionClass{ast.JSONInsert, 3, -1}},
-	ast.JSONReplace:       &jsonReplaceFunctionClass{baseFunctionClass{ast.JSONReplace, 3, -1}},
-	ast.JSONRemove:        &jsonRemoveFunctionClass{baseFunctionClass{ast.JSONRemove, 2, -1}},
-	ast.JSONMerge:         &jsonMergeFunctionClass{baseFunctionClass{ast.JSONMerge, 2, -1}},
-	ast.JSONObject:        &jsonObjectFunctionClass{baseFunctionClass{ast.JSONObject, 0, -1}},
-	ast.JSONArray:         &jsonArrayFunctionClass{baseFunctionClass{ast.JSONArray, 0, -1}},
-	ast.JSONMemberOf:      &jsonMemberOfFunctionClass{baseFunctionClass{ast.JSONMemberOf, 2, 2}},
-	ast.JSONContains:      &jsonContainsFunctionClass{baseFunctionClass{ast.JSONContains, 2, 3}},
-	ast.JSONOverlaps:      &jsonOverlapsFunctionClass{baseFunctionClass{ast.JSONOverlaps, 2, 2}},
-	ast.JSONContainsPath:  &jsonContainsPathFunctionClass{baseFunctionClass{ast.JSONContainsPath, 3, -1}},
-	ast.JSONValid:         &jsonValidFunctionClass{baseFunctionClass{ast.JSONValid, 1, 1}},
-	ast.JSONArrayAppend:   &jsonArrayAppendFunctionClass{baseFunctionClass{ast.JSONArrayAppend, 3, -1}},
-	ast.JSONArrayInsert:   &jsonArrayInsertFunctionClass{baseFunctionClass{ast.JSONArrayInsert, 3, -1}},
-	ast.JSONMergePatch:    &jsonMergePatchFunctionClass{baseFunctionClass{ast.JSONMergePatch, 2, -1}},
-	ast.JSONMergePreserve: &jsonMergePreserveFunctionClass{baseFunctionClass{ast.JSONMergePreserve, 2, -1}},
-	ast.JSONPretty:        &jsonPrettyFunctionClass{baseFunctionClass{ast.JSONPretty, 1, 1}},
-	ast.JSONQuote:         &jsonQuoteFunctionClass{baseFunctionClass{ast.JSONQuote, 1, 1}},
-	ast.JSONSearch:        &jsonSearchFunctionClass{baseFunctionClass{ast.JSONSearch, 3, -1}},
-	ast.JSONStorageFree:   &jsonStorageFreeFunctionClass{baseFunctionClass{ast.JSONStorageFree, 1, 1}},
-	ast.JSONStorageSize:   &jsonStorageSizeFunctionClass{baseFunctionClass{ast.JSONStorageSize, 1, 1}},
-	ast.JSONDepth:         &jsonDepthFunctionClass{baseFunctionClass{ast.JSONDepth, 1, 1}},
-	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
-	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
+	ast.JSONType:                   &jsonTypeFunctionClass{baseFunctionClass{ast.JSONType, 1, 1}},
+	ast.JSONExtract:                &jsonExtractFunctionClass{baseFunctionClass{ast.JSONExtract, 2, -1}},
+	ast.JSONUnquote:                &jsonUnquoteFunctionClass{baseFunctionClass{ast.JSONUnquote, 1, 1}},
+	ast.JSONSet:                    &jsonSetFunctionClass{baseFunctionClass{ast.JSONSet, 3, -1}},
+	ast.JSONInsert:                 &jsonInsertFunctionClass{baseFunctionClass{ast.JSONInsert, 3, -1}},
+	ast.JSONReplace:                &jsonReplaceFunctionClass{baseFunctionClass{ast.JSONReplace, 3, -1}},
+	ast.JSONRemove:                 &jsonRemoveFunctionClass{baseFunctionClass{ast.JSONRemove, 2, -1}},
+	ast.JSONMerge:                  &jsonMergeFunctionClass{baseFunctionClass{ast.JSONMerge, 2, -1}},
+	ast.JSONObject:                 &jsonObjectFunctionClass{baseFunctionClass{ast.JSONObject, 0, -1}},
+	ast.JSONArray:                  &jsonArrayFunctionClass{baseFunctionClass{ast.JSONArray, 0, -1}},
+	ast.JSONMemberOf:               &jsonMemberOfFunctionClass{baseFunctionClass{ast.JSONMemberOf, 2, 2}},
+	ast.JSONContains:               &jsonContainsFunctionClass{baseFunctionClass{ast.JSONContains, 2, 3}},
+	ast.JSONOverlaps:               &jsonOverlapsFunctionClass{baseFunctionClass{ast.JSONOverlaps, 2, 2}},
+	ast.JSONContainsPath:           &jsonContainsPathFunctionClass{baseFunctionClass{ast.JSONContainsPath, 3, -1}},
+	ast.JSONValid:                  &jsonValidFunctionClass{baseFunctionClass{ast.JSONValid, 1, 1}},
+	ast.JSONArrayAppend:            &jsonArrayAppendFunctionClass{baseFunctionClass{ast.JSONArrayAppend, 3, -1}},
+	ast.JSONArrayInsert:            &jsonArrayInsertFunctionClass{baseFunctionClass{ast.JSONArrayInsert, 3, -1}},
+	ast.JSONMergePatch:             &jsonMergePatchFunctionClass{baseFunctionClass{ast.JSONMergePatch, 2, -1}},
+	ast.JSONMergePreserve:          &jsonMergePreserveFunctionClass{baseFunctionClass{ast.JSONMergePreserve, 2, -1}},
+	ast.JSONPretty:                 &jsonPrettyFunctionClass{baseFunctionClass{ast.JSONPretty, 1, 1}},
+	ast.JSONQuote:                  &jsonQuoteFunctionClass{baseFunctionClass{ast.JSONQuote, 1, 1}},
+	ast.JSONSearch:                 &jsonSearchFunctionClass{baseFunctionClass{ast.JSONSearch, 3, -1}},
+	ast.JSONStorageFree:            &jsonStorageFreeFunctionClass{baseFunctionClass{ast.JSONStorageFree, 1, 1}},
+	ast.JSONStorageSize:            &jsonStorageSizeFunctionClass{baseFunctionClass{ast.JSONStorageSize, 1, 1}},
+	ast.JSONDepth:                  &jsonDepthFunctionClass{baseFunctionClass{ast.JSONDepth, 1, 1}},
+	ast.JSONKeys:                   &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
+	ast.JSONLength:                 &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
+	ast.JSONValue:                  &jsonValueFunctionClass{baseFunctionClass{ast.JSONValue, 6, 6}},
+	ast.JSONSchemaValid:            &jsonSchemaValidFunctionClass{baseFunctionClass{ast.JSONSchemaValid, 2, 2}},
+	ast.JSONSchemaValidationReport: &jsonSchemaValidationReportFunctionClass{baseFunctionClass{ast.JSONSchemaValidationReport, 2, 2}},
+
+	// spatial functions
+	ast.Point:              &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.LineString:         &geometryConstructorFunctionClass{baseFunctionClass{ast.LineString, 1, -1}, types.GeometryTypeLineString},
+	ast.Polygon:            &geometryConstructorFunctionClass{baseFunctionClass{ast.Polygon, 1, -1}, types.GeometryTypePolygon},
+	ast.MultiPoint:         &geometryConstructorFunctionClass{baseFunctionClass{ast.MultiPoint, 1, -1}, types.GeometryTypeMultiPoint},
+	ast.MultiLineString:    &geometryConstructorFunctionClass{baseFunctionClass{ast.MultiLineString, 1, -1}, types.GeometryTypeMultiLineString},
+	ast.MultiPolygon:       &geometryConstructorFunctionClass{baseFunctionClass{ast.MultiPolygon, 1, -1}, types.GeometryTypeMultiPolygon},
+	ast.GeometryCollection: &geometryConstructorFunctionClass{baseFunctionClass{ast.GeometryCollection, 0, -1}, types.GeometryTypeGeometryCollection},
+	ast.GeomCollection:     &geometryConstructorFunctionClass{baseFunctionClass{ast.GeomCollection, 0, -1}, types.GeometryTypeGeometryCollection},
+	ast.STAsBinary:         &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 1}},
+	ast.STAsWKB:            &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 1}},
+	ast.STAsGeoJSON:        &stAsGeoJSONFunctionClass{baseFunctionClass{ast.STAsGeoJSON, 1, 3}},
+	ast.STAsText:           &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKT:            &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STContains:         &spatialRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STDistance:         &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STDistanceSphere:   &stDistanceSphereFunctionClass{baseFunctionClass{ast.STDistanceSphere, 2, 3}},
+	ast.STGeomFromGeoJSON:  &stGeomFromGeoJSONFunctionClass{baseFunctionClass{ast.STGeomFromGeoJSON, 1, 3}},
+	ast.STGeomFromText:     &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STGeometryFromText: &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}},
+	ast.STGeomFromWKB:      &stGeomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 2}},
+	ast.STGeometryFromWKB:  &stGeomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 2}},
+	ast.STGeometryType:     &stGeometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STIntersects:       &spatialRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+	ast.STSRID:             &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 2}},
+	ast.STWithin:           &spatialRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STX:                &stXFunctionClass{baseFunctionClass{ast.STX, 1, 1}},
+	ast.STY:                &stYFunctionClass{baseFunctionClass{ast.STY, 1, 1}},
+
+	// full-text search function
+	ast.FullTextMatch: &matchAgainstFunctionClass{baseFunctionClass{ast.FullTextMatch, 4, 4}},
 
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
@@ -0,0 +1,130 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"sync"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/fulltext"
+)
+
+var (
+	_ functionClass = &matchAgainstFunctionClass{}
+
+	_ builtinFunc = &builtinMatchAgainstSig{}
+)
+
+// The arguments of the match function, which is rewritten from MATCH (col1, col2, ...) AGAINST (expr modifier).
+const (
+	// matchTextIdx is the text to search, which concatenates the columns by spaces.
+	matchTextIdx = iota
+	// matchAgainstIdx is the search string.
+	matchAgainstIdx
+	// matchModifierIdx is the ast.FulltextSearchModifier.
+	matchModifierIdx
+	// matchParserIdx is the parser of the FULLTEXT index on the columns.
+	matchParserIdx
+)
+
+type matchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *matchAgainstFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinMatchAgainstSig{baseBuiltinFunc: bf}
+	return sig, nil
+}
+
+type builtinMatchAgainstSig struct {
+	baseBuiltinFunc
+
+	// memorizedQuery is the parsed search string, it's initialized once if the search string is constant.
+	memorizedQuery *fulltext.Query
+	// memorizedErr is the error of parsing the search string, it's returned for every row.
+	memorizedErr error
+	once         sync.Once
+}
+
+func (b *builtinMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinMatchAgainstSig{memorizedQuery: b.memorizedQuery}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinMatchAgainstSig) canMemorize() bool {
+	sc := b.ctx.GetSessionVars().StmtCtx
+	return b.args[matchAgainstIdx].ConstItem(sc) && b.args[matchModifierIdx].ConstItem(sc) && b.args[matchParserIdx].ConstItem(sc)
+}
+
+// evalQuery parses the search string.
+func (b *builtinMatchAgainstSig) evalQuery(row chunk.Row) (*fulltext.Query, bool, error) {
+	against, isNull, err := b.args[matchAgainstIdx].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	modifier, isNull, err := b.args[matchModifierIdx].EvalInt(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	parser, isNull, err := b.args[matchParserIdx].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	tokenizer := fulltext.NewTokenizer(parser, b.args[matchTextIdx].GetType().GetCollate())
+	return fulltext.ParseQuery(tokenizer, against, ast.FulltextSearchModifier(modifier).IsBooleanMode()), false, nil
+}
+
+// evalReal evals a builtinMatchAgainstSig, which returns the relevance of the text to the search string. The row
+// matches the search string if the relevance is positive.
+// See https://dev.mysql.com/doc/refman/8.0/en/fulltext-search.html#function_match
+func (b *builtinMatchAgainstSig) evalReal(row chunk.Row) (float64, bool, error) {
+	var (
+		query *fulltext.Query
+		err   error
+	)
+	if b.canMemorize() {
+		b.once.Do(func() {
+			if b.memorizedQuery == nil {
+				b.memorizedQuery, _, b.memorizedErr = b.evalQuery(row)
+			}
+		})
+		query, err = b.memorizedQuery, b.memorizedErr
+	} else {
+		query, _, err = b.evalQuery(row)
+	}
+	if err != nil {
+		return 0, true, err
+	}
+	text, isNull, err := b.args[matchTextIdx].EvalString(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	// Like MySQL, the relevance is 0 rather than NULL if the text or the search string is NULL.
+	if query == nil || isNull {
+		return 0, false, nil
+	}
+	return query.TextRelevance(text), false, nil
+}
@@ -0,0 +1,58 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatchAgainst(t *testing.T) {
+	ctx := createContext(t)
+	tbl := []struct {
+		text      interface{}
+		against   interface{}
+		modifier  ast.FulltextSearchModifier
+		parser    string
+		relevance float64
+	}{
+		{"The quick brown fox", "fox dog", ast.FulltextSearchModifierNaturalLanguageMode, "", 1},
+		{"The quick brown fox", "cat dog", ast.FulltextSearchModifierNaturalLanguageMode, "", 0},
+		{"fox and fox", "FOX", ast.FulltextSearchModifierNaturalLanguageMode, "", 1 + math.Log(2)},
+		{"The quick brown fox", "+fox -dog", ast.FulltextSearchModifierBooleanMode, "", 1},
+		{"The quick brown fox jumps over the lazy dog", "+fox -dog", ast.FulltextSearchModifierBooleanMode, "", 0},
+		{"The quick brown fox", "qui*", ast.FulltextSearchModifierBooleanMode, "", 1},
+		{"The quick brown fox", `"brown fox"`, ast.FulltextSearchModifierBooleanMode, "", 1},
+		{"The quick brown fox", `"fox brown"`, ast.FulltextSearchModifierBooleanMode, "", 0},
+		{"分布式数据库", "数据", ast.FulltextSearchModifierNaturalLanguageMode, "ngram", 1},
+		{"分布式数据库", "+数据库 -缓存", ast.FulltextSearchModifierBooleanMode, "ngram", 1},
+		{nil, "fox", ast.FulltextSearchModifierNaturalLanguageMode, "", 0},
+		{"The quick brown fox", nil, ast.FulltextSearchModifierNaturalLanguageMode, "", 0},
+	}
+	for _, tt := range tbl {
+		f, err := newFunctionForTest(ctx, ast.FullTextMatch, primitiveValsToConstants(ctx, []interface{}{tt.text, tt.against, int64(tt.modifier), tt.parser})...)
+		require.NoError(t, err)
+		require.Equal(t, mysql.TypeDouble, f.GetType().GetType())
+		d, err := f.Eval(chunk.Row{})
+		require.NoError(t, err)
+		require.False(t, d.IsNull())
+		require.InDelta(t, tt.relevance, d.GetFloat64(), 1e-9, "MATCH(%v) AGAINST(%v)", tt.text, tt.against)
+	}
+}
@@ -19,12 +19,14 @@ import (
 	goJSON "encoding/json"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/hack"
@@ -59,6 +61,9 @@ var (
 	_ functionClass = &jsonDepthFunctionClass{}
 	_ functionClass = &jsonKeysFunctionClass{}
 	_ functionClass = &jsonLengthFunctionClass{}
+	_ functionClass = &jsonValueFunctionClass{}
+	_ functionClass = &jsonSchemaValidFunctionClass{}
+	_ functionClass = &jsonSchemaValidationReportFunctionClass{}
 
 	_ builtinFunc = &builtinJSONTypeSig{}
 	_ builtinFunc = &builtinJSONQuoteSig{}
@@ -85,6 +90,15 @@ var (
 	_ builtinFunc = &builtinJSONValidJSONSig{}
 	_ builtinFunc = &builtinJSONValidStringSig{}
 	_ builtinFunc = &builtinJSONValidOthersSig{}
+	_ builtinFunc = &builtinJSONValueIntSig{}
+	_ builtinFunc = &builtinJSONValueRealSig{}
+	_ builtinFunc = &builtinJSONValueDecimalSig{}
+	_ builtinFunc = &builtinJSONValueStringSig{}
+	_ builtinFunc = &builtinJSONValueTimeSig{}
+	_ builtinFunc = &builtinJSONValueDurationSig{}
+	_ builtinFunc = &builtinJSONValueJSONSig{}
+	_ builtinFunc = &builtinJSONSchemaValidSig{}
+	_ builtinFunc = &builtinJSONSchemaValidationReportSig{}
 )
 
 type jsonTypeFunctionClass struct {
@@ -1798,3 +1812,387 @@ func (b *builtinJSONLengthSig) evalInt(row chunk.Row) (res int64, isNull bool, e
 	}
 	return int64(obj.GetElemCount()), false, nil
 }
+
+type jsonValueFunctionClass struct {
+	baseFunctionClass
+}
+
+// getFunction builds the json_value function. The arguments are built by the planner: the JSON document, the path,
+// the type and the default value of ON EMPTY, and the type and the default value of ON ERROR. The default values are
+// constants of the RETURNING type.
+func (c *jsonValueFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	path, _, err := args[1].EvalString(ctx, chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	if pathExpr.CouldMatchMultipleValues() {
+		return nil, types.ErrInvalidJSONPathMultipleSelection
+	}
+	onEmpty, _, err := args[2].EvalInt(ctx, chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+	onError, _, err := args[4].EvalInt(ctx, chunk.Row{})
+	if err != nil {
+		return nil, err
+	}
+
+	args[0] = WrapWithCastAsJSON(ctx, args[0])
+	tp := args[3].GetType().Clone()
+	tp.DelFlag(mysql.NotNullFlag)
+	bf, err := newBaseBuiltinFuncWithFieldType(ctx, tp, args)
+	if err != nil {
+		return nil, err
+	}
+	base := builtinJSONValueSig{
+		baseBuiltinFunc: bf,
+		path:            pathExpr,
+		onEmpty:         ast.JSONTableOnResponseType(onEmpty),
+		onError:         ast.JSONTableOnResponseType(onError),
+	}
+	var sig builtinFunc
+	switch tp.EvalType() {
+	case types.ETInt:
+		sig = &builtinJSONValueIntSig{base}
+	case types.ETReal:
+		sig = &builtinJSONValueRealSig{base}
+	case types.ETDecimal:
+		sig = &builtinJSONValueDecimalSig{base}
+	case types.ETDatetime, types.ETTimestamp:
+		sig = &builtinJSONValueTimeSig{base}
+	case types.ETDuration:
+		sig = &builtinJSONValueDurationSig{base}
+	case types.ETJson:
+		sig = &builtinJSONValueJSONSig{base}
+	default:
+		sig = &builtinJSONValueStringSig{base}
+	}
+	return sig, nil
+}
+
+type builtinJSONValueSig struct {
+	baseBuiltinFunc
+
+	path    types.JSONPathExpression
+	onEmpty ast.JSONTableOnResponseType
+	onError ast.JSONTableOnResponseType
+}
+
+func (b *builtinJSONValueSig) clone() builtinJSONValueSig {
+	newSig := builtinJSONValueSig{path: b.path, onEmpty: b.onEmpty, onError: b.onError}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSONValue extracts the value by the path and converts it to the return type.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+func (b *builtinJSONValueSig) evalJSONValue(row chunk.Row) (types.Datum, bool, error) {
+	doc, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return types.Datum{}, isNull, err
+	}
+	return b.extractJSONValue(row, doc)
+}
+
+// extractJSONValue extracts the value from the JSON document, the ON EMPTY and ON ERROR clauses are evaluated on the row.
+func (b *builtinJSONValueSig) extractJSONValue(row chunk.Row, doc types.BinaryJSON) (types.Datum, bool, error) {
+	value, found := doc.Extract([]types.JSONPathExpression{b.path})
+	if !found {
+		return b.evalResponse(row, b.onEmpty, 3, types.ErrMissingJSONValue.GenWithStackByArgs(ast.JSONValue))
+	}
+	d, err := b.convert(value)
+	if err != nil {
+		return b.evalResponse(row, b.onError, 5, err)
+	}
+	return d, d.IsNull(), nil
+}
+
+// evalResponse evaluates the ON EMPTY or ON ERROR clause, the default value is the argument at defaultIdx.
+func (b *builtinJSONValueSig) evalResponse(row chunk.Row, tp ast.JSONTableOnResponseType, defaultIdx int, err error) (types.Datum, bool, error) {
+	switch tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, true, err
+	case ast.JSONTableOnResponseDefault:
+		d, err := b.args[defaultIdx].Eval(row)
+		return d, d.IsNull(), err
+	}
+	return types.Datum{}, true, nil
+}
+
+// convert converts the JSON value to the return type strictly, so the invalid values are handled by ON ERROR.
+func (b *builtinJSONValueSig) convert(value types.BinaryJSON) (types.Datum, error) {
+	if b.tp.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	var d types.Datum
+	switch value.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, types.ErrTruncatedWrongVal.GenWithStackByArgs(types.TypeStr(b.tp.GetType()), value.String())
+	case types.JSONTypeCodeLiteral:
+		if value.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		d = types.NewJSONDatum(value)
+	case types.JSONTypeCodeString:
+		d = types.NewStringDatum(string(value.GetString()))
+	default:
+		d = types.NewJSONDatum(value)
+	}
+	sc := &stmtctx.StatementContext{TimeZone: b.ctx.GetSessionVars().Location()}
+	return d.ConvertTo(sc, b.tp)
+}
+
+type builtinJSONValueIntSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueIntSig) Clone() builtinFunc {
+	return &builtinJSONValueIntSig{b.clone()}
+}
+
+func (b *builtinJSONValueIntSig) evalInt(row chunk.Row) (int64, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+type builtinJSONValueRealSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueRealSig) Clone() builtinFunc {
+	return &builtinJSONValueRealSig{b.clone()}
+}
+
+func (b *builtinJSONValueRealSig) evalReal(row chunk.Row) (float64, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+type builtinJSONValueDecimalSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueDecimalSig) Clone() builtinFunc {
+	return &builtinJSONValueDecimalSig{b.clone()}
+}
+
+func (b *builtinJSONValueDecimalSig) evalDecimal(row chunk.Row) (*types.MyDecimal, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+type builtinJSONValueStringSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueStringSig) Clone() builtinFunc {
+	return &builtinJSONValueStringSig{b.clone()}
+}
+
+func (b *builtinJSONValueStringSig) evalString(row chunk.Row) (string, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return d.GetString(), false, nil
+}
+
+type builtinJSONValueTimeSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueTimeSig) Clone() builtinFunc {
+	return &builtinJSONValueTimeSig{b.clone()}
+}
+
+func (b *builtinJSONValueTimeSig) evalTime(row chunk.Row) (types.Time, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return types.ZeroTime, isNull, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+type builtinJSONValueDurationSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueDurationSig) Clone() builtinFunc {
+	return &builtinJSONValueDurationSig{b.clone()}
+}
+
+func (b *builtinJSONValueDurationSig) evalDuration(row chunk.Row) (types.Duration, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return types.ZeroDuration, isNull, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+type builtinJSONValueJSONSig struct {
+	builtinJSONValueSig
+}
+
+func (b *builtinJSONValueJSONSig) Clone() builtinFunc {
+	return &builtinJSONValueJSONSig{b.clone()}
+}
+
+func (b *builtinJSONValueJSONSig) evalJSON(row chunk.Row) (types.BinaryJSON, bool, error) {
+	d, isNull, err := b.evalJSONValue(row)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, isNull, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
+
+type jsonSchemaValidFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *jsonSchemaValidFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinJSONSchemaValidSig{jsonSchemaBaseFuncSig{baseBuiltinFunc: bf, funcName: c.funcName}}, nil
+}
+
+type jsonSchemaValidationReportFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *jsonSchemaValidationReportFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinJSONSchemaValidationReportSig{jsonSchemaBaseFuncSig{baseBuiltinFunc: bf, funcName: c.funcName}}, nil
+}
+
+// jsonSchemaBaseFuncSig is the base of the functions validating a JSON document against a JSON schema. The schema is
+// compiled only once if it's a constant.
+type jsonSchemaBaseFuncSig struct {
+	baseBuiltinFunc
+	funcName string
+
+	once            sync.Once
+	memorizedSchema *types.JSONSchema
+	memorizedErr    error
+}
+
+func (b *jsonSchemaBaseFuncSig) parseSchema(schema types.BinaryJSON) (*types.JSONSchema, error) {
+	parse := func() (*types.JSONSchema, error) {
+		if schema.TypeCode != types.JSONTypeCodeObject {
+			return nil, types.ErrInvalidJSONType.GenWithStackByArgs(1, b.funcName, "object")
+		}
+		return types.ParseJSONSchema(schema)
+	}
+	if !b.args[0].ConstItem(b.ctx.GetSessionVars().StmtCtx) {
+		return parse()
+	}
+	b.once.Do(func() {
+		b.memorizedSchema, b.memorizedErr = parse()
+	})
+	return b.memorizedSchema, b.memorizedErr
+}
+
+func (b *jsonSchemaBaseFuncSig) validate(schema, doc types.BinaryJSON) (*types.JSONSchemaValidationError, error) {
+	s, err := b.parseSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	return s.Validate(doc)
+}
+
+func (b *jsonSchemaBaseFuncSig) evalValidation(row chunk.Row) (*types.JSONSchemaValidationError, bool, error) {
+	schema, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	doc, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	verr, err := b.validate(schema, doc)
+	return verr, false, err
+}
+
+type builtinJSONSchemaValidSig struct {
+	jsonSchemaBaseFuncSig
+}
+
+func (b *builtinJSONSchemaValidSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidSig{}
+	newSig.funcName = b.funcName
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinJSONSchemaValidSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-valid
+func (b *builtinJSONSchemaValidSig) evalInt(row chunk.Row) (int64, bool, error) {
+	verr, isNull, err := b.evalValidation(row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if verr != nil {
+		return 0, false, nil
+	}
+	return 1, false, nil
+}
+
+type builtinJSONSchemaValidationReportSig struct {
+	jsonSchemaBaseFuncSig
+}
+
+func (b *builtinJSONSchemaValidationReportSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidationReportSig{}
+	newSig.funcName = b.funcName
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals a builtinJSONSchemaValidationReportSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-validation-functions.html#function_json-schema-validation-report
+func (b *builtinJSONSchemaValidationReportSig) evalJSON(row chunk.Row) (types.BinaryJSON, bool, error) {
+	verr, isNull, err := b.evalValidation(row)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, isNull, err
+	}
+	return jsonSchemaValidationReport(verr), false, nil
+}
+
+func jsonSchemaValidationReport(verr *types.JSONSchemaValidationError) types.BinaryJSON {
+	if verr == nil {
+		return types.CreateBinaryJSON(map[string]interface{}{"valid": true})
+	}
+	return types.CreateBinaryJSON(map[string]interface{}{
+		"valid":                 false,
+		"reason":                verr.Reason(),
+		"schema-location":       verr.SchemaLocation,
+		"document-location":     verr.DocumentLocation,
+		"schema-failed-keyword": verr.Keyword,
+	})
+}
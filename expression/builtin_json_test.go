@@ -1342,3 +1342,69 @@ func TestJSONMergePatch(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONSchemaValid(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.JSONSchemaValid]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		success  bool
+	}{
+		{[]interface{}{`{"type": "object"}`, `{}`}, 1, true},
+		{[]interface{}{`{"type": "object"}`, `[]`}, 0, true},
+		{[]interface{}{`{"properties": {"a": {"maximum": 10}}, "required": ["a"]}`, `{"a": 10}`}, 1, true},
+		{[]interface{}{`{"properties": {"a": {"maximum": 10}}, "required": ["a"]}`, `{"a": 11}`}, 0, true},
+		{[]interface{}{`{"properties": {"a": {"maximum": 10}}, "required": ["a"]}`, `{"b": 1}`}, 0, true},
+		{[]interface{}{nil, `{}`}, nil, true},
+		{[]interface{}{`{}`, nil}, nil, true},
+		// The schema must be an object.
+		{[]interface{}{`[]`, `{}`}, nil, false},
+		{[]interface{}{`{"type": "unknown"}`, `{}`}, nil, false},
+		{[]interface{}{`{`, `{}`}, nil, false},
+	}
+	for _, tt := range tbl {
+		args := types.MakeDatums(tt.input...)
+		f, err := fc.getFunction(ctx, datumsToConstants(args))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.success {
+			require.NoError(t, err)
+			if tt.expected == nil {
+				require.True(t, d.IsNull())
+			} else {
+				require.Equal(t, int64(tt.expected.(int)), d.GetInt64())
+			}
+		} else {
+			require.Error(t, err)
+		}
+	}
+}
+
+func TestJSONSchemaValidationReport(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.JSONSchemaValidationReport]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+	}{
+		{[]interface{}{`{"type": "object"}`, `{}`}, `{"valid": true}`},
+		{[]interface{}{`{"properties": {"a": {"maximum": 10}}}`, `{"a": 11}`}, `{"valid": false, "reason": "The JSON document location '#/a' failed requirement 'maximum' at JSON Schema location '#/properties/a'", ` +
+			`"schema-location": "#/properties/a", "document-location": "#/a", "schema-failed-keyword": "maximum"}`},
+		{[]interface{}{nil, `{}`}, nil},
+	}
+	for _, tt := range tbl {
+		args := types.MakeDatums(tt.input...)
+		f, err := fc.getFunction(ctx, datumsToConstants(args))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		if tt.expected == nil {
+			require.True(t, d.IsNull())
+		} else {
+			j, err := types.ParseBinaryJSONFromString(tt.expected.(string))
+			require.NoError(t, err)
+			require.Equal(t, j.String(), d.GetMysqlJSON().String())
+		}
+	}
+}
@@ -1395,3 +1395,224 @@ func (b *builtinJSONMergePatchSig) vecEvalJSON(input *chunk.Chunk, result *chunk
 
 	return nil
 }
+
+func (b *builtinJSONSchemaValidSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONSchemaValidSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	schemaBuf, docBuf, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(schemaBuf)
+	defer b.bufAllocator.put(docBuf)
+	result.ResizeInt64(n, false)
+	result.MergeNulls(schemaBuf, docBuf)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		verr, err := b.validate(schemaBuf.GetJSON(i), docBuf.GetJSON(i))
+		if err != nil {
+			return err
+		}
+		if verr == nil {
+			i64s[i] = 1
+		} else {
+			i64s[i] = 0
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONSchemaValidationReportSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONSchemaValidationReportSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	schemaBuf, docBuf, err := b.vecEvalArgs(input)
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(schemaBuf)
+	defer b.bufAllocator.put(docBuf)
+	result.ReserveJSON(n)
+	for i := 0; i < n; i++ {
+		if schemaBuf.IsNull(i) || docBuf.IsNull(i) {
+			result.AppendNull()
+			continue
+		}
+		verr, err := b.validate(schemaBuf.GetJSON(i), docBuf.GetJSON(i))
+		if err != nil {
+			return err
+		}
+		result.AppendJSON(jsonSchemaValidationReport(verr))
+	}
+	return nil
+}
+
+// vecEvalArgs evaluates the schema and the document, the caller should put the buffers back to the allocator.
+func (b *jsonSchemaBaseFuncSig) vecEvalArgs(input *chunk.Chunk) (schemaBuf, docBuf *chunk.Column, err error) {
+	schemaBuf, err = b.bufAllocator.get()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = b.args[0].VecEvalJSON(b.ctx, input, schemaBuf); err != nil {
+		b.bufAllocator.put(schemaBuf)
+		return nil, nil, err
+	}
+	docBuf, err = b.bufAllocator.get()
+	if err != nil {
+		b.bufAllocator.put(schemaBuf)
+		return nil, nil, err
+	}
+	if err = b.args[1].VecEvalJSON(b.ctx, input, docBuf); err != nil {
+		b.bufAllocator.put(schemaBuf)
+		b.bufAllocator.put(docBuf)
+		return nil, nil, err
+	}
+	return schemaBuf, docBuf, nil
+}
+
+// vecEvalJSONValue evaluates json_value on every row of the input, appendFn is called with the value of each row in
+// order, the value is a NULL datum if the result is NULL.
+func (b *builtinJSONValueSig) vecEvalJSONValue(input *chunk.Chunk, appendFn func(i int, d types.Datum)) error {
+	n := input.NumRows()
+	docBuf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(docBuf)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, docBuf); err != nil {
+		return err
+	}
+	for i := 0; i < n; i++ {
+		if docBuf.IsNull(i) {
+			appendFn(i, types.Datum{})
+			continue
+		}
+		d, isNull, err := b.extractJSONValue(input.GetRow(i), docBuf.GetJSON(i))
+		if err != nil {
+			return err
+		}
+		if isNull {
+			d = types.Datum{}
+		}
+		appendFn(i, d)
+	}
+	return nil
+}
+
+func (b *builtinJSONValueIntSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueIntSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeInt64(input.NumRows(), false)
+	i64s := result.Int64s()
+	return b.vecEvalJSONValue(input, func(i int, d types.Datum) {
+		if d.IsNull() {
+			result.SetNull(i, true)
+			return
+		}
+		i64s[i] = d.GetInt64()
+	})
+}
+
+func (b *builtinJSONValueRealSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueRealSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeFloat64(input.NumRows(), false)
+	f64s := result.Float64s()
+	return b.vecEvalJSONValue(input, func(i int, d types.Datum) {
+		if d.IsNull() {
+			result.SetNull(i, true)
+			return
+		}
+		f64s[i] = d.GetFloat64()
+	})
+}
+
+func (b *builtinJSONValueDecimalSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueDecimalSig) vecEvalDecimal(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeDecimal(input.NumRows(), false)
+	decs := result.Decimals()
+	return b.vecEvalJSONValue(input, func(i int, d types.Datum) {
+		if d.IsNull() {
+			result.SetNull(i, true)
+			return
+		}
+		decs[i] = *d.GetMysqlDecimal()
+	})
+}
+
+func (b *builtinJSONValueStringSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueStringSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	result.ReserveString(input.NumRows())
+	return b.vecEvalJSONValue(input, func(_ int, d types.Datum) {
+		if d.IsNull() {
+			result.AppendNull()
+			return
+		}
+		result.AppendString(d.GetString())
+	})
+}
+
+func (b *builtinJSONValueTimeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueTimeSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeTime(input.NumRows(), false)
+	times := result.Times()
+	return b.vecEvalJSONValue(input, func(i int, d types.Datum) {
+		if d.IsNull() {
+			result.SetNull(i, true)
+			return
+		}
+		times[i] = d.GetMysqlTime()
+	})
+}
+
+func (b *builtinJSONValueDurationSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueDurationSig) vecEvalDuration(input *chunk.Chunk, result *chunk.Column) error {
+	result.ResizeGoDuration(input.NumRows(), false)
+	durations := result.GoDurations()
+	return b.vecEvalJSONValue(input, func(i int, d types.Datum) {
+		if d.IsNull() {
+			result.SetNull(i, true)
+			return
+		}
+		durations[i] = d.GetMysqlDuration().Duration
+	})
+}
+
+func (b *builtinJSONValueJSONSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONValueJSONSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	result.ReserveJSON(input.NumRows())
+	return b.vecEvalJSONValue(input, func(_ int, d types.Datum) {
+		if d.IsNull() {
+			result.AppendNull()
+			return
+		}
+		result.AppendJSON(d.GetMysqlJSON())
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
 )
 
@@ -138,6 +139,41 @@ var vecBuiltinJSONCases = map[string][]vecExprBenchCase{
 	ast.JSONQuote: {
 		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}},
 	},
+	ast.JSONValue: {
+		newJSONValueBenchCase(types.ETInt, "$.a", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewIntDatum(0)),
+		newJSONValueBenchCase(types.ETInt, "$.x", ast.JSONTableOnResponseDefault, ast.JSONTableOnResponseNull, types.NewIntDatum(7)),
+		newJSONValueBenchCase(types.ETInt, "$.b", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseDefault, types.NewIntDatum(-1)),
+		newJSONValueBenchCase(types.ETReal, "$.c", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewFloat64Datum(0)),
+		newJSONValueBenchCase(types.ETDecimal, "$.a", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewDecimalDatum(types.NewDecFromInt(0))),
+		newJSONValueBenchCase(types.ETDecimal, "$.x", ast.JSONTableOnResponseDefault, ast.JSONTableOnResponseNull, types.NewDecimalDatum(types.NewDecFromInt(0))),
+		newJSONValueBenchCase(types.ETString, "$.s", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewStringDatum("")),
+		newJSONValueBenchCase(types.ETString, "$.n", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewStringDatum("")),
+		newJSONValueBenchCase(types.ETString, "$.x", ast.JSONTableOnResponseDefault, ast.JSONTableOnResponseNull, types.NewStringDatum("empty")),
+		newJSONValueBenchCase(types.ETDatetime, "$.d", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewDatum(nil)),
+		newJSONValueBenchCase(types.ETDuration, "$.t", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewDatum(nil)),
+		newJSONValueBenchCase(types.ETJson, "$.b", ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, types.NewDatum(nil)),
+	},
+}
+
+// newJSONValueBenchCase builds the arguments of json_value like the planner, the default value is used by both
+// ON EMPTY and ON ERROR.
+func newJSONValueBenchCase(retTp types.EvalType, path string, onEmpty, onError ast.JSONTableOnResponseType, dft types.Datum) vecExprBenchCase {
+	tp := eType2FieldType(retTp)
+	tp.SetDecimal(0)
+	respTp := types.NewFieldType(mysql.TypeTiny)
+	return vecExprBenchCase{
+		retEvalType:   retTp,
+		childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETInt, retTp, types.ETInt, retTp},
+		geners:        []dataGenerator{newNullWrappedGener(0.1, &constJSONGener{`{"a": "12", "b": [1, 2], "c": 1.5, "s": "abc", "n": null, "d": "2020-01-02 03:04:05", "t": "10:11:12"}`})},
+		constants: []*Constant{
+			nil,
+			{Value: types.NewStringDatum(path), RetType: types.NewFieldType(mysql.TypeVarString)},
+			{Value: types.NewIntDatum(int64(onEmpty)), RetType: respTp},
+			{Value: dft, RetType: tp},
+			{Value: types.NewIntDatum(int64(onError)), RetType: respTp},
+			{Value: dft, RetType: tp},
+		},
+	}
 }
 
 func TestVectorizedBuiltinJSONFunc(t *testing.T) {
@@ -0,0 +1,866 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+var (
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &geometryConstructorFunctionClass{}
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stGeomFromWKBFunctionClass{}
+	_ functionClass = &stGeomFromGeoJSONFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stAsGeoJSONFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stXFunctionClass{}
+	_ functionClass = &stYFunctionClass{}
+	_ functionClass = &spatialRelationFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stDistanceSphereFunctionClass{}
+
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinGeometryConstructorSig{}
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTGeomFromWKBSig{}
+	_ builtinFunc = &builtinSTGeomFromGeoJSONSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTAsGeoJSONSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTSRIDSetSig{}
+	_ builtinFunc = &builtinSTXSig{}
+	_ builtinFunc = &builtinSTYSig{}
+	_ builtinFunc = &builtinSpatialRelationSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTDistanceSphereSig{}
+)
+
+// defaultEarthRadius is the default radius of ST_Distance_Sphere in meters, which is the same as MySQL.
+const defaultEarthRadius = 6370986
+
+// setGeometryRetType sets the return type of a function returning geometry values.
+func setGeometryRetType(bf *baseBuiltinFunc) {
+	bf.tp.SetType(mysql.TypeGeometry)
+	bf.tp.SetFlen(mysql.MaxLongBlobWidth)
+	types.SetBinChsClnFlag(bf.tp)
+}
+
+// evalGeometry evaluates the argument as a geometry value in the stored format.
+func evalGeometry(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (*types.Geometry, bool, error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	g, err := types.DecodeGeometry(hack.Slice(s))
+	if err != nil {
+		return nil, false, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g, false, nil
+}
+
+// evalGeometryPair evaluates the two arguments of a binary spatial function, they must have the same SRID.
+func evalGeometryPair(ctx sessionctx.Context, args []Expression, row chunk.Row, funcName string) (g1, g2 *types.Geometry, isNull bool, err error) {
+	if g1, isNull, err = evalGeometry(ctx, args[0], row, funcName); isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	if g2, isNull, err = evalGeometry(ctx, args[1], row, funcName); isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	if g1.SRID != g2.SRID {
+		return nil, nil, false, errGISDifferentSRIDs.GenWithStackByArgs(funcName, g1.SRID, g2.SRID)
+	}
+	return g1, g2, false, nil
+}
+
+// evalSRID evaluates the SRID argument of a function.
+func evalSRID(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (uint32, bool, error) {
+	srid, isNull, err := arg.EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if srid < 0 || srid > math.MaxUint32 {
+		return 0, false, types.ErrOverflow.GenWithStackByArgs("SRID", funcName)
+	}
+	return uint32(srid), false, nil
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinPointSig{bf}, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinPointSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(types.NewPointGeometry(x, y).Encode()), false, nil
+}
+
+// geometryConstructorFunctionClass is the function class of LineString, Polygon, MultiPoint, MultiLineString,
+// MultiPolygon and GeometryCollection, which build a geometry from its elements.
+type geometryConstructorFunctionClass struct {
+	baseFunctionClass
+	tp types.GeometryType
+}
+
+func (c *geometryConstructorFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, len(args))
+	for i := range args {
+		argTps[i] = types.ETString
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinGeometryConstructorSig{bf, c.tp, c.funcName}, nil
+}
+
+type builtinGeometryConstructorSig struct {
+	baseBuiltinFunc
+	tp       types.GeometryType
+	funcName string
+}
+
+func (b *builtinGeometryConstructorSig) Clone() builtinFunc {
+	newSig := &builtinGeometryConstructorSig{tp: b.tp, funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinGeometryConstructorSig. All the elements must have the same SRID, which is the SRID
+// of the result.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html
+func (b *builtinGeometryConstructorSig) evalString(row chunk.Row) (string, bool, error) {
+	elems := make([]*types.Geometry, 0, len(b.args))
+	for _, arg := range b.args {
+		elem, isNull, err := evalGeometry(b.ctx, arg, row, b.funcName)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if len(elems) > 0 && elem.SRID != elems[0].SRID {
+			return "", false, errGISDifferentSRIDs.GenWithStackByArgs(b.funcName, elems[0].SRID, elem.SRID)
+		}
+		elems = append(elems, elem)
+	}
+	g, err := types.NewGeometry(b.tp, elems)
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if len(elems) > 0 {
+		g.SRID = elems[0].SRID
+	}
+	return string(g.Encode()), false, nil
+}
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTGeomFromTextSig{bf, c.funcName}, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTGeomFromTextSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinSTGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	var srid uint32
+	if len(b.args) > 1 {
+		if srid, isNull, err = evalSRID(b.ctx, b.args[1], row, b.funcName); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	g, err := types.ParseGeometryWKT(wkt)
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	g.SRID = srid
+	return string(g.Encode()), false, nil
+}
+
+type stGeomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTGeomFromWKBSig{bf, c.funcName}, nil
+}
+
+type builtinSTGeomFromWKBSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTGeomFromWKBSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinSTGeomFromWKBSig) evalString(row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	var srid uint32
+	if len(b.args) > 1 {
+		if srid, isNull, err = evalSRID(b.ctx, b.args[1], row, b.funcName); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	g, err := types.ParseGeometryWKB(hack.Slice(wkb))
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	g.SRID = srid
+	return string(g.Encode()), false, nil
+}
+
+type stGeomFromGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETInt}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTGeomFromGeoJSONSig{bf}, nil
+}
+
+type builtinSTGeomFromGeoJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTGeomFromGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromGeoJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTGeomFromGeoJSONSig. The coordinates of higher dimensions are always stripped, which
+// is the same as the options 2, 3 and 4 of MySQL. The SRID is 4326 by default.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html#function_st-geomfromgeojson
+func (b *builtinSTGeomFromGeoJSONSig) evalString(row chunk.Row) (string, bool, error) {
+	doc, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	if len(b.args) > 1 {
+		options, isNull, err := b.args[1].EvalInt(b.ctx, row)
+		if isNull || err != nil {
+			return "", isNull, err
+		}
+		if options < 1 || options > 4 {
+			return "", false, errIncorrectArgs.GenWithStackByArgs(ast.STGeomFromGeoJSON)
+		}
+	}
+	srid := uint32(4326)
+	if len(b.args) > 2 {
+		if srid, isNull, err = evalSRID(b.ctx, b.args[2], row, ast.STGeomFromGeoJSON); isNull || err != nil {
+			return "", isNull, err
+		}
+	}
+	g, err := types.ParseGeometryGeoJSON(hack.Slice(doc))
+	if err != nil {
+		return "", false, errInvalidGeoJSON.GenWithStackByArgs(ast.STGeomFromGeoJSON)
+	}
+	// The geometry of the Feature is null.
+	if g == nil {
+		return "", true, nil
+	}
+	g.SRID = srid
+	return string(g.Encode()), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(mysql.MaxLongBlobWidth)
+	return &builtinSTAsTextSig{bf, c.funcName}, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTAsTextSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetType(mysql.TypeLongBlob)
+	bf.tp.SetFlen(mysql.MaxLongBlobWidth)
+	types.SetBinChsClnFlag(bf.tp)
+	return &builtinSTAsBinarySig{bf, c.funcName}, nil
+}
+
+type builtinSTAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTAsBinarySig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinSTAsBinarySig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.WKB()), false, nil
+}
+
+type stAsGeoJSONFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsGeoJSONFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt, types.ETInt}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTAsGeoJSONSig{bf}, nil
+}
+
+type builtinSTAsGeoJSONSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTAsGeoJSONSig) Clone() builtinFunc {
+	newSig := &builtinSTAsGeoJSONSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON evals a builtinSTAsGeoJSONSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-geojson-functions.html#function_st-asgeojson
+func (b *builtinSTAsGeoJSONSig) evalJSON(row chunk.Row) (types.BinaryJSON, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STAsGeoJSON)
+	if isNull || err != nil {
+		return types.BinaryJSON{}, isNull, err
+	}
+	maxDecimalDigits, options := int64(math.MaxInt32), int64(0)
+	if len(b.args) > 1 {
+		if maxDecimalDigits, isNull, err = b.args[1].EvalInt(b.ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, isNull, err
+		}
+		if maxDecimalDigits < 0 {
+			return types.BinaryJSON{}, false, errIncorrectArgs.GenWithStackByArgs(ast.STAsGeoJSON)
+		}
+	}
+	if len(b.args) > 2 {
+		if options, isNull, err = b.args[2].EvalInt(b.ctx, row); isNull || err != nil {
+			return types.BinaryJSON{}, isNull, err
+		}
+		allOptions := int64(types.GeoJSONOptionBoundingBox | types.GeoJSONOptionShortCRS | types.GeoJSONOptionLongCRS)
+		if options < 0 || options&^allOptions != 0 {
+			return types.BinaryJSON{}, false, errIncorrectArgs.GenWithStackByArgs(ast.STAsGeoJSON)
+		}
+	}
+	if maxDecimalDigits > math.MaxInt32 {
+		maxDecimalDigits = math.MaxInt32
+	}
+	res, err := g.GeoJSON(int(maxDecimalDigits), int(options))
+	return res, false, err
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(len("MULTILINESTRING"))
+	return &builtinSTGeometryTypeSig{bf}, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals a builtinSTGeometryTypeSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-geometrytype
+func (b *builtinSTGeometryTypeSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STGeometryType)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.TypeName(), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+		if err != nil {
+			return nil, err
+		}
+		bf.tp.AddFlag(mysql.UnsignedFlag)
+		return &builtinSTSRIDSig{bf}, nil
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(&bf)
+	return &builtinSTSRIDSetSig{bf}, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STSRID)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(g.SRID), false, nil
+}
+
+type builtinSTSRIDSetSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTSRIDSetSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSetSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_SRID(g, srid), which returns the geometry with the new SRID.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSetSig) evalString(row chunk.Row) (string, bool, error) {
+	g, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.STSRID)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	if g.SRID, isNull, err = evalSRID(b.ctx, b.args[1], row, ast.STSRID); isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.Encode()), false, nil
+}
+
+// evalPoint evaluates the argument as a point.
+func evalPoint(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (types.GeoPoint, bool, error) {
+	g, isNull, err := evalGeometry(ctx, arg, row, funcName)
+	if isNull || err != nil {
+		return types.GeoPoint{}, isNull, err
+	}
+	if g.Type != types.GeometryTypePoint {
+		return types.GeoPoint{}, false, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return g.Points[0], false, nil
+}
+
+type stXFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stXFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTXSig{bf}, nil
+}
+
+type builtinSTXSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTXSig) Clone() builtinFunc {
+	newSig := &builtinSTXSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTXSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html#function_st-x
+func (b *builtinSTXSig) evalReal(row chunk.Row) (float64, bool, error) {
+	p, isNull, err := evalPoint(b.ctx, b.args[0], row, ast.STX)
+	return p.X, isNull, err
+}
+
+type stYFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stYFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTYSig{bf}, nil
+}
+
+type builtinSTYSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTYSig) Clone() builtinFunc {
+	newSig := &builtinSTYSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTYSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html#function_st-y
+func (b *builtinSTYSig) evalReal(row chunk.Row) (float64, bool, error) {
+	p, isNull, err := evalPoint(b.ctx, b.args[0], row, ast.STY)
+	return p.Y, isNull, err
+}
+
+// spatialRelationFunctionClass is the function class of ST_Contains, ST_Within and ST_Intersects.
+type spatialRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *spatialRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinSpatialRelationSig{bf, c.funcName}, nil
+}
+
+type builtinSpatialRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinSpatialRelationSig) Clone() builtinFunc {
+	newSig := &builtinSpatialRelationSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinSpatialRelationSig.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinSpatialRelationSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	var res bool
+	switch b.funcName {
+	case ast.STContains:
+		res = g1.Contains(g2)
+	case ast.STWithin:
+		res = g2.Contains(g1)
+	case ast.STIntersects:
+		res = g1.Intersects(g2)
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSig{bf}, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTDistanceSig. The distance is always computed in a Cartesian plane, and it's NULL
+// if any of the geometries is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinSTDistanceSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, ast.STDistance)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	dist, ok := g1.Distance(g2)
+	return dist, !ok, nil
+}
+
+type stDistanceSphereFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceSphereFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETString, types.ETReal}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps[:len(args)]...)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSphereSig{bf}, nil
+}
+
+type builtinSTDistanceSphereSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinSTDistanceSphereSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSphereSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals a builtinSTDistanceSphereSig, which returns the minimum spherical distance between the points
+// or multipoints on a sphere. The X of a point is its longitude and the Y is its latitude in degrees.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-convenience-functions.html#function_st-distance-sphere
+func (b *builtinSTDistanceSphereSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, ast.STDistanceSphere)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	radius := float64(defaultEarthRadius)
+	if len(b.args) > 2 {
+		if radius, isNull, err = b.args[2].EvalReal(b.ctx, row); isNull || err != nil {
+			return 0, isNull, err
+		}
+		if radius <= 0 {
+			return 0, false, errNonPositiveRadius.GenWithStackByArgs(ast.STDistanceSphere)
+		}
+	}
+	points1, err := sphereDistancePoints(g1)
+	if err != nil {
+		return 0, false, err
+	}
+	points2, err := sphereDistancePoints(g2)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(points1) == 0 || len(points2) == 0 {
+		return 0, true, nil
+	}
+	dist := math.Inf(1)
+	for _, p := range points1 {
+		for _, q := range points2 {
+			dist = math.Min(dist, haversineDistance(p, q, radius))
+		}
+	}
+	return dist, false, nil
+}
+
+// sphereDistancePoints returns the points of a point or multipoint, and checks their coordinates are valid
+// longitudes and latitudes.
+func sphereDistancePoints(g *types.Geometry) ([]types.GeoPoint, error) {
+	var points []types.GeoPoint
+	switch g.Type {
+	case types.GeometryTypePoint:
+		points = g.Points
+	case types.GeometryTypeMultiPoint:
+		for _, elem := range g.Geoms {
+			points = append(points, elem.Points[0])
+		}
+	default:
+		return nil, ErrNotSupportedYet.GenWithStackByArgs(ast.STDistanceSphere + " on " + g.TypeName())
+	}
+	for _, p := range points {
+		if p.X <= -180 || p.X > 180 {
+			return nil, errLongitudeOutOfRange.GenWithStackByArgs(p.X, ast.STDistanceSphere, -180.0, 180.0)
+		}
+		if p.Y < -90 || p.Y > 90 {
+			return nil, errLatitudeOutOfRange.GenWithStackByArgs(p.Y, ast.STDistanceSphere, -90.0, 90.0)
+		}
+	}
+	return points, nil
+}
+
+// haversineDistance returns the great-circle distance between two points on the sphere with the radius.
+func haversineDistance(p, q types.GeoPoint, radius float64) float64 {
+	lat1, lat2 := p.Y*math.Pi/180, q.Y*math.Pi/180
+	dLat, dLon := lat2-lat1, (q.X-p.X)*math.Pi/180
+	h := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLon/2), 2)
+	return 2 * radius * math.Asin(math.Min(1, math.Sqrt(h)))
+}
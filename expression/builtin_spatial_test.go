@@ -0,0 +1,206 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func geomFromTextForTest(t *testing.T, ctx sessionctx.Context, wkt string, srid int64) Expression {
+	f, err := newFunctionForTest(ctx, ast.STGeomFromText, primitiveValsToConstants(ctx, []interface{}{wkt, srid})...)
+	require.NoError(t, err)
+	return f
+}
+
+func TestGeometryConstructors(t *testing.T) {
+	ctx := createContext(t)
+	tbl := []struct {
+		funcName string
+		args     []string
+		expected interface{}
+	}{
+		{ast.LineString, []string{"POINT(0 0)", "POINT(1 1)"}, "LINESTRING(0 0,1 1)"},
+		{ast.LineString, []string{"POINT(0 0)"}, nil},
+		{ast.Polygon, []string{"LINESTRING(0 0,1 0,1 1,0 0)"}, "POLYGON((0 0,1 0,1 1,0 0))"},
+		{ast.Polygon, []string{"LINESTRING(0 0,1 0,1 1)"}, nil},
+		{ast.MultiPoint, []string{"POINT(0 0)", "POINT(1 1)"}, "MULTIPOINT((0 0),(1 1))"},
+		{ast.MultiPoint, []string{"LINESTRING(0 0,1 1)"}, nil},
+		{ast.MultiLineString, []string{"LINESTRING(0 0,1 1)"}, "MULTILINESTRING((0 0,1 1))"},
+		{ast.MultiPolygon, []string{"POLYGON((0 0,1 0,1 1,0 0))"}, "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))"},
+		{ast.GeomCollection, []string{"POINT(0 0)", "LINESTRING(0 0,1 1)"}, "GEOMETRYCOLLECTION(POINT(0 0),LINESTRING(0 0,1 1))"},
+		{ast.GeometryCollection, nil, "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tbl {
+		args := make([]Expression, 0, len(tt.args))
+		for _, wkt := range tt.args {
+			args = append(args, geomFromTextForTest(t, ctx, wkt, 0))
+		}
+		g, err := newFunctionForTest(ctx, tt.funcName, args...)
+		require.NoError(t, err)
+		require.Equal(t, mysql.TypeGeometry, g.GetType().GetType())
+		f, err := funcs[ast.STAsText].getFunction(ctx, []Expression{g})
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.expected == nil {
+			require.True(t, errGISInvalidData.Equal(err), "%s(%v): %v", tt.funcName, tt.args, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, d.GetString())
+	}
+
+	// The elements must have the same SRID.
+	g, err := newFunctionForTest(ctx, ast.LineString, geomFromTextForTest(t, ctx, "POINT(0 0)", 4326), geomFromTextForTest(t, ctx, "POINT(1 1)", 0))
+	require.NoError(t, err)
+	_, err = g.Eval(chunk.Row{})
+	require.True(t, errGISDifferentSRIDs.Equal(err))
+}
+
+func TestSTGeomFromText(t *testing.T) {
+	ctx := createContext(t)
+	tbl := []struct {
+		args []interface{}
+		srid interface{}
+		err  bool
+	}{
+		{[]interface{}{"POINT(1 2)"}, int64(0), false},
+		{[]interface{}{"POINT(1 2)", 4326}, int64(4326), false},
+		{[]interface{}{nil}, nil, false},
+		{[]interface{}{"POINT(1 2)", nil}, nil, false},
+		{[]interface{}{"POINT(1)"}, nil, true},
+		{[]interface{}{"POINT(1 2)", -1}, nil, true},
+	}
+	for _, tt := range tbl {
+		g, err := newFunctionForTest(ctx, ast.STGeomFromText, primitiveValsToConstants(ctx, tt.args)...)
+		require.NoError(t, err)
+		srid, err := funcs[ast.STSRID].getFunction(ctx, []Expression{g})
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(srid, chunk.Row{})
+		if tt.err {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		if tt.srid == nil {
+			require.True(t, d.IsNull())
+		} else {
+			require.Equal(t, uint64(tt.srid.(int64)), d.GetUint64())
+		}
+	}
+}
+
+func TestSpatialRelations(t *testing.T) {
+	ctx := createContext(t)
+	polygon := "POLYGON((0 0,10 0,10 10,0 10,0 0))"
+	tbl := []struct {
+		funcName string
+		g1, g2   string
+		expected int64
+	}{
+		{ast.STContains, polygon, "POINT(5 5)", 1},
+		{ast.STContains, polygon, "POINT(10 5)", 0},
+		{ast.STContains, polygon, "LINESTRING(1 1,9 9)", 1},
+		{ast.STWithin, "POINT(5 5)", polygon, 1},
+		{ast.STWithin, polygon, "POINT(5 5)", 0},
+		{ast.STIntersects, polygon, "POINT(10 5)", 1},
+		{ast.STIntersects, polygon, "LINESTRING(11 0,11 10)", 0},
+	}
+	for _, tt := range tbl {
+		f, err := funcs[tt.funcName].getFunction(ctx, []Expression{
+			geomFromTextForTest(t, ctx, tt.g1, 0), geomFromTextForTest(t, ctx, tt.g2, 0),
+		})
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, d.GetInt64(), "%s(%s, %s)", tt.funcName, tt.g1, tt.g2)
+	}
+
+	f, err := funcs[ast.STContains].getFunction(ctx, []Expression{
+		geomFromTextForTest(t, ctx, polygon, 4326), geomFromTextForTest(t, ctx, "POINT(5 5)", 0),
+	})
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	require.True(t, errGISDifferentSRIDs.Equal(err))
+}
+
+func TestSTDistanceSphere(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.STDistanceSphere]
+	tbl := []struct {
+		g1, g2   string
+		radius   interface{}
+		expected interface{}
+		err      error
+	}{
+		{"POINT(0 0)", "POINT(0 1)", nil, 111194.93, nil},
+		{"POINT(0 0)", "POINT(180 0)", 1.0, 3.14159, nil},
+		{"MULTIPOINT((0 0),(10 10))", "POINT(0 1)", nil, 111194.93, nil},
+		{"POINT(0 0)", "POINT(0 1)", 0.0, nil, errNonPositiveRadius},
+		{"POINT(0 0)", "POINT(-180 0)", nil, nil, errLongitudeOutOfRange},
+		{"POINT(0 0)", "POINT(0 91)", nil, nil, errLatitudeOutOfRange},
+		{"POINT(0 0)", "LINESTRING(0 0,1 1)", nil, nil, ErrNotSupportedYet},
+	}
+	for _, tt := range tbl {
+		args := []Expression{geomFromTextForTest(t, ctx, tt.g1, 0), geomFromTextForTest(t, ctx, tt.g2, 0)}
+		if tt.radius != nil {
+			args = append(args, primitiveValsToConstants(ctx, []interface{}{tt.radius})...)
+		}
+		f, err := fc.getFunction(ctx, args)
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), "%v", err)
+			continue
+		}
+		require.NoError(t, err)
+		require.InDelta(t, tt.expected, d.GetFloat64(), 0.01)
+	}
+}
+
+func TestSTAsGeoJSON(t *testing.T) {
+	ctx := createContext(t)
+	fc := funcs[ast.STAsGeoJSON]
+	tbl := []struct {
+		args     []interface{}
+		expected string
+	}{
+		{nil, `{"type": "Point", "coordinates": [1.234567, 2]}`},
+		{[]interface{}{2}, `{"type": "Point", "coordinates": [1.23, 2]}`},
+		{[]interface{}{2, 2}, `{"type": "Point", "crs": {"type": "name", "properties": {"name": "EPSG:4326"}}, "coordinates": [1.23, 2]}`},
+	}
+	for _, tt := range tbl {
+		args := append([]Expression{geomFromTextForTest(t, ctx, "POINT(1.234567 2)", 4326)}, primitiveValsToConstants(ctx, tt.args)...)
+		f, err := fc.getFunction(ctx, args)
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		expected, err := types.ParseBinaryJSONFromString(tt.expected)
+		require.NoError(t, err)
+		require.Equal(t, 0, types.CompareBinaryJSON(expected, d.GetMysqlJSON()), d.GetMysqlJSON().String())
+	}
+
+	f, err := fc.getFunction(ctx, append([]Expression{geomFromTextForTest(t, ctx, "POINT(1 2)", 0)}, primitiveValsToConstants(ctx, []interface{}{2, 8})...))
+	require.NoError(t, err)
+	_, err = evalBuiltinFunc(f, chunk.Row{})
+	require.True(t, errIncorrectArgs.Equal(err))
+}
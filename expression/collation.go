@@ -282,6 +282,11 @@ func deriveCollation(ctx sessionctx.Context, funcName string, args []Expression,
 		ec = &ExprCollation{Coer: CoercibilityCoercible, Repe: ASCII}
 		ec.Charset, ec.Collation = ctx.GetSessionVars().GetCharsetInfo()
 		return ec, nil
+	case ast.Point, ast.LineString, ast.Polygon, ast.MultiPoint, ast.MultiLineString, ast.MultiPolygon,
+		ast.GeometryCollection, ast.GeomCollection, ast.STGeomFromText, ast.STGeometryFromText, ast.STGeomFromWKB,
+		ast.STGeometryFromWKB, ast.STGeomFromGeoJSON, ast.STSRID, ast.STAsBinary, ast.STAsWKB:
+		// Geometry and WKB values are binary strings.
+		return &ExprCollation{Coer: CoercibilityCoercible, Repe: UNICODE, Charset: charset.CharsetBin, Collation: charset.CollationBin}, nil
 	case ast.JSONPretty, ast.JSONQuote:
 		// JSON function always return utf8mb4 and utf8mb4_bin.
 		ec = &ExprCollation{Coer: CoercibilityCoercible, Repe: UNICODE, Charset: charset.CharsetUTF8MB4, Collation: charset.CollationUTF8MB4}
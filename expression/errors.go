@@ -61,6 +61,12 @@ var (
 	errUserLockDeadlock              = dbterror.ClassExpression.NewStd(mysql.ErrUserLockDeadlock)
 	errUserLockWrongName             = dbterror.ClassExpression.NewStd(mysql.ErrUserLockWrongName)
 	errJSONInBooleanContext          = dbterror.ClassExpression.NewStd(mysql.ErrJSONInBooleanContext)
+	errGISInvalidData                = dbterror.ClassExpression.NewStd(mysql.ErrGISInvalidData)
+	errGISDifferentSRIDs             = dbterror.ClassExpression.NewStd(mysql.ErrGISDifferentSRIDs)
+	errInvalidGeoJSON                = dbterror.ClassExpression.NewStd(mysql.ErrInvalidGeoJSONUnspecified)
+	errLongitudeOutOfRange           = dbterror.ClassExpression.NewStd(mysql.ErrLongitudeOutOfRange)
+	errLatitudeOutOfRange            = dbterror.ClassExpression.NewStd(mysql.ErrLatitudeOutOfRange)
+	errNonPositiveRadius             = dbterror.ClassExpression.NewStd(mysql.ErrNonPositiveRadius)
 
 	// Sequence usage privilege check.
 	errSequenceAccessDenied      = dbterror.ClassExpression.NewStd(mysql.ErrTableaccessDenied)
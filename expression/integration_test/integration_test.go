@@ -6638,8 +6638,8 @@ func TestPrimaryKeyRequiredSysvar(t *testing.T) {
        id int(11) NOT NULL,
        c1 int(11) DEFAULT NULL,
        PRIMARY KEY(id) NONCLUSTERED)`)
-	tk.MustGetErrMsg(`ALTER TABLE t2
-       DROP COLUMN id`, "[ddl:8200]can't drop column id with composite index covered or Primary Key covered now")
+	tk.MustGetErrCode(`ALTER TABLE t2
+       DROP COLUMN id`, errno.ErrTableWithoutPrimaryKey)
 	tk.MustGetErrCode(`ALTER TABLE t2 DROP PRIMARY KEY`, errno.ErrTableWithoutPrimaryKey)
 
 	// this sysvar is ignored in internal sessions
@@ -7884,6 +7884,60 @@ func TestJSONExtractRange(t *testing.T) {
 	tk.MustQuery(`select json_extract('[{"a": [1,2,3,4]}]', '$[0].a[0 to 2]')`).Check(testkit.Rows("[1, 2, 3]"))
 }
 
+func TestJSONValue(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select json_value('{"a": 1}', '$.a'), json_value('{"a": "x"}', '$.a'), json_value('{"a": null}', '$.a')`).Check(testkit.Rows("1 x <nil>"))
+	tk.MustQuery(`select json_value('{"a": [1]}', '$.a'), json_value('{"a": 1}', '$.b'), json_value('{"a": 1}', '$.b' default '10' on empty)`).Check(testkit.Rows("<nil> <nil> 10"))
+	tk.MustQuery(`select json_value('{"a": "12"}', '$.a' returning signed) + 1, json_value('{"a": "x"}', '$.a' returning signed)`).Check(testkit.Rows("13 <nil>"))
+	tk.MustQuery(`select json_value('{"a": "x"}', '$.a' returning signed default '-1' on error)`).Check(testkit.Rows("-1"))
+	tk.MustQuery(`select json_value('{"a": 1.5}', '$.a' returning decimal(4, 2)), json_value('{"a": "2023-01-02"}', '$.a' returning date)`).Check(testkit.Rows("1.50 2023-01-02"))
+	tk.MustQuery(`select json_value('{"a": [1, 2]}', '$.a' returning json), json_value('{"a": "10:20:30"}', '$.a' returning time)`).Check(testkit.Rows("[1, 2] 10:20:30"))
+	require.Error(t, tk.QueryToErr(`select json_value('{"a": "x"}', '$.a' returning signed error on error)`))
+	tk.MustGetErrCode(`select json_value('{}', '$.a' error on empty)`, errno.ErrMissingJSONValue)
+	tk.MustGetErrCode(`select json_value('{}', '$.a' returning signed default 'x' on empty)`, errno.ErrTruncatedWrongValue)
+	require.Error(t, tk.QueryToErr(`select json_value('[1, 2]', '$[*]')`))
+
+	// json_value can be used by generated columns and indexes.
+	tk.MustExec("create table t (j json, id int as (json_value(j, '$.id' returning signed)), index idx(id), index idx2((json_value(j, '$.name' returning char(10)))))")
+	tk.MustExec(`insert into t (j) values ('{"id": 1, "name": "a"}'), ('{"id": "2", "name": "b"}'), ('{}')`)
+	tk.MustQuery("select id from t use index(idx) where id > 0 order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where json_value(j, '$.name' returning char(10)) = 'b'").Check(testkit.Rows("2"))
+}
+
+func TestJSONSchemaValidation(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	schema := `'{"type": "object", "properties": {"age": {"type": "integer", "minimum": 0}}, "required": ["age"]}'`
+	tk.MustQuery(`select json_schema_valid(` + schema + `, '{"age": 1}'), json_schema_valid(` + schema + `, '{"age": -1}'), json_schema_valid(` + schema + `, null)`).
+		Check(testkit.Rows("1 0 <nil>"))
+	tk.MustQuery(`select json_schema_validation_report(` + schema + `, '{"age": 1}')`).Check(testkit.Rows(`{"valid": true}`))
+	tk.MustQuery(`select r->>'$.valid', r->>'$.reason', r->>'$.schema-failed-keyword' from (select json_schema_validation_report(` + schema + `, '{}') as r) as t`).
+		Check(testkit.Rows("false The JSON document location '#' failed requirement 'required' at JSON Schema location '#' required"))
+	tk.MustGetErrCode(`select json_schema_valid('[]', '{}')`, errno.ErrInvalidJSONType)
+	tk.MustGetErrCode(`select json_schema_valid('{"type": "int"}', '{}')`, errno.ErrInvalidJSONSchema)
+
+	// The schema isn't constant.
+	tk.MustExec("create table s (schema_doc json, doc json)")
+	tk.MustExec(`insert into s values ('{"maxLength": 2}', '"abc"'), ('{"type": "string"}', '"abc"'), (null, '1')`)
+	tk.MustQuery("select json_schema_valid(schema_doc, doc) from s").Check(testkit.Rows("0", "1", "<nil>"))
+
+	// The violation of a json_schema_valid check constraint reports the reason.
+	tk.MustExec("set @@global.tidb_enable_check_constraint = 1")
+	defer tk.MustExec("set @@global.tidb_enable_check_constraint = default")
+	tk.MustExec("create table t (doc json, check (json_schema_valid(" + schema + ", doc)))")
+	tk.MustExec(`insert into t values ('{"age": 1}')`)
+	err := tk.ExecToErr(`insert into t values ('{"age": -1}')`)
+	require.EqualError(t, err, "[table:3934]The JSON document location '#/age' failed requirement 'minimum' at JSON Schema location '#/properties/age'.")
+	tk.MustExec(`insert ignore into t values ('{"age": -1}')`)
+	tk.MustQuery("show warnings").CheckContain("failed requirement 'minimum'")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("1"))
+}
+
 func TestIfNullParamMarker(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -7954,3 +8008,110 @@ func TestIfFunctionWithNull(t *testing.T) {
 	tk.MustQuery("select min(if(apply_to_now_days <= 30,loan,null)) as min, max(if(apply_to_now_days <= 720,loan,null)) as max from (select loan, datediff(from_unixtime(unix_timestamp('2023-05-18 18:43:43') + 18000), from_unixtime(apply_time/1000 + 18000)) as apply_to_now_days from orders) t1;").Sort().Check(
 		testkit.Rows("20000 35100"))
 }
+
+func TestSpatialTypesAndFunctions(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, p point not null, g geometry, spatial key idx_p (p))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `p` point NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  SPATIAL KEY `idx_p` (`p`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("insert into t values (1, point(1, 1), st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')), " +
+		"(2, st_geomfromtext('POINT(20 20)'), null), (3, st_geomfromwkb(st_asbinary(point(5, 5))), st_geomfromtext('LINESTRING(0 0,0 10)'))")
+	tk.MustQuery("select id, st_astext(p), st_astext(g), st_geometrytype(g) from t order by id").Check(testkit.Rows(
+		"1 POINT(1 1) POLYGON((0 0,10 0,10 10,0 10,0 0)) POLYGON",
+		"2 POINT(20 20) <nil> <nil>",
+		"3 POINT(5 5) LINESTRING(0 0,0 10) LINESTRING"))
+	tk.MustQuery("select id from t where st_contains(st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))'), p) order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id, st_distance(p, g) from t where g is not null order by id").Check(testkit.Rows("1 0", "3 5"))
+	tk.MustQuery("select st_x(p), st_y(p), st_srid(p) from t where id = 2").Check(testkit.Rows("20 20 0"))
+	tk.MustQuery("select st_asgeojson(p) from t where id = 3").Check(testkit.Rows(`{"coordinates": [5, 5], "type": "Point"}`))
+	tk.MustQuery("select st_astext(st_geomfromgeojson('{\"type\": \"LineString\", \"coordinates\": [[1, 2], [3, 4]]}'))").Check(testkit.Rows("LINESTRING(1 2,3 4)"))
+	tk.MustQuery("select round(st_distance_sphere(point(0, 0), point(0, 1)), 2)").Check(testkit.Rows("111194.93"))
+
+	// Only the values of the column's geometry type can be stored.
+	tk.MustGetErrCode("insert into t values (4, st_geomfromtext('LINESTRING(0 0,1 1)'), null)", mysql.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (4, 'abc', null)", mysql.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("select st_astext(st_geomfromtext('POINT(1)'))", errno.ErrGISInvalidData)
+	tk.MustGetErrCode("select st_contains(st_geomfromtext('POINT(1 1)', 4326), point(1, 1))", errno.ErrGISDifferentSRIDs)
+
+	// A SPATIAL index must be built on exactly one NOT NULL geometry column.
+	tk.MustGetErrCode("alter table t add spatial index idx_g (g)", mysql.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("alter table t add spatial index idx_id (id)", mysql.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("alter table t add spatial index idx_p_id (p, id)", mysql.ErrTooManyKeyParts)
+	tk.MustGetErrCode("alter table t add index idx_g (g)", mysql.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("alter table t add index idx_g (g(10))", mysql.ErrWrongSubKey)
+	tk.MustExec("admin check table t")
+	tk.MustExec("create table t2 (l linestring not null)")
+	tk.MustExec("insert into t2 values (linestring(point(0, 0), point(1, 1))), (st_geomfromtext('LINESTRING(2 0,2 2)'))")
+	tk.MustExec("create spatial index idx_l on t2 (l)")
+	tk.MustQuery("select st_astext(l) from t2 where st_intersects(l, point(1, 1))").Check(testkit.Rows("LINESTRING(0 0,1 1)"))
+	tk.MustExec("admin check table t2")
+
+	// Dumpling dumps the stored bytes as hex literals in SQL files and as raw bytes in CSV files,
+	// both of them are restored to the same geometries.
+	tk.MustExec("create table t3 like t")
+	tk.MustExec("create table t4 like t")
+	for _, row := range tk.MustQuery("select id, hex(p), hex(g) from t").Rows() {
+		g, rawG := "null", "null"
+		if row[2] != "<nil>" {
+			g, rawG = fmt.Sprintf("x'%s'", row[2]), fmt.Sprintf("unhex('%s')", row[2])
+		}
+		tk.MustExec(fmt.Sprintf("insert into t3 values (%s, x'%s', %s)", row[0], row[1], g))
+		tk.MustExec(fmt.Sprintf("insert into t4 values (%s, unhex('%s'), %s)", row[0], row[1], rawG))
+	}
+	for _, tbl := range []string{"t3", "t4"} {
+		tk.MustQuery(fmt.Sprintf("select count(*) from t join %s r on t.id = r.id and t.p = r.p and t.g <=> r.g", tbl)).Check(testkit.Rows("3"))
+		tk.MustQuery(fmt.Sprintf("select id, st_astext(p), st_astext(g) from %s where st_contains(g, p) order by id", tbl)).Check(testkit.Rows(
+			"1 POINT(1 1) POLYGON((0 0,10 0,10 10,0 10,0 0))"))
+		tk.MustExec("admin check table " + tbl)
+	}
+}
+
+func TestFullTextSearch(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int primary key, title varchar(255), body text, fulltext key ft (title, body)) collate utf8mb4_general_ci")
+	tk.MustExec("insert into t values (1, 'MySQL Tutorial', 'DBMS stands for DataBase'), " +
+		"(2, 'How To Use MySQL Well', 'After you went through a tutorial'), " +
+		"(3, 'Optimizing MySQL', 'In this tutorial, we show how to optimize'), " +
+		"(4, 'MySQL vs. YourSQL', 'In the following database comparison'), " +
+		"(5, 'Security', null)")
+	tk.MustExec("admin check table t")
+
+	tk.MustQuery("select id from t where match (title, body) against ('database') order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where match (body, title) against ('database comparison' in natural language mode) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id, match (title, body) against ('tutorial') > 0 from t order by id").Check(testkit.Rows("1 1", "2 1", "3 1", "4 0", "5 0"))
+	tk.MustQuery("select id from t where match (title, body) against ('+mysql -yoursql' in boolean mode) order by id").Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select id from t where match (title, body) against ('+tutorial +optimiz*' in boolean mode) order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from t where match (title, body) against ('\"went through\"' in boolean mode) order by id").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t where match (title, body) against ('secur*' in boolean mode) order by id").Check(testkit.Rows("5"))
+	tk.MustQuery("select id from t where match (title, body) against (null) order by id").Check(testkit.Rows())
+	require.True(t, tk.HasPlan("select /*+ use_index_merge(t) */ id from t where match (title, body) against ('database')", "IndexMerge"))
+	require.True(t, tk.HasPlan("select /*+ use_index_merge(t) */ id from t where match (title, body) against ('+mysql +tutorial' in boolean mode)", "IndexMerge"))
+	tk.MustQuery("select /*+ use_index_merge(t) */ id from t where match (title, body) against ('+mysql +tutorial' in boolean mode) order by id").Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select /*+ use_index_merge(t) */ id from t where match (title, body) against ('data* stands' in boolean mode) order by id").Check(testkit.Rows("1", "4"))
+
+	tk.MustGetErrCode("select id from t where match (title) against ('database')", mysql.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select id from t where match (title, body) against (title)", mysql.ErrWrongArguments)
+	tk.MustGetErrCode("select id from t where match (title, body) against ('database' with query expansion)", mysql.ErrNotSupportedYet)
+
+	// The n-gram parser splits the text into the tokens of 2 characters.
+	tk.MustExec("drop table if exists t2")
+	tk.MustExec("create table t2 (id int primary key, content text, fulltext key ft (content) with parser ngram)")
+	tk.MustExec("insert into t2 values (1, '分布式数据库'), (2, '分布式缓存'), (3, '关系型数据库')")
+	tk.MustQuery("select id from t2 where match (content) against ('数据库') order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t2 where match (content) against ('+分布式 -缓存' in boolean mode) order by id").Check(testkit.Rows("1"))
+	tk.MustExec("update t2 set content = '分布式数据库缓存' where id = 1")
+	tk.MustExec("delete from t2 where id = 3")
+	tk.MustQuery("select /*+ use_index_merge(t2) */ id from t2 where match (content) against ('缓存') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustExec("admin check table t2")
+}
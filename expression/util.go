@@ -1290,6 +1290,45 @@ func ContainCorrelatedColumn(exprs []Expression) bool {
 	return false
 }
 
+// CloneWithNewCtx deep clones the expression and binds the cloned one to the given session context, including the
+// parameters of the plan cache. It's used to share the cached plans among sessions.
+func CloneWithNewCtx(ctx sessionctx.Context, expr Expression) Expression {
+	cloned := expr.Clone()
+	bindCtx(ctx, cloned)
+	return cloned
+}
+
+// CloneExprsWithNewCtx deep clones the expressions and binds the cloned ones to the given session context.
+func CloneExprsWithNewCtx(ctx sessionctx.Context, exprs []Expression) []Expression {
+	if exprs == nil {
+		return nil
+	}
+	cloned := make([]Expression, 0, len(exprs))
+	for _, expr := range exprs {
+		cloned = append(cloned, CloneWithNewCtx(ctx, expr))
+	}
+	return cloned
+}
+
+// bindCtx binds the cloned expression to the session context in place.
+func bindCtx(ctx sessionctx.Context, expr Expression) {
+	switch x := expr.(type) {
+	case *ScalarFunction:
+		x.Function.setCtx(ctx)
+		for _, arg := range x.GetArgs() {
+			bindCtx(ctx, arg)
+		}
+	case *Constant:
+		// The cloned constant shares the ParamMarker and the DeferredExpr with the original one.
+		if x.ParamMarker != nil {
+			x.ParamMarker = &ParamMarker{ctx: ctx, order: x.ParamMarker.order}
+		}
+		if x.DeferredExpr != nil {
+			x.DeferredExpr = CloneWithNewCtx(ctx, x.DeferredExpr)
+		}
+	}
+}
+
 // MaybeOverOptimized4PlanCache used to check whether an optimization can work
 // for the statement when we enable the plan cache.
 // In some situations, some optimizations maybe over-optimize and cache an
@@ -225,10 +225,15 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return b.applyRecoverTable(m, diff)
 	case model.ActionCreateTables:
 		return b.applyCreateTables(m, diff)
-	case model.ActionReorganizePartition:
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
 		return b.applyReorganizePartition(m, diff)
+	case model.ActionRebuildTable:
+		return b.applyRebuildTable(m, diff)
 	case model.ActionFlashbackCluster:
 		return []int64{-1}, nil
+	case model.ActionCreateProcedure, model.ActionDropProcedure:
+		return nil, b.applyProcedureChange(m, diff)
 	default:
 		return b.applyDefaultAction(m, diff)
 	}
@@ -298,6 +303,11 @@ func (b *Builder) applyReorganizePartition(m *meta.Meta, diff *model.SchemaDiff)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	if diff.OldTableID != diff.TableID {
+		// The partitioning change is done and the table got a new ID.
+		b.deleteBundle(b.is, diff.OldTableID)
+		b.markTableBundleShouldUpdate(diff.TableID)
+	}
 	for _, opt := range diff.AffectedOpts {
 		if opt.OldTableID != 0 {
 			b.deleteBundle(b.is, opt.OldTableID)
@@ -309,6 +319,19 @@ func (b *Builder) applyReorganizePartition(m *meta.Meta, diff *model.SchemaDiff)
 	return tblIDs, nil
 }
 
+func (b *Builder) applyRebuildTable(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
+	tblIDs, err := b.applyTableUpdate(m, diff)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if diff.OldTableID != diff.TableID {
+		// The rebuilt table has replaced the original table.
+		b.deleteBundle(b.is, diff.OldTableID)
+		b.markTableBundleShouldUpdate(diff.TableID)
+	}
+	return tblIDs, nil
+}
+
 func (b *Builder) applyRecoverTable(m *meta.Meta, diff *model.SchemaDiff) ([]int64, error) {
 	tblIDs, err := b.applyTableUpdate(m, diff)
 	if err != nil {
@@ -415,7 +438,8 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 		newTableID = diff.TableID
 	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence:
 		oldTableID = diff.TableID
-	case model.ActionTruncateTable, model.ActionCreateView, model.ActionExchangeTablePartition:
+	case model.ActionTruncateTable, model.ActionCreateView, model.ActionExchangeTablePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning, model.ActionRebuildTable:
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
 	default:
@@ -624,6 +648,22 @@ func (b *Builder) applyModifySchemaCharsetAndCollate(m *meta.Meta, diff *model.S
 	return nil
 }
 
+func (b *Builder) applyProcedureChange(m *meta.Meta, diff *model.SchemaDiff) error {
+	di, ok := b.is.SchemaByID(diff.SchemaID)
+	if !ok {
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	procedures, err := m.ListProcedures(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDbInfo := b.getSchemaAndCopyIfNecessary(di.Name.L)
+	newDbInfo.Procedures = procedures
+	return nil
+}
+
 func (b *Builder) applyModifySchemaDefaultPlacement(m *meta.Meta, diff *model.SchemaDiff) error {
 	di, err := m.GetDatabase(diff.SchemaID)
 	if err != nil {
@@ -719,7 +759,10 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 	case model.ActionDropTablePartition:
 	case model.ActionTruncateTablePartition:
 	// ReorganizePartition handle the bundles in applyReorganizePartition
-	case model.ActionReorganizePartition:
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning,
+		model.ActionRemovePartitioning:
+	// RebuildTable handle the bundles in applyRebuildTable
+	case model.ActionRebuildTable:
 	default:
 		pi := tblInfo.GetPartitionInfo()
 		if pi != nil {
@@ -773,6 +816,18 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 				newAlloc := autoid.NewAllocator(b.store, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoRandomBitColUnsigned(), autoid.AutoRandomType, tblVer)
 				allocs = allocs.Append(newAlloc)
 			}
+		case model.ActionAddColumn:
+			// An auto-increment column is being added.
+			if tblInfo.GetAutoIncrementColInfo() != nil && allocs.Get(autoid.AutoIncrementType) == nil {
+				newAlloc := autoid.NewAllocator(b.store, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), autoid.AutoIncrementType, tblVer, autoid.CustomAutoIncCacheOption(tblInfo.AutoIdCache))
+				allocs = allocs.Append(newAlloc)
+			}
+		case model.ActionRebuildTable:
+			// The rebuild may need a _tidb_rowid allocator for a table with a clustered index.
+			if tblInfo.Rebuild != nil && allocs.Get(autoid.RowIDAllocType) == nil {
+				newAlloc := autoid.NewAllocator(b.store, dbInfo.ID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), autoid.RowIDAllocType, tblVer)
+				allocs = allocs.Append(newAlloc)
+			}
 		}
 	}
 	tbl, err := b.tableFromMeta(allocs, tblInfo)
@@ -781,6 +836,7 @@ func (b *Builder) applyCreateTable(m *meta.Meta, dbInfo *model.DBInfo, tableID i
 	}
 
 	b.is.addReferredForeignKeys(dbInfo.Name, tblInfo)
+	b.is.addMaterializedView(tblInfo)
 
 	tableNames := b.is.schemaMap[dbInfo.Name.L]
 	tableNames.tables[tblInfo.Name.L] = tbl
@@ -863,6 +919,7 @@ func (b *Builder) applyDropTable(dbInfo *model.DBInfo, tableID int64, affected [
 				dbInfo.Tables = append(dbInfo.Tables[:i], dbInfo.Tables[i+1:]...)
 			}
 			b.is.deleteReferredForeignKeys(dbInfo.Name, tblInfo)
+			b.is.deleteMaterializedView(tblInfo)
 			break
 		}
 	}
@@ -885,6 +942,7 @@ func (b *Builder) InitWithOldInfoSchema(oldSchema InfoSchema) *Builder {
 	b.copyResourceGroupMap(oldIS)
 	b.copyTemporaryTableIDsMap(oldIS)
 	b.copyReferredForeignKeyMap(oldIS)
+	b.copyMaterializedViewMap(oldIS)
 
 	copy(b.is.sortedTablesBuckets, oldIS.sortedTablesBuckets)
 	return b
@@ -936,6 +994,12 @@ func (b *Builder) copyReferredForeignKeyMap(oldIS *infoSchema) {
 	}
 }
 
+func (b *Builder) copyMaterializedViewMap(oldIS *infoSchema) {
+	for k, v := range oldIS.materializedViewMap {
+		b.is.materializedViewMap[k] = v
+	}
+}
+
 // getSchemaAndCopyIfNecessary creates a new schemaTables instance when a table in the database has changed.
 // It also does modifications on the new one because old schemaTables must be read-only.
 // And it will only copy the changed database once in the lifespan of the Builder.
@@ -975,6 +1039,7 @@ func (b *Builder) InitWithDBInfos(dbInfos []*model.DBInfo, policies []*model.Pol
 	for _, di := range dbInfos {
 		for _, t := range di.Tables {
 			b.is.addReferredForeignKeys(di.Name, t)
+			b.is.addMaterializedView(t)
 		}
 	}
 
@@ -1078,6 +1143,7 @@ func NewBuilder(store kv.Storage, factory func() (pools.Resource, error)) *Build
 			ruleBundleMap:         map[int64]*placement.Bundle{},
 			sortedTablesBuckets:   make([]sortedTables, bucketCount),
 			referredForeignKeyMap: make(map[SchemaAndTableName][]*model.ReferredFKInfo),
+			materializedViewMap:   make(map[int64][]int64),
 		},
 		dirtyDB: make(map[string]bool),
 		factory: factory,
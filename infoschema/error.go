@@ -38,6 +38,14 @@ var (
 	ErrResourceGroupNotExists = dbterror.ClassSchema.NewStd(mysql.ErrResourceGroupNotExists)
 	// ErrResourceGroupInvalidBackgroundTaskName return for unknown resource group background task name.
 	ErrResourceGroupInvalidBackgroundTaskName = dbterror.ClassExecutor.NewStd(mysql.ErrResourceGroupInvalidBackgroundTaskName)
+	// ErrProcedureExists returns for procedure already exists.
+	ErrProcedureExists = dbterror.ClassSchema.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrProcedureNotExists returns for procedure not exists.
+	ErrProcedureNotExists = dbterror.ClassSchema.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrTriggerExists returns for trigger already exists.
+	ErrTriggerExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTriggerNotExists returns for trigger not exists.
+	ErrTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgDoesNotExist)
 	// ErrReservedSyntax for internal syntax.
 	ErrReservedSyntax = dbterror.ClassSchema.NewStd(mysql.ErrReservedSyntax)
 	// ErrTableExists returns for table already exists.
@@ -49,6 +49,12 @@ type InfoSchema interface {
 	AllSchemas() []*model.DBInfo
 	Clone() (result []*model.DBInfo)
 	SchemaTables(schema model.CIStr) []table.Table
+	// ProcedureByName gets the stored procedure by schema name and procedure name.
+	ProcedureByName(schema, name model.CIStr) (*model.ProcedureInfo, bool)
+	// SchemaProcedures returns all the stored procedures in the schema.
+	SchemaProcedures(schema model.CIStr) []*model.ProcedureInfo
+	// TriggerByName gets the trigger and the table it belongs to by schema name and trigger name.
+	TriggerByName(schema, name model.CIStr) (*model.TriggerInfo, *model.TableInfo, bool)
 	SchemaMetaVersion() int64
 	// TableIsView indicates whether the schema.table is a view.
 	TableIsView(schema, table model.CIStr) bool
@@ -67,6 +73,8 @@ type InfoSchema interface {
 	HasTemporaryTable() bool
 	// GetTableReferredForeignKeys gets the table's ReferredFKInfo by lowercase schema and table name.
 	GetTableReferredForeignKeys(schema, table string) []*model.ReferredFKInfo
+	// GetTableMaterializedViews gets the IDs of the materialized views which read the table.
+	GetTableMaterializedViews(tableID int64) []int64
 }
 
 type sortedTables []table.Table
@@ -114,6 +122,10 @@ type infoSchema struct {
 	// referredForeignKeyMap records all table's ReferredFKInfo.
 	// referredSchemaAndTableName => child SchemaAndTableAndForeignKeyName => *model.ReferredFKInfo
 	referredForeignKeyMap map[SchemaAndTableName][]*model.ReferredFKInfo
+
+	// materializedViewMap records the materialized views reading the tables.
+	// base table ID => materialized view table IDs
+	materializedViewMap map[int64][]int64
 }
 
 // SchemaAndTableName contains the lower-case schema name and table name.
@@ -320,6 +332,46 @@ func (is *infoSchema) SchemaTables(schema model.CIStr) (tables []table.Table) {
 	return
 }
 
+// ProcedureByName implements InfoSchema.ProcedureByName.
+func (is *infoSchema) ProcedureByName(schema, name model.CIStr) (*model.ProcedureInfo, bool) {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil, false
+	}
+	for _, proc := range schemaTables.dbInfo.Procedures {
+		if proc.Name.L == name.L {
+			return proc, true
+		}
+	}
+	return nil, false
+}
+
+// SchemaProcedures implements InfoSchema.SchemaProcedures.
+func (is *infoSchema) SchemaProcedures(schema model.CIStr) []*model.ProcedureInfo {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil
+	}
+	return schemaTables.dbInfo.Procedures
+}
+
+// TriggerByName implements InfoSchema.TriggerByName.
+func (is *infoSchema) TriggerByName(schema, name model.CIStr) (*model.TriggerInfo, *model.TableInfo, bool) {
+	schemaTables, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil, nil, false
+	}
+	for _, tbl := range schemaTables.tables {
+		tblInfo := tbl.Meta()
+		for _, trigger := range tblInfo.Triggers {
+			if trigger.Name.L == name.L {
+				return trigger, tblInfo, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
 // FindTableByPartitionID finds the partition-table info by the partitionID.
 // FindTableByPartitionID will traverse all the tables to find the partitionID partition in which partition-table.
 func (is *infoSchema) FindTableByPartitionID(partitionID int64) (table.Table, *model.DBInfo, *model.PartitionDefinition) {
@@ -547,6 +599,51 @@ func (is *infoSchema) GetTableReferredForeignKeys(schema, table string) []*model
 	return is.referredForeignKeyMap[name]
 }
 
+func (is *infoSchema) addMaterializedView(tbInfo *model.TableInfo) {
+	if tbInfo.MaterializedView == nil {
+		return
+	}
+	for _, base := range tbInfo.MaterializedView.BaseTables {
+		mvIDs := is.materializedViewMap[base.ID]
+		if slices.Contains(mvIDs, tbInfo.ID) {
+			continue
+		}
+		newMVIDs := make([]int64, 0, len(mvIDs)+1)
+		newMVIDs = append(newMVIDs, mvIDs...)
+		newMVIDs = append(newMVIDs, tbInfo.ID)
+		slices.Sort(newMVIDs)
+		is.materializedViewMap[base.ID] = newMVIDs
+	}
+}
+
+func (is *infoSchema) deleteMaterializedView(tbInfo *model.TableInfo) {
+	if tbInfo.MaterializedView == nil {
+		return
+	}
+	for _, base := range tbInfo.MaterializedView.BaseTables {
+		mvIDs := is.materializedViewMap[base.ID]
+		if len(mvIDs) == 0 {
+			continue
+		}
+		newMVIDs := make([]int64, 0, len(mvIDs)-1)
+		for _, id := range mvIDs {
+			if id != tbInfo.ID {
+				newMVIDs = append(newMVIDs, id)
+			}
+		}
+		if len(newMVIDs) == 0 {
+			delete(is.materializedViewMap, base.ID)
+			continue
+		}
+		is.materializedViewMap[base.ID] = newMVIDs
+	}
+}
+
+// GetTableMaterializedViews gets the IDs of the materialized views which read the table.
+func (is *infoSchema) GetTableMaterializedViews(tableID int64) []int64 {
+	return is.materializedViewMap[tableID]
+}
+
 // SessionTables store local temporary tables
 type SessionTables struct {
 	// Session tables can be accessed after the db is dropped, so there needs a way to retain the DBInfo.
@@ -297,6 +297,7 @@ func TestInfoTables(t *testing.T) {
 		"PLACEMENT_POLICIES",
 		"TRX_SUMMARY",
 		"RESOURCE_GROUPS",
+		"INSTANCE_PLAN_CACHE_STATS",
 	}
 	for _, tbl := range infoTables {
 		tb, err1 := is.TableByName(util.InformationSchemaName, model.NewCIStr(tbl))
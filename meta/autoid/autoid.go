@@ -664,7 +664,8 @@ func NewAllocatorsFromTblInfo(store kv.Storage, schemaID int64, tblInfo *model.T
 	idCacheOpt := CustomAutoIncCacheOption(tblInfo.AutoIdCache)
 	tblVer := AllocOptionTableInfoVersion(tblInfo.Version)
 
-	hasRowID := !tblInfo.PKIsHandle && !tblInfo.IsCommonHandle
+	// A table being rebuilt shares the _tidb_rowid allocator with the other table of the rebuild.
+	hasRowID := (!tblInfo.PKIsHandle && !tblInfo.IsCommonHandle) || tblInfo.Rebuild != nil
 	hasAutoIncID := tblInfo.GetAutoIncrementColInfo() != nil
 	if hasRowID || hasAutoIncID {
 		alloc := NewAllocator(store, dbID, tblInfo.ID, tblInfo.IsAutoIncColUnsigned(), RowIDAllocType, idCacheOpt, tblVer)
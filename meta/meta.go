@@ -74,6 +74,7 @@ var (
 	mPolicyPrefix        = "Policy"
 	mResourceGroups      = []byte("ResourceGroups")
 	mResourceGroupPrefix = "RG"
+	mProcedurePrefix     = "Procedure"
 	mPolicyGlobalID      = []byte("PolicyGlobalID")
 	mPolicyMagicByte     = CurrentMagicByteVer
 	mDDLTableVersion     = []byte("DDLTableVersion")
@@ -365,6 +366,12 @@ func ParseTableKey(tableKey []byte) (int64, error) {
 	return int64(id), errors.Trace(err)
 }
 
+// procedureKey encodes the procedure name into the field of the database hash. Procedures are
+// looked up by name, so the key is the lower-case name instead of the ID.
+func (*Meta) procedureKey(name string) []byte {
+	return []byte(fmt.Sprintf("%s:%s", mProcedurePrefix, strings.ToLower(name)))
+}
+
 func (*Meta) sequenceKey(sequenceID int64) []byte {
 	return SequenceKey(sequenceID)
 }
@@ -850,6 +857,73 @@ func (m *Meta) DropTableOrView(dbID int64, tblID int64) error {
 	return nil
 }
 
+// CreateProcedure creates or replaces a procedure in database.
+func (m *Meta) CreateProcedure(dbID int64, procedure *model.ProcedureInfo) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	data, err := json.Marshal(procedure)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, m.procedureKey(procedure.Name.L), data)
+}
+
+// DropProcedure drops a procedure in database.
+func (m *Meta) DropProcedure(dbID int64, name string) error {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HDel(dbKey, m.procedureKey(name))
+}
+
+// GetProcedure gets the procedure by name, it returns nil if the procedure doesn't exist.
+func (m *Meta) GetProcedure(dbID int64, name string) (*model.ProcedureInfo, error) {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	value, err := m.txn.HGet(dbKey, m.procedureKey(name))
+	if err != nil || value == nil {
+		return nil, errors.Trace(err)
+	}
+
+	procedure := &model.ProcedureInfo{}
+	err = json.Unmarshal(value, procedure)
+	return procedure, errors.Trace(err)
+}
+
+// ListProcedures shows all procedures in database.
+func (m *Meta) ListProcedures(dbID int64) ([]*model.ProcedureInfo, error) {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res, err := m.txn.HGetAll(dbKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	procedures := make([]*model.ProcedureInfo, 0)
+	for _, r := range res {
+		if !strings.HasPrefix(string(r.Field), mProcedurePrefix+":") {
+			continue
+		}
+
+		procedure := &model.ProcedureInfo{}
+		if err := json.Unmarshal(r.Value, procedure); err != nil {
+			return nil, errors.Trace(err)
+		}
+		procedures = append(procedures, procedure)
+	}
+	return procedures, nil
+}
+
 // UpdateTable updates the table with table info.
 func (m *Meta) UpdateTable(dbID int64, tableInfo *model.TableInfo) error {
 	// Check if db exists.
@@ -353,6 +353,29 @@ func TestMeta(t *testing.T) {
 	tables, err = m.ListTables(1)
 	require.NoError(t, err)
 	require.Equal(t, []*model.TableInfo{tbInfo}, tables)
+
+	// Procedures are stored in the same hash as tables, but don't show up as tables.
+	procInfo := &model.ProcedureInfo{
+		ID:         3,
+		Name:       model.NewCIStr("P1"),
+		Definition: "create procedure p1() select 1",
+	}
+	err = m.CreateProcedure(1, procInfo)
+	require.NoError(t, err)
+	proc, err := m.GetProcedure(1, "p1")
+	require.NoError(t, err)
+	require.Equal(t, procInfo.Definition, proc.Definition)
+	procs, err := m.ListProcedures(1)
+	require.NoError(t, err)
+	require.Len(t, procs, 1)
+	tables, err = m.ListTables(1)
+	require.NoError(t, err)
+	require.Equal(t, []*model.TableInfo{tbInfo}, tables)
+	err = m.DropProcedure(1, "p1")
+	require.NoError(t, err)
+	proc, err = m.GetProcedure(1, "p1")
+	require.NoError(t, err)
+	require.Nil(t, proc)
 	{
 		idx := 0
 		err := m.IterTables(1, func(info *model.TableInfo) error {
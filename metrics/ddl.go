@@ -176,6 +176,7 @@ const (
 	LblModifyColumn  = "modify_column"
 
 	LblReorgPartition = "reorganize_partition"
+	LblRebuildTable   = "rebuild_table"
 )
 
 // GenerateReorgLabel returns the label with schema name and table name.
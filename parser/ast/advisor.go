@@ -18,7 +18,10 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 )
 
-var _ StmtNode = &IndexAdviseStmt{}
+var (
+	_ StmtNode = &IndexAdviseStmt{}
+	_ StmtNode = &AdviseIndexesStmt{}
+)
 
 // IndexAdviseStmt is used to advise indexes
 type IndexAdviseStmt struct {
@@ -64,6 +67,45 @@ func (n *IndexAdviseStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// AdviseIndexesStmt is used to advise indexes for a workload.
+// If Queries is empty, the workload is read from the statement summary.
+type AdviseIndexesStmt struct {
+	stmtNode
+
+	Queries     []string
+	MaxIndexNum *MaxIndexNumClause
+}
+
+// Restore implements Node interface.
+func (n *AdviseIndexesStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ADVISE INDEXES FOR ")
+	if len(n.Queries) == 0 {
+		ctx.WriteKeyWord("WORKLOAD")
+	}
+	for i, query := range n.Queries {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteString(query)
+	}
+	if n.MaxIndexNum != nil {
+		if err := n.MaxIndexNum.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AdviseIndexesStmt.MaxIndexNum")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AdviseIndexesStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AdviseIndexesStmt)
+	return v.Leave(n)
+}
+
 // MaxIndexNumClause represents 'maximum number of indexes' clause in index advise statement.
 type MaxIndexNumClause struct {
 	PerTable uint64
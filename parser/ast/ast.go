@@ -245,6 +245,8 @@ func GetStmtLabel(stmtNode StmtNode) string {
 		return "CreateBinding"
 	case *IndexAdviseStmt:
 		return "IndexAdvise"
+	case *AdviseIndexesStmt:
+		return "AdviseIndexes"
 	case *DropBindingStmt:
 		return "DropBinding"
 	case *TraceStmt:
@@ -800,6 +800,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -854,6 +855,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1208,16 +1211,19 @@ func (n *CreateTableStmt) Accept(v Visitor) (Node, bool) {
 type DropTableStmt struct {
 	ddlNode
 
-	IfExists         bool
-	Tables           []*TableName
-	IsView           bool
-	TemporaryKeyword // make sense ONLY if/when IsView == false
+	IfExists           bool
+	Tables             []*TableName
+	IsView             bool
+	IsMaterializedView bool
+	TemporaryKeyword   // make sense ONLY if/when IsView == false
 }
 
 // Restore implements Node interface.
 func (n *DropTableStmt) Restore(ctx *format.RestoreCtx) error {
 	if n.IsView {
 		ctx.WriteKeyWord("DROP VIEW ")
+	} else if n.IsMaterializedView {
+		ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
 	} else {
 		switch n.TemporaryKeyword {
 		case TemporaryNone:
@@ -3298,9 +3304,7 @@ func (n *AlterTableSpec) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord(" TO ")
 		ctx.WriteName(n.ToKey.O)
 	case AlterTableForce:
-		// TODO: not support
 		ctx.WriteKeyWord("FORCE")
-		ctx.WritePlain(" /* AlterTableForce is not supported */ ")
 	case AlterTableAddPartitions:
 		ctx.WriteKeyWord("ADD PARTITION")
 		if n.IfNotExists {
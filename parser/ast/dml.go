@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -50,6 +51,7 @@ var (
 	_ Node = &TableName{}
 	_ Node = &TableRefsClause{}
 	_ Node = &TableSource{}
+	_ Node = &JSONTable{}
 	_ Node = &SetOprSelectList{}
 	_ Node = &WildCardField{}
 	_ Node = &WindowSpec{}
@@ -531,6 +533,9 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates the derived table can reference the columns of the preceding tables.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -576,6 +581,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -1037,6 +1045,168 @@ func (s *TableSample) Accept(v Visitor) (node Node, ok bool) {
 	return v.Leave(s)
 }
 
+// JSONTableColumnType is the type of a column in JSON_TABLE.
+type JSONTableColumnType int
+
+// JSONTableColumnType types.
+const (
+	// JSONTableColumnPath is `name type PATH 'path' [on_empty] [on_error]`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnExists is `name type EXISTS PATH 'path'`.
+	JSONTableColumnExists
+	// JSONTableColumnOrdinality is `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnNested is `NESTED [PATH] 'path' COLUMNS (...)`.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the type of the ON EMPTY / ON ERROR clause of a JSON_TABLE column.
+type JSONTableOnResponseType int
+
+// JSONTableOnResponseType types.
+const (
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	JSONTableOnResponseError
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the `{NULL | ERROR | DEFAULT json_string} ON {EMPTY | ERROR}` clause.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the json string used when Tp is JSONTableOnResponseDefault.
+	Default string
+}
+
+// Restore writes the response part of the clause, without the trailing `ON EMPTY` or `ON ERROR`.
+func (r *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) {
+	switch r.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(r.Default)
+	}
+}
+
+// JSONTableColumn is a column definition in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp JSONTableColumnType
+	// Name is the column name, it is empty for nested columns.
+	Name model.CIStr
+	// FieldType is the column type, it is nil for ordinality and nested columns.
+	FieldType *types.FieldType
+	// Path is the JSON path relative to the current row path.
+	Path string
+	// OnEmpty and OnError are only used by path columns, nil means NULL.
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+	// NestedColumns are the columns of a nested path.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (c *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if c.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(c.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, c.NestedColumns)
+	}
+	ctx.WriteName(c.Name.O)
+	switch c.Tp {
+	case JSONTableColumnOrdinality:
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	case JSONTableColumnExists:
+		ctx.WritePlain(" ")
+		if err := c.FieldType.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+		}
+		ctx.WriteKeyWord(" EXISTS PATH ")
+		ctx.WriteString(c.Path)
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := c.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(c.Path)
+	if c.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		c.OnEmpty.Restore(ctx)
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if c.OnError != nil {
+		ctx.WritePlain(" ")
+		c.OnError.Restore(ctx)
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable represents the JSON_TABLE table function.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	// Expr is the JSON document, it may reference columns of preceding tables.
+	Expr ExprNode
+	// Path is the row path of the JSON_TABLE.
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 type SelectStmtKind uint8
 
 const (
@@ -0,0 +1,262 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/format"
+)
+
+var (
+	_ DDLNode = &CreateEventStmt{}
+	_ DDLNode = &AlterEventStmt{}
+	_ DDLNode = &DropEventStmt{}
+)
+
+// EventCompletionType is the type of the ON COMPLETION clause of an event.
+type EventCompletionType int
+
+// List of event completion types.
+const (
+	EventCompletionNone EventCompletionType = iota
+	EventCompletionNotPreserve
+	EventCompletionPreserve
+)
+
+// EventStatusType is the type of the ENABLE and DISABLE clause of an event.
+type EventStatusType int
+
+// List of event status types.
+const (
+	EventStatusNone EventStatusType = iota
+	EventStatusEnable
+	EventStatusDisable
+)
+
+// EventSchedule is the ON SCHEDULE clause of an event.
+type EventSchedule struct {
+	// At is set for the one-time event.
+	At ExprNode
+	// Every and Unit are set for the recurring event, Starts and Ends are optional.
+	Every  ExprNode
+	Unit   TimeUnitType
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore restores the schedule clause.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ON SCHEDULE ")
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+func (n *EventSchedule) accept(v Visitor) bool {
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return false
+		}
+		*expr = node.(ExprNode)
+	}
+	return true
+}
+
+func restoreEventOptions(ctx *format.RestoreCtx, completion EventCompletionType, status EventStatusType, comment *string) {
+	switch completion {
+	case EventCompletionNotPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION NOT PRESERVE")
+	case EventCompletionPreserve:
+		ctx.WriteKeyWord(" ON COMPLETION PRESERVE")
+	}
+	switch status {
+	case EventStatusEnable:
+		ctx.WriteKeyWord(" ENABLE")
+	case EventStatusDisable:
+		ctx.WriteKeyWord(" DISABLE")
+	}
+	if comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*comment)
+	}
+}
+
+// CreateEventStmt is a statement to create an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	EventName   *TableName
+	Schedule    *EventSchedule
+	Completion  EventCompletionType
+	Status      EventStatusType
+	// Comment is nil if the COMMENT clause is not specified.
+	Comment *string
+	Body    StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WritePlain(" ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	restoreEventOptions(ctx, n.Completion, n.Status, n.Comment)
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	if !n.Schedule.accept(v) {
+		return n, false
+	}
+	node, ok := n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to change an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	ddlNode
+
+	EventName *TableName
+	// Schedule, Comment and Body are nil if they are not changed.
+	Schedule   *EventSchedule
+	Completion EventCompletionType
+	Status     EventStatusType
+	Comment    *string
+	Body       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WritePlain(" ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	restoreEventOptions(ctx, n.Completion, n.Status, n.Comment)
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	if n.Schedule != nil && !n.Schedule.accept(v) {
+		return n, false
+	}
+	if n.Body != nil {
+		node, ok := n.Body.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+type DropEventStmt struct {
+	ddlNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	return v.Leave(n)
+}
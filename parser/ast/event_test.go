@@ -0,0 +1,125 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventVisitorCover(t *testing.T) {
+	valueExpr := ast.NewValueExpr(1, "", "")
+	stmts := []ast.StmtNode{
+		&ast.CreateEventStmt{
+			Schedule: &ast.EventSchedule{Every: valueExpr, Starts: valueExpr, Ends: valueExpr},
+			Body:     &ast.ProcedureBlock{},
+		},
+		&ast.AlterEventStmt{Schedule: &ast.EventSchedule{At: valueExpr}, Body: &ast.ProcedureBlock{}},
+		&ast.AlterEventStmt{},
+		&ast.DropEventStmt{},
+	}
+	for _, v := range stmts {
+		v.Accept(visitor{})
+		v.Accept(visitor1{})
+	}
+}
+
+func TestCreateEvent(t *testing.T) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create event if not exists test.e on schedule every 1 hour starts '2023-01-01 00:00:00' "+
+		"ends now() + interval 1 day on completion preserve disable comment 'cleanup' do begin delete from t; end", "", "")
+	require.NoError(t, err)
+	event := stmt.(*ast.CreateEventStmt)
+	require.True(t, event.IfNotExists)
+	require.Equal(t, "test", event.EventName.Schema.O)
+	require.Equal(t, "e", event.EventName.Name.O)
+	require.Nil(t, event.Schedule.At)
+	require.NotNil(t, event.Schedule.Every)
+	require.Equal(t, ast.TimeUnitHour, event.Schedule.Unit)
+	require.NotNil(t, event.Schedule.Starts)
+	require.NotNil(t, event.Schedule.Ends)
+	require.Equal(t, ast.EventCompletionPreserve, event.Completion)
+	require.Equal(t, ast.EventStatusDisable, event.Status)
+	require.Equal(t, "cleanup", *event.Comment)
+	require.Equal(t, "begin delete from t; end", event.Body.Text())
+
+	stmt, err = p.ParseOneStmt("create event e on schedule at current_timestamp + interval 1 hour do insert into t values (1)", "", "")
+	require.NoError(t, err)
+	event = stmt.(*ast.CreateEventStmt)
+	require.NotNil(t, event.Schedule.At)
+	require.Nil(t, event.Schedule.Every)
+	require.Equal(t, ast.EventCompletionNone, event.Completion)
+	require.Equal(t, ast.EventStatusNone, event.Status)
+	require.Nil(t, event.Comment)
+	require.Equal(t, "insert into t values (1)", event.Body.Text())
+
+	_, err = p.ParseOneStmt("create event e on schedule every 1 day do", "", "")
+	require.Error(t, err)
+	_, err = p.ParseOneStmt("create event e do select 1", "", "")
+	require.Error(t, err)
+	_, err = p.ParseOneStmt("create event e on schedule at now() starts now() do select 1", "", "")
+	require.Error(t, err)
+
+	stmt, err = p.ParseOneStmt("alter event e on completion not preserve enable", "", "")
+	require.NoError(t, err)
+	alter := stmt.(*ast.AlterEventStmt)
+	require.Equal(t, "e", alter.EventName.Name.O)
+	require.Nil(t, alter.Schedule)
+	require.Equal(t, ast.EventCompletionNotPreserve, alter.Completion)
+	require.Equal(t, ast.EventStatusEnable, alter.Status)
+	require.Nil(t, alter.Comment)
+	require.Nil(t, alter.Body)
+
+	stmt, err = p.ParseOneStmt("alter event e on schedule every 2 minute comment '' do set @a = 1", "", "")
+	require.NoError(t, err)
+	alter = stmt.(*ast.AlterEventStmt)
+	require.Equal(t, ast.TimeUnitMinute, alter.Schedule.Unit)
+	require.Equal(t, "", *alter.Comment)
+	require.Equal(t, "set @a = 1", alter.Body.Text())
+
+	stmt, err = p.ParseOneStmt("drop event if exists test.e", "", "")
+	require.NoError(t, err)
+	drop := stmt.(*ast.DropEventStmt)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "test", drop.EventName.Schema.O)
+	require.Equal(t, "e", drop.EventName.Name.O)
+}
+
+func TestEventRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"CREATE EVENT e ON SCHEDULE AT '2023-01-01 00:00:00' DO DELETE FROM t",
+			"CREATE EVENT `e` ON SCHEDULE AT _UTF8MB4'2023-01-01 00:00:00' DO DELETE FROM `t`",
+		},
+		{
+			"CREATE EVENT IF NOT EXISTS test.e ON SCHEDULE EVERY 1 DAY STARTS NOW() ENDS NOW() + INTERVAL 1 YEAR ON COMPLETION PRESERVE DISABLE COMMENT 'c' DO BEGIN DELETE FROM t; END",
+			"CREATE EVENT IF NOT EXISTS `test`.`e` ON SCHEDULE EVERY 1 DAY STARTS NOW() ENDS DATE_ADD(NOW(), INTERVAL 1 YEAR) ON COMPLETION PRESERVE DISABLE COMMENT 'c' DO BEGIN DELETE FROM `t`; END",
+		},
+		{"ALTER EVENT e ENABLE", "ALTER EVENT `e` ENABLE"},
+		{
+			"ALTER EVENT e ON SCHEDULE EVERY 10 MINUTE ON COMPLETION NOT PRESERVE COMMENT '' DO SET @a = 1",
+			"ALTER EVENT `e` ON SCHEDULE EVERY 10 MINUTE ON COMPLETION NOT PRESERVE COMMENT '' DO SET @`a`=1",
+		},
+		{"DROP EVENT e", "DROP EVENT `e`"},
+		{"DROP EVENT IF EXISTS test.e", "DROP EVENT IF EXISTS `test`.`e`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
@@ -29,6 +29,7 @@ var (
 	_ FuncNode = &AggregateFuncExpr{}
 	_ FuncNode = &FuncCallExpr{}
 	_ FuncNode = &FuncCastExpr{}
+	_ FuncNode = &JSONValueExpr{}
 	_ FuncNode = &WindowFuncExpr{}
 )
 
@@ -326,33 +327,68 @@ const (
 	ValidatePasswordStrength = "validate_password_strength"
 
 	// json functions
-	JSONType          = "json_type"
-	JSONExtract       = "json_extract"
-	JSONUnquote       = "json_unquote"
-	JSONArray         = "json_array"
-	JSONObject        = "json_object"
-	JSONMerge         = "json_merge"
-	JSONSet           = "json_set"
-	JSONInsert        = "json_insert"
-	JSONReplace       = "json_replace"
-	JSONRemove        = "json_remove"
-	JSONOverlaps      = "json_overlaps"
-	JSONContains      = "json_contains"
-	JSONMemberOf      = "json_memberof"
-	JSONContainsPath  = "json_contains_path"
-	JSONValid         = "json_valid"
-	JSONArrayAppend   = "json_array_append"
-	JSONArrayInsert   = "json_array_insert"
-	JSONMergePatch    = "json_merge_patch"
-	JSONMergePreserve = "json_merge_preserve"
-	JSONPretty        = "json_pretty"
-	JSONQuote         = "json_quote"
-	JSONSearch        = "json_search"
-	JSONStorageFree   = "json_storage_free"
-	JSONStorageSize   = "json_storage_size"
-	JSONDepth         = "json_depth"
-	JSONKeys          = "json_keys"
-	JSONLength        = "json_length"
+	JSONType                   = "json_type"
+	JSONExtract                = "json_extract"
+	JSONUnquote                = "json_unquote"
+	JSONArray                  = "json_array"
+	JSONObject                 = "json_object"
+	JSONMerge                  = "json_merge"
+	JSONSet                    = "json_set"
+	JSONInsert                 = "json_insert"
+	JSONReplace                = "json_replace"
+	JSONRemove                 = "json_remove"
+	JSONOverlaps               = "json_overlaps"
+	JSONContains               = "json_contains"
+	JSONMemberOf               = "json_memberof"
+	JSONContainsPath           = "json_contains_path"
+	JSONValid                  = "json_valid"
+	JSONArrayAppend            = "json_array_append"
+	JSONArrayInsert            = "json_array_insert"
+	JSONMergePatch             = "json_merge_patch"
+	JSONMergePreserve          = "json_merge_preserve"
+	JSONPretty                 = "json_pretty"
+	JSONQuote                  = "json_quote"
+	JSONSearch                 = "json_search"
+	JSONStorageFree            = "json_storage_free"
+	JSONStorageSize            = "json_storage_size"
+	JSONDepth                  = "json_depth"
+	JSONKeys                   = "json_keys"
+	JSONLength                 = "json_length"
+	JSONValue                  = "json_value"
+	JSONSchemaValid            = "json_schema_valid"
+	JSONSchemaValidationReport = "json_schema_validation_report"
+
+	// spatial functions
+	Point              = "point"
+	LineString         = "linestring"
+	Polygon            = "polygon"
+	MultiPoint         = "multipoint"
+	MultiLineString    = "multilinestring"
+	MultiPolygon       = "multipolygon"
+	GeometryCollection = "geometrycollection"
+	GeomCollection     = "geomcollection"
+	STAsBinary         = "st_asbinary"
+	STAsWKB            = "st_aswkb"
+	STAsGeoJSON        = "st_asgeojson"
+	STAsText           = "st_astext"
+	STAsWKT            = "st_aswkt"
+	STContains         = "st_contains"
+	STDistance         = "st_distance"
+	STDistanceSphere   = "st_distance_sphere"
+	STGeomFromGeoJSON  = "st_geomfromgeojson"
+	STGeomFromText     = "st_geomfromtext"
+	STGeometryFromText = "st_geometryfromtext"
+	STGeomFromWKB      = "st_geomfromwkb"
+	STGeometryFromWKB  = "st_geometryfromwkb"
+	STGeometryType     = "st_geometrytype"
+	STIntersects       = "st_intersects"
+	STSRID             = "st_srid"
+	STWithin           = "st_within"
+	STX                = "st_x"
+	STY                = "st_y"
+
+	// FullTextMatch is the function of MATCH (col1, col2, ...) AGAINST (expr [search_modifier]).
+	FullTextMatch = "match"
 
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
@@ -673,6 +709,77 @@ func (n *FuncCastExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONValueExpr is the json_value function, e.g, json_value(doc, '$.a' RETURNING signed DEFAULT '0' ON EMPTY).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+type JSONValueExpr struct {
+	funcNode
+	// Expr is the JSON document.
+	Expr ExprNode
+	// Path is the JSON path.
+	Path string
+	// Tp is the RETURNING type, nil means the default type.
+	Tp *types.FieldType
+	// ExplicitCharSet is true when charset is explicit indicated in the RETURNING type.
+	ExplicitCharSet bool
+	// OnEmpty and OnError are the ON EMPTY and ON ERROR clauses, nil means NULL.
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+}
+
+// Restore implements Node interface.
+func (n *JSONValueExpr) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_VALUE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore JSONValueExpr.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	if n.Tp != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		n.Tp.RestoreAsCastType(ctx, n.ExplicitCharSet)
+	}
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		n.OnEmpty.Restore(ctx)
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		n.OnError.Restore(ctx)
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Format the ExprNode into a Writer.
+func (n *JSONValueExpr) Format(w io.Writer) {
+	fmt.Fprint(w, "JSON_VALUE(")
+	n.Expr.Format(w)
+	fmt.Fprintf(w, ", '%s'", n.Path)
+	if n.Tp != nil {
+		fmt.Fprint(w, " RETURNING ")
+		n.Tp.FormatAsCastType(w, n.ExplicitCharSet)
+	}
+	fmt.Fprint(w, ")")
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONValueExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONValueExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // TrimDirectionType is the type for trim direction.
 type TrimDirectionType int
 
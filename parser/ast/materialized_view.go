@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ DDLNode  = &CreateMaterializedViewStmt{}
+	_ StmtNode = &RefreshMaterializedViewStmt{}
+)
+
+// CreateMaterializedViewStmt is a statement to create a materialized view, which stores the result of the query in a
+// table.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	ViewName    *TableName
+	Cols        []model.CIStr
+	Select      StmtNode
+
+	// The fields below are filled by the planner.
+	// ColumnTypes are the types of the view columns, they're inferred from the query.
+	ColumnTypes []*types.FieldType
+	// Info describes the query and whether the view can be refreshed incrementally.
+	Info *model.MaterializedViewInfo
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
+	return v.Leave(n)
+}
+
+// RefreshMaterializedViewMode is the mode of REFRESH MATERIALIZED VIEW.
+type RefreshMaterializedViewMode int
+
+// List of refresh modes.
+const (
+	// RefreshMaterializedViewDefault refreshes the view incrementally if it's possible, or completely otherwise.
+	RefreshMaterializedViewDefault RefreshMaterializedViewMode = iota
+	// RefreshMaterializedViewComplete recomputes the whole result of the view.
+	RefreshMaterializedViewComplete
+	// RefreshMaterializedViewIncremental only recomputes the rows affected by the changes of the base tables.
+	RefreshMaterializedViewIncremental
+)
+
+// String implements fmt.Stringer interface.
+func (m RefreshMaterializedViewMode) String() string {
+	switch m {
+	case RefreshMaterializedViewComplete:
+		return "COMPLETE"
+	case RefreshMaterializedViewIncremental:
+		return "INCREMENTAL"
+	}
+	return ""
+}
+
+// RefreshMaterializedViewStmt is a statement to refresh the data of a materialized view.
+type RefreshMaterializedViewStmt struct {
+	stmtNode
+
+	ViewName *TableName
+	Mode     RefreshMaterializedViewMode
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Mode != RefreshMaterializedViewDefault {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Mode.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
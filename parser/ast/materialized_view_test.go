@@ -0,0 +1,91 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedViewVisitorCover(t *testing.T) {
+	stmts := []ast.StmtNode{
+		&ast.CreateMaterializedViewStmt{ViewName: &ast.TableName{}, Select: &ast.SelectStmt{}},
+		&ast.RefreshMaterializedViewStmt{ViewName: &ast.TableName{}},
+	}
+	for _, v := range stmts {
+		v.Accept(visitor{})
+		v.Accept(visitor1{})
+	}
+}
+
+func TestCreateMaterializedView(t *testing.T) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create materialized view if not exists test.mv (a, cnt) as select a, count(*) from t group by a", "", "")
+	require.NoError(t, err)
+	mv := stmt.(*ast.CreateMaterializedViewStmt)
+	require.True(t, mv.IfNotExists)
+	require.Equal(t, "test", mv.ViewName.Schema.O)
+	require.Equal(t, "mv", mv.ViewName.Name.O)
+	require.Len(t, mv.Cols, 2)
+	require.Equal(t, "cnt", mv.Cols[1].O)
+	require.Equal(t, "select a, count(*) from t group by a", mv.Select.Text())
+
+	stmt, err = p.ParseOneStmt("create materialized view mv as (select * from t1 union select * from t2)", "", "")
+	require.NoError(t, err)
+	mv = stmt.(*ast.CreateMaterializedViewStmt)
+	require.False(t, mv.IfNotExists)
+	require.Nil(t, mv.Cols)
+	require.IsType(t, &ast.SetOprStmt{}, mv.Select)
+
+	_, err = p.ParseOneStmt("create or replace materialized view mv as select * from t", "", "")
+	require.Error(t, err)
+	_, err = p.ParseOneStmt("create materialized view mv", "", "")
+	require.Error(t, err)
+}
+
+func TestRefreshMaterializedView(t *testing.T) {
+	p := parser.New()
+	for _, tt := range []struct {
+		sql  string
+		mode ast.RefreshMaterializedViewMode
+	}{
+		{"refresh materialized view mv", ast.RefreshMaterializedViewDefault},
+		{"refresh materialized view test.mv complete", ast.RefreshMaterializedViewComplete},
+		{"refresh materialized view test.mv incremental", ast.RefreshMaterializedViewIncremental},
+	} {
+		stmt, err := p.ParseOneStmt(tt.sql, "", "")
+		require.NoError(t, err)
+		require.Equal(t, tt.mode, stmt.(*ast.RefreshMaterializedViewStmt).Mode)
+	}
+	_, err := p.ParseOneStmt("refresh materialized view mv fast", "", "")
+	require.Error(t, err)
+}
+
+func TestMaterializedViewRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{"CREATE MATERIALIZED VIEW `mv` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`", "CREATE MATERIALIZED VIEW `mv` AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`a`,`b`) AS SELECT `t1`.`a`,`t2`.`b` FROM `t1` JOIN `t2` ON `t1`.`id`=`t2`.`id`", "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`a`,`b`) AS SELECT `t1`.`a`,`t2`.`b` FROM `t1` JOIN `t2` ON `t1`.`id`=`t2`.`id`"},
+		{"REFRESH MATERIALIZED VIEW `mv`", "REFRESH MATERIALIZED VIEW `mv`"},
+		{"REFRESH MATERIALIZED VIEW `test`.`mv` COMPLETE", "REFRESH MATERIALIZED VIEW `test`.`mv` COMPLETE"},
+		{"REFRESH MATERIALIZED VIEW `mv` INCREMENTAL", "REFRESH MATERIALIZED VIEW `mv` INCREMENTAL"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
@@ -2289,6 +2289,7 @@ const (
 	AdminResetTelemetryID
 	AdminReloadStatistics
 	AdminFlushPlanCache
+	AdminAlterDDLJob
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2362,6 +2363,24 @@ func (n *ShowSlow) Restore(ctx *format.RestoreCtx) error {
 	return nil
 }
 
+// AlterJobOption is the option of `ADMIN ALTER DDL JOBS`, like `THREAD = 8`.
+type AlterJobOption struct {
+	// Name is the lower case name of the option.
+	Name string
+	// only literal is allowed, we use ExprNode to support negative number
+	Value ExprNode
+}
+
+// Restore implements Node interface.
+func (n *AlterJobOption) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord(n.Name)
+	ctx.WritePlain(" = ")
+	if err := n.Value.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore AlterJobOption")
+	}
+	return nil
+}
+
 // LimitSimple is the struct for Admin statement limit option.
 type LimitSimple struct {
 	Count  uint64
@@ -2384,6 +2403,8 @@ type AdminStmt struct {
 	Where          ExprNode
 	StatementScope StatementScope
 	LimitSimple    LimitSimple
+
+	AlterJobOptions []*AlterJobOption
 }
 
 // Restore implements Node interface.
@@ -2481,6 +2502,19 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 	case AdminResumeDDLJobs:
 		ctx.WriteKeyWord("RESUME DDL JOBS ")
 		restoreJobIDs()
+	case AdminAlterDDLJob:
+		ctx.WriteKeyWord("ALTER DDL JOBS ")
+		ctx.WritePlainf("%d", n.JobNumber)
+		for i, option := range n.AlterJobOptions {
+			if i == 0 {
+				ctx.WritePlain(" ")
+			} else {
+				ctx.WritePlain(", ")
+			}
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore AdminStmt.AlterJobOptions[%d]", i)
+			}
+		}
 	case AdminShowDDLJobQueries:
 		ctx.WriteKeyWord("SHOW DDL JOB QUERIES ")
 		restoreJobIDs()
@@ -2568,6 +2602,14 @@ func (n *AdminStmt) Accept(v Visitor) (Node, bool) {
 		n.Where = node.(ExprNode)
 	}
 
+	for _, option := range n.AlterJobOptions {
+		node, ok := option.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		option.Value = node.(ExprNode)
+	}
+
 	return v.Leave(n)
 }
 
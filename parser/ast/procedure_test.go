@@ -99,6 +99,8 @@ func TestProcedure(t *testing.T) {
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while; end`,
 		`create procedure proc_2() begin labelname: while id < 10 do set id = id + 1; select 1; end while labelname; end`,
 		`create procedure proc_2(id int) begin labelname: REPEAT set id = id + 1; select 1; UNTIL id < 10 end REPEAT labelname; end`,
+		`create procedure proc_2(id int) begin labelname: loop set id = id + 1; if id > 10 then leave labelname; end if; end loop labelname; end`,
+		`create procedure proc_2(id int) begin call proc_1(id); call test.proc_1(); call proc_3; end`,
 	}
 	for _, testcase := range testcases {
 		stmt, _, err := p.Parse(testcase, "", "")
@@ -213,6 +215,14 @@ func TestProcedureRestore(t *testing.T) {
 			"CREATE PROCEDURE `proc_2`() BEGIN `labelname`: WHILE `id`<10 DO SET @@SESSION.`id`=`id`+1;SELECT 1;END WHILE `labelname`; END",
 			"CREATE PROCEDURE `proc_2`() BEGIN `labelname`: WHILE `id`<10 DO SET @@SESSION.`id`=`id`+1;SELECT 1;END WHILE `labelname`; END",
 		},
+		{
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;ITERATE `labelname`;END LOOP `labelname`; END",
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: LOOP SET @@SESSION.`id`=`id`+1;ITERATE `labelname`;END LOOP `labelname`; END",
+		},
+		{
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN CALL `proc_1`(`id`);CALL `test`.`proc_1`(); END",
+			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN CALL `proc_1`(`id`);CALL `test`.`proc_1`(); END",
+		},
 		{
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
 			"CREATE PROCEDURE `proc_2`( IN `id` INT(11)) BEGIN `labelname`: REPEAT SET @@SESSION.`id`=`id`+1;SELECT 1;UNTIL `id`<10 END REPEAT `labelname`; END",
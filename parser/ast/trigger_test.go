@@ -0,0 +1,90 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerVisitorCover(t *testing.T) {
+	stmts := []ast.StmtNode{
+		&ast.CreateTriggerStmt{Table: &ast.TableName{}, Body: &ast.ProcedureBlock{}},
+		&ast.DropTriggerStmt{},
+	}
+	for _, v := range stmts {
+		v.Accept(visitor{})
+		v.Accept(visitor1{})
+	}
+}
+
+func TestCreateTrigger(t *testing.T) {
+	p := parser.New()
+	stmt, err := p.ParseOneStmt("create trigger if not exists test.trg before update on t for each row follows trg0 begin set new.a = old.a + 1; end", "", "")
+	require.NoError(t, err)
+	trigger := stmt.(*ast.CreateTriggerStmt)
+	require.True(t, trigger.IfNotExists)
+	require.Equal(t, "test", trigger.TriggerName.Schema.O)
+	require.Equal(t, "trg", trigger.TriggerName.Name.O)
+	require.Equal(t, model.TriggerTimingBefore, trigger.Timing)
+	require.Equal(t, model.TriggerEventUpdate, trigger.Event)
+	require.Equal(t, "t", trigger.Table.Name.O)
+	require.Equal(t, ast.TriggerOrderFollows, trigger.OrderType)
+	require.Equal(t, "trg0", trigger.OrderTrigger.O)
+	require.Equal(t, "begin set new.a = old.a + 1; end", trigger.Body.Text())
+	set := trigger.Body.(*ast.ProcedureBlock).ProcedureProcStmts[0].(*ast.SetStmt)
+	require.Equal(t, "new.a", set.Variables[0].Name)
+
+	_, err = p.ParseOneStmt("create trigger trg after insert on t for each row insert into log values (new.id)", "", "")
+	require.NoError(t, err)
+	_, err = p.ParseOneStmt("create trigger trg after insert on t insert into log values (new.id)", "", "")
+	require.Error(t, err)
+	_, err = p.ParseOneStmt("create trigger trg on t for each row insert into log values (new.id)", "", "")
+	require.Error(t, err)
+
+	stmt, err = p.ParseOneStmt("drop trigger if exists test.trg", "", "")
+	require.NoError(t, err)
+	drop := stmt.(*ast.DropTriggerStmt)
+	require.True(t, drop.IfExists)
+	require.Equal(t, "test", drop.TriggerName.Schema.O)
+	require.Equal(t, "trg", drop.TriggerName.Name.O)
+}
+
+func TestTriggerRestore(t *testing.T) {
+	testCases := []NodeRestoreTestCase{
+		{
+			"CREATE TRIGGER trg BEFORE INSERT ON t FOR EACH ROW INSERT INTO log VALUES (new.id)",
+			"CREATE TRIGGER `trg` BEFORE INSERT ON `t` FOR EACH ROW INSERT INTO `log` VALUES (`new`.`id`)",
+		},
+		{
+			"CREATE TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW FOLLOWS `trg0` BEGIN DELETE FROM `log` WHERE `id`=`old`.`id`; END",
+			"CREATE TRIGGER IF NOT EXISTS `test`.`trg` AFTER UPDATE ON `test`.`t` FOR EACH ROW FOLLOWS `trg0` BEGIN DELETE FROM `log` WHERE `id`=`old`.`id`; END",
+		},
+		{
+			"CREATE TRIGGER `trg` AFTER DELETE ON `t` FOR EACH ROW PRECEDES `trg0` IF `old`.`id`>1 THEN UPDATE `s` SET `c`=`c`-1;END IF",
+			"CREATE TRIGGER `trg` AFTER DELETE ON `t` FOR EACH ROW PRECEDES `trg0` IF `old`.`id`>1 THEN UPDATE `s` SET `c`=`c`-1;END IF",
+		},
+		{"DROP TRIGGER trg", "DROP TRIGGER `trg`"},
+		{"DROP TRIGGER IF EXISTS test.trg", "DROP TRIGGER IF EXISTS `test`.`trg`"},
+	}
+	extractNodeFunc := func(node ast.Node) ast.Node {
+		return node
+	}
+	runNodeRestoreTest(t, testCases, "%s", extractNodeFunc)
+}
@@ -15,8 +15,10 @@ package charset
 
 import (
 	"bytes"
+	"unicode/utf8"
 
 	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
 )
 
 // EncodingLatin1Impl is the instance of encodingLatin1.
@@ -58,3 +60,22 @@ func (*encodingLatin1) Tp() EncodingTp {
 func (*encodingLatin1) Transform(_ *bytes.Buffer, src []byte, _ Op) ([]byte, error) {
 	return src, nil
 }
+
+// DecodeLatin1 decodes the bytes stored in a latin1 column to utf-8.
+// TiDB stores latin1 values as they are, so the values written by utf-8 clients are kept.
+// The other values are written by latin1 clients, which are decoded as cp1252 like MySQL,
+// including the bytes undefined in cp1252.
+func DecodeLatin1(src []byte) []byte {
+	if utf8.Valid(src) {
+		return src
+	}
+	dst := make([]byte, 0, len(src)*2)
+	for _, b := range src {
+		r := charmap.Windows1252.DecodeByte(b)
+		if r == utf8.RuneError {
+			r = rune(b)
+		}
+		dst = utf8.AppendRune(dst, r)
+	}
+	return dst
+}
@@ -149,3 +149,20 @@ func TestEncodingValidate(t *testing.T) {
 		require.Equal(t, tc.expected, string(replace), msg)
 	}
 }
+
+func TestDecodeLatin1(t *testing.T) {
+	testCases := []struct {
+		src      []byte
+		expected string
+	}{
+		{[]byte(""), ""},
+		{[]byte("qwerty"), "qwerty"},
+		{[]byte("qwÊrty中文"), "qwÊrty中文"},
+		{[]byte{'c', 'a', 'f', 0xe9}, "café"},
+		{[]byte{0x80, 0x20, 0x41, 0xc0}, "€ AÀ"},
+		{[]byte{0xff, 0xfe, 0x81}, "ÿþ\u0081"},
+	}
+	for _, tc := range testCases {
+		require.Equal(t, tc.expected, string(charset.DecodeLatin1(tc.src)), fmt.Sprintf("%v", tc.src))
+	}
+}
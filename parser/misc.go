@@ -167,6 +167,7 @@ var tokenMap = map[string]int{
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
+	"AT":                       at,
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
@@ -185,6 +186,7 @@ var tokenMap = map[string]int{
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
 	"BEGIN":                    begin,
+	"BEFORE":                   before,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
 	"BIGINT":                   bigIntType,
@@ -249,6 +251,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETE":                 complete,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -328,6 +332,7 @@ var tokenMap = map[string]int{
 	"DRY":                      dry,
 	"DRYRUN":                   dryRun,
 	"DUAL":                     dual,
+	"EACH":                     each,
 	"DUMP":                     dump,
 	"DUPLICATE":                duplicate,
 	"DURATION":                 timeDuration,
@@ -339,10 +344,12 @@ var tokenMap = map[string]int{
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
 	"END":                      end,
+	"ENDS":                     ends,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
 	"ENGINE":                   engine,
 	"ENGINES":                  engines,
+	"EMPTY":                    emptyKwd,
 	"ENUM":                     enum,
 	"ERROR":                    errorKwd,
 	"ERRORS":                   identSQLErrors,
@@ -350,6 +357,7 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -379,6 +387,7 @@ var tokenMap = map[string]int{
 	"FOLLOWERS":                followers,
 	"FOLLOWER_CONSTRAINTS":     followerConstraints,
 	"FOLLOWING":                following,
+	"FOLLOWS":                  follows,
 	"FOR":                      forKwd,
 	"FORCE":                    force,
 	"FOREIGN":                  foreign,
@@ -392,6 +401,9 @@ var tokenMap = map[string]int{
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
+	"GEOMCOLLECTION":           geomCollection,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
@@ -443,6 +455,7 @@ var tokenMap = map[string]int{
 	"INVISIBLE":                invisible,
 	"INVOKER":                  invoker,
 	"ITERATE":                  iterate,
+	"JSON_TABLE":               jsonTable,
 	"IO":                       io,
 	"RU_PER_SEC":               ruRate,
 	"PRIORITY":                 priority,
@@ -470,6 +483,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -484,6 +498,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -494,10 +509,12 @@ var tokenMap = map[string]int{
 	"LOCKED":                   locked,
 	"LOGS":                     logs,
 	"LONG":                     long,
+	"LOOP":                     loop,
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
+	"MATERIALIZED":             materialized,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
@@ -527,10 +544,14 @@ var tokenMap = map[string]int{
 	"MODE":                     mode,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -572,6 +593,7 @@ var tokenMap = map[string]int{
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
+	"ORDINALITY":               ordinality,
 	"OUT":                      out,
 	"OUTER":                    outer,
 	"OUTFILE":                  outfile,
@@ -583,6 +605,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     path,
 	"PAUSE":                    pause,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
@@ -593,9 +616,11 @@ var tokenMap = map[string]int{
 	"PLAN_CACHE":               planCache,
 	"PLUGINS":                  plugins,
 	"POINT":                    point,
+	"POLYGON":                  polygon,
 	"POLICY":                   policy,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
+	"PRECEDES":                 precedes,
 	"PRECEDING":                preceding,
 	"PREDICATE":                predicate,
 	"PRECISION":                precisionType,
@@ -624,6 +649,7 @@ var tokenMap = map[string]int{
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
 	"RECOVER":                  recover,
+	"REFRESH":                  refresh,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
 	"REFERENCES":               references,
@@ -668,6 +694,7 @@ var tokenMap = map[string]int{
 	"RTREE":                    rtree,
 	"HYPO":                     hypo,
 	"RESUME":                   resume,
+	"RETURNING":                returning,
 	"RUN":                      run,
 	"RUNNING":                  running,
 	"S3":                       s3,
@@ -733,6 +760,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -907,6 +935,7 @@ var btFuncTokenMap = map[string]int{
 	"DATE_SUB":              builtinDateSub,
 	"EXTRACT":               builtinExtract,
 	"GROUP_CONCAT":          builtinGroupConcat,
+	"JSON_VALUE":            builtinJSONValue,
 	"MAX":                   builtinMax,
 	"MID":                   builtinSubstring,
 	"MIN":                   builtinMin,
@@ -102,6 +102,13 @@ const (
 	ActionCreateResourceGroup           ActionType = 68
 	ActionAlterResourceGroup            ActionType = 69
 	ActionDropResourceGroup             ActionType = 70
+	ActionCreateProcedure               ActionType = 71
+	ActionDropProcedure                 ActionType = 72
+	ActionCreateTrigger                 ActionType = 73
+	ActionDropTrigger                   ActionType = 74
+	ActionAlterTablePartitioning        ActionType = 75
+	ActionRemovePartitioning            ActionType = 76
+	ActionRebuildTable                  ActionType = 77
 )
 
 var actionMap = map[ActionType]string{
@@ -170,6 +177,13 @@ var actionMap = map[ActionType]string{
 	ActionCreateResourceGroup:           "create resource group",
 	ActionAlterResourceGroup:            "alter resource group",
 	ActionDropResourceGroup:             "drop resource group",
+	ActionCreateProcedure:               "create procedure",
+	ActionDropProcedure:                 "drop procedure",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionAlterTablePartitioning:        "alter table partition by",
+	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionRebuildTable:                  "rebuild table",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -797,9 +811,10 @@ func (job *Job) NotStarted() bool {
 // MayNeedReorg indicates that this job may need to reorganize the data.
 func (job *Job) MayNeedReorg() bool {
 	switch job.Type {
-	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition:
+	case ActionAddIndex, ActionAddPrimaryKey, ActionReorganizePartition,
+		ActionAlterTablePartitioning, ActionRemovePartitioning, ActionRebuildTable:
 		return true
-	case ActionModifyColumn:
+	case ActionModifyColumn, ActionAddColumn:
 		if len(job.CtxVars) > 0 {
 			needReorg, ok := job.CtxVars[0].(bool)
 			return ok && needReorg
@@ -834,6 +849,12 @@ func (job *Job) IsRollbackable() bool {
 		}
 	case ActionAddTablePartition:
 		return job.SchemaState == StateNone || job.SchemaState == StateReplicaOnly
+	case ActionAlterTablePartitioning, ActionRemovePartitioning:
+		// The new partitioning is public in StateDeleteReorganization.
+		return job.SchemaState != StateDeleteReorganization
+	case ActionRebuildTable:
+		// The rebuilt table is public in StateDeleteReorganization.
+		return job.SchemaState != StateDeleteReorganization
 	case ActionDropColumn, ActionDropSchema, ActionDropTable, ActionDropSequence,
 		ActionDropForeignKey, ActionDropTablePartition, ActionTruncateTablePartition:
 		return job.SchemaState == StatePublic
@@ -15,6 +15,7 @@ package model_test
 
 import (
 	"testing"
+	"time"
 	"unsafe"
 
 	"github.com/pingcap/tidb/parser/model"
@@ -102,3 +103,65 @@ func TestMayNeedReorg(t *testing.T) {
 		require.False(t, job.MayNeedReorg())
 	}
 }
+
+func TestDDLRunWindow(t *testing.T) {
+	loc := &model.TimeZoneLocation{Name: "UTC", Offset: 0}
+	day := func(hour, minute int) time.Time {
+		return time.Date(2023, 7, 1, hour, minute, 0, 0, time.UTC)
+	}
+
+	w, err := model.ParseDDLRunWindow("01:00-05:30", loc)
+	require.NoError(t, err)
+	require.Equal(t, "01:00-05:30", w.String())
+	for _, c := range []struct {
+		t  time.Time
+		in bool
+	}{
+		{day(0, 59), false},
+		{day(1, 0), true},
+		{day(5, 29), true},
+		{day(5, 30), false},
+		{day(23, 0), false},
+	} {
+		in, err := w.Contains(c.t)
+		require.NoError(t, err)
+		require.Equal(t, c.in, in, c.t.String())
+	}
+
+	// The window crosses midnight.
+	w, err = model.ParseDDLRunWindow("22:00-06:00", loc)
+	require.NoError(t, err)
+	for _, c := range []struct {
+		t  time.Time
+		in bool
+	}{
+		{day(21, 59), false},
+		{day(22, 0), true},
+		{day(0, 0), true},
+		{day(5, 59), true},
+		{day(6, 0), false},
+	} {
+		in, err := w.Contains(c.t)
+		require.NoError(t, err)
+		require.Equal(t, c.in, in, c.t.String())
+	}
+
+	// The times are in the time zone of the window.
+	w, err = model.ParseDDLRunWindow("01:00-05:00", &model.TimeZoneLocation{Name: "UTC+8", Offset: 8 * 3600})
+	require.NoError(t, err)
+	in, err := w.Contains(day(18, 0))
+	require.NoError(t, err)
+	require.True(t, in)
+	in, err = w.Contains(day(2, 0))
+	require.NoError(t, err)
+	require.False(t, in)
+
+	w, err = model.ParseDDLRunWindow("20:00-24:00", loc)
+	require.NoError(t, err)
+	require.Equal(t, "20:00-24:00", w.String())
+
+	for _, s := range []string{"", "01:00", "01:00-05:00x", "1-5", "25:00-05:00", "01:60-05:00", "24:01-05:00", "01:00-01:00", "-1:00-05:00"} {
+		_, err = model.ParseDDLRunWindow(s, loc)
+		require.Error(t, err, s)
+	}
+}
@@ -521,6 +521,26 @@ type TableInfo struct {
 	ExchangePartitionInfo *ExchangePartitionInfo `json:"exchange_partition_info"`
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
+
+	// Triggers are the triggers of the table, the ones with the same action time and event
+	// are fired in the order of the slice.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the result of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+
+	// Rebuild is set while the table is being rebuilt into a new physical table.
+	Rebuild *TableRebuildInfo `json:"rebuild,omitempty"`
+
+	// OnCommitPreserveRows is set for the global temporary tables created with ON COMMIT PRESERVE ROWS.
+	OnCommitPreserveRows bool `json:"on_commit_preserve_rows,omitempty"`
+}
+
+// HasSessionScopedRows returns whether the rows of the temporary table are kept in the session across
+// transactions, that is, the table is a local temporary table or a global temporary table created with
+// ON COMMIT PRESERVE ROWS.
+func (t *TableInfo) HasSessionScopedRows() bool {
+	return t.TempTableType == TempTableLocal || (t.TempTableType == TempTableGlobal && t.OnCommitPreserveRows)
 }
 
 // SepAutoInc decides whether _rowid and auto_increment id use separate allocator.
@@ -732,6 +752,18 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.Rebuild != nil {
+		nt.Rebuild = t.Rebuild.Clone()
+	}
 
 	return &nt
 }
@@ -994,6 +1026,11 @@ func (t *TableInfo) IsSequence() bool {
 	return t.Sequence != nil
 }
 
+// IsMaterializedView checks if TableInfo is a materialized view.
+func (t *TableInfo) IsMaterializedView() bool {
+	return t.MaterializedView != nil
+}
+
 // IsBaseTable checks to see the table is neither a view or a sequence.
 func (t *TableInfo) IsBaseTable() bool {
 	return t.Sequence == nil && t.View == nil
@@ -1116,6 +1153,9 @@ type PartitionType int
 
 // Partition types.
 const (
+	// PartitionTypeNone is used for a non-partitioned table that is handled
+	// as a single partition during ALTER TABLE PARTITION BY and REMOVE PARTITIONING.
+	PartitionTypeNone       PartitionType = 0
 	PartitionTypeRange      PartitionType = 1
 	PartitionTypeHash       PartitionType = 2
 	PartitionTypeList       PartitionType = 3
@@ -1135,6 +1175,8 @@ func (p PartitionType) String() string {
 		return "KEY"
 	case PartitionTypeSystemTime:
 		return "SYSTEM_TIME"
+	case PartitionTypeNone:
+		return "NONE"
 	default:
 		return ""
 	}
@@ -1147,6 +1189,36 @@ type ExchangePartitionInfo struct {
 	ExchangePartitionDefID int64 `json:"exchange_partition_def_id"`
 }
 
+// TableRebuildInfo provides the information of a table being rebuilt by ALTER TABLE ... FORCE
+// or by adding or dropping a clustered primary key.
+// The rows are copied to a new physical table, and the writes go to both tables until the rebuild is done.
+type TableRebuildInfo struct {
+	// Table is the other table of the rebuild. It is the new table before the switch to it,
+	// and the original table after the switch.
+	// The new table and its indexes are in StateWriteReorganization until the switch.
+	Table *TableInfo `json:"table"`
+	// OldTableID is the ID of the original table.
+	OldTableID int64 `json:"old_table_id"`
+	// MappingIndex is set when only one of the two tables has a clustered index.
+	// It maps the primary key of the clustered table to the _tidb_rowid of the other one,
+	// and it is stored in the key range of the original table.
+	MappingIndex *IndexInfo `json:"mapping_index"`
+	// DDLState is the state of the rebuild.
+	DDLState SchemaState `json:"ddl_state"`
+}
+
+// Clone clones TableRebuildInfo.
+func (ri *TableRebuildInfo) Clone() *TableRebuildInfo {
+	nri := *ri
+	if ri.Table != nil {
+		nri.Table = ri.Table.Clone()
+	}
+	if ri.MappingIndex != nil {
+		nri.MappingIndex = ri.MappingIndex.Clone()
+	}
+	return &nri
+}
+
 // PartitionInfo provides table partition info.
 type PartitionInfo struct {
 	Type    PartitionType `json:"type"`
@@ -1170,6 +1242,16 @@ type PartitionInfo struct {
 	Num    uint64           `json:"num"`
 	// Only used during ReorganizePartition so far
 	DDLState SchemaState `json:"ddl_state"`
+	// DDLType, DDLExpr and DDLColumns are only used during ALTER TABLE PARTITION BY
+	// and REMOVE PARTITIONING. They describe the partitioning of the partitions
+	// that are not in Definitions, i.e. the AddingDefinitions until
+	// StateDeleteReorganization and the DroppingDefinitions after it.
+	DDLType    PartitionType `json:"ddl_type,omitempty"`
+	DDLExpr    string        `json:"ddl_expr,omitempty"`
+	DDLColumns []CIStr       `json:"ddl_columns,omitempty"`
+	// NewTableID is the table ID the table gets when ALTER TABLE PARTITION BY
+	// or REMOVE PARTITIONING is done.
+	NewTableID int64 `json:"new_table_id,omitempty"`
 }
 
 // Clone clones itself.
@@ -1177,6 +1259,10 @@ func (pi *PartitionInfo) Clone() *PartitionInfo {
 	newPi := *pi
 	newPi.Columns = make([]CIStr, len(pi.Columns))
 	copy(newPi.Columns, pi.Columns)
+	if pi.DDLColumns != nil {
+		newPi.DDLColumns = make([]CIStr, len(pi.DDLColumns))
+		copy(newPi.DDLColumns, pi.DDLColumns)
+	}
 
 	newPi.Definitions = make([]PartitionDefinition, len(pi.Definitions))
 	for i := range pi.Definitions {
@@ -1390,6 +1476,8 @@ func (t IndexType) String() string {
 		return "RTREE"
 	case IndexTypeHypo:
 		return "HYPO"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
 	default:
 		return ""
 	}
@@ -1402,6 +1490,7 @@ const (
 	IndexTypeHash
 	IndexTypeRtree
 	IndexTypeHypo
+	IndexTypeFulltext
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -1421,6 +1510,11 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	// FullTextColumns are the text columns of a FULLTEXT index, whose only index column is a hidden column
+	// concatenating them.
+	FullTextColumns []CIStr `json:"fulltext_columns,omitempty"`
+	// FullTextParser is the parser of a FULLTEXT index, the empty string is the default parser.
+	FullTextParser string `json:"fulltext_parser,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -1433,9 +1527,18 @@ func (index *IndexInfo) Clone() *IndexInfo {
 	for i := range index.Columns {
 		ni.Columns[i] = index.Columns[i].Clone()
 	}
+	if index.FullTextColumns != nil {
+		ni.FullTextColumns = make([]CIStr, len(index.FullTextColumns))
+		copy(ni.FullTextColumns, index.FullTextColumns)
+	}
 	return &ni
 }
 
+// IsFullText returns whether the index is a FULLTEXT index.
+func (index *IndexInfo) IsFullText() bool {
+	return index.Tp == IndexTypeFulltext
+}
+
 // HasPrefixIndex returns whether any columns of this index uses prefix length.
 func (index *IndexInfo) HasPrefixIndex() bool {
 	for _, ic := range index.Columns {
@@ -1638,13 +1741,14 @@ func (fk *FKInfo) Clone() *FKInfo {
 
 // DBInfo provides meta data describing a DB.
 type DBInfo struct {
-	ID                 int64          `json:"id"`      // Database ID
-	Name               CIStr          `json:"db_name"` // DB name.
-	Charset            string         `json:"charset"`
-	Collate            string         `json:"collate"`
-	Tables             []*TableInfo   `json:"-"` // Tables in the DB.
-	State              SchemaState    `json:"state"`
-	PlacementPolicyRef *PolicyRefInfo `json:"policy_ref_info"`
+	ID                 int64            `json:"id"`      // Database ID
+	Name               CIStr            `json:"db_name"` // DB name.
+	Charset            string           `json:"charset"`
+	Collate            string           `json:"collate"`
+	Tables             []*TableInfo     `json:"-"` // Tables in the DB.
+	Procedures         []*ProcedureInfo `json:"-"` // Procedures in the DB.
+	State              SchemaState      `json:"state"`
+	PlacementPolicyRef *PolicyRefInfo   `json:"policy_ref_info"`
 }
 
 // Clone clones DBInfo.
@@ -1654,6 +1758,12 @@ func (db *DBInfo) Clone() *DBInfo {
 	for i := range db.Tables {
 		newInfo.Tables[i] = db.Tables[i].Clone()
 	}
+	if db.Procedures != nil {
+		newInfo.Procedures = make([]*ProcedureInfo, len(db.Procedures))
+		for i := range db.Procedures {
+			newInfo.Procedures[i] = db.Procedures[i].Clone()
+		}
+	}
 	return &newInfo
 }
 
@@ -1662,6 +1772,10 @@ func (db *DBInfo) Copy() *DBInfo {
 	newInfo := *db
 	newInfo.Tables = make([]*TableInfo, len(db.Tables))
 	copy(newInfo.Tables, db.Tables)
+	if db.Procedures != nil {
+		newInfo.Procedures = make([]*ProcedureInfo, len(db.Procedures))
+		copy(newInfo.Procedures, db.Procedures)
+	}
 	return &newInfo
 }
 
@@ -2056,6 +2170,153 @@ func (p *ResourceGroupInfo) Clone() *ResourceGroupInfo {
 	return &cloned
 }
 
+// ProcedureInfo is the struct to store the stored procedure.
+type ProcedureInfo struct {
+	ID                  int64              `json:"id"`
+	Name                CIStr              `json:"name"`
+	Definer             *auth.UserIdentity `json:"definer"`
+	SQLMode             string             `json:"sql_mode"`
+	CharacterSetClient  string             `json:"character_set_client"`
+	CollationConnection string             `json:"collation_connection"`
+	DBCollation         string             `json:"db_collation"`
+	// Definition is the original CREATE PROCEDURE statement, the body is parsed from it
+	// with SQLMode when the procedure is called.
+	Definition string      `json:"definition"`
+	Created    time.Time   `json:"created"`
+	State      SchemaState `json:"state"`
+}
+
+// Clone clones the ProcedureInfo.
+func (p *ProcedureInfo) Clone() *ProcedureInfo {
+	cloned := *p
+	if p.Definer != nil {
+		definer := *p.Definer
+		cloned.Definer = &definer
+	}
+	return &cloned
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming byte
+
+// List of trigger action times.
+const (
+	TriggerTimingBefore TriggerTiming = iota + 1
+	TriggerTimingAfter
+)
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerTimingBefore:
+		return "BEFORE"
+	case TriggerTimingAfter:
+		return "AFTER"
+	}
+	return ""
+}
+
+// TriggerEvent is the kind of the statement that activates a trigger.
+type TriggerEvent byte
+
+// List of trigger events.
+const (
+	TriggerEventInsert TriggerEvent = iota + 1
+	TriggerEventUpdate
+	TriggerEventDelete
+)
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerEventInsert:
+		return "INSERT"
+	case TriggerEventUpdate:
+		return "UPDATE"
+	case TriggerEventDelete:
+		return "DELETE"
+	}
+	return ""
+}
+
+// TriggerInfo is the struct to store the trigger of a table.
+type TriggerInfo struct {
+	ID                  int64              `json:"id"`
+	Name                CIStr              `json:"name"`
+	Timing              TriggerTiming      `json:"timing"`
+	Event               TriggerEvent       `json:"event"`
+	Definer             *auth.UserIdentity `json:"definer"`
+	SQLMode             string             `json:"sql_mode"`
+	CharacterSetClient  string             `json:"character_set_client"`
+	CollationConnection string             `json:"collation_connection"`
+	DBCollation         string             `json:"db_collation"`
+	// Definition is the original CREATE TRIGGER statement, the body is parsed from it
+	// with SQLMode when the trigger is fired.
+	Definition string `json:"definition"`
+	// Statement is the text of the trigger body.
+	Statement string      `json:"statement"`
+	Created   time.Time   `json:"created"`
+	State     SchemaState `json:"state"`
+}
+
+// Clone clones the TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	cloned := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		cloned.Definer = &definer
+	}
+	return &cloned
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view.
+type MaterializedViewInfo struct {
+	// Definition is the query of the view, the tables in it are qualified by the schema names.
+	Definition string `json:"definition"`
+	// Digest is the digest of the normalized query, the queries with the same digest can be answered by the view.
+	Digest string `json:"digest"`
+	// QueryColumns are the names of the result columns of the query, the columns of the view may be renamed.
+	QueryColumns []CIStr `json:"query_columns"`
+	// SQLMode is the sql_mode the query is executed with when the view is refreshed.
+	SQLMode string `json:"sql_mode"`
+	// Incremental indicates the view can be refreshed incrementally by the changes of the base tables.
+	Incremental bool `json:"incremental"`
+	// BaseTables are the tables the query reads.
+	BaseTables []*MaterializedViewBaseTable `json:"base_tables"`
+}
+
+// Clone clones the MaterializedViewInfo.
+func (mv *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	cloned := *mv
+	cloned.QueryColumns = append([]CIStr(nil), mv.QueryColumns...)
+	cloned.BaseTables = make([]*MaterializedViewBaseTable, len(mv.BaseTables))
+	for i, t := range mv.BaseTables {
+		cloned.BaseTables[i] = t.Clone()
+	}
+	return &cloned
+}
+
+// MaterializedViewBaseTable is a table read by the query of a materialized view.
+type MaterializedViewBaseTable struct {
+	ID     int64 `json:"id"`
+	Schema CIStr `json:"schema"`
+	Name   CIStr `json:"name"`
+	// Alias is the name the query refers to the table by, it's the table name if there is no alias.
+	Alias CIStr `json:"alias"`
+	// KeyColumns are the columns of the table which identify the rows of the view affected by a changed row, and
+	// ViewColumns are the columns of the view they are projected to. They're only set for an incremental view.
+	KeyColumns  []CIStr `json:"key_columns"`
+	ViewColumns []CIStr `json:"view_columns"`
+}
+
+// Clone clones the MaterializedViewBaseTable.
+func (t *MaterializedViewBaseTable) Clone() *MaterializedViewBaseTable {
+	cloned := *t
+	cloned.KeyColumns = append([]CIStr(nil), t.KeyColumns...)
+	cloned.ViewColumns = append([]CIStr(nil), t.ViewColumns...)
+	return &cloned
+}
+
 // StatsOptions is the struct to store the stats options.
 type StatsOptions struct {
 	*StatsWindowSettings
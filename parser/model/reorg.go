@@ -15,6 +15,8 @@ package model
 
 import (
 	"encoding/json"
+	"fmt"
+	"time"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -29,6 +31,69 @@ type DDLReorgMeta struct {
 	Location      *TimeZoneLocation                `json:"location"`
 	ReorgTp       ReorgType                        `json:"reorg_tp"`
 	IsDistReorg   bool                             `json:"is_dist_reorg"`
+
+	// The following parameters are set by `ADMIN ALTER DDL JOBS` and take effect on the running job.
+	// Concurrency and BatchSize override the global variables tidb_ddl_reorg_worker_cnt and
+	// tidb_ddl_reorg_batch_size if they are not 0.
+	Concurrency int `json:"concurrency"`
+	BatchSize   int `json:"batch_size"`
+	// MaxWriteSpeed limits the bytes written per second by the data reorganization, 0 means no limit.
+	MaxWriteSpeed int64 `json:"max_write_speed"`
+	// RunWindow is the daily time window in which the data reorganization runs, nil means all day.
+	RunWindow *DDLRunWindow `json:"run_window"`
+}
+
+// DDLRunWindow is a daily time window like "01:00-05:00".
+// The window crosses midnight if End is less than Start, like "22:00-06:00".
+type DDLRunWindow struct {
+	// Start and End are the minutes after midnight.
+	Start    int               `json:"start"`
+	End      int               `json:"end"`
+	Location *TimeZoneLocation `json:"location"`
+}
+
+// ParseDDLRunWindow parses the time window in the format "HH:MM-HH:MM", the times are in the time zone loc.
+func ParseDDLRunWindow(s string, loc *TimeZoneLocation) (*DDLRunWindow, error) {
+	var startHour, startMinute, endHour, endMinute int
+	var rest string
+	n, _ := fmt.Sscanf(s, "%d:%d-%d:%d%s", &startHour, &startMinute, &endHour, &endMinute, &rest)
+	if n != 4 || !isValidClock(startHour, startMinute) || !isValidClock(endHour, endMinute) {
+		return nil, errors.Errorf("invalid time window '%s', the format should be 'HH:MM-HH:MM'", s)
+	}
+	w := &DDLRunWindow{
+		Start:    startHour*60 + startMinute,
+		End:      endHour*60 + endMinute,
+		Location: loc,
+	}
+	if w.Start == w.End {
+		return nil, errors.Errorf("invalid time window '%s', the start and end time should be different", s)
+	}
+	return w, nil
+}
+
+func isValidClock(hour, minute int) bool {
+	return hour >= 0 && hour <= 24 && minute >= 0 && minute < 60 && (hour < 24 || minute == 0)
+}
+
+// Contains checks whether t is in the time window.
+func (w *DDLRunWindow) Contains(t time.Time) (bool, error) {
+	if w.Location != nil {
+		loc, err := w.Location.GetLocation()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		t = t.In(loc)
+	}
+	minutes := t.Hour()*60 + t.Minute()
+	if w.Start < w.End {
+		return minutes >= w.Start && minutes < w.End, nil
+	}
+	return minutes >= w.Start || minutes < w.End, nil
+}
+
+// String implements fmt.Stringer interface.
+func (w *DDLRunWindow) String() string {
+	return fmt.Sprintf("%02d:%02d-%02d:%02d", w.Start/60, w.Start%60, w.End/60, w.End%60)
 }
 
 // ReorgType indicates which process is used for the data reorganization.
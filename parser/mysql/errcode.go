@@ -884,6 +884,7 @@ const (
 	ErrErrorLast                                             = 1863
 	ErrInvalidFieldSize                                      = 3013
 	ErrPasswordExpireAnonymousUser                           = 3016
+	ErrReferencedTrgDoesNotExist                             = 3022
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrIncorrectType                                         = 3064
 	ErrInvalidJSONData                                       = 3069
@@ -900,6 +900,7 @@ var MySQLErrName = map[uint16]*ErrMessage{
 	ErrGeneratedColumnRefAutoInc:                             Message("Generated column '%s' cannot refer to auto-increment column.", nil),
 	ErrInvalidFieldSize:                                      Message("Invalid size for column '%s'.", nil),
 	ErrPasswordExpireAnonymousUser:                           Message("The password for anonymous user cannot be expired.", nil),
+	ErrReferencedTrgDoesNotExist:                             Message("Referenced trigger '%s' for the given action time and event type does not exist.", nil),
 	ErrIncorrectType:                                         Message("Incorrect type for argument %s in function %s.", nil),
 	ErrInvalidJSONData:                                       Message("Invalid JSON data provided to function %s: %s", nil),
 	ErrInvalidJSONText:                                       Message("Invalid JSON text: %-.192s", nil),
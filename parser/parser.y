@@ -84,6 +84,7 @@ import (
 	array             "ARRAY"
 	as                "AS"
 	asc               "ASC"
+	before            "BEFORE"
 	between           "BETWEEN"
 	bigIntType        "BIGINT"
 	binaryType        "BINARY"
@@ -130,6 +131,7 @@ import (
 	doubleType        "DOUBLE"
 	drop              "DROP"
 	dual              "DUAL"
+	each              "EACH"
 	elseIfKwd         "ELSEIF"
 	elseKwd           "ELSE"
 	enclosed          "ENCLOSED"
@@ -177,12 +179,14 @@ import (
 	int4Type          "INT4"
 	int8Type          "INT8"
 	iterate           "ITERATE"
+	jsonTable         "JSON_TABLE"
 	join              "JOIN"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -196,6 +200,7 @@ import (
 	localTime         "LOCALTIME"
 	localTs           "LOCALTIMESTAMP"
 	lock              "LOCK"
+	loop              "LOOP"
 	longblobType      "LONGBLOB"
 	longtextType      "LONGTEXT"
 	lowPriority       "LOW_PRIORITY"
@@ -318,6 +323,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	statsOptions          "STATS_OPTIONS"
@@ -367,6 +373,8 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	concurrency           "CONCURRENCY"
@@ -408,14 +416,17 @@ import (
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
 	end                   "END"
+	ends                  "ENDS"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
 	engines               "ENGINES"
 	enum                  "ENUM"
+	emptyKwd              "EMPTY"
 	errorKwd              "ERROR"
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
 	exclusive             "EXCLUSIVE"
@@ -430,11 +441,15 @@ import (
 	fixed                 "FIXED"
 	flush                 "FLUSH"
 	found                 "FOUND"
+	follows               "FOLLOWS"
 	following             "FOLLOWING"
 	format                "FORMAT"
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollection        "GEOMCOLLECTION"
+	geometryType          "GEOMETRY"
+	geometryCollection    "GEOMETRYCOLLECTION"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -468,12 +483,14 @@ import (
 	lastval               "LASTVAL"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	local                 "LOCAL"
 	locked                "LOCKED"
 	location              "LOCATION"
 	logs                  "LOGS"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
@@ -492,9 +509,13 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -519,6 +540,7 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -527,14 +549,17 @@ import (
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
 	pause                 "PAUSE"
+	path                  "PATH"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
 	pipesAsOr
 	plugins               "PLUGINS"
 	point                 "POINT"
+	polygon               "POLYGON"
 	policy                "POLICY"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
+	precedes              "PRECEDES"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
 	preserve              "PRESERVE"
@@ -552,6 +577,7 @@ import (
 	rateLimit             "RATE_LIMIT"
 	rebuild               "REBUILD"
 	recover               "RECOVER"
+	refresh               "REFRESH"
 	redundant             "REDUNDANT"
 	reload                "RELOAD"
 	remove                "REMOVE"
@@ -568,6 +594,7 @@ import (
 	restore               "RESTORE"
 	restores              "RESTORES"
 	resume                "RESUME"
+	returning             "RETURNING"
 	reuse                 "REUSE"
 	reverse               "REVERSE"
 	role                  "ROLE"
@@ -617,6 +644,7 @@ import (
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsPersistent       "STATS_PERSISTENT"
 	statsSamplePages      "STATS_SAMPLE_PAGES"
@@ -849,6 +877,7 @@ import (
 	builtinDateSub
 	builtinExtract
 	builtinGroupConcat
+	builtinJSONValue
 	builtinMax
 	builtinMin
 	builtinNow
@@ -931,6 +960,7 @@ import (
 %type	<statement>
 	AdminStmt                  "Check table statement or show ddl statement"
 	AlterDatabaseStmt          "Alter database statement"
+	AlterEventStmt             "ALTER EVENT statement"
 	AlterTableStmt             "Alter table statement"
 	AlterUserStmt              "Alter user statement"
 	AlterInstanceStmt          "Alter instance statement"
@@ -945,9 +975,11 @@ import (
 	CommitStmt                 "COMMIT statement"
 	CreateTableStmt            "CREATE TABLE statement"
 	CreateViewStmt             "CREATE VIEW  statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	CreateUserStmt             "CREATE User statement"
 	CreateRoleStmt             "CREATE Role statement"
 	CreateDatabaseStmt         "Create Database Statement"
+	CreateEventStmt            "CREATE EVENT statement"
 	CreateIndexStmt            "CREATE INDEX statement"
 	CreateBindingStmt          "CREATE BINDING  statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
@@ -955,14 +987,17 @@ import (
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
 	CreateStatisticsStmt       "CREATE STATISTICS statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
 	DoStmt                     "Do statement"
 	DropDatabaseStmt           "DROP DATABASE statement"
+	DropEventStmt              "DROP EVENT statement"
 	DropIndexStmt              "DROP INDEX statement"
 	DropProcedureStmt          "DROP PROCEDURE statement"
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
 	DropStatsStmt              "DROP STATS statement"
 	DropTableStmt              "DROP TABLE statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
 	DropSequenceStmt           "DROP SEQUENCE statement"
 	DropUserStmt               "DROP USER"
 	DropRoleStmt               "DROP ROLE"
@@ -987,6 +1022,7 @@ import (
 	InsertIntoStmt             "INSERT INTO statement"
 	CallStmt                   "CALL statement"
 	IndexAdviseStmt            "INDEX ADVISE statement"
+	AdviseIndexesStmt          "ADVISE INDEXES statement"
 	ImportIntoStmt             "IMPORT INTO statement"
 	KillStmt                   "Kill statement"
 	LoadDataStmt               "Load data statement"
@@ -1005,6 +1041,7 @@ import (
 	RenameUserStmt             "rename user statement"
 	ReplaceIntoStmt            "REPLACE INTO statement"
 	RecoverTableStmt           "recover table statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
 	RevokeStmt                 "Revoke statement"
 	RevokeRoleStmt             "Revoke role statement"
 	RollbackStmt               "ROLLBACK statement"
@@ -1066,6 +1103,8 @@ import (
 	AdminStmtLimitOpt                      "Admin show ddl jobs limit option"
 	AllOrPartitionNameList                 "All or partition name list"
 	AlgorithmClause                        "Alter table algorithm"
+	AlterJobOption                         "Alter DDL job option"
+	AlterJobOptionList                     "Alter DDL job option list"
 	AlterTableSpecSingleOpt                "Alter table single option"
 	AlterTableSpec                         "Alter table specification"
 	AlterTableSpecList                     "Alter table specification list"
@@ -1180,6 +1219,13 @@ import (
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
+	JSONTable                              "JSON_TABLE table function"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnsClause                 "JSON_TABLE COLUMNS clause"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE column ON EMPTY and ON ERROR clauses optional"
+	JSONTableOnResponse                    "JSON_TABLE column NULL, ERROR or DEFAULT response"
+	JSONValueReturningOpt                  "JSON_VALUE RETURNING clause optional"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1359,6 +1405,7 @@ import (
 	ViewDefiner                            "view definer"
 	ViewName                               "view name"
 	ViewFieldList                          "create view statement field list"
+	RefreshMaterializedViewModeOpt         "Optional refresh mode of REFRESH MATERIALIZED VIEW"
 	ViewSQLSecurity                        "view sql security"
 	WhereClause                            "WHERE clause"
 	WhereClauseOptional                    "Optional WHERE clause"
@@ -1414,6 +1461,7 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1471,6 +1519,18 @@ import (
 	OptionalShardColumn                    "Optional shard column"
 	SpOptInout                             "Optional procedure param type"
 	OptSpPdparams                          "Optional procedure param list"
+	AlterEventScheduleOpt                  "Optional ON SCHEDULE and ON COMPLETION clauses of ALTER EVENT"
+	EventBodyOpt                           "Optional event body"
+	EventCommentOpt                        "Optional event comment"
+	EventCompletion                        "Event completion"
+	EventCompletionOpt                     "Optional ON COMPLETION clause"
+	EventEndsOpt                           "Optional ENDS clause"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Optional STARTS clause"
+	EventStatusOpt                         "Optional ENABLE or DISABLE clause"
+	TriggerEvent                           "Trigger event"
+	TriggerOrder                           "Trigger order clause"
+	TriggerTiming                          "Trigger action time"
 	SpPdparams                             "Procedure params"
 	SpPdparam                              "Procedure param"
 	ProcedureOptDefault                    "Optional procedure variable default value"
@@ -2567,7 +2627,6 @@ AlterTableSpec:
 	}
 |	"FORCE"
 	{
-		// Parse it and ignore it. Just for compatibility.
 		$$ = &ast.AlterTableSpec{
 			Tp: ast.AlterTableForce,
 		}
@@ -3680,6 +3739,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4842,6 +4914,60 @@ CreateViewStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *      CREATE MATERIALIZED VIEW IF NOT EXISTS mv (a, cnt) AS SELECT a, COUNT(*) FROM t GROUP BY a
+ *******************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList "AS" CreateViewSelectOpt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $8.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
+		x := &ast.CreateMaterializedViewStmt{
+			IfNotExists: $4.(bool),
+			ViewName:    $5.(*ast.TableName),
+			Select:      selStmt,
+		}
+		if $6 != nil {
+			x.Cols = $6.([]model.CIStr)
+		}
+		$$ = x
+	}
+
+/*******************************************************************
+ *
+ *  Refresh Materialized View Statement
+ *
+ *  Example:
+ *      REFRESH MATERIALIZED VIEW mv INCREMENTAL
+ *******************************************************************/
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName RefreshMaterializedViewModeOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Mode:     $5.(ast.RefreshMaterializedViewMode),
+		}
+	}
+
+RefreshMaterializedViewModeOpt:
+	/* empty */
+	{
+		$$ = ast.RefreshMaterializedViewDefault
+	}
+|	"COMPLETE"
+	{
+		$$ = ast.RefreshMaterializedViewComplete
+	}
+|	"INCREMENTAL"
+	{
+		$$ = ast.RefreshMaterializedViewIncremental
+	}
+
 OrReplace:
 	/* EMPTY */
 	{
@@ -5103,6 +5229,10 @@ DropViewStmt:
 	{
 		$$ = &ast.DropTableStmt{IfExists: true, Tables: $5.([]*ast.TableName), IsView: true}
 	}
+|	"DROP" "MATERIALIZED" "VIEW" IfExists TableNameList
+	{
+		$$ = &ast.DropTableStmt{IfExists: $4.(bool), Tables: $5.([]*ast.TableName), IsMaterializedView: true}
+	}
 
 DropUserStmt:
 	"DROP" "USER" UsernameList
@@ -6457,6 +6587,7 @@ UnReservedKeyword:
 	"ACTION"
 |	"ADVISE"
 |	"ASCII"
+|	"AT"
 |	"ATTRIBUTE"
 |	"ATTRIBUTES"
 |	"BINDING_CACHE"
@@ -6486,6 +6617,8 @@ UnReservedKeyword:
 |	"SAN"
 |	"COMMIT"
 |	"COMPACT"
+|	"COMPLETE"
+|	"COMPLETION"
 |	"COMPRESSED"
 |	"CONSISTENCY"
 |	"CONSISTENT"
@@ -6500,14 +6633,17 @@ UnReservedKeyword:
 |	"DYNAMIC"
 |	"ENCRYPTION"
 |	"END"
+|	"ENDS"
 |	"ENFORCED"
 |	"ENGINE"
 |	"ENGINES"
 |	"ENUM"
+|	"EMPTY"
 |	"ERROR"
 |	"ERRORS"
 |	"ESCAPE"
 |	"EVOLVE"
+|	"EVERY"
 |	"EXECUTE"
 |	"EXTENDED"
 |	"FIELDS"
@@ -6515,6 +6651,7 @@ UnReservedKeyword:
 |	"FIRST"
 |	"FIXED"
 |	"FLUSH"
+|	"FOLLOWS"
 |	"FOLLOWING"
 |	"FORMAT"
 |	"FULL"
@@ -6539,6 +6676,7 @@ UnReservedKeyword:
 |	"QUICK"
 |	"REBUILD"
 |	"REDUNDANT"
+|	"REFRESH"
 |	"REORGANIZE"
 |	"RESOURCE"
 |	"RESTART"
@@ -6551,9 +6689,18 @@ UnReservedKeyword:
 |	"SHUTDOWN"
 |	"SNAPSHOT"
 |	"START"
+|	"STARTS"
 |	"STATUS"
 |	"OPEN"
 |	"POINT"
+|	"GEOMETRY"
+|	"GEOMCOLLECTION"
+|	"GEOMETRYCOLLECTION"
+|	"LINESTRING"
+|	"MULTILINESTRING"
+|	"MULTIPOINT"
+|	"MULTIPOLYGON"
+|	"POLYGON"
 |	"SUBPARTITIONS"
 |	"SUBPARTITION"
 |	"TABLES"
@@ -6585,10 +6732,12 @@ UnReservedKeyword:
 |	"COMPRESSION"
 |	"KEY_BLOCK_SIZE"
 |	"MASTER"
+|	"MATERIALIZED"
 |	"MAX_ROWS"
 |	"MIN_ROWS"
 |	"NATIONAL"
 |	"NCHAR"
+|	"NESTED"
 |	"ROW_FORMAT"
 |	"QUARTER"
 |	"GRANTS"
@@ -6639,6 +6788,7 @@ UnReservedKeyword:
 |	"MICROSECOND"
 |	"MINUTE"
 |	"PLUGINS"
+|	"PRECEDES"
 |	"PRECEDING"
 |	"QUERY"
 |	"QUERIES"
@@ -6791,8 +6941,11 @@ UnReservedKeyword:
 |	"PERCENT"
 |	"PAUSE"
 |	"RESUME"
+|	"RETURNING"
 |	"OFF"
 |	"OPTIONAL"
+|	"ORDINALITY"
+|	"PATH"
 |	"REQUIRED"
 |	"PURGE"
 |	"SKIP"
@@ -7767,6 +7920,13 @@ FunctionNameConflict:
 |	"MONTH"
 |	builtinNow
 |	"POINT"
+|	"GEOMCOLLECTION"
+|	"GEOMETRYCOLLECTION"
+|	"LINESTRING"
+|	"MULTILINESTRING"
+|	"MULTIPOINT"
+|	"MULTIPOLYGON"
+|	"POLYGON"
 |	"QUARTER"
 |	"REPEAT"
 |	"REPLACE"
@@ -8038,6 +8198,23 @@ FunctionCallNonKeyword:
 			Args:   []ast.ExprNode{$3, $5, $7},
 		}
 	}
+|	builtinJSONValue '(' Expression ',' stringLit JSONValueReturningOpt JSONTableOnEmptyOnErrorOpt ')'
+	{
+		/* See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value */
+		responses := $7.([]*ast.JSONTableOnResponse)
+		expr := &ast.JSONValueExpr{
+			Expr:    $3,
+			Path:    $5,
+			OnEmpty: responses[0],
+			OnError: responses[1],
+		}
+		if $6 != nil {
+			expr.Tp = $6.(*types.FieldType)
+			expr.ExplicitCharSet = parser.explicitCharset
+			parser.explicitCharset = false
+		}
+		$$ = expr
+	}
 
 GetFormatSelector:
 	"DATE"
@@ -9543,12 +9720,118 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsName
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	JSONTable TableAsName
+	{
+		$$ = &ast.TableSource{Source: $1.(*ast.JSONTable), AsName: $2.(model.CIStr)}
+	}
+
+JSONTable:
+	"JSON_TABLE" '(' Expression ',' stringLit JSONTableColumnsClause ')'
+	{
+		$$ = &ast.JSONTable{Expr: $3, Path: $5, Columns: $6.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableColumnsClause:
+	"COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = $3
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		responses := $5.([]*ast.JSONTableOnResponse)
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnPath,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $4,
+			OnEmpty:   responses[0],
+			OnError:   responses[1],
+		}
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{
+			Tp:        ast.JSONTableColumnExists,
+			Name:      model.NewCIStr($1),
+			FieldType: $2.(*types.FieldType),
+			Path:      $5,
+		}
+	}
+|	"NESTED" "PATH" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $4.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $3.([]*ast.JSONTableColumn)}
+	}
+
+JSONValueReturningOpt:
+	{
+		$$ = nil
+	}
+|	"RETURNING" CastType
+	{
+		$$ = $2
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, nil}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -10895,6 +11178,14 @@ AdminStmt:
 			JobIDs: $5.([]int64),
 		}
 	}
+|	"ADMIN" "ALTER" "DDL" "JOBS" Int64Num AlterJobOptionList
+	{
+		$$ = &ast.AdminStmt{
+			Tp:              ast.AdminAlterDDLJob,
+			JobNumber:       $5.(int64),
+			AlterJobOptions: $6.([]*ast.AlterJobOption),
+		}
+	}
 |	"ADMIN" "PAUSE" "DDL" "JOBS" NumList
 	{
 		$$ = &ast.AdminStmt{
@@ -11086,6 +11377,25 @@ NumList:
 		$$ = append($1.([]int64), $3.(int64))
 	}
 
+AlterJobOptionList:
+	AlterJobOption
+	{
+		$$ = []*ast.AlterJobOption{$1.(*ast.AlterJobOption)}
+	}
+|	AlterJobOptionList ',' AlterJobOption
+	{
+		$$ = append($1.([]*ast.AlterJobOption), $3.(*ast.AlterJobOption))
+	}
+
+AlterJobOption:
+	identifier "=" SignedLiteral
+	{
+		$$ = &ast.AlterJobOption{
+			Name:  strings.ToLower($1),
+			Value: $3.(ast.ExprNode),
+		}
+	}
+
 /****************************Show Statement*******************************/
 ShowStmt:
 	"SHOW" ShowTargetFilterable ShowLikeOrWhereOpt
@@ -11822,6 +12132,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterDatabaseStmt
+|	AlterEventStmt
 |	AlterTableStmt
 |	AlterUserStmt
 |	AlterInstanceStmt
@@ -11840,9 +12151,11 @@ Statement:
 |	CalibrateResourceStmt
 |	ChangeStmt
 |	CreateDatabaseStmt
+|	CreateEventStmt
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateMaterializedViewStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
@@ -11851,11 +12164,14 @@ Statement:
 |	CreateResourceGroupStmt
 |	CreateSequenceStmt
 |	CreateStatisticsStmt
+|	CreateTriggerStmt
 |	DoStmt
 |	DropDatabaseStmt
+|	DropEventStmt
 |	DropIndexStmt
 |	DropTableStmt
 |	DropProcedureStmt
+|	DropTriggerStmt
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
@@ -11876,6 +12192,7 @@ Statement:
 |	ImportIntoStmt
 |	InsertIntoStmt
 |	IndexAdviseStmt
+|	AdviseIndexesStmt
 |	KillStmt
 |	LoadDataStmt
 |	LoadStatsStmt
@@ -11888,6 +12205,7 @@ Statement:
 |	RenameUserStmt
 |	ReplaceIntoStmt
 |	RecoverTableStmt
+|	RefreshMaterializedViewStmt
 |	ReleaseSavepointStmt
 |	RevokeStmt
 |	RevokeRoleStmt
@@ -12391,6 +12709,53 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(types.GeometryType))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		tp.AddFlag(mysql.BinaryFlag)
+		$$ = tp
+	}
+
+SpatialType:
+	"GEOMETRY"
+	{
+		$$ = types.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = types.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = types.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = types.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = types.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = types.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = types.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
+|	"GEOMCOLLECTION"
+	{
+		$$ = types.GeometryTypeGeometryCollection
+	}
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -14886,6 +15251,33 @@ IndexAdviseStmt:
 		$$ = x
 	}
 
+/*******************************************************************
+ *
+ *  Advise Indexes Statement
+ *
+ *  Example:
+ *	ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_TABLE 3
+ *	ADVISE INDEXES FOR 'select * from t where a = 1', 'select * from t where b > 1'
+ *
+ *******************************************************************/
+AdviseIndexesStmt:
+	"ADVISE" "INDEXES" "FOR" "WORKLOAD" MaxIndexNumOpt
+	{
+		x := &ast.AdviseIndexesStmt{}
+		if $5 != nil {
+			x.MaxIndexNum = $5.(*ast.MaxIndexNumClause)
+		}
+		$$ = x
+	}
+|	"ADVISE" "INDEXES" "FOR" StringList MaxIndexNumOpt
+	{
+		x := &ast.AdviseIndexesStmt{Queries: $4.([]string)}
+		if $5 != nil {
+			x.MaxIndexNum = $5.(*ast.MaxIndexNumClause)
+		}
+		$$ = x
+	}
+
 MaxMinutesOpt:
 	{
 		$$ = uint64(ast.UnspecifiedSize)
@@ -15219,6 +15611,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -15609,6 +16002,12 @@ ProcedureUnlabelLoopStmt:
 			Condition: $4.(ast.ExprNode),
 		}
 	}
+|	"LOOP" ProcedureProcStmt1s "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Body: $2.([]ast.StmtNode),
+		}
+	}
 
 ProcedureLabeledBlock:
 	identifier ':' ProcedureBlockContent ProcedurceLabelOpt
@@ -15731,6 +16130,275 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *	CREATE
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  [trigger_order]
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ *  trigger_order: { FOLLOWS | PRECEDES } other_trigger_name
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrder ProcedureProcStmt
+	{
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+			Timing:      $5.(model.TriggerTiming),
+			Event:       $6.(model.TriggerEvent),
+			Table:       $8.(*ast.TableName),
+			Body:        $13,
+		}
+		if $12 != nil {
+			order := $12.([]interface{})
+			x.OrderType = order[0].(ast.TriggerOrderType)
+			x.OrderTrigger = model.NewCIStr(order[1].(string))
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		$13.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerTimingBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerTimingAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerEventInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerEventUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerEventDelete
+	}
+
+TriggerOrder:
+	{
+		$$ = nil
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = []interface{}{ast.TriggerOrderFollows, $2}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = []interface{}{ast.TriggerOrderPrecedes, $2}
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *	CREATE
+ *  EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: { AT timestamp | EVERY interval [STARTS timestamp] [ENDS timestamp] }
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		x := &ast.CreateEventStmt{
+			IfNotExists: $3.(bool),
+			EventName:   $4.(*ast.TableName),
+			Schedule:    $7.(*ast.EventSchedule),
+			Completion:  $8.(ast.EventCompletionType),
+			Status:      $9.(ast.EventStatusType),
+			Body:        $12,
+		}
+		if $10 != nil {
+			comment := $10.(string)
+			x.Comment = &comment
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		$12.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionNone
+	}
+|	"ON" "COMPLETION" EventCompletion
+	{
+		$$ = $3
+	}
+
+EventCompletion:
+	"PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusNone
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+ *
+ *  Alter Event Statement
+ *
+ *  Example:
+ *	ALTER
+ *  EVENT event_name
+ *  [ON SCHEDULE schedule]
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE]
+ *  [COMMENT 'string']
+ *  [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	"ALTER" "EVENT" TableName AlterEventScheduleOpt EventStatusOpt EventCommentOpt EventBodyOpt
+	{
+		x := &ast.AlterEventStmt{
+			EventName: $3.(*ast.TableName),
+			Status:    $5.(ast.EventStatusType),
+		}
+		opts := $4.([]interface{})
+		if opts[0] != nil {
+			x.Schedule = opts[0].(*ast.EventSchedule)
+		}
+		x.Completion = opts[1].(ast.EventCompletionType)
+		if $6 != nil {
+			comment := $6.(string)
+			x.Comment = &comment
+		}
+		if $7 != nil {
+			x.Body = $7.(ast.StmtNode)
+		}
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	{
+		$$ = []interface{}{nil, ast.EventCompletionNone}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = []interface{}{$3, $4}
+	}
+|	"ON" "COMPLETION" EventCompletion
+	{
+		$$ = []interface{}{nil, $3}
+	}
+
+EventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		startOffset := parser.startOffset(&yyS[yypt])
+		$2.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = $2
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] [schema_name.]event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -37,12 +37,12 @@ func TestSimple(t *testing.T) {
 	p := parser.New()
 
 	reservedKws := []string{
-		"add", "all", "alter", "analyze", "and", "as", "asc", "between", "bigint",
+		"add", "all", "alter", "analyze", "and", "as", "asc", "before", "between", "bigint",
 		"binary", "blob", "both", "by", "call", "cascade", "case", "change", "character", "check", "collate",
 		"column", "constraint", "convert", "create", "cross", "current_date", "current_time",
 		"current_timestamp", "current_user", "database", "databases", "day_hour", "day_microsecond",
 		"day_minute", "day_second", "decimal", "default", "delete", "desc", "describe",
-		"distinct", "distinctRow", "div", "double", "drop", "dual", "else", "enclosed", "escaped",
+		"distinct", "distinctRow", "div", "double", "drop", "dual", "each", "else", "enclosed", "escaped",
 		"exists", "explain", "false", "float", "fetch", "for", "force", "foreign", "from",
 		"fulltext", "grant", "group", "having", "hour_microsecond", "hour_minute",
 		"hour_second", "if", "ignore", "in", "index", "infile", "inner", "insert", "int", "into", "integer",
@@ -96,7 +96,7 @@ func TestSimple(t *testing.T) {
 		"ln", "log", "log2", "log10", "timestampdiff", "pi", "proxy", "quote", "none", "super", "shared", "exclusive",
 		"always", "stats", "stats_meta", "stats_histogram", "stats_buckets", "stats_healthy", "tidb_version", "replication", "slave", "client",
 		"max_connections_per_hour", "max_queries_per_hour", "max_updates_per_hour", "max_user_connections", "event", "reload", "routine", "temporary",
-		"following", "preceding", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
+		"following", "follows", "preceding", "precedes", "at", "complete", "completion", "ends", "materialized", "refresh", "every", "starts", "unbounded", "respect", "nulls", "current", "last", "against", "expansion",
 		"chain", "error", "general", "nvarchar", "pack_keys", "p", "shard_row_id_bits", "pre_split_regions",
 		"constraints", "role", "replicas", "policy", "s3", "strict", "running", "stop", "preserve", "placement", "attributes", "attribute", "resource",
 		"burstable", "calibrate", "rollup",
@@ -844,6 +844,13 @@ func TestDMLStmt(t *testing.T) {
 		{"admin resume ddl jobs 3", true, "ADMIN RESUME DDL JOBS 3"},
 		{"admin resume ddl jobs", false, "ADMIN RESUME DDL JOBS"},
 		{"admin resume ddl jobs str_not_num", false, "ADMIN RESUME DDL JOBS str_not_num"},
+		{"admin alter ddl jobs 1 thread = 8", true, "ADMIN ALTER DDL JOBS 1 THREAD = 8"},
+		{"admin alter ddl jobs 1 thread = 8, batch_size = 256, max_write_speed = '200MiB'", true, "ADMIN ALTER DDL JOBS 1 THREAD = 8, BATCH_SIZE = 256, MAX_WRITE_SPEED = _UTF8MB4'200MiB'"},
+		{"admin alter ddl jobs 2 run_window = '01:00-05:00'", true, "ADMIN ALTER DDL JOBS 2 RUN_WINDOW = _UTF8MB4'01:00-05:00'"},
+		{"admin alter ddl jobs 2 max_write_speed = -1", true, "ADMIN ALTER DDL JOBS 2 MAX_WRITE_SPEED = -1"},
+		{"admin alter ddl jobs 1, 2 thread = 8", false, ""},
+		{"admin alter ddl jobs 1", false, ""},
+		{"admin alter ddl jobs 1 thread = a", false, ""},
 		{"admin recover index t1 idx_a", true, "ADMIN RECOVER INDEX `t1` idx_a"},
 		{"admin cleanup index t1 idx_a", true, "ADMIN CLEANUP INDEX `t1` idx_a"},
 		{"admin show slow top 3", true, "ADMIN SHOW SLOW TOP 3"},
@@ -1649,6 +1656,11 @@ func TestBuiltin(t *testing.T) {
 		// for cast as JSON
 		{"SELECT *, CAST(data AS JSON) FROM t;", true, "SELECT *,CAST(`data` AS JSON) FROM `t`"},
 
+		// for spatial functions
+		{"SELECT ST_ASTEXT(POINT(1, 2)), ST_GEOMFROMTEXT('POINT(1 2)', 4326)", true, "SELECT ST_ASTEXT(POINT(1, 2)),ST_GEOMFROMTEXT(_UTF8MB4'POINT(1 2)', 4326)"},
+		{"SELECT linestring(point(0, 0), point(1, 1)), polygon(linestring(point(0, 0), point(1, 1), point(0, 0)))", true, "SELECT LINESTRING(POINT(0, 0), POINT(1, 1)),POLYGON(LINESTRING(POINT(0, 0), POINT(1, 1), POINT(0, 0)))"},
+		{"SELECT multipoint(point(0, 0)), multilinestring(l), multipolygon(p), geometrycollection(g), geomcollection()", true, "SELECT MULTIPOINT(POINT(0, 0)),MULTILINESTRING(`l`),MULTIPOLYGON(`p`),GEOMETRYCOLLECTION(`g`),GEOMCOLLECTION()"},
+
 		// for cast as signed int, fix issue #3691.
 		{"select cast(1 as signed int);", true, "SELECT CAST(1 AS SIGNED)"},
 
@@ -2738,6 +2750,9 @@ func TestDDL(t *testing.T) {
 		{"drop view xxx, yyy", true, "DROP VIEW `xxx`, `yyy`"},
 		{"drop view if exists xxx", true, "DROP VIEW IF EXISTS `xxx`"},
 		{"drop view if exists xxx, yyy", true, "DROP VIEW IF EXISTS `xxx`, `yyy`"},
+		{"drop materialized view", false, ""},
+		{"drop materialized view xxx", true, "DROP MATERIALIZED VIEW `xxx`"},
+		{"drop materialized view if exists xxx, yyy", true, "DROP MATERIALIZED VIEW IF EXISTS `xxx`, `yyy`"},
 		{"drop stats t", true, "DROP STATS `t`"},
 		{"drop stats t1, t2, t3", true, "DROP STATS `t1`, `t2`, `t3`"},
 		{"drop stats t global", true, "DROP STATS `t` GLOBAL"},
@@ -3057,6 +3072,8 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD SPATIAL KEY `g` (`g`)", true, "ALTER TABLE `t` ADD SPATIAL `g`(`g`)"},
+		{"ALTER TABLE t ADD SPATIAL INDEX (`g`)", true, "ALTER TABLE `t` ADD SPATIAL(`g`)"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3106,7 +3123,7 @@ func TestDDL(t *testing.T) {
 		{"alter table d_n.t_n convert to char set default", true, "ALTER TABLE `d_n`.`t_n` CONVERT TO CHARACTER SET DEFAULT"},
 		{"alter table d_n.t_n convert to character set default collate utf8mb4_0900_ai_ci", true, "ALTER TABLE `d_n`.`t_n` CONVERT TO CHARACTER SET DEFAULT COLLATE UTF8MB4_0900_AI_CI"},
 
-		{"ALTER TABLE t FORCE", true, "ALTER TABLE `t` FORCE /* AlterTableForce is not supported */ "},
+		{"ALTER TABLE t FORCE", true, "ALTER TABLE `t` FORCE"},
 		{"ALTER TABLE t DROP INDEX;", false, "ALTER TABLE `t` DROP INDEX"},
 		{"ALTER TABLE t DROP INDEX a", true, "ALTER TABLE `t` DROP INDEX `a`"},
 		{"ALTER TABLE t DROP INDEX IF EXISTS a", true, "ALTER TABLE `t` DROP INDEX IF EXISTS `a`"},
@@ -4701,6 +4718,15 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{"create table t (g geometry, p point, l linestring, a polygon)", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT,`l` LINESTRING,`a` POLYGON)"},
+		{"create table t (mp multipoint, ml multilinestring, ma multipolygon)", true, "CREATE TABLE `t` (`mp` MULTIPOINT,`ml` MULTILINESTRING,`ma` MULTIPOLYGON)"},
+		{"create table t (c1 geometrycollection, c2 geomcollection not null)", true, "CREATE TABLE `t` (`c1` GEOMCOLLECTION,`c2` GEOMCOLLECTION NOT NULL)"},
+		{"create table t (g geometry(10))", false, ""},
+		{"create table t (g geometry not null, spatial index idx(g))", true, "CREATE TABLE `t` (`g` GEOMETRY NOT NULL,SPATIAL `idx`(`g`))"},
+		{"create table t (g geometry not null, spatial key (g))", true, "CREATE TABLE `t` (`g` GEOMETRY NOT NULL,SPATIAL(`g`))"},
+		{"create table point (polygon int, linestring int)", true, "CREATE TABLE `point` (`polygon` INT,`linestring` INT)"},
 	}
 	RunTest(t, table, false)
 }
@@ -5806,6 +5832,69 @@ func TestAnalyze(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$')) jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1,2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (id for ordinality, b varchar(10) exists path '$.b')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `b` VARCHAR(10) EXISTS PATH '$.b')) AS `jt`"},
+		{"select * from json_table('{}', '$' columns (a int path '$.a' default '0' on empty null on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` INT PATH '$.a' DEFAULT '0' ON EMPTY NULL ON ERROR)) AS `jt`"},
+		{"select * from json_table('{}', '$' columns (a int path '$.a' error on empty)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` INT PATH '$.a' ERROR ON EMPTY)) AS `jt`"},
+		{"select * from json_table('{}', '$' columns (a int path '$.a' error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('{}', '$' columns (a json path '$.a', nested path '$.b[*]' columns (c int path '$', nested '$.d' columns (e for ordinality)))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` JSON PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`c` INT PATH '$', NESTED PATH '$.d' COLUMNS (`e` FOR ORDINALITY)))) AS `jt`"},
+		{"select t.a, jt.* from t, json_table(t.j, '$[*]' columns (path int path '$.path', nested int path '$.nested')) as jt", true, "SELECT `t`.`a`,`jt`.* FROM (`t`) JOIN JSON_TABLE(`t`.`j`, '$[*]' COLUMNS (`path` INT PATH '$.path', `nested` INT PATH '$.nested')) AS `jt`"},
+		{"select * from t left join json_table(t.j, '$' columns (a int path '$')) as jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`j`, '$' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+
+		// an alias is required
+		{"select * from json_table('[1,2]', '$[*]' columns (a int path '$'))", false, ""},
+		// at least one column is required
+		{"select * from json_table('[1,2]', '$[*]' columns ()) as jt", false, ""},
+		// ON ERROR must follow ON EMPTY
+		{"select * from json_table('{}', '$' columns (a int path '$.a' null on error null on empty)) as jt", false, ""},
+		// JSON_TABLE is a reserved keyword
+		{"create table json_table (a int)", false, ""},
+		{"create table ordinality (nested int, path int, empty int)", true, "CREATE TABLE `ordinality` (`nested` INT,`path` INT,`empty` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestJSONValue(t *testing.T) {
+	table := []testCase{
+		{"select json_value('{\"a\": 1}', '$.a')", true, "SELECT JSON_VALUE(_UTF8MB4'{\"a\": 1}', '$.a')"},
+		{"select json_value(j, '$.a' returning signed) from t", true, "SELECT JSON_VALUE(`j`, '$.a' RETURNING SIGNED) FROM `t`"},
+		{"select json_value(j, '$.a' returning decimal(5, 2) default '0' on empty error on error) from t", true, "SELECT JSON_VALUE(`j`, '$.a' RETURNING DECIMAL(5, 2) DEFAULT '0' ON EMPTY ERROR ON ERROR) FROM `t`"},
+		{"select json_value(j, '$.a' returning char(10) charset latin1 null on error) from t", true, "SELECT JSON_VALUE(`j`, '$.a' RETURNING CHAR(10) CHARSET LATIN1 NULL ON ERROR) FROM `t`"},
+		{"select json_value(j, '$.a' returning date error on empty) from t", true, "SELECT JSON_VALUE(`j`, '$.a' RETURNING DATE ERROR ON EMPTY) FROM `t`"},
+		{"create table t (j json, check (json_value(j, '$.a' returning unsigned) > 0))", true, "CREATE TABLE `t` (`j` JSON,CHECK(JSON_VALUE(`j`, '$.a' RETURNING UNSIGNED)>0) ENFORCED)"},
+
+		// the path must be a string literal
+		{"select json_value(j, p) from t", false, ""},
+		// ON ERROR must follow ON EMPTY
+		{"select json_value(j, '$.a' null on error null on empty) from t", false, ""},
+		// JSON_VALUE and RETURNING are not reserved keywords
+		{"create table json_value (returning int)", true, "CREATE TABLE `json_value` (`returning` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	table := []testCase{
+		{"select * from t, lateral (select * from s where s.a = t.a) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT * FROM `s` WHERE `s`.`a`=`t`.`a`) AS `dt`"},
+		{"select * from t join lateral (select * from s where s.a = t.a order by s.b limit 2) dt on true", true, "SELECT * FROM `t` JOIN LATERAL (SELECT * FROM `s` WHERE `s`.`a`=`t`.`a` ORDER BY `s`.`b` LIMIT 2) AS `dt` ON TRUE"},
+		{"select * from t left join lateral (select count(*) as c from s where s.a = t.a) as dt on true", true, "SELECT * FROM `t` LEFT JOIN LATERAL (SELECT COUNT(1) AS `c` FROM `s` WHERE `s`.`a`=`t`.`a`) AS `dt` ON TRUE"},
+		{"select * from t, lateral (select a from s union select a from r) as dt", true, "SELECT * FROM (`t`) JOIN LATERAL (SELECT `a` FROM `s` UNION SELECT `a` FROM `r`) AS `dt`"},
+		{"select * from t, lateral (select 1) dt1, lateral (select dt1.`1`) dt2", true, "SELECT * FROM ((`t`) JOIN LATERAL (SELECT 1) AS `dt1`) JOIN LATERAL (SELECT `dt1`.`1`) AS `dt2`"},
+
+		// an alias is required
+		{"select * from t, lateral (select 1)", false, ""},
+		// only derived tables can be lateral
+		{"select * from t, lateral s", false, ""},
+		// LATERAL is a reserved keyword
+		{"create table lateral (a int)", false, ""},
+		{"create table `lateral` (a int)", true, "CREATE TABLE `lateral` (`a` INT)"},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTableSample(t *testing.T) {
 	table := []testCase{
 		// positive test cases
@@ -6732,6 +6821,21 @@ func TestIndexAdviseStmt(t *testing.T) {
 	RunTest(t, table, false)
 }
 
+func TestAdviseIndexesStmt(t *testing.T) {
+	table := []testCase{
+		{"ADVISE INDEXES FOR WORKLOAD", true, "ADVISE INDEXES FOR WORKLOAD"},
+		{"advise indexes for workload max_idxnum per_table 3", true, "ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_TABLE 3"},
+		{"ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_TABLE 3 PER_DB 5", true, "ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_TABLE 3 PER_DB 5"},
+		{"ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_DB 5", true, "ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_DB 5"},
+		{"ADVISE INDEXES FOR 'select * from t where a = 1'", true, "ADVISE INDEXES FOR 'select * from t where a = 1'"},
+		{"ADVISE INDEXES FOR 'select * from t where a = 1', \"select * from t where b = 'x'\" MAX_IDXNUM PER_TABLE 1", true, "ADVISE INDEXES FOR 'select * from t where a = 1', 'select * from t where b = ''x''' MAX_IDXNUM PER_TABLE 1"},
+		{"ADVISE INDEXES FOR", false, ""},
+		{"ADVISE INDEXES FOR WORKLOAD MAX_IDXNUM PER_TABLE -1", false, ""},
+		{"ADVISE INDEXES WORKLOAD", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 // For BRIE
 func TestBRIE(t *testing.T) {
 	table := []testCase{
@@ -57,6 +57,8 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the type of the values stored in a geometry column.
+	geometryType GeometryType
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -81,7 +83,8 @@ func (ft *FieldType) IsDecimalValid() bool {
 // IsVarLengthType Determine whether the column type is a variable-length type
 func (ft *FieldType) IsVarLengthType() bool {
 	switch ft.GetType() {
-	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeJSON, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeJSON, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		return true
 	default:
 		return false
@@ -126,6 +129,16 @@ func (ft *FieldType) GetElems() []string {
 	return ft.elems
 }
 
+// GetGeometryType returns the geometry type of the FieldType.
+func (ft *FieldType) GetGeometryType() GeometryType {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the geometry type of the FieldType.
+func (ft *FieldType) SetGeometryType(tp GeometryType) {
+	ft.geometryType = tp
+}
+
 // SetType sets the type of the FieldType.
 func (ft *FieldType) SetType(tp byte) {
 	ft.tp = tp
@@ -296,7 +309,8 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
+		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag) &&
+		ft.geometryType == other.geometryType
 	if !partialEqual || len(ft.elems) != len(other.elems) {
 		return false
 	}
@@ -370,6 +384,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.GetType(), ft.charset)
+	if ft.GetType() == mysql.TypeGeometry {
+		ts = ft.geometryType.String()
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -463,7 +480,11 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
+	if ft.GetType() == mysql.TypeGeometry {
+		ctx.WriteKeyWord(ft.geometryType.String())
+	} else {
+		ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
+	}
 
 	precision := UnspecifiedLength
 	scale := UnspecifiedLength
@@ -634,6 +655,7 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     GeometryType `json:",omitempty"`
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -650,6 +672,7 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
 	}
 	return err
 }
@@ -666,6 +689,7 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
 	return json.Marshal(r)
 }
 
@@ -0,0 +1,50 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// GeometryType is the type of the values stored in a spatial column. The values are the same as the
+// geometry type codes of WKB, so GeometryTypeGeometry, the zero value, means any geometry.
+type GeometryType byte
+
+// GeometryType values.
+const (
+	GeometryTypeGeometry GeometryType = iota
+	GeometryTypePoint
+	GeometryTypeLineString
+	GeometryTypePolygon
+	GeometryTypeMultiPoint
+	GeometryTypeMultiLineString
+	GeometryTypeMultiPolygon
+	GeometryTypeGeometryCollection
+)
+
+var geometryTypeNames = []string{
+	GeometryTypeGeometry:           "geometry",
+	GeometryTypePoint:              "point",
+	GeometryTypeLineString:         "linestring",
+	GeometryTypePolygon:            "polygon",
+	GeometryTypeMultiPoint:         "multipoint",
+	GeometryTypeMultiLineString:    "multilinestring",
+	GeometryTypeMultiPolygon:       "multipolygon",
+	GeometryTypeGeometryCollection: "geomcollection",
+}
+
+// String implements the fmt.Stringer interface.
+func (t GeometryType) String() string {
+	if int(t) < len(geometryTypeNames) {
+		return geometryTypeNames[t]
+	}
+	return "geometry"
+}
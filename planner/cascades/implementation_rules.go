@@ -18,10 +18,13 @@ import (
 	"math"
 
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/mysql"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	impl "github.com/pingcap/tidb/planner/implementation"
 	"github.com/pingcap/tidb/planner/memo"
 	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/util/plancodec"
 )
 
 // ImplementationRule defines the interface for implementation rules.
@@ -52,6 +55,9 @@ var defaultImplementationMap = map[memo.Operand][]ImplementationRule{
 	memo.OperandTiKVSingleGather: {
 		&ImplTiKVSingleReadGather{},
 	},
+	memo.OperandIndexMerge: {
+		&ImplIndexMerge{},
+	},
 	memo.OperandShow: {
 		&ImplShow{},
 	},
@@ -88,6 +94,18 @@ var defaultImplementationMap = map[memo.Operand][]ImplementationRule{
 	memo.OperandWindow: {
 		&ImplWindow{},
 	},
+	memo.OperandUnionScan: {
+		&ImplUnionScan{},
+	},
+	memo.OperandLock: {
+		&ImplLock{},
+	},
+	memo.OperandCTE: {
+		&ImplCTE{},
+	},
+	memo.OperandCTETable: {
+		&ImplCTETable{},
+	},
 }
 
 // ImplTableDual implements LogicalTableDual as PhysicalTableDual.
@@ -166,14 +184,19 @@ type ImplTiKVSingleReadGather struct {
 }
 
 // Match implements ImplementationRule Match interface.
-func (*ImplTiKVSingleReadGather) Match(_ *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
-	return true
+func (*ImplTiKVSingleReadGather) Match(expr *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	sg := expr.ExprNode.(*plannercore.TiKVSingleGather)
+	// The TableScans in TiFlash don't keep order in the MPP and batch cop tasks.
+	return sg.StoreType != kv.TiFlash || prop.IsSortItemEmpty()
 }
 
 // OnImplement implements ImplementationRule OnImplement interface.
 func (*ImplTiKVSingleReadGather) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
 	logicProp := expr.Group.Prop
 	sg := expr.ExprNode.(*plannercore.TiKVSingleGather)
+	if sg.StoreType == kv.TiFlash {
+		return getImplForTiFlashGather(sg, logicProp, reqProp), nil
+	}
 	if sg.IsIndexGather {
 		reader := sg.GetPhysicalIndexReader(logicProp.Schema, logicProp.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
 		return []memo.Implementation{impl.NewIndexReaderImpl(reader, sg.Source)}, nil
@@ -182,6 +205,50 @@ func (*ImplTiKVSingleReadGather) OnImplement(expr *memo.GroupExpr, reqProp *prop
 	return []memo.Implementation{impl.NewTableReaderImpl(reader, sg.Source)}, nil
 }
 
+// getImplForTiFlashGather implements the TiKVSingleGather reading from TiFlash as the
+// PhysicalTableReader of MPP tasks and the one of cop or batch cop tasks.
+func getImplForTiFlashGather(sg *plannercore.TiKVSingleGather, logicProp *property.LogicalProperty, reqProp *property.PhysicalProperty) []memo.Implementation {
+	stats := logicProp.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt)
+	impls := make([]memo.Implementation, 0, 2)
+	if sg.CanUseMPP() {
+		childProp := &property.PhysicalProperty{
+			TaskTp:         property.MppTaskType,
+			ExpectedCnt:    reqProp.ExpectedCnt,
+			MPPPartitionTp: property.AnyType,
+		}
+		reader := sg.GetPhysicalTableReader(logicProp.Schema, stats, childProp)
+		impls = append(impls, impl.NewMPPTableReaderImpl(reader, sg.Source))
+	}
+	if sg.CanUseCop() {
+		childProp := &property.PhysicalProperty{
+			TaskTp:      property.CopSingleReadTaskType,
+			ExpectedCnt: reqProp.ExpectedCnt,
+		}
+		reader := sg.GetPhysicalTableReader(logicProp.Schema, stats, childProp)
+		impls = append(impls, impl.NewTableReaderImpl(reader, sg.Source))
+	}
+	return impls
+}
+
+// ImplIndexMerge implements LogicalIndexMerge as PhysicalIndexMergeReader.
+type ImplIndexMerge struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplIndexMerge) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	return prop.IsSortItemEmpty()
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplIndexMerge) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
+	im := expr.ExprNode.(*plannercore.LogicalIndexMerge)
+	reader, cost, err := im.GetPhysicalIndexMergeReader(reqProp.ExpectedCnt)
+	if err != nil || reader == nil {
+		return nil, err
+	}
+	return []memo.Implementation{impl.NewIndexMergeReaderImpl(reader, cost)}, nil
+}
+
 // ImplTableScan implements TableScan as PhysicalTableScan.
 type ImplTableScan struct {
 }
@@ -189,6 +256,9 @@ type ImplTableScan struct {
 // Match implements ImplementationRule Match interface.
 func (*ImplTableScan) Match(expr *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
 	ts := expr.ExprNode.(*plannercore.LogicalTableScan)
+	if expr.Group.EngineType == memo.EngineTiFlash {
+		return prop.IsSortItemEmpty()
+	}
 	return prop.IsSortItemEmpty() || (len(prop.SortItems) == 1 && ts.HandleCols != nil && prop.SortItems[0].Col.Equal(nil, ts.HandleCols.GetCol(0)))
 }
 
@@ -201,6 +271,14 @@ func (*ImplTableScan) OnImplement(expr *memo.GroupExpr, reqProp *property.Physic
 		ts.KeepOrder = true
 		ts.Desc = reqProp.SortItems[0].Desc
 	}
+	if expr.Group.EngineType == memo.EngineTiFlash {
+		ts.StoreType = kv.TiFlash
+		for _, col := range ts.Columns {
+			if col.IsGenerated() && !col.GeneratedStored {
+				col.AddFlag(mysql.GeneratedColumnFlag)
+			}
+		}
+	}
 	tblCols, tblColHists := logicalScan.Source.TblCols, logicalScan.Source.TblColHists
 	return []memo.Implementation{impl.NewTableScanImpl(ts, tblCols, tblColHists)}, nil
 }
@@ -273,7 +351,8 @@ func (*ImplSelection) OnImplement(expr *memo.GroupExpr, reqProp *property.Physic
 	switch expr.Group.EngineType {
 	case memo.EngineTiDB:
 		return []memo.Implementation{impl.NewTiDBSelectionImpl(physicalSel)}, nil
-	case memo.EngineTiKV:
+	case memo.EngineTiKV, memo.EngineTiFlash:
+		// The Selection is executed in the coprocessor of TiFlash as well as TiKV.
 		return []memo.Implementation{impl.NewTiKVSelectionImpl(physicalSel)}, nil
 	default:
 		return nil, plannercore.ErrInternal.GenWithStack("Unsupported EngineType '%s' for Selection.", expr.Group.EngineType.String())
@@ -436,8 +515,28 @@ func getImplForHashJoin(expr *memo.GroupExpr, prop *property.PhysicalProperty, i
 		chReqProps[1-innerIdx].ExpectedCnt = expr.Children[1-innerIdx].Prop.Stats.RowCount * expCntScale
 	}
 	hashJoin := plannercore.NewPhysicalHashJoin(join, innerIdx, useOuterToBuild, stats.ScaleByExpectCnt(prop.ExpectedCnt), chReqProps...)
-	hashJoin.SetSchema(expr.Group.Prop.Schema)
-	return impl.NewHashJoinImpl(hashJoin)
+	hashJoin.SetSchema(join.Schema())
+	return restoreJoinColumnOrder(expr, impl.NewHashJoinImpl(hashJoin))
+}
+
+// restoreJoinColumnOrder adds a Projection above the join if its columns are in a
+// different order from its Group, which happens when the join is reordered by the
+// join reorder rules.
+func restoreJoinColumnOrder(expr *memo.GroupExpr, joinImpl memo.Implementation) memo.Implementation {
+	join := joinImpl.GetPlan()
+	groupCols, joinCols := expr.Schema().Columns, join.Schema().Columns
+	sameOrder := len(groupCols) == len(joinCols)
+	for i := 0; sameOrder && i < len(groupCols); i++ {
+		sameOrder = groupCols[i].UniqueID == joinCols[i].UniqueID
+	}
+	if sameOrder {
+		return joinImpl
+	}
+	proj := plannercore.PhysicalProjection{
+		Exprs: expression.Column2Exprs(groupCols),
+	}.Init(join.SCtx(), join.StatsInfo(), join.SelectBlockOffset())
+	proj.SetSchema(expr.Schema())
+	return impl.NewReorderedJoinImpl(joinImpl, proj)
 }
 
 // ImplHashJoinBuildLeft implements LogicalJoin to PhysicalHashJoin which uses the left child to build hash table.
@@ -500,23 +599,24 @@ type ImplMergeJoin struct {
 }
 
 // Match implements ImplementationRule Match interface.
-func (*ImplMergeJoin) Match(_ *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
-	return true
+func (*ImplMergeJoin) Match(expr *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
+	// The merge join doesn't support the null aware equal conditions.
+	return len(expr.ExprNode.(*plannercore.LogicalJoin).NAEQConditions) == 0
 }
 
 // OnImplement implements ImplementationRule OnImplement interface.
 func (*ImplMergeJoin) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
 	join := expr.ExprNode.(*plannercore.LogicalJoin)
-	physicalMergeJoins := join.GetMergeJoin(reqProp, expr.Schema(), expr.Group.Prop.Stats, expr.Children[0].Prop.Stats, expr.Children[1].Prop.Stats)
+	physicalMergeJoins := join.GetMergeJoin(reqProp, join.Schema(), expr.Group.Prop.Stats, expr.Children[0].Prop.Stats, expr.Children[1].Prop.Stats)
 	mergeJoinImpls := make([]memo.Implementation, 0, len(physicalMergeJoins))
 	for _, physicalPlan := range physicalMergeJoins {
 		physicalMergeJoin := physicalPlan.(*plannercore.PhysicalMergeJoin)
-		mergeJoinImpls = append(mergeJoinImpls, impl.NewMergeJoinImpl(physicalMergeJoin))
+		mergeJoinImpls = append(mergeJoinImpls, restoreJoinColumnOrder(expr, impl.NewMergeJoinImpl(physicalMergeJoin)))
 	}
 	return mergeJoinImpls, nil
 }
 
-// ImplUnionAll implements LogicalUnionAll to PhysicalUnionAll.
+// ImplUnionAll implements LogicalUnionAll and LogicalPartitionUnionAll to PhysicalUnionAll.
 type ImplUnionAll struct {
 }
 
@@ -527,7 +627,7 @@ func (*ImplUnionAll) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (
 
 // OnImplement implements ImplementationRule OnImplement interface.
 func (*ImplUnionAll) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
-	logicalUnion := expr.ExprNode.(*plannercore.LogicalUnionAll)
+	logicalUnion := expr.ExprNode
 	chReqProps := make([]*property.PhysicalProperty, len(expr.Children))
 	for i := range expr.Children {
 		chReqProps[i] = &property.PhysicalProperty{ExpectedCnt: reqProp.ExpectedCnt}
@@ -539,6 +639,9 @@ func (*ImplUnionAll) OnImplement(expr *memo.GroupExpr, reqProp *property.Physica
 		chReqProps...,
 	)
 	physicalUnion.SetSchema(expr.Group.Prop.Schema)
+	if _, ok := logicalUnion.(*plannercore.LogicalPartitionUnionAll); ok {
+		physicalUnion.SetTP(plancodec.TypePartitionUnion)
+	}
 	return []memo.Implementation{impl.NewUnionAllImpl(physicalUnion)}, nil
 }
 
@@ -622,3 +725,66 @@ func (*ImplWindow) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalP
 	physicalWindow.SetSchema(expr.Group.Prop.Schema)
 	return []memo.Implementation{impl.NewWindowImpl(physicalWindow)}, nil
 }
+
+// ImplUnionScan implements LogicalUnionScan to PhysicalUnionScan.
+type ImplUnionScan struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplUnionScan) Match(_ *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
+	return true
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplUnionScan) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
+	us := expr.ExprNode.(*plannercore.LogicalUnionScan)
+	physicalUnionScan := us.GetPhysicalUnionScan(expr.Group.Prop.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
+	return []memo.Implementation{impl.NewUnionScanImpl(physicalUnionScan)}, nil
+}
+
+// ImplLock implements LogicalLock to PhysicalLock.
+type ImplLock struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplLock) Match(_ *memo.GroupExpr, _ *property.PhysicalProperty) (matched bool) {
+	return true
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplLock) OnImplement(expr *memo.GroupExpr, reqProp *property.PhysicalProperty) ([]memo.Implementation, error) {
+	lock := expr.ExprNode.(*plannercore.LogicalLock)
+	physicalLock := lock.GetPhysicalLock(expr.Group.Prop.Stats.ScaleByExpectCnt(reqProp.ExpectedCnt), reqProp)
+	return []memo.Implementation{impl.NewLockImpl(physicalLock)}, nil
+}
+
+// ImplCTE implements LogicalCTE to PhysicalCTE. The physical plans of the seed part
+// and the recursive part are built in the preprocessing phase.
+type ImplCTE struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplCTE) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	return prop.IsSortItemEmpty()
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplCTE) OnImplement(expr *memo.GroupExpr, _ *property.PhysicalProperty) ([]memo.Implementation, error) {
+	cte := expr.ExprNode.(*plannercore.LogicalCTE)
+	return []memo.Implementation{impl.NewCTEImpl(cte.GetPhysicalCTE(expr.Group.Prop.Stats))}, nil
+}
+
+// ImplCTETable implements LogicalCTETable to PhysicalCTETable.
+type ImplCTETable struct {
+}
+
+// Match implements ImplementationRule Match interface.
+func (*ImplCTETable) Match(_ *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
+	return prop.IsSortItemEmpty()
+}
+
+// OnImplement implements ImplementationRule OnImplement interface.
+func (*ImplCTETable) OnImplement(expr *memo.GroupExpr, _ *property.PhysicalProperty) ([]memo.Implementation, error) {
+	cteTable := expr.ExprNode.(*plannercore.LogicalCTETable)
+	return []memo.Implementation{impl.NewCTETableImpl(cteTable.GetPhysicalCTETable(expr.Group.Prop.Stats))}, nil
+}
@@ -19,10 +19,13 @@ import (
 	"testing"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/planner/cascades"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/testkit/testdata"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSimpleProjDual(t *testing.T) {
@@ -439,3 +442,78 @@ func TestInlineProjection(t *testing.T) {
 		tk.MustQuery(sql).Check(testkit.Rows(output[i].Result...))
 	}
 }
+
+func TestUnsupportedPlans(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, pt")
+	tk.MustExec("create table t(a int, b int, c int, index ia(a), index ib(b))")
+	tk.MustExec("create table pt(a int, b int) partition by range(a) (partition p0 values less than (10), partition p1 values less than (maxvalue))")
+	tk.MustExec("insert into t values (1, 2, 3), (2, 3, 4), (3, 1, 2), (4, 4, 1), (12, 1, 1)")
+	tk.MustExec("insert into pt values (1, 1), (3, 3), (12, 12), (15, 15)")
+	tk.MustExec("set @@tidb_enable_cascades_planner = 1")
+
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static'")
+	tk.MustQuery("select * from pt where a > 2").Sort().Check(testkit.Rows("12 12", "15 15", "3 3"))
+	tk.MustQuery("explain format = 'brief' select * from pt where a > 12").CheckNotContain("partition:p0")
+	tk.MustQuery("select * from pt where a < 5 order by b limit 1").Check(testkit.Rows("1 1"))
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
+	tk.MustQuery("select * from pt where a > 2").Sort().Check(testkit.Rows("12 12", "15 15", "3 3"))
+
+	tk.MustQuery("explain format = 'brief' select /*+ use_index_merge(t, ia, ib) */ * from t where a = 1 or b = 1").CheckContain("IndexMerge")
+	tk.MustGetErrCode("select * from t tablesample regions()", errno.ErrNotSupportedYet)
+
+	// The supported queries still get the same results as the default planner.
+	checkResults := func(sql string) {
+		tk.MustExec("set @@tidb_enable_cascades_planner = 0")
+		expected := tk.MustQuery(sql).Sort().Rows()
+		tk.MustExec("set @@tidb_enable_cascades_planner = 1")
+		tk.MustQuery(sql).Sort().Check(expected)
+	}
+	checkResults("select t1.a, t2.b, t3.c from t t1 join t t2 on t1.a = t2.a join t t3 on t2.b = t3.b")
+	checkResults("select a, rank() over (partition by b order by a) from t")
+	checkResults("select t.a, pt.b from t join pt on t.a = pt.a")
+	checkResults("select /*+ use_index_merge(t, ia, ib) */ * from t where a = 1 or b = 1")
+	checkResults("select /*+ use_index_merge(t, ia, ib) */ a, c from t where (a = 2 or b = 1) and c > 1")
+	checkResults("with recursive cte(n) as (select 1 union all select n + 1 from cte where n < 5) select * from cte")
+	checkResults("with cte as (select a, b from t where a > 1) select c1.a, c2.b from cte c1 join cte c2 on c1.a = c2.b")
+
+	// The UnionScans and Locks in the transaction.
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("insert into t values (5, 5, 5)")
+	tk.MustQuery("select a, b from t where a > 3").Sort().Check(testkit.Rows("12 1", "4 4", "5 5"))
+	tk.MustQuery("select a from t where b = 5 for update").Check(testkit.Rows("5"))
+	tk.MustExec("rollback")
+}
+
+func TestTiFlashReader(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b int, index ib(b))")
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	// Set the hacked TiFlash replica for explain tests.
+	tbl.Meta().TiFlashReplica = &model.TiFlashReplicaInfo{Count: 1, Available: true}
+	tk.MustExec("set @@tidb_enable_cascades_planner = 1")
+	tk.MustExec("set @@tidb_isolation_read_engines = 'tiflash'")
+
+	tk.MustExec("set @@tidb_allow_mpp = 1")
+	tk.MustQuery("explain format = 'brief' select a, b from t where b > 1").Check(testkit.Rows(
+		"TableReader 3333.33 root  MppVersion: 2, data:ExchangeSender",
+		"└─ExchangeSender 3333.33 mpp[tiflash]  ExchangeType: PassThrough",
+		"  └─Selection 3333.33 mpp[tiflash]  gt(test.t.b, 1)",
+		"    └─TableFullScan 10000.00 mpp[tiflash] table:t keep order:false, stats:pseudo"))
+
+	tk.MustExec("set @@tidb_allow_mpp = 0")
+	tk.MustQuery("explain format = 'brief' select a, b from t where b > 1").CheckContain("cop[tiflash]")
+
+	// The TiKV access paths are used if TiKV is preferred by the hints.
+	tk.MustExec("set @@tidb_isolation_read_engines = 'tikv, tiflash'")
+	tk.MustQuery("explain format = 'brief' select /*+ read_from_storage(tikv[t]) */ a, b from t where b > 1").CheckNotContain("tiflash")
+	tk.MustQuery("explain format = 'brief' select /*+ read_from_storage(tiflash[t]) */ a, b from t where b > 1").CheckNotContain("tikv")
+}
@@ -16,6 +16,7 @@ package cascades
 
 import (
 	"container/list"
+	"context"
 	"math"
 
 	"github.com/pingcap/tidb/expression"
@@ -70,7 +71,16 @@ func (opt *Optimizer) GetImplementationRules(node plannercore.LogicalPlan) []Imp
 // ------------------------------------------------------------------------------
 //
 // The target of this phase is to preprocess the plan tree by some heuristic
-// rules which should always be beneficial, for example Column Pruning.
+// rules which should always be beneficial, for example Column Pruning. The
+// seed parts and the recursive parts of the CTEs are optimized, and the
+// partitioned tables are rewritten to the unions of their partitions in the
+// static partition prune mode in this phase.
+//
+// The plans which can't be optimized by the cascades planner are rejected with
+// ErrNotSupportedYet in this phase, including:
+//   - the operators without implementation rules, e.g, Sequences,
+//   - the TABLESAMPLE access paths,
+//   - the TiFlash reads in the sessions which ban both MPP and cop tasks.
 //
 // ------------------------------------------------------------------------------
 // Phase 2: Exploration
@@ -97,8 +107,8 @@ func (opt *Optimizer) GetImplementationRules(node plannercore.LogicalPlan) []Imp
 // for each expression in each group under the required physical property. A
 // memo structure is used for a group to reduce the repeated search on the same
 // required physical property.
-func (opt *Optimizer) FindBestPlan(sctx sessionctx.Context, logical plannercore.LogicalPlan) (p plannercore.PhysicalPlan, cost float64, err error) {
-	logical, err = opt.onPhasePreprocessing(sctx, logical)
+func (opt *Optimizer) FindBestPlan(ctx context.Context, sctx sessionctx.Context, logical plannercore.LogicalPlan) (p plannercore.PhysicalPlan, cost float64, err error) {
+	logical, err = opt.onPhasePreprocessing(ctx, sctx, logical)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -115,12 +125,61 @@ func (opt *Optimizer) FindBestPlan(sctx sessionctx.Context, logical plannercore.
 	return p, cost, err
 }
 
-func (*Optimizer) onPhasePreprocessing(_ sessionctx.Context, plan plannercore.LogicalPlan) (plannercore.LogicalPlan, error) {
+func (opt *Optimizer) onPhasePreprocessing(ctx context.Context, sctx sessionctx.Context, plan plannercore.LogicalPlan) (plannercore.LogicalPlan, error) {
 	err := plan.PruneColumns(plan.Schema().Columns, nil)
 	if err != nil {
 		return nil, err
 	}
-	return plan, nil
+	if err = opt.checkSupported(plan); err != nil {
+		return nil, err
+	}
+	if err = opt.buildCTEParts(ctx, sctx, plan); err != nil {
+		return nil, err
+	}
+	if !sctx.GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
+		plan, err = plannercore.PrunePartitions(plan)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return plannercore.StabilizeResults(ctx, plan)
+}
+
+// buildCTEParts optimizes the seed parts and the recursive parts of the CTEs in
+// the plan by the cascades planner, they are optimized separately and shared by
+// all the references of the CTE.
+func (opt *Optimizer) buildCTEParts(ctx context.Context, sctx sessionctx.Context, plan plannercore.LogicalPlan) error {
+	if cte, ok := plan.(*plannercore.LogicalCTE); ok {
+		return cte.BuildPhysicalParts(func(part plannercore.LogicalPlan) (plannercore.PhysicalPlan, error) {
+			p, _, err := opt.FindBestPlan(ctx, sctx, part)
+			return p, err
+		})
+	}
+	for _, child := range plan.Children() {
+		if err := opt.buildCTEParts(ctx, sctx, child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkSupported checks whether the plan can be optimized by the cascades planner.
+// The operators without implementation rules, e.g, CTEs, and the data sources
+// which need the access paths only supported by the default planner are
+// reported as unsupported.
+func (opt *Optimizer) checkSupported(plan plannercore.LogicalPlan) error {
+	if ds, ok := plan.(*plannercore.DataSource); ok {
+		return ds.CheckConvert2Gathers()
+	}
+	if len(opt.GetImplementationRules(plan)) == 0 {
+		return plannercore.ErrNotSupportedYet.GenWithStackByArgs("operator " + plan.TP() + " in the cascades planner")
+	}
+	for _, child := range plan.Children() {
+		if err := opt.checkSupported(child); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (opt *Optimizer) onPhaseExploration(_ sessionctx.Context, g *memo.Group) error {
@@ -122,7 +122,7 @@ func TestPreparePossibleProperties(t *testing.T) {
 	logic, ok := plan.(plannercore.LogicalPlan)
 	require.True(t, ok)
 
-	logic, err = optimizer.onPhasePreprocessing(ctx, logic)
+	logic, err = optimizer.onPhasePreprocessing(context.Background(), ctx, logic)
 	require.NoError(t, err)
 
 	// collect the target columns: f, a
@@ -64,7 +64,7 @@ func TestGroupStringer(t *testing.T) {
 		logic, ok := plan.(plannercore.LogicalPlan)
 		require.True(t, ok)
 
-		logic, err = optimizer.onPhasePreprocessing(ctx, logic)
+		logic, err = optimizer.onPhasePreprocessing(context.Background(), ctx, logic)
 		require.NoError(t, err)
 
 		group := memo.Convert2Group(logic)
@@ -15,6 +15,7 @@
 package cascades
 
 import (
+	"fmt"
 	"math"
 
 	"github.com/pingcap/tidb/expression"
@@ -73,6 +74,7 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 		NewRulePushSelDownUnionAll(),
 		NewRulePushSelDownWindow(),
 		NewRuleMergeAdjacentSelection(),
+		NewRuleGenerateIndexMerge(),
 	},
 	memo.OperandAggregation: {
 		NewRuleMergeAggregationProjection(),
@@ -106,6 +108,8 @@ var TiDBLayerOptimizationBatch = TransformationRuleBatch{
 	},
 	memo.OperandJoin: {
 		NewRuleTransformJoinCondToSel(),
+		NewRuleJoinAssociativity(),
+		NewRuleJoinLeftExchange(),
 	},
 	memo.OperandWindow: {
 		NewRuleMergeAdjacentWindow(),
@@ -331,7 +335,7 @@ func (*PushSelDownTiKVSingleGather) OnTransform(old *memo.ExprIter) (newExprs []
 	childGroup := old.Children[0].Children[0].Group
 	var pushed, remained []expression.Expression
 	sctx := sg.SCtx()
-	pushed, remained = expression.PushDownExprs(sctx.GetSessionVars().StmtCtx, sel.Conditions, sctx.GetClient(), kv.TiKV)
+	pushed, remained = expression.PushDownExprs(sctx.GetSessionVars().StmtCtx, sel.Conditions, sctx.GetClient(), sg.StoreType)
 	if len(pushed) == 0 {
 		return nil, false, false, nil
 	}
@@ -377,7 +381,11 @@ func (*EnumeratePaths) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupEx
 	gathers := ds.Convert2Gathers()
 	for _, gather := range gathers {
 		expr := memo.Convert2GroupExpr(gather)
-		expr.Children[0].SetEngineType(memo.EngineTiKV)
+		if gather.(*plannercore.TiKVSingleGather).StoreType == kv.TiFlash {
+			expr.Children[0].SetEngineType(memo.EngineTiFlash)
+		} else {
+			expr.Children[0].SetEngineType(memo.EngineTiKV)
+		}
 		newExprs = append(newExprs, expr)
 	}
 	return newExprs, true, false, nil
@@ -717,6 +725,46 @@ func (*PushSelDownWindow) OnTransform(old *memo.ExprIter) (newExprs []*memo.Grou
 	return []*memo.GroupExpr{newTopSelExpr}, true, false, nil
 }
 
+// GenerateIndexMerge generates the IndexMerge access paths of the DataSource
+// by the conditions of the Selection.
+type GenerateIndexMerge struct {
+	baseRule
+}
+
+// NewRuleGenerateIndexMerge creates a new Transformation GenerateIndexMerge.
+// The pattern of this rule is `Selection -> DataSource`.
+func NewRuleGenerateIndexMerge() Transformation {
+	rule := &GenerateIndexMerge{}
+	rule.pattern = memo.BuildPattern(
+		memo.OperandSelection,
+		memo.EngineTiDBOnly,
+		memo.NewPattern(memo.OperandDataSource, memo.EngineTiDBOnly),
+	)
+	return rule
+}
+
+// Match implements Transformation interface.
+func (r *GenerateIndexMerge) Match(expr *memo.ExprIter) bool {
+	return !expr.GetExpr().HasAppliedRule(r)
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Selection -> DataSource` to `IndexMerge`, and
+// erase all the other expressions if the IndexMerge is specified by the hints.
+func (r *GenerateIndexMerge) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	sel := old.GetExpr().ExprNode.(*plannercore.LogicalSelection)
+	ds := old.Children[0].GetExpr().ExprNode.(*plannercore.DataSource)
+	old.GetExpr().AddAppliedRule(r)
+	merges, preferred, err := ds.Convert2IndexMerges(sel.Conditions)
+	if err != nil {
+		return nil, false, false, err
+	}
+	for _, merge := range merges {
+		newExprs = append(newExprs, memo.NewGroupExpr(merge))
+	}
+	return newExprs, false, preferred, nil
+}
+
 // TransformLimitToTopN transforms Limit+Sort to TopN.
 type TransformLimitToTopN struct {
 	baseRule
@@ -820,7 +868,7 @@ func (r *PushLimitDownUnionAll) Match(expr *memo.ExprIter) bool {
 // It will transform `Limit->UnionAll->X` to `Limit->UnionAll->Limit->X`.
 func (r *PushLimitDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	limit := old.GetExpr().ExprNode.(*plannercore.LogicalLimit)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 	unionAllSchema := old.Children[0].Group.Prop.Schema
 
 	newLimit := plannercore.LogicalLimit{
@@ -1014,6 +1062,11 @@ func (r *PushSelDownJoin) OnTransform(old *memo.ExprIter) (newExprs []*memo.Grou
 	return []*memo.GroupExpr{newJoinExpr}, true, false, nil
 }
 
+// joinCondsPushedDown marks the join GroupExprs whose conditions have been pushed down by
+// TransformJoinCondToSel in their applied rule sets. The conditions of the joins built by
+// the join reorder rules come from these joins, so they are marked as well.
+var joinCondsPushedDown = &TransformJoinCondToSel{}
+
 // TransformJoinCondToSel convert Join(len(cond) > 0) to Join-->(Sel, Sel).
 type TransformJoinCondToSel struct {
 	baseRule
@@ -1030,7 +1083,7 @@ func NewRuleTransformJoinCondToSel() Transformation {
 
 // Match implements Transformation interface.
 func (r *TransformJoinCondToSel) Match(expr *memo.ExprIter) bool {
-	if expr.GetExpr().HasAppliedRule(r) {
+	if expr.GetExpr().HasAppliedRule(joinCondsPushedDown) {
 		return false
 	}
 	join := expr.GetExpr().ExprNode.(*plannercore.LogicalJoin)
@@ -1055,10 +1108,228 @@ func (r *TransformJoinCondToSel) OnTransform(old *memo.ExprIter) (newExprs []*me
 	rightGroup = buildChildSelectionGroup(sctx, join.SelectBlockOffset(), rightCond, rightGroup)
 	newJoinExpr := memo.NewGroupExpr(newJoin)
 	newJoinExpr.SetChildren(leftGroup, rightGroup)
-	newJoinExpr.AddAppliedRule(r)
+	newJoinExpr.AddAppliedRule(joinCondsPushedDown)
 	return []*memo.GroupExpr{newJoinExpr}, true, false, nil
 }
 
+// buildJoinReorderPattern builds the pattern `Join -> (Join, Any)` of the join reorder rules.
+func buildJoinReorderPattern() *memo.Pattern {
+	return memo.BuildPattern(
+		memo.OperandJoin,
+		memo.EngineTiDBOnly,
+		memo.NewPattern(memo.OperandJoin, memo.EngineTiDBOnly),
+		memo.NewPattern(memo.OperandAny, memo.EngineTiDBOnly),
+	)
+}
+
+// isReorderableJoin checks whether the join GroupExpr can be reordered. Only the inner joins whose
+// conditions have been pushed down by TransformJoinCondToSel are reordered.
+func isReorderableJoin(expr *memo.GroupExpr) bool {
+	join, ok := expr.ExprNode.(*plannercore.LogicalJoin)
+	if !ok || !join.CanReorder() || !expr.HasAppliedRule(joinCondsPushedDown) {
+		return false
+	}
+	if len(join.LeftConditions) > 0 || len(join.RightConditions) > 0 {
+		return false
+	}
+	// The schemas of the natural joins and the joins with `using` clauses don't contain all the columns of
+	// their children, we don't reorder them.
+	return join.Schema().Len() == expr.Children[0].Prop.Schema.Len()+expr.Children[1].Prop.Schema.Len()
+}
+
+// matchJoinReorder checks whether the two adjacent joins can be reordered.
+func matchJoinReorder(expr *memo.ExprIter) bool {
+	return isReorderableJoin(expr.GetExpr()) && isReorderableJoin(expr.Children[0].GetExpr())
+}
+
+// collectJoinConds collects all the conditions of the join.
+func collectJoinConds(join *plannercore.LogicalJoin) []expression.Expression {
+	conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.LeftConditions)+len(join.RightConditions)+len(join.OtherConditions))
+	conds = append(conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+	conds = append(conds, join.LeftConditions...)
+	conds = append(conds, join.RightConditions...)
+	return append(conds, join.OtherConditions...)
+}
+
+// joinCondKeys returns the keys of the join conditions. The arguments of the equal conditions are
+// sorted, because ExtractOnCondition may swap them when the join children are reordered.
+func joinCondKeys(sctx sessionctx.Context, conds []expression.Expression) []string {
+	sc := sctx.GetSessionVars().StmtCtx
+	keys := make([]string, 0, len(conds))
+	for _, cond := range conds {
+		if f, ok := cond.(*expression.ScalarFunction); ok && f.FuncName.L == ast.EQ {
+			l, r := string(f.GetArgs()[0].HashCode(sc)), string(f.GetArgs()[1].HashCode(sc))
+			if l > r {
+				l, r = r, l
+			}
+			keys = append(keys, fmt.Sprintf("eq(%d:%s,%s)", len(l), l, r))
+			continue
+		}
+		keys = append(keys, string(cond.HashCode(sc)))
+	}
+	return keys
+}
+
+// getJoinInfo returns the JoinInfo of the Group. A Group which doesn't contain any reorderable join is
+// a leaf itself, and the join Groups are registered by their JoinInfos when they're visited first, so
+// the join reorder rules reuse them instead of building the same joins again.
+func getJoinInfo(g *memo.Group) *memo.JoinInfo {
+	if info := g.JoinInfo(); info != nil {
+		return info
+	}
+	for elem := g.GetFirstElem(memo.OperandJoin); elem != nil; elem = elem.Next() {
+		expr := elem.Value.(*memo.GroupExpr)
+		if memo.GetOperand(expr.ExprNode) != memo.OperandJoin {
+			break
+		}
+		if !isReorderableJoin(expr) {
+			continue
+		}
+		info := mergeJoinInfo(expr.ExprNode.SCtx(), collectJoinConds(expr.ExprNode.(*plannercore.LogicalJoin)), expr.Children[0], expr.Children[1])
+		g.RegisterJoinGroup(info)
+		return info
+	}
+	return memo.NewJoinInfo([]*memo.Group{g}, nil)
+}
+
+// mergeJoinInfo builds the JoinInfo of the join of the two Groups with the given conditions.
+func mergeJoinInfo(sctx sessionctx.Context, conds []expression.Expression, leftGroup, rightGroup *memo.Group) *memo.JoinInfo {
+	left, right := getJoinInfo(leftGroup), getJoinInfo(rightGroup)
+	leaves := append(append([]*memo.Group(nil), left.Leaves...), right.Leaves...)
+	condKeys := append(append(joinCondKeys(sctx, conds), left.Conds...), right.Conds...)
+	return memo.NewJoinInfo(leaves, condKeys)
+}
+
+// buildReorderedJoin builds a new inner join of the two groups with the given conditions.
+func buildReorderedJoin(join *plannercore.LogicalJoin, conds []expression.Expression, leftGroup, rightGroup *memo.Group) *memo.GroupExpr {
+	newJoin := join.Shallow()
+	newJoin.EqualConditions = nil
+	newJoin.LeftConditions = nil
+	newJoin.RightConditions = nil
+	newJoin.OtherConditions = nil
+	eq, left, right, other := newJoin.ExtractOnCondition(conds, leftGroup.Prop.Schema, rightGroup.Prop.Schema, false, false)
+	newJoin.AppendJoinConds(eq, left, right, other)
+	newJoin.SetSchema(expression.MergeSchema(leftGroup.Prop.Schema, rightGroup.Prop.Schema))
+	newJoinExpr := memo.NewGroupExpr(newJoin)
+	newJoinExpr.SetChildren(leftGroup, rightGroup)
+	// The conditions are collected from the joins whose conditions have been pushed down.
+	newJoinExpr.AddAppliedRule(joinCondsPushedDown)
+	return newJoinExpr
+}
+
+// buildReorderedBottomJoin builds the new bottom join group of the two groups for the join reorder rules,
+// and returns the conditions left for the new top join. It returns nil if the new bottom join
+// is a cartesian product. The join groups of the same leaves and conditions are shared.
+func buildReorderedBottomJoin(old *memo.ExprIter, leftGroup, rightGroup *memo.Group) (*memo.Group, []expression.Expression) {
+	topJoin := old.GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	bottomJoin := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	conds := append(collectJoinConds(topJoin), collectJoinConds(bottomJoin)...)
+	bottomSchema := expression.MergeSchema(leftGroup.Prop.Schema, rightGroup.Prop.Schema)
+	var bottomConds, topConds []expression.Expression
+	for _, cond := range conds {
+		if expression.ExprFromSchema(cond, bottomSchema) {
+			bottomConds = append(bottomConds, cond)
+		} else {
+			topConds = append(topConds, cond)
+		}
+	}
+	newBottomJoinExpr := buildReorderedJoin(bottomJoin, bottomConds, leftGroup, rightGroup)
+	if len(newBottomJoinExpr.ExprNode.(*plannercore.LogicalJoin).EqualConditions) == 0 {
+		return nil, nil
+	}
+	info := mergeJoinInfo(bottomJoin.SCtx(), bottomConds, leftGroup, rightGroup)
+	if g := memo.FindJoinGroup(info); g != nil {
+		return g, topConds
+	}
+	g := memo.NewGroupWithSchema(newBottomJoinExpr, bottomSchema)
+	g.RegisterJoinGroup(info)
+	return g, topConds
+}
+
+// hasJoinOfChildren checks whether the Group already contains a join of the two child Groups.
+func hasJoinOfChildren(g *memo.Group, leftGroup, rightGroup *memo.Group) bool {
+	for elem := g.GetFirstElem(memo.OperandJoin); elem != nil; elem = elem.Next() {
+		expr := elem.Value.(*memo.GroupExpr)
+		if memo.GetOperand(expr.ExprNode) != memo.OperandJoin {
+			break
+		}
+		if expr.Children[0] == leftGroup && expr.Children[1] == rightGroup {
+			return true
+		}
+	}
+	return false
+}
+
+// reorderJoin builds the reordered top join of the two groups if the current Group doesn't contain it.
+func reorderJoin(old *memo.ExprIter, newBottomGroup *memo.Group, topConds []expression.Expression, leftGroup, rightGroup *memo.Group) []*memo.GroupExpr {
+	// Register the current Group, so the reordered joins of its sub-trees can be found by the rules.
+	getJoinInfo(old.GetExpr().Group)
+	if newBottomGroup == nil || hasJoinOfChildren(old.GetExpr().Group, leftGroup, rightGroup) {
+		return nil
+	}
+	topJoin := old.GetExpr().ExprNode.(*plannercore.LogicalJoin)
+	return []*memo.GroupExpr{buildReorderedJoin(topJoin, topConds, leftGroup, rightGroup)}
+}
+
+// JoinAssociativity reorders the inner joins by the associativity.
+// The join reorder rules are applied on the reordered joins again until no new join
+// can be found, the join Groups of the same leaves and conditions are shared in the memo.
+type JoinAssociativity struct {
+	baseRule
+}
+
+// NewRuleJoinAssociativity creates a new Transformation JoinAssociativity.
+// The pattern of this rule is `Join -> (Join, Any)`.
+func NewRuleJoinAssociativity() Transformation {
+	rule := &JoinAssociativity{}
+	rule.pattern = buildJoinReorderPattern()
+	return rule
+}
+
+// Match implements Transformation interface.
+func (*JoinAssociativity) Match(expr *memo.ExprIter) bool {
+	return matchJoinReorder(expr)
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Join(Join(A, B), C)` to `Join(A, Join(B, C))`.
+func (*JoinAssociativity) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	a := old.Children[0].GetExpr().Children[0]
+	b := old.Children[0].GetExpr().Children[1]
+	c := old.GetExpr().Children[1]
+	newBottomGroup, topConds := buildReorderedBottomJoin(old, b, c)
+	return reorderJoin(old, newBottomGroup, topConds, a, newBottomGroup), false, false, nil
+}
+
+// JoinLeftExchange reorders the inner joins by exchanging the right children of the two joins.
+type JoinLeftExchange struct {
+	baseRule
+}
+
+// NewRuleJoinLeftExchange creates a new Transformation JoinLeftExchange.
+// The pattern of this rule is `Join -> (Join, Any)`.
+func NewRuleJoinLeftExchange() Transformation {
+	rule := &JoinLeftExchange{}
+	rule.pattern = buildJoinReorderPattern()
+	return rule
+}
+
+// Match implements Transformation interface.
+func (*JoinLeftExchange) Match(expr *memo.ExprIter) bool {
+	return matchJoinReorder(expr)
+}
+
+// OnTransform implements Transformation interface.
+// It will transform `Join(Join(A, B), C)` to `Join(Join(A, C), B)`. The columns of the new join
+// are in a different order from the Group, the order is restored when the join is implemented.
+func (*JoinLeftExchange) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
+	a := old.Children[0].GetExpr().Children[0]
+	b := old.Children[0].GetExpr().Children[1]
+	c := old.GetExpr().Children[1]
+	newBottomGroup, topConds := buildReorderedBottomJoin(old, a, c)
+	return reorderJoin(old, newBottomGroup, topConds, newBottomGroup, b), false, false, nil
+}
+
 // PushSelDownUnionAll pushes selection through union all.
 type PushSelDownUnionAll struct {
 	baseRule
@@ -1080,7 +1351,7 @@ func NewRulePushSelDownUnionAll() Transformation {
 // It will transform `Selection->UnionAll->x` to `UnionAll->Selection->x`.
 func (*PushSelDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	sel := old.GetExpr().ExprNode.(*plannercore.LogicalSelection)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 	childGroups := old.Children[0].GetExpr().Children
 
 	newUnionAllExpr := memo.NewGroupExpr(unionAll)
@@ -1358,7 +1629,7 @@ func (r *PushTopNDownUnionAll) Match(expr *memo.ExprIter) bool {
 // It will transform `TopN->UnionAll->X` to `TopN->UnionAll->TopN->X`.
 func (r *PushTopNDownUnionAll) OnTransform(old *memo.ExprIter) (newExprs []*memo.GroupExpr, eraseOld bool, eraseAll bool, err error) {
 	topN := old.GetExpr().ExprNode.(*plannercore.LogicalTopN)
-	unionAll := old.Children[0].GetExpr().ExprNode.(*plannercore.LogicalUnionAll)
+	unionAll := old.Children[0].GetExpr().ExprNode
 
 	newTopN := plannercore.LogicalTopN{
 		Count:   topN.Count + topN.Offset,
@@ -1401,7 +1672,8 @@ func NewRulePushTopNDownTiKVSingleGather() Transformation {
 // Match implements Transformation interface.
 // Use appliedRuleSet in GroupExpr to avoid re-apply rules.
 func (r *PushTopNDownTiKVSingleGather) Match(expr *memo.ExprIter) bool {
-	return !expr.GetExpr().HasAppliedRule(r)
+	// TODO: Remove the engine check when we have implemented TiFlashTopN.
+	return !expr.GetExpr().HasAppliedRule(r) && expr.Children[0].GetExpr().Children[0].EngineType == memo.EngineTiKV
 }
 
 // OnTransform implements Transformation interface.
@@ -1836,7 +2108,8 @@ func NewRulePushLimitDownTiKVSingleGather() Transformation {
 // Match implements Transformation interface.
 // Use appliedRuleSet in GroupExpr to avoid re-apply rules.
 func (r *PushLimitDownTiKVSingleGather) Match(expr *memo.ExprIter) bool {
-	return !expr.GetExpr().HasAppliedRule(r)
+	// TODO: Remove the engine check when we have implemented TiFlashLimit.
+	return !expr.GetExpr().HasAppliedRule(r) && expr.Children[0].GetExpr().Children[0].EngineType == memo.EngineTiKV
 }
 
 // OnTransform implements Transformation interface.
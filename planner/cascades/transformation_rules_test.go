@@ -47,7 +47,7 @@ func testGroupToString(t *testing.T, input []string, output []struct {
 		logic, ok := plan.(plannercore.LogicalPlan)
 		require.True(t, ok)
 
-		logic, err = optimizer.onPhasePreprocessing(ctx, logic)
+		logic, err = optimizer.onPhasePreprocessing(context.Background(), ctx, logic)
 		require.NoError(t, err)
 
 		group := memo.Convert2Group(logic)
@@ -96,7 +96,7 @@ func TestAggPushDownGather(t *testing.T) {
 		logic, ok := plan.(plannercore.LogicalPlan)
 		require.True(t, ok)
 
-		logic, err = optimizer.onPhasePreprocessing(ctx, logic)
+		logic, err = optimizer.onPhasePreprocessing(context.Background(), ctx, logic)
 		require.NoError(t, err)
 
 		group := memo.Convert2Group(logic)
@@ -491,3 +491,103 @@ func TestMergeAdjacentWindow(t *testing.T) {
 	transformationRulesSuiteData.LoadTestCases(t, &input, &output)
 	testGroupToString(t, input, output, optimizer)
 }
+
+func TestJoinReorder(t *testing.T) {
+	optimizer := NewOptimizer()
+	optimizer.ResetTransformationRules(map[memo.Operand][]Transformation{
+		memo.OperandJoin: {
+			NewRuleTransformJoinCondToSel(),
+			NewRuleJoinAssociativity(),
+			NewRuleJoinLeftExchange(),
+		},
+	})
+	defer func() {
+		optimizer.ResetTransformationRules(DefaultRuleBatches...)
+	}()
+	p := parser.New()
+	ctx := plannercore.MockContext()
+	is := infoschema.MockInfoSchema([]*model.TableInfo{plannercore.MockSignedTable()})
+	domain.GetDomain(ctx).MockInfoCacheAndLoadInfoSchema(is)
+
+	exploreJoinGroup := func(sql string) *memo.Group {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		plan, _, err := plannercore.BuildLogicalPlanForTest(context.Background(), ctx, stmt, is)
+		require.NoError(t, err)
+		logic, err := optimizer.onPhasePreprocessing(context.Background(), ctx, plan.(plannercore.LogicalPlan))
+		require.NoError(t, err)
+		group := memo.Convert2Group(logic)
+		require.NoError(t, optimizer.onPhaseExploration(ctx, group))
+		// The root group is the Projection of `select *`.
+		return group.Equivalents.Front().Value.(*memo.GroupExpr).Children[0]
+	}
+	joinExprs := func(g *memo.Group) []*memo.GroupExpr {
+		var exprs []*memo.GroupExpr
+		for elem := g.Equivalents.Front(); elem != nil; elem = elem.Next() {
+			expr := elem.Value.(*memo.GroupExpr)
+			if _, ok := expr.ExprNode.(*plannercore.LogicalJoin); ok {
+				exprs = append(exprs, expr)
+			}
+		}
+		return exprs
+	}
+	// Each join of the same child groups is only kept once in a group.
+	checkNoDuplicatedJoin := func(g *memo.Group) {
+		children := make(map[[2]*memo.Group]struct{})
+		for _, expr := range joinExprs(g) {
+			key := [2]*memo.Group{expr.Children[0], expr.Children[1]}
+			_, ok := children[key]
+			require.False(t, ok)
+			children[key] = struct{}{}
+		}
+	}
+
+	// Join(Join(t1, t2), t3) -> Join(t1, Join(t2, t3)).
+	g := exploreJoinGroup("select * from t t1 join t t2 on t1.a = t2.a join t t3 on t2.b = t3.b")
+	require.Equal(t, 2, g.Equivalents.Len())
+	expr := g.Equivalents.Back().Value.(*memo.GroupExpr)
+	join, ok := expr.ExprNode.(*plannercore.LogicalJoin)
+	require.True(t, ok)
+	require.Len(t, join.EqualConditions, 1)
+	require.Equal(t, g.Prop.Schema.Len(), join.Schema().Len())
+	bottomJoin, ok := expr.Children[1].Equivalents.Front().Value.(*memo.GroupExpr).ExprNode.(*plannercore.LogicalJoin)
+	require.True(t, ok)
+	require.Len(t, bottomJoin.EqualConditions, 1)
+
+	// Join(Join(t1, t2), t3) -> Join(Join(t1, t3), t2). Exchanging the joins again finds the
+	// original bottom join group, so no more join is added.
+	g = exploreJoinGroup("select * from t t1 join t t2 on t1.a = t2.a join t t3 on t1.b = t3.b")
+	exprs := joinExprs(g)
+	require.Len(t, exprs, 2)
+	join = exprs[1].ExprNode.(*plannercore.LogicalJoin)
+	require.Len(t, join.EqualConditions, 1)
+	require.Equal(t, g.Prop.Schema.Len(), join.Schema().Len())
+	require.NotEqual(t, g.Prop.Schema.Columns[len(g.Prop.Schema.Columns)-1].UniqueID, join.Schema().Columns[len(join.Schema().Columns)-1].UniqueID)
+	require.Len(t, getJoinInfo(exprs[1].Children[0]).Leaves, 2)
+
+	// The reordered joins are reordered again, so Join(t1, Join(t2, Join(t3, t4))) is found
+	// from Join(Join(Join(t1, t2), t3), t4).
+	g = exploreJoinGroup("select * from t t1 join t t2 on t1.a = t2.a join t t3 on t2.b = t3.b join t t4 on t3.c = t4.c")
+	checkNoDuplicatedJoin(g)
+	require.Len(t, getJoinInfo(g).Leaves, 4)
+	found := false
+	for _, expr := range joinExprs(g) {
+		if len(getJoinInfo(expr.Children[0]).Leaves) != 1 || len(getJoinInfo(expr.Children[1]).Leaves) != 3 {
+			continue
+		}
+		for _, child := range joinExprs(expr.Children[1]) {
+			found = found || len(getJoinInfo(child.Children[1]).Leaves) == 2
+		}
+		checkNoDuplicatedJoin(expr.Children[1])
+	}
+	require.True(t, found)
+
+	g = exploreJoinGroup("select * from t t1 join t t2 on t1.a = t2.a join t t3 on t1.b = t3.b and t2.b = t3.b")
+	require.Equal(t, 3, g.Equivalents.Len())
+	checkNoDuplicatedJoin(g)
+	// The cartesian products and outer joins are not reordered.
+	g = exploreJoinGroup("select * from t t1 join t t2 on t1.a = t2.a join t t3 on t3.c = t1.c + t2.c")
+	require.Equal(t, 1, g.Equivalents.Len())
+	g = exploreJoinGroup("select * from t t1 left join t t2 on t1.a = t2.a join t t3 on t2.b = t3.b")
+	require.Equal(t, 1, g.Equivalents.Len())
+}
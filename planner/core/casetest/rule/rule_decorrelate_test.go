@@ -0,0 +1,78 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rule
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, s")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("create table s(a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into s values (1, 10), (1, 20), (1, 30), (2, 40), (null, 50)")
+
+	// Top N per group.
+	tk.MustQuery("select t.a, dt.b from t, lateral (select b from s where s.a = t.a order by b desc limit 2) as dt order by t.a, dt.b").
+		Check(testkit.Rows("1 20", "1 30", "2 40"))
+	tk.MustQuery("select t.a, dt.b from t left join lateral (select b from s where s.a = t.a order by b limit 1, 1) as dt on true order by t.a").
+		Check(testkit.Rows("1 20", "2 <nil>", "3 <nil>"))
+	tk.MustQuery("select t.a, dt.c from t, lateral (select count(*) as c from s where s.a = t.a) as dt order by t.a").
+		Check(testkit.Rows("1 3", "2 1", "3 0"))
+	// A lateral derived table can reference the preceding lateral derived tables.
+	tk.MustQuery("select t.a, dt1.m, dt2.b from t, lateral (select max(b) as m from s where s.a = t.a) as dt1, " +
+		"lateral (select b from s where s.b < dt1.m order by b desc limit 1) as dt2 order by t.a").
+		Check(testkit.Rows("1 30 20", "2 40 30"))
+	tk.MustQuery("select t.a, dt.x from t, lateral (select t.b + s.b as x from s where s.a = t.a order by s.b limit 1) as dt order by t.a").
+		Check(testkit.Rows("1 11", "2 42"))
+
+	// Only the lateral derived tables can reference the preceding tables.
+	tk.MustGetErrCode("select * from t, (select t.a) as dt", 1054)
+	tk.MustGetErrCode("select * from lateral (select t.a) as dt join t on true", 1054)
+	tk.MustGetErrCode("select * from t right join lateral (select t.a) as dt on true", 1054)
+}
+
+func TestDecorrelateLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t, s")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("create table s(a int, b int)")
+
+	// The correlated limit is computed by the row_number window function.
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select b from s where s.a = t.a order by b desc limit 2) as dt").
+		CheckContain("Window")
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select b from s where s.a = t.a order by b desc limit 2) as dt").
+		CheckNotContain("Apply")
+	tk.MustQuery("explain format = 'brief' select * from t left join lateral (select b from s where s.a = t.a and s.b > 0 limit 1, 2) as dt on t.b < dt.b").
+		CheckNotContain("Apply")
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select count(*) as c from s where s.a = t.a) as dt").
+		CheckNotContain("Apply")
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select * from s where s.a = t.a) as dt").
+		CheckNotContain("Apply")
+
+	// The limit is kept when the inner side is correlated by other conditions.
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select b from s where s.a > t.a order by b limit 2) as dt").
+		CheckContain("Apply")
+	tk.MustQuery("explain format = 'brief' select * from t, lateral (select t.b + s.b from s where s.a = t.a order by s.b limit 2) as dt").
+		CheckContain("Apply")
+}
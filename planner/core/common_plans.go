@@ -141,6 +141,20 @@ type ResumeDDLJobs struct {
 	JobIDs []int64
 }
 
+// AlterDDLJob is the plan of `ADMIN ALTER DDL JOBS`, which changes the parameters of a running DDL job.
+type AlterDDLJob struct {
+	baseSchemaProducer
+
+	JobID   int64
+	Options []*AlterDDLJobOpt
+}
+
+// AlterDDLJobOpt represents an option of `ADMIN ALTER DDL JOBS`.
+type AlterDDLJobOpt struct {
+	Name  string
+	Value expression.Expression
+}
+
 // ReloadExprPushdownBlacklist reloads the data from expr_pushdown_blacklist table.
 type ReloadExprPushdownBlacklist struct {
 	baseSchemaProducer
@@ -643,6 +657,15 @@ type IndexAdvise struct {
 	LineFieldsInfo
 }
 
+// AdviseIndexes represents an advise indexes plan.
+type AdviseIndexes struct {
+	baseSchemaProducer
+
+	// Queries is the workload to advise indexes for, the workload is read from the statement summary if it's empty.
+	Queries     []string
+	MaxIndexNum *ast.MaxIndexNumClause
+}
+
 // SplitRegion represents a split regions plan.
 type SplitRegion struct {
 	baseSchemaProducer
@@ -55,12 +55,17 @@ func (p *LogicalUnionScan) exhaustPhysicalPlans(prop *property.PhysicalProperty)
 			"MPP mode may be blocked because operator `UnionScan` is not supported now.")
 		return nil, true, nil
 	}
+	us := p.GetPhysicalUnionScan(p.StatsInfo(), prop)
+	return []PhysicalPlan{us}, true, nil
+}
+
+// GetPhysicalUnionScan returns PhysicalUnionScan for the LogicalUnionScan under the required property.
+func (p *LogicalUnionScan) GetPhysicalUnionScan(stats *property.StatsInfo, prop *property.PhysicalProperty) *PhysicalUnionScan {
 	childProp := prop.CloneEssentialFields()
-	us := PhysicalUnionScan{
+	return PhysicalUnionScan{
 		Conditions: p.conditions,
 		HandleCols: p.handleCols,
-	}.Init(p.SCtx(), p.StatsInfo(), p.SelectBlockOffset(), childProp)
-	return []PhysicalPlan{us}, true, nil
+	}.Init(p.SCtx(), stats, p.SelectBlockOffset(), childProp)
 }
 
 func getMaxSortPrefix(sortCols, allCols []*expression.Column) []int {
@@ -3466,13 +3471,18 @@ func (p *LogicalLock) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]P
 			"MPP mode may be blocked because operator `Lock` is not supported now.")
 		return nil, true, nil
 	}
+	lock := p.GetPhysicalLock(p.StatsInfo().ScaleByExpectCnt(prop.ExpectedCnt), prop)
+	return []PhysicalPlan{lock}, true, nil
+}
+
+// GetPhysicalLock returns PhysicalLock for the LogicalLock under the required property.
+func (p *LogicalLock) GetPhysicalLock(stats *property.StatsInfo, prop *property.PhysicalProperty) *PhysicalLock {
 	childProp := prop.CloneEssentialFields()
-	lock := PhysicalLock{
+	return PhysicalLock{
 		Lock:               p.Lock,
 		TblID2Handle:       p.tblID2Handle,
 		TblID2PhysTblIDCol: p.tblID2PhysTblIDCol,
-	}.Init(p.SCtx(), p.StatsInfo().ScaleByExpectCnt(prop.ExpectedCnt), childProp)
-	return []PhysicalPlan{lock}, true, nil
+	}.Init(p.SCtx(), stats, childProp)
 }
 
 func (p *LogicalUnionAll) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
@@ -246,6 +246,10 @@ func (p *PhysicalTableScan) OperatorInfo(normalized bool) string {
 	if p.StoreType == kv.TiFlash && p.Table.GetPartitionInfo() != nil && p.IsMPPOrBatchCop && p.SCtx().GetSessionVars().StmtCtx.UseDynamicPartitionPrune() {
 		buffer.WriteString(", PartitionTableScan:true")
 	}
+	if p.SampleInfo != nil {
+		buffer.WriteString(", ")
+		buffer.WriteString(p.SampleInfo.explainInfo(normalized))
+	}
 	if len(p.runtimeFilterList) > 0 {
 		buffer.WriteString(", runtime filter:")
 		for i, runtimeFilter := range p.runtimeFilterList {
@@ -413,6 +417,20 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Path)
+}
+
+func explainJSONTable(expr expression.Expression, path types.JSONPathExpression) string {
+	var str strings.Builder
+	str.WriteString("expr:")
+	str.WriteString(expr.ExplainInfo())
+	str.WriteString(", path:")
+	str.WriteString(path.String())
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -920,6 +938,11 @@ func (p *LogicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Path)
+}
+
 // ExplainInfo implements Plan interface.
 func (ds *DataSource) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -1088,6 +1111,22 @@ func (p *TiKVSingleGather) ExplainInfo() string {
 	if p.IsIndexGather {
 		buffer.WriteString(", index:" + p.Index.Name.String())
 	}
+	if p.StoreType == kv.TiFlash {
+		buffer.WriteString(", store:tiflash")
+	}
+	return buffer.String()
+}
+
+// ExplainInfo implements Plan interface.
+func (p *LogicalIndexMerge) ExplainInfo() string {
+	buffer := bytes.NewBufferString(p.Source.ExplainInfo())
+	for _, partialPath := range p.Path.PartialIndexPaths {
+		if partialPath.IsTablePath() {
+			buffer.WriteString(", table path")
+		} else {
+			buffer.WriteString(", index:" + partialPath.Index.Name.String())
+		}
+	}
 	return buffer.String()
 }
 
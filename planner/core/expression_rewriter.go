@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/opcode"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
@@ -1307,6 +1308,10 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 		er.patternLikeOrIlikeToExpression(v)
 	case *ast.PatternRegexpExpr:
 		er.regexpToScalarFunc(v)
+	case *ast.MatchAgainst:
+		er.matchAgainstToScalarFunc(v)
+	case *ast.JSONValueExpr:
+		er.jsonValueToScalarFunc(v)
 	case *ast.RowExpr:
 		er.rowToScalarFunc(v)
 	case *ast.PatternInExpr:
@@ -1870,6 +1875,180 @@ func (er *expressionRewriter) regexpToScalarFunc(v *ast.PatternRegexpExpr) {
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+// matchAgainstToScalarFunc rewrites MATCH (col1, col2, ...) AGAINST (expr modifier) to
+// match(concat_ws(' ', col1, col2, ...), expr, modifier, parser). Like MySQL, the columns must be covered by a
+// FULLTEXT index exactly, and the text is the same as the hidden column of the index, so the columns can be
+// substituted by the hidden column later.
+func (er *expressionRewriter) matchAgainstToScalarFunc(v *ast.MatchAgainst) {
+	if v.Modifier.WithQueryExpansion() {
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	l := len(er.ctxStack)
+	colLen := len(v.ColumnNames)
+	er.err = expression.CheckArgsNotMultiColumnRow(er.ctxStack[l-colLen-1:]...)
+	if er.err != nil {
+		return
+	}
+	against := er.ctxStack[l-1]
+	if len(expression.ExtractColumns(against)) > 0 || len(expression.ExtractCorColumns(against)) > 0 {
+		er.err = ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	idx, err := er.findFullTextIndex(er.ctxStack[l-colLen-1:l-1], er.ctxNameStk[l-colLen-1:l-1])
+	if err != nil {
+		er.err = err
+		return
+	}
+
+	// The separator takes the collation of the searched columns, so the relevance doesn't depend on the
+	// collation of the session.
+	colType := er.ctxStack[l-colLen-1].GetType()
+	sepType := types.NewFieldType(mysql.TypeVarString)
+	sepType.SetCharset(colType.GetCharset())
+	sepType.SetCollate(colType.GetCollate())
+	sep := &expression.Constant{Value: types.NewCollationStringDatum(" ", colType.GetCollate()), RetType: sepType}
+	initConstantRepertoire(sep)
+	args := make([]expression.Expression, 0, colLen+1)
+	args = append(args, sep)
+	args = append(args, er.ctxStack[l-colLen-1:l-1]...)
+	text, err := er.newFunction(ast.ConcatWS, types.NewFieldType(mysql.TypeVarString), args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	modifier := &expression.Constant{Value: types.NewIntDatum(int64(v.Modifier)), RetType: types.NewFieldType(mysql.TypeLonglong)}
+	parser := &expression.Constant{Value: types.NewStringDatum(idx.FullTextParser), RetType: types.NewFieldType(mysql.TypeVarString)}
+	function, err := er.newFunction(ast.FullTextMatch, &v.Type, text, against, modifier, parser)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colLen + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// jsonValueToScalarFunc rewrites JSON_VALUE(doc, path RETURNING type ... ON EMPTY ... ON ERROR) to
+// json_value(doc, path, on_empty, on_empty_default, on_error, on_error_default). The default values are converted to
+// the RETURNING type here, and the function takes its return type from them.
+func (er *expressionRewriter) jsonValueToScalarFunc(v *ast.JSONValueExpr) {
+	doc := er.ctxStack[len(er.ctxStack)-1]
+	er.err = expression.CheckArgsNotMultiColumnRow(doc)
+	if er.err != nil {
+		return
+	}
+	pathExpr, err := types.ParseJSONPathExpr(v.Path)
+	if err != nil {
+		er.err = err
+		return
+	}
+	if pathExpr.CouldMatchMultipleValues() {
+		er.err = types.ErrInvalidJSONPathMultipleSelection
+		return
+	}
+
+	var tp *types.FieldType
+	if v.Tp == nil {
+		// The default RETURNING type is VARCHAR(512) like MySQL.
+		tp = types.NewFieldType(mysql.TypeVarString)
+		tp.SetFlen(512)
+		tp.SetCharset(mysql.DefaultCharset)
+		tp.SetCollate(mysql.DefaultCollationName)
+	} else {
+		tp = v.Tp.Clone()
+		if er.err = er.checkTimePrecision(tp); er.err != nil {
+			return
+		}
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.GetType())
+		if tp.GetFlen() == types.UnspecifiedLength {
+			tp.SetFlen(defaultFlen)
+		}
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(defaultDecimal)
+		}
+	}
+
+	args := make([]expression.Expression, 0, 6)
+	args = append(args, doc, &expression.Constant{Value: types.NewStringDatum(v.Path), RetType: types.NewFieldType(mysql.TypeVarString)})
+	for _, resp := range []*ast.JSONTableOnResponse{v.OnEmpty, v.OnError} {
+		respTp := ast.JSONTableOnResponseNull
+		dft := types.NewDatum(nil)
+		if resp != nil {
+			respTp = resp.Tp
+		}
+		if respTp == ast.JSONTableOnResponseDefault {
+			sc := &stmtctx.StatementContext{TimeZone: er.sctx.GetSessionVars().Location()}
+			str := types.NewStringDatum(resp.Default)
+			dft, err = str.ConvertTo(sc, tp)
+			if err != nil {
+				er.err = err
+				return
+			}
+		}
+		args = append(args,
+			&expression.Constant{Value: types.NewIntDatum(int64(respTp)), RetType: types.NewFieldType(mysql.TypeTiny)},
+			&expression.Constant{Value: dft, RetType: tp})
+	}
+	function, err := er.newFunction(ast.JSONValue, tp, args...)
+	if err != nil {
+		er.err = err
+		return
+	}
+	if tp.EvalType() == types.ETString {
+		function.SetCoercibility(expression.CoercibilityImplicit)
+	}
+	er.ctxStackPop(1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFullTextIndex finds the public FULLTEXT index which covers exactly the columns of MATCH.
+func (er *expressionRewriter) findFullTextIndex(cols []expression.Expression, names types.NameSlice) (*model.IndexInfo, error) {
+	var tblName *types.FieldName
+	colNames := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		if _, ok := col.(*expression.Column); !ok {
+			return nil, ErrWrongArguments.GenWithStackByArgs("MATCH")
+		}
+		name := names[i]
+		if name.OrigTblName.L == "" {
+			return nil, ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+		}
+		if tblName == nil {
+			tblName = name
+		} else if tblName.DBName.L != name.DBName.L || tblName.TblName.L != name.TblName.L {
+			return nil, ErrWrongArguments.GenWithStackByArgs("MATCH")
+		}
+		colNames[name.OrigColName.L] = struct{}{}
+	}
+	dbName := tblName.DBName
+	if dbName.L == "" {
+		dbName = model.NewCIStr(er.sctx.GetSessionVars().CurrentDB)
+	}
+	if er.b.is == nil {
+		return nil, ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+	}
+	tbl, err := er.b.is.TableByName(dbName, tblName.OrigTblName)
+	if err != nil {
+		return nil, ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+	}
+	for _, idx := range tbl.Meta().Indices {
+		if !idx.IsFullText() || idx.State != model.StatePublic || len(idx.FullTextColumns) != len(colNames) {
+			continue
+		}
+		covered := true
+		for _, col := range idx.FullTextColumns {
+			if _, ok := colNames[col.L]; !ok {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return idx, nil
+		}
+	}
+	return nil, ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+}
+
 func (er *expressionRewriter) rowToScalarFunc(v *ast.RowExpr) {
 	stkLen := len(er.ctxStack)
 	length := len(v.Values)
@@ -2141,6 +2320,19 @@ func (er *expressionRewriter) toTable(v *ast.TableName) {
 }
 
 func (er *expressionRewriter) toColumn(v *ast.ColumnName) {
+	if procVars := er.sctx.GetSessionVars().ProcedureVars; procVars != nil && v.Schema.L == "" {
+		// The local variables of the stored procedure take precedence over the columns,
+		// the NEW and OLD rows of the trigger are read as the variables "new.col" and "old.col".
+		name := v.Name.L
+		if v.Table.L != "" {
+			name = v.Table.L + "." + name
+		}
+		if val, tp, ok := procVars.GetProcedureVar(name); ok {
+			er.sctx.GetSessionVars().StmtCtx.SetSkipPlanCache(errors.Errorf("query uses the local variable '%s' of the stored procedure", v.Name.O))
+			er.ctxStackAppend(&expression.Constant{Value: val, RetType: tp.Clone()}, types.EmptyName)
+			return
+		}
+	}
 	idx, err := expression.FindFieldName(er.names, v)
 	if err != nil {
 		er.err = ErrAmbiguous.GenWithStackByArgs(v.Name, clauseMsg[fieldList])
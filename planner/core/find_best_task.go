@@ -154,6 +154,22 @@ func (p *LogicalTableDual) findBestTask(prop *property.PhysicalProperty, planCou
 	return &rootTask{p: dual, isEmpty: p.RowCount == 0}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jsonTable := PhysicalJSONTable{
+		AsName:  p.AsName,
+		Expr:    p.Expr,
+		Path:    p.Path,
+		Columns: p.Columns,
+	}.Init(p.SCtx(), p.StatsInfo(), p.SelectBlockOffset())
+	jsonTable.SetSchema(p.schema)
+	planCounter.Dec(1)
+	opt.appendCandidate(p, jsonTable, prop)
+	return &rootTask{p: jsonTable}, 1, nil
+}
+
 func (p *LogicalShow) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, _ *physicalOptimizeOp) (task, int64, error) {
 	if !prop.IsSortItemEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
@@ -1024,7 +1040,7 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 			prop.CanAddEnforcer = true
 		}
 		ds.storeTask(prop, t)
-		if ds.SampleInfo != nil && !t.invalid() {
+		if ds.SampleInfo.needTableSamplePlan() && !t.invalid() {
 			if _, ok := t.plan().(*PhysicalTableSample); !ok {
 				warning := expression.ErrInvalidTableSample.GenWithStackByArgs("plan not supported")
 				ds.SCtx().GetSessionVars().StmtCtx.AppendWarning(warning)
@@ -1098,7 +1114,8 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 			return t, cntPlan, nil
 		}
 
-		canConvertPointGet := len(path.Ranges) > 0 && path.StoreType == kv.TiKV && ds.isPointGetConvertableSchema()
+		// The point get can't be sampled by regions.
+		canConvertPointGet := len(path.Ranges) > 0 && path.StoreType == kv.TiKV && ds.isPointGetConvertableSchema() && ds.SampleInfo == nil
 
 		if canConvertPointGet && path.Index != nil && path.Index.MVIndex {
 			canConvertPointGet = false // cannot use PointGet upon MVIndex
@@ -1188,7 +1205,7 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 				continue
 			}
 			var tblTask task
-			if ds.SampleInfo != nil {
+			if ds.SampleInfo.needTableSamplePlan() {
 				tblTask, err = ds.convertToSampleTable(prop, candidate, opt)
 			} else {
 				tblTask, err = ds.convertToTableScan(prop, candidate, opt)
@@ -1297,6 +1314,19 @@ func (ds *DataSource) convertToIndexMergeScan(prop *property.PhysicalProperty, c
 	return task, nil
 }
 
+// GetPhysicalIndexMergeReader returns the root plan reading the table by the IndexMerge access path
+// and its cost, the conditions which can't be pushed down are kept in a Selection on the reader.
+func (im *LogicalIndexMerge) GetPhysicalIndexMergeReader(expectedCnt float64) (PhysicalPlan, float64, error) {
+	prop := &property.PhysicalProperty{TaskTp: property.RootTaskType, ExpectedCnt: expectedCnt}
+	t, err := im.Source.convertToIndexMergeScan(prop, &candidatePath{path: im.Path}, nil)
+	if err != nil || t.invalid() {
+		return nil, 0, err
+	}
+	p := t.plan()
+	cost, err := p.getPlanCostVer1(property.RootTaskType, NewDefaultPlanCostOption())
+	return p, cost, err
+}
+
 func (ds *DataSource) convertToPartialIndexScan(prop *property.PhysicalProperty, path *util.AccessPath) (indexPlan PhysicalPlan) {
 	is := ds.getOriginalPhysicalIndexScan(prop, path, false, false)
 	// TODO: Consider using isIndexCoveringColumns() to avoid another TableRead
@@ -1910,6 +1940,22 @@ func getMostCorrCol4Handle(exprs []expression.Expression, histColl *statistics.T
 	return nil, corr
 }
 
+// getHypoIndexRowCount synthesizes the row count of the access conditions on a hypothetical index.
+// A hypothetical index has no statistics of its own, so the count is derived from the column
+// statistics of the table, the same way as the selectivity of the table filters.
+func (ds *DataSource) getHypoIndexRowCount(accessConds []expression.Expression) float64 {
+	rowCount := ds.tableStats.RowCount
+	if len(accessConds) == 0 {
+		return rowCount
+	}
+	selectivity, _, err := ds.tableStats.HistColl.Selectivity(ds.SCtx(), accessConds, nil)
+	if err != nil {
+		logutil.BgLogger().Debug("calculate selectivity failed, use selection factor", zap.Error(err))
+		selectivity = SelectionFactor
+	}
+	return rowCount * selectivity
+}
+
 // getColumnRangeCounts estimates row count for each range respectively.
 func getColumnRangeCounts(sctx sessionctx.Context, colID int64, ranges []*ranger.Range, histColl *statistics.HistColl, idxID int64) ([]float64, bool) {
 	var err error
@@ -2187,6 +2233,8 @@ func (ds *DataSource) convertToTableScan(prop *property.PhysicalProperty, candid
 		return invalidTask, nil
 	}
 	ts, _ := ds.getOriginalPhysicalTableScan(prop, candidate.path, candidate.isMatchProp)
+	// The SYSTEM sampling is done by reading the sampled regions, see filterPathsForSystemSample.
+	ts.SampleInfo = ds.SampleInfo
 	if ts.KeepOrder && ts.StoreType == kv.TiFlash && (ts.Desc || ds.SCtx().GetSessionVars().TiFlashFastScan) {
 		// TiFlash fast mode(https://github.com/pingcap/tidb/pull/35851) does not keep order in TableScan
 		return invalidTask, nil
@@ -2679,8 +2727,7 @@ func (p *LogicalCTE) findBestTask(prop *property.PhysicalProperty, counter *Plan
 		return invalidTask, 1, nil
 	}
 	// The physical plan has been build when derive stats.
-	pcte := PhysicalCTE{SeedPlan: p.cte.seedPartPhysicalPlan, RecurPlan: p.cte.recursivePartPhysicalPlan, CTE: p.cte, cteAsName: p.cteAsName, cteName: p.cteName}.Init(p.SCtx(), p.StatsInfo())
-	pcte.SetSchema(p.schema)
+	pcte := p.GetPhysicalCTE(p.StatsInfo())
 	if prop.IsFlashProp() && prop.CTEProducerStatus == property.AllCTECanMpp {
 		pcte.readerReceiver = PhysicalExchangeReceiver{IsCTEReader: true}.Init(p.SCtx(), p.StatsInfo())
 		if prop.MPPPartitionTp != property.AnyType {
@@ -2706,12 +2753,44 @@ func (p *LogicalCTETable) findBestTask(prop *property.PhysicalProperty, _ *PlanC
 		return nil, 1, nil
 	}
 
-	pcteTable := PhysicalCTETable{IDForStorage: p.idForStorage}.Init(p.SCtx(), p.StatsInfo())
-	pcteTable.SetSchema(p.schema)
+	pcteTable := p.GetPhysicalCTETable(p.StatsInfo())
 	t = &rootTask{p: pcteTable}
 	return t, 1, nil
 }
 
+// GetPhysicalCTE returns PhysicalCTE for the LogicalCTE, the physical plans of the seed
+// part and the recursive part should have been built.
+func (p *LogicalCTE) GetPhysicalCTE(stats *property.StatsInfo) *PhysicalCTE {
+	pcte := PhysicalCTE{SeedPlan: p.cte.seedPartPhysicalPlan, RecurPlan: p.cte.recursivePartPhysicalPlan, CTE: p.cte, cteAsName: p.cteAsName, cteName: p.cteName}.Init(p.SCtx(), stats)
+	pcte.SetSchema(p.schema)
+	return pcte
+}
+
+// BuildPhysicalParts builds the physical plans of the seed part and the recursive part of
+// the CTE by the optimize function if they haven't been built, so DeriveStats doesn't
+// optimize them by the default planner. It's used by the cascades planner.
+func (p *LogicalCTE) BuildPhysicalParts(optimize func(LogicalPlan) (PhysicalPlan, error)) (err error) {
+	if p.cte.seedPartPhysicalPlan == nil {
+		p.cte.seedPartPhysicalPlan, err = optimize(p.cte.seedPartLogicalPlan)
+		if err != nil {
+			return err
+		}
+	}
+	// The stats of the recursive part depend on the seed part through the LogicalCTETables.
+	*p.seedStat = *p.cte.seedPartPhysicalPlan.StatsInfo()
+	if p.cte.recursivePartLogicalPlan != nil && p.cte.recursivePartPhysicalPlan == nil {
+		p.cte.recursivePartPhysicalPlan, err = optimize(p.cte.recursivePartLogicalPlan)
+	}
+	return err
+}
+
+// GetPhysicalCTETable returns PhysicalCTETable for the LogicalCTETable.
+func (p *LogicalCTETable) GetPhysicalCTETable(stats *property.StatsInfo) *PhysicalCTETable {
+	pcteTable := PhysicalCTETable{IDForStorage: p.idForStorage}.Init(p.SCtx(), stats)
+	pcteTable.SetSchema(p.schema)
+	return pcteTable
+}
+
 func appendCandidate(lp LogicalPlan, task task, prop *property.PhysicalProperty, opt *physicalOptimizeOp) {
 	if task == nil || task.invalid() {
 		return
@@ -30,6 +30,8 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -66,7 +68,7 @@ func (ds *DataSource) generateIndexMergePath() error {
 		return nil
 	}
 
-	if ds.tableInfo.TempTableType == model.TempTableLocal {
+	if ds.tableInfo.HasSessionScopedRows() {
 		warningMsg = "IndexMerge is inapplicable or disabled. Cannot use IndexMerge on temporary table."
 		return nil
 	}
@@ -79,6 +81,9 @@ func (ds *DataSource) generateIndexMergePath() error {
 	if err := ds.generateIndexMerge4MVIndex(regularPathCount, indexMergeConds); err != nil {
 		return err
 	}
+	if err := ds.generateIndexMerge4FullTextIndex(indexMergeConds); err != nil {
+		return err
+	}
 
 	// If without hints, it means that `enableIndexMerge` is true
 	if len(ds.indexMergeHints) == 0 {
@@ -649,6 +654,168 @@ func (ds *DataSource) generateIndexMerge4MVIndex(normalPathCnt int, filters []ex
 	return nil
 }
 
+// generateIndexMerge4FullTextIndex generates paths for MATCH ... AGAINST on FULLTEXT indexes.
+// Like MVIndex, a row has an index entry for each distinct token in a FULLTEXT index, so the index can only be
+// accessed by IndexMerge. The index only finds the candidate rows, MATCH ... AGAINST is always kept as a table filter.
+/*
+	1. select * from t where match(a) against('fox dog')
+		IndexMerge(OR)
+			IndexRangeScan(ft, ["fox","fox"])
+			IndexRangeScan(ft, ["dog","dog"])
+			Selection(match(a) against('fox dog'))
+				TableRowIdScan(t)
+	2. select * from t where match(a) against('+fox +dog -cat' in boolean mode)
+		IndexMerge(AND)
+			IndexRangeScan(ft, ["fox","fox"])
+			IndexRangeScan(ft, ["dog","dog"])
+			Selection(match(a) against('+fox +dog -cat' in boolean mode))
+				TableRowIdScan(t)
+	3. select * from t where match(a) against('data*' in boolean mode)
+		IndexMerge(OR)
+			IndexRangeScan(ft, ["data","datb"))
+			Selection(match(a) against('data*' in boolean mode))
+				TableRowIdScan(t)
+*/
+func (ds *DataSource) generateIndexMerge4FullTextIndex(filters []expression.Expression) error {
+	useInvisibleIndexes := ds.SCtx().GetSessionVars().OptimizerUseInvisibleIndexes
+	for _, idx := range ds.tableInfo.Indices {
+		if !idx.IsFullText() || idx.State != model.StatePublic || (idx.Invisible && !useInvisibleIndexes) {
+			continue
+		}
+		idxCol, ok := ds.prepareCol4FullTextIndex(idx)
+		if !ok {
+			continue
+		}
+		for _, filter := range filters {
+			terms, isIntersection, ok := ds.collectTerms4FullTextIndex(filter, idx, idxCol)
+			if !ok {
+				continue
+			}
+			partialPaths := make([]*util.AccessPath, 0, len(terms))
+			for _, term := range terms {
+				partialPath, err := ds.buildPartialPath4FullTextIndex(term, idxCol, idx)
+				if err != nil {
+					return err
+				}
+				partialPaths = append(partialPaths, partialPath)
+			}
+			ds.possibleAccessPaths = append(ds.possibleAccessPaths, ds.buildPartialPathUp4MVIndex(partialPaths, isIntersection, filters))
+		}
+	}
+	return nil
+}
+
+// prepareCol4FullTextIndex returns the hidden column of the FULLTEXT index.
+func (ds *DataSource) prepareCol4FullTextIndex(idx *model.IndexInfo) (*expression.Column, bool) {
+	colMeta := ds.tableInfo.Cols()[idx.Columns[0].Offset]
+	for _, c := range ds.TblCols {
+		if c.ID == colMeta.ID {
+			return c, true
+		}
+	}
+	return nil, false
+}
+
+// collectTerms4FullTextIndex returns the terms to look up in the FULLTEXT index for the filter, the filter must be a
+// MATCH ... AGAINST on the columns of the index with a constant search string.
+func (ds *DataSource) collectTerms4FullTextIndex(filter expression.Expression, idx *model.IndexInfo, idxCol *expression.Column) (terms []fulltext.IndexTerm, isIntersection bool, ok bool) {
+	sf, ok := filter.(*expression.ScalarFunction)
+	if !ok || sf.FuncName.L != ast.FullTextMatch {
+		return nil, false, false
+	}
+	args := sf.GetArgs()
+	for _, arg := range args[1:] {
+		c, ok := arg.(*expression.Constant)
+		if !ok || c.ParamMarker != nil || c.DeferredExpr != nil {
+			return nil, false, false
+		}
+	}
+	parser, isNull, err := args[3].EvalString(ds.SCtx(), chunk.Row{})
+	if err != nil || isNull || parser != idx.FullTextParser || !ds.isFullTextIndexText(args[0], idx, idxCol) {
+		return nil, false, false
+	}
+	against, isNull, err := args[1].EvalString(ds.SCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return nil, false, false
+	}
+	modifier, isNull, err := args[2].EvalInt(ds.SCtx(), chunk.Row{})
+	if err != nil || isNull {
+		return nil, false, false
+	}
+	tokenizer := fulltext.NewTokenizer(idx.FullTextParser, idxCol.GetType().GetCollate())
+	query := fulltext.ParseQuery(tokenizer, against, ast.FulltextSearchModifier(modifier).IsBooleanMode())
+	terms, isIntersection, ok = query.IndexTerms()
+	if !ok || !isIntersection {
+		return terms, isIntersection, ok
+	}
+	// A row may be found several times by a prefix, which breaks the counting of the IndexMerge intersection, so
+	// only the exact tokens are intersected.
+	exactTerms := make([]fulltext.IndexTerm, 0, len(terms))
+	for _, term := range terms {
+		if !term.Prefix {
+			exactTerms = append(exactTerms, term)
+		}
+	}
+	if len(exactTerms) == 0 {
+		return terms[:1], false, true
+	}
+	return exactTerms, true, true
+}
+
+// isFullTextIndexText checks whether the text of MATCH ... AGAINST is the hidden column of the FULLTEXT index, or it's
+// built by the same columns as the index.
+func (ds *DataSource) isFullTextIndexText(text expression.Expression, idx *model.IndexInfo, idxCol *expression.Column) bool {
+	cols := expression.ExtractColumns(text)
+	if len(cols) == 1 && cols[0].ID == idxCol.ID {
+		return true
+	}
+	colIDs := make(map[int64]struct{}, len(cols))
+	for _, col := range cols {
+		colIDs[col.ID] = struct{}{}
+	}
+	if len(colIDs) != len(idx.FullTextColumns) {
+		return false
+	}
+	for _, name := range idx.FullTextColumns {
+		colInfo := model.FindColumnInfo(ds.tableInfo.Columns, name.L)
+		if colInfo == nil {
+			return false
+		}
+		if _, ok := colIDs[colInfo.ID]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// buildPartialPath4FullTextIndex builds a partial path on the FULLTEXT index to look up the term.
+func (ds *DataSource) buildPartialPath4FullTextIndex(term fulltext.IndexTerm, idxCol *expression.Column, idx *model.IndexInfo) (*util.AccessPath, error) {
+	// The tokens are written into the index as bytes, see getFullTextIndexedValue in table/tables.
+	ran := &ranger.Range{
+		LowVal:    []types.Datum{types.NewBytesDatum([]byte(term.Token))},
+		HighVal:   []types.Datum{types.NewBytesDatum([]byte(term.Token))},
+		Collators: collate.GetBinaryCollatorSlice(1),
+	}
+	if term.Prefix {
+		ran.HighVal = []types.Datum{types.NewBytesDatum(kv.Key(term.Token).PrefixNext())}
+		ran.HighExclude = true
+	}
+	partialPath := &util.AccessPath{
+		Index:          idx,
+		Ranges:         ranger.Ranges{ran},
+		IdxCols:        []*expression.Column{idxCol},
+		IdxColLens:     []int{types.UnspecifiedLength},
+		FullIdxCols:    []*expression.Column{idxCol},
+		FullIdxColLens: []int{types.UnspecifiedLength},
+	}
+	var err error
+	partialPath.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.SCtx(), idx.ID, partialPath.Ranges)
+	if err != nil {
+		return nil, err
+	}
+	return partialPath, nil
+}
+
 // buildPartialPathUp4MVIndex builds these partial paths up to a complete index merge path.
 func (*DataSource) buildPartialPathUp4MVIndex(partialPaths []*util.AccessPath, isIntersection bool, remainingFilters []expression.Expression) *util.AccessPath {
 	indexMergePath := &util.AccessPath{PartialIndexPaths: partialPaths, IndexMergeAccessMVIndex: true}
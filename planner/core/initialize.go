@@ -50,6 +50,12 @@ func (sg TiKVSingleGather) Init(ctx sessionctx.Context, offset int) *TiKVSingleG
 	return &sg
 }
 
+// Init initializes LogicalIndexMerge.
+func (im LogicalIndexMerge) Init(ctx sessionctx.Context, offset int) *LogicalIndexMerge {
+	im.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeIndexMerge, &im, offset)
+	return &im
+}
+
 // Init initializes LogicalTableScan.
 func (ts LogicalTableScan) Init(ctx sessionctx.Context, offset int) *LogicalTableScan {
 	ts.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeTableScan, &ts, offset)
@@ -191,6 +197,19 @@ func (p PhysicalTableDual) Init(ctx sessionctx.Context, stats *property.StatsInf
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx sessionctx.Context, offset int) *LogicalJSONTable {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.SetStats(stats)
+	return &p
+}
+
 // Init initializes LogicalMaxOneRow.
 func (p LogicalMaxOneRow) Init(ctx sessionctx.Context, offset int) *LogicalMaxOneRow {
 	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeMaxOneRow, &p, offset)
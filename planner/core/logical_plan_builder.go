@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"math"
 	"math/bits"
+	"math/rand"
 	"sort"
 	"strconv"
 	"strings"
@@ -508,6 +509,8 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -978,10 +981,22 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// Table functions like JSON_TABLE and LATERAL derived tables can reference the columns of the tables
+	// preceding them, so the left side is visible as an outer schema when building the right side.
+	lateral := joinNode.Tp != ast.RightJoin && containsLateralTableSource(joinNode.Right)
+	if lateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if lateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	lateral = lateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -992,7 +1007,22 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var (
+		joinPlan   *LogicalJoin
+		resultPlan LogicalPlan
+	)
+	if lateral {
+		// The right side is evaluated for every row of the left side.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{
+			LogicalJoin: LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin},
+			Lateral:     true,
+		}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -1092,7 +1122,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1102,7 +1132,20 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// containsLateralTableSource checks whether the table reference contains a table source
+// which can reference the columns of the preceding tables, like JSON_TABLE and LATERAL derived tables.
+func containsLateralTableSource(node ast.ResultSetNode) bool {
+	switch x := node.(type) {
+	case *ast.Join:
+		return containsLateralTableSource(x.Left) || (x.Right != nil && containsLateralTableSource(x.Right))
+	case *ast.TableSource:
+		_, ok := x.Source.(*ast.JSONTable)
+		return ok || x.Lateral
+	}
+	return false
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -4527,6 +4570,157 @@ func (b *PlanBuilder) tryToBuildSequence(ctes []*cteInfo, p LogicalPlan) Logical
 	return seq
 }
 
+// buildJSONTable builds the plan of JSON_TABLE. The expression can reference the columns of
+// the tables preceding it in the FROM clause, see buildJoin for how they are made visible.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	dual := LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(expression.NewSchema())
+	oldClause := b.curClause
+	b.curClause = tableFunctionClause
+	expr, np, err := b.rewrite(ctx, jt.Expr, dual, nil, true)
+	b.curClause = oldClause
+	if err != nil {
+		return nil, err
+	}
+	if np != dual {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("subquery in JSON_TABLE")
+	}
+	if tp := expr.GetType().EvalType(); tp != types.ETJson && tp != types.ETString {
+		return nil, expression.ErrInvalidTypeForJSON.GenWithStackByArgs(1, "json_table")
+	}
+	path, err := types.ParseJSONPathExpr(jt.Path)
+	if err != nil {
+		return nil, err
+	}
+	p := LogicalJSONTable{AsName: asName, Expr: expr, Path: path}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Columns, err = b.buildJSONTableColumns(jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+func (b *PlanBuilder) buildJSONTableColumns(cols []*ast.JSONTableColumn, asName model.CIStr, schema *expression.Schema, names *types.NameSlice) ([]*JSONTableColumn, error) {
+	result := make([]*JSONTableColumn, 0, len(cols))
+	for _, col := range cols {
+		path := types.JSONPathExpression{}
+		if col.Tp != ast.JSONTableColumnOrdinality {
+			var err error
+			path, err = types.ParseJSONPathExpr(col.Path)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTableColumns(col.NestedColumns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, &JSONTableColumn{Tp: col.Tp, Path: path, Offset: -1, Nested: nested})
+			continue
+		}
+		var retType *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			retType = types.NewFieldType(mysql.TypeLonglong)
+			retType.AddFlag(mysql.UnsignedFlag)
+			retType.SetFlen(mysql.MaxIntWidth)
+			types.SetBinChsClnFlag(retType)
+		} else {
+			var err error
+			retType, err = jsonTableColumnType(col.FieldType)
+			if err != nil {
+				return nil, err
+			}
+		}
+		onEmpty, err := buildJSONTableOnResponse(col.OnEmpty)
+		if err != nil {
+			return nil, err
+		}
+		onError, err := buildJSONTableOnResponse(col.OnError)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range *names {
+			if name.ColName.L == col.Name.L {
+				return nil, ErrDupFieldName.GenWithStackByArgs(col.Name.O)
+			}
+		}
+		result = append(result, &JSONTableColumn{
+			Tp:      col.Tp,
+			Name:    col.Name,
+			Path:    path,
+			Offset:  schema.Len(),
+			RetType: retType,
+			OnEmpty: onEmpty,
+			OnError: onError,
+		})
+		schema.Append(&expression.Column{
+			RetType:  retType,
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			OrigName: fmt.Sprintf("%s.%s", asName.L, col.Name.L),
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			OrigTblName: asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return result, nil
+}
+
+func buildJSONTableOnResponse(resp *ast.JSONTableOnResponse) (JSONTableOnResponse, error) {
+	if resp == nil {
+		return JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}, nil
+	}
+	result := JSONTableOnResponse{Tp: resp.Tp}
+	if resp.Tp == ast.JSONTableOnResponseDefault {
+		var err error
+		result.Default, err = types.ParseBinaryJSONFromString(resp.Default)
+		if err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// jsonTableColumnType fills the unspecified length, decimal, charset and collation of
+// a JSON_TABLE column type with their defaults.
+func jsonTableColumnType(tp *types.FieldType) (*types.FieldType, error) {
+	ft := tp.Clone()
+	if ft.EvalType() == types.ETString && ft.GetCharset() != charset.CharsetBin {
+		if ft.GetCharset() == "" {
+			chs, coll := charset.GetDefaultCharsetAndCollate()
+			ft.SetCharset(chs)
+			if ft.GetCollate() == "" {
+				ft.SetCollate(coll)
+			}
+		}
+		if ft.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(ft.GetCharset())
+			if err != nil {
+				return nil, err
+			}
+			ft.SetCollate(coll)
+		}
+	} else {
+		types.SetBinChsClnFlag(ft)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(defaultFlen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(defaultDecimal)
+	}
+	return ft, nil
+}
+
 func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	b.handleHelper.pushMap(nil)
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
@@ -4789,7 +4983,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 	tableInfo := tbl.Meta()
 
-	if b.isCreateView && tableInfo.TempTableType == model.TempTableLocal {
+	if (b.isCreateView || b.isCreateMaterializedView) && tableInfo.TempTableType == model.TempTableLocal {
 		return nil, ErrViewSelectTemporaryTable.GenWithStackByArgs(tn.Name)
 	}
 
@@ -4925,6 +5119,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		}
 	}
 
+	if tn.TableSample != nil && tn.TableSample.SampleMethod == ast.SampleMethodTypeSystem {
+		possiblePaths, err = filterPathsForSystemSample(tableInfo, possiblePaths)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Try to substitute generate column only if there is an index on generate column.
 	for _, index := range tableInfo.Indices {
 		if index.State != model.StatePublic {
@@ -5066,8 +5267,15 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	ds.SetSchema(schema)
 	ds.names = names
 	ds.setPreferredStoreType(b.TableHints())
-	ds.SampleInfo = NewTableSampleInfo(tn.TableSample, schema.Clone(), b.partitionedTable)
-	b.isSampling = ds.SampleInfo != nil
+	sampleInfo, err := b.buildTableSampleInfo(tn.TableSample, schema.Clone())
+	if err != nil {
+		return nil, err
+	}
+	// The BERNOULLI sampling is done by a selection on the data source, see buildBernoulliSampleSelection.
+	if sampleInfo != nil && sampleInfo.AstNode.SampleMethod != ast.SampleMethodTypeBernoulli {
+		ds.SampleInfo = sampleInfo
+	}
+	b.isSampling = ds.SampleInfo.needTableSamplePlan()
 
 	for i, colExpr := range ds.Schema().Columns {
 		var expr expression.Expression
@@ -5114,7 +5322,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 
 	var result LogicalPlan = ds
 	dirty := tableHasDirtyContent(b.ctx, tableInfo)
-	if dirty || tableInfo.TempTableType == model.TempTableLocal || tableInfo.TableCacheStatusType == model.TableCacheStatusEnable {
+	if dirty || tableInfo.HasSessionScopedRows() || tableInfo.TableCacheStatusType == model.TableCacheStatusEnable {
 		us := LogicalUnionScan{handleCols: handleCols}.Init(b.ctx, b.getSelectOffset())
 		us.SetChildren(ds)
 		if tableInfo.Partition != nil && b.optFlag&flagPartitionProcessor == 0 {
@@ -5126,6 +5334,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		result = us
 	}
 
+	if sampleInfo != nil && sampleInfo.AstNode.SampleMethod == ast.SampleMethodTypeBernoulli {
+		result, err = b.buildBernoulliSampleSelection(result, handleCols, sampleInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Adding ExtraPhysTblIDCol for SelectLock (SELECT FOR UPDATE) is done when building SelectLock
 
 	if sessionVars.StmtCtx.TblInfo2UnionScan == nil {
@@ -5136,6 +5351,92 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	return result, nil
 }
 
+// buildTableSampleInfo builds the TableSampleInfo of the TABLESAMPLE clause. The sample percentage and the seed
+// of the SYSTEM and BERNOULLI sampling methods are evaluated here, a random seed is used if REPEATABLE is absent.
+func (b *PlanBuilder) buildTableSampleInfo(node *ast.TableSample, fullSchema *expression.Schema) (*TableSampleInfo, error) {
+	info := NewTableSampleInfo(node, fullSchema, b.partitionedTable)
+	if info == nil || node.SampleMethod == ast.SampleMethodTypeTiDBRegion {
+		return info, nil
+	}
+	sc := b.ctx.GetSessionVars().StmtCtx
+	// The percentage and the seed are evaluated into the plan, so it can't be cached.
+	sc.SetSkipPlanCache(errors.New("query has TABLESAMPLE clause"))
+	percent, err := expression.EvalAstExpr(b.ctx, node.Expr)
+	if err != nil {
+		return nil, err
+	}
+	if !percent.IsNull() {
+		info.Percent, err = percent.ToFloat64(sc)
+	}
+	if percent.IsNull() || err != nil || info.Percent < 0 || info.Percent > 100 {
+		return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("Sample percentage must be between 0 and 100")
+	}
+	if node.RepeatableSeed == nil {
+		info.Seed = rand.Int63() // #nosec G404
+		return info, nil
+	}
+	seed, err := expression.EvalAstExpr(b.ctx, node.RepeatableSeed)
+	if err != nil {
+		return nil, err
+	}
+	if !seed.IsNull() {
+		info.Seed, err = seed.ToInt64(sc)
+	}
+	if seed.IsNull() || err != nil {
+		return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("Invalid REPEATABLE seed")
+	}
+	return info, nil
+}
+
+// filterPathsForSystemSample keeps only the TiKV table path for the SYSTEM sampling method, which takes
+// the regions of the table as the sampling blocks.
+func filterPathsForSystemSample(tableInfo *model.TableInfo, paths []*util.AccessPath) ([]*util.AccessPath, error) {
+	if tableInfo.TempTableType != model.TempTableNone || tableInfo.TableCacheStatusType == model.TableCacheStatusEnable {
+		return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("Unsupported SYSTEM sampling method in temporary or cached tables")
+	}
+	for _, path := range paths {
+		if path.IsTablePath() && path.StoreType == kv.TiKV {
+			return []*util.AccessPath{path}, nil
+		}
+	}
+	return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("SYSTEM sampling method can only read the table from TiKV")
+}
+
+// buildBernoulliSampleSelection builds a selection which keeps the rows whose crc32 hash of the seed and the handle
+// is below the threshold of the sample percentage. So the sample is deterministic for the same seed, and the
+// selection can be pushed down to the coprocessor like other filters.
+func (b *PlanBuilder) buildBernoulliSampleSelection(p LogicalPlan, handleCols HandleCols, info *TableSampleInfo) (LogicalPlan, error) {
+	if info.Percent >= 100 {
+		return p, nil
+	}
+	sep := &expression.Constant{
+		Value:   types.NewStringDatum(","),
+		RetType: types.NewFieldTypeWithCollation(mysql.TypeVarString, charset.CollationBin, 1),
+	}
+	args := []expression.Expression{sep, expression.NewInt64Const(info.Seed)}
+	for i := 0; i < handleCols.NumCols(); i++ {
+		args = append(args, handleCols.GetCol(i))
+	}
+	hashInput, err := expression.NewFunction(b.ctx, ast.ConcatWS, types.NewFieldType(mysql.TypeVarString), args...)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := expression.NewFunction(b.ctx, ast.CRC32, types.NewFieldType(mysql.TypeLonglong), hashInput)
+	if err != nil {
+		return nil, err
+	}
+	thresholdType := types.NewFieldType(mysql.TypeLonglong)
+	thresholdType.AddFlag(mysql.UnsignedFlag)
+	threshold := expression.NewUInt64ConstWithFieldType(info.HashThreshold(), thresholdType)
+	cond, err := expression.NewFunction(b.ctx, ast.LT, types.NewFieldType(mysql.TypeTiny), hash, threshold)
+	if err != nil {
+		return nil, err
+	}
+	sel := LogicalSelection{Conditions: []expression.Expression{cond}}.Init(b.ctx, b.getSelectOffset())
+	sel.SetChildren(p)
+	return sel, nil
+}
+
 // ExtractFD implements the LogicalPlan interface.
 func (ds *DataSource) ExtractFD() *fd.FDSet {
 	// FD in datasource (leaf node) can be cached and reused.
@@ -5997,7 +6298,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		foundListItem := false
 		for _, tl := range tableList {
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
+				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() || b.isReadOnlyMaterializedView(tl.TableInfo) {
 					return nil, nil, false, ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				foundListItem = true
@@ -6319,6 +6620,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if b.isReadOnlyMaterializedView(tn.TableInfo) {
+				return nil, ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+			}
 			if sessionVars.User != nil {
 				authErr = ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6338,6 +6642,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if b.isReadOnlyMaterializedView(v.TableInfo) {
+				return nil, ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -20,9 +20,11 @@ import (
 	"math"
 	"unsafe"
 
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/model"
@@ -36,6 +38,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/ranger"
 	"github.com/pingcap/tidb/util/size"
 	"github.com/pingcap/tipb/go-tipb"
@@ -52,6 +55,7 @@ var (
 	_ LogicalPlan = &LogicalTableDual{}
 	_ LogicalPlan = &DataSource{}
 	_ LogicalPlan = &TiKVSingleGather{}
+	_ LogicalPlan = &LogicalIndexMerge{}
 	_ LogicalPlan = &LogicalTableScan{}
 	_ LogicalPlan = &LogicalIndexScan{}
 	_ LogicalPlan = &LogicalUnionAll{}
@@ -60,6 +64,7 @@ var (
 	_ LogicalPlan = &LogicalLimit{}
 	_ LogicalPlan = &LogicalWindow{}
 	_ LogicalPlan = &LogicalExpand{}
+	_ LogicalPlan = &LogicalJSONTable{}
 )
 
 // JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, SemiJoin, AntiJoin.
@@ -216,6 +221,16 @@ func (p *LogicalJoin) Shallow() *LogicalJoin {
 	return join.Init(p.SCtx(), p.SelectBlockOffset())
 }
 
+// CanReorder checks whether the join can take part in the join reorder, which
+// requires it to be an inner join without any join order or join algorithm hints.
+func (p *LogicalJoin) CanReorder() bool {
+	if p.JoinType != InnerJoin || p.StraightJoin || p.SCtx().GetSessionVars().StmtCtx.StraightJoinOrder {
+		return false
+	}
+	return p.hintInfo == nil && p.preferJoinType == 0 && !p.preferJoinOrder &&
+		p.leftPreferJoinType == 0 && p.rightPreferJoinType == 0 && len(p.NAEQConditions) == 0 && len(p.DefaultValues) == 0
+}
+
 // ExtractFD implements the interface LogicalPlan.
 func (p *LogicalJoin) ExtractFD() *fd.FDSet {
 	switch p.JoinType {
@@ -1346,6 +1361,8 @@ type LogicalApply struct {
 	CorCols []*expression.CorrelatedColumn
 	// NoDecorrelate is from /*+ no_decorrelate() */ hint.
 	NoDecorrelate bool
+	// Lateral indicates the apply is built from a table reference like `t, LATERAL (subquery) AS dt`.
+	Lateral bool
 }
 
 // ExtractCorrelatedCols implements LogicalPlan interface.
@@ -1407,6 +1424,46 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// JSONTableColumn is a resolved column definition of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name model.CIStr
+	// Path is the JSON path relative to the row path of the nesting level.
+	Path types.JSONPathExpression
+	// Offset is the position of the column in the output schema, it is -1 for nested paths.
+	Offset int
+	// RetType is the type of the output column, it is nil for nested paths.
+	RetType *types.FieldType
+	// OnEmpty and OnError are the resolved ON EMPTY and ON ERROR clauses of path columns.
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+	// Nested are the columns of a nested path.
+	Nested []*JSONTableColumn
+}
+
+// JSONTableOnResponse is a resolved ON EMPTY or ON ERROR clause of JSON_TABLE.
+type JSONTableOnResponse struct {
+	Tp      ast.JSONTableOnResponseType
+	Default types.BinaryJSON
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function. Expr may contain correlated
+// columns of the preceding tables, in this case the plan is built as the inner side of an Apply.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// AsName is the alias of the JSON_TABLE.
+	AsName  model.CIStr
+	Expr    expression.Expression
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -1529,6 +1586,19 @@ type TiKVSingleGather struct {
 	// PhysicalTableReader or PhysicalIndexReader.
 	IsIndexGather bool
 	Index         *model.IndexInfo
+	// StoreType is the storage which the tuples are gathered from, it's
+	// kv.TiKV or kv.TiFlash.
+	StoreType kv.StoreType
+}
+
+// LogicalIndexMerge is a leaf logical operator of TiDB layer to read the
+// table by the IndexMerge access path. It's only used by the cascades planner.
+type LogicalIndexMerge struct {
+	logicalSchemaProducer
+	// Source is the DataSource filtered by the conditions, whose stats and
+	// access paths are derived by these conditions.
+	Source *DataSource
+	Path   *util.AccessPath
 }
 
 // LogicalTableScan is the logical table scan operator for TiKV.
@@ -1587,10 +1657,10 @@ func getTablePath(paths []*util.AccessPath) *util.AccessPath {
 	return nil
 }
 
-func (ds *DataSource) buildTableGather() LogicalPlan {
+func (ds *DataSource) buildTableGather(storeType kv.StoreType) LogicalPlan {
 	ts := LogicalTableScan{Source: ds, HandleCols: ds.handleCols}.Init(ds.SCtx(), ds.SelectBlockOffset())
 	ts.SetSchema(ds.Schema())
-	sg := TiKVSingleGather{Source: ds, IsIndexGather: false}.Init(ds.SCtx(), ds.SelectBlockOffset())
+	sg := TiKVSingleGather{Source: ds, IsIndexGather: false, StoreType: storeType}.Init(ds.SCtx(), ds.SelectBlockOffset())
 	sg.SetSchema(ds.Schema())
 	sg.SetChildren(ts)
 	return sg
@@ -1616,17 +1686,110 @@ func (ds *DataSource) buildIndexGather(path *util.AccessPath) LogicalPlan {
 		Source:        ds,
 		IsIndexGather: true,
 		Index:         path.Index,
+		StoreType:     kv.TiKV,
 	}.Init(ds.SCtx(), ds.SelectBlockOffset())
 	sg.SetSchema(ds.Schema())
 	sg.SetChildren(is)
 	return sg
 }
 
-// Convert2Gathers builds logical TiKVSingleGathers from DataSource.
+// CheckConvert2Gathers checks whether the DataSource can be converted to TiKVSingleGathers. It returns
+// ErrNotSupportedYet if the DataSource needs the access paths which are only supported by the default
+// planner, e.g, table sample and the TiFlash reads in the sessions which ban both MPP and cop tasks.
+func (ds *DataSource) CheckConvert2Gathers() error {
+	var feature string
+	switch {
+	case ds.SampleInfo != nil:
+		feature = "TABLESAMPLE"
+	case !ds.hasTiKVPath() && !ds.canReadFromTiFlash():
+		feature = "reading from TiFlash"
+	default:
+		return nil
+	}
+	return ErrNotSupportedYet.GenWithStackByArgs(feature + " in the cascades planner")
+}
+
+// hasTiKVPath checks whether the DataSource can be read from TiKV.
+func (ds *DataSource) hasTiKVPath() bool {
+	for _, path := range ds.possibleAccessPaths {
+		if path.StoreType == kv.TiKV {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert2IndexMerges builds LogicalIndexMerges from the DataSource filtered by the conditions, so
+// each of them can replace the Selection on the DataSource. The access paths are derived on a copy
+// of the DataSource. It returns whether the LogicalIndexMerges are specified by the hints, in which
+// case the other access paths should be ignored.
+func (ds *DataSource) Convert2IndexMerges(conds []expression.Expression) (merges []LogicalPlan, preferred bool, err error) {
+	newDataSource := *ds
+	newDataSource.baseLogicalPlan = newBaseLogicalPlan(ds.SCtx(), plancodec.TypeTableScan, &newDataSource, ds.SelectBlockOffset())
+	newDataSource.SetID(ds.ID())
+	newDataSource.allConds = make([]expression.Expression, len(conds))
+	copy(newDataSource.allConds, conds)
+	newDataSource.pushedDownConds, _ = expression.PushDownExprs(ds.SCtx().GetSessionVars().StmtCtx, newDataSource.allConds, ds.SCtx().GetClient(), kv.UnSpecified)
+	newDataSource.possibleAccessPaths = make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		newPath := *path
+		newDataSource.possibleAccessPaths = append(newDataSource.possibleAccessPaths, &newPath)
+	}
+	if _, err = newDataSource.DeriveStats(nil, newDataSource.schema, nil, nil); err != nil {
+		return nil, false, err
+	}
+	for _, path := range newDataSource.possibleAccessPaths {
+		if path.PartialIndexPaths == nil {
+			continue
+		}
+		im := LogicalIndexMerge{Source: &newDataSource, Path: path}.Init(ds.SCtx(), ds.SelectBlockOffset())
+		im.SetSchema(ds.Schema())
+		merges = append(merges, im)
+	}
+	return merges, len(merges) > 0 && len(newDataSource.indexMergeHints) > 0, nil
+}
+
+// canReadFromTiFlash checks whether the cascades planner can read the DataSource from TiFlash.
+func (ds *DataSource) canReadFromTiFlash() bool {
+	sessVars := ds.SCtx().GetSessionVars()
+	switch {
+	case ds.preferStoreType&preferTiKV != 0:
+		return false
+	// The locking reads in the explicit transactions should read the latest data from TiKV.
+	case ds.isForUpdateRead && sessVars.TxnCtx.IsExplicit:
+		return false
+	// The TiFlash TableScans built by the cascades planner don't carry the partition infos
+	// which are needed in the dynamic partition prune mode.
+	case ds.tableInfo.GetPartitionInfo() != nil && sessVars.StmtCtx.UseDynamicPartitionPrune():
+		return false
+	case !sessVars.IsMPPAllowed() && (sessVars.IsTiFlashCopBanned() || config.GetGlobalConfig().DisaggregatedTiFlash):
+		return false
+	}
+	for _, path := range ds.possibleAccessPaths {
+		if path.StoreType == kv.TiFlash {
+			return true
+		}
+	}
+	return false
+}
+
+// Convert2Gathers builds logical TiKVSingleGathers from DataSource. The TiFlash table gather is built
+// if the DataSource can be read from TiFlash, and the TiKV gathers are skipped if TiFlash is preferred.
 func (ds *DataSource) Convert2Gathers() (gathers []LogicalPlan) {
-	tg := ds.buildTableGather()
-	gathers = append(gathers, tg)
+	if ds.canReadFromTiFlash() {
+		gathers = append(gathers, ds.buildTableGather(kv.TiFlash))
+		if ds.preferStoreType&preferTiFlash != 0 {
+			return gathers
+		}
+	}
+	if !ds.hasTiKVPath() {
+		return gathers
+	}
+	gathers = append(gathers, ds.buildTableGather(kv.TiKV))
 	for _, path := range ds.possibleAccessPaths {
+		if path.StoreType != kv.TiKV {
+			continue
+		}
 		if !path.IsIntHandlePath {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
 			path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.schema.Columns, path.Index)
@@ -1661,6 +1824,10 @@ func (ds *DataSource) detachCondAndBuildRangeForPath(path *util.AccessPath, cond
 			path.ConstCols[i] = res.ColumnValues[i] != nil
 		}
 	}
+	if path.Index.Tp == model.IndexTypeHypo && !ds.statisticTable.Pseudo {
+		path.CountAfterAccess = ds.getHypoIndexRowCount(path.AccessConds)
+		return nil
+	}
 	path.CountAfterAccess, err = ds.tableStats.HistColl.GetRowCountByIndexRanges(ds.SCtx(), path.Index.ID, path.Ranges)
 	return err
 }
@@ -2200,6 +2367,7 @@ type ShowContents struct {
 	Partition         model.CIStr     // Use for showing partition
 	Column            *ast.ColumnName // Used for `desc table column`.
 	IndexName         model.CIStr
+	Procedure         *ast.TableName       // Used for showing create procedure.
 	ResourceGroupName string               // Used for showing resource group
 	Flag              int                  // Some flag parsed from sql, such as FULL.
 	User              *auth.UserIdentity   // Used for show grants.
@@ -0,0 +1,490 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// nonIncrementalFunctions are the functions whose results may change while the base tables don't, the views using
+// them can't be refreshed incrementally.
+var nonIncrementalFunctions = map[string]struct{}{
+	ast.Sysdate:      {},
+	ast.Rand:         {},
+	ast.UUID:         {},
+	ast.UUIDShort:    {},
+	ast.Sleep:        {},
+	ast.Benchmark:    {},
+	ast.GetLock:      {},
+	ast.FoundRows:    {},
+	ast.RowCount:     {},
+	ast.LastInsertId: {},
+	ast.ConnectionID: {},
+	ast.CurrentUser:  {},
+	ast.CurrentRole:  {},
+	ast.SessionUser:  {},
+	ast.SystemUser:   {},
+	ast.User:         {},
+	ast.Database:     {},
+	ast.Schema:       {},
+	ast.NextVal:      {},
+	ast.LastVal:      {},
+	ast.SetVal:       {},
+	ast.GetVar:       {},
+	ast.SetVar:       {},
+}
+
+// restoreMaterializedViewQuery restores the query of a materialized view. It's used both to store the query and to
+// match the queries which can be answered by the view, so the restored text must be stable.
+func restoreMaterializedViewQuery(node ast.StmtNode) (string, error) {
+	// Always use `format.RestoreNameBackQuotes` despite the `ANSI_QUOTES` SQL Mode is enabled or not, like views.
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// materializedViewQueryDigest returns the digest of the normalized query, it's used to match the queries which can be
+// answered by a materialized view. The keywords and the names are restored in the same cases, so the queries only
+// different in them are matched.
+func materializedViewQueryDigest(node ast.StmtNode) (string, error) {
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameLowercase |
+		format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return "", err
+	}
+	return parser.DigestNormalized(sb.String()).String(), nil
+}
+
+// buildCreateMaterializedView plans the query of a materialized view, it fills the names and the types of the view
+// columns, and decides whether the view can be refreshed incrementally.
+func (b *PlanBuilder) buildCreateMaterializedView(ctx context.Context, v *ast.CreateMaterializedViewStmt) error {
+	// Restore the query before building it, the building may rewrite the AST.
+	definition, err := restoreMaterializedViewQuery(v.Select)
+	if err != nil {
+		return err
+	}
+	digest, err := materializedViewQueryDigest(v.Select)
+	if err != nil {
+		return err
+	}
+	b.isCreateMaterializedView = true
+	defer func() {
+		b.isCreateMaterializedView = false
+	}()
+	plan, err := b.Build(ctx, v.Select)
+	if err != nil {
+		return err
+	}
+	schema := plan.Schema()
+	names := plan.OutputNames()
+	if v.Cols == nil {
+		adjustOverlongViewColname(plan.(LogicalPlan))
+		v.Cols = make([]model.CIStr, len(schema.Columns))
+		for i, name := range names {
+			v.Cols[i] = name.ColName
+		}
+	}
+	if len(v.Cols) != schema.Len() {
+		return dbterror.ErrViewWrongList
+	}
+	v.ColumnTypes = make([]*types.FieldType, 0, schema.Len())
+	for _, col := range schema.Columns {
+		v.ColumnTypes = append(v.ColumnTypes, materializedViewColumnType(col.RetType))
+	}
+	sqlMode, _ := b.ctx.GetSessionVars().GetSystemVar(variable.SQLModeVar)
+	v.Info = &model.MaterializedViewInfo{
+		Definition:   definition,
+		Digest:       digest,
+		QueryColumns: make([]model.CIStr, 0, len(names)),
+		SQLMode:      sqlMode,
+	}
+	for _, name := range names {
+		v.Info.QueryColumns = append(v.Info.QueryColumns, name.ColName)
+	}
+	if baseTables, ok := b.incrementalMaterializedViewBaseTables(v.Select, v.Cols, names); ok {
+		v.Info.Incremental = true
+		v.Info.BaseTables = baseTables
+	} else {
+		v.Info.BaseTables = b.materializedViewBaseTables(v.Select)
+	}
+	return nil
+}
+
+// materializedViewColumnType converts the type of a query result column to the type of the view column storing it.
+func materializedViewColumnType(tp *types.FieldType) *types.FieldType {
+	if tp.GetType() == mysql.TypeNull {
+		ft := types.NewFieldType(mysql.TypeString)
+		ft.SetFlen(0)
+		ft.SetCharset(charset.CharsetBin)
+		ft.SetCollate(charset.CollationBin)
+		ft.AddFlag(mysql.BinaryFlag)
+		return ft
+	}
+	ft := tp.Clone()
+	// The result of an expression may be NULL even if its arguments aren't, so NOT NULL isn't kept.
+	ft.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag))
+	switch ft.GetType() {
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeString:
+		maxLen := 1
+		if cs, err := charset.GetCharsetInfo(ft.GetCharset()); err == nil {
+			maxLen = cs.Maxlen
+		}
+		switch {
+		case ft.GetFlen() == types.UnspecifiedLength || ft.GetFlen()*maxLen > mysql.MaxFieldVarCharLength:
+			ft.SetType(mysql.TypeLongBlob)
+			ft.SetFlen(types.UnspecifiedLength)
+		case ft.GetType() != mysql.TypeString || ft.GetFlen() > mysql.MaxFieldCharLength:
+			ft.SetType(mysql.TypeVarchar)
+		}
+	case mysql.TypeNewDecimal:
+		if ft.GetFlen() == types.UnspecifiedLength || ft.GetDecimal() == types.UnspecifiedLength {
+			ft.SetFlen(mysql.MaxDecimalWidth)
+			ft.SetDecimal(mysql.MaxDecimalScale)
+		}
+	}
+	return ft
+}
+
+// materializedViewBaseTables collects the tables and views the query reads.
+func (b *PlanBuilder) materializedViewBaseTables(node ast.StmtNode) []*model.MaterializedViewBaseTable {
+	collector := &tableNameCollector{}
+	node.Accept(collector)
+	seen := make(map[int64]struct{}, len(collector.names))
+	baseTables := make([]*model.MaterializedViewBaseTable, 0, len(collector.names))
+	for _, tn := range collector.names {
+		// The names of the CTEs aren't qualified by the schema names.
+		if tn.Schema.L == "" {
+			continue
+		}
+		tbl, err := b.is.TableByName(tn.Schema, tn.Name)
+		if err != nil {
+			continue
+		}
+		tblInfo := tbl.Meta()
+		if _, ok := seen[tblInfo.ID]; ok {
+			continue
+		}
+		seen[tblInfo.ID] = struct{}{}
+		baseTables = append(baseTables, &model.MaterializedViewBaseTable{
+			ID:     tblInfo.ID,
+			Schema: tn.Schema,
+			Name:   tblInfo.Name,
+			Alias:  tblInfo.Name,
+		})
+	}
+	return baseTables
+}
+
+// incrementalMaterializedViewBaseTables returns the base tables of the query with their key columns if the view can
+// be refreshed incrementally. The changed rows of a base table affect the view rows whose key columns are equal to
+// theirs, so the view is refreshed by recomputing these rows. It's the case when the query is either:
+//   - an aggregation of a single table grouped by the columns of the table, the keys are the group columns;
+//   - an inner join of the tables with primary keys, the keys are the primary key columns.
+//
+// The key columns must be in the output of the query.
+func (b *PlanBuilder) incrementalMaterializedViewBaseTables(node ast.StmtNode, cols []model.CIStr, names types.NameSlice) ([]*model.MaterializedViewBaseTable, bool) {
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.With != nil || sel.Distinct || sel.OrderBy != nil || sel.Limit != nil || len(sel.WindowSpecs) > 0 ||
+		sel.From == nil || (sel.GroupBy != nil && sel.GroupBy.Rollup) {
+		return nil, false
+	}
+	checker := &incrementalQueryChecker{incremental: true}
+	sel.Accept(checker)
+	if !checker.incremental {
+		return nil, false
+	}
+	sources, ok := innerJoinTableSources(sel.From.TableRefs, nil)
+	if !ok {
+		return nil, false
+	}
+	baseTables := make([]*model.MaterializedViewBaseTable, 0, len(sources))
+	tblInfos := make([]*model.TableInfo, 0, len(sources))
+	for _, source := range sources {
+		tn := source.Source.(*ast.TableName)
+		if util.IsMemOrSysDB(tn.Schema.L) {
+			return nil, false
+		}
+		tbl, err := b.is.TableByName(tn.Schema, tn.Name)
+		if err != nil {
+			return nil, false
+		}
+		tblInfo := tbl.Meta()
+		if !tblInfo.IsBaseTable() || tblInfo.TempTableType != model.TempTableNone {
+			return nil, false
+		}
+		alias := source.AsName
+		if alias.L == "" {
+			alias = tblInfo.Name
+		}
+		baseTables = append(baseTables, &model.MaterializedViewBaseTable{
+			ID:     tblInfo.ID,
+			Schema: tn.Schema,
+			Name:   tblInfo.Name,
+			Alias:  alias,
+		})
+		tblInfos = append(tblInfos, tblInfo)
+	}
+	if checker.hasAgg || sel.GroupBy != nil {
+		// The scalar aggregation always outputs a row, and the aggregation of joins can't be located by the keys of a
+		// single table.
+		if sel.GroupBy == nil || len(baseTables) != 1 {
+			return nil, false
+		}
+		for _, item := range sel.GroupBy.Items {
+			colExpr, ok := item.Expr.(*ast.ColumnNameExpr)
+			if !ok || model.FindColumnInfo(tblInfos[0].Columns, colExpr.Name.Name.L) == nil {
+				return nil, false
+			}
+			baseTables[0].KeyColumns = append(baseTables[0].KeyColumns, colExpr.Name.Name)
+		}
+	} else {
+		for i, tblInfo := range tblInfos {
+			keys := primaryKeyColumnNames(tblInfo)
+			if len(keys) == 0 {
+				return nil, false
+			}
+			baseTables[i].KeyColumns = keys
+		}
+	}
+	for i, base := range baseTables {
+		for _, key := range base.KeyColumns {
+			colInfo := model.FindColumnInfo(tblInfos[i].Columns, key.L)
+			if !isIncrementalKeyType(&colInfo.FieldType) {
+				return nil, false
+			}
+			idx := -1
+			for j, name := range names {
+				if name.TblName.L == base.Alias.L && name.OrigColName.L == key.L {
+					idx = j
+					break
+				}
+			}
+			if idx < 0 {
+				return nil, false
+			}
+			base.ViewColumns = append(base.ViewColumns, cols[idx])
+		}
+	}
+	return baseTables, true
+}
+
+// isIncrementalKeyType checks whether the values of the type are compared exactly after they're logged and read back.
+func isIncrementalKeyType(tp *types.FieldType) bool {
+	switch tp.GetType() {
+	case mysql.TypeFloat, mysql.TypeDouble, mysql.TypeTimestamp, mysql.TypeJSON, mysql.TypeBit, mysql.TypeGeometry:
+		return false
+	}
+	return true
+}
+
+// primaryKeyColumnNames returns the names of the primary key columns, or nil if the table has no primary key.
+func primaryKeyColumnNames(tblInfo *model.TableInfo) []model.CIStr {
+	if tblInfo.PKIsHandle {
+		if pk := tblInfo.GetPkColInfo(); pk != nil {
+			return []model.CIStr{pk.Name}
+		}
+		return nil
+	}
+	for _, idx := range tblInfo.Indices {
+		if !idx.Primary {
+			continue
+		}
+		names := make([]model.CIStr, 0, len(idx.Columns))
+		for _, col := range idx.Columns {
+			names = append(names, col.Name)
+		}
+		return names
+	}
+	return nil
+}
+
+// innerJoinTableSources returns the table sources of the inner joins of tables.
+func innerJoinTableSources(node ast.ResultSetNode, sources []*ast.TableSource) ([]*ast.TableSource, bool) {
+	switch x := node.(type) {
+	case *ast.Join:
+		if x.Tp != ast.CrossJoin || x.StraightJoin {
+			return nil, false
+		}
+		left, ok := innerJoinTableSources(x.Left, sources)
+		if !ok || x.Right == nil {
+			return left, ok
+		}
+		return innerJoinTableSources(x.Right, left)
+	case *ast.TableSource:
+		tn, ok := x.Source.(*ast.TableName)
+		if !ok || tn.Schema.L == "" || len(tn.PartitionNames) > 0 || tn.TableSample != nil {
+			return nil, false
+		}
+		return append(sources, x), true
+	}
+	return nil, false
+}
+
+// incrementalQueryChecker checks whether the expressions of a query prevent it from being refreshed incrementally.
+type incrementalQueryChecker struct {
+	incremental bool
+	hasAgg      bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *incrementalQueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr, *ast.WindowFuncExpr, *ast.VariableExpr,
+		*driver.ParamMarkerExpr, *ast.DefaultExpr:
+		c.incremental = false
+	case *ast.FuncCallExpr:
+		if _, ok := nonIncrementalFunctions[x.FnName.L]; ok {
+			c.incremental = false
+		} else if _, ok := expression.DeferredFunctions[x.FnName.L]; ok {
+			c.incremental = false
+		}
+	case *ast.AggregateFuncExpr:
+		c.hasAgg = true
+	}
+	return in, !c.incremental
+}
+
+// Leave implements ast.Visitor interface.
+func (c *incrementalQueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// tableNameCollector collects the table names in a statement.
+type tableNameCollector struct {
+	names []*ast.TableName
+}
+
+// Enter implements ast.Visitor interface.
+func (c *tableNameCollector) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok {
+		c.names = append(c.names, tn)
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *tableNameCollector) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// isReadOnlyMaterializedView checks whether the table is a materialized view which can't be modified by the statement.
+// The views are only modified by the internal statements refreshing them.
+func (b *PlanBuilder) isReadOnlyMaterializedView(tblInfo *model.TableInfo) bool {
+	return tblInfo != nil && tblInfo.IsMaterializedView() && !b.ctx.GetSessionVars().InRestrictedSQL
+}
+
+// tryBuildMaterializedViewScan tries to answer the query by a materialized view whose normalized query is the same. It
+// returns nil if there is no such view, then the query should be built as usual.
+func (b *PlanBuilder) tryBuildMaterializedViewScan(ctx context.Context, sel *ast.SelectStmt) (LogicalPlan, error) {
+	sessVars := b.ctx.GetSessionVars()
+	if !sessVars.EnableMaterializedViewRewrite || sessVars.InRestrictedSQL || b.isCreateView || b.isCreateMaterializedView ||
+		sel.From == nil || sel.OrderBy != nil || (sel.LockInfo != nil && sel.LockInfo.LockType != ast.SelectLockNone) {
+		return nil, nil
+	}
+	// The views reading any of the tables are candidates, the digest decides whether the view reads all of them.
+	collector := &tableNameCollector{}
+	sel.Accept(collector)
+	var candidates []int64
+	seen := make(map[int64]struct{})
+	for _, tn := range collector.names {
+		if tn.TableInfo == nil {
+			continue
+		}
+		for _, id := range b.is.GetTableMaterializedViews(tn.TableInfo.ID) {
+			if _, ok := seen[id]; !ok {
+				seen[id] = struct{}{}
+				candidates = append(candidates, id)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+	digest, err := materializedViewQueryDigest(sel)
+	if err != nil {
+		return nil, nil
+	}
+	var (
+		mvInfo *model.TableInfo
+		dbName model.CIStr
+	)
+	for _, id := range candidates {
+		tbl, ok := b.is.TableByID(id)
+		if !ok || tbl.Meta().MaterializedView.Digest != digest {
+			continue
+		}
+		db, ok := b.is.SchemaByTable(tbl.Meta())
+		if !ok {
+			continue
+		}
+		mvInfo, dbName = tbl.Meta(), db.Name
+		break
+	}
+	if mvInfo == nil {
+		return nil, nil
+	}
+	visitInfoLen := len(b.visitInfo)
+	scan, err := b.buildSelect(ctx, &ast.SelectStmt{
+		Kind:   ast.SelectStmtKindSelect,
+		Fields: &ast.FieldList{Fields: []*ast.SelectField{{WildCard: &ast.WildCardField{}}}},
+		From: &ast.TableRefsClause{TableRefs: &ast.Join{Left: &ast.TableSource{
+			Source: &ast.TableName{Schema: dbName, Name: mvInfo.Name, TableInfo: mvInfo},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	// Reading the view requires the same privileges as the query rather than the privileges of the view.
+	b.visitInfo = b.visitInfo[:visitInfoLen]
+	if len(scan.OutputNames()) != len(mvInfo.MaterializedView.QueryColumns) {
+		return nil, nil
+	}
+	for _, base := range mvInfo.MaterializedView.BaseTables {
+		var authErr error
+		if sessVars.User != nil {
+			authErr = ErrTableaccessDenied.FastGenByArgs("SELECT", sessVars.User.AuthUsername, sessVars.User.AuthHostname, base.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SelectPriv, base.Schema.L, base.Name.L, "", authErr)
+	}
+	// The result columns are named as the ones of the query.
+	names := make(types.NameSlice, 0, len(scan.OutputNames()))
+	for i, name := range scan.OutputNames() {
+		renamed := *name
+		renamed.ColName = mvInfo.MaterializedView.QueryColumns[i]
+		names = append(names, &renamed)
+	}
+	scan.SetOutputNames(names)
+	sessVars.StmtCtx.SetSkipPlanCache(errors.Errorf("query is answered by the materialized view '%s'", mvInfo.Name.O))
+	return scan, nil
+}
@@ -30,6 +30,8 @@ var (
 	nonPreparedPlanCacheUnsupportedCounter prometheus.Counter
 	sessionPlanCacheInstancePlanNumCounter prometheus.Gauge
 	sessionPlanCacheInstanceMemoryUsage    prometheus.Gauge
+	instancePlanCachePlanNumCounter        prometheus.Gauge
+	instancePlanCacheMemoryUsage           prometheus.Gauge
 )
 
 func init() {
@@ -48,6 +50,8 @@ func InitMetricsVars() {
 	nonPreparedPlanCacheUnsupportedCounter = metrics.PlanCacheMissCounter.WithLabelValues("non-prepared-unsupported")
 	sessionPlanCacheInstancePlanNumCounter = metrics.PlanCacheInstancePlanNumCounter.WithLabelValues(" session-plan-cache")
 	sessionPlanCacheInstanceMemoryUsage = metrics.PlanCacheInstanceMemoryUsage.WithLabelValues(" session-plan-cache")
+	instancePlanCachePlanNumCounter = metrics.PlanCacheInstancePlanNumCounter.WithLabelValues(" instance-plan-cache")
+	instancePlanCacheMemoryUsage = metrics.PlanCacheInstanceMemoryUsage.WithLabelValues(" instance-plan-cache")
 }
 
 // GetPlanCacheHitCounter get different plan cache hit counter
@@ -80,3 +84,13 @@ func GetPlanCacheInstanceNumCounter() prometheus.Gauge {
 func GetPlanCacheInstanceMemoryUsage() prometheus.Gauge {
 	return sessionPlanCacheInstanceMemoryUsage
 }
+
+// GetInstancePlanCachePlanNumCounter get the plan counter of the instance-level plan cache
+func GetInstancePlanCachePlanNumCounter() prometheus.Gauge {
+	return instancePlanCachePlanNumCounter
+}
+
+// GetInstancePlanCacheMemoryUsage get the memory usage counter of the instance-level plan cache
+func GetInstancePlanCacheMemoryUsage() prometheus.Gauge {
+	return instancePlanCacheMemoryUsage
+}
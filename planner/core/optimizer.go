@@ -283,6 +283,14 @@ func checkStableResultMode(sctx sessionctx.Context) bool {
 	return s.EnableStableResultMode && (!st.InInsertStmt && !st.InUpdateStmt && !st.InDeleteStmt && !st.InLoadDataStmt)
 }
 
+// StabilizeResults makes the results of the logical plan stable if the stable result mode is enabled.
+func StabilizeResults(ctx context.Context, logic LogicalPlan) (LogicalPlan, error) {
+	if !checkStableResultMode(logic.SCtx()) {
+		return logic, nil
+	}
+	return logicalOptimize(ctx, flagStabilizeResults, logic)
+}
+
 // DoOptimizeAndLogicAsRet optimizes a logical plan to a physical plan and return the optimized logical plan.
 func DoOptimizeAndLogicAsRet(ctx context.Context, sctx sessionctx.Context, flag uint64, logic LogicalPlan) (LogicalPlan, PhysicalPlan, float64, error) {
 	sessVars := sctx.GetSessionVars()
@@ -1336,7 +1344,7 @@ func existsOverlongType(schema *expression.Schema) bool {
 	for _, column := range schema.Columns {
 		switch column.RetType.GetType() {
 		case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
-			mysql.TypeBlob, mysql.TypeJSON:
+			mysql.TypeBlob, mysql.TypeJSON, mysql.TypeGeometry:
 			return true
 		case mysql.TypeVarString, mysql.TypeVarchar:
 			// if the column is varchar and the length of
@@ -21,6 +21,7 @@ import (
 	"unsafe"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/kv"
@@ -49,6 +50,7 @@ var (
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalMaxOneRow{}
 	_ PhysicalPlan = &PhysicalTableDual{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 	_ PhysicalPlan = &PhysicalUnionAll{}
 	_ PhysicalPlan = &PhysicalSort{}
 	_ PhysicalPlan = &NominalSort{}
@@ -233,7 +235,7 @@ func setMppOrBatchCopForTableScan(curPlan PhysicalPlan) {
 
 // GetPhysicalTableReader returns PhysicalTableReader for logical TiKVSingleGather.
 func (sg *TiKVSingleGather) GetPhysicalTableReader(schema *expression.Schema, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalTableReader {
-	reader := PhysicalTableReader{}.Init(sg.SCtx(), sg.SelectBlockOffset())
+	reader := PhysicalTableReader{StoreType: sg.StoreType}.Init(sg.SCtx(), sg.SelectBlockOffset())
 	reader.PartitionInfo = PartitionInfo{
 		PruningConds:   sg.Source.allConds,
 		PartitionNames: sg.Source.partitionNames,
@@ -246,9 +248,49 @@ func (sg *TiKVSingleGather) GetPhysicalTableReader(schema *expression.Schema, st
 	return reader
 }
 
+// CanUseMPP checks whether the TiKVSingleGather can read from TiFlash by MPP.
+func (sg *TiKVSingleGather) CanUseMPP() bool {
+	if sg.StoreType != kv.TiFlash || !sg.SCtx().GetSessionVars().IsMPPAllowed() {
+		return false
+	}
+	// The virtual columns can't be resolved by the MPP table reader, see convertToTableScan.
+	for _, col := range sg.Schema().Columns {
+		if col.VirtualExpr != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// CanUseCop checks whether the TiKVSingleGather can read by cop or batch cop tasks.
+func (sg *TiKVSingleGather) CanUseCop() bool {
+	if sg.StoreType != kv.TiFlash {
+		return true
+	}
+	return !sg.SCtx().GetSessionVars().IsTiFlashCopBanned() && !config.GetGlobalConfig().DisaggregatedTiFlash
+}
+
+// SetMPPChild sets the child of the PhysicalTableReader which reads from TiFlash by MPP,
+// the child is sent to TiDB by a pass-through PhysicalExchangeSender.
+func (p *PhysicalTableReader) SetMPPChild(child PhysicalPlan) {
+	tryExpandVirtualColumn(child)
+	sender := PhysicalExchangeSender{
+		ExchangeType: tipb.ExchangeType_PassThrough,
+	}.Init(p.SCtx(), child.StatsInfo())
+	sender.SetChildren(child)
+	p.SetChildren(sender)
+	collectPartitionInfosFromMPPPlan(p, child)
+}
+
 // GetPhysicalIndexReader returns PhysicalIndexReader for logical TiKVSingleGather.
 func (sg *TiKVSingleGather) GetPhysicalIndexReader(schema *expression.Schema, stats *property.StatsInfo, props ...*property.PhysicalProperty) *PhysicalIndexReader {
 	reader := PhysicalIndexReader{}.Init(sg.SCtx(), sg.SelectBlockOffset())
+	reader.PartitionInfo = PartitionInfo{
+		PruningConds:   sg.Source.allConds,
+		PartitionNames: sg.Source.partitionNames,
+		Columns:        sg.Source.TblCols,
+		ColumnNames:    sg.Source.names,
+	}
 	reader.SetStats(stats)
 	reader.SetSchema(schema)
 	reader.childrenReqProps = props
@@ -278,6 +320,13 @@ func (p *PhysicalTableReader) Clone() (PhysicalPlan, error) {
 func (p *PhysicalTableReader) SetChildren(children ...PhysicalPlan) {
 	p.tablePlan = children[0]
 	p.TablePlans = flattenPushDownPlan(p.tablePlan)
+	if p.StoreType == kv.TiFlash {
+		p.ReadReqType = Cop
+		p.adjustReadReqType(p.SCtx())
+		if p.ReadReqType == BatchCop || p.ReadReqType == MPP {
+			setMppOrBatchCopForTableScan(p.tablePlan)
+		}
+	}
 }
 
 // ExtractCorrelatedCols implements PhysicalPlan interface.
@@ -2252,6 +2301,35 @@ func (p *PhysicalTableDual) MemoryUsage() (sum int64) {
 	return
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	AsName  model.CIStr
+	Expr    expression.Expression
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInterface + size.SizeOfSlice*2 +
+		int64(cap(p.Columns))*size.SizeOfPointer
+	if p.Expr != nil {
+		sum += p.Expr.MemoryUsage()
+	}
+	return
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
@@ -2505,10 +2583,33 @@ type PhysicalTableSample struct {
 }
 
 // TableSampleInfo contains the information for PhysicalTableSample.
+// For the SYSTEM sampling method, it's kept in the PhysicalTableScan to sample the regions to read.
 type TableSampleInfo struct {
 	AstNode    *ast.TableSample
 	FullSchema *expression.Schema
 	Partitions []table.PartitionedTable
+	// Percent and Seed are evaluated from the SYSTEM and BERNOULLI sampling clause.
+	Percent float64
+	Seed    int64
+}
+
+// HashThreshold returns the exclusive upper bound of the crc32 hash values which are sampled, the hash is
+// calculated from the seed and the row handle for BERNOULLI, or the region start key for SYSTEM.
+func (t *TableSampleInfo) HashThreshold() uint64 {
+	return uint64(t.Percent / 100 * (1 << 32))
+}
+
+// needTableSamplePlan returns whether the sampling is done by a PhysicalTableSample, which scans the regions
+// by itself. Otherwise, the rows are read by the normal readers and the sampling is pushed down to the coprocessor.
+func (t *TableSampleInfo) needTableSamplePlan() bool {
+	return t != nil && t.AstNode.SampleMethod == ast.SampleMethodTypeTiDBRegion
+}
+
+func (t *TableSampleInfo) explainInfo(normalized bool) string {
+	if normalized {
+		return "sample:system"
+	}
+	return fmt.Sprintf("sample:system(%v), seed:%d", t.Percent, t.Seed)
 }
 
 // MemoryUsage return the memory usage of TableSampleInfo
@@ -2517,7 +2618,7 @@ func (t *TableSampleInfo) MemoryUsage() (sum int64) {
 		return
 	}
 
-	sum = size.SizeOfPointer*2 + size.SizeOfSlice + int64(cap(t.Partitions))*size.SizeOfInterface
+	sum = size.SizeOfPointer*2 + size.SizeOfSlice + int64(cap(t.Partitions))*size.SizeOfInterface + size.SizeOfFloat64 + size.SizeOfInt64
 	if t.AstNode != nil {
 		sum += int64(unsafe.Sizeof(ast.TableSample{}))
 	}
@@ -254,7 +254,15 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 
-	candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts)
+	var candidate kvcache.Value
+	var exist, fromInstanceCache bool
+	if instanceCache := getInstancePlanCache(sctx); instanceCache != nil {
+		candidate, exist = instanceCache.Get(sctx, cacheKey, matchOpts)
+		fromInstanceCache = exist
+	}
+	if !exist {
+		candidate, exist = sctx.GetSessionPlanCache().Get(cacheKey, matchOpts)
+	}
 	if !exist {
 		return nil, nil, false, nil
 	}
@@ -266,13 +274,20 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
 			// TODO we can inject UnionScan into cached plan to avoid invalidating it, though
 			// rebuilding the filters in UnionScan is pretty trivial.
-			sctx.GetSessionPlanCache().Delete(cacheKey)
+			// The plan in the instance plan cache is still valid for other sessions, so we only skip it.
+			if !fromInstanceCache {
+				sctx.GetSessionPlanCache().Delete(cacheKey)
+			}
 			return nil, nil, false, nil
 		}
 	}
 	if !RebuildPlan4CachedPlan(cachedVal.Plan) {
 		return nil, nil, false, nil
 	}
+	if fromInstanceCache && stmt.PlanDigest == nil {
+		// The plan may be generated by other sessions.
+		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(cachedVal.Plan)
+	}
 	sessVars.FoundInPlanCache = true
 	if len(bindSQL) > 0 {
 		// When the `len(bindSQL) > 0`, it means we use the binding.
@@ -331,12 +346,26 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
 		stmtCtx.SetPlan(p)
 		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
-		sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		if instanceCache := getInstancePlanCache(sctx); instanceCache == nil || !instanceCache.Put(sctx, cacheKey, cached, matchOpts) {
+			sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		}
 	}
 	sessVars.FoundInPlanCache = false
 	return p, names, err
 }
 
+// getInstancePlanCache returns the instance-level plan cache if it's enabled.
+// The plans which can't be shared by sessions are still cached in the session plan cache.
+func getInstancePlanCache(sctx sessionctx.Context) sessionctx.InstancePlanCache {
+	if !variable.EnableInstancePlanCache.Load() {
+		return nil
+	}
+	if dom := domain.GetDomain(sctx); dom != nil {
+		return dom.InstancePlanCache()
+	}
+	return nil
+}
+
 // RebuildPlan4CachedPlan will rebuild this plan under current user parameters.
 func RebuildPlan4CachedPlan(p Plan) (ok bool) {
 	sc := p.SCtx().GetSessionVars().StmtCtx
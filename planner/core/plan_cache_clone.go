@@ -0,0 +1,302 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+// clonePlanForInstancePlanCache clones the physical plan for the instance-level plan cache and binds the cloned plan
+// to the given session context.
+// The cloned plan shares the parts which are never changed after the plan is built, like the schema, the statistics
+// and the table info, with the original plan. All the parts which can be changed when the plan is rebuilt with new
+// parameters or executed, like the expressions, the ranges and the point get values, are copied. So the cached
+// plan can be used by many sessions at the same time.
+// It returns false if some operators of the plan can't be cloned safely, and the plan should only be cached in the
+// session plan cache.
+func clonePlanForInstancePlanCache(p PhysicalPlan, sctx sessionctx.Context) (PhysicalPlan, bool) {
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		tablePlan, ok := clonePlanForInstancePlanCache(x.tablePlan, sctx)
+		if !ok {
+			return nil, false
+		}
+		cloned.tablePlan = tablePlan
+		cloned.TablePlans = flattenPushDownPlan(tablePlan)
+		cloned.PartitionInfo = clonePartitionInfoForPlanCache(x.PartitionInfo, sctx)
+		return &cloned, true
+	case *PhysicalIndexReader:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		indexPlan, ok := clonePlanForInstancePlanCache(x.indexPlan, sctx)
+		if !ok {
+			return nil, false
+		}
+		cloned.indexPlan = indexPlan
+		cloned.IndexPlans = flattenPushDownPlan(indexPlan)
+		cloned.PartitionInfo = clonePartitionInfoForPlanCache(x.PartitionInfo, sctx)
+		return &cloned, true
+	case *PhysicalIndexLookUpReader:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		indexPlan, ok := clonePlanForInstancePlanCache(x.indexPlan, sctx)
+		if !ok {
+			return nil, false
+		}
+		tablePlan, ok := clonePlanForInstancePlanCache(x.tablePlan, sctx)
+		if !ok {
+			return nil, false
+		}
+		cloned.indexPlan, cloned.tablePlan = indexPlan, tablePlan
+		cloned.IndexPlans = flattenPushDownPlan(indexPlan)
+		cloned.TablePlans = flattenPushDownPlan(tablePlan)
+		cloned.PartitionInfo = clonePartitionInfoForPlanCache(x.PartitionInfo, sctx)
+		return &cloned, true
+	case *PhysicalTableScan:
+		if len(x.runtimeFilterList) > 0 {
+			return nil, false
+		}
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.AccessCondition = expression.CloneExprsWithNewCtx(sctx, x.AccessCondition)
+		cloned.filterCondition = expression.CloneExprsWithNewCtx(sctx, x.filterCondition)
+		cloned.lateMaterializationFilterCondition = expression.CloneExprsWithNewCtx(sctx, x.lateMaterializationFilterCondition)
+		cloned.ByItems = cloneByItemsForPlanCache(x.ByItems, sctx)
+		cloned.PartitionInfo = clonePartitionInfoForPlanCache(x.PartitionInfo, sctx)
+		return &cloned, true
+	case *PhysicalIndexScan:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.AccessCondition = expression.CloneExprsWithNewCtx(sctx, x.AccessCondition)
+		cloned.ByItems = cloneByItemsForPlanCache(x.ByItems, sctx)
+		if x.GenExprs != nil {
+			cloned.GenExprs = make(map[model.TableItemID]expression.Expression, len(x.GenExprs))
+			for id, expr := range x.GenExprs {
+				cloned.GenExprs[id] = expression.CloneWithNewCtx(sctx, expr)
+			}
+		}
+		return &cloned, true
+	case *PhysicalSelection:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.Conditions = expression.CloneExprsWithNewCtx(sctx, x.Conditions)
+		return &cloned, true
+	case *PhysicalProjection:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.Exprs = expression.CloneExprsWithNewCtx(sctx, x.Exprs)
+		return &cloned, true
+	case *PhysicalLimit:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		return &cloned, true
+	case *PhysicalTopN:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.ByItems = cloneByItemsForPlanCache(x.ByItems, sctx)
+		return &cloned, true
+	case *PhysicalSort:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.ByItems = cloneByItemsForPlanCache(x.ByItems, sctx)
+		return &cloned, true
+	case *PhysicalHashAgg:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.basePhysicalAgg.cloneExprsForPlanCache(sctx)
+		return &cloned, true
+	case *PhysicalStreamAgg:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.basePhysicalAgg.cloneExprsForPlanCache(sctx)
+		return &cloned, true
+	case *PhysicalHashJoin:
+		if len(x.runtimeFilterList) > 0 {
+			return nil, false
+		}
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		cloned.LeftConditions = expression.CloneExprsWithNewCtx(sctx, x.LeftConditions)
+		cloned.RightConditions = expression.CloneExprsWithNewCtx(sctx, x.RightConditions)
+		cloned.OtherConditions = expression.CloneExprsWithNewCtx(sctx, x.OtherConditions)
+		cloned.EqualConditions = cloneScalarFuncsForPlanCache(x.EqualConditions, sctx)
+		cloned.NAEqualConditions = cloneScalarFuncsForPlanCache(x.NAEqualConditions, sctx)
+		return &cloned, true
+	case *PhysicalUnionAll:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		return &cloned, true
+	case *PhysicalTableDual:
+		cloned := *x
+		if !cloned.cloneBaseForPlanCache(&cloned, sctx) {
+			return nil, false
+		}
+		return &cloned, true
+	case *PointGetPlan:
+		if len(x.probeParents) > 0 || x.PartitionInfo != nil {
+			return nil, false
+		}
+		cloned := *x
+		cloned.SetSCtx(sctx)
+		cloned.ctx = sctx
+		if x.HandleConstant != nil {
+			cloned.HandleConstant = expression.CloneWithNewCtx(sctx, x.HandleConstant).(*expression.Constant)
+		}
+		cloned.IndexConstants = cloneConstantsForPlanCache(x.IndexConstants, sctx)
+		cloned.AccessConditions = expression.CloneExprsWithNewCtx(sctx, x.AccessConditions)
+		cloned.IndexValues = append([]types.Datum(nil), x.IndexValues...)
+		return &cloned, true
+	case *BatchPointGetPlan:
+		if len(x.probeParents) > 0 || x.PartitionExpr != nil {
+			return nil, false
+		}
+		cloned := *x
+		cloned.SetSCtx(sctx)
+		cloned.ctx = sctx
+		cloned.Handles = append([]kv.Handle(nil), x.Handles...)
+		cloned.HandleParams = cloneConstantsForPlanCache(x.HandleParams, sctx)
+		if x.IndexValues != nil {
+			cloned.IndexValues = make([][]types.Datum, 0, len(x.IndexValues))
+			for _, values := range x.IndexValues {
+				cloned.IndexValues = append(cloned.IndexValues, append([]types.Datum(nil), values...))
+			}
+		}
+		if x.IndexValueParams != nil {
+			cloned.IndexValueParams = make([][]*expression.Constant, 0, len(x.IndexValueParams))
+			for _, params := range x.IndexValueParams {
+				cloned.IndexValueParams = append(cloned.IndexValueParams, cloneConstantsForPlanCache(params, sctx))
+			}
+		}
+		cloned.AccessConditions = expression.CloneExprsWithNewCtx(sctx, x.AccessConditions)
+		cloned.PartitionIDs = append([]int64(nil), x.PartitionIDs...)
+		return &cloned, true
+	}
+	return nil, false
+}
+
+// cloneBaseForPlanCache binds the shallow copied basePhysicalPlan to the new plan and the session context, and clones
+// its children. It returns false if the children can't be cloned.
+func (p *basePhysicalPlan) cloneBaseForPlanCache(newSelf PhysicalPlan, sctx sessionctx.Context) bool {
+	if len(p.probeParents) > 0 {
+		return false
+	}
+	p.self = newSelf
+	p.SetSCtx(sctx)
+	if p.children == nil {
+		return true
+	}
+	children := make([]PhysicalPlan, 0, len(p.children))
+	for _, child := range p.children {
+		cloned, ok := clonePlanForInstancePlanCache(child, sctx)
+		if !ok {
+			return false
+		}
+		children = append(children, cloned)
+	}
+	p.children = children
+	return true
+}
+
+func (p *basePhysicalAgg) cloneExprsForPlanCache(sctx sessionctx.Context) {
+	aggFuncs := make([]*aggregation.AggFuncDesc, 0, len(p.AggFuncs))
+	for _, aggFunc := range p.AggFuncs {
+		cloned := aggFunc.Clone()
+		cloned.Args = expression.CloneExprsWithNewCtx(sctx, aggFunc.Args)
+		cloned.OrderByItems = cloneByItemsForPlanCache(aggFunc.OrderByItems, sctx)
+		aggFuncs = append(aggFuncs, cloned)
+	}
+	p.AggFuncs = aggFuncs
+	p.GroupByItems = expression.CloneExprsWithNewCtx(sctx, p.GroupByItems)
+}
+
+func clonePartitionInfoForPlanCache(info PartitionInfo, sctx sessionctx.Context) PartitionInfo {
+	info.PruningConds = expression.CloneExprsWithNewCtx(sctx, info.PruningConds)
+	return info
+}
+
+func cloneByItemsForPlanCache(items []*util.ByItems, sctx sessionctx.Context) []*util.ByItems {
+	if items == nil {
+		return nil
+	}
+	cloned := make([]*util.ByItems, 0, len(items))
+	for _, item := range items {
+		cloned = append(cloned, &util.ByItems{Expr: expression.CloneWithNewCtx(sctx, item.Expr), Desc: item.Desc})
+	}
+	return cloned
+}
+
+func cloneScalarFuncsForPlanCache(funcs []*expression.ScalarFunction, sctx sessionctx.Context) []*expression.ScalarFunction {
+	if funcs == nil {
+		return nil
+	}
+	cloned := make([]*expression.ScalarFunction, 0, len(funcs))
+	for _, f := range funcs {
+		cloned = append(cloned, expression.CloneWithNewCtx(sctx, f).(*expression.ScalarFunction))
+	}
+	return cloned
+}
+
+// cloneConstantsForPlanCache clones the parameters of the point get plans, in which nil means the value isn't a
+// parameter.
+func cloneConstantsForPlanCache(constants []*expression.Constant, sctx sessionctx.Context) []*expression.Constant {
+	if constants == nil {
+		return nil
+	}
+	cloned := make([]*expression.Constant, 0, len(constants))
+	for _, c := range constants {
+		if c == nil {
+			cloned = append(cloned, nil)
+			continue
+		}
+		cloned = append(cloned, expression.CloneWithNewCtx(sctx, c).(*expression.Constant))
+	}
+	return cloned
+}
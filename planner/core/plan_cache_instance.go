@@ -0,0 +1,334 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/config"
+	core_metrics "github.com/pingcap/tidb/planner/core/metrics"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/memory"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"github.com/pingcap/tidb/util/syncutil"
+	"golang.org/x/exp/slices"
+)
+
+// InstancePlanCache is a least recently used plan cache shared by all sessions of the instance.
+// The cached plans are immutable: a copy of the plan is put into the cache, and every session gets its own copy
+// which is bound to the session. The memory usage of the cache is limited by tidb_instance_plan_cache_max_size.
+// When the limit is exceeded, the least recently used plans are evicted until the reserved percentage of the
+// memory is released.
+type InstancePlanCache struct {
+	// lock make cache thread safe
+	lock syncutil.Mutex
+	// buckets replace the map in general LRU
+	buckets map[string]map[*list.Element]struct{}
+	lruList *list.List
+	// onEvict will be called if any eviction happened, only for test use now
+	onEvict func(kvcache.Key, kvcache.Value)
+
+	memTracker *memory.Tracker
+	stats      utilpc.InstancePlanCacheStats
+}
+
+// NewInstancePlanCache creates an InstancePlanCache object. The memory usage of the cache is tracked by the
+// global memory tracker, and the cached plans are evicted when the global tracker exceeds its limit.
+func NewInstancePlanCache(globalTracker *memory.Tracker) *InstancePlanCache {
+	c := &InstancePlanCache{
+		buckets:    make(map[string]map[*list.Element]struct{}),
+		lruList:    list.New(),
+		memTracker: memory.NewTracker(memory.LabelForInstancePlanCache, -1),
+	}
+	if globalTracker != nil {
+		c.memTracker.AttachToGlobalTracker(globalTracker)
+		// The action of the global tracker is registered once, it evicts the plans of the latest cache.
+		instancePlanCacheEvictMu.Lock()
+		action, ok := instancePlanCacheEvictActions[globalTracker]
+		if !ok {
+			action = &instancePlanCacheEvictAction{}
+			instancePlanCacheEvictActions[globalTracker] = action
+			globalTracker.FallbackOldAndSetNewAction(action)
+		}
+		instancePlanCacheEvictMu.Unlock()
+		action.cache.Store(c)
+	}
+	return c
+}
+
+var (
+	instancePlanCacheEvictMu      sync.Mutex
+	instancePlanCacheEvictActions = make(map[*memory.Tracker]*instancePlanCacheEvictAction)
+)
+
+// instancePlanCacheEvictAction evicts all the plans of the instance plan cache when the memory usage of the instance
+// exceeds the limit, and it falls back to the original action if there's nothing to evict.
+type instancePlanCacheEvictAction struct {
+	memory.BaseOOMAction
+	cache atomic.Pointer[InstancePlanCache]
+}
+
+// Action implements the memory.ActionOnExceed interface.
+func (a *instancePlanCacheEvictAction) Action(t *memory.Tracker) {
+	if c := a.cache.Load(); c != nil && c.MemoryUsage() > 0 {
+		// The action may be triggered by the cache itself with the lock held, so evict the plans in another goroutine.
+		go c.DeleteAll()
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority implements the memory.ActionOnExceed interface.
+func (*instancePlanCacheEvictAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+var (
+	instancePlanCacheVarsOnce sync.Once
+	instancePlanCacheSysVars  []*variable.SysVar
+)
+
+// instancePlanCacheVars returns the system variables which affect the plans but aren't in the plan cache key.
+// The session plan cache ignores them as before, but the plans of the instance plan cache are only shared by
+// the sessions with the same values of them.
+func instancePlanCacheVars() []*variable.SysVar {
+	instancePlanCacheVarsOnce.Do(func() {
+		names := []string{
+			variable.TiDBEnableIndexMerge,
+			variable.TiDBEnableIndexMergeJoin,
+			variable.TiDBEnableINLJoinInnerMultiPattern,
+			variable.TiDBPartitionPruneMode,
+			variable.TiDBOptimizerEnableOuterJoinReorder,
+			variable.TiDBOptimizerEnableNAAJ,
+			variable.TiDBOptimizerEnableNewOnlyFullGroupByCheck,
+			variable.TiDBOptimizerSelectivityLevel,
+			variable.TiDBDefaultStrMatchSelectivity,
+			variable.TiDBCostModelVersion,
+			variable.TiDBEnableCascadesPlanner,
+			variable.TiDBEnableOrderedResultMode,
+			variable.TiDBEnableExtendedStats,
+			variable.TiDBEnableWindowFunction,
+			variable.TiDBEnablePipelinedWindowFunction,
+			variable.TiDBEnablePseudoForOutdatedStats,
+			variable.TiDBAllowMPPExecution,
+			variable.TiDBEnforceMPPExecution,
+			variable.TiDBAllowBatchCop,
+			variable.TiDBAllowTiFlashCop,
+			variable.TiDBEnableTiFlashReadForWriteStmt,
+		}
+		sysVars := variable.GetSysVars()
+		for name := range sysVars {
+			if strings.HasPrefix(name, "tidb_opt_") {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			if sv, ok := sysVars[name]; ok {
+				instancePlanCacheSysVars = append(instancePlanCacheSysVars, sv)
+			}
+		}
+	})
+	return instancePlanCacheSysVars
+}
+
+// instancePlanCacheKey returns a copy of the key without the connection ID, so the plans can be shared by sessions.
+// The values of the session variables which affect the plans and the lock state of the point get plans are added
+// to the key instead.
+func instancePlanCacheKey(sctx sessionctx.Context, key kvcache.Key) *planCacheKey {
+	k := *key.(*planCacheKey)
+	k.connID = 0
+	k.hash = nil
+	k.memoryUsage = 0
+
+	vars := sctx.GetSessionVars()
+	var sb strings.Builder
+	for _, sv := range instancePlanCacheVars() {
+		val, ok := vars.GetSystemVar(sv.Name)
+		if !ok {
+			val = sv.Value
+		}
+		sb.WriteString(val)
+		sb.WriteByte(0)
+	}
+	k.optimizerVars = sb.String()
+	k.lockRead = !vars.IsAutocommit() || vars.InTxn() || config.GetGlobalConfig().PessimisticTxn.PessimisticAutoCommit.Load()
+	k.lockWaitTimeout = vars.LockWaitTimeout
+	return &k
+}
+
+// Get implements the sessionctx.InstancePlanCache interface.
+func (c *InstancePlanCache) Get(sctx sessionctx.Context, key kvcache.Key, opts *utilpc.PlanCacheMatchOpts) (kvcache.Value, bool) {
+	k := instancePlanCacheKey(sctx, key)
+	c.lock.Lock()
+	var cached *PlanCacheValue
+	if bucket, exist := c.buckets[strHashKey(k, false)]; exist {
+		if element, ok := pickPlanFromBucket(sctx.GetSessionVars(), bucket, opts); ok {
+			c.lruList.MoveToFront(element)
+			cached = element.Value.(*planCacheEntry).PlanValue.(*PlanCacheValue)
+		}
+	}
+	if cached == nil {
+		c.stats.Misses++
+		c.lock.Unlock()
+		return nil, false
+	}
+	c.stats.Hits++
+	c.lock.Unlock()
+
+	// The cached plan is never changed, so it's safe to clone it without the lock.
+	plan, ok := clonePlanForInstancePlanCache(cached.Plan.(PhysicalPlan), sctx)
+	if !ok {
+		return nil, false
+	}
+	return &PlanCacheValue{
+		Plan:              plan,
+		OutPutNames:       cached.OutPutNames,
+		TblInfo2UnionScan: cached.TblInfo2UnionScan,
+		matchOpts:         cached.matchOpts,
+	}, true
+}
+
+// Put implements the sessionctx.InstancePlanCache interface.
+func (c *InstancePlanCache) Put(sctx sessionctx.Context, key kvcache.Key, value kvcache.Value, opts *utilpc.PlanCacheMatchOpts) bool {
+	v := value.(*PlanCacheValue)
+	p, ok := v.Plan.(PhysicalPlan)
+	if !ok {
+		return false
+	}
+	// The cached plan isn't bound to any session, it's only used to be cloned for the sessions.
+	plan, ok := clonePlanForInstancePlanCache(p, nil)
+	if !ok {
+		return false
+	}
+	entry := &planCacheEntry{
+		PlanKey: instancePlanCacheKey(sctx, key),
+		PlanValue: &PlanCacheValue{
+			Plan:              plan,
+			OutPutNames:       v.OutPutNames,
+			TblInfo2UnionScan: v.TblInfo2UnionScan,
+			matchOpts:         v.matchOpts,
+		},
+	}
+	memLimit := variable.InstancePlanCacheMaxMemSize.Load()
+	mem := entry.MemoryUsage()
+	if mem > memLimit {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.stats.Puts++
+	hash := strHashKey(entry.PlanKey, true)
+	bucket, bucketExist := c.buckets[hash]
+	if bucketExist {
+		if element, exist := pickPlanFromBucket(sctx.GetSessionVars(), bucket, opts); exist {
+			// The plan may be put by another session concurrently, replace it.
+			old := element.Value.(*planCacheEntry)
+			c.consume(-old.MemoryUsage(), 0)
+			element.Value = entry
+			c.lruList.MoveToFront(element)
+			c.consume(mem, 0)
+			c.evictIfNeeded(memLimit)
+			return true
+		}
+	} else {
+		bucket = make(map[*list.Element]struct{}, 1)
+		c.buckets[hash] = bucket
+	}
+	element := c.lruList.PushFront(entry)
+	bucket[element] = struct{}{}
+	c.consume(mem, 1)
+	c.evictIfNeeded(memLimit)
+	return true
+}
+
+// DeleteAll implements the sessionctx.InstancePlanCache interface.
+func (c *InstancePlanCache) DeleteAll() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.consume(-c.memTracker.BytesConsumed(), -c.lruList.Len())
+	c.buckets = make(map[string]map[*list.Element]struct{})
+	c.lruList = list.New()
+}
+
+// Size implements the sessionctx.InstancePlanCache interface.
+func (c *InstancePlanCache) Size() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.lruList.Len()
+}
+
+// MemoryUsage returns the memory usage of the cached plans.
+func (c *InstancePlanCache) MemoryUsage() int64 {
+	return c.memTracker.BytesConsumed()
+}
+
+// Stats implements the sessionctx.InstancePlanCache interface.
+func (c *InstancePlanCache) Stats() utilpc.InstancePlanCacheStats {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	stats := c.stats
+	stats.PlanNum = int64(c.lruList.Len())
+	stats.MemUsage = c.memTracker.BytesConsumed()
+	stats.MemLimit = variable.InstancePlanCacheMaxMemSize.Load()
+	return stats
+}
+
+// evictIfNeeded evicts the least recently used plans until the reserved percentage of the memory limit is free
+// if the memory limit is exceeded.
+func (c *InstancePlanCache) evictIfNeeded(memLimit int64) {
+	if c.memTracker.BytesConsumed() <= memLimit {
+		return
+	}
+	target := int64(float64(memLimit) * (1 - variable.InstancePlanCacheReservedPercentage.Load()))
+	for c.memTracker.BytesConsumed() > target && c.lruList.Len() > 0 {
+		c.removeOldest()
+	}
+}
+
+// removeOldest removes the oldest element from the cache.
+func (c *InstancePlanCache) removeOldest() {
+	lru := c.lruList.Back()
+	entry := lru.Value.(*planCacheEntry)
+	if c.onEvict != nil {
+		c.onEvict(entry.PlanKey, entry.PlanValue)
+	}
+	mem := entry.MemoryUsage()
+	c.lruList.Remove(lru)
+	hash := strHashKey(entry.PlanKey, false)
+	bucket := c.buckets[hash]
+	delete(bucket, lru)
+	if len(bucket) == 0 {
+		delete(c.buckets, hash)
+	}
+	c.stats.Evictions++
+	c.stats.EvictedMem += mem
+	c.consume(-mem, -1)
+}
+
+// consume updates the memory usage and the plan number of the cache, and the metrics.
+func (c *InstancePlanCache) consume(mem int64, planNum int) {
+	c.memTracker.Consume(mem)
+	core_metrics.GetInstancePlanCacheMemoryUsage().Add(float64(mem))
+	core_metrics.GetInstancePlanCachePlanNumCounter().Add(float64(planNum))
+}
@@ -0,0 +1,150 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/memory"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"github.com/stretchr/testify/require"
+)
+
+func newInstancePlanCacheValue(t *testing.T, i int) (*planCacheKey, *PlanCacheValue, *utilpc.PlanCacheMatchOpts) {
+	ctx := MockContext()
+	key, err := NewPlanCacheKey(ctx.GetSessionVars(), "select "+strconv.Itoa(i), "test", 1, 0, "", 0)
+	require.NoError(t, err)
+	opts := &utilpc.PlanCacheMatchOpts{ParamTypes: []*types.FieldType{types.NewFieldType(mysql.TypeLonglong)}}
+	value := &PlanCacheValue{
+		Plan:      PhysicalTableDual{RowCount: i}.Init(ctx, nil, 0),
+		matchOpts: opts,
+	}
+	return key.(*planCacheKey), value, opts
+}
+
+func TestInstancePlanCache(t *testing.T) {
+	cache := NewInstancePlanCache(nil)
+	sctx1, sctx2 := MockContext(), MockContext()
+
+	key, value, opts := newInstancePlanCacheValue(t, 1)
+	_, ok := cache.Get(sctx1, key, opts)
+	require.False(t, ok)
+	require.True(t, cache.Put(sctx1, key, value, opts))
+	require.Equal(t, 1, cache.Size())
+
+	// the plan put by a session can be used by another session with a different connection ID
+	sctx2.GetSessionVars().ConnectionID = sctx1.GetSessionVars().ConnectionID + 1
+	key2, err := NewPlanCacheKey(sctx2.GetSessionVars(), "select 1", "test", 1, 0, "", 0)
+	require.NoError(t, err)
+	cached, ok := cache.Get(sctx2, key2, opts)
+	require.True(t, ok)
+	dual := cached.(*PlanCacheValue).Plan.(*PhysicalTableDual)
+	require.Equal(t, 1, dual.RowCount)
+	require.True(t, dual.SCtx() == sctx2)
+	require.True(t, dual != value.Plan)
+
+	// plans with different parameter types are not matched
+	otherOpts := &utilpc.PlanCacheMatchOpts{ParamTypes: []*types.FieldType{types.NewFieldType(mysql.TypeVarchar)}}
+	_, ok = cache.Get(sctx2, key2, otherOpts)
+	require.False(t, ok)
+
+	stats := cache.Stats()
+	require.Equal(t, int64(1), stats.PlanNum)
+	require.Equal(t, int64(1), stats.Hits)
+	require.Equal(t, int64(2), stats.Misses)
+	require.Equal(t, int64(1), stats.Puts)
+	require.Equal(t, cache.MemoryUsage(), stats.MemUsage)
+	require.Greater(t, stats.MemUsage, int64(0))
+
+	// plans are not shared by the sessions with different optimizer variables or lock states
+	require.NoError(t, sctx2.GetSessionVars().SetSystemVar(variable.TiDBEnableIndexMerge, variable.Off))
+	_, ok = cache.Get(sctx2, key2, opts)
+	require.False(t, ok)
+	require.NoError(t, sctx2.GetSessionVars().SetSystemVar(variable.TiDBEnableIndexMerge, variable.On))
+	sctx2.GetSessionVars().LockWaitTimeout = sctx1.GetSessionVars().LockWaitTimeout + 1
+	_, ok = cache.Get(sctx2, key2, opts)
+	require.False(t, ok)
+	sctx2.GetSessionVars().LockWaitTimeout = sctx1.GetSessionVars().LockWaitTimeout
+	_, ok = cache.Get(sctx2, key2, opts)
+	require.True(t, ok)
+
+	cache.DeleteAll()
+	require.Equal(t, 0, cache.Size())
+	require.Equal(t, int64(0), cache.MemoryUsage())
+}
+
+func TestInstancePlanCacheEviction(t *testing.T) {
+	defer func(maxMemSize int64, reserved float64) {
+		variable.InstancePlanCacheMaxMemSize.Store(maxMemSize)
+		variable.InstancePlanCacheReservedPercentage.Store(reserved)
+	}(variable.InstancePlanCacheMaxMemSize.Load(), variable.InstancePlanCacheReservedPercentage.Load())
+
+	cache := NewInstancePlanCache(nil)
+	sctx := MockContext()
+	evicted := make(map[string]struct{})
+	cache.onEvict = func(key kvcache.Key, _ kvcache.Value) {
+		evicted[key.(*planCacheKey).stmtText] = struct{}{}
+	}
+
+	key, value, opts := newInstancePlanCacheValue(t, 0)
+	require.True(t, cache.Put(sctx, key, value, opts))
+	planMem := cache.MemoryUsage()
+
+	// a plan larger than the limit is never cached
+	variable.InstancePlanCacheMaxMemSize.Store(planMem - 1)
+	key, value, opts = newInstancePlanCacheValue(t, 1)
+	require.False(t, cache.Put(sctx, key, value, opts))
+	require.Equal(t, 1, cache.Size())
+
+	// at most 4 plans can be cached, and 2 plans are evicted when the limit is exceeded
+	variable.InstancePlanCacheMaxMemSize.Store(planMem*4 + planMem/2)
+	variable.InstancePlanCacheReservedPercentage.Store(0.5)
+	for i := 1; i < 5; i++ {
+		key, value, opts = newInstancePlanCacheValue(t, i)
+		require.True(t, cache.Put(sctx, key, value, opts))
+	}
+	require.Equal(t, 2, cache.Size())
+	require.Len(t, evicted, 3)
+	for i := 0; i < 3; i++ {
+		require.Contains(t, evicted, "select "+strconv.Itoa(i))
+	}
+	stats := cache.Stats()
+	require.Equal(t, int64(3), stats.Evictions)
+	require.Equal(t, planMem*3, stats.EvictedMem)
+	require.LessOrEqual(t, stats.MemUsage, stats.MemLimit/2)
+}
+
+func TestInstancePlanCacheMemTracker(t *testing.T) {
+	globalTracker := memory.NewGlobalTracker(memory.LabelForGlobalMemory, -1)
+	cache := NewInstancePlanCache(globalTracker)
+	sctx := MockContext()
+	key, value, opts := newInstancePlanCacheValue(t, 0)
+	require.True(t, cache.Put(sctx, key, value, opts))
+	require.Equal(t, cache.MemoryUsage(), globalTracker.BytesConsumed())
+
+	// the cached plans are evicted when the global tracker exceeds its limit
+	globalTracker.SetBytesLimit(1)
+	globalTracker.Consume(1)
+	require.Eventually(t, func() bool {
+		return cache.Size() == 0
+	}, time.Second, 10*time.Millisecond)
+	require.Equal(t, int64(1), globalTracker.BytesConsumed())
+}
@@ -2535,3 +2535,44 @@ func BenchmarkNonPreparedPlanCacheDML(b *testing.B) {
 		tk.MustExec("delete from t where a = 2")
 	}
 }
+
+func TestInstancePlanCache(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	tk1.MustExec(`use test`)
+	tk2.MustExec(`use test`)
+	tk1.MustExec(`set tidb_enable_non_prepared_plan_cache=0`)
+	tk2.MustExec(`set tidb_enable_non_prepared_plan_cache=0`)
+	tk1.MustExec(`set global tidb_enable_instance_plan_cache=1`)
+	defer tk1.MustExec(`set global tidb_enable_instance_plan_cache=0`)
+	tk1.MustExec(`create table t (a int, b int, key(a))`)
+	tk1.MustExec(`insert into t values (1, 1), (2, 2), (3, 3)`)
+
+	tk1.MustExec(`prepare st from 'select b from t where a > ? order by b'`)
+	tk1.MustExec(`set @a=1`)
+	tk1.MustQuery(`execute st using @a`).Check(testkit.Rows("2", "3"))
+	tk1.MustQuery(`execute st using @a`).Check(testkit.Rows("2", "3"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	// the plan generated by tk1 is reused by tk2
+	tk2.MustExec(`prepare st from 'select b from t where a > ? order by b'`)
+	tk2.MustExec(`set @a=2`)
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk2.MustExec(`set @a=0`)
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+	tk1.MustQuery(`execute st using @a`).Check(testkit.Rows("2", "3"))
+	tk1.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("1"))
+
+	tk1.MustQuery(`select plan_num, hits, misses, puts, evictions from information_schema.instance_plan_cache_stats`).Check(
+		testkit.Rows("1 4 1 1 0"))
+	tk1.MustQuery(`select memory_usage > 0, memory_limit from information_schema.instance_plan_cache_stats`).Check(
+		testkit.Rows("1 104857600"))
+
+	tk1.MustExec(`admin flush instance plan_cache`)
+	tk1.MustQuery(`select plan_num, memory_usage from information_schema.instance_plan_cache_stats`).Check(testkit.Rows("0 0"))
+	tk2.MustQuery(`execute st using @a`).Check(testkit.Rows("1", "2", "3"))
+	tk2.MustQuery(`select @@last_plan_from_cache`).Check(testkit.Rows("0"))
+}
@@ -219,6 +219,11 @@ type planCacheKey struct {
 	TiDBSuperReadOnly        bool
 	ExprBlacklistTS          int64 // expr-pushdown-blacklist can affect query optimization, so we need to consider it in plan cache.
 
+	// The fields below are only set in the keys of the instance plan cache, whose plans are shared by sessions.
+	optimizerVars   string // the values of the system variables which affect the plans, see instancePlanCacheVars
+	lockRead        bool   // whether the point get plans of SELECT FOR UPDATE lock the rows, see getLockWaitTime
+	lockWaitTimeout int64
+
 	memoryUsage int64 // Do not include in hash
 	hash        []byte
 }
@@ -251,6 +256,9 @@ func (key *planCacheKey) Hash() []byte {
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.restrictedReadOnly))...)
 		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.TiDBSuperReadOnly))...)
 		key.hash = codec.EncodeInt(key.hash, key.ExprBlacklistTS)
+		key.hash = append(key.hash, hack.Slice(key.optimizerVars)...)
+		key.hash = append(key.hash, hack.Slice(strconv.FormatBool(key.lockRead))...)
+		key.hash = codec.EncodeInt(key.hash, key.lockWaitTimeout)
 	}
 	return key.hash
 }
@@ -266,7 +274,7 @@ func (key *planCacheKey) MemoryUsage() (sum int64) {
 	if key.memoryUsage > 0 {
 		return key.memoryUsage
 	}
-	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)) +
+	sum = emptyPlanCacheKeySize + int64(len(key.database)+len(key.stmtText)+len(key.bindSQL)+len(key.optimizerVars)) +
 		int64(len(key.isolationReadEngines))*size.SizeOfUint8 + int64(cap(key.hash))
 	key.memoryUsage = sum
 	return
@@ -1012,6 +1012,24 @@ func TestHypoIndexPlan(t *testing.T) {
 		`Point_Get_5 1.00 root table:t, index:hypo_a(a) `))
 }
 
+func TestHypoIndexStats(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`create table t (a int, b int)`)
+	tk.MustExec(`insert into t values (1, 1), (1, 2), (2, 3), (3, 4), (4, 5), (5, 6), (6, 7), (7, 8), (8, 9), (9, 10)`)
+	tk.MustExec(`analyze table t`)
+
+	// The row count of the hypo-index is synthesized from the column statistics.
+	tk.MustExec(`create index hypo_a type hypo on t (a)`)
+	rows := tk.MustQuery(`explain select a from t where a = 1`).Rows()
+	require.Len(t, rows, 2)
+	require.Contains(t, rows[1][0], "IndexRangeScan")
+	require.Equal(t, "2.00", rows[1][1])
+	require.Contains(t, rows[1][3], "index:hypo_a(a)")
+	require.NotContains(t, rows[1][4], "stats:pseudo")
+}
+
 func TestHypoTiFlashReplica(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
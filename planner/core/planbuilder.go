@@ -419,6 +419,7 @@ const (
 	expressionClause
 	windowOrderByClause
 	partitionByClause
+	tableFunctionClause
 )
 
 var clauseMsg = map[clauseCode]string{
@@ -434,6 +435,7 @@ var clauseMsg = map[clauseCode]string{
 	expressionClause:    "expression",
 	windowOrderByClause: "window order by",
 	partitionByClause:   "window partition by",
+	tableFunctionClause: "a table function argument",
 }
 
 type capFlagType = uint64
@@ -553,6 +555,8 @@ type PlanBuilder struct {
 	renamingViewName string
 	// isCreateView indicates whether the query is create view.
 	isCreateView bool
+	// isCreateMaterializedView indicates whether the query is create materialized view.
+	isCreateMaterializedView bool
 
 	// evalDefaultExpr needs this information to find the corresponding column.
 	// It stores the OutputNames before buildProjection.
@@ -826,6 +830,8 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		return b.buildUnlockStats(x), nil
 	case *ast.IndexAdviseStmt:
 		return b.buildIndexAdvise(x), nil
+	case *ast.AdviseIndexesStmt:
+		return b.buildAdviseIndexes(x), nil
 	case *ast.PlanReplayerStmt:
 		return b.buildPlanReplayer(x), nil
 	case *ast.PrepareStmt:
@@ -834,6 +840,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		if x.SelectIntoOpt != nil {
 			return b.buildSelectInto(ctx, x)
 		}
+		if p, err := b.tryBuildMaterializedViewScan(ctx, x); err != nil || p != nil {
+			return p, err
+		}
 		return b.buildSelect(ctx, x)
 	case *ast.SetOprStmt:
 		return b.buildSetOpr(ctx, x)
@@ -854,7 +863,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt:
+		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.RefreshMaterializedViewStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1387,6 +1396,10 @@ func getPossibleAccessPaths(ctx sessionctx.Context, tableHints *tableHintInfo, i
 			if !optimizerUseInvisibleIndexes && index.Invisible {
 				continue
 			}
+			// FULLTEXT index can only be accessed by IndexMerge, see generateIndexMerge4FullTextIndex.
+			if index.IsFullText() {
+				continue
+			}
 			if tblInfo.IsCommonHandle && index.Primary {
 				continue
 			}
@@ -1704,6 +1717,11 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		p := &ResumeDDLJobs{JobIDs: as.JobIDs}
 		p.setSchemaAndNames(buildResumeDDLJobsFields())
 		ret = p
+	case ast.AdminAlterDDLJob:
+		ret, err = b.buildAdminAlterDDLJob(ctx, as)
+		if err != nil {
+			return nil, err
+		}
 	case ast.AdminCheckIndexRange:
 		schema, names, err := b.buildCheckIndexSchema(as.Tables[0], as.Index)
 		if err != nil {
@@ -1765,6 +1783,19 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 	return ret, nil
 }
 
+func (b *PlanBuilder) buildAdminAlterDDLJob(ctx context.Context, as *ast.AdminStmt) (Plan, error) {
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	options := make([]*AlterDDLJobOpt, 0, len(as.AlterJobOptions))
+	for _, opt := range as.AlterJobOptions {
+		value, _, err := b.rewrite(ctx, opt.Value, mockTablePlan, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		options = append(options, &AlterDDLJobOpt{Name: opt.Name, Value: value})
+	}
+	return &AlterDDLJob{JobID: as.JobNumber, Options: options}, nil
+}
+
 func (b *PlanBuilder) buildPhysicalIndexLookUpReader(_ context.Context, dbName model.CIStr, tbl table.Table, idx *model.IndexInfo) (Plan, error) {
 	tblInfo := tbl.Meta()
 	physicalID, isPartition := getPhysicalID(tbl)
@@ -1939,6 +1970,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsFullText() {
+			// Skip checking FULLTEXT index, which stores the tokens rather than the values of the columns.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -2212,7 +2247,7 @@ func (b *PlanBuilder) getMustAnalyzedColumns(tbl *ast.TableName, cols *calcOnceM
 		}
 		virtualExprs := make([]expression.Expression, 0, len(tblInfo.Columns))
 		for _, idx := range tblInfo.Indices {
-			if idx.State != model.StatePublic || idx.MVIndex {
+			if idx.State != model.StatePublic || idx.MVIndex || idx.IsFullText() {
 				continue
 			}
 			for _, idxCol := range idx.Columns {
@@ -2404,6 +2439,10 @@ func getModifiedIndexesInfoForAnalyze(sctx sessionctx.Context, tblInfo *model.Ta
 			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", originIdx.Name.L))
 			continue
 		}
+		if originIdx.IsFullText() {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing FULLTEXT indexes is not supported, skip %s", originIdx.Name.L))
+			continue
+		}
 		if allColumns {
 			// If all the columns need to be analyzed, we don't need to modify IndexColumn.Offset.
 			idxsInfo = append(idxsInfo, originIdx)
@@ -2744,6 +2783,10 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFullText() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 			for i, id := range physicalIDs {
 				if id == tbl.TableInfo.ID {
 					id = -1
@@ -2841,6 +2884,10 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.IsFullText() {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
 		for i, id := range physicalIDs {
 			if id == tblInfo.ID {
 				id = -1
@@ -2887,6 +2934,10 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.IsFullText() {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing FULLTEXT indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 
 			for i, id := range physicalIDs {
 				if id == tblInfo.ID {
@@ -3172,6 +3223,18 @@ func buildSplitRegionsSchema() (*expression.Schema, types.NameSlice) {
 	return schema.col2Schema(), schema.names
 }
 
+func buildAdviseIndexesSchema() (*expression.Schema, types.NameSlice) {
+	schema := newColumnsWithNames(7)
+	schema.Append(buildColumnWithName("", "DB_NAME", mysql.TypeVarchar, mysql.MaxDatabaseNameLength))
+	schema.Append(buildColumnWithName("", "TABLE_NAME", mysql.TypeVarchar, mysql.MaxTableNameLength))
+	schema.Append(buildColumnWithName("", "INDEX_NAME", mysql.TypeVarchar, mysql.MaxIndexIdentifierLen))
+	schema.Append(buildColumnWithName("", "INDEX_COLUMNS", mysql.TypeVarchar, 256))
+	schema.Append(buildColumnWithName("", "IMPACTED_QUERIES", mysql.TypeLonglong, 4))
+	schema.Append(buildColumnWithName("", "EST_COST_REDUCTION", mysql.TypeDouble, 8))
+	schema.Append(buildColumnWithName("", "CREATE_STATEMENT", mysql.TypeVarchar, 512))
+	return schema.col2Schema(), schema.names
+}
+
 func buildShowDDLJobQueriesFields() (*expression.Schema, types.NameSlice) {
 	schema := newColumnsWithNames(1)
 	schema.Append(buildColumnWithName("", "QUERY", mysql.TypeVarchar, 256))
@@ -3366,6 +3429,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			Partition:             show.Partition,
 			Column:                show.Column,
 			IndexName:             show.IndexName,
+			Procedure:             show.Procedure,
 			ResourceGroupName:     show.ResourceGroupName,
 			Flag:                  show.Flag,
 			User:                  show.User,
@@ -3384,8 +3448,9 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	buildPattern := true
 
 	switch show.Tp {
-	case ast.ShowDatabases, ast.ShowVariables, ast.ShowTables, ast.ShowColumns, ast.ShowTableStatus, ast.ShowCollation:
-		if (show.Tp == ast.ShowTables || show.Tp == ast.ShowTableStatus) && p.DBName == "" {
+	case ast.ShowDatabases, ast.ShowVariables, ast.ShowTables, ast.ShowColumns, ast.ShowTableStatus, ast.ShowCollation, ast.ShowTriggers,
+		ast.ShowEvents:
+		if (show.Tp == ast.ShowTables || show.Tp == ast.ShowTableStatus || show.Tp == ast.ShowTriggers || show.Tp == ast.ShowEvents) && p.DBName == "" {
 			return nil, ErrNoDB
 		}
 		if extractor := newShowBaseExtractor(*show); extractor.Extract() {
@@ -3474,8 +3539,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus {
+			// The pattern of SHOW PROCEDURE STATUS matches the routine name.
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3605,6 +3675,15 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		}
 	case *ast.ShutdownStmt:
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShutdownPriv, "", "", "", nil)
+	case *ast.RefreshMaterializedViewStmt:
+		// Refreshing a view replaces its rows.
+		for _, priv := range []mysql.PrivilegeType{mysql.InsertPriv, mysql.DeletePriv} {
+			var err error
+			if user := b.ctx.GetSessionVars().User; user != nil {
+				err = ErrTableaccessDenied.GenWithStackByArgs(priv.String(), user.AuthUsername, user.AuthHostname, raw.ViewName.Name.L)
+			}
+			b.visitInfo = appendVisitInfo(b.visitInfo, priv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", err)
+		}
 	case *ast.BeginStmt:
 		readTS := b.ctx.GetSessionVars().TxnReadTS.PeakTxnReadTS()
 		if raw.AsOf != nil {
@@ -3855,6 +3934,9 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		}
 		return nil, err
 	}
+	if b.isReadOnlyMaterializedView(tableInfo) {
+		return nil, ErrNonUpdatableTable.GenWithStackByArgs(tableInfo.Name.O, "INSERT")
+	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
 	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx, tn.Schema, tableInfo)
 	if err != nil {
@@ -4472,6 +4554,20 @@ func (*PlanBuilder) buildIndexAdvise(node *ast.IndexAdviseStmt) Plan {
 	return p
 }
 
+func (b *PlanBuilder) buildAdviseIndexes(node *ast.AdviseIndexesStmt) Plan {
+	if len(node.Queries) == 0 {
+		// The statement summary contains the statements of all users.
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ProcessPriv, "", "", "", err)
+	}
+	p := &AdviseIndexes{
+		Queries:     node.Queries,
+		MaxIndexNum: node.MaxIndexNum,
+	}
+	p.setSchemaAndNames(buildAdviseIndexesSchema())
+	return p
+}
+
 func (b *PlanBuilder) buildSplitRegion(node *ast.SplitRegionStmt) (Plan, error) {
 	if node.Table.TableInfo.TempTableType != model.TempTableNone {
 		return nil, ErrOptOnTemporaryTable.GenWithStackByArgs("split table")
@@ -4894,6 +4990,16 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		if err := b.buildCreateMaterializedView(ctx, v); err != nil {
+			return nil, err
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", user.AuthUsername,
+				user.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -4901,6 +5007,50 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name.Schema.L,
 			v.Name.Name.L, "", authErr)
+	case *ast.ProcedureInfo:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, v.ProcedureName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+	case *ast.DropProcedureStmt:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrProcaccessDenied.GenWithStackByArgs("alter routine", user.AuthUsername, user.AuthHostname,
+				v.ProcedureName.Schema.L+"."+v.ProcedureName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, v.ProcedureName.Schema.L,
+			"", "", authErr)
+	case *ast.CreateTriggerStmt:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", user.AuthUsername, user.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		// The privilege is checked on the table of the trigger.
+		var tblName string
+		if _, tblInfo, ok := b.is.TriggerByName(v.TriggerName.Schema, v.TriggerName.Name); ok {
+			tblName = tblInfo.Name.L
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", user.AuthUsername, user.AuthHostname, tblName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L,
+			tblName, "", authErr)
+	case *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		var schema string
+		switch x := v.(type) {
+		case *ast.CreateEventStmt:
+			schema = x.EventName.Schema.L
+		case *ast.AlterEventStmt:
+			schema = x.EventName.Schema.L
+		case *ast.DropEventStmt:
+			schema = x.EventName.Schema.L
+		}
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, schema)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.EventPriv, schema, "", "", authErr)
 	case *ast.DropDatabaseStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
@@ -5323,6 +5473,8 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreateView:
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowDrainerStatus:
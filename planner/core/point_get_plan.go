@@ -1795,6 +1795,9 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 	}
 
 	switch pi.Type {
+	case model.PartitionTypeNone:
+		// The table is being partitioned and all data is still in a single partition.
+		return &pi.Definitions[0], 0, 0, false
 	case model.PartitionTypeHash:
 		expr := partitionExpr.OrigExpr
 		col, ok := expr.(*ast.ColumnNameExpr)
@@ -1894,6 +1897,8 @@ func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.Partition
 
 	var partitionColName model.CIStr
 	switch pi.Type {
+	case model.PartitionTypeNone:
+		return 0, nil
 	case model.PartitionTypeHash:
 		col, ok := partitionExpr.OrigExpr.(*ast.ColumnNameExpr)
 		if !ok {
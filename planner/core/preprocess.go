@@ -45,6 +45,7 @@ import (
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/util/domainutil"
 	"github.com/pingcap/tidb/util/logutil"
 	utilparser "github.com/pingcap/tidb/util/parser"
@@ -263,6 +264,10 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
 		p.checkCreateViewWithSelectGrammar(node)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -348,6 +353,37 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.checkCreateProcedureGrammar(node)
+		// The statements in the procedure body are resolved when the procedure is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.ProcedureName)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.checkCreateTriggerGrammar(node)
+		// The statements in the trigger body are resolved when the trigger is fired.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.TriggerName)
+		return in, true
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.checkEventGrammar(node.EventName, node.Body)
+		// The statements in the event body are resolved when the event is executed.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.checkEventGrammar(node.EventName, node.Body)
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveProcedureName(node.EventName)
+		return in, true
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -367,8 +403,14 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if v, ok := node.Source.(*ast.TableName); ok && v.TableSample != nil {
 			switch v.TableSample.SampleMethod {
 			case ast.SampleMethodTypeTiDBRegion:
+			case ast.SampleMethodTypeSystem, ast.SampleMethodTypeBernoulli:
+				if v.TableSample.Expr == nil {
+					p.err = expression.ErrInvalidTableSample.GenWithStackByArgs("SYSTEM and BERNOULLI sampling methods require a sample percentage")
+				} else if v.TableSample.SampleClauseUnit == ast.SampleClauseUnitTypeRow {
+					p.err = expression.ErrInvalidTableSample.GenWithStackByArgs("SYSTEM and BERNOULLI sampling methods only support sampling by PERCENT")
+				}
 			default:
-				p.err = expression.ErrInvalidTableSample.GenWithStackByArgs("Only supports REGIONS sampling method")
+				p.err = expression.ErrInvalidTableSample.GenWithStackByArgs("Only supports SYSTEM, BERNOULLI and REGIONS sampling methods")
 			}
 		}
 	case *ast.GroupByClause:
@@ -557,7 +599,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -1000,10 +1042,297 @@ func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt
 	}
 }
 
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if isIncorrectName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if isIncorrectName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	switch sel := stmt.Select.(type) {
+	case *ast.SelectStmt:
+		p.checkCreateViewWithSelect(sel)
+	case *ast.SetOprStmt:
+		p.checkCreateViewWithSelect(sel.SelectList)
+	}
+}
+
 func (p *preprocessor) checkDropSequenceGrammar(stmt *ast.DropSequenceStmt) {
 	p.checkDropTableNames(stmt.Sequences)
 }
 
+func (p *preprocessor) resolveProcedureName(name *ast.TableName) {
+	if name.Schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(ErrNoDB)
+		return
+	}
+	name.Schema = model.NewCIStr(currentDB)
+}
+
+func (p *preprocessor) checkCreateProcedureGrammar(stmt *ast.ProcedureInfo) {
+	p.resolveProcedureName(stmt.ProcedureName)
+	if p.err != nil {
+		return
+	}
+	params := make(map[string]struct{}, len(stmt.ProcedureParam))
+	for _, param := range stmt.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params[name]; ok {
+			p.err = exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+			return
+		}
+		params[name] = struct{}{}
+	}
+	checker := &procedureChecker{vars: []map[string]struct{}{params}}
+	p.err = checker.checkStmt(stmt.ProcedureBody)
+}
+
+func (p *preprocessor) checkCreateTriggerGrammar(stmt *ast.CreateTriggerStmt) {
+	p.resolveProcedureName(stmt.TriggerName)
+	if p.err != nil {
+		return
+	}
+	p.resolveProcedureName(stmt.Table)
+	if p.err != nil {
+		return
+	}
+	checker := &procedureChecker{trigger: stmt}
+	p.err = checker.checkStmt(stmt.Body)
+}
+
+func (p *preprocessor) checkEventGrammar(name *ast.TableName, body ast.StmtNode) {
+	p.resolveProcedureName(name)
+	if p.err != nil || body == nil {
+		return
+	}
+	checker := &procedureChecker{}
+	p.err = checker.checkStmt(body)
+}
+
+// procedureLabel is a label visible to the LEAVE and ITERATE statements.
+type procedureLabel struct {
+	name   string
+	isLoop bool
+}
+
+// procedureChecker checks the declarations, labels and the references to the variables and
+// cursors in the body of a procedure, the statements in it are resolved when it is called.
+type procedureChecker struct {
+	labels  []procedureLabel
+	vars    []map[string]struct{}
+	cursors []map[string]struct{}
+	// trigger is not nil if it checks the body of a trigger.
+	trigger *ast.CreateTriggerStmt
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock:
+		return c.checkLabel(x.LabelName, x.LabelEnd, x.LabelError, false, x.Block)
+	case *ast.ProcedureLabelLoop:
+		return c.checkLabel(x.LabelName, x.LabelEnd, x.LabelError, true, x.Block)
+	case *ast.ProcedureIfInfo:
+		return c.checkIf(x.IfBody)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureWhileStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureLoopStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureJump:
+		name := strings.ToLower(x.Name)
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			if c.labels[i].name == name && (x.IsLeave || c.labels[i].isLoop) {
+				return nil
+			}
+		}
+		if x.IsLeave {
+			return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("LEAVE", x.Name)
+		}
+		return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs("ITERATE", x.Name)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if !c.declared(c.vars, name) {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+		}
+	case *ast.SetStmt:
+		if c.trigger != nil {
+			return c.checkTriggerSet(x)
+		}
+	case *ast.SelectStmt:
+		if c.trigger != nil && x.SelectIntoOpt == nil {
+			return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+	case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		if c.trigger != nil {
+			return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		if c.trigger != nil {
+			return exeerrors.ErrCommitNotAllowedInSfOrTrg
+		}
+	}
+	return nil
+}
+
+// checkTriggerSet checks the assignments to the NEW and OLD rows, only the NEW row of
+// the BEFORE INSERT and BEFORE UPDATE triggers can be changed.
+func (c *procedureChecker) checkTriggerSet(stmt *ast.SetStmt) error {
+	for _, assignment := range stmt.Variables {
+		if !assignment.IsSystem || assignment.IsGlobal {
+			continue
+		}
+		row, _, ok := strings.Cut(strings.ToLower(assignment.Name), ".")
+		if !ok {
+			continue
+		}
+		switch {
+		case row == "old":
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+		case row != "new":
+		case c.trigger.Event == model.TriggerEventDelete:
+			return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs("NEW", "on DELETE")
+		case c.trigger.Timing == model.TriggerTimingAfter:
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkIf(block *ast.ProcedureIfBlock) error {
+	if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+		return err
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return c.checkIf(x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return c.checkStmts(x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkLabel(name, end string, mismatch, isLoop bool, body ast.StmtNode) error {
+	if mismatch {
+		return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+	}
+	label := procedureLabel{name: strings.ToLower(name), isLoop: isLoop}
+	for _, l := range c.labels {
+		if l.name == label.name {
+			return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, label)
+	err := c.checkStmt(body)
+	c.labels = c.labels[:len(c.labels)-1]
+	return err
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	vars := make(map[string]struct{})
+	cursors := make(map[string]struct{})
+	handlers := make([]*ast.ProcedureErrorControl, 0)
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			if len(cursors) > 0 || len(handlers) > 0 {
+				return exeerrors.ErrSpVarcondAfterCurshndlr.GenWithStackByArgs()
+			}
+			for _, name := range x.DeclNames {
+				if _, ok := vars[strings.ToLower(name)]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				vars[strings.ToLower(name)] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if len(handlers) > 0 {
+				return exeerrors.ErrSpCursorAfterHandler.GenWithStackByArgs()
+			}
+			if _, ok := cursors[strings.ToLower(x.CurName)]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			cursors[strings.ToLower(x.CurName)] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			handlers = append(handlers, x)
+		}
+	}
+	c.vars = append(c.vars, vars)
+	c.cursors = append(c.cursors, cursors)
+	defer func() {
+		c.vars = c.vars[:len(c.vars)-1]
+		c.cursors = c.cursors[:len(c.cursors)-1]
+	}()
+	// The statement of a handler can't jump to the labels outside of it.
+	labels := c.labels
+	c.labels = nil
+	for _, handler := range handlers {
+		if err := c.checkStmt(handler.Operate); err != nil {
+			c.labels = labels
+			return err
+		}
+	}
+	c.labels = labels
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	if !c.declared(c.cursors, name) {
+		return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+	}
+	return nil
+}
+
+func (*procedureChecker) declared(scopes []map[string]struct{}, name string) bool {
+	for _, scope := range scopes {
+		if _, ok := scope[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (p *preprocessor) checkDropTableGrammar(stmt *ast.DropTableStmt) {
 	p.checkDropTableNames(stmt.Tables)
 	if stmt.TemporaryKeyword != ast.TemporaryNone {
@@ -1632,6 +1961,9 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	} else if node.Table != nil && node.Table.Schema.L == "" {
 		node.Table.Schema = model.NewCIStr(node.DBName)
 	}
+	if node.Procedure != nil {
+		p.resolveProcedureName(node.Procedure)
+	}
 	if node.User != nil && node.User.CurrentUser {
 		// Fill the Username and Hostname with the current user.
 		currentUser := p.sctx.GetSessionVars().User
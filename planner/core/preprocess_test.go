@@ -273,8 +273,11 @@ func TestValidator(t *testing.T) {
 		// TABLESAMPLE
 		{"select * from t tablesample bernoulli();", false, expression.ErrInvalidTableSample},
 		{"select * from t tablesample bernoulli(10 rows);", false, expression.ErrInvalidTableSample},
-		{"select * from t tablesample bernoulli(23 percent) repeatable (23);", false, expression.ErrInvalidTableSample},
+		{"select * from t tablesample bernoulli(23 percent) repeatable (23);", false, nil},
 		{"select * from t tablesample system() repeatable (10);", false, expression.ErrInvalidTableSample},
+		{"select * from t tablesample system(10 rows);", false, expression.ErrInvalidTableSample},
+		{"select * from t tablesample system(10) repeatable (10);", false, nil},
+		{"select * from t tablesample (10);", false, expression.ErrInvalidTableSample},
 	}
 
 	store := testkit.CreateMockStore(t)
@@ -24,6 +24,8 @@ import (
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/planner/util"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/plancodec"
 )
@@ -283,6 +285,20 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 			appendRemoveProjTraceStep(apply, proj, opt)
 			return s.optimize(ctx, p, opt)
 		} else if li, ok := innerPlan.(*LogicalLimit); ok {
+			// A limit correlated by equal conditions, like the lateral derived table in
+			// `select * from t, lateral (select * from s where s.a = t.a order by s.b limit 2) as dt`,
+			// picks the top N rows of every group, which can be computed by the row_number window function.
+			// The scalar subqueries with `limit 1` are left to the apply, which usually reads them by index lookups.
+			if apply.Lateral && (apply.JoinType == InnerJoin || apply.JoinType == LeftOuterJoin) {
+				np, err := s.decorrelateLimitByWindow(apply, li, opt)
+				if err != nil {
+					return nil, err
+				}
+				if np == nil {
+					goto NoOptimize
+				}
+				return s.optimize(ctx, np, opt)
+			}
 			// The presence of 'limit' in 'exists' will make the plan not optimal, so we need to decorrelate the 'limit' of subquery in optimization.
 			// e.g. select count(*) from test t1 where exists (select value from test t2 where t1.id = t2.id limit 1); When using 'limit' in subquery, the plan will not optimal.
 			// If apply is not SemiJoin, the output of it might be expanded even though we are `limit 1`.
@@ -301,6 +317,13 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 				return s.optimize(ctx, p, opt)
 			}
 		} else if agg, ok := innerPlan.(*LogicalAggregation); ok {
+			// The aggregation without group by items always outputs one row, so the inner join is the same as the
+			// left outer join, e.g. `select * from t, lateral (select count(*) from s where s.a = t.a) as dt`.
+			if apply.JoinType == InnerJoin && len(agg.GroupByItems) == 0 &&
+				len(apply.EqualConditions)+len(apply.LeftConditions)+len(apply.RightConditions)+len(apply.OtherConditions) == 0 {
+				apply.JoinType = LeftOuterJoin
+				resetNotNullFlag(apply.schema, outerPlan.Schema().Len(), apply.schema.Len())
+			}
 			if apply.canPullUpAgg() && agg.canPullUp() {
 				innerPlan = agg.children[0]
 				apply.JoinType = LeftOuterJoin
@@ -458,6 +481,131 @@ NoOptimize:
 	return p, nil
 }
 
+// decorrelateLimitByWindow rewrites `Apply(outer, Limit(Sort(Projection(Selection(inner.col = outer.col)))))` to
+// `Projection(Join(outer, Selection(row_number <= N, Window(Projection(inner)))))`, where the window is partitioned by
+// the inner columns of the equal conditions. It returns nil if the inner plan doesn't match the pattern.
+func (*decorrelateSolver) decorrelateLimitByWindow(apply *LogicalApply, li *LogicalLimit, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	outerPlan := apply.children[0]
+	child := li.children[0]
+	var byItems []*util.ByItems
+	if sort, ok := child.(*LogicalSort); ok {
+		byItems = sort.ByItems
+		child = sort.children[0]
+	}
+	windowChild := child
+	var projs []*LogicalProjection
+	for {
+		proj, ok := child.(*LogicalProjection)
+		if !ok {
+			break
+		}
+		projs = append(projs, proj)
+		child = proj.children[0]
+	}
+	sel, ok := child.(*LogicalSelection)
+	if !ok {
+		return nil, nil
+	}
+	orderBy := make([]property.SortItem, 0, len(byItems))
+	for _, item := range byItems {
+		col, ok := item.Expr.(*expression.Column)
+		if !ok {
+			return nil, nil
+		}
+		orderBy = append(orderBy, property.SortItem{Col: col, Desc: item.Desc})
+	}
+
+	var (
+		eqConds     []expression.Expression
+		remained    []expression.Expression
+		partitionBy []property.SortItem
+	)
+	for _, cond := range sel.Conditions {
+		expr := apply.deCorColFromEqExpr(cond)
+		if expr == nil {
+			remained = append(remained, cond)
+			continue
+		}
+		args := expr.(*expression.ScalarFunction).GetArgs()
+		outerCol, innerCol := args[0].(*expression.Column), args[1].(*expression.Column)
+		// The rows of a partition must be exactly the rows matching the outer value, so the strings must be compared
+		// with the collation of the inner column.
+		if types.IsString(innerCol.RetType.GetType()) && innerCol.RetType.GetCollate() != outerCol.RetType.GetCollate() {
+			return nil, nil
+		}
+		eqConds = append(eqConds, expr)
+		partitionBy = append(partitionBy, property.SortItem{Col: innerCol})
+	}
+	if len(eqConds) == 0 {
+		return nil, nil
+	}
+	originalConds := sel.Conditions
+	sel.Conditions = remained
+	if len(extractCorColumnsBySchema4LogicalPlan(li, outerPlan.Schema())) > 0 {
+		sel.Conditions = originalConds
+		return nil, nil
+	}
+	// The join keys must be outputted by the projections.
+	for i := len(projs) - 1; i >= 0; i-- {
+		for _, item := range partitionBy {
+			if !projs[i].schema.Contains(item.Col) {
+				projs[i].Exprs = append(projs[i].Exprs, item.Col)
+				projs[i].schema.Append(item.Col)
+			}
+		}
+	}
+
+	ctx := apply.SCtx()
+	desc, err := aggregation.NewWindowFuncDesc(ctx, ast.WindowFuncRowNumber, nil, false)
+	if err != nil {
+		return nil, err
+	}
+	window := LogicalWindow{
+		WindowFuncDescs: []*aggregation.WindowFuncDesc{desc},
+		PartitionBy:     partitionBy,
+		OrderBy:         orderBy,
+		Frame: &WindowFrame{
+			Type:  ast.Rows,
+			Start: &FrameBound{Type: ast.CurrentRow},
+			End:   &FrameBound{Type: ast.CurrentRow},
+		},
+	}.Init(ctx, li.SelectBlockOffset())
+	rowNumber := &expression.Column{
+		UniqueID: ctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  desc.RetTp,
+	}
+	windowSchema := windowChild.Schema().Clone()
+	windowSchema.Append(rowNumber)
+	window.SetSchema(windowSchema)
+	window.SetChildren(windowChild)
+
+	uintType := types.NewFieldType(mysql.TypeLonglong)
+	uintType.AddFlag(mysql.UnsignedFlag)
+	conds := []expression.Expression{expression.NewFunctionInternal(ctx, ast.LE, types.NewFieldType(mysql.TypeTiny), rowNumber,
+		&expression.Constant{Value: types.NewUintDatum(li.Offset + li.Count), RetType: uintType})}
+	if li.Offset > 0 {
+		conds = append(conds, expression.NewFunctionInternal(ctx, ast.GT, types.NewFieldType(mysql.TypeTiny), rowNumber,
+			&expression.Constant{Value: types.NewUintDatum(li.Offset), RetType: uintType}))
+	}
+	rowNumberSel := LogicalSelection{Conditions: conds}.Init(ctx, li.SelectBlockOffset())
+	rowNumberSel.SetChildren(window)
+
+	proj := LogicalProjection{Exprs: expression.Column2Exprs(apply.schema.Columns)}.Init(ctx, apply.SelectBlockOffset())
+	proj.SetSchema(apply.schema.Clone())
+	join := &apply.LogicalJoin
+	join.self = join
+	join.SetTP(plancodec.TypeJoin)
+	join.SetChildren(outerPlan, rowNumberSel)
+	join.SetSchema(expression.MergeSchema(outerPlan.Schema(), rowNumberSel.Schema()))
+	if join.JoinType == LeftOuterJoin {
+		resetNotNullFlag(join.schema, outerPlan.Schema().Len(), join.schema.Len())
+	}
+	join.AttachOnConds(eqConds)
+	proj.SetChildren(join)
+	appendLimitToWindowTraceStep(apply, li, window, opt)
+	return proj, nil
+}
+
 func (*decorrelateSolver) name() string {
 	return "decorrelate"
 }
@@ -502,6 +650,17 @@ func appendRemoveLimitTraceStep(limit *LogicalLimit, opt *logicalOptimizeOp) {
 	opt.appendStepToCurrent(limit.ID(), limit.TP(), reason, action)
 }
 
+func appendLimitToWindowTraceStep(p *LogicalApply, limit *LogicalLimit, window *LogicalWindow, opt *logicalOptimizeOp) {
+	action := func() string {
+		return fmt.Sprintf("%v_%v replaced by %v_%v, and %v_%v simplified into %v_%v",
+			limit.TP(), limit.ID(), window.TP(), window.ID(), plancodec.TypeApply, p.ID(), plancodec.TypeJoin, p.ID())
+	}
+	reason := func() string {
+		return fmt.Sprintf("%v_%v is only correlated to %v_%v by equal conditions", limit.TP(), limit.ID(), p.TP(), p.ID())
+	}
+	opt.appendStepToCurrent(limit.ID(), limit.TP(), reason, action)
+}
+
 func appendRemoveProjTraceStep(p *LogicalApply, proj *LogicalProjection, opt *logicalOptimizeOp) {
 	action := func() string {
 		return fmt.Sprintf("%v_%v removed from plan tree", proj.TP(), proj.ID())
@@ -65,6 +65,31 @@ func (s *partitionProcessor) optimize(_ context.Context, lp LogicalPlan, opt *lo
 	return p, err
 }
 
+// PrunePartitions rewrites the partitioned DataSources to the unions of their partitions in the
+// static partition prune mode. The partitions are pruned by the conditions of the Selections right
+// above the DataSources. It's used by the cascades planner, which pushes down the predicates after
+// the preprocessing phase.
+func PrunePartitions(lp LogicalPlan) (LogicalPlan, error) {
+	collectPartitionPruningConds(lp)
+	return (&partitionProcessor{}).rewriteDataSource(lp, defaultLogicalOptimizeOption())
+}
+
+func collectPartitionPruningConds(lp LogicalPlan) {
+	if sel, ok := lp.(*LogicalSelection); ok {
+		child := sel.Children()[0]
+		if us, ok := child.(*LogicalUnionScan); ok {
+			child = us.Children()[0]
+		}
+		if ds, ok := child.(*DataSource); ok && ds.allConds == nil {
+			ds.allConds = make([]expression.Expression, len(sel.Conditions))
+			copy(ds.allConds, sel.Conditions)
+		}
+	}
+	for _, child := range lp.Children() {
+		collectPartitionPruningConds(child)
+	}
+}
+
 func (s *partitionProcessor) rewriteDataSource(lp LogicalPlan, opt *logicalOptimizeOp) (LogicalPlan, error) {
 	// Assert there will not be sel -> sel in the ast.
 	switch p := lp.(type) {
@@ -1599,6 +1624,12 @@ func (*partitionProcessor) resolveAccessPaths(ds *DataSource) error {
 	if err != nil {
 		return err
 	}
+	if ds.SampleInfo != nil && ds.SampleInfo.AstNode.SampleMethod == ast.SampleMethodTypeSystem {
+		possiblePaths, err = filterPathsForSystemSample(ds.tableInfo, possiblePaths)
+		if err != nil {
+			return err
+		}
+	}
 	ds.possibleAccessPaths = possiblePaths
 	return nil
 }
@@ -32,6 +32,7 @@ const (
 	databaseKey     = "database"
 	collationKey    = "collation"
 	databaseNameKey = "db_name"
+	eventKey        = "event"
 )
 
 var (
@@ -102,10 +103,12 @@ func (e *ShowBaseExtractor) explainInfo() string {
 	switch e.ShowStmt.Tp {
 	case ast.ShowVariables, ast.ShowColumns:
 		key = fieldKey
-	case ast.ShowTables, ast.ShowTableStatus:
+	case ast.ShowTables, ast.ShowTableStatus, ast.ShowTriggers:
 		key = tableKey
 	case ast.ShowDatabases:
 		key = databaseKey
+	case ast.ShowEvents:
+		key = eventKey
 	case ast.ShowCollation:
 		key = collationKey
 	case ast.ShowStatsHealthy:
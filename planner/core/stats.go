@@ -61,6 +61,25 @@ func (p *LogicalTableDual) DeriveStats(_ []*property.StatsInfo, selfSchema *expr
 	return p.StatsInfo(), nil
 }
 
+// jsonTableDefaultRowCount is the estimated number of rows JSON_TABLE produces for one document.
+const jsonTableDefaultRowCount = 10
+
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	profile := &property.StatsInfo{
+		RowCount: jsonTableDefaultRowCount,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		profile.ColNDVs[col.UniqueID] = jsonTableDefaultRowCount
+	}
+	p.SetStats(profile)
+	return p.StatsInfo(), nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalMemTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.StatsInfo() != nil {
@@ -520,6 +539,13 @@ func getMinSelectivityFromPaths(paths []*util.AccessPath, totalRowCount float64)
 	return minSelectivity
 }
 
+// DeriveStats implements LogicalPlan DeriveStats interface.
+func (im *LogicalIndexMerge) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	// The stats of the filtered DataSource have been derived when building the LogicalIndexMerge.
+	im.SetStats(im.Source.StatsInfo())
+	return im.StatsInfo(), nil
+}
+
 // DeriveStats implements LogicalPlan DeriveStats interface.
 func (ts *LogicalTableScan) DeriveStats(_ []*property.StatsInfo, _ *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (_ *property.StatsInfo, err error) {
 	ts.Source.initStats(nil)
@@ -425,6 +425,8 @@ func GetPhysID(tblInfo *model.TableInfo, partitionExpr *tables.PartitionExpr, d
 	}
 
 	switch pi.Type {
+	case model.PartitionTypeNone:
+		return pi.Definitions[0].ID, nil
 	case model.PartitionTypeHash:
 		intVal := d.GetInt64()
 		partIdx := mathutil.Abs(intVal % int64(pi.Num))
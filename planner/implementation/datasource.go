@@ -18,7 +18,6 @@ import (
 	"math"
 
 	"github.com/pingcap/tidb/expression"
-	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/model"
 	plannercore "github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/planner/memo"
@@ -101,6 +100,22 @@ func (impl *TableReaderImpl) GetCostLimit(costLimit float64, _ ...memo.Implement
 	return costLimit * copIterWorkers
 }
 
+// MPPTableReaderImpl is the implementation of PhysicalTableReader reading from TiFlash by MPP.
+type MPPTableReaderImpl struct {
+	TableReaderImpl
+}
+
+// NewMPPTableReaderImpl creates a new MPP table reader Implementation.
+func NewMPPTableReaderImpl(reader *plannercore.PhysicalTableReader, source *plannercore.DataSource) *MPPTableReaderImpl {
+	return &MPPTableReaderImpl{*NewTableReaderImpl(reader, source)}
+}
+
+// AttachChildren implements Implementation AttachChildren interface.
+func (impl *MPPTableReaderImpl) AttachChildren(children ...memo.Implementation) memo.Implementation {
+	impl.plan.(*plannercore.PhysicalTableReader).SetMPPChild(children[0].GetPlan())
+	return impl
+}
+
 // TableScanImpl implementation of PhysicalTableScan.
 type TableScanImpl struct {
 	baseImpl
@@ -123,7 +138,7 @@ func NewTableScanImpl(ts *plannercore.PhysicalTableScan, cols []*expression.Colu
 // CalcCost calculates the cost of the table scan Implementation.
 func (impl *TableScanImpl) CalcCost(outCount float64, _ ...memo.Implementation) float64 {
 	ts := impl.plan.(*plannercore.PhysicalTableScan)
-	width := impl.tblColHists.GetTableAvgRowSize(impl.plan.SCtx(), impl.tblCols, kv.TiKV, true)
+	width := impl.tblColHists.GetTableAvgRowSize(impl.plan.SCtx(), impl.tblCols, ts.StoreType, true)
 	sessVars := ts.SCtx().GetSessionVars()
 	impl.cost = outCount * sessVars.GetScanFactor(ts.Table) * width
 	if ts.Desc {
@@ -198,3 +213,25 @@ func NewIndexScanImpl(scan *plannercore.PhysicalIndexScan, tblColHists *statisti
 		tblColHists: tblColHists,
 	}
 }
+
+// IndexMergeReaderImpl is the implementation of PhysicalIndexMergeReader.
+type IndexMergeReaderImpl struct {
+	baseImpl
+}
+
+// NewIndexMergeReaderImpl creates a new IndexMergeReader Implementation, the cost
+// of the reader has been calculated when it's built.
+func NewIndexMergeReaderImpl(reader plannercore.PhysicalPlan, cost float64) *IndexMergeReaderImpl {
+	return &IndexMergeReaderImpl{baseImpl{plan: reader, cost: cost}}
+}
+
+// CalcCost implements Implementation interface.
+func (impl *IndexMergeReaderImpl) CalcCost(_ float64, _ ...memo.Implementation) float64 {
+	return impl.cost
+}
+
+// AttachChildren implements Implementation interface.
+func (impl *IndexMergeReaderImpl) AttachChildren(_ ...memo.Implementation) memo.Implementation {
+	// The reader may be wrapped by a Selection, whose child shouldn't be reset.
+	return impl
+}
@@ -72,3 +72,39 @@ func (impl *MergeJoinImpl) AttachChildren(children ...memo.Implementation) memo.
 func NewMergeJoinImpl(mergeJoin *plannercore.PhysicalMergeJoin) *MergeJoinImpl {
 	return &MergeJoinImpl{baseImpl{plan: mergeJoin}}
 }
+
+// ReorderedJoinImpl is the implementation of the join reordered by the join reorder rules.
+// The columns of the reordered join are in a different order from its Group, so a
+// PhysicalProjection is added above the join to restore the column order.
+type ReorderedJoinImpl struct {
+	memo.Implementation
+	proj *plannercore.PhysicalProjection
+}
+
+// CalcCost implements Implementation CalcCost interface.
+func (impl *ReorderedJoinImpl) CalcCost(outCount float64, children ...memo.Implementation) float64 {
+	joinCost := impl.Implementation.CalcCost(outCount, children...)
+	impl.Implementation.SetCost(joinCost + impl.proj.GetCost(impl.Implementation.GetPlan().StatsInfo().RowCount))
+	return impl.Implementation.GetCost()
+}
+
+// GetPlan implements Implementation GetPlan interface. The join is returned before the
+// children are attached, so the required properties of the children can be got from it.
+func (impl *ReorderedJoinImpl) GetPlan() plannercore.PhysicalPlan {
+	if len(impl.proj.Children()) == 0 {
+		return impl.Implementation.GetPlan()
+	}
+	return impl.proj
+}
+
+// AttachChildren implements Implementation AttachChildren interface.
+func (impl *ReorderedJoinImpl) AttachChildren(children ...memo.Implementation) memo.Implementation {
+	join := impl.Implementation.AttachChildren(children...)
+	impl.proj.SetChildren(join.GetPlan())
+	return impl
+}
+
+// NewReorderedJoinImpl creates a new ReorderedJoinImpl.
+func NewReorderedJoinImpl(join memo.Implementation, proj *plannercore.PhysicalProjection) *ReorderedJoinImpl {
+	return &ReorderedJoinImpl{Implementation: join, proj: proj}
+}
@@ -271,3 +271,51 @@ func (impl *WindowImpl) CalcCost(_ float64, children ...memo.Implementation) flo
 	impl.cost = children[0].GetCost()
 	return impl.cost
 }
+
+// UnionScanImpl is the implementation of PhysicalUnionScan.
+type UnionScanImpl struct {
+	baseImpl
+}
+
+// CalcCost implements Implementation CalcCost interface.
+func (impl *UnionScanImpl) CalcCost(_ float64, children ...memo.Implementation) float64 {
+	// The rows read from the storage are merged with the dirty rows in the membuffer.
+	impl.cost = children[0].GetPlan().StatsInfo().RowCount*
+		impl.plan.SCtx().GetSessionVars().GetCPUFactor() + children[0].GetCost()
+	return impl.cost
+}
+
+// NewUnionScanImpl creates a new UnionScanImpl.
+func NewUnionScanImpl(us *plannercore.PhysicalUnionScan) *UnionScanImpl {
+	return &UnionScanImpl{baseImpl{plan: us}}
+}
+
+// LockImpl is the implementation of PhysicalLock.
+type LockImpl struct {
+	baseImpl
+}
+
+// NewLockImpl creates a new LockImpl.
+func NewLockImpl(lock *plannercore.PhysicalLock) *LockImpl {
+	return &LockImpl{baseImpl{plan: lock}}
+}
+
+// CTEImpl is the implementation of PhysicalCTE.
+type CTEImpl struct {
+	baseImpl
+}
+
+// NewCTEImpl creates a new CTEImpl.
+func NewCTEImpl(cte *plannercore.PhysicalCTE) *CTEImpl {
+	return &CTEImpl{baseImpl{plan: cte}}
+}
+
+// CTETableImpl is the implementation of PhysicalCTETable.
+type CTETableImpl struct {
+	baseImpl
+}
+
+// NewCTETableImpl creates a new CTETableImpl.
+func NewCTETableImpl(cteTable *plannercore.PhysicalCTETable) *CTETableImpl {
+	return &CTETableImpl{baseImpl{plan: cteTable}}
+}
@@ -17,6 +17,9 @@ package memo
 import (
 	"container/list"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/pingcap/tidb/expression"
 	plannercore "github.com/pingcap/tidb/planner/core"
@@ -115,6 +118,86 @@ type Group struct {
 	// unique key or maxOneRow (in LogicalProp). For each Group, we only need
 	// to collect these information once.
 	hasBuiltKeyInfo bool
+
+	// joinInfo is set for the join Groups registered by RegisterJoinGroup.
+	joinInfo *JoinInfo
+	// joinGroups maps the keys of the registered join Groups to the Groups,
+	// it's kept by the first leaf of the join Groups.
+	joinGroups map[string]*Group
+}
+
+// JoinInfo describes a join Group by the leaf Groups and the conditions of
+// its join tree. It's used by the join reorder rules to share the Groups of
+// the same joins, so the rules can be applied repeatedly without building
+// the same join twice.
+type JoinInfo struct {
+	// Leaves are the leaf Groups sorted by their fingerprints.
+	Leaves []*Group
+	// Conds are the sorted keys of all the conditions in the join tree.
+	Conds []string
+}
+
+// NewJoinInfo creates a JoinInfo, the duplicated leaves and conditions are removed.
+func NewJoinInfo(leaves []*Group, conds []string) *JoinInfo {
+	info := &JoinInfo{
+		Leaves: make([]*Group, 0, len(leaves)),
+		Conds:  make([]string, 0, len(conds)),
+	}
+	sortedLeaves := append([]*Group(nil), leaves...)
+	sort.Slice(sortedLeaves, func(i, j int) bool {
+		return sortedLeaves[i].FingerPrint() < sortedLeaves[j].FingerPrint()
+	})
+	for i, leaf := range sortedLeaves {
+		if i == 0 || leaf != sortedLeaves[i-1] {
+			info.Leaves = append(info.Leaves, leaf)
+		}
+	}
+	sortedConds := append([]string(nil), conds...)
+	sort.Strings(sortedConds)
+	for i, cond := range sortedConds {
+		if i == 0 || cond != sortedConds[i-1] {
+			info.Conds = append(info.Conds, cond)
+		}
+	}
+	return info
+}
+
+func (info *JoinInfo) key() string {
+	var sb strings.Builder
+	for _, leaf := range info.Leaves {
+		sb.WriteString(leaf.FingerPrint())
+		sb.WriteByte(',')
+	}
+	for _, cond := range info.Conds {
+		sb.WriteByte('|')
+		sb.WriteString(strconv.Itoa(len(cond)))
+		sb.WriteByte(':')
+		sb.WriteString(cond)
+	}
+	return sb.String()
+}
+
+// JoinInfo returns the JoinInfo of the Group registered by RegisterJoinGroup.
+// It returns nil if the Group isn't registered.
+func (g *Group) JoinInfo() *JoinInfo {
+	return g.joinInfo
+}
+
+// RegisterJoinGroup registers the join Group with its JoinInfo, so it can be
+// found by FindJoinGroup.
+func (g *Group) RegisterJoinGroup(info *JoinInfo) {
+	g.joinInfo = info
+	first := info.Leaves[0]
+	if first.joinGroups == nil {
+		first.joinGroups = make(map[string]*Group)
+	}
+	first.joinGroups[info.key()] = g
+}
+
+// FindJoinGroup finds the join Group registered with the same leaves and
+// conditions as the JoinInfo. It returns nil if there isn't.
+func FindJoinGroup(info *JoinInfo) *Group {
+	return info.Leaves[0].joinGroups[info.key()]
 }
 
 // NewGroupWithSchema creates a new Group with given schema.
@@ -187,6 +187,29 @@ func TestGetInsertGroupImpl(t *testing.T) {
 	require.Nil(t, g.GetImpl(orderProp))
 }
 
+func TestJoinGroup(t *testing.T) {
+	ctx := plannercore.MockContext()
+	newGroup := func() *Group {
+		return NewGroupWithSchema(NewGroupExpr(plannercore.LogicalLimit{}.Init(ctx, 0)), expression.NewSchema())
+	}
+	a, b, c := newGroup(), newGroup(), newGroup()
+	ab, bc := newGroup(), newGroup()
+	require.Nil(t, ab.JoinInfo())
+
+	info := NewJoinInfo([]*Group{b, a, b}, []string{"y", "x", "y"})
+	require.Len(t, info.Leaves, 2)
+	require.Equal(t, []string{"x", "y"}, info.Conds)
+	require.Nil(t, FindJoinGroup(info))
+	ab.RegisterJoinGroup(info)
+	require.Equal(t, info, ab.JoinInfo())
+	require.Equal(t, ab, FindJoinGroup(NewJoinInfo([]*Group{a, b}, []string{"y", "x"})))
+	require.Nil(t, FindJoinGroup(NewJoinInfo([]*Group{a, b}, []string{"x"})))
+
+	bc.RegisterJoinGroup(NewJoinInfo([]*Group{c, b}, []string{"x"}))
+	require.Equal(t, bc, FindJoinGroup(NewJoinInfo([]*Group{b, c}, []string{"x"})))
+	require.Nil(t, FindJoinGroup(NewJoinInfo([]*Group{a, b, c}, []string{"x"})))
+}
+
 func TestEngineTypeSet(t *testing.T) {
 	require.True(t, EngineAll.Contains(EngineTiDB))
 	require.True(t, EngineAll.Contains(EngineTiKV))
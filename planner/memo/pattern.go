@@ -58,6 +58,8 @@ const (
 	OperandLimit
 	// OperandTiKVSingleGather is the operand for TiKVSingleGather.
 	OperandTiKVSingleGather
+	// OperandIndexMerge is the operand for LogicalIndexMerge.
+	OperandIndexMerge
 	// OperandMemTableScan is the operand for MemTableScan.
 	OperandMemTableScan
 	// OperandTableScan is the operand for TableScan.
@@ -68,6 +70,10 @@ const (
 	OperandShow
 	// OperandWindow is the operand for window function.
 	OperandWindow
+	// OperandCTE is the operand for LogicalCTE.
+	OperandCTE
+	// OperandCTETable is the operand for LogicalCTETable.
+	OperandCTETable
 	// OperandUnsupported is the operand for unsupported operators.
 	OperandUnsupported
 )
@@ -93,7 +99,7 @@ func GetOperand(p plannercore.LogicalPlan) Operand {
 		return OperandDataSource
 	case *plannercore.LogicalUnionScan:
 		return OperandUnionScan
-	case *plannercore.LogicalUnionAll:
+	case *plannercore.LogicalUnionAll, *plannercore.LogicalPartitionUnionAll:
 		return OperandUnionAll
 	case *plannercore.LogicalSort:
 		return OperandSort
@@ -105,6 +111,8 @@ func GetOperand(p plannercore.LogicalPlan) Operand {
 		return OperandLimit
 	case *plannercore.TiKVSingleGather:
 		return OperandTiKVSingleGather
+	case *plannercore.LogicalIndexMerge:
+		return OperandIndexMerge
 	case *plannercore.LogicalTableScan:
 		return OperandTableScan
 	case *plannercore.LogicalMemTable:
@@ -115,6 +123,10 @@ func GetOperand(p plannercore.LogicalPlan) Operand {
 		return OperandShow
 	case *plannercore.LogicalWindow:
 		return OperandWindow
+	case *plannercore.LogicalCTE:
+		return OperandCTE
+	case *plannercore.LogicalCTETable:
+		return OperandCTETable
 	default:
 		return OperandUnsupported
 	}
@@ -32,10 +32,15 @@ func TestGetOperand(t *testing.T) {
 	require.Equal(t, OperandDataSource, GetOperand(&plannercore.DataSource{}))
 	require.Equal(t, OperandUnionScan, GetOperand(&plannercore.LogicalUnionScan{}))
 	require.Equal(t, OperandUnionAll, GetOperand(&plannercore.LogicalUnionAll{}))
+	require.Equal(t, OperandUnionAll, GetOperand(&plannercore.LogicalPartitionUnionAll{}))
+	require.Equal(t, OperandIndexMerge, GetOperand(&plannercore.LogicalIndexMerge{}))
 	require.Equal(t, OperandSort, GetOperand(&plannercore.LogicalSort{}))
 	require.Equal(t, OperandTopN, GetOperand(&plannercore.LogicalTopN{}))
 	require.Equal(t, OperandLock, GetOperand(&plannercore.LogicalLock{}))
 	require.Equal(t, OperandLimit, GetOperand(&plannercore.LogicalLimit{}))
+	require.Equal(t, OperandCTE, GetOperand(&plannercore.LogicalCTE{}))
+	require.Equal(t, OperandCTETable, GetOperand(&plannercore.LogicalCTETable{}))
+	require.Equal(t, OperandUnsupported, GetOperand(&plannercore.LogicalSequence{}))
 }
 
 func TestOperandMatch(t *testing.T) {
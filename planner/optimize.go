@@ -506,7 +506,7 @@ func optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 
 	// Handle the logical plan statement, use cascades planner if enabled.
 	if sessVars.GetEnableCascadesPlanner() {
-		finalPlan, cost, err := cascades.DefaultOptimizer.FindBestPlan(sctx, logic)
+		finalPlan, cost, err := cascades.DefaultOptimizer.FindBestPlan(ctx, sctx, logic)
 		return finalPlan, names, cost, err
 	}
 
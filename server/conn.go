@@ -2015,6 +2015,15 @@ func (cc *clientConn) handleStmt(ctx context.Context, stmt ast.StmtNode, warns [
 		if cc.getStatus() == connStatusShutdown {
 			return false, exeerrors.ErrQueryInterrupted
 		}
+		if multi, ok := rs.(resultset.MultiResultSet); ok {
+			// Every result set is followed by more results, and the statement ends with an OK packet.
+			for _, r := range multi.ResultSets() {
+				if retryable, err := cc.writeResultSet(ctx, r, false, status|mysql.ServerMoreResultsExists, 0); err != nil {
+					return retryable, err
+				}
+			}
+			return false, cc.writeOkWith(ctx, mysql.OKHeader, true, status)
+		}
 		if retryable, err := cc.writeResultSet(ctx, rs, false, status, 0); err != nil {
 			return retryable, err
 		}
@@ -253,7 +253,7 @@ func ExecArgs(sc *stmtctx.StatementContext, params []expression.Expression, boun
 				args[i] = types.NewDecimalDatum(&dec)
 			}
 			continue
-		case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 			if len(paramValues) < (pos + 1) {
 				err = mysql.ErrMalformPacket
 				return
@@ -271,7 +271,7 @@ func ExecArgs(sc *stmtctx.StatementContext, params []expression.Expression, boun
 			}
 			continue
 		case mysql.TypeUnspecified, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeString,
-			mysql.TypeEnum, mysql.TypeSet, mysql.TypeGeometry, mysql.TypeBit:
+			mysql.TypeEnum, mysql.TypeSet, mysql.TypeBit:
 			if len(paramValues) < (pos + 1) {
 				err = mysql.ErrMalformPacket
 				return
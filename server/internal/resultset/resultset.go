@@ -37,10 +37,32 @@ type ResultSet interface {
 	SetPreparedStmt(stmt *core.PlanCacheStmt)
 }
 
+// MultiResultSet is the result of a statement that returns more than one result set, like CALL.
+type MultiResultSet interface {
+	ResultSet
+	// ResultSets returns all the result sets in order.
+	ResultSets() []ResultSet
+}
+
 var _ ResultSet = &tidbResultSet{}
+var _ MultiResultSet = &tidbMultiResultSet{}
 
 // New creates a new result set
 func New(recordSet sqlexec.RecordSet, preparedStmt *core.PlanCacheStmt) ResultSet {
+	if multi, ok := recordSet.(sqlexec.MultiRecordSet); ok {
+		recordSets := multi.RecordSets()
+		resultSets := make([]ResultSet, 0, len(recordSets))
+		for _, rs := range recordSets {
+			resultSets = append(resultSets, New(rs, preparedStmt))
+		}
+		return &tidbMultiResultSet{
+			tidbResultSet: tidbResultSet{
+				recordSet:    recordSet,
+				preparedStmt: preparedStmt,
+			},
+			resultSets: resultSets,
+		}
+	}
 	return &tidbResultSet{
 		recordSet:    recordSet,
 		preparedStmt: preparedStmt,
@@ -54,6 +76,16 @@ type tidbResultSet struct {
 	closed       int32
 }
 
+type tidbMultiResultSet struct {
+	tidbResultSet
+	resultSets []ResultSet
+}
+
+// ResultSets implements MultiResultSet.ResultSets interface.
+func (trs *tidbMultiResultSet) ResultSets() []ResultSet {
+	return trs.resultSets
+}
+
 func (trs *tidbResultSet) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
 	return trs.recordSet.NewChunk(alloc)
 }
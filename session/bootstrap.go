@@ -650,6 +650,47 @@ const (
 		PRIMARY KEY (id),
 		KEY (created_by),
 		KEY (status));`
+
+	// CreateEventHistory is a table that stores the execution history of the events.
+	CreateEventHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_event_history (
+		event_id varchar(64) PRIMARY KEY,
+		event_schema varchar(64) NOT NULL,
+		event_name varchar(64) NOT NULL,
+		start_time timestamp NOT NULL,
+		end_time timestamp NULL DEFAULT NULL,
+		status varchar(64) NOT NULL,
+		error_message text DEFAULT NULL,
+		key(event_schema, event_name, start_time),
+		key(start_time));`
+
+	// CreateMaterializedViewLog is a table that stores the keys of the rows changed in the base tables of the
+	// incremental materialized views, they're consumed by REFRESH MATERIALIZED VIEW.
+	CreateMaterializedViewLog = `CREATE TABLE IF NOT EXISTS mysql.tidb_mview_log (
+		mview_id bigint(64) NOT NULL,
+		id bigint(64) NOT NULL AUTO_INCREMENT,
+		base_idx int NOT NULL,
+		row_key blob NOT NULL,
+		PRIMARY KEY (mview_id, id));`
+
+	// CreateEvolvePlanHistory is a table that stores the verification history of the plan baseline evolution.
+	CreateEvolvePlanHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_evolve_plan_history (
+		id bigint(64) NOT NULL AUTO_INCREMENT,
+		original_sql text NOT NULL,
+		default_db text NOT NULL,
+		sql_digest varchar(64) NOT NULL DEFAULT '',
+		bind_sql text NOT NULL,
+		snapshot_ts bigint(64) unsigned NOT NULL DEFAULT 0,
+		current_exec_time double DEFAULT NULL,
+		current_total_keys bigint(64) NOT NULL DEFAULT 0,
+		verify_exec_time double DEFAULT NULL,
+		verify_total_keys bigint(64) NOT NULL DEFAULT 0,
+		status varchar(64) NOT NULL,
+		message text DEFAULT NULL,
+		start_time timestamp(3) NOT NULL,
+		end_time timestamp(3) NOT NULL,
+		PRIMARY KEY (id),
+		key(sql_digest),
+		key(start_time));`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -947,11 +988,19 @@ const (
 	version168 = 168
 	version169 = 169
 	version170 = 170
+	// version 171 adds the table tidb_event_history
+	version171 = 171
+	// version 172 adds the table tidb_mview_log
+	version172 = 172
+	// version 173 adds the table tidb_evolve_plan_history
+	version173 = 173
+	// version 174 adds the primary key (mview_id, id) to the table tidb_mview_log
+	version174 = 174
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version170
+var currentBootstrapVersion int64 = version174
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1090,6 +1139,10 @@ var (
 		upgradeToVer168,
 		upgradeToVer169,
 		upgradeToVer170,
+		upgradeToVer171,
+		upgradeToVer172,
+		upgradeToVer173,
+		upgradeToVer174,
 	}
 )
 
@@ -2744,6 +2797,38 @@ func upgradeToVer170(s Session, ver int64) {
 	mustExecute(s, CreateTimers)
 }
 
+func upgradeToVer171(s Session, ver int64) {
+	if ver >= version171 {
+		return
+	}
+	mustExecute(s, CreateEventHistory)
+}
+
+func upgradeToVer172(s Session, ver int64) {
+	if ver >= version172 {
+		return
+	}
+	mustExecute(s, CreateMaterializedViewLog)
+}
+
+func upgradeToVer173(s Session, ver int64) {
+	if ver >= version173 {
+		return
+	}
+	mustExecute(s, CreateEvolvePlanHistory)
+}
+
+func upgradeToVer174(s Session, ver int64) {
+	if ver >= version174 {
+		return
+	}
+	// The table of version 172 has no primary key, it's recreated and the logs not consumed yet are copied into it.
+	doReentrantDDL(s, "RENAME TABLE mysql.tidb_mview_log TO mysql.tidb_mview_log_v172", infoschema.ErrTableNotExists, infoschema.ErrTableExists)
+	doReentrantDDL(s, CreateMaterializedViewLog)
+	mustExecute(s, "INSERT HIGH_PRIORITY INTO mysql.tidb_mview_log (mview_id, base_idx, row_key) SELECT mview_id, base_idx, row_key FROM mysql.tidb_mview_log_v172")
+	doReentrantDDL(s, "DROP TABLE IF EXISTS mysql.tidb_mview_log_v172")
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -2866,6 +2951,12 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateRunawayTable)
 	// create tidb_timers
 	mustExecute(s, CreateTimers)
+	// create tidb_event_history
+	mustExecute(s, CreateEventHistory)
+	// create tidb_mview_log
+	mustExecute(s, CreateMaterializedViewLog)
+	// create tidb_evolve_plan_history
+	mustExecute(s, CreateEvolvePlanHistory)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -0,0 +1,99 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/eventscheduler"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+var _ eventscheduler.EventExecutor = &session{}
+
+// ExecuteEvent implements the eventscheduler.EventExecutor interface. A new session is created for every
+// execution, the body of the event is interpreted by the procedure interpreter with the privileges of the
+// definer, and the SQL mode and the time zone at the time the event was defined.
+func (s *session) ExecuteEvent(ctx context.Context, info *eventscheduler.EventInfo) error {
+	vars := s.sessionVars
+	if definer := info.Definer; definer != nil && definer.Username != "" {
+		if pm := privilege.GetPrivilegeManager(s); pm != nil {
+			if !pm.GetAuthWithoutVerification(definer.Username, definer.Hostname) {
+				return exeerrors.ErrNoSuchDefiner.GenWithStackByArgs(definer.Username, definer.Hostname)
+			}
+			vars.User = &auth.UserIdentity{
+				Username:     definer.Username,
+				Hostname:     definer.Hostname,
+				AuthUsername: definer.Username,
+				AuthHostname: definer.Hostname,
+			}
+			vars.ActiveRoles = pm.GetDefaultRoles(definer.Username, definer.Hostname)
+		}
+	}
+
+	sqlMode, err := mysql.GetSQLMode(info.SQLMode)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	vars.SQLMode = sqlMode
+	for name, val := range map[string]string{
+		variable.TimeZone:            info.TimeZone,
+		variable.CharacterSetClient:  info.CharacterSetClient,
+		variable.CollationConnection: info.CollationConnection,
+	} {
+		if val == "" {
+			continue
+		}
+		if err = vars.SetSystemVar(name, val); err != nil {
+			return err
+		}
+	}
+	vars.CurrentDB = info.Schema
+
+	stmts, _, err := s.ParseSQL(ctx, info.Definition)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var body ast.StmtNode
+	if len(stmts) == 1 {
+		switch x := stmts[0].(type) {
+		case *ast.CreateEventStmt:
+			body = x.Body
+		case *ast.AlterEventStmt:
+			body = x.Body
+		}
+	}
+	if body == nil {
+		return errors.Errorf("invalid definition of event %s.%s", info.Schema, info.Name)
+	}
+
+	pe := &procedureExec{s: s, results: &procedureResults{}}
+	vars.ProcedureVars = pe
+	// The statements that reference the local variables can't share the cached plans.
+	vars.EnableNonPreparedPlanCache = false
+	_, err = pe.execStmt(ctx, body)
+	if unhandled, ok := err.(*procedureUnhandledError); ok {
+		err = unhandled.err
+	}
+	// The transaction which is started by the event and not committed is rolled back.
+	s.RollbackTxn(ctx)
+	return err
+}
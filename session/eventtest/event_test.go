@@ -0,0 +1,135 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2030-01-01 00:00:00' comment 'hourly' do insert into t values (1)")
+	tk.MustGetErrMsg("create event e1 on schedule every 1 day do insert into t values (1)", "[executor:1537]Event 'e1' already exists")
+	tk.MustExec("create event if not exists e1 on schedule every 1 day do insert into t values (1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+	tk.MustGetErrCode("create event no_such_db.e2 on schedule every 1 day do select 1", 1049)
+	tk.MustGetErrCode("create event e2 on schedule every 0 second do insert into t values (1)", 1542)
+	tk.MustGetErrCode("create event e2 on schedule every 1 month do insert into t values (1)", 1235)
+	tk.MustGetErrCode("create event e2 on schedule every 1 day starts '2030-01-02' ends '2030-01-01' do insert into t values (1)", 1543)
+
+	// The one-time event in the past is not created if it's not preserved, or it's created disabled.
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' do insert into t values (2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' on completion preserve do insert into t values (2)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+
+	tk.MustQuery("select event_schema, event_name, event_definition, event_type, execute_at, interval_value, interval_field, " +
+		"starts, ends, status, on_completion, event_comment from information_schema.events order by event_name").Check(testkit.Rows(
+		"test e1 insert into t values (1) RECURRING <nil> 1 HOUR 2030-01-01 00:00:00 <nil> ENABLED NOT PRESERVE hourly",
+		"test e2 insert into t values (2) ONE TIME 2000-01-01 00:00:00 <nil> <nil> <nil> <nil> DISABLED PRESERVE ",
+	))
+	tk.MustQuery("show events").Check(testkit.RowsWithSep("|",
+		"test|e1|SYSTEM|@|RECURRING|<nil>|1|HOUR|2030-01-01 00:00:00|<nil>|ENABLED|0|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+		"test|e2|SYSTEM|@|ONE TIME|2000-01-01 00:00:00|<nil>|<nil>|<nil>|<nil>|DISABLED|0|utf8mb4|utf8mb4_bin|utf8mb4_bin",
+	))
+	tk.MustQuery("show events like 'e1'").CheckAt([]int{1}, testkit.Rows("e1"))
+
+	tk.MustExec("alter event e1 disable comment 'daily'")
+	tk.MustExec("alter event e1 on schedule every 1 day starts '2030-01-01 00:00:00' ends '2030-02-01 00:00:00' do insert into t values (3)")
+	tk.MustQuery("select event_definition, interval_value, interval_field, ends, status, event_comment from information_schema.events " +
+		"where event_name = 'e1'").Check(testkit.Rows("insert into t values (3) 1 DAY 2030-02-01 00:00:00 DISABLED daily"))
+	tk.MustExec("alter event e1 enable")
+	tk.MustQuery("select status from information_schema.events where event_name = 'e1'").Check(testkit.Rows("ENABLED"))
+	tk.MustGetErrCode("alter event no_such_event enable", 1539)
+	tk.MustGetErrCode("alter event e2 on completion not preserve enable", 1589)
+
+	tk.MustExec("drop event e2")
+	tk.MustGetErrCode("drop event e2", 1539)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+
+	// The events are dropped with the database.
+	tk.MustExec("create database db1")
+	tk.MustExec("create event db1.e1 on schedule every 1 day do select 1")
+	tk.MustQuery("select event_schema, event_name from information_schema.events order by event_schema").Check(testkit.Rows("db1 e1", "test e1"))
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select event_schema, event_name from information_schema.events").Check(testkit.Rows("test e1"))
+	tk.MustExec("drop event e1")
+}
+
+func TestEventPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create user u")
+	tk.MustExec("create event test.e1 on schedule every 1 day do select 1")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("create event test.e2 on schedule every 1 day do select 1", 1044)
+	tk1.MustGetErrCode("drop event test.e1", 1044)
+	tk1.MustQuery("select event_name from information_schema.events").Check(testkit.Rows())
+
+	tk.MustExec("grant event on test.* to u")
+	tk1.MustExec("create event test.e2 on schedule every 1 day do select 1")
+	tk1.MustQuery("select event_name, definer from information_schema.events order by event_name").Check(testkit.Rows(
+		"e1 @", "e2 u@%"))
+	tk1.MustExec("drop event test.e1")
+	tk1.MustExec("drop event test.e2")
+}
+
+func TestEventSchedule(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("set @@global.event_scheduler = on")
+	defer tk.MustExec("set @@global.event_scheduler = off")
+
+	tk.MustExec("create event e1 on schedule every 1 second do insert into t values (1)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from t where a = 1").Rows()) >= 2
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustExec("alter event e1 disable")
+	tk.MustQuery("select count(*) > 0 from mysql.tidb_event_history where event_schema = 'test' and event_name = 'e1' " +
+		"and status = 'success'").Check(testkit.Rows("1"))
+	tk.MustQuery("select last_executed is not null from information_schema.events where event_name = 'e1'").Check(testkit.Rows("1"))
+
+	// The one-time event is dropped after it's executed.
+	tk.MustExec("create event e2 on schedule at now() + interval 1 second do insert into t values (2)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select event_name from information_schema.events where event_name = 'e2'").Rows()) == 0
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select * from t where a = 2").Check(testkit.Rows("2"))
+
+	// The failed execution is recorded in the history.
+	tk.MustExec("create event e3 on schedule at now() + interval 1 second on completion preserve do insert into no_such_table values (3)")
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select * from mysql.tidb_event_history where event_name = 'e3' and status = 'failed'").Rows()) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+	tk.MustQuery("select error_message from mysql.tidb_event_history where event_name = 'e3'").Check(testkit.Rows(
+		"[schema:1146]Table 'test.no_such_table' doesn't exist"))
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select status from information_schema.events where event_name = 'e3' and status = 'DISABLED'").Rows()) == 1
+	}, 10*time.Second, 100*time.Millisecond)
+}
@@ -0,0 +1,203 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package materializedviewtest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaterializedViewDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 1, 'x'), (2, 1, 'y'), (3, 2, 'z')")
+
+	tk.MustExec("create materialized view mv as select a, count(*) as cnt, sum(id) as s from t group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 3", "2 1 3"))
+	tk.MustQuery("show create table mv").Check(testkit.Rows(
+		"mv CREATE MATERIALIZED VIEW `mv` (`a`, `cnt`, `s`) AS SELECT `a`,COUNT(1) AS `cnt`,SUM(`id`) AS `s` FROM `test`.`t` GROUP BY `a`"))
+	tk.MustGetErrCode("create materialized view mv as select * from t", 1050)
+	tk.MustExec("create materialized view if not exists mv as select * from t")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+	tk.MustExec("create materialized view mv2 (x, y) as select id, b from t where a = 1")
+	tk.MustQuery("select * from mv2 order by x").Check(testkit.Rows("1 x", "2 y"))
+	tk.MustGetErrCode("create materialized view mv3 (x) as select id, b from t", 1353)
+	tk.MustGetErrCode("create materialized view mv3 as select * from no_such", 1146)
+
+	// The views are only changed by refreshing them.
+	tk.MustGetErrCode("insert into mv values (3, 1, 1)", 1288)
+	tk.MustGetErrCode("replace into mv values (3, 1, 1)", 1288)
+	tk.MustGetErrCode("update mv set cnt = 0", 1288)
+	tk.MustGetErrCode("delete from mv", 1288)
+	tk.MustGetErrCode("delete mv from mv, t where mv.a = t.a", 1288)
+	tk.MustGetErrCode("truncate table mv", 1347)
+	tk.MustGetErrCode("drop table mv", 1347)
+	tk.MustGetErrCode("drop materialized view t", 1347)
+	tk.MustGetErrCode("refresh materialized view t", 1347)
+
+	tk.MustExec("drop materialized view mv, mv2")
+	tk.MustGetErrCode("select * from mv", 1146)
+	tk.MustGetErrCode("drop materialized view mv", 1051)
+	tk.MustExec("drop materialized view if exists mv")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1051 Unknown table 'test.mv'"))
+}
+
+func TestMaterializedViewIncrementalRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int, b int)")
+	tk.MustExec("create table s(id int, c varchar(10), primary key(id, c))")
+	tk.MustExec("insert into t values (1, 1, 10), (2, 1, 20), (3, 2, 30)")
+	tk.MustExec("insert into s values (1, 'a'), (3, 'b')")
+
+	tk.MustExec("create materialized view agg as select a, count(*) as cnt, sum(b) as total from t group by a having count(*) > 0")
+	tk.MustExec("create materialized view j as select t.id, s.id as sid, s.c, t.b from t join s on t.id = s.id where t.b > 5")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	tk.MustExec("insert into t values (4, 2, 40), (5, 3, 50)")
+	tk.MustExec("update t set a = 3 where id = 1")
+	tk.MustExec("delete from t where id = 3")
+	tk.MustExec("insert into t values (2, 1, 0) on duplicate key update b = b + 1")
+	tk.MustExec("replace into s values (5, 'c')")
+	tk.MustExec("begin")
+	tk.MustExec("insert into s values (4, 'd')")
+	tk.MustExec("rollback")
+	// The views aren't changed until they're refreshed.
+	tk.MustQuery("select * from agg order by a").Check(testkit.Rows("1 2 30", "2 1 30"))
+	tk.MustQuery("select * from j order by id").Check(testkit.Rows("1 1 a 10", "3 3 b 30"))
+	tk.MustQuery("select count(*) > 0 from mysql.tidb_mview_log").Check(testkit.Rows("1"))
+
+	tk.MustExec("refresh materialized view agg incremental")
+	tk.MustQuery("select * from agg order by a").Check(tk.MustQuery(
+		"select a, count(*), sum(b) from t group by a order by a").Rows())
+	tk.MustQuery("select * from agg order by a").Check(testkit.Rows("1 1 21", "2 1 40", "3 2 60"))
+	tk.MustExec("refresh materialized view j")
+	tk.MustQuery("select * from j order by id").Check(testkit.Rows("1 1 a 10", "5 5 c 50"))
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	// Truncating a base table loses the logs, the view is refreshed completely.
+	tk.MustExec("truncate table s")
+	tk.MustExec("insert into s values (2, 'e')")
+	tk.MustExec("refresh materialized view j incremental")
+	tk.MustQuery("select * from j order by id").Check(testkit.Rows("2 2 e 21"))
+
+	// The logs of the dropped views are deleted.
+	tk.MustExec("insert into t values (6, 6, 6)")
+	tk.MustQuery("select count(*) > 0 from mysql.tidb_mview_log").Check(testkit.Rows("1"))
+	tk.MustExec("drop materialized view agg, j")
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+}
+
+func TestMaterializedViewCompleteRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("create table s(a int primary key)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (null, 3)")
+	tk.MustExec("insert into s values (1)")
+
+	// These views can't be refreshed incrementally: no primary key, outer joins, scalar aggregation and limit.
+	for _, query := range []string{
+		"select * from t",
+		"select t.a, s.a as sa from s left join t on s.a = t.a",
+		"select count(*) as cnt from s",
+		"select a from s order by a limit 1",
+		"select a, rand() as r from s",
+	} {
+		tk.MustExec("create materialized view mv as " + query)
+		tk.MustGetErrCode("refresh materialized view mv incremental", 8263)
+		tk.MustExec("refresh materialized view mv complete")
+		tk.MustExec("drop materialized view mv")
+	}
+	tk.MustQuery("select count(*) from mysql.tidb_mview_log").Check(testkit.Rows("0"))
+
+	tk.MustExec("create materialized view mv as select a, b from t where b like '%'")
+	tk.MustExec("insert into t values (4, 4)")
+	tk.MustQuery("select count(*) from mv").Check(testkit.Rows("3"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select count(*) from mv").Check(testkit.Rows("4"))
+
+	// The query is executed with the sql_mode the view is created with.
+	tk.MustExec("set sql_mode = ''")
+	tk.MustExec("create materialized view mv2 as select a / 0 as c from t where a = 1")
+	tk.MustExec("set sql_mode = default")
+	tk.MustExec("refresh materialized view mv2")
+	tk.MustQuery("select * from mv2").Check(testkit.Rows("<nil>"))
+
+	// The refresh commits the current transaction first.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (5, 5)")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from mv").Check(testkit.Rows("5"))
+}
+
+func TestMaterializedViewRewrite(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+	tk.MustExec("create materialized view mv as select a, sum(b) from t group by a")
+	tk.MustExec("insert into t values (2, 4)")
+
+	query := "select a, sum(b) from t group by a"
+	tk.MustQuery(query + " order by a").Check(testkit.Rows("1 3", "2 7"))
+	tk.MustExec("set @@tidb_enable_materialized_view_rewrite = 1")
+	// The query is answered by the view, so the result is stale until the view is refreshed.
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 3", "2 3"))
+	tk.MustQuery("explain format = 'brief' " + query).CheckContain("table:mv")
+	rs, err := tk.Exec(query)
+	require.NoError(t, err)
+	require.Equal(t, "sum(b)", rs.Fields()[1].ColumnAsName.O)
+	require.NoError(t, rs.Close())
+	// The queries different from the view aren't rewritten.
+	tk.MustQuery("explain format = 'brief' select a, sum(b) from t where a > 0 group by a").CheckNotContain("table:mv")
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery(query).Sort().Check(testkit.Rows("1 3", "2 7"))
+	// The queries are normalized, so the ones only different in the cases of the keywords and the names are rewritten.
+	tk.MustQuery("explain format = 'brief' SELECT A, SUM(B) FROM T GROUP BY A").CheckContain("table:mv")
+
+	// The result columns are named as the ones of the query even if the view renames them.
+	tk.MustExec("create table s(a int primary key, c int)")
+	tk.MustExec("insert into s values (1, 10), (2, 20)")
+	tk.MustExec("create materialized view mv_join (x, y) as select s.c, t.b from s join t on t.a = s.a where t.b > 3")
+	joinQuery := "select s.c, t.b from s join t on t.a = s.a where t.b > 3"
+	tk.MustQuery("explain format = 'brief' " + joinQuery).CheckContain("table:mv_join")
+	tk.MustQuery(joinQuery).Check(testkit.Rows("20 4"))
+	rs, err = tk.Exec(joinQuery)
+	require.NoError(t, err)
+	require.Equal(t, "c", rs.Fields()[0].ColumnAsName.O)
+	require.Equal(t, "b", rs.Fields()[1].ColumnAsName.O)
+	require.NoError(t, rs.Close())
+
+	// Reading the view requires the privileges of the base tables.
+	tk.MustExec("create user 'u'@'%'")
+	tk.MustExec("grant select on test.mv to 'u'@'%'")
+	tk2 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk2.MustExec("use test")
+	tk2.MustExec("set @@tidb_enable_materialized_view_rewrite = 1")
+	tk2.MustGetErrCode(query, 1142)
+	tk2.MustQuery("select count(*) from mv").Check(testkit.Rows("2"))
+	tk2.MustGetErrCode("refresh materialized view mv", 1142)
+}
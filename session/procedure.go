@@ -0,0 +1,889 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/privilege/privileges"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// callProcedure executes the CALL statement. The procedure is interpreted statement by statement,
+// every SQL statement in its body is executed by ExecuteStmt with the privileges of the definer,
+// and the result sets of the SELECT statements are returned as a sqlexec.MultiRecordSet.
+func (s *session) callProcedure(ctx context.Context, stmt *ast.CallStmt) (sqlexec.RecordSet, error) {
+	vars := s.sessionVars
+	stmtCtx, startTime, durationParse := vars.StmtCtx, vars.StartTime, vars.DurationParse
+	results := &procedureResults{}
+	err := s.execCallStmt(ctx, stmt, nil, results)
+	// The statements in the body reset the statement context. Restore the one of CALL to record the
+	// slow log and the statement summary of CALL, and return the result of the last statement.
+	if last := vars.StmtCtx; last != stmtCtx {
+		stmtCtx.SetAffectedRows(last.AffectedRows())
+		stmtCtx.SetWarnings(last.GetWarnings())
+		vars.StmtCtx = stmtCtx
+	}
+	vars.StartTime, vars.DurationParse, vars.DurationCompile = startTime, durationParse, 0
+	execStmt := &executor.ExecStmt{
+		GoCtx:    ctx,
+		Plan:     &plannercore.Simple{Statement: stmt},
+		Text:     stmt.Text(),
+		StmtNode: stmt,
+		Ctx:      s,
+	}
+	execStmt.FinishExecuteStmt(0, err, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.recordSets) == 0 {
+		return nil, nil
+	}
+	return results, nil
+}
+
+// execCallStmt calls the procedure, the caller is nil if it's not called by another procedure.
+func (s *session) execCallStmt(ctx context.Context, stmt *ast.CallStmt, caller *procedureExec, results *procedureResults) error {
+	vars := s.sessionVars
+	dbName := stmt.Procedure.Schema
+	if dbName.L == "" {
+		if vars.CurrentDB == "" {
+			return errors.Trace(plannercore.ErrNoDB)
+		}
+		dbName = model.NewCIStr(vars.CurrentDB)
+	}
+	procName := stmt.Procedure.FnName
+	fullName := dbName.O + "." + procName.O
+
+	if checker := privilege.GetPrivilegeManager(s); checker != nil && !checker.RequestVerification(vars.ActiveRoles, dbName.L, "", "", mysql.ExecutePriv) {
+		var user, host string
+		if vars.User != nil {
+			user, host = vars.User.AuthUsername, vars.User.AuthHostname
+		}
+		return plannercore.ErrProcaccessDenied.GenWithStackByArgs("execute", user, host, fullName)
+	}
+	is := s.GetInfoSchema().(infoschema.InfoSchema)
+	proc, ok := is.ProcedureByName(dbName, procName)
+	if !ok {
+		return infoschema.ErrProcedureNotExists.GenWithStackByArgs("PROCEDURE", fullName)
+	}
+	for p := caller; p != nil; p = p.caller {
+		if p.proc != nil && p.proc.ID == proc.ID {
+			// max_sp_recursion_depth is not supported, which means it is always 0.
+			return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(0, procName.O)
+		}
+	}
+
+	// The definition is parsed with the SQL mode at the time the procedure was created.
+	sqlMode, err := mysql.GetSQLMode(proc.SQLMode)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	originSQLMode := vars.SQLMode
+	vars.SQLMode = sqlMode
+	stmts, _, err := s.ParseSQL(ctx, proc.Definition)
+	vars.SQLMode = originSQLMode
+	if err != nil {
+		return errors.Trace(err)
+	}
+	procInfo, ok := stmts[0].(*ast.ProcedureInfo)
+	if !ok || len(stmts) != 1 {
+		return errors.Errorf("invalid definition of procedure %s", fullName)
+	}
+	if len(procInfo.ProcedureParam) != len(stmt.Procedure.Args) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs("PROCEDURE", fullName, len(procInfo.ProcedureParam), len(stmt.Procedure.Args))
+	}
+
+	pe := &procedureExec{
+		s:       s,
+		caller:  caller,
+		proc:    proc,
+		results: results,
+	}
+	// The arguments are evaluated in the context of the caller.
+	params := newProcedureScope()
+	outArgs := make([]func(*procedureVar) error, len(procInfo.ProcedureParam))
+	for i, param := range procInfo.ProcedureParam {
+		v := &procedureVar{tp: procedureVarType(param.ParamType)}
+		params.vars[strings.ToLower(param.ParamName)] = v
+		arg := stmt.Procedure.Args[i]
+		if param.Paramstatus != ast.MODE_IN {
+			if outArgs[i] = pe.outArgument(arg, caller); outArgs[i] == nil {
+				return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+		}
+		if param.Paramstatus == ast.MODE_OUT {
+			continue
+		}
+		d, err := evalProcedureExpr(ctx, s, arg)
+		if err != nil {
+			return err
+		}
+		if err = pe.assign(v, d); err != nil {
+			return err
+		}
+	}
+
+	restoreInvoker, err := s.switchToDefiner(proc.Definer)
+	if err != nil {
+		return err
+	}
+	defer restoreInvoker()
+	originDB, originProcVars, originPlanCache := vars.CurrentDB, vars.ProcedureVars, vars.EnableNonPreparedPlanCache
+	vars.CurrentDB, vars.SQLMode, vars.ProcedureVars = dbName.O, sqlMode, pe
+	// The statements that reference the local variables can't share the cached plans.
+	vars.EnableNonPreparedPlanCache = false
+	defer func() {
+		vars.CurrentDB, vars.SQLMode, vars.ProcedureVars = originDB, originSQLMode, originProcVars
+		vars.EnableNonPreparedPlanCache = originPlanCache
+	}()
+
+	pe.scopes = append(pe.scopes, params)
+	_, err = pe.execStmt(ctx, procInfo.ProcedureBody)
+	pe.closeScope(params)
+	if err != nil {
+		if unhandled, ok := err.(*procedureUnhandledError); ok {
+			// Let the handlers of the caller handle it.
+			err = unhandled.err
+		}
+		return err
+	}
+	for i, param := range procInfo.ProcedureParam {
+		if outArgs[i] == nil {
+			continue
+		}
+		if err := outArgs[i](params.vars[strings.ToLower(param.ParamName)]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// switchToDefiner switches the identity of the session to the definer of the procedure or trigger, so
+// the body is executed with the privileges of the definer, which is SQL SECURITY DEFINER in MySQL. It
+// returns the function to switch back to the invoker. The routines created without a user keep the invoker.
+func (s *session) switchToDefiner(definer *auth.UserIdentity) (func(), error) {
+	vars := s.sessionVars
+	originPM := privilege.GetPrivilegeManager(s)
+	if originPM == nil || definer == nil || (definer.Username == "" && definer.Hostname == "") {
+		return func() {}, nil
+	}
+	extensions, err := extension.GetExtensions()
+	if err != nil {
+		return nil, err
+	}
+	pm := privileges.NewUserPrivileges(domain.GetDomain(s).PrivilegeHandle(), extensions)
+	pm.AuthSuccess(definer.Username, definer.Hostname)
+	originUser, originRoles := vars.User, vars.ActiveRoles
+	privilege.BindPrivilegeManager(s, pm)
+	vars.User = &auth.UserIdentity{
+		Username:     definer.Username,
+		Hostname:     definer.Hostname,
+		AuthUsername: definer.Username,
+		AuthHostname: definer.Hostname,
+	}
+	vars.ActiveRoles = pm.GetDefaultRoles(definer.Username, definer.Hostname)
+	return func() {
+		privilege.BindPrivilegeManager(s, originPM)
+		vars.User, vars.ActiveRoles = originUser, originRoles
+	}, nil
+}
+
+// outArgument returns the function to set the OUT or INOUT argument, it returns nil if the argument
+// is neither a user variable nor a local variable of the caller.
+func (pe *procedureExec) outArgument(arg ast.ExprNode, caller *procedureExec) func(*procedureVar) error {
+	switch x := arg.(type) {
+	case *ast.VariableExpr:
+		if !x.IsSystem && x.Value == nil {
+			name := strings.ToLower(x.Name)
+			return func(v *procedureVar) error {
+				vars := pe.s.sessionVars
+				if v.val.IsNull() {
+					vars.UnsetUserVar(name)
+					return nil
+				}
+				vars.SetUserVarVal(name, v.val)
+				vars.SetUserVarType(name, v.tp)
+				return nil
+			}
+		}
+	case *ast.ColumnNameExpr:
+		if caller != nil && x.Name.Table.L == "" {
+			if target := caller.lookupVar(x.Name.Name.L); target != nil {
+				return func(v *procedureVar) error {
+					return caller.assign(target, v.val)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// procedureResults is the result sets returned by the CALL statement, it reads the first one by default.
+type procedureResults struct {
+	recordSets []sqlexec.RecordSet
+}
+
+var _ sqlexec.MultiRecordSet = &procedureResults{}
+
+// Fields implements the sqlexec.RecordSet interface.
+func (r *procedureResults) Fields() []*ast.ResultField {
+	return r.recordSets[0].Fields()
+}
+
+// Next implements the sqlexec.RecordSet interface.
+func (r *procedureResults) Next(ctx context.Context, req *chunk.Chunk) error {
+	return r.recordSets[0].Next(ctx, req)
+}
+
+// NewChunk implements the sqlexec.RecordSet interface.
+func (r *procedureResults) NewChunk(alloc chunk.Allocator) *chunk.Chunk {
+	return r.recordSets[0].NewChunk(alloc)
+}
+
+// Close implements the sqlexec.RecordSet interface.
+func (*procedureResults) Close() error {
+	return nil
+}
+
+// RecordSets implements the sqlexec.MultiRecordSet interface.
+func (r *procedureResults) RecordSets() []sqlexec.RecordSet {
+	return r.recordSets
+}
+
+// procedureVar is a local variable or a parameter of the procedure.
+type procedureVar struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+// procedureCursor is a cursor of the procedure. The result of the query is read into memory when it's opened.
+type procedureCursor struct {
+	query ast.StmtNode
+	rows  [][]interface{}
+	open  bool
+}
+
+// procedureScope is the variables, cursors and handlers declared in a BEGIN ... END block.
+type procedureScope struct {
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+	// inHandler is true when a handler of this scope is running, the errors in the handler are
+	// handled by the outer scopes.
+	inHandler bool
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+// procedureCtrl changes the control flow of the procedure.
+type procedureCtrl struct {
+	// label is the label of LEAVE or ITERATE.
+	label   string
+	iterate bool
+	// exitScope is the scope that declares the EXIT handler, the block of it is left.
+	exitScope *procedureScope
+}
+
+// procedureUnhandledError is an error that no handler of the procedure can handle.
+type procedureUnhandledError struct {
+	err error
+}
+
+func (e *procedureUnhandledError) Error() string {
+	return e.err.Error()
+}
+
+// procedureExec interprets a procedure or the body of a trigger.
+type procedureExec struct {
+	s       *session
+	caller  *procedureExec
+	proc    *model.ProcedureInfo
+	scopes  []*procedureScope
+	results *procedureResults
+	// trigger is not nil if it executes a trigger, proc is nil then.
+	trigger *triggerRows
+}
+
+// GetProcedureVar implements the variable.ProcedureVarReader interface.
+func (pe *procedureExec) GetProcedureVar(name string) (types.Datum, *types.FieldType, bool) {
+	if v := pe.lookupVar(name); v != nil {
+		return v.val, v.tp, true
+	}
+	if pe.trigger != nil {
+		if row, col, offset := pe.trigger.lookup(name); offset >= 0 {
+			return row[offset], &col.FieldType, true
+		}
+	}
+	return types.Datum{}, nil, false
+}
+
+func (pe *procedureExec) lookupVar(name string) *procedureVar {
+	name = strings.ToLower(name)
+	for i := len(pe.scopes) - 1; i >= 0; i-- {
+		if v, ok := pe.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (pe *procedureExec) lookupCursor(name string) (*procedureCursor, error) {
+	name = strings.ToLower(name)
+	for i := len(pe.scopes) - 1; i >= 0; i-- {
+		if c, ok := pe.scopes[i].cursors[name]; ok {
+			return c, nil
+		}
+	}
+	return nil, exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (pe *procedureExec) assign(v *procedureVar, d types.Datum) error {
+	if d.IsNull() {
+		v.val.SetNull()
+		return nil
+	}
+	val, err := d.ConvertTo(pe.s.sessionVars.StmtCtx, v.tp)
+	if err != nil {
+		return err
+	}
+	v.val = val
+	return nil
+}
+
+// procedureVarType returns the type of the local variable, the unspecified charset and length
+// are filled with the defaults.
+func procedureVarType(tp *types.FieldType) *types.FieldType {
+	tp = tp.Clone()
+	if types.IsString(tp.GetType()) && tp.GetCharset() == "" {
+		cs, co := charset.GetDefaultCharsetAndCollate()
+		tp.SetCharset(cs)
+		tp.SetCollate(co)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	return tp
+}
+
+func (pe *procedureExec) closeScope(scope *procedureScope) {
+	for _, c := range scope.cursors {
+		c.rows, c.open = nil, false
+	}
+	pe.scopes = pe.scopes[:len(pe.scopes)-1]
+}
+
+func (pe *procedureExec) execStmts(ctx context.Context, stmts []ast.StmtNode) (*procedureCtrl, error) {
+	for _, stmt := range stmts {
+		sc := pe.s.sessionVars.StmtCtx
+		n := len(sc.GetWarnings())
+		ctrl, err := pe.execStmt(ctx, stmt)
+		if err != nil {
+			if ctrl, err = pe.handleError(ctx, err); err != nil {
+				return nil, err
+			}
+		} else if ctrl == nil && !isCompoundProcedureStmt(stmt) {
+			if ctrl, err = pe.handleWarnings(ctx, sc, n); err != nil {
+				return nil, err
+			}
+		}
+		if ctrl != nil {
+			return ctrl, nil
+		}
+	}
+	return nil, nil
+}
+
+// isCompoundProcedureStmt reports whether the statement runs other statements, which raise their
+// warnings to the handlers by themselves.
+func isCompoundProcedureStmt(stmt ast.StmtNode) bool {
+	switch stmt.(type) {
+	case *ast.ProcedureBlock, *ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop, *ast.ProcedureWhileStmt,
+		*ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt, *ast.ProcedureIfInfo, *ast.SimpleCaseStmt,
+		*ast.SearchCaseStmt, *ast.CallStmt:
+		return true
+	}
+	return false
+}
+
+func (pe *procedureExec) execStmt(ctx context.Context, stmt ast.StmtNode) (*procedureCtrl, error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return pe.execBlock(ctx, x)
+	case *ast.ProcedureLabelBlock:
+		ctrl, err := pe.execBlock(ctx, x.Block)
+		if ctrl != nil && !ctrl.iterate && strings.EqualFold(ctrl.label, x.LabelName) {
+			ctrl = nil
+		}
+		return ctrl, err
+	case *ast.ProcedureLabelLoop:
+		return pe.execLoop(ctx, x.Block, strings.ToLower(x.LabelName))
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return pe.execLoop(ctx, x, "")
+	case *ast.ProcedureJump:
+		return &procedureCtrl{label: strings.ToLower(x.Name), iterate: !x.IsLeave}, nil
+	case *ast.ProcedureIfInfo:
+		return pe.execIf(ctx, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			ok, err := pe.evalCondition(ctx, &ast.BinaryOperationExpr{Op: opcode.EQ, L: x.Condition, R: when.Expr})
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return pe.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		return pe.execCaseElse(ctx, x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			ok, err := pe.evalCondition(ctx, when.Expr)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return pe.execStmts(ctx, when.ProcedureStmts)
+			}
+		}
+		return pe.execCaseElse(ctx, x.ElseCases)
+	case *ast.ProcedureOpenCur:
+		return nil, pe.openCursor(ctx, x)
+	case *ast.ProcedureFetchInto:
+		return nil, pe.fetchCursor(x)
+	case *ast.ProcedureCloseCur:
+		c, err := pe.lookupCursor(x.CurName)
+		if err != nil {
+			return nil, err
+		}
+		if !c.open {
+			return nil, exeerrors.ErrSpCursorNotOpen
+		}
+		c.rows, c.open = nil, false
+		return nil, nil
+	case *ast.SetStmt:
+		return nil, pe.execSet(ctx, x)
+	case *ast.CallStmt:
+		return nil, pe.s.execCallStmt(ctx, x, pe, pe.results)
+	}
+	rs, err := execProcedureSQL(ctx, pe.s, stmt)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	fields, rows, err := drainProcedureRecordSet(ctx, pe.s, rs)
+	if err != nil {
+		return nil, err
+	}
+	pe.results.recordSets = append(pe.results.recordSets, &sqlexec.SimpleRecordSet{
+		ResultFields: fields,
+		Rows:         rows,
+		MaxChunkSize: pe.s.sessionVars.MaxChunkSize,
+	})
+	return nil, nil
+}
+
+func (pe *procedureExec) execBlock(ctx context.Context, block *ast.ProcedureBlock) (*procedureCtrl, error) {
+	scope := newProcedureScope()
+	pe.scopes = append(pe.scopes, scope)
+	defer pe.closeScope(scope)
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			d := types.Datum{}
+			if x.DeclDefault != nil {
+				var err error
+				if d, err = evalProcedureExpr(ctx, pe.s, x.DeclDefault); err != nil {
+					return nil, err
+				}
+			}
+			for _, name := range x.DeclNames {
+				v := &procedureVar{tp: procedureVarType(x.DeclType)}
+				if err := pe.assign(v, d); err != nil {
+					return nil, err
+				}
+				scope.vars[strings.ToLower(name)] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[strings.ToLower(x.CurName)] = &procedureCursor{query: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	ctrl, err := pe.execStmts(ctx, block.ProcedureProcStmts)
+	if ctrl != nil && ctrl.exitScope == scope {
+		ctrl = nil
+	}
+	return ctrl, err
+}
+
+func (pe *procedureExec) execLoop(ctx context.Context, loop ast.StmtNode, label string) (*procedureCtrl, error) {
+	for {
+		var (
+			body []ast.StmtNode
+			cond ast.ExprNode
+		)
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			ok, err := pe.evalCondition(ctx, x.Condition)
+			if err != nil || !ok {
+				return nil, err
+			}
+			body = x.Body
+		case *ast.ProcedureRepeatStmt:
+			body, cond = x.Body, x.Condition
+		case *ast.ProcedureLoopStmt:
+			body = x.Body
+		default:
+			return nil, errors.Errorf("unknown loop statement %T", loop)
+		}
+		ctrl, err := pe.execStmts(ctx, body)
+		if err != nil {
+			return nil, err
+		}
+		if ctrl != nil {
+			if label == "" || ctrl.label != label {
+				return ctrl, nil
+			}
+			if !ctrl.iterate {
+				return nil, nil
+			}
+			continue
+		}
+		if cond != nil {
+			if ok, err := pe.evalCondition(ctx, cond); err != nil || ok {
+				return nil, err
+			}
+		}
+	}
+}
+
+func (pe *procedureExec) execIf(ctx context.Context, block *ast.ProcedureIfBlock) (*procedureCtrl, error) {
+	ok, err := pe.evalCondition(ctx, block.IfExpr)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		return pe.execStmts(ctx, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return pe.execIf(ctx, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return pe.execStmts(ctx, x.ProcedureIfStmts)
+	}
+	return nil, nil
+}
+
+func (pe *procedureExec) execCaseElse(ctx context.Context, stmts []ast.StmtNode) (*procedureCtrl, error) {
+	if stmts == nil {
+		return nil, exeerrors.ErrSpCaseNotFound
+	}
+	return pe.execStmts(ctx, stmts)
+}
+
+// execSet assigns the local variables, the other variables are set by the SET statement.
+func (pe *procedureExec) execSet(ctx context.Context, stmt *ast.SetStmt) error {
+	for _, assignment := range stmt.Variables {
+		if assignment.IsSystem && !assignment.IsGlobal {
+			if v := pe.lookupVar(assignment.Name); v != nil {
+				d, err := evalProcedureExpr(ctx, pe.s, assignment.Value)
+				if err != nil {
+					return err
+				}
+				if err = pe.assign(v, d); err != nil {
+					return err
+				}
+				continue
+			}
+			if pe.trigger != nil && strings.Contains(assignment.Name, ".") {
+				d, err := evalProcedureExpr(ctx, pe.s, assignment.Value)
+				if err != nil {
+					return err
+				}
+				ok, err := pe.assignTriggerRow(assignment.Name, d)
+				if err != nil {
+					return err
+				}
+				if ok {
+					continue
+				}
+			}
+		}
+		if _, err := execProcedureSQL(ctx, pe.s, &ast.SetStmt{Variables: []*ast.VariableAssignment{assignment}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (pe *procedureExec) openCursor(ctx context.Context, stmt *ast.ProcedureOpenCur) error {
+	c, err := pe.lookupCursor(stmt.CurName)
+	if err != nil {
+		return err
+	}
+	if c.open {
+		return exeerrors.ErrSpCursorAlreadyOpen
+	}
+	rs, err := execProcedureSQL(ctx, pe.s, c.query)
+	if err != nil {
+		return err
+	}
+	if rs != nil {
+		if _, c.rows, err = drainProcedureRecordSet(ctx, pe.s, rs); err != nil {
+			return err
+		}
+	}
+	c.open = true
+	return nil
+}
+
+func (pe *procedureExec) fetchCursor(stmt *ast.ProcedureFetchInto) error {
+	c, err := pe.lookupCursor(stmt.CurName)
+	if err != nil {
+		return err
+	}
+	if !c.open {
+		return exeerrors.ErrSpCursorNotOpen
+	}
+	if len(c.rows) == 0 {
+		return exeerrors.ErrSpFetchNoData
+	}
+	row := c.rows[0]
+	if len(row) != len(stmt.Variables) {
+		return exeerrors.ErrSpWrongNoOfFetchArgs
+	}
+	c.rows = c.rows[1:]
+	for i, name := range stmt.Variables {
+		v := pe.lookupVar(name)
+		if v == nil {
+			return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := pe.assign(v, types.NewDatum(row[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// handleError runs the handler of the error. The handlers of the inner scope take precedence, and in
+// the same scope, the handler of the error code is chosen first, then SQLSTATE, then the condition class.
+func (pe *procedureExec) handleError(ctx context.Context, err error) (*procedureCtrl, error) {
+	if _, ok := err.(*procedureUnhandledError); ok {
+		return nil, err
+	}
+	code, state := procedureConditionOf(err)
+	ctrl, handled, handlerErr := pe.runHandler(ctx, code, state, false)
+	if !handled {
+		return nil, &procedureUnhandledError{err: err}
+	}
+	return ctrl, handlerErr
+}
+
+// handleWarnings raises the warnings the statement appends to the statement context after the first n
+// ones. The statements in the body except the ones of triggers reset the statement context, so all the
+// warnings are new if the statement context is not sc. Like MySQL, only one handler is activated for
+// the statement, the one of the first warning.
+func (pe *procedureExec) handleWarnings(ctx context.Context, sc *stmtctx.StatementContext, n int) (*procedureCtrl, error) {
+	if last := pe.s.sessionVars.StmtCtx; last != sc {
+		sc, n = last, 0
+	}
+	warnings := sc.GetWarnings()
+	for i := n; i < len(warnings); i++ {
+		if warnings[i].Level != stmtctx.WarnLevelWarning {
+			continue
+		}
+		code, state := procedureConditionOf(warnings[i].Err)
+		ctrl, _, err := pe.runHandler(ctx, code, state, true)
+		return ctrl, err
+	}
+	return nil, nil
+}
+
+func procedureConditionOf(err error) (code uint16, state string) {
+	code, state = uint16(mysql.ErrUnknown), mysql.DefaultMySQLState
+	if terr, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(terr)
+		code, state = sqlErr.Code, sqlErr.State
+	}
+	return code, state
+}
+
+// runHandler runs the handler of the condition, handled is false if no handler matches it.
+func (pe *procedureExec) runHandler(ctx context.Context, code uint16, state string, warning bool) (ctrl *procedureCtrl, handled bool, err error) {
+	for i := len(pe.scopes) - 1; i >= 0; i-- {
+		scope := pe.scopes[i]
+		if scope.inHandler {
+			continue
+		}
+		handler, priority := (*ast.ProcedureErrorControl)(nil), 0
+		for _, h := range scope.handlers {
+			if p := handlerPriority(h, code, state, warning); p > priority {
+				handler, priority = h, p
+			}
+		}
+		if handler == nil {
+			continue
+		}
+		// The handler runs in the scope that declares it.
+		scopes := pe.scopes
+		pe.scopes = append([]*procedureScope(nil), scopes[:i+1]...)
+		scope.inHandler = true
+		ctrl, err := pe.execStmt(ctx, handler.Operate)
+		scope.inHandler = false
+		pe.scopes = scopes
+		if err != nil || ctrl != nil {
+			return ctrl, true, err
+		}
+		if handler.ControlHandle == ast.PROCEDUR_EXIT {
+			return &procedureCtrl{exitScope: scope}, true, nil
+		}
+		return nil, true, nil
+	}
+	return nil, false, nil
+}
+
+// handlerPriority returns the priority of the handler for the condition, 0 means it doesn't match. Like
+// MySQL, SQLWARNING matches the warnings and the class "01", SQLEXCEPTION matches the other errors.
+func handlerPriority(h *ast.ProcedureErrorControl, code uint16, state string, warning bool) int {
+	priority := 0
+	for _, cond := range h.ErrorCon {
+		p := 0
+		switch x := cond.(type) {
+		case *ast.ProcedureErrorVal:
+			if x.ErrorNum == uint64(code) {
+				p = 3
+			}
+		case *ast.ProcedureErrorState:
+			if x.CodeStatus == state {
+				p = 2
+			}
+		case *ast.ProcedureErrorCon:
+			class := state[:2]
+			switch x.ErrorCon {
+			case ast.PROCEDUR_NOT_FOUND:
+				if class == "02" {
+					p = 1
+				}
+			case ast.PROCEDUR_SQLWARNING:
+				if warning || class == "01" {
+					p = 1
+				}
+			case ast.PROCEDUR_SQLEXCEPTION:
+				if !warning && class != "00" && class != "01" && class != "02" {
+					p = 1
+				}
+			}
+		}
+		if p > priority {
+			priority = p
+		}
+	}
+	return priority
+}
+
+func (pe *procedureExec) evalCondition(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	d, err := evalProcedureExpr(ctx, pe.s, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	i, err := d.ToBool(pe.s.sessionVars.StmtCtx)
+	return i != 0, err
+}
+
+// evalProcedureExpr evaluates the expression by a SELECT statement.
+func evalProcedureExpr(ctx context.Context, s *session, expr ast.ExprNode) (types.Datum, error) {
+	sel := &ast.SelectStmt{
+		SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+		Kind:           ast.SelectStmtKindSelect,
+		Fields:         &ast.FieldList{Fields: []*ast.SelectField{{Expr: expr}}},
+	}
+	rs, err := execProcedureSQL(ctx, s, sel)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	_, rows, err := drainProcedureRecordSet(ctx, s, rs)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return types.NewDatum(rows[0][0]), nil
+}
+
+// execProcedureSQL executes a statement in the body of the procedure.
+func execProcedureSQL(ctx context.Context, s *session, stmt ast.StmtNode) (sqlexec.RecordSet, error) {
+	if stmt.Text() == "" {
+		// The statements in the body have no text, restore it for the statement summary and the plan cache.
+		var sb strings.Builder
+		if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return nil, errors.Trace(err)
+		}
+		stmt.SetText(nil, sb.String())
+	}
+	if len(s.sessionVars.StmtCtx.TriggerTableIDs) > 0 {
+		// The statements of the triggers run in the statement that fires them.
+		return executor.ExecTriggerStmt(ctx, s, stmt)
+	}
+	return s.ExecuteStmt(ctx, stmt)
+}
+
+func drainProcedureRecordSet(ctx context.Context, s *session, rs sqlexec.RecordSet) ([]*ast.ResultField, [][]interface{}, error) {
+	fields := rs.Fields()
+	chunkRows, err := drainRecordSet(ctx, s, rs, nil)
+	if err != nil {
+		terror.Call(rs.Close)
+		return nil, nil, err
+	}
+	fieldTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		fieldTypes = append(fieldTypes, &field.Column.FieldType)
+	}
+	rows := make([][]interface{}, 0, len(chunkRows))
+	for _, row := range chunkRows {
+		datums := row.GetDatumRow(fieldTypes)
+		values := make([]interface{}, 0, len(datums))
+		for i := range datums {
+			values = append(values, datums[i].Clone().GetValue())
+		}
+		rows = append(rows, values)
+	}
+	return fields, rows, rs.Close()
+}
@@ -0,0 +1,242 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package proceduretest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcedureDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustGetErrCode("create procedure p() select 1", 1046)
+	tk.MustExec("use test")
+
+	createSQL := "create procedure p(in a int, out b varchar(10)) begin set b = concat('v', a); end"
+	tk.MustExec(createSQL)
+	tk.MustGetErrMsg(createSQL, "[schema:1304]PROCEDURE p already exists")
+	tk.MustExec("create procedure if not exists p() select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p already exists"))
+	tk.MustQuery("show create procedure p").Check(testkit.Rows(
+		"p ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION " +
+			createSQL + " utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustExec("create database test2")
+	tk.MustExec("create procedure test2.p2() select 1")
+	rows := tk.MustQuery("show procedure status like 'p%'").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, []interface{}{"test", "p", "PROCEDURE"}, rows[0][:3])
+	require.Equal(t, []interface{}{"test2", "p2", "PROCEDURE"}, rows[1][:3])
+	tk.MustQuery("show procedure status where db = 'test2'").CheckAt([]int{0, 1}, testkit.Rows("test2 p2"))
+	tk.MustQuery("show function status").Check(testkit.Rows())
+
+	// The procedures are dropped with the database.
+	tk.MustExec("drop database test2")
+	tk.MustQuery("show procedure status").CheckAt([]int{0, 1}, testkit.Rows("test p"))
+
+	tk.MustExec("drop procedure p")
+	tk.MustGetErrMsg("drop procedure p", "[schema:1305]PROCEDURE test.p does not exist")
+	tk.MustGetErrMsg("show create procedure p", "[schema:1305]PROCEDURE test.p does not exist")
+	tk.MustExec("drop procedure if exists p")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p does not exist"))
+
+	// The procedure body is checked when it's created.
+	tk.MustGetErrCode("create procedure p(a int, a int) select 1", 1330)
+	tk.MustGetErrCode("create procedure p() begin declare a int; declare a int; end", 1331)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare a int; end", 1337)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare c cursor for select 2; end", 1333)
+	tk.MustGetErrCode("create procedure p() begin open c; end", 1324)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; fetch c into a; end", 1327)
+	tk.MustGetErrCode("create procedure p() begin leave l; end", 1308)
+	tk.MustGetErrCode("create procedure p() l: begin iterate l; end", 1308)
+	tk.MustGetErrCode("create procedure p() l: begin l: loop leave l; end loop; end", 1309)
+	tk.MustGetErrCode("create procedure p() l: begin select 1; end l2", 1310)
+	tk.MustQuery("show procedure status").Check(testkit.Rows())
+}
+
+func TestCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+
+	// Parameters, local variables and control flow.
+	tk.MustExec(`create procedure fill(in n int, inout total int, out last varchar(10))
+	begin
+		declare i int default 0;
+		l: loop
+			set i = i + 1;
+			if i > n then
+				leave l;
+			elseif i % 2 = 0 then
+				iterate l;
+			end if;
+			insert into t values (i, concat('v', i));
+			set total = total + i, last = concat('v', i);
+		end loop l;
+		while i > 0 do
+			set i = i - 1;
+		end while;
+		repeat
+			set i = i + 10;
+		until i >= 30 end repeat;
+		set @i = i;
+	end`)
+	tk.MustExec("set @total = 100")
+	tk.MustExec("call fill(5, @total, @last)")
+	tk.MustQuery("select @total, @last, @i").Check(testkit.Rows("109 v5 30"))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 v1", "3 v3", "5 v5"))
+	tk.MustGetErrMsg("call fill(1)", "[executor:1318]Incorrect number of arguments for PROCEDURE test.fill; expected 3, got 1")
+	tk.MustGetErrCode("call fill(1, 2, @last)", 1414)
+	tk.MustGetErrMsg("call nonexist()", "[schema:1305]PROCEDURE test.nonexist does not exist")
+
+	// CASE statements and the result sets of the SELECT statements.
+	tk.MustExec(`create procedure describe_num(n int)
+	begin
+		case n when 1 then select 'one';
+		when 2 then select 'two', 2;
+		end case;
+		case when n > 1 then select v from t where id > n order by id; else select v from t where id = n; end case;
+	end`)
+	tk.MustQuery("call describe_num(1)").Check(testkit.Rows("one"))
+	rs, err := tk.Exec("call describe_num(2)")
+	require.NoError(t, err)
+	recordSets := rs.(sqlexec.MultiRecordSet).RecordSets()
+	require.Len(t, recordSets, 2)
+	tk.ResultSetToResult(recordSets[0], "case").Check(testkit.Rows("two 2"))
+	tk.ResultSetToResult(recordSets[1], "case").Check(testkit.Rows("v3", "v5"))
+	require.NoError(t, rs.Close())
+	tk.MustGetErrCode("call describe_num(3)", 1339)
+
+	// Cursors and handlers.
+	tk.MustExec(`create procedure concat_all(out s varchar(100))
+	begin
+		declare done int default 0;
+		declare v varchar(10);
+		declare c cursor for select t.v from t order by id;
+		declare continue handler for not found set done = 1;
+		set s = '';
+		open c;
+		read_loop: loop
+			fetch c into v;
+			if done then
+				leave read_loop;
+			end if;
+			set s = concat(s, v);
+		end loop;
+		close c;
+	end`)
+	tk.MustExec("call concat_all(@s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("v1v3v5"))
+	tk.MustExec(`create procedure insert_dup(out result varchar(20))
+	begin
+		declare exit handler for 1062 set result = 'duplicated';
+		set result = 'ok';
+		insert into t values (1, 'x');
+		set result = 'unreachable';
+	end`)
+	tk.MustExec("call insert_dup(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("duplicated"))
+	tk.MustExec(`create procedure cast_warning(out result varchar(20))
+	begin
+		declare exit handler for sqlexception set result = 'error';
+		begin
+			declare v int;
+			declare exit handler for sqlwarning set result = 'warned';
+			set result = 'ok';
+			set v = cast('x' as signed);
+			set result = 'unreachable';
+		end;
+	end`)
+	tk.MustExec("call cast_warning(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("warned"))
+	tk.MustExec(`create procedure ignore_warning(out result varchar(20))
+	begin
+		declare v int;
+		declare exit handler for sqlexception set result = 'error';
+		set result = 'ok';
+		set v = cast('x' as signed);
+	end`)
+	tk.MustExec("call ignore_warning(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("ok"))
+	tk.MustExec(`create procedure cursor_error()
+	begin
+		declare c cursor for select 1;
+		open c;
+		open c;
+	end`)
+	tk.MustGetErrCode("call cursor_error()", 1325)
+
+	// Nested calls can set the local variables of the caller, the recursive call is not allowed.
+	tk.MustExec("create procedure outer_proc(out r varchar(100)) begin declare s varchar(100); call concat_all(s); set r = concat('[', s, ']'); end")
+	tk.MustExec("call outer_proc(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("[v1v3v5]"))
+	tk.MustExec("create procedure recursive_proc() begin call recursive_proc(); end")
+	tk.MustGetErrCode("call recursive_proc()", 1456)
+}
+
+func TestProcedurePrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create procedure p() select 1")
+	tk.MustExec("create user u")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrMsg("call test.p()", "[planner:1370]execute command denied to user 'u'@'%' for routine 'test.p'")
+	tk1.MustGetErrCode("create procedure test.p2() select 1", 1044)
+	tk1.MustGetErrCode("drop procedure test.p", 1370)
+
+	tk.MustExec("grant execute, create routine, alter routine on test.* to u")
+	tk1.MustQuery("call test.p()").Check(testkit.Rows("1"))
+	tk1.MustExec("create procedure test.p2() select 1")
+	tk1.MustExec("drop procedure test.p")
+}
+
+func TestProcedureSQLSecurity(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1)")
+	tk.MustExec("create user d, u")
+	tk.MustExec("grant select on test.t to d")
+	tk.MustExec("grant execute, create routine on test.* to d")
+	tk.MustExec("grant execute on test.* to u")
+
+	tkd := testkit.NewTestKit(t, store)
+	require.NoError(t, tkd.Session().Auth(&auth.UserIdentity{Username: "d", Hostname: "%"}, nil, nil, nil))
+	tkd.MustExec("create procedure test.p() select a, current_user() from test.t")
+	rows := tkd.MustQuery("show procedure status").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "d@%", rows[0][3])
+	require.Equal(t, "DEFINER", rows[0][6])
+
+	// the procedure is executed with the privileges of the definer
+	tku := testkit.NewTestKit(t, store)
+	require.NoError(t, tku.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tku.MustGetErrCode("select * from test.t", 1142)
+	tku.MustQuery("call test.p()").Check(testkit.Rows("1 d@%"))
+	tku.MustGetErrCode("select * from test.t", 1142)
+	tku.MustQuery("select current_user()").Check(testkit.Rows("u@%"))
+
+	// CALL is recorded in the statement summary
+	tk.MustQuery("select exec_count, sample_user from information_schema.statements_summary where query_sample_text = 'call test.p()'").Check(testkit.Rows("1 u"))
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/eventscheduler"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/extension"
@@ -835,14 +836,23 @@ func (s *session) commitTxnWithTemporaryData(ctx context.Context, txn kv.Transac
 			continue
 		}
 
-		if tbl.GetMeta().TempTableType != model.TempTableLocal {
+		if !tbl.GetMeta().HasSessionScopedRows() {
 			continue
 		}
-		if _, ok := localTempTables.TableByID(tblID); !ok {
-			continue
+		if tbl.GetMeta().TempTableType == model.TempTableLocal {
+			if _, ok := localTempTables.TableByID(tblID); !ok {
+				continue
+			}
 		}
 
 		if stage == kv.InvalidStagingHandle {
+			if sessionData == nil {
+				// The session data may be not created yet if only global temporary tables are written.
+				var err error
+				if sessionData, err = temptable.EnsureSessionData(s); err != nil {
+					return err
+				}
+			}
 			stage = sessionData.Staging()
 		}
 
@@ -2155,7 +2165,6 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	if err := s.loadCommonGlobalVariablesIfNeeded(); err != nil {
 		return nil, err
 	}
-
 	sessVars := s.sessionVars
 	sessVars.StartTime = time.Now()
 
@@ -2199,6 +2208,10 @@ func (s *session) ExecuteStmt(ctx context.Context, stmtNode ast.StmtNode) (sqlex
 	// Uncorrelated subqueries will execute once when building plan, so we reset process info before building plan.
 	s.currentPlan = nil // reset current plan
 	s.SetProcessInfo(stmtNode.Text(), time.Now(), cmdByte, 0)
+	if callStmt, ok := stmtNode.(*ast.CallStmt); ok {
+		// The procedure is interpreted in the session, each statement of it is executed by ExecuteStmt.
+		return s.callProcedure(ctx, callStmt)
+	}
 	s.txn.onStmtStart(digest.String())
 	defer sessiontxn.GetTxnManager(s).OnStmtEnd()
 	defer s.txn.onStmtEnd()
@@ -3372,6 +3385,7 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	rebuildAllPartitionValueMapAndSorted(ses[0])
 
 	dom := domain.GetDomain(ses[0])
+	dom.SetInstancePlanCache(plannercore.NewInstancePlanCache(executor.GlobalMemoryUsageTracker))
 
 	// We should make the load bind-info loop before other loops which has internal SQL.
 	// Because the internal SQL may access the global bind-info handler. As the result, the data race occurs here as the
@@ -3510,6 +3524,13 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartEventScheduler(func() (eventscheduler.EventExecutor, error) {
+		se, err := CreateSession(store)
+		if err != nil {
+			return nil, err
+		}
+		return se.(*session), nil
+	})
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -0,0 +1,157 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+var _ executor.TriggerExecutor = &session{}
+
+// triggerRows is the NEW and OLD rows of a trigger.
+type triggerRows struct {
+	cols   []*table.Column
+	oldRow []types.Datum
+	newRow []types.Datum
+}
+
+// lookup returns the row and the offset of the column for the name "new.col" or "old.col",
+// the offset is -1 if the name is not a column of the rows.
+func (r *triggerRows) lookup(name string) ([]types.Datum, *table.Column, int) {
+	rowName, colName, ok := strings.Cut(strings.ToLower(name), ".")
+	if !ok {
+		return nil, nil, -1
+	}
+	var row []types.Datum
+	switch rowName {
+	case "new":
+		row = r.newRow
+	case "old":
+		row = r.oldRow
+	}
+	if row == nil {
+		return nil, nil, -1
+	}
+	for i, col := range r.cols {
+		if col.Name.L == colName {
+			return row, col, i
+		}
+	}
+	return nil, nil, -1
+}
+
+// ExecuteTrigger implements the executor.TriggerExecutor interface. The body of the trigger is
+// interpreted by the procedure interpreter, the NEW and OLD rows are read as the local variables.
+func (s *session) ExecuteTrigger(ctx context.Context, tbl table.Table, trigger *model.TriggerInfo, oldRow, newRow []types.Datum) error {
+	vars := s.sessionVars
+	is := s.GetInfoSchema().(infoschema.InfoSchema)
+	db, ok := is.SchemaByTable(tbl.Meta())
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs("")
+	}
+
+	sqlMode, err := mysql.GetSQLMode(trigger.SQLMode)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	stmt, err := s.parseTrigger(ctx, db.Name, trigger, sqlMode)
+	if err != nil {
+		return err
+	}
+	restoreInvoker, err := s.switchToDefiner(trigger.Definer)
+	if err != nil {
+		return err
+	}
+	defer restoreInvoker()
+
+	pe := &procedureExec{
+		s:       s,
+		results: &procedureResults{},
+		trigger: &triggerRows{cols: tbl.Cols(), oldRow: oldRow, newRow: newRow},
+	}
+	originDB, originSQLMode, originProcVars, originPlanCache := vars.CurrentDB, vars.SQLMode, vars.ProcedureVars, vars.EnableNonPreparedPlanCache
+	vars.CurrentDB, vars.SQLMode, vars.ProcedureVars = db.Name.O, sqlMode, pe
+	// The statements that reference the NEW and OLD rows can't share the cached plans.
+	vars.EnableNonPreparedPlanCache = false
+	defer func() {
+		vars.CurrentDB, vars.SQLMode, vars.ProcedureVars = originDB, originSQLMode, originProcVars
+		vars.EnableNonPreparedPlanCache = originPlanCache
+	}()
+
+	if _, err = pe.execStmt(ctx, stmt.Body); err != nil {
+		if unhandled, ok := err.(*procedureUnhandledError); ok {
+			err = unhandled.err
+		}
+		return err
+	}
+	if len(pe.results.recordSets) > 0 {
+		// The procedures called by the trigger can't return result sets.
+		return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	}
+	return nil
+}
+
+// parseTrigger parses the definition of the trigger with the SQL mode at the time the trigger was
+// created. The result is cached in the statement context, so the trigger is parsed once per statement.
+func (s *session) parseTrigger(ctx context.Context, dbName model.CIStr, trigger *model.TriggerInfo, sqlMode mysql.SQLMode) (*ast.CreateTriggerStmt, error) {
+	sc := s.sessionVars.StmtCtx
+	if stmt, ok := sc.TriggerStmts[trigger]; ok {
+		return stmt, nil
+	}
+	originSQLMode := s.sessionVars.SQLMode
+	s.sessionVars.SQLMode = sqlMode
+	stmts, _, err := s.ParseSQL(ctx, trigger.Definition)
+	s.sessionVars.SQLMode = originSQLMode
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(stmts) != 1 {
+		return nil, errors.Errorf("invalid definition of trigger %s.%s", dbName.O, trigger.Name.O)
+	}
+	stmt, ok := stmts[0].(*ast.CreateTriggerStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of trigger %s.%s", dbName.O, trigger.Name.O)
+	}
+	if sc.TriggerStmts == nil {
+		sc.TriggerStmts = make(map[*model.TriggerInfo]*ast.CreateTriggerStmt)
+	}
+	sc.TriggerStmts[trigger] = stmt
+	return stmt, nil
+}
+
+// assignTriggerRow sets the column of the NEW row, it returns false if the name is not a column of the row.
+func (pe *procedureExec) assignTriggerRow(name string, d types.Datum) (bool, error) {
+	row, col, offset := pe.trigger.lookup(name)
+	if offset < 0 {
+		return false, nil
+	}
+	val, err := table.CastValue(pe.s, d, col.ToInfo(), false, false)
+	if err != nil {
+		return true, err
+	}
+	row[offset] = val
+	return true, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package triggertest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerDDL(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int)")
+	tk.MustExec("create view v as select * from t")
+
+	tk.MustExec("create trigger trg1 before insert on t for each row set new.a = new.a * 10")
+	tk.MustGetErrMsg("create trigger trg1 after delete on t for each row set @a = 1", "[schema:1359]Trigger already exists")
+	tk.MustExec("create trigger if not exists trg1 after delete on t for each row set @a = 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustGetErrCode("create trigger test2.trg2 before insert on test.t for each row set @a = 1", 1435)
+	tk.MustGetErrCode("create trigger trg2 before insert on v for each row set @a = 1", 1361)
+	tk.MustGetErrCode("create trigger trg2 before insert on mysql.user for each row set @a = 1", 1465)
+	tk.MustGetErrCode("create trigger trg2 before insert on t for each row follows no_such set @a = 1", 3022)
+	tk.MustGetErrCode("create trigger trg2 after insert on t for each row follows trg1 set @a = 1", 3022)
+
+	// The body of the trigger is checked when it's created.
+	tk.MustGetErrMsg("create trigger trg2 after insert on t for each row set new.a = 1",
+		"[executor:1362]Updating of NEW row is not allowed in after trigger")
+	tk.MustGetErrMsg("create trigger trg2 before update on t for each row set old.a = 1",
+		"[executor:1362]Updating of OLD row is not allowed in trigger")
+	tk.MustGetErrMsg("create trigger trg2 before delete on t for each row set new.a = 1",
+		"[executor:1363]There is no NEW row in on DELETE trigger")
+	tk.MustGetErrMsg("create trigger trg2 before insert on t for each row select 1",
+		"[executor:1415]Not allowed to return a result set from a trigger")
+
+	tk.MustExec("create trigger trg2 before insert on t for each row set @a = 2")
+	tk.MustExec("create trigger trg3 before insert on t for each row precedes trg1 set @a = 3")
+	tk.MustQuery("select trigger_schema, trigger_name, event_manipulation, event_object_table, action_order, " +
+		"action_statement, action_orientation, action_timing from information_schema.triggers " +
+		"where trigger_schema = 'test' order by action_order").Check(testkit.Rows(
+		"test trg3 INSERT t 1 set @a = 3 ROW BEFORE",
+		"test trg1 INSERT t 2 set new.a = new.a * 10 ROW BEFORE",
+		"test trg2 INSERT t 3 set @a = 2 ROW BEFORE",
+	))
+	tk.MustQuery("show triggers").CheckAt([]int{0, 1, 2, 3, 4}, testkit.Rows(
+		"trg3 INSERT t set @a = 3 BEFORE",
+		"trg1 INSERT t set new.a = new.a * 10 BEFORE",
+		"trg2 INSERT t set @a = 2 BEFORE",
+	))
+	tk.MustQuery("show triggers like 't'").CheckAt([]int{0}, testkit.Rows("trg3", "trg1", "trg2"))
+	tk.MustQuery("show triggers like 'v'").Check(testkit.Rows())
+
+	tk.MustExec("drop trigger trg3")
+	tk.MustGetErrMsg("drop trigger trg3", "[schema:1360]Trigger does not exist")
+	tk.MustExec("drop trigger if exists trg3")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustQuery("select trigger_name from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("trg1", "trg2"))
+
+	// The triggers are dropped with the table.
+	tk.MustExec("drop table t")
+	tk.MustQuery("select trigger_name from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows())
+	tk.MustExec("create table t(id int primary key, a int)")
+	tk.MustExec("create trigger trg1 before insert on t for each row set @a = 1")
+}
+
+func TestInsertTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int, b int as (a + 1))")
+	tk.MustExec("create table log(id int auto_increment primary key, msg varchar(50))")
+	tk.MustExec("create trigger bi before insert on t for each row begin " +
+		"if new.a is null then set new.a = 0; end if; set new.a = new.a * 10; end")
+	tk.MustExec("create trigger ai after insert on t for each row " +
+		"insert into log(msg) values (concat('insert ', new.id, ' ', new.a, ' ', new.b))")
+
+	tk.MustExec("insert into t(id, a) values (1, 1), (2, null)")
+	// The rows changed by the triggers are not counted.
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 10 11", "2 0 1"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("insert 1 10 11", "insert 2 0 1"))
+
+	// The triggers run in the transaction of the statement.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t(id, a) values (3, 3)")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("insert 1 10 11", "insert 2 0 1", "insert 3 30 31"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("insert 1 10 11", "insert 2 0 1"))
+
+	// The changes of the statement are rolled back with the triggers if it fails.
+	tk.MustGetErrCode("insert into t(id, a) values (4, 4), (1, 1)", 1062)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("2"))
+
+	// A trigger can't change the table of the statement that fires it.
+	tk.MustExec("create trigger ai2 after insert on t for each row insert into t(id, a) values (new.id + 100, 0)")
+	tk.MustGetErrMsg("insert into t(id, a) values (5, 5)",
+		"[executor:1442]Can't update table 't' in stored function/trigger because it is already used by statement which invoked this stored function/trigger.")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("2"))
+}
+
+func TestUpdateAndDeleteTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, a int)")
+	tk.MustExec("create table log(id int auto_increment primary key, msg varchar(50))")
+	tk.MustExec("insert into t values (1, 1), (2, 2)")
+	tk.MustExec("create trigger bu before update on t for each row set new.a = old.a + new.a")
+	tk.MustExec("create trigger au after update on t for each row " +
+		"insert into log(msg) values (concat('update ', new.id, ' ', old.a, '->', new.a))")
+	tk.MustExec("create trigger bd before delete on t for each row insert into log(msg) values (concat('before delete ', old.id))")
+	tk.MustExec("create trigger ad after delete on t for each row insert into log(msg) values (concat('after delete ', old.id))")
+
+	tk.MustExec("update t set a = 10")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 11", "2 12"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("update 1 1->11", "update 2 2->12"))
+
+	tk.MustExec("delete from log")
+	tk.MustExec("delete from t where id = 1")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 12"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("before delete 1", "after delete 1"))
+
+	// The rows removed by REPLACE fire the DELETE triggers, and INSERT ... ON DUPLICATE KEY UPDATE
+	// fires the UPDATE triggers.
+	tk.MustExec("delete from log")
+	tk.MustExec("replace into t values (2, 20)")
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 20"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("before delete 2", "after delete 2"))
+	tk.MustExec("delete from log")
+	tk.MustExec("insert into t values (2, 0) on duplicate key update a = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 21"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("update 2 20->21"))
+}
+
+func TestTriggerPrivilege(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create table test.t(id int primary key, a int)")
+	tk.MustExec("create user u")
+	tk.MustExec("grant select, insert on test.t to u")
+
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tk1.MustGetErrCode("create trigger test.trg before insert on test.t for each row set new.a = 1", 1142)
+	tk1.MustQuery("select trigger_name from information_schema.triggers").Check(testkit.Rows())
+
+	tk.MustExec("grant trigger on test.t to u")
+	tk1.MustExec("create trigger test.trg before insert on test.t for each row set new.a = 1")
+	tk1.MustQuery("select trigger_name from information_schema.triggers").Check(testkit.Rows("trg"))
+	tk1.MustExec("insert into test.t(id) values (1)")
+	tk1.MustQuery("select * from test.t").Check(testkit.Rows("1 1"))
+	tk1.MustExec("drop trigger test.trg")
+}
+
+func TestTriggerSQLSecurity(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create table test.t(id int primary key, a int)")
+	tk.MustExec("create table test.audit(id int, u varchar(32))")
+	tk.MustExec("create user d, u")
+	tk.MustExec("grant trigger, insert on test.t to d")
+	tk.MustExec("grant insert on test.audit to d")
+	tk.MustExec("grant insert on test.t to u")
+
+	tkd := testkit.NewTestKit(t, store)
+	require.NoError(t, tkd.Session().Auth(&auth.UserIdentity{Username: "d", Hostname: "%"}, nil, nil, nil))
+	tkd.MustExec("create trigger test.trg after insert on test.t for each row insert into test.audit values (new.id, current_user())")
+
+	// the trigger is executed with the privileges of the definer
+	tku := testkit.NewTestKit(t, store)
+	require.NoError(t, tku.Session().Auth(&auth.UserIdentity{Username: "u", Hostname: "%"}, nil, nil, nil))
+	tku.MustGetErrCode("insert into test.audit values (0, 'u')", 1142)
+	tku.MustExec("insert into test.t values (1, 1), (2, 2), (3, 3)")
+	tku.MustQuery("select current_user()").Check(testkit.Rows("u@%"))
+	tk.MustQuery("select * from test.audit order by id").Check(testkit.Rows("1 d@%", "2 d@%", "3 d@%"))
+}
@@ -668,7 +668,7 @@ func TestFormatAndAddTiDBSpecificComment(t *testing.T) {
 		},
 		{
 			"alter table t force, auto_increment = 12;",
-			"ALTER TABLE `t` FORCE /* AlterTableForce is not supported */ , AUTO_INCREMENT = 12;",
+			"ALTER TABLE `t` FORCE, AUTO_INCREMENT = 12;",
 		},
 		{
 			// https://github.com/pingcap/tiflow/issues/3755
@@ -63,6 +63,17 @@ type PlanCache interface {
 	Close()
 }
 
+// InstancePlanCache is an interface for the instance-level plan cache, which is shared by all sessions.
+type InstancePlanCache interface {
+	// Get returns a copy of the cached value which is bound to the given session.
+	Get(sctx Context, key kvcache.Key, opts *utilpc.PlanCacheMatchOpts) (value kvcache.Value, ok bool)
+	// Put puts a copy of the value into the cache, and it returns false if the value can't be shared.
+	Put(sctx Context, key kvcache.Key, value kvcache.Value, opts *utilpc.PlanCacheMatchOpts) bool
+	DeleteAll()
+	Size() int
+	Stats() utilpc.InstancePlanCacheStats
+}
+
 // Context is an interface for transaction and executive args environment.
 type Context interface {
 	SessionStatesHandler
@@ -386,6 +386,12 @@ type StatementContext struct {
 	// InHandleForeignKeyTrigger indicates currently are handling foreign key trigger.
 	InHandleForeignKeyTrigger bool
 
+	// TriggerTableIDs is the IDs of the tables whose triggers are running, the innermost one is the last.
+	TriggerTableIDs []int64
+	// TriggerStmts caches the parsed definitions of the triggers fired by the statement, so a trigger
+	// is parsed once rather than once per row. The keys are the trigger metadata of the tables.
+	TriggerStmts map[*model.TriggerInfo]*ast.CreateTriggerStmt
+
 	// ForeignKeyTriggerCtx is the contain information for foreign key cascade execution.
 	ForeignKeyTriggerCtx struct {
 		// The SavepointName is use to do rollback when handle foreign key cascade failed.
@@ -674,8 +680,9 @@ type TableEntry struct {
 
 // AddAffectedRows adds affected rows.
 func (sc *StatementContext) AddAffectedRows(rows uint64) {
-	if sc.InHandleForeignKeyTrigger {
-		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger.
+	if sc.InHandleForeignKeyTrigger || len(sc.TriggerTableIDs) > 0 {
+		// For compatibility with MySQL, not add the affected row cause by the foreign key trigger
+		// or the statements in the triggers.
 		return
 	}
 	sc.mu.Lock()
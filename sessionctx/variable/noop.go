@@ -280,7 +280,6 @@ var noopSysVars = []*SysVar{
 	{Scope: ScopeGlobal | ScopeSession, Name: "ndb_force_send", Value: ""},
 	{Scope: ScopeNone, Name: "skip_show_database", Value: "0"},
 	{Scope: ScopeGlobal, Name: "log_timestamps", Value: ""},
-	{Scope: ScopeGlobal, Name: "event_scheduler", Value: Off},
 	{Scope: ScopeGlobal | ScopeSession, Name: "ndb_deferred_constraints", Value: ""},
 	{Scope: ScopeGlobal, Name: "log_syslog_include_pid", Value: ""},
 	{Scope: ScopeNone, Name: "innodb_ft_cache_size", Value: "8000000"},
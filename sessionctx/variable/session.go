@@ -667,6 +667,12 @@ type HookContext interface {
 	GetStore() kv.Storage
 }
 
+// ProcedureVarReader reads the local variables and parameters of the running stored procedure.
+type ProcedureVarReader interface {
+	// GetProcedureVar returns the value and the type of the local variable, ok is false if it's not declared.
+	GetProcedureVar(name string) (val types.Datum, tp *types.FieldType, ok bool)
+}
+
 // SessionVars is to handle user-defined or global variables in the current session.
 type SessionVars struct {
 	Concurrency
@@ -772,6 +778,11 @@ type SessionVars struct {
 	// InRestrictedSQL indicates if the session is handling restricted SQL execution.
 	InRestrictedSQL bool
 
+	// ProcedureVars is not nil when the session is executing the statements of a stored procedure
+	// or a trigger, the unqualified column names are resolved as its local variables first, and the
+	// NEW.col and OLD.col of a trigger are resolved as the columns of the rows.
+	ProcedureVars ProcedureVarReader
+
 	// SnapshotTS is used for reading history data. For simplicity, SnapshotTS only supports distsql request.
 	SnapshotTS uint64
 
@@ -1021,6 +1032,9 @@ type SessionVars struct {
 	// DDLReorgPriority is the operation priority of adding indices.
 	DDLReorgPriority int
 
+	// DDLCharsetConversionDryRun indicates whether to only check the data of the DDL which converts the charset of columns.
+	DDLCharsetConversionDryRun bool
+
 	// EnableAutoIncrementInGenerated is used to control whether to allow auto incremented columns in generated columns.
 	EnableAutoIncrementInGenerated bool
 
@@ -1270,6 +1284,10 @@ type SessionVars struct {
 	// TemporaryTableData stores committed kv values for temporary table for current session.
 	TemporaryTableData TemporaryTableData
 
+	// sessionScopedTempTables keeps the global temporary tables created with ON COMMIT PRESERVE ROWS
+	// across transactions, so their auto IDs don't restart in each transaction.
+	sessionScopedTempTables map[int64]tableutil.TempTable
+
 	// MPPStoreFailTTL indicates the duration that protect TiDB from sending task to a new recovered TiFlash.
 	MPPStoreFailTTL string
 
@@ -1475,6 +1493,9 @@ type SessionVars struct {
 	// Enable late materialization: push down some selection condition to tablescan.
 	EnableLateMaterialization bool
 
+	// EnableMaterializedViewRewrite indicates whether to rewrite the queries to read the matching materialized views.
+	EnableMaterializedViewRewrite bool
+
 	// EnableRowLevelChecksum indicates whether row level checksum is enabled.
 	EnableRowLevelChecksum bool
 
@@ -2569,7 +2590,11 @@ func (s *SessionVars) GetTemporaryTable(tblInfo *model.TableInfo) tableutil.Temp
 		tempTables := s.TxnCtx.TemporaryTables
 		tempTable, ok := tempTables[tblInfo.ID]
 		if !ok {
-			tempTable = tableutil.TempTableFromMeta(tblInfo)
+			if tblInfo.TempTableType == model.TempTableGlobal && tblInfo.OnCommitPreserveRows {
+				tempTable = s.getSessionScopedTempTable(tblInfo)
+			} else {
+				tempTable = tableutil.TempTableFromMeta(tblInfo)
+			}
 			tempTables[tblInfo.ID] = tempTable
 		}
 		return tempTable
@@ -2578,6 +2603,22 @@ func (s *SessionVars) GetTemporaryTable(tblInfo *model.TableInfo) tableutil.Temp
 	return nil
 }
 
+// getSessionScopedTempTable gets the TempTable of a global temporary table created with ON COMMIT PRESERVE ROWS.
+// The size and the modification are still tracked in each transaction, the committed size is in TemporaryTableData.
+func (s *SessionVars) getSessionScopedTempTable(tblInfo *model.TableInfo) tableutil.TempTable {
+	if s.sessionScopedTempTables == nil {
+		s.sessionScopedTempTables = make(map[int64]tableutil.TempTable)
+	}
+	tempTable, ok := s.sessionScopedTempTables[tblInfo.ID]
+	if !ok {
+		tempTable = tableutil.TempTableFromMeta(tblInfo)
+		s.sessionScopedTempTables[tblInfo.ID] = tempTable
+	}
+	tempTable.SetSize(0)
+	tempTable.SetModified(false)
+	return tempTable
+}
+
 // EncodeSessionStates saves session states into SessionStates.
 func (s *SessionVars) EncodeSessionStates(_ context.Context, sessionStates *sessionstates.SessionStates) (err error) {
 	// Encode user-defined variables.
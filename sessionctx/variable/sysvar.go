@@ -277,6 +277,10 @@ var defaultSysVars = []*SysVar{
 		s.setDDLReorgPriority(val)
 		return nil
 	}},
+	{Scope: ScopeSession, Name: TiDBDDLCharsetConversionDryRun, Value: BoolToOnOff(DefTiDBDDLCharsetConversionDryRun), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.DDLCharsetConversionDryRun = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeSession, Name: TiDBSlowQueryFile, Value: "", skipInit: true, SetSession: func(s *SessionVars, val string) error {
 		s.SlowQueryFile = val
 		return nil
@@ -895,6 +899,12 @@ var defaultSysVars = []*SysVar{
 	}},
 	{Scope: ScopeGlobal, Name: SkipNameResolve, Value: Off, Type: TypeBool},
 	{Scope: ScopeGlobal, Name: DefaultAuthPlugin, Value: mysql.AuthNativePassword, Type: TypeEnum, PossibleValues: []string{mysql.AuthNativePassword, mysql.AuthCachingSha2Password, mysql.AuthTiDBSM3Password, mysql.AuthLDAPSASL, mysql.AuthLDAPSimple}},
+	{Scope: ScopeGlobal, Name: EventScheduler, Value: BoolToOnOff(DefEventScheduler), Type: TypeBool, SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+		EnableEventScheduler.Store(TiDBOptOn(s))
+		return nil
+	}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+		return BoolToOnOff(EnableEventScheduler.Load()), nil
+	}},
 	{Scope: ScopeGlobal, Name: TiDBPersistAnalyzeOptions, Value: BoolToOnOff(DefTiDBPersistAnalyzeOptions), Type: TypeBool,
 		GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 			return BoolToOnOff(PersistAnalyzeOptions.Load()), nil
@@ -2776,6 +2786,31 @@ var defaultSysVars = []*SysVar{
 	}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 		return BoolToOnOff(EnableCheckConstraint.Load()), nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnableMaterializedViewRewrite, Value: BoolToOnOff(DefTiDBEnableMaterializedViewRewrite), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnableMaterializedViewRewrite = TiDBOptOn(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBEnableInstancePlanCache, Value: BoolToOnOff(DefTiDBEnableInstancePlanCache), Type: TypeBool, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		EnableInstancePlanCache.Store(TiDBOptOn(val))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return BoolToOnOff(EnableInstancePlanCache.Load()), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBInstancePlanCacheMaxMemSize, Value: strconv.FormatInt(DefTiDBInstancePlanCacheMaxMemSize, 10), Type: TypeUnsigned, MinValue: 1, MaxValue: math.MaxInt64, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		InstancePlanCacheMaxMemSize.Store(TidbOptInt64(val, DefTiDBInstancePlanCacheMaxMemSize))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatInt(InstancePlanCacheMaxMemSize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBInstancePlanCacheReservedPercentage, Value: strconv.FormatFloat(DefTiDBInstancePlanCacheReservedPercentage, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: 1, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		f, err := strconv.ParseFloat(val, 64)
+		if err == nil {
+			InstancePlanCacheReservedPercentage.Store(f)
+		}
+		return err
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatFloat(InstancePlanCacheReservedPercentage.Load(), 'f', -1, 64), nil
+	}},
 }
 
 func setTiFlashComputeDispatchPolicy(s *SessionVars, val string) error {
@@ -3081,6 +3116,8 @@ const (
 	ReadOnly = "read_only"
 	// DefaultAuthPlugin is the name of 'default_authentication_plugin' system variable.
 	DefaultAuthPlugin = "default_authentication_plugin"
+	// EventScheduler is the name of 'event_scheduler' system variable.
+	EventScheduler = "event_scheduler"
 	// LastInsertID is the name of 'last_insert_id' system variable.
 	LastInsertID = "last_insert_id"
 	// Identity is the name of 'identity' system variable.
@@ -502,6 +502,10 @@ const (
 	// It can be: PRIORITY_LOW, PRIORITY_NORMAL, PRIORITY_HIGH
 	TiDBDDLReorgPriority = "tidb_ddl_reorg_priority"
 
+	// TiDBDDLCharsetConversionDryRun is used to check the data of the DDL which converts the charset of columns,
+	// instead of running it. The rows whose values can't be converted to the new charset are reported as warnings.
+	TiDBDDLCharsetConversionDryRun = "tidb_ddl_charset_conversion_dry_run"
+
 	// TiDBEnableAutoIncrementInGenerated disables the mysql compatibility check on using auto-incremented columns in
 	// expression indexes and generated columns described here https://dev.mysql.com/doc/refman/5.7/en/create-table-generated-columns.html for details.
 	TiDBEnableAutoIncrementInGenerated = "tidb_enable_auto_increment_in_generated"
@@ -904,6 +908,10 @@ const (
 
 	// TiDBEnableCheckConstraint indicates whether to enable check constraint feature.
 	TiDBEnableCheckConstraint = "tidb_enable_check_constraint"
+
+	// TiDBEnableMaterializedViewRewrite indicates whether to rewrite the queries to read the matching materialized
+	// views, which return the data as of the last refresh of the views.
+	TiDBEnableMaterializedViewRewrite = "tidb_enable_materialized_view_rewrite"
 )
 
 // TiDB vars that have only global scope
@@ -1079,6 +1087,13 @@ const (
 	TiDBRuntimeFilterTypeName = "tidb_runtime_filter_type"
 	// TiDBRuntimeFilterModeName the mode of runtime filter, such as "OFF", "LOCAL"
 	TiDBRuntimeFilterModeName = "tidb_runtime_filter_mode"
+	// TiDBEnableInstancePlanCache indicates whether to share the cached plans among all sessions of the instance.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize indicates the memory limit of the instance-level plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_size"
+	// TiDBInstancePlanCacheReservedPercentage indicates the percentage of the memory limit of the instance-level
+	// plan cache to be released when the cache is full.
+	TiDBInstancePlanCacheReservedPercentage = "tidb_instance_plan_cache_reserved_percentage"
 )
 
 // TiDB intentional limits
@@ -1175,6 +1190,7 @@ const (
 	DefTiDBDDLReorgBatchSize                       = 256
 	DefTiDBDDLFlashbackConcurrency                 = 64
 	DefTiDBDDLErrorCountLimit                      = 512
+	DefTiDBDDLCharsetConversionDryRun              = false
 	DefTiDBMaxDeltaSchemaCount                     = 1024
 	DefTiDBPlacementMode                           = PlacementModeStrict
 	DefTiDBEnableAutoIncrementInGenerated          = false
@@ -1335,6 +1351,7 @@ const (
 	DefTiDBEnablePlanReplayerCapture                  = true
 	DefTiDBIndexMergeIntersectionConcurrency          = ConcurrencyUnset
 	DefTiDBTTLJobEnable                               = true
+	DefEventScheduler                                 = false
 	DefTiDBTTLScanBatchSize                           = 500
 	DefTiDBTTLScanBatchMaxSize                        = 10240
 	DefTiDBTTLScanBatchMinSize                        = 1
@@ -1382,6 +1399,14 @@ const (
 	DefRuntimeFilterMode                              = "OFF"
 	DefTiDBLockUnchangedKeys                          = true
 	DefTiDBEnableCheckConstraint                      = false
+	DefTiDBEnableMaterializedViewRewrite              = false
+)
+
+// Default values of the instance-level plan cache variables.
+const (
+	DefTiDBEnableInstancePlanCache             = false
+	DefTiDBInstancePlanCacheMaxMemSize         = 100 << 20 // 100MB.
+	DefTiDBInstancePlanCacheReservedPercentage = 0.1
 )
 
 // Process global variables.
@@ -1448,6 +1473,7 @@ var (
 	PasswordValidtaionNumberCount      = atomic.NewInt32(1)
 	PasswordValidationSpecialCharCount = atomic.NewInt32(1)
 	EnableTTLJob                       = atomic.NewBool(DefTiDBTTLJobEnable)
+	EnableEventScheduler               = atomic.NewBool(DefEventScheduler)
 	TTLScanBatchSize                   = atomic.NewInt64(DefTiDBTTLScanBatchSize)
 	TTLDeleteBatchSize                 = atomic.NewInt64(DefTiDBTTLDeleteBatchSize)
 	TTLDeleteRateLimit                 = atomic.NewInt64(DefTiDBTTLDeleteRateLimit)
@@ -1476,6 +1502,10 @@ var (
 	// It will be initialized to the right value after the first call of `rebuildSysVarCache`
 	EnableResourceControl = atomic.NewBool(false)
 	EnableCheckConstraint = atomic.NewBool(DefTiDBEnableCheckConstraint)
+
+	EnableInstancePlanCache             = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	InstancePlanCacheMaxMemSize         = atomic.NewInt64(DefTiDBInstancePlanCacheMaxMemSize)
+	InstancePlanCacheReservedPercentage = atomic.NewFloat64(DefTiDBInstancePlanCacheReservedPercentage)
 )
 
 var (
@@ -33,6 +33,11 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 		// Nothing to do, no change with scale ratio
 		return sampleNDV, scaleRatio
 	}
+	return estimateNDVByGEE(sampleSize, sampleNDV, onlyOnceItems, rowCount), scaleRatio
+}
+
+// estimateNDVByGEE estimates the ndv of the data from a sample of it.
+func estimateNDVByGEE(sampleSize, sampleNDV, onlyOnceItems, rowCount uint64) uint64 {
 	// Charikar, Moses, et al. "Towards estimation error guarantees for distinct values."
 	// Proceedings of the nineteenth ACM SIGMOD-SIGACT-SIGART symposium on Principles of database systems. ACM, 2000.
 	// This is GEE in that paper.
@@ -44,8 +49,8 @@ func calculateEstimateNDV(h *topNHelper, rowCount uint64) (ndv uint64, scaleRati
 	rowCountN := float64(rowCount)
 	d := float64(sampleNDV)
 
-	ndv = uint64(math.Sqrt(rowCountN/n)*f1 + d - f1 + 0.5)
+	ndv := uint64(math.Sqrt(rowCountN/n)*f1 + d - f1 + 0.5)
 	ndv = mathutil.Max(ndv, sampleNDV)
 	ndv = mathutil.Min(ndv, rowCount)
-	return ndv, scaleRatio
+	return ndv
 }
@@ -0,0 +1,151 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package statistics
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+)
+
+// EncodeDependencyDegrees encodes the degrees of the dependency extended stats into the string value stored in
+// mysql.stats_extended.
+func EncodeDependencyDegrees(degrees [2]float64) string {
+	return fmt.Sprintf("[%f,%f]", degrees[0], degrees[1])
+}
+
+// DependencyDegrees returns the degrees of the functional dependencies `ColIDs[0] => ColIDs[1]` and
+// `ColIDs[1] => ColIDs[0]`. The degree is the fraction of rows whose determinant value always comes with
+// the same dependent value, 1 means the dependent column is fully determined by the determinant column.
+func (item *ExtendedStatsItem) DependencyDegrees() (degrees [2]float64, err error) {
+	var vals []float64
+	if err = json.Unmarshal([]byte(item.StringVals), &vals); err != nil {
+		return degrees, errors.Trace(err)
+	}
+	if len(vals) != len(degrees) {
+		return degrees, errors.Errorf("invalid dependency extended stats %s", item.StringVals)
+	}
+	copy(degrees[:], vals)
+	return degrees, nil
+}
+
+// sampleRowsOfColumns groups the samples of the columns by their ordinals, i.e, the sample rows they come from.
+// The value of a column is NULL in a row if the row has no sample of it, and the rows without any sample
+// of the columns are ignored.
+func sampleRowsOfColumns(collectors []*SampleCollector) map[int][]types.Datum {
+	rows := make(map[int][]types.Datum)
+	for i, collector := range collectors {
+		for _, item := range collector.Samples {
+			row, ok := rows[item.Ordinal]
+			if !ok {
+				row = make([]types.Datum, len(collectors))
+				rows[item.Ordinal] = row
+			}
+			row[i] = item.Value
+		}
+	}
+	return rows
+}
+
+// BuildCardinalityExtendedStats estimates the number of distinct value combinations of the columns from the samples.
+func BuildCardinalityExtendedStats(sc *stmtctx.StatementContext, collectors []*SampleCollector) (float64, error) {
+	rows := sampleRowsOfColumns(collectors)
+	if len(rows) == 0 {
+		return 0, nil
+	}
+	groupCounts := make(map[string]uint64, len(rows))
+	for _, row := range rows {
+		key, err := codec.EncodeKey(sc, nil, row...)
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		groupCounts[string(key)]++
+	}
+	var onlyOnceItems uint64
+	for _, cnt := range groupCounts {
+		if cnt == 1 {
+			onlyOnceItems++
+		}
+	}
+	sampleSize := uint64(len(rows))
+	rowCount := sampleSize
+	for _, collector := range collectors {
+		if uint64(collector.Count) > rowCount {
+			rowCount = uint64(collector.Count)
+		}
+	}
+	if onlyOnceItems == sampleSize {
+		// Assume the combinations are unique, the same as calculateEstimateNDV.
+		return float64(rowCount), nil
+	}
+	return float64(estimateNDVByGEE(sampleSize, uint64(len(groupCounts)), onlyOnceItems, rowCount)), nil
+}
+
+// BuildDependencyExtendedStats computes the degrees of the functional dependencies between two columns from the samples.
+func BuildDependencyExtendedStats(sc *stmtctx.StatementContext, x, y *SampleCollector) (degrees [2]float64, err error) {
+	rows := sampleRowsOfColumns([]*SampleCollector{x, y})
+	if len(rows) == 0 {
+		return degrees, nil
+	}
+	keys := make([][2]string, 0, len(rows))
+	for _, row := range rows {
+		var key [2]string
+		for i := range key {
+			b, err := codec.EncodeKey(sc, nil, row[i])
+			if err != nil {
+				return degrees, errors.Trace(err)
+			}
+			key[i] = string(b)
+		}
+		keys = append(keys, key)
+	}
+	degrees[0] = dependencyDegree(keys, 0)
+	degrees[1] = dependencyDegree(keys, 1)
+	return degrees, nil
+}
+
+// dependencyDegree returns the fraction of the rows whose value of keys[determinant] always comes with the same
+// value of the other column in the sample.
+func dependencyDegree(keys [][2]string, determinant int) float64 {
+	type valueGroup struct {
+		dependent  string
+		rows       int
+		consistent bool
+	}
+	groups := make(map[string]*valueGroup, len(keys))
+	for _, key := range keys {
+		dependent := key[1-determinant]
+		group, ok := groups[key[determinant]]
+		if !ok {
+			groups[key[determinant]] = &valueGroup{dependent: dependent, rows: 1, consistent: true}
+			continue
+		}
+		group.rows++
+		if group.dependent != dependent {
+			group.consistent = false
+		}
+	}
+	supported := 0
+	for _, group := range groups {
+		if group.consistent {
+			supported += group.rows
+		}
+	}
+	return float64(supported) / float64(len(keys))
+}
@@ -19,6 +19,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"sync"
@@ -647,6 +648,7 @@ type GlobalStats struct {
 	Cms         []*statistics.CMSketch
 	TopN        []*statistics.TopN
 	Fms         []*statistics.FMSketch
+	ExtStats    *statistics.ExtendedStatsColl
 	Num         int
 	Count       int64
 	ModifyCount int64
@@ -830,9 +832,114 @@ func (h *Handle) mergePartitionStats2GlobalStats(sc sessionctx.Context,
 		}
 		globalStats.Hg[i].NDV = globalStatsNDV
 	}
+
+	if isIndex == 0 {
+		globalStats.ExtStats = mergePartitionExtendedStats(globalStats, histIDs, partitionIDs, allPartitionStats)
+	}
 	return
 }
 
+// mergePartitionExtendedStats merges the partition-level extended stats to the global-level ones.
+// The correlations and the dependency degrees are averaged by the row counts of the partitions. The cardinality
+// is the sum of those of the partitions, which is exact when the partitions don't share any value combination,
+// and it's bounded by the global-level row count and NDVs of the columns.
+func mergePartitionExtendedStats(globalStats *GlobalStats, histIDs []int64, partitionIDs []int64,
+	allPartitionStats map[int64]*statistics.Table) *statistics.ExtendedStatsColl {
+	type mergingItem struct {
+		item      *statistics.ExtendedStatsItem
+		partNum   int
+		weight    float64
+		scalarSum float64
+		scalarMax float64
+		degrees   [2]float64
+	}
+	items := make(map[string]*mergingItem)
+	nonEmptyPartNum := 0
+	for _, partitionID := range partitionIDs {
+		partitionStats := allPartitionStats[partitionID]
+		if partitionStats == nil || partitionStats.RealtimeCount == 0 {
+			continue
+		}
+		nonEmptyPartNum++
+		if partitionStats.ExtendedStats == nil {
+			continue
+		}
+		weight := float64(partitionStats.RealtimeCount)
+		for name, item := range partitionStats.ExtendedStats.Stats {
+			m, ok := items[name]
+			if !ok {
+				m = &mergingItem{item: &statistics.ExtendedStatsItem{ColIDs: item.ColIDs, Tp: item.Tp}}
+				items[name] = m
+			}
+			if m.item.Tp != item.Tp {
+				continue
+			}
+			switch item.Tp {
+			case ast.StatsTypeCorrelation:
+				m.scalarSum += item.ScalarVals * weight
+			case ast.StatsTypeCardinality:
+				m.scalarSum += item.ScalarVals
+				m.scalarMax = math.Max(m.scalarMax, item.ScalarVals)
+			case ast.StatsTypeDependency:
+				degrees, err := item.DependencyDegrees()
+				if err != nil {
+					continue
+				}
+				for i := range degrees {
+					m.degrees[i] += degrees[i] * weight
+				}
+			}
+			m.partNum++
+			m.weight += weight
+		}
+	}
+	if nonEmptyPartNum == 0 {
+		return nil
+	}
+	colNDVs := make(map[int64]int64, len(histIDs))
+	for i, histID := range histIDs {
+		if globalStats.Hg[i] != nil {
+			colNDVs[histID] = globalStats.Hg[i].NDV
+		}
+	}
+	extStats := statistics.NewExtendedStatsColl()
+	for name, m := range items {
+		// The stats missing in some partitions can't be merged, e.g, the partitions were analyzed before the
+		// extended stats were created.
+		if m.partNum != nonEmptyPartNum {
+			continue
+		}
+		item := m.item
+		switch item.Tp {
+		case ast.StatsTypeCorrelation:
+			item.ScalarVals = m.scalarSum / m.weight
+		case ast.StatsTypeDependency:
+			item.StringVals = statistics.EncodeDependencyDegrees([2]float64{m.degrees[0] / m.weight, m.degrees[1] / m.weight})
+		case ast.StatsTypeCardinality:
+			upper, lower := math.Min(m.scalarSum, float64(globalStats.Count)), m.scalarMax
+			product, allNDVsKnown := 1.0, true
+			for _, colID := range item.ColIDs {
+				ndv, ok := colNDVs[colID]
+				if !ok {
+					allNDVsKnown = false
+					continue
+				}
+				product *= float64(ndv)
+				lower = math.Max(lower, float64(ndv))
+			}
+			if allNDVsKnown {
+				upper = math.Min(upper, product)
+			}
+			item.ScalarVals = math.Max(lower, upper)
+		}
+		extStats.Stats[name] = item
+	}
+	if len(extStats.Stats) == 0 {
+		return nil
+	}
+	return extStats
+}
+
 func (h *Handle) mergeGlobalStatsTopN(sc sessionctx.Context, wrapper *statistics.StatsWrapper,
 	timeZone *time.Location, version int, n uint32, isIndex bool) (*statistics.TopN,
 	[]statistics.TopNMeta, []*statistics.Histogram, error) {
@@ -1849,6 +1956,7 @@ func (h *Handle) ReloadExtendedStatistics() error {
 }
 
 // BuildExtendedStats build extended stats for column groups if needed based on the column samples.
+// The tableID is the ID of the table which the extended stats are created on, i.e, the partitioned table for partitions.
 func (h *Handle) BuildExtendedStats(tableID int64, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) (*statistics.ExtendedStatsColl, error) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnStats)
 	const sql = "SELECT name, type, column_ids FROM mysql.stats_extended WHERE table_id = %? and status in (%?, %?)"
@@ -1882,14 +1990,67 @@ func (h *Handle) BuildExtendedStats(tableID int64, cols []*model.ColumnInfo, col
 
 func (h *Handle) fillExtendedStatsItemVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
 	switch item.Tp {
-	case ast.StatsTypeCardinality, ast.StatsTypeDependency:
-		return nil
+	case ast.StatsTypeCardinality:
+		return h.fillExtStatsCardVals(item, cols, collectors)
+	case ast.StatsTypeDependency:
+		return h.fillExtStatsDepVals(item, cols, collectors)
 	case ast.StatsTypeCorrelation:
 		return h.fillExtStatsCorrVals(item, cols, collectors)
 	}
 	return nil
 }
 
+// extStatsCollectors returns the sample collectors of the columns in the extended stats item, or nil if any of them is missing.
+func extStatsCollectors(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) []*statistics.SampleCollector {
+	itemCollectors := make([]*statistics.SampleCollector, 0, len(item.ColIDs))
+	for _, id := range item.ColIDs {
+		for i, col := range cols {
+			if col.ID == id {
+				if i < len(collectors) && collectors[i] != nil {
+					itemCollectors = append(itemCollectors, collectors[i])
+				}
+				break
+			}
+		}
+	}
+	if len(itemCollectors) != len(item.ColIDs) {
+		return nil
+	}
+	return itemCollectors
+}
+
+func (h *Handle) fillExtStatsCardVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
+	itemCollectors := extStatsCollectors(item, cols, collectors)
+	if len(itemCollectors) < 2 {
+		return nil
+	}
+	h.mu.Lock()
+	sc := h.mu.ctx.GetSessionVars().StmtCtx
+	h.mu.Unlock()
+	var err error
+	item.ScalarVals, err = statistics.BuildCardinalityExtendedStats(sc, itemCollectors)
+	if err != nil {
+		return nil
+	}
+	return item
+}
+
+func (h *Handle) fillExtStatsDepVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
+	itemCollectors := extStatsCollectors(item, cols, collectors)
+	if len(itemCollectors) != 2 {
+		return nil
+	}
+	h.mu.Lock()
+	sc := h.mu.ctx.GetSessionVars().StmtCtx
+	h.mu.Unlock()
+	degrees, err := statistics.BuildDependencyExtendedStats(sc, itemCollectors[0], itemCollectors[1])
+	if err != nil {
+		return nil
+	}
+	item.StringVals = statistics.EncodeDependencyDegrees(degrees)
+	return item
+}
+
 func (h *Handle) fillExtStatsCorrVals(item *statistics.ExtendedStatsItem, cols []*model.ColumnInfo, collectors []*statistics.SampleCollector) *statistics.ExtendedStatsItem {
 	colOffsets := make([]int, 0, 2)
 	for _, id := range item.ColIDs {
@@ -2222,17 +2222,41 @@ func TestFeedbackWithGlobalStats(t *testing.T) {
 }
 
 func TestExtendedStatsPartitionTable(t *testing.T) {
-	store := testkit.CreateMockStore(t)
+	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
 	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("set @@tidb_analyze_version = 2")
+	tk.MustExec("set @@tidb_partition_prune_mode = 'dynamic'")
 	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t1, t2")
-	tk.MustExec("create table t1(a int, b int, c int) partition by range(a) (partition p0 values less than (5), partition p1 values less than (10))")
-	tk.MustExec("create table t2(a int, b int, c int) partition by hash(a) partitions 4")
-	err := tk.ExecToErr("alter table t1 add stats_extended s1 correlation(b,c)")
-	require.Equal(t, "Extended statistics on partitioned tables are not supported now", err.Error())
-	err = tk.ExecToErr("alter table t2 add stats_extended s1 correlation(b,c)")
-	require.Equal(t, "Extended statistics on partitioned tables are not supported now", err.Error())
+	tk.MustExec("create table t(a int, b int, c int) partition by range(a) (partition p0 values less than (5), partition p1 values less than (10))")
+	tk.MustExec("insert into t values (1,1,10),(1,1,10),(2,2,20),(2,2,20),(6,1,10),(6,1,10),(7,2,20),(7,2,20)")
+	tk.MustExec("alter table t add stats_extended s1 cardinality(a,b)")
+	tk.MustExec("alter table t add stats_extended s2 dependency(b,c)")
+	tk.MustGetErrMsg("alter table t add stats_extended s3 cardinality(a)", "Only support Cardinality statistics type on at least 2 columns")
+	tk.MustExec("analyze table t")
+
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblInfo := tbl.Meta()
+	for _, def := range tblInfo.Partition.Definitions {
+		tk.MustQuery(fmt.Sprintf("select name, stats from mysql.stats_extended where table_id = %d and status = 1", def.ID)).Sort().Check(testkit.Rows(
+			"s1 2.000000",
+			"s2 [1.000000,1.000000]",
+		))
+	}
+	// The partitions don't share any value of a, so the merged cardinality is the sum of those of the partitions.
+	tk.MustQuery(fmt.Sprintf("select name, stats from mysql.stats_extended where table_id = %d and status = 1", tblInfo.ID)).Sort().Check(testkit.Rows(
+		"s1 4.000000",
+		"s2 [1.000000,1.000000]",
+	))
+	require.NoError(t, dom.StatsHandle().Update(dom.InfoSchema()))
+	tk.MustQuery("show stats_extended where table_name = 't' and stats_name = 's1'").CheckAt([]int{3, 4, 5}, testkit.Rows(
+		"[a,b] cardinality 4.000000",
+	))
+
+	// Dropping the extended stats drops the partition-level ones too.
+	tk.MustExec("alter table t drop stats_extended s1")
+	tk.MustQuery("select count(*) from mysql.stats_extended where name = 's1' and status != 2").Check(testkit.Rows("0"))
 }
 
 func TestHideIndexUsageSyncLease(t *testing.T) {
@@ -651,6 +651,35 @@ func TestCrossValidationSelectivity(t *testing.T) {
 		"  └─TableRangeScan 2.00 cop[tikv] table:t range:(1 0,1 1000), keep order:false"))
 }
 
+func TestExtendedStatsSelectivity(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	h := dom.StatsHandle()
+	tk.MustExec("use test")
+	tk.MustExec("set @@tidb_analyze_version = 2")
+	tk.MustExec("set session tidb_enable_extended_stats = on")
+	tk.MustExec("set @@tidb_enable_non_prepared_plan_cache = 0")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1,1,1), (1,1,1), (2,2,2), (2,2,2), (3,3,3), (3,3,3), (4,4,4), (4,4,4)")
+	tk.MustExec("alter table t add stats_extended s1 dependency(a,b)")
+	tk.MustExec("alter table t add stats_extended s2 cardinality(a,c)")
+	tk.MustExec("analyze table t")
+	require.NoError(t, h.Update(dom.InfoSchema()))
+
+	// sel(a = 1 and b = 1) = sel(a = 1) * (1 + (1 - 1) * sel(b = 1)) = 0.25 with the dependency a => b.
+	rows := tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Rows()
+	require.Equal(t, "2.00", rows[0][1])
+	// sel(a = 1 and c = 1) = 1 / cardinality(a, c) = 0.25.
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1 and c = 1").Rows()
+	require.Equal(t, "2.00", rows[0][1])
+	// The columns are independent without the extended stats.
+	rows = tk.MustQuery("explain format = 'brief' select * from t where b = 1 and c = 1").Rows()
+	require.Equal(t, "0.50", rows[0][1])
+	tk.MustExec("set session tidb_enable_extended_stats = off")
+	rows = tk.MustQuery("explain format = 'brief' select * from t where a = 1 and b = 1").Rows()
+	require.Equal(t, "0.50", rows[0][1])
+}
+
 func TestShowHistogramsLoadStatus(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -361,6 +361,15 @@ func (coll *HistColl) Selectivity(
 		}
 	}
 
+	if ctx.GetSessionVars().EnableExtendedStats {
+		if factor := coll.extendedStatsSelectivityFactor(ctx, usedSets); factor != 1 {
+			ret *= factor
+			if sc.EnableOptimizerDebugTrace {
+				debugtrace.RecordAnyValuesWithNames(ctx, "Extended stats factor", factor)
+			}
+		}
+	}
+
 	notCoveredConstants := make(map[int]*expression.Constant)
 	notCoveredDNF := make(map[int]*expression.ScalarFunction)
 	notCoveredStrMatch := make(map[int]*expression.ScalarFunction)
@@ -645,6 +654,97 @@ func GetUsableSetsByGreedy(nodes []*StatsNode) (newBlocks []*StatsNode) {
 	return
 }
 
+// extendedStatsSelectivityFactor returns the factor to correct the selectivity of the column conditions combined by
+// the independence assumption, using the cardinality and dependency extended stats on the columns. Only the columns
+// with point ranges are considered, since these stats describe the combinations of the column values.
+func (coll *HistColl) extendedStatsSelectivityFactor(sctx sessionctx.Context, sets []*StatsNode) float64 {
+	if coll.ExtendedStats == nil || len(coll.ExtendedStats.Stats) == 0 {
+		return 1
+	}
+	// colNodes maps the column ID in table info to the StatsNode of the column.
+	colNodes := make(map[int64]*StatsNode, len(sets))
+	for _, set := range sets {
+		if set.Tp != ColType || set.partCover || len(set.Ranges) == 0 {
+			continue
+		}
+		col, ok := coll.Columns[set.ID]
+		if !ok || col.Info == nil {
+			continue
+		}
+		isPoint := true
+		for _, ran := range set.Ranges {
+			if !ran.IsPointNonNullable(sctx) {
+				isPoint = false
+				break
+			}
+		}
+		if isPoint {
+			colNodes[col.Info.ID] = set
+		}
+	}
+	if len(colNodes) < 2 {
+		return 1
+	}
+	names := maps.Keys(coll.ExtendedStats.Stats)
+	slices.Sort(names)
+	factor := 1.0
+	usedCols := make(map[int64]struct{}, len(colNodes))
+	// The cardinality stats are used first since they may cover more columns.
+	for _, tp := range []uint8{ast.StatsTypeCardinality, ast.StatsTypeDependency} {
+	ITEM:
+		for _, name := range names {
+			item := coll.ExtendedStats.Stats[name]
+			if item.Tp != tp {
+				continue
+			}
+			independent := 1.0
+			for _, colID := range item.ColIDs {
+				node, ok := colNodes[colID]
+				if _, used := usedCols[colID]; !ok || used {
+					continue ITEM
+				}
+				independent *= node.Selectivity
+			}
+			if independent <= 0 {
+				continue
+			}
+			var combined float64
+			switch tp {
+			case ast.StatsTypeCardinality:
+				if item.ScalarVals < 1 {
+					continue
+				}
+				// Assume the value combinations are uniformly distributed, while the selectivity can't be
+				// larger than that of any single column.
+				points, minSel := 1.0, 1.0
+				for _, colID := range item.ColIDs {
+					points *= float64(len(colNodes[colID].Ranges))
+					minSel = math.Min(minSel, colNodes[colID].Selectivity)
+				}
+				combined = math.Max(independent, math.Min(points/item.ScalarVals, minSel))
+			case ast.StatsTypeDependency:
+				degrees, err := item.DependencyDegrees()
+				if err != nil || len(item.ColIDs) != 2 {
+					continue
+				}
+				// sel(a, b) = sel(a) * (d + (1 - d) * sel(b)) for the dependency `a => b` with degree d,
+				// we use the direction with the larger degree.
+				determinant, dependent, degree := item.ColIDs[0], item.ColIDs[1], degrees[0]
+				if degrees[1] > degrees[0] {
+					determinant, dependent, degree = item.ColIDs[1], item.ColIDs[0], degrees[1]
+				}
+				detSel, depSel := colNodes[determinant].Selectivity, colNodes[dependent].Selectivity
+				combined = detSel * (degree + (1-degree)*depSel)
+			}
+			factor *= combined / independent
+			for _, colID := range item.ColIDs {
+				usedCols[colID] = struct{}{}
+			}
+		}
+	}
+	return factor
+}
+
 // FindPrefixOfIndexByCol will find columns in index by checking the unique id or the virtual expression.
 // So it will return at once no matching column is found.
 func FindPrefixOfIndexByCol(cols []*expression.Column, idxColIDs []int64, cachedPath *planutil.AccessPath) []*expression.Column {
@@ -65,9 +65,8 @@ const (
 // Table represents statistics for a table.
 type Table struct {
 	HistColl
-	Version       uint64
-	Name          string
-	ExtendedStats *ExtendedStatsColl
+	Version uint64
+	Name    string
 	// TblInfoUpdateTS is the UpdateTS of the TableInfo used when filling this struct.
 	// It is the schema version of the corresponding table. It is used to skip redundant
 	// loading of stats, i.e, if the cached stats is already update-to-date with mysql.stats_xxx tables,
@@ -121,6 +120,9 @@ type HistColl struct {
 	// The physical id is used when try to load column stats from storage.
 	HavePhysicalID bool
 	Pseudo         bool
+
+	// ExtendedStats is used to estimate the selectivity of the conditions on the correlated columns.
+	ExtendedStats *ExtendedStatsColl
 }
 
 // TableMemoryUsage records tbl memory usage
@@ -993,6 +995,7 @@ func (coll *HistColl) GenerateHistCollFromColumnInfo(tblInfo *model.TableInfo, c
 		Indices:        newIdxHistMap,
 		ColID2IdxIDs:   colID2IdxIDs,
 		Idx2ColumnIDs:  idx2Columns,
+		ExtendedStats:  coll.ExtendedStats,
 	}
 	return newColl
 }
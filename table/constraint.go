@@ -22,6 +22,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
@@ -98,6 +99,42 @@ func buildConstraintExpression(ctx sessionctx.Context, exprString string,
 	return exprs[0], nil
 }
 
+// ViolationError returns the error of a row violating the constraint. Like MySQL, the violation of a constraint like
+// `CHECK (json_schema_valid(schema, doc))` reports why the document is invalid against the schema.
+func (c *Constraint) ViolationError(sctx sessionctx.Context, row chunk.Row) error {
+	if f, ok := c.ConstraintExpr.(*expression.ScalarFunction); ok && f.FuncName.L == ast.JSONSchemaValid {
+		if reason := jsonSchemaViolationReason(sctx, f.GetArgs(), row); reason != "" {
+			return ErrJSONSchemaValidationErrorWithDetailedReport.FastGenByArgs(reason)
+		}
+	}
+	return ErrCheckConstraintViolated.FastGenByArgs(c.Name.O)
+}
+
+// IsErrCheckConstraintViolated checks whether the error is returned by ViolationError.
+func IsErrCheckConstraintViolated(err error) bool {
+	return ErrCheckConstraintViolated.Equal(err) || ErrJSONSchemaValidationErrorWithDetailedReport.Equal(err)
+}
+
+func jsonSchemaViolationReason(sctx sessionctx.Context, args []expression.Expression, row chunk.Row) string {
+	schema, isNull, err := args[0].EvalJSON(sctx, row)
+	if isNull || err != nil {
+		return ""
+	}
+	doc, isNull, err := args[1].EvalJSON(sctx, row)
+	if isNull || err != nil {
+		return ""
+	}
+	s, err := types.ParseJSONSchema(schema)
+	if err != nil {
+		return ""
+	}
+	verr, err := s.Validate(doc)
+	if verr == nil || err != nil {
+		return ""
+	}
+	return verr.Reason()
+}
+
 // IsSupportedExpr checks whether the check constraint expression is allowed
 func IsSupportedExpr(constr *ast.Constraint) (bool, error) {
 	checker := &checkConstraintChecker{
@@ -109,6 +109,8 @@ var (
 	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
 	// ErrCheckConstraintViolated return when check constraint is violated.
 	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrJSONSchemaValidationErrorWithDetailedReport returns when a json_schema_valid check constraint is violated.
+	ErrJSONSchemaValidationErrorWithDetailedReport = dbterror.ClassTable.NewStd(mysql.ErrJSONSchemaValidationErrorWithDetailedReport)
 )
 
 // RecordIterFunc is used for low-level record iteration.
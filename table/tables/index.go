@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/rowcodec"
 	"github.com/pingcap/tidb/util/tracing"
 )
@@ -43,6 +44,8 @@ type index struct {
 	// the collation global variable is initialized *after* `NewIndex()`.
 	initNeedRestoreData sync.Once
 	needRestoredData    bool
+	// tokenizer splits the text into the tokens stored in a FULLTEXT index.
+	tokenizer *fulltext.Tokenizer
 }
 
 // NeedRestoredData checks whether the index columns needs restored data.
@@ -73,6 +76,10 @@ func NewIndex(physicalID int64, tblInfo *model.TableInfo, indexInfo *model.Index
 		prefix:   prefix,
 		phyTblID: physicalID,
 	}
+	if indexInfo.IsFullText() {
+		col := tblInfo.Columns[indexInfo.Columns[0].Offset]
+		index.tokenizer = fulltext.NewTokenizer(indexInfo.FullTextParser, col.GetCollate())
+	}
 	return index
 }
 
@@ -109,7 +116,11 @@ func (c *index) GenIndexValue(sc *stmtctx.StatementContext, distinct bool, index
 // 2. (i1, [m1,m2], i2, ...) ==> [(i1, m1, i2, ...), (i1, m2, i2, ...)]
 // 3. (i1, null, i2, ...) ==> [(i1, null, i2, ...)]
 // 4. (i1, [], i2, ...) ==> nothing.
+// 5. If FULLTEXT index, (text) ==> [(t1), (t2), ...], where t1, t2 are the distinct tokens of the text.
 func (c *index) getIndexedValue(indexedValues []types.Datum) [][]types.Datum {
+	if c.idxInfo.IsFullText() {
+		return c.getFullTextIndexedValue(indexedValues[0])
+	}
 	if !c.idxInfo.MVIndex {
 		return [][]types.Datum{indexedValues}
 	}
@@ -156,6 +167,23 @@ out:
 	return vals
 }
 
+func (c *index) getFullTextIndexedValue(text types.Datum) [][]types.Datum {
+	if text.IsNull() {
+		return nil
+	}
+	tokens := c.tokenizer.Tokenize(text.GetString())
+	vals := make([][]types.Datum, 0, len(tokens))
+	existsVals := make(map[string]struct{}, len(tokens))
+	for _, token := range tokens {
+		if _, exists := existsVals[token]; exists {
+			continue
+		}
+		existsVals[token] = struct{}{}
+		vals = append(vals, []types.Datum{types.NewBytesDatum([]byte(token))})
+	}
+	return vals
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
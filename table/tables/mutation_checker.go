@@ -215,6 +215,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		if indexInfo.IsFullText() {
+			// The FULLTEXT index stores the tokens of the text rather than the value of the column.
+			continue
+		}
 		rowColInfos, ok := indexIDToRowColInfos[idxID]
 		if !ok {
 			return errors.New("index not found")
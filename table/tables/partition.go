@@ -102,6 +102,9 @@ type partitionedTable struct {
 	// doubleWritePartitions are the partitions not visible, but we should double write to
 	doubleWritePartitions map[int64]interface{}
 	reorgPartitionExpr    *PartitionExpr
+	// reorgPartitionInfo is the partitioning used by reorgPartitionExpr,
+	// which differs from the table's when the partitioning scheme is changed.
+	reorgPartitionInfo *model.PartitionInfo
 }
 
 // TODO: Check which data structures that can be shared between all partitions and which
@@ -144,10 +147,12 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 	// and if any new change happens in AddingDefinitions, it needs to be done
 	// also in DroppingDefinitions (since session running on schema version -1)
 	// should also see the changes
+	reorgTblInfo := getReorgTableInfo(tblInfo)
+	ret.reorgPartitionInfo = reorgTblInfo.Partition
 	if pi.DDLState == model.StateDeleteReorganization {
 		origIdx := setIndexesState(ret, pi.DDLState)
 		defer unsetIndexesState(ret, origIdx)
-		ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.DroppingDefinitions)
+		ret.reorgPartitionExpr, err = newPartitionExpr(reorgTblInfo, pi.DroppingDefinitions)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
@@ -168,7 +173,7 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 		if len(pi.AddingDefinitions) > 0 {
 			origIdx := setIndexesState(ret, pi.DDLState)
 			defer unsetIndexesState(ret, origIdx)
-			ret.reorgPartitionExpr, err = newPartitionExpr(tblInfo, pi.AddingDefinitions)
+			ret.reorgPartitionExpr, err = newPartitionExpr(reorgTblInfo, pi.AddingDefinitions)
 			if err != nil {
 				return nil, errors.Trace(err)
 			}
@@ -192,6 +197,23 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Part
 	return ret, nil
 }
 
+// getReorgTableInfo returns the table info with the partitioning of the
+// partitions being reorganized. When ALTER TABLE ... PARTITION BY or
+// REMOVE PARTITIONING is running it is a copy using the DDL partitioning.
+func getReorgTableInfo(tblInfo *model.TableInfo) *model.TableInfo {
+	pi := tblInfo.Partition
+	if pi.NewTableID == 0 {
+		return tblInfo
+	}
+	reorgPi := pi.Clone()
+	reorgPi.Type = pi.DDLType
+	reorgPi.Expr = pi.DDLExpr
+	reorgPi.Columns = pi.DDLColumns
+	reorgTblInfo := *tblInfo
+	reorgTblInfo.Partition = reorgPi
+	return &reorgTblInfo
+}
+
 func setIndexesState(t *partitionedTable, state model.SchemaState) []*model.IndexInfo {
 	orig := t.meta.Indices
 	t.meta.Indices = make([]*model.IndexInfo, 0, len(orig))
@@ -245,6 +267,10 @@ func newPartitionExpr(tblInfo *model.TableInfo, defs []model.PartitionDefinition
 		return generateKeyPartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, defs, columns, names)
+	case model.PartitionTypeNone:
+		// A single partition covering the whole table, used when
+		// changing the partitioning of a non-partitioned table.
+		return &PartitionExpr{}, nil
 	}
 	panic("cannot reach here")
 }
@@ -1182,6 +1208,9 @@ func (t *partitionedTable) GetPartitionColumnIDs() []int64 {
 		return colIDs
 	}
 
+	if t.partitionExpr.Expr == nil {
+		return nil
+	}
 	partitionCols := expression.ExtractColumns(t.partitionExpr.Expr)
 	colIDs := make([]int64, 0, len(partitionCols))
 	for _, col := range partitionCols {
@@ -1228,7 +1257,7 @@ func (t *partitionedTable) CheckForExchangePartition(ctx sessionctx.Context, pi
 func (t *partitionedTable) locatePartitionCommon(ctx sessionctx.Context, pi *model.PartitionInfo, partitionExpr *PartitionExpr, num uint64, r []types.Datum) (int, error) {
 	var err error
 	var idx int
-	switch t.meta.Partition.Type {
+	switch pi.Type {
 	case model.PartitionTypeRange:
 		if len(pi.Columns) == 0 {
 			idx, err = t.locateRangePartition(ctx, partitionExpr, r)
@@ -1242,6 +1271,8 @@ func (t *partitionedTable) locatePartitionCommon(ctx sessionctx.Context, pi *mod
 		idx, err = partitionExpr.LocateKeyPartition(num, r)
 	case model.PartitionTypeList:
 		idx, err = t.locateListPartition(ctx, partitionExpr, r)
+	case model.PartitionTypeNone:
+		idx = 0
 	}
 	if err != nil {
 		return 0, errors.Trace(err)
@@ -1270,7 +1301,7 @@ func (t *partitionedTable) locateReorgPartition(ctx sessionctx.Context, r []type
 	} else {
 		numParts = uint64(len(pi.AddingDefinitions))
 	}
-	idx, err := t.locatePartitionCommon(ctx, pi, t.reorgPartitionExpr, numParts, r)
+	idx, err := t.locatePartitionCommon(ctx, t.reorgPartitionInfo, t.reorgPartitionExpr, numParts, r)
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
@@ -1337,7 +1368,7 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, partitio
 		isNull bool
 		err    error
 	)
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+	if col, ok := partitionExpr.Expr.(*expression.Column); ok {
 		if r[col.Index].IsNull() {
 			isNull = true
 		}
@@ -1346,13 +1377,13 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, partitio
 		evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 		defer t.evalBufferPool.Put(evalBuffer)
 		evalBuffer.SetDatums(r...)
-		val, isNull, err = t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+		val, isNull, err = partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
 		if err != nil {
 			return 0, err
 		}
 		ret = val
 	}
-	unsigned := mysql.HasUnsignedFlag(t.partitionExpr.Expr.GetType().GetFlag())
+	unsigned := mysql.HasUnsignedFlag(partitionExpr.Expr.GetType().GetFlag())
 	ranges := partitionExpr.ForRangePruning
 	length := len(ranges.LessThan)
 	pos := sort.Search(length, func(i int) bool {
@@ -1449,6 +1480,13 @@ func GetReorganizedPartitionedTable(t table.Table) (table.PartitionedTable, erro
 		return nil, dbterror.ErrUnsupportedReorganizePartition.GenWithStackByArgs()
 	}
 	tblInfo := t.Meta().Clone()
+	pi := tblInfo.Partition
+	if pi.NewTableID != 0 {
+		// The partitioning scheme is changed, use the new one.
+		pi.Type, pi.DDLType = pi.DDLType, pi.Type
+		pi.Expr, pi.DDLExpr = pi.DDLExpr, pi.Expr
+		pi.Columns, pi.DDLColumns = pi.DDLColumns, pi.Columns
+	}
 	tblInfo.Partition.Definitions = tblInfo.Partition.AddingDefinitions
 	tblInfo.Partition.AddingDefinitions = nil
 	tblInfo.Partition.DroppingDefinitions = nil
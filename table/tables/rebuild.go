@@ -0,0 +1,391 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+)
+
+// rebuildingTable is a table being rebuilt into a new physical table, see model.TableRebuildInfo.
+// Besides the table itself, the writes also go to the other table of the rebuild.
+type rebuildingTable struct {
+	*TableCommon
+	// other is the other table of the rebuild, the new table before the switch and the original table after it.
+	other *TableCommon
+	// mappingIdx maps the primary key of the clustered table to the _tidb_rowid of the other one.
+	// It is nil if both tables have a clustered index, or neither of them has.
+	mappingIdx table.Index
+	state      model.SchemaState
+}
+
+func newRebuildingTable(t *TableCommon, allocs autoid.Allocators, ri *model.TableRebuildInfo) (table.Table, error) {
+	// Both tables share the allocators, so the _tidb_rowid of the new rows does not depend on the side they are written to.
+	otherTbl, err := TableFromMeta(allocs, ri.Table)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	other, ok := otherTbl.(*TableCommon)
+	if !ok {
+		return nil, errors.Errorf("unexpected table type %T of the rebuilt table %s", otherTbl, ri.Table.Name)
+	}
+	ret := &rebuildingTable{
+		TableCommon: t,
+		other:       other,
+		state:       ri.DDLState,
+	}
+	if ri.MappingIndex != nil {
+		// The values of the mapping index are the _tidb_rowid of the non-clustered table.
+		nonClustered := t.meta
+		if nonClustered.HasClusteredIndex() {
+			nonClustered = ri.Table
+		}
+		ret.mappingIdx = NewIndex(ri.OldTableID, nonClustered, ri.MappingIndex)
+	}
+	return ret, nil
+}
+
+// AddRecord implements table.Table AddRecord interface.
+func (t *rebuildingTable) AddRecord(sctx sessionctx.Context, r []types.Datum, opts ...table.AddRecordOption) (kv.Handle, error) {
+	h, err := t.TableCommon.AddRecord(sctx, r, opts...)
+	if err != nil || t.state == model.StateDeleteOnly {
+		return h, err
+	}
+	return h, t.addOtherRecord(sctx, h, t.trimRow(r))
+}
+
+// RemoveRecord implements table.Table RemoveRecord interface.
+func (t *rebuildingTable) RemoveRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	if err := t.TableCommon.RemoveRecord(sctx, h, r); err != nil {
+		return err
+	}
+	return t.removeOtherRecord(sctx, h, r)
+}
+
+// UpdateRecord implements table.Table UpdateRecord interface.
+func (t *rebuildingTable) UpdateRecord(ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, touched []bool) error {
+	if err := t.TableCommon.UpdateRecord(ctx, sctx, h, oldData, newData, touched); err != nil {
+		return err
+	}
+	if t.state == model.StateDeleteOnly {
+		return t.removeOtherRecord(sctx, h, oldData)
+	}
+	if err := t.other.checkNotNull(newData); err != nil {
+		return err
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	otherH, found, err := t.otherHandle(ctx, sctx, txn, h, oldData)
+	if err != nil {
+		return err
+	}
+	if !found {
+		// The row is not copied yet, so copy the new row.
+		return t.addOtherRecord(sctx, h, newData)
+	}
+	if t.other.meta.HasClusteredIndex() {
+		newOtherH, err := handleFromRow(sctx, t.other.meta, newData)
+		if err != nil {
+			return err
+		}
+		if !newOtherH.Equal(otherH) {
+			if err = t.other.RemoveRecord(sctx, otherH, oldData); err != nil {
+				return err
+			}
+			if t.mappingIdx != nil {
+				if err = t.mappingIdx.Delete(sctx.GetSessionVars().StmtCtx, txn, t.mappingValues(oldData), h); err != nil {
+					return err
+				}
+			}
+			return t.addOtherRecord(sctx, h, newData)
+		}
+		if t.mappingIdx != nil {
+			// The row may not be copied yet, map its primary key to it, so the backfill takes the row as copied.
+			vals := t.mappingValues(oldData)
+			if err = t.mappingIdx.Delete(sctx.GetSessionVars().StmtCtx, txn, vals, h); err != nil {
+				return err
+			}
+			if _, err = t.mappingIdx.Create(sctx, txn, vals, h, nil); err != nil {
+				return err
+			}
+		}
+	}
+	// The row may not be copied yet, so rewrite all the indexes of the other table,
+	// otherwise the backfill would skip the row and the untouched indexes would miss it.
+	allTouched := make([]bool, len(touched))
+	for i := range allTouched {
+		allTouched[i] = true
+	}
+	return t.other.UpdateRecord(ctx, sctx, otherH, oldData, newData, allTouched)
+}
+
+// trimRow removes the _tidb_rowid appended to the row by an insert.
+func (t *rebuildingTable) trimRow(r []types.Datum) []types.Datum {
+	if cols := t.Cols(); len(r) > len(cols) {
+		return r[:len(cols)]
+	}
+	return r
+}
+
+func (t *rebuildingTable) addOtherRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	// The columns of a new primary key may still be NULL in this table.
+	if err = t.other.checkNotNull(r); err != nil {
+		return err
+	}
+	var rowID kv.Handle
+	otherRow := r
+	if !t.other.meta.HasClusteredIndex() {
+		rowID = h
+		if t.meta.HasClusteredIndex() {
+			if rowID, err = AllocHandle(context.Background(), sctx, t.TableCommon); err != nil {
+				return err
+			}
+		}
+		// Pass the _tidb_rowid as the last value like an insert with an explicit _tidb_rowid.
+		otherRow = make([]types.Datum, 0, len(r)+1)
+		otherRow = append(otherRow, r...)
+		otherRow = append(otherRow, types.NewIntDatum(rowID.IntValue()))
+	}
+	if _, err = t.other.AddRecord(sctx, otherRow); err != nil || t.mappingIdx == nil {
+		return err
+	}
+	if rowID == nil {
+		rowID = h
+	}
+	_, err = t.mappingIdx.Create(sctx, txn, t.mappingValues(r), rowID, nil)
+	return err
+}
+
+func (t *rebuildingTable) removeOtherRecord(sctx sessionctx.Context, h kv.Handle, r []types.Datum) error {
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	otherH, found, err := t.otherHandle(context.Background(), sctx, txn, h, r)
+	if err != nil {
+		return err
+	}
+	if found {
+		if err = t.other.RemoveRecord(sctx, otherH, r); err != nil {
+			return err
+		}
+	}
+	if t.mappingIdx == nil {
+		return nil
+	}
+	// Also delete the entry when the row is not copied yet, so a concurrent backfill of the row conflicts with it.
+	return t.mappingIdx.Delete(sctx.GetSessionVars().StmtCtx, txn, t.mappingValues(r), h)
+}
+
+// otherHandle returns the handle of the row in the other table. It returns false if the row
+// is known to be missing in the other table.
+func (t *rebuildingTable) otherHandle(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, h kv.Handle, r []types.Datum) (kv.Handle, bool, error) {
+	if t.other.meta.HasClusteredIndex() {
+		otherH, err := handleFromRow(sctx, t.other.meta, r)
+		if table.ErrColumnCantNull.Equal(err) {
+			// A row with a NULL primary key column can not be copied.
+			return nil, false, nil
+		}
+		return otherH, err == nil, err
+	}
+	if !t.meta.HasClusteredIndex() {
+		return h, true, nil
+	}
+	return t.lookupMapping(ctx, sctx, txn, r)
+}
+
+func (t *rebuildingTable) mappingValues(r []types.Datum) []types.Datum {
+	cols := t.mappingIdx.Meta().Columns
+	vals := make([]types.Datum, 0, len(cols))
+	for _, col := range cols {
+		vals = append(vals, r[col.Offset])
+	}
+	return vals
+}
+
+// lookupMapping returns the _tidb_rowid the mapping index maps the primary key of the row to.
+func (t *rebuildingTable) lookupMapping(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, r []types.Datum) (kv.Handle, bool, error) {
+	key, _, err := t.mappingIdx.GenIndexKey(sctx.GetSessionVars().StmtCtx, t.mappingValues(r), nil, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	val, err := txn.Get(ctx, key)
+	if err != nil {
+		if kv.IsErrNotFound(err) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	h, err := tablecodec.DecodeHandleInUniqueIndexValue(val, false)
+	return h, err == nil, err
+}
+
+// backfillRecord copies a row of the table to the other table in the transaction.
+// It returns false if the row is already copied, by a concurrent write or by an earlier backfill.
+func (t *rebuildingTable) backfillRecord(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, h kv.Handle, r []types.Datum) (bool, error) {
+	if err := t.other.checkNotNull(r); err != nil {
+		return false, err
+	}
+	var (
+		otherH kv.Handle
+		err    error
+	)
+	switch {
+	case t.other.meta.HasClusteredIndex():
+		otherH, err = handleFromRow(sctx, t.other.meta, r)
+	case !t.meta.HasClusteredIndex():
+		otherH = h
+	default:
+		var found bool
+		if _, found, err = t.lookupMapping(ctx, sctx, txn, r); err != nil || found {
+			return false, err
+		}
+		otherH, err = AllocHandle(ctx, sctx, t.TableCommon)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	key := t.other.RecordKey(otherH)
+	existing, err := txn.Get(ctx, key)
+	if err == nil {
+		same, err := t.isCopyOf(ctx, sctx, txn, h, otherH, existing)
+		if err != nil || same {
+			return false, err
+		}
+		handleStr := getDuplicateErrorHandleString(t.other, otherH, r)
+		return false, kv.ErrKeyExists.FastGenByArgs(handleStr, t.other.meta.Name.String()+".PRIMARY")
+	} else if !kv.IsErrNotFound(err) {
+		return false, err
+	}
+
+	colIDs := make([]int64, 0, len(r))
+	row := make([]types.Datum, 0, len(r))
+	for _, col := range t.other.Cols() {
+		if t.other.canSkip(col, &r[col.Offset]) {
+			continue
+		}
+		colIDs = append(colIDs, col.ID)
+		row = append(row, r[col.Offset])
+	}
+	sessVars := sctx.GetSessionVars()
+	value, err := tablecodec.EncodeRow(sessVars.StmtCtx, row, colIDs, nil, nil, &sessVars.RowEncoder)
+	if err != nil {
+		return false, err
+	}
+	if err = txn.Set(key, value); err != nil {
+		return false, err
+	}
+	opts := []table.CreateIdxOptFunc{table.WithIgnoreAssertion, table.FromBackfill}
+	if _, err = t.other.addIndices(sctx, otherH, r, txn, opts); err != nil {
+		return false, err
+	}
+	if t.mappingIdx != nil {
+		rowID := h
+		if !t.other.meta.HasClusteredIndex() {
+			rowID = otherH
+		}
+		if _, err = t.mappingIdx.Create(sctx, txn, t.mappingValues(r), rowID, nil, opts...); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// isCopyOf checks whether the existing row of the other table is the copy of the row with the handle h.
+func (t *rebuildingTable) isCopyOf(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, h, otherH kv.Handle, value []byte) (bool, error) {
+	if !t.meta.HasClusteredIndex() {
+		if t.mappingIdx == nil {
+			// Both tables use the same _tidb_rowid.
+			return true, nil
+		}
+		otherRow, _, err := DecodeRawRowData(sctx, t.other.meta, otherH, t.other.Cols(), value)
+		if err != nil {
+			return false, err
+		}
+		rowID, found, err := t.lookupMapping(ctx, sctx, txn, otherRow)
+		return found && rowID.Equal(h), err
+	}
+	otherRow, _, err := DecodeRawRowData(sctx, t.other.meta, otherH, t.other.Cols(), value)
+	if err != nil {
+		return false, err
+	}
+	copied, err := handleFromRow(sctx, t.meta, otherRow)
+	if err != nil {
+		return false, err
+	}
+	return copied.Equal(h), nil
+}
+
+// checkNotNull checks the NOT NULL columns of the table are not NULL in the row.
+func (t *TableCommon) checkNotNull(r []types.Datum) error {
+	for _, col := range t.Cols() {
+		if mysql.HasNotNullFlag(col.GetFlag()) && r[col.Offset].IsNull() {
+			return table.ErrColumnCantNull.GenWithStackByArgs(col.Name.O)
+		}
+	}
+	return nil
+}
+
+// handleFromRow builds the handle of a row of a table with a clustered index.
+func handleFromRow(sctx sessionctx.Context, tblInfo *model.TableInfo, r []types.Datum) (kv.Handle, error) {
+	if tblInfo.PKIsHandle {
+		col := tblInfo.GetPkColInfo()
+		if r[col.Offset].IsNull() {
+			return nil, table.ErrColumnCantNull.GenWithStackByArgs(col.Name.O)
+		}
+		return kv.IntHandle(r[col.Offset].GetInt64()), nil
+	}
+	pkIdx := FindPrimaryIndex(tblInfo)
+	pkDts := make([]types.Datum, 0, len(pkIdx.Columns))
+	for _, idxCol := range pkIdx.Columns {
+		if r[idxCol.Offset].IsNull() {
+			return nil, table.ErrColumnCantNull.GenWithStackByArgs(idxCol.Name.O)
+		}
+		pkDts = append(pkDts, r[idxCol.Offset])
+	}
+	tablecodec.TruncateIndexValues(tblInfo, pkIdx, pkDts)
+	handleBytes, err := codec.EncodeKey(sctx.GetSessionVars().StmtCtx, nil, pkDts...)
+	if err != nil {
+		return nil, err
+	}
+	return kv.NewCommonHandle(handleBytes)
+}
+
+// BackfillRebuildRecord copies a row of a table being rebuilt to the other table of the rebuild in the transaction.
+// It returns false if the row has been copied already.
+func BackfillRebuildRecord(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, t table.Table, h kv.Handle, r []types.Datum) (bool, error) {
+	rt, ok := t.(*rebuildingTable)
+	if !ok {
+		return false, errors.Errorf("table %s is not being rebuilt", t.Meta().Name)
+	}
+	return rt.backfillRecord(ctx, sctx, txn, h, r)
+}
@@ -172,6 +172,9 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 		if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 			return newCachedTable(&t)
 		}
+		if tblInfo.Rebuild != nil {
+			return newRebuildingTable(&t, allocs, tblInfo.Rebuild)
+		}
 		return &t, nil
 	}
 	return newPartitionedTable(&t, tblInfo)
@@ -383,6 +386,10 @@ func (t *TableCommon) RecordKey(h kv.Handle) kv.Key {
 // shouldAssert checks if the partition should be in consistent
 // state and can have assertion.
 func (t *TableCommon) shouldAssert(sctx sessionctx.Context) bool {
+	if t.meta.State != model.StatePublic {
+		// The new table of a table rebuild misses the rows that are not copied yet.
+		return false
+	}
 	p := t.Meta().Partition
 	if p != nil {
 		// This disables asserting during Reorganize Partition.
@@ -508,12 +515,13 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 	}
 	// check data constraint
 	for _, constraint := range t.WritableConstraint() {
-		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, chunk.MutRowFromDatums(rowToCheck).ToRow())
+		checkRow := chunk.MutRowFromDatums(rowToCheck).ToRow()
+		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, checkRow)
 		if err != nil {
 			return err
 		}
 		if ok == 0 && !isNull {
-			return table.ErrCheckConstraintViolated.FastGenByArgs(constraint.Name.O)
+			return constraint.ViolationError(sctx, checkRow)
 		}
 	}
 	sessVars := sctx.GetSessionVars()
@@ -646,8 +654,9 @@ func (t *TableCommon) rebuildIndices(ctx sessionctx.Context, txn kv.Transaction,
 		// If InHandleForeignKeyTrigger or ForeignKeyTriggerCtx.HasFKCascades is true indicate we may have
 		// foreign key cascade need to handle later, then we still need to write index value,
 		// otherwise, the later foreign cascade executor may see data-index inconsistency in txn-mem-buffer.
+		// It's the same for the statements in the triggers.
 		sessVars := ctx.GetSessionVars()
-		if untouched && !sessVars.InTxn() &&
+		if untouched && !sessVars.InTxn() && len(sessVars.StmtCtx.TriggerTableIDs) == 0 &&
 			!sessVars.StmtCtx.InHandleForeignKeyTrigger && !sessVars.StmtCtx.ForeignKeyTriggerCtx.HasFKCascades {
 			continue
 		}
@@ -947,7 +956,12 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			} else {
 				// If `AddRecord` is called by an insert and the col is in write only or write reorganization state, we must
 				// add it with its default value.
-				value, err = table.GetColOriginDefaultValue(sctx, col.ToInfo())
+				// An auto-increment column being added gets the next value, the rows written before are backfilled by the DDL.
+				if mysql.HasAutoIncrementFlag(col.GetFlag()) {
+					value, err = t.allocNonPublicAutoIncValue(sctx, col)
+				} else {
+					value, err = table.GetColOriginDefaultValue(sctx, col.ToInfo())
+				}
 				if err != nil {
 					return nil, err
 				}
@@ -968,12 +982,13 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 	}
 
 	for _, constraint := range t.WritableConstraint() {
-		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, chunk.MutRowFromDatums(r).ToRow())
+		checkRow := chunk.MutRowFromDatums(r).ToRow()
+		ok, isNull, err := constraint.ConstraintExpr.EvalInt(sctx, checkRow)
 		if err != nil {
 			return nil, err
 		}
 		if ok == 0 && !isNull {
-			return nil, table.ErrCheckConstraintViolated.FastGenByArgs(constraint.Name.O)
+			return nil, constraint.ViolationError(sctx, checkRow)
 		}
 	}
 
@@ -1923,6 +1938,17 @@ func (t *TableCommon) canSkip(col *table.Column, value *types.Datum) bool {
 	return CanSkip(t.Meta(), col, value)
 }
 
+// allocNonPublicAutoIncValue allocates the value of an auto-increment column that is being added.
+func (t *TableCommon) allocNonPublicAutoIncValue(sctx sessionctx.Context, col *table.Column) (types.Datum, error) {
+	id, err := table.AllocAutoIncrementValue(context.Background(), t, sctx)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	var d types.Datum
+	d.SetAutoID(id, col.GetFlag())
+	return table.CastValue(sctx, d, col.ToInfo(), false, false)
+}
+
 // CanSkip is for these cases, we can skip the columns in encoded row:
 // 1. the column is included in primary key;
 // 2. the column's default value is null, and the value equals to that but has no origin default;
@@ -17,6 +17,8 @@ package temptable
 import (
 	"bytes"
 	"context"
+	"fmt"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/infoschema"
@@ -30,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
 	"github.com/tikv/client-go/v2/tikv"
 )
 
@@ -38,6 +41,7 @@ type TemporaryTableDDL interface {
 	CreateLocalTemporaryTable(db *model.DBInfo, info *model.TableInfo) error
 	DropLocalTemporaryTable(schema model.CIStr, tblName model.CIStr) error
 	TruncateLocalTemporaryTable(schema model.CIStr, tblName model.CIStr) error
+	AlterLocalTemporaryTable(schema model.CIStr, tblName model.CIStr, info *model.TableInfo) error
 }
 
 // temporaryTableDDL implements temptable.TemporaryTableDDL
@@ -46,7 +50,7 @@ type temporaryTableDDL struct {
 }
 
 func (d *temporaryTableDDL) CreateLocalTemporaryTable(db *model.DBInfo, info *model.TableInfo) error {
-	if _, err := ensureSessionData(d.sctx); err != nil {
+	if _, err := EnsureSessionData(d.sctx); err != nil {
 		return err
 	}
 
@@ -90,14 +94,141 @@ func (d *temporaryTableDDL) TruncateLocalTemporaryTable(schema model.CIStr, tblN
 	return d.clearTemporaryTableRecords(oldTblInfo.ID)
 }
 
+// AlterLocalTemporaryTable replaces the table info of a local temporary table with the altered one, which
+// has the same table ID. The indexes only in the new table info are backfilled from the rows in the session,
+// and the indexes only in the old table info are cleaned up.
+func (d *temporaryTableDDL) AlterLocalTemporaryTable(schema model.CIStr, tblName model.CIStr, info *model.TableInfo) error {
+	oldTbl, err := checkLocalTemporaryExistsAndReturn(d.sctx, schema, tblName)
+	if err != nil {
+		return err
+	}
+
+	oldTblInfo := oldTbl.Meta()
+	newTbl, err := tables.TableFromMeta(oldTbl.Allocators(nil), info)
+	if err != nil {
+		return err
+	}
+
+	if sessionData := getSessionData(d.sctx); sessionData != nil {
+		stage := sessionData.Staging()
+		if err = d.reorgTemporaryTableIndexes(sessionData, oldTbl, newTbl); err != nil {
+			sessionData.Cleanup(stage)
+			return err
+		}
+		sessionData.Release(stage)
+	}
+
+	localTempTables := getLocalTemporaryTables(d.sctx)
+	db, _ := localTempTables.SchemaByTable(oldTblInfo)
+	localTempTables.RemoveTable(schema, tblName)
+	return localTempTables.AddTable(db, newTbl)
+}
+
+func (d *temporaryTableDDL) reorgTemporaryTableIndexes(sessionData variable.TemporaryTableData, oldTbl, newTbl table.Table) error {
+	tblID := newTbl.Meta().ID
+	newIndexes := make([]table.Index, 0, len(newTbl.Indices()))
+	for _, idx := range newTbl.Indices() {
+		if model.FindIndexInfoByID(oldTbl.Meta().Indices, idx.Meta().ID) == nil {
+			newIndexes = append(newIndexes, idx)
+		}
+	}
+	for _, idxInfo := range oldTbl.Meta().Indices {
+		if model.FindIndexInfoByID(newTbl.Meta().Indices, idxInfo.ID) == nil {
+			idxPrefix := tablecodec.EncodeTableIndexPrefix(tblID, idxInfo.ID)
+			if err := d.clearTemporaryTableKeys(tblID, idxPrefix, idxPrefix.PrefixNext()); err != nil {
+				return err
+			}
+		}
+	}
+	if len(newIndexes) == 0 {
+		return nil
+	}
+
+	// Collect the rows before writing the index keys, so the iterator is not affected by the writes.
+	type tempTableRow struct {
+		handle kv.Handle
+		row    []types.Datum
+	}
+	var rows []tempTableRow
+	recordPrefix := tablecodec.GenTableRecordPrefix(tblID)
+	iter, err := sessionData.Iter(recordPrefix, recordPrefix.PrefixNext())
+	if err != nil {
+		return err
+	}
+	for iter.Valid() && iter.Key().HasPrefix(recordPrefix) {
+		if len(iter.Value()) > 0 {
+			handle, err := tablecodec.DecodeRowKey(iter.Key())
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			row, _, err := tables.DecodeRawRowData(d.sctx, newTbl.Meta(), handle, newTbl.Cols(), iter.Value())
+			if err != nil {
+				iter.Close()
+				return err
+			}
+			rows = append(rows, tempTableRow{handle: handle, row: row})
+		}
+		if err = iter.Next(); err != nil {
+			iter.Close()
+			return err
+		}
+	}
+	iter.Close()
+
+	sc := d.sctx.GetSessionVars().StmtCtx
+	for _, r := range rows {
+		for _, idx := range newIndexes {
+			vals, err := idx.FetchValues(r.row, nil)
+			if err != nil {
+				return err
+			}
+			rsData := tables.TryGetHandleRestoredDataWrapper(newTbl.Meta(), r.row, nil, idx.Meta())
+			kvIter := idx.GenIndexKVIter(sc, vals, r.handle, rsData)
+			for kvIter.Valid() {
+				key, val, distinct, err := kvIter.Next(nil)
+				if err != nil {
+					return err
+				}
+				if distinct {
+					if existing, err := sessionData.Get(context.Background(), key); err == nil && len(existing) > 0 {
+						return genTemporaryTableKeyExistsErr(newTbl.Meta(), idx.Meta(), vals)
+					} else if err != nil && !kv.IsErrNotFound(err) {
+						return err
+					}
+				}
+				if err = sessionData.SetTableKey(tblID, key, val); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func genTemporaryTableKeyExistsErr(tblInfo *model.TableInfo, idxInfo *model.IndexInfo, vals []types.Datum) error {
+	valueStr := make([]string, 0, len(vals))
+	for _, val := range vals {
+		str, err := val.ToString()
+		if err != nil {
+			str = val.String()
+		}
+		valueStr = append(valueStr, str)
+	}
+	indexName := fmt.Sprintf("%s.%s", tblInfo.Name.String(), idxInfo.Name.String())
+	return kv.ErrKeyExists.FastGenByArgs(strings.Join(valueStr, "-"), indexName)
+}
+
 func (d *temporaryTableDDL) clearTemporaryTableRecords(tblID int64) error {
+	return d.clearTemporaryTableKeys(tblID, tablecodec.EncodeTablePrefix(tblID), tablecodec.EncodeTablePrefix(tblID+1))
+}
+
+func (d *temporaryTableDDL) clearTemporaryTableKeys(tblID int64, tblPrefix, endKey kv.Key) error {
 	sessionData := getSessionData(d.sctx)
 	if sessionData == nil {
 		return nil
 	}
 
-	tblPrefix := tablecodec.EncodeTablePrefix(tblID)
-	endKey := tablecodec.EncodeTablePrefix(tblID + 1)
 	iter, err := sessionData.Iter(tblPrefix, endKey)
 	if err != nil {
 		return err
@@ -142,7 +273,8 @@ func getSessionData(sctx sessionctx.Context) variable.TemporaryTableData {
 	return sctx.GetSessionVars().TemporaryTableData
 }
 
-func ensureSessionData(sctx sessionctx.Context) (variable.TemporaryTableData, error) {
+// EnsureSessionData gets the temporary table data of the session, and creates it if it doesn't exist.
+func EnsureSessionData(sctx sessionctx.Context) (variable.TemporaryTableData, error) {
 	sessVars := sctx.GetSessionVars()
 	if sessVars.TemporaryTableData == nil {
 		// Create this txn just for getting a MemBuffer. It's a little tricky
@@ -17,6 +17,7 @@ package temptable_test
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/stretchr/testify/require"
@@ -37,3 +38,82 @@ func TestSelectTemporaryTableUnionView(t *testing.T) {
 	tk.MustQuery("select * from t").Check(testkit.Rows("2"))
 	tk.MustQuery("select * from (select a from t union all select a from tv) t1 order by a").Check(testkit.Rows("1", "2"))
 }
+
+func TestGlobalTemporaryTableOnCommitPreserveRows(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create global temporary table t (id int primary key auto_increment, a int, key(a)) on commit preserve rows")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE GLOBAL TEMPORARY TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL AUTO_INCREMENT,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `a` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin ON COMMIT PRESERVE ROWS"))
+
+	// The rows are kept after the transaction commits, and dropped when it rolls back.
+	tk.MustExec("insert into t(a) values (1), (2)")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t(a) values (3)")
+	tk.MustExec("rollback")
+	tk.MustExec("begin")
+	tk.MustExec("update t set a = 20 where id = 2")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1", "2 20"))
+	tk.MustQuery("select a from t use index(a) where a > 1").Check(testkit.Rows("20"))
+	tk.MustExec("insert into t(a) values (4)")
+	tk.MustQuery("select * from t where id > 2").Check(testkit.Rows("4 4"))
+
+	// The rows are only visible to the session which writes them.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	tk2.MustQuery("select * from t").Check(testkit.Rows())
+	tk2.MustExec("insert into t(a) values (10)")
+	tk2.MustQuery("select * from t").Check(testkit.Rows("1 10"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("3"))
+
+	// The reorg DDL can't see the rows in the sessions.
+	tk.MustGetErrCode("alter table t add index i(id, a)", errno.ErrOptOnTemporaryTable)
+	tk.MustExec("alter table t add column b int")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 <nil>", "2 20 <nil>", "4 4 <nil>"))
+
+	tk.MustExec("truncate table t")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+}
+
+func TestLocalTemporaryTableAlter(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create temporary table t (id int primary key, a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 1, 'a'), (2, 2, 'b'), (3, 2, 'c')")
+
+	tk.MustExec("alter table t add column c int default 5 after a, add index i(a, b)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 5 a", "2 2 5 b", "3 2 5 c"))
+	tk.MustQuery("select id from t use index(i) where a = 2 order by id").Check(testkit.Rows("2", "3"))
+	tk.MustExec("insert into t values (4, 4, 6, 'd')")
+	tk.MustQuery("select id, c from t use index(i) where a = 4").Check(testkit.Rows("4 6"))
+
+	// The unique index is checked against the existing rows.
+	tk.MustGetErrMsg("create unique index u on t(a)", "[kv:1062]Duplicate entry '2' for key 't.u'")
+	tk.MustExec("create unique index u on t(b)")
+	tk.MustGetErrCode("insert into t values (5, 5, 5, 'a')", errno.ErrDupEntry)
+	tk.MustGetErrCode("create index u on t(a)", errno.ErrDupKeyName)
+
+	tk.MustExec("alter table t drop column b")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TEMPORARY TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT '5',\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `i` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select id from t use index(i) where a = 2 order by id").Check(testkit.Rows("2", "3"))
+	tk.MustExec("drop index i on t")
+	tk.MustExec("drop index if exists i on t")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 1 5", "2 2 5", "3 2 5", "4 4 6"))
+
+	// The failed statement doesn't change the table.
+	tk.MustGetErrCode("alter table t add index j(a), add column d int auto_increment", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("select count(*) from t use index(j)", errno.ErrKeyDoesNotExist)
+}
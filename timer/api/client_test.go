@@ -139,6 +139,14 @@ func TestUpdateTimerOption(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, []string{"l1", "l2"}, tags)
 	require.Equal(t, []string{"Tags", "Enable", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
+
+	// test 'Data' field
+	require.False(t, update.Data.Present())
+	WithSetData([]byte("data1"))(&update)
+	data, ok := update.Data.Get()
+	require.True(t, ok)
+	require.Equal(t, []byte("data1"), data)
+	require.Equal(t, []string{"Tags", "Enable", "Data", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
 }
 
 func TestDefaultClient(t *testing.T) {
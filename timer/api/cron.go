@@ -0,0 +1,271 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+)
+
+// maxCronSearchYears is the max years to search the next time of a cron expression. An expression such as
+// `0 0 30 2 *` never matches, and it's considered to have no next time.
+const maxCronSearchYears = 5
+
+// cronField is the definition of a field in the cron expression.
+type cronField struct {
+	name     string
+	min, max int
+	// names are the alias of the values, they are indexed from min.
+	names []string
+}
+
+var (
+	cronSecond     = cronField{name: "second", min: 0, max: 59}
+	cronMinute     = cronField{name: "minute", min: 0, max: 59}
+	cronHour       = cronField{name: "hour", min: 0, max: 23}
+	cronDayOfMonth = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth      = cronField{name: "month", min: 1, max: 12, names: []string{
+		"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+	}}
+	// The day of week 7 is also Sunday.
+	cronDayOfWeek = cronField{name: "day of week", min: 0, max: 7, names: []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+	}}
+)
+
+var cronDescriptors = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// cronSchedule is the parsed cron expression, every field is a bitset of the matched values.
+type cronSchedule struct {
+	second, minute, hour, dom, month, dow uint64
+	// domStar or dowStar is true if the day of month or the day of week is `*` or `?`. If both of them are
+	// restricted, a day matches if either of them matches, which is the same as the standard cron.
+	domStar, dowStar bool
+	loc              *time.Location
+}
+
+// parseCron parses a cron expression with 5 fields (minute, hour, day of month, month, day of week) or 6
+// fields (with second before minute). The expression can be prefixed with `CRON_TZ=<zone>` or `TZ=<zone>`
+// to specify its time zone, otherwise it's in the local time zone.
+func parseCron(expr string) (*cronSchedule, error) {
+	expr = strings.TrimSpace(expr)
+	loc := time.Local
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if !strings.HasPrefix(expr, prefix) {
+			continue
+		}
+		zone, rest, _ := strings.Cut(expr[len(prefix):], " ")
+		var err error
+		if loc, err = time.LoadLocation(zone); err != nil {
+			return nil, errors.Errorf("invalid time zone '%s'", zone)
+		}
+		expr = strings.TrimSpace(rest)
+		break
+	}
+	if descriptor, ok := cronDescriptors[strings.ToLower(expr)]; ok {
+		expr = descriptor
+	}
+
+	fields := strings.Fields(expr)
+	switch len(fields) {
+	case 5:
+		fields = append([]string{"0"}, fields...)
+	case 6:
+	default:
+		return nil, errors.Errorf("expected 5 or 6 fields, found %d", len(fields))
+	}
+
+	s := &cronSchedule{loc: loc}
+	var err error
+	for i, f := range []struct {
+		bits *uint64
+		def  cronField
+	}{
+		{&s.second, cronSecond},
+		{&s.minute, cronMinute},
+		{&s.hour, cronHour},
+		{&s.dom, cronDayOfMonth},
+		{&s.month, cronMonth},
+		{&s.dow, cronDayOfWeek},
+	} {
+		if *f.bits, err = parseCronField(fields[i], f.def); err != nil {
+			return nil, err
+		}
+	}
+	s.domStar = fields[3] == "*" || fields[3] == "?"
+	s.dowStar = fields[5] == "*" || fields[5] == "?"
+	if s.dow&(1<<7) != 0 {
+		s.dow |= 1
+	}
+	return s, nil
+}
+
+// parseCronField parses a field which is a list of `*`, `?`, `value`, `start-end` and their steps `x/step`.
+func parseCronField(field string, def cronField) (bits uint64, err error) {
+	for _, item := range strings.Split(field, ",") {
+		rangeStr, stepStr, hasStep := strings.Cut(item, "/")
+		start, end, step := def.min, def.max, 1
+		switch {
+		case rangeStr == "*" || rangeStr == "?":
+		case strings.Contains(rangeStr, "-"):
+			startStr, endStr, _ := strings.Cut(rangeStr, "-")
+			if start, err = parseCronValue(startStr, def); err != nil {
+				return 0, err
+			}
+			if end, err = parseCronValue(endStr, def); err != nil {
+				return 0, err
+			}
+			if start > end {
+				return 0, errors.Errorf("invalid range '%s' of %s", rangeStr, def.name)
+			}
+		default:
+			if start, err = parseCronValue(rangeStr, def); err != nil {
+				return 0, err
+			}
+			// `start/step` means from start to the max value every step.
+			if !hasStep {
+				end = start
+			}
+		}
+		if hasStep {
+			if step, err = strconv.Atoi(stepStr); err != nil || step <= 0 {
+				return 0, errors.Errorf("invalid step '%s' of %s", stepStr, def.name)
+			}
+		}
+		for i := start; i <= end; i += step {
+			bits |= 1 << uint(i)
+		}
+	}
+	return bits, nil
+}
+
+func parseCronValue(s string, def cronField) (int, error) {
+	for i, name := range def.names {
+		if strings.EqualFold(s, name) {
+			return def.min + i, nil
+		}
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < def.min || v > def.max {
+		return 0, errors.Errorf("invalid value '%s' of %s", s, def.name)
+	}
+	return v, nil
+}
+
+func (s *cronSchedule) dayMatches(t time.Time) bool {
+	domMatch := s.dom&(1<<uint(t.Day())) != 0
+	dowMatch := s.dow&(1<<uint(t.Weekday())) != 0
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+	return domMatch || dowMatch
+}
+
+// next returns the first time matching the schedule after t.
+func (s *cronSchedule) next(t time.Time) (time.Time, bool) {
+	origLoc := t.Location()
+	t = t.In(s.loc).Truncate(time.Second).Add(time.Second)
+	yearLimit := t.Year() + maxCronSearchYears
+
+	// Find the first matched field from the largest unit to the smallest one, the smaller units are reset
+	// when a larger unit is moved.
+WRAP:
+	if t.Year() > yearLimit {
+		return time.Time{}, false
+	}
+
+	for s.month&(1<<uint(t.Month())) == 0 {
+		t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.loc)
+		if t.Month() == time.January {
+			goto WRAP
+		}
+	}
+
+	for !s.dayMatches(t) {
+		month := t.Month()
+		t = time.Date(t.Year(), month, t.Day()+1, 0, 0, 0, 0, s.loc)
+		if t.Month() != month {
+			goto WRAP
+		}
+	}
+
+	for s.hour&(1<<uint(t.Hour())) == 0 {
+		day := t.Day()
+		t = time.Date(t.Year(), t.Month(), day, t.Hour()+1, 0, 0, 0, s.loc)
+		if t.Day() != day {
+			goto WRAP
+		}
+	}
+
+	for s.minute&(1<<uint(t.Minute())) == 0 {
+		hour := t.Hour()
+		t = t.Truncate(time.Minute).Add(time.Minute)
+		if t.Hour() != hour {
+			goto WRAP
+		}
+	}
+
+	for s.second&(1<<uint(t.Second())) == 0 {
+		minute := t.Minute()
+		t = t.Add(time.Second)
+		if t.Minute() != minute {
+			goto WRAP
+		}
+	}
+
+	return t.In(origLoc), true
+}
+
+// SchedCronPolicy implements SchedEventPolicy, it is the policy of type `SchedEventCron`.
+type SchedCronPolicy struct {
+	expr     string
+	schedule *cronSchedule
+	nowFunc  func() time.Time
+}
+
+// NewSchedCronPolicy creates a new SchedCronPolicy.
+func NewSchedCronPolicy(expr string) (*SchedCronPolicy, error) {
+	schedule, err := parseCron(expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule event expr '%s'", expr)
+	}
+
+	return &SchedCronPolicy{
+		expr:     expr,
+		schedule: schedule,
+		nowFunc:  time.Now,
+	}, nil
+}
+
+// NextEventTime returns the next time of the timer event.
+// A next event should be triggered at the first time matching the cron expression after watermark. If the
+// watermark is zero, the event is triggered at the first matched time after now.
+func (p *SchedCronPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
+	if watermark.IsZero() {
+		watermark = p.nowFunc()
+	}
+	return p.schedule.next(watermark)
+}
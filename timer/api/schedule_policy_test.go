@@ -65,3 +65,165 @@ func TestIntervalPolicy(t *testing.T) {
 		require.Equal(t, watermark2.Add(c.interval), tm)
 	}
 }
+
+func TestCronPolicy(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	watermark := time.Date(2023, 6, 1, 10, 30, 15, 0, time.UTC)
+
+	cases := []struct {
+		expr string
+		err  string
+		next []time.Time
+	}{
+		{
+			expr: "CRON_TZ=UTC */15 * * * *",
+			next: []time.Time{
+				time.Date(2023, 6, 1, 10, 45, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr: "TZ=UTC 30 * * * * *",
+			next: []time.Time{
+				time.Date(2023, 6, 1, 10, 30, 30, 0, time.UTC),
+				time.Date(2023, 6, 1, 10, 31, 30, 0, time.UTC),
+			},
+		},
+		{
+			expr: "CRON_TZ=Asia/Shanghai 0 2 * * *",
+			next: []time.Time{
+				time.Date(2023, 6, 2, 2, 0, 0, 0, loc),
+				time.Date(2023, 6, 3, 2, 0, 0, 0, loc),
+			},
+		},
+		{
+			expr: "CRON_TZ=UTC 0 0 * * MON-FRI",
+			next: []time.Time{
+				time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 5, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			// The day matches either the day of month or the day of week if both of them are restricted.
+			expr: "CRON_TZ=UTC 0 0 13 * 5",
+			next: []time.Time{
+				time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 9, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 13, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr: "CRON_TZ=UTC 0 0 29 feb ?",
+			next: []time.Time{
+				time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+				time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr: "CRON_TZ=UTC 0 0 * * 7",
+			next: []time.Time{
+				time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 11, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr: "CRON_TZ=UTC @hourly",
+			next: []time.Time{
+				time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			expr: "CRON_TZ=UTC 5/20 1-3,22 * * *",
+			next: []time.Time{
+				time.Date(2023, 6, 1, 22, 5, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 22, 25, 0, 0, time.UTC),
+				time.Date(2023, 6, 1, 22, 45, 0, 0, time.UTC),
+				time.Date(2023, 6, 2, 1, 5, 0, 0, time.UTC),
+			},
+		},
+		{
+			// never matches
+			expr: "0 0 30 2 *",
+		},
+		{
+			expr: "* * * *",
+			err:  "expected 5 or 6 fields, found 4",
+		},
+		{
+			expr: "0 0 32 * *",
+			err:  "invalid value '32' of day of month",
+		},
+		{
+			expr: "0 0 * * 1-8",
+			err:  "invalid value '8' of day of week",
+		},
+		{
+			expr: "0 10-2 * * *",
+			err:  "invalid range '10-2' of hour",
+		},
+		{
+			expr: "*/0 * * * *",
+			err:  "invalid step '0' of minute",
+		},
+		{
+			expr: "CRON_TZ=Invalid/Zone 0 2 * * *",
+			err:  "invalid time zone 'Invalid/Zone'",
+		},
+	}
+
+	for _, c := range cases {
+		p, err := NewSchedCronPolicy(c.expr)
+		if c.err != "" {
+			require.EqualError(t, err, fmt.Sprintf("invalid schedule event expr '%s': %s", c.expr, c.err))
+			continue
+		}
+		require.NoError(t, err, c.expr)
+		tm, ok := p.NextEventTime(watermark)
+		if len(c.next) == 0 {
+			require.False(t, ok, c.expr)
+			continue
+		}
+		for _, next := range c.next {
+			require.True(t, ok, c.expr)
+			require.True(t, next.Equal(tm), "expr: %s, expected: %s, got: %s", c.expr, next, tm)
+			tm, ok = p.NextEventTime(tm)
+		}
+	}
+
+	// The expression is in the local time zone by default.
+	p, err := NewSchedCronPolicy("0 2 * * *")
+	require.NoError(t, err)
+	require.Same(t, time.Local, p.schedule.loc)
+
+	// The zero watermark indicates to schedule from now.
+	p, err = NewSchedCronPolicy("CRON_TZ=UTC 0 * * * *")
+	require.NoError(t, err)
+	p.nowFunc = func() time.Time {
+		return watermark
+	}
+	tm, ok := p.NextEventTime(time.Time{})
+	require.True(t, ok)
+	require.Equal(t, time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC), tm)
+}
+
+func TestAtPolicy(t *testing.T) {
+	at := time.Date(2023, 6, 1, 2, 0, 0, 0, time.UTC)
+	p, err := NewSchedAtPolicy("2023-06-01T10:00:00+08:00")
+	require.NoError(t, err)
+
+	tm, ok := p.NextEventTime(time.Time{})
+	require.True(t, ok)
+	require.True(t, at.Equal(tm))
+	tm, ok = p.NextEventTime(at.Add(-time.Second))
+	require.True(t, ok)
+	require.True(t, at.Equal(tm))
+	_, ok = p.NextEventTime(at)
+	require.False(t, ok)
+	_, ok = p.NextEventTime(at.Add(time.Hour))
+	require.False(t, ok)
+
+	_, err = NewSchedAtPolicy("2023-06-01 10:00:00")
+	require.ErrorContains(t, err, "invalid schedule event expr '2023-06-01 10:00:00'")
+}
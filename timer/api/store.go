@@ -168,6 +168,8 @@ type TimerUpdate struct {
 	Tags OptionalVal[[]string]
 	// Enable indicates to set the timer's `Enable` field.
 	Enable OptionalVal[bool]
+	// Data indicates to set the timer's `Data` field.
+	Data OptionalVal[[]byte]
 	// SchedPolicyType indicates to set the timer's `SchedPolicyType` field.
 	SchedPolicyType OptionalVal[SchedPolicyType]
 	// SchedPolicyExpr indicates to set the timer's `SchedPolicyExpr` field.
@@ -216,6 +218,10 @@ func (u *TimerUpdate) Apply(record *TimerRecord) (*TimerRecord, error) {
 		record.Enable = v
 	}
 
+	if v, ok := u.Data.Get(); ok {
+		record.Data = v
+	}
+
 	if v, ok := u.SchedPolicyType.Get(); ok {
 		record.SchedPolicyType = v
 	}
@@ -244,6 +244,7 @@ func TestTimerUpdate(t *testing.T) {
 	now := time.Now()
 	update = &TimerUpdate{
 		Enable:          NewOptionalVal(true),
+		Data:            NewOptionalVal([]byte("data1")),
 		SchedPolicyType: NewOptionalVal(SchedEventInterval),
 		SchedPolicyExpr: NewOptionalVal("5h"),
 		Watermark:       NewOptionalVal(now),
@@ -270,6 +271,7 @@ func TestTimerUpdate(t *testing.T) {
 	record, err := update.Apply(tm)
 	require.NoError(t, err)
 	require.True(t, record.Enable)
+	require.Equal(t, []byte("data1"), record.Data)
 	require.Equal(t, SchedEventInterval, record.SchedPolicyType)
 	require.Equal(t, "5h", record.SchedPolicyExpr)
 	require.Equal(t, now, record.Watermark)
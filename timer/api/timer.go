@@ -27,6 +27,10 @@ type SchedPolicyType string
 const (
 	// SchedEventInterval indicates to schedule events every fixed interval.
 	SchedEventInterval SchedPolicyType = "INTERVAL"
+	// SchedEventCron indicates to schedule events by a cron expression.
+	SchedEventCron SchedPolicyType = "CRON"
+	// SchedEventAt indicates to schedule an event only once at a specified time.
+	SchedEventAt SchedPolicyType = "AT"
 )
 
 // SchedEventPolicy is an interface to tell the runtime how to schedule a timer's events.
@@ -64,6 +68,37 @@ func (p *SchedIntervalPolicy) NextEventTime(watermark time.Time) (time.Time, boo
 	return watermark.Add(p.interval), true
 }
 
+// SchedAtPolicy implements SchedEventPolicy, it is the policy of type `SchedEventAt`.
+type SchedAtPolicy struct {
+	expr string
+	at   time.Time
+}
+
+// NewSchedAtPolicy creates a new SchedAtPolicy, the expr is a time in RFC3339 format, such as
+// "2023-06-01T02:00:00+08:00".
+func NewSchedAtPolicy(expr string) (*SchedAtPolicy, error) {
+	at, err := time.Parse(time.RFC3339, expr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule event expr '%s'", expr)
+	}
+
+	return &SchedAtPolicy{
+		expr: expr,
+		// The watermark is persisted in seconds, so is the time to trigger the event.
+		at: at.Truncate(time.Second),
+	}, nil
+}
+
+// NextEventTime returns the next time of the timer event.
+// The only event should be triggered at the specified time, and there is no more event once the watermark
+// reaches it.
+func (p *SchedAtPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
+	if !watermark.Before(p.at) {
+		return time.Time{}, false
+	}
+	return p.at, true
+}
+
 // ManualRequest is the request info to trigger timer manually.
 type ManualRequest struct {
 	// ManualRequestID is the id of manual request.
@@ -161,6 +196,10 @@ func CreateSchedEventPolicy(tp SchedPolicyType, expr string) (SchedEventPolicy,
 	switch tp {
 	case SchedEventInterval:
 		return NewSchedIntervalPolicy(expr)
+	case SchedEventCron:
+		return NewSchedCronPolicy(expr)
+	case SchedEventAt:
+		return NewSchedAtPolicy(expr)
 	default:
 		return nil, errors.Errorf("invalid schedule event type: '%s'", tp)
 	}
@@ -45,4 +45,18 @@ func TestTimerValidate(t *testing.T) {
 
 	record.SchedPolicyExpr = "1h"
 	require.Nil(t, record.Validate())
+
+	record.SchedPolicyType = SchedEventCron
+	err = record.Validate()
+	require.EqualError(t, err, "schedule event configuration is not valid: invalid schedule event expr '1h': expected 5 or 6 fields, found 1")
+
+	record.SchedPolicyExpr = "CRON_TZ=UTC 0 2 * * *"
+	require.Nil(t, record.Validate())
+
+	record.SchedPolicyType = SchedEventAt
+	err = record.Validate()
+	require.ErrorContains(t, err, "schedule event configuration is not valid: invalid schedule event expr 'CRON_TZ=UTC 0 2 * * *'")
+
+	record.SchedPolicyExpr = "2023-06-01T02:00:00Z"
+	require.Nil(t, record.Validate())
 }
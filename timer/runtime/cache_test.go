@@ -164,6 +164,39 @@ func TestCacheUpdate(t *testing.T) {
 	checkSortedCache(t, cache, [][]any{{t1, now}})
 }
 
+func TestCacheUpdateWithSchedPolicies(t *testing.T) {
+	now := time.Date(2023, 6, 1, 10, 30, 0, 0, time.UTC)
+	cache := newTimersCache()
+	cache.nowFunc = func() time.Time {
+		return now
+	}
+
+	// cron policy
+	t1 := newTestTimer("t1", "CRON_TZ=UTC 0 2 * * *", now)
+	t1.SchedPolicyType = api.SchedEventCron
+	require.True(t, cache.updateTimer(t1))
+	checkSortedCache(t, cache, [][]any{{t1, time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC)}})
+
+	// at policy
+	t2 := newTestTimer("t2", "2023-06-01T11:00:00Z", now)
+	t2.SchedPolicyType = api.SchedEventAt
+	require.True(t, cache.updateTimer(t2))
+	checkSortedCache(t, cache, [][]any{
+		{t2, time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC)},
+		{t1, time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC)},
+	})
+
+	// no more event after the watermark reaches the time of the at policy
+	t2.Watermark = time.Date(2023, 6, 1, 11, 0, 0, 0, time.UTC)
+	t2.Version++
+	require.True(t, cache.updateTimer(t2))
+	require.Nil(t, cache.items[t2.ID].nextEventTime)
+	checkSortedCache(t, cache, [][]any{
+		{t1, time.Date(2023, 6, 2, 2, 0, 0, 0, time.UTC)},
+		{t2, time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)},
+	})
+}
+
 func TestCacheSort(t *testing.T) {
 	now := time.Now()
 	nowFunc := func() time.Time {
@@ -367,11 +400,11 @@ func checkSortedCache(t *testing.T, cache *timersCache, sorted [][]any) {
 			require.Equal(t, tryTriggerTime, *nextEventTime)
 		} else {
 			if p, err := timer.CreateSchedEventPolicy(); err == nil && timer.Enable {
-				require.NotNil(t, nextEventTime)
 				tm, ok := p.NextEventTime(timer.Watermark)
 				if !ok {
 					require.Nil(t, nextEventTime)
 				} else {
+					require.NotNil(t, nextEventTime)
 					require.Equal(t, tm, *nextEventTime)
 				}
 			} else {
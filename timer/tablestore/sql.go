@@ -396,6 +396,11 @@ func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, er
 		args = append(args, val)
 	}
 
+	if val, ok := update.Data.Get(); ok {
+		updateFields = append(updateFields, "TIMER_DATA = %?")
+		args = append(args, val)
+	}
+
 	extFields := make(map[string]any)
 	if val, ok := update.Tags.Get(); ok {
 		if len(val) == 0 {
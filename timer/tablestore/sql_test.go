@@ -414,6 +414,7 @@ func TestBuildUpdateCriteria(t *testing.T) {
 		{
 			update: &api.TimerUpdate{
 				Enable:          api.NewOptionalVal(false),
+				Data:            api.NewOptionalVal([]byte("timerdata")),
 				Tags:            api.NewOptionalVal([]string{"l1", "l2"}),
 				SchedPolicyType: api.NewOptionalVal(api.SchedEventInterval),
 				SchedPolicyExpr: api.NewOptionalVal("1h"),
@@ -437,13 +438,13 @@ func TestBuildUpdateCriteria(t *testing.T) {
 				CheckEventID: api.NewOptionalVal("ee"),
 				CheckVersion: api.NewOptionalVal(uint64(1)),
 			},
-			criteria: "ENABLE = %?, SCHED_POLICY_TYPE = %?, SCHED_POLICY_EXPR = %?, EVENT_STATUS = %?, " +
+			criteria: "ENABLE = %?, TIMER_DATA = %?, SCHED_POLICY_TYPE = %?, SCHED_POLICY_EXPR = %?, EVENT_STATUS = %?, " +
 				"EVENT_ID = %?, EVENT_DATA = %?, EVENT_START = FROM_UNIXTIME(%?), " +
 				"WATERMARK = FROM_UNIXTIME(%?), SUMMARY_DATA = %?, " +
 				"TIMER_EXT = JSON_MERGE_PATCH(TIMER_EXT, %?), " +
 				"VERSION = VERSION + 1",
 			args: []any{
-				false, "INTERVAL", "1h", "TRIGGER", "event1", []byte("data1"), now.Unix(),
+				false, []byte("timerdata"), "INTERVAL", "1h", "TRIGGER", "event1", []byte("data1"), now.Unix(),
 				now.Unix() + 1, []byte("summary"),
 				json.RawMessage(`{` +
 					`"event":{"manual_request_id":"req2","watermark_unix":456},` +
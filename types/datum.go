@@ -939,6 +939,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1045,6 +1047,12 @@ func (d *Datum) convertToString(sc *stmtctx.StatementContext, target *FieldType)
 			s, err = d.GetBinaryStringDecoded(sc, target.GetCharset())
 		} else if toBinary {
 			s = d.GetBinaryStringEncoded()
+		} else if strings.HasPrefix(d.Collation(), charset.CharsetLatin1) &&
+			target.GetCharset() != charset.CharsetLatin1 && charset.IsSupportedEncoding(target.GetCharset()) {
+			// The latin1 value may be written by a latin1 client, decode it before converting to the other charsets.
+			var decoded Datum
+			decoded.SetBytesAsString(charset.DecodeLatin1(d.GetBytes()), d.Collation(), 0)
+			s, err = decoded.GetStringWithCheck(sc, target.GetCharset())
 		} else {
 			s, err = d.GetStringWithCheck(sc, target.GetCharset())
 		}
@@ -1686,6 +1694,24 @@ func (d *Datum) convertToMysqlSet(sc *stmtctx.StatementContext, target *FieldTyp
 	return ret, err
 }
 
+// convertToGeometry checks whether the value is a geometry of the target type in the stored format, the
+// geometry is re-encoded so its WKB is always in little-endian byte order.
+func (d *Datum) convertToGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes, KindBinaryLiteral:
+		g, err := DecodeGeometry(d.GetBytes())
+		if err != nil {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		if tp := target.GetGeometryType(); tp != GeometryTypeGeometry && tp != g.Type {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		ret.SetBytes(g.Encode())
+		return ret, nil
+	}
+	return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+}
+
 func (d *Datum) convertToMysqlJSON(_ *stmtctx.StatementContext, _ *FieldType) (ret Datum, err error) {
 	switch d.k {
 	case KindString, KindBytes:
@@ -0,0 +1,1293 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	ast "github.com/pingcap/tidb/parser/types"
+)
+
+// GeometryType is the type of a geometry value.
+type GeometryType = ast.GeometryType
+
+const (
+	// GeometryTypeGeometry represents any geometry.
+	GeometryTypeGeometry = ast.GeometryTypeGeometry
+	// GeometryTypePoint represents a Point.
+	GeometryTypePoint = ast.GeometryTypePoint
+	// GeometryTypeLineString represents a LineString.
+	GeometryTypeLineString = ast.GeometryTypeLineString
+	// GeometryTypePolygon represents a Polygon.
+	GeometryTypePolygon = ast.GeometryTypePolygon
+	// GeometryTypeMultiPoint represents a MultiPoint.
+	GeometryTypeMultiPoint = ast.GeometryTypeMultiPoint
+	// GeometryTypeMultiLineString represents a MultiLineString.
+	GeometryTypeMultiLineString = ast.GeometryTypeMultiLineString
+	// GeometryTypeMultiPolygon represents a MultiPolygon.
+	GeometryTypeMultiPolygon = ast.GeometryTypeMultiPolygon
+	// GeometryTypeGeometryCollection represents a GeometryCollection.
+	GeometryTypeGeometryCollection = ast.GeometryTypeGeometryCollection
+)
+
+// GeoPoint is a point of a geometry value.
+type GeoPoint struct {
+	X, Y float64
+}
+
+// Geometry is a spatial value. It's stored in the same format as MySQL, which is a 4-byte little-endian SRID
+// followed by the WKB of the geometry. The coordinates are always computed in a flat plane, the SRID is kept
+// but its spatial reference system is not interpreted.
+type Geometry struct {
+	Type GeometryType
+	SRID uint32
+	// Points are the coordinates of a Point or a LineString.
+	Points []GeoPoint
+	// Rings are the rings of a Polygon, the first one is the exterior ring and the others are holes.
+	Rings [][]GeoPoint
+	// Geoms are the elements of a MultiPoint, MultiLineString, MultiPolygon or GeometryCollection.
+	Geoms []*Geometry
+}
+
+const (
+	geometrySRIDLen          = 4
+	wkbByteOrderBigEndian    = 0
+	wkbByteOrderLittleEndian = 1
+	wkbPointLen              = 16
+	// maxGeometryDepth is the max depth of nested geometry collections.
+	maxGeometryDepth = 64
+)
+
+var (
+	errInvalidWKB      = errors.New("invalid WKB")
+	errInvalidWKT      = errors.New("invalid WKT")
+	errInvalidGeoJSON  = errors.New("invalid GeoJSON")
+	errGeometryTooDeep = errors.New("geometry collections are nested too deep")
+)
+
+// NewPointGeometry creates a Point.
+func NewPointGeometry(x, y float64) *Geometry {
+	return &Geometry{Type: ast.GeometryTypePoint, Points: []GeoPoint{{x, y}}}
+}
+
+// NewGeometry creates a LineString or a Polygon from the points of its elements, or a MultiPoint,
+// MultiLineString, MultiPolygon or GeometryCollection from its elements, and checks whether it's valid.
+func NewGeometry(tp GeometryType, elems []*Geometry) (*Geometry, error) {
+	g := &Geometry{Type: tp}
+	switch tp {
+	case ast.GeometryTypeLineString:
+		for _, elem := range elems {
+			if elem.Type != ast.GeometryTypePoint {
+				return nil, errors.Errorf("invalid element %s of %s", elem.Type, tp)
+			}
+			g.Points = append(g.Points, elem.Points[0])
+		}
+	case ast.GeometryTypePolygon:
+		for _, elem := range elems {
+			if elem.Type != ast.GeometryTypeLineString {
+				return nil, errors.Errorf("invalid element %s of %s", elem.Type, tp)
+			}
+			g.Rings = append(g.Rings, elem.Points)
+		}
+	default:
+		g.Geoms = elems
+	}
+	if err := g.validate(0); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// validate checks the structure of the geometry.
+func (g *Geometry) validate(depth int) error {
+	if depth > maxGeometryDepth {
+		return errGeometryTooDeep
+	}
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		if len(g.Points) != 1 {
+			return errors.New("a point must have exactly one coordinate")
+		}
+		return validateGeoPoints(g.Points)
+	case ast.GeometryTypeLineString:
+		if len(g.Points) < 2 {
+			return errors.New("a linestring must have at least 2 points")
+		}
+		return validateGeoPoints(g.Points)
+	case ast.GeometryTypePolygon:
+		if len(g.Rings) == 0 {
+			return errors.New("a polygon must have at least one ring")
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return errors.New("a polygon ring must be closed and have at least 4 points")
+			}
+			if err := validateGeoPoints(ring); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ast.GeometryTypeMultiPoint, ast.GeometryTypeMultiLineString, ast.GeometryTypeMultiPolygon:
+		if len(g.Geoms) == 0 {
+			return errors.Errorf("a %s must have at least one element", g.Type)
+		}
+		elemType := g.Type - ast.GeometryTypeMultiPoint + ast.GeometryTypePoint
+		for _, elem := range g.Geoms {
+			if elem.Type != elemType {
+				return errors.Errorf("invalid element %s of %s", elem.Type, g.Type)
+			}
+			if err := elem.validate(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ast.GeometryTypeGeometryCollection:
+		for _, elem := range g.Geoms {
+			if err := elem.validate(depth + 1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return errors.Errorf("unknown geometry type %d", g.Type)
+}
+
+func validateGeoPoints(points []GeoPoint) error {
+	for _, p := range points {
+		if math.IsNaN(p.X) || math.IsInf(p.X, 0) || math.IsNaN(p.Y) || math.IsInf(p.Y, 0) {
+			return errors.New("the coordinates must be finite numbers")
+		}
+	}
+	return nil
+}
+
+// DecodeGeometry decodes a geometry from its stored format.
+func DecodeGeometry(data []byte) (*Geometry, error) {
+	if len(data) < geometrySRIDLen {
+		return nil, errInvalidWKB
+	}
+	g, err := ParseGeometryWKB(data[geometrySRIDLen:])
+	if err != nil {
+		return nil, err
+	}
+	g.SRID = binary.LittleEndian.Uint32(data)
+	return g, nil
+}
+
+// Encode encodes the geometry to its stored format.
+func (g *Geometry) Encode() []byte {
+	buf := binary.LittleEndian.AppendUint32(nil, g.SRID)
+	return g.appendWKB(buf)
+}
+
+// ParseGeometryWKB parses a geometry from its WKB, the SRID of the result is 0.
+func ParseGeometryWKB(wkb []byte) (*Geometry, error) {
+	r := wkbReader{data: wkb}
+	g, err := r.readGeometry(0)
+	if err != nil {
+		return nil, err
+	}
+	if r.pos != len(r.data) {
+		return nil, errInvalidWKB
+	}
+	if err = g.validate(0); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// WKB returns the WKB of the geometry in little-endian byte order.
+func (g *Geometry) WKB() []byte {
+	return g.appendWKB(nil)
+}
+
+func (g *Geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, wkbByteOrderLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Type))
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		buf = appendWKBPoint(buf, g.Points[0])
+	case ast.GeometryTypeLineString:
+		buf = appendWKBPoints(buf, g.Points)
+	case ast.GeometryTypePolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendWKBPoints(buf, ring)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Geoms)))
+		for _, elem := range g.Geoms {
+			buf = elem.appendWKB(buf)
+		}
+	}
+	return buf
+}
+
+func appendWKBPoint(buf []byte, p GeoPoint) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(buf []byte, points []GeoPoint) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+type wkbReader struct {
+	data []byte
+	pos  int
+}
+
+func (r *wkbReader) readGeometry(depth int) (*Geometry, error) {
+	if depth > maxGeometryDepth {
+		return nil, errGeometryTooDeep
+	}
+	if r.pos >= len(r.data) {
+		return nil, errInvalidWKB
+	}
+	var order binary.ByteOrder
+	switch r.data[r.pos] {
+	case wkbByteOrderBigEndian:
+		order = binary.BigEndian
+	case wkbByteOrderLittleEndian:
+		order = binary.LittleEndian
+	default:
+		return nil, errInvalidWKB
+	}
+	r.pos++
+	tp, err := r.readUint32(order)
+	if err != nil {
+		return nil, err
+	}
+	if tp < uint32(ast.GeometryTypePoint) || tp > uint32(ast.GeometryTypeGeometryCollection) {
+		return nil, errInvalidWKB
+	}
+
+	g := &Geometry{Type: GeometryType(tp)}
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		p, err := r.readPoint(order)
+		if err != nil {
+			return nil, err
+		}
+		g.Points = []GeoPoint{p}
+	case ast.GeometryTypeLineString:
+		if g.Points, err = r.readPoints(order); err != nil {
+			return nil, err
+		}
+	case ast.GeometryTypePolygon:
+		n, err := r.readCount(order, 4)
+		if err != nil {
+			return nil, err
+		}
+		g.Rings = make([][]GeoPoint, n)
+		for i := range g.Rings {
+			if g.Rings[i], err = r.readPoints(order); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		// Every element has at least a byte order and a type.
+		n, err := r.readCount(order, 5)
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < n; i++ {
+			elem, err := r.readGeometry(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			g.Geoms = append(g.Geoms, elem)
+		}
+	}
+	return g, nil
+}
+
+func (r *wkbReader) readUint32(order binary.ByteOrder) (uint32, error) {
+	if r.pos+4 > len(r.data) {
+		return 0, errInvalidWKB
+	}
+	v := order.Uint32(r.data[r.pos:])
+	r.pos += 4
+	return v, nil
+}
+
+// readCount reads the number of elements, and checks whether there are enough bytes for them.
+func (r *wkbReader) readCount(order binary.ByteOrder, minElemLen int) (int, error) {
+	n, err := r.readUint32(order)
+	if err != nil {
+		return 0, err
+	}
+	if uint64(n)*uint64(minElemLen) > uint64(len(r.data)-r.pos) {
+		return 0, errInvalidWKB
+	}
+	return int(n), nil
+}
+
+func (r *wkbReader) readPoint(order binary.ByteOrder) (GeoPoint, error) {
+	if r.pos+wkbPointLen > len(r.data) {
+		return GeoPoint{}, errInvalidWKB
+	}
+	p := GeoPoint{
+		X: math.Float64frombits(order.Uint64(r.data[r.pos:])),
+		Y: math.Float64frombits(order.Uint64(r.data[r.pos+8:])),
+	}
+	r.pos += wkbPointLen
+	return p, nil
+}
+
+func (r *wkbReader) readPoints(order binary.ByteOrder) ([]GeoPoint, error) {
+	n, err := r.readCount(order, wkbPointLen)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]GeoPoint, n)
+	for i := range points {
+		if points[i], err = r.readPoint(order); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// ParseGeometryWKT parses a geometry from its WKT, the SRID of the result is 0.
+func ParseGeometryWKT(wkt string) (*Geometry, error) {
+	l := wktLexer{s: wkt}
+	g, err := l.parseGeometry(0)
+	if err != nil {
+		return nil, err
+	}
+	l.skipSpaces()
+	if l.pos != len(l.s) {
+		return nil, errInvalidWKT
+	}
+	if err = g.validate(0); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+type wktLexer struct {
+	s   string
+	pos int
+}
+
+func (l *wktLexer) skipSpaces() {
+	for l.pos < len(l.s) && strings.IndexByte(" \t\r\n", l.s[l.pos]) >= 0 {
+		l.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end.
+func (l *wktLexer) peek() byte {
+	l.skipSpaces()
+	if l.pos >= len(l.s) {
+		return 0
+	}
+	return l.s[l.pos]
+}
+
+func (l *wktLexer) consume(c byte) bool {
+	if l.peek() != c {
+		return false
+	}
+	l.pos++
+	return true
+}
+
+func (l *wktLexer) expect(c byte) error {
+	if !l.consume(c) {
+		return errInvalidWKT
+	}
+	return nil
+}
+
+func (l *wktLexer) word() string {
+	l.skipSpaces()
+	start := l.pos
+	for l.pos < len(l.s) && (l.s[l.pos] >= 'a' && l.s[l.pos] <= 'z' || l.s[l.pos] >= 'A' && l.s[l.pos] <= 'Z') {
+		l.pos++
+	}
+	return strings.ToUpper(l.s[start:l.pos])
+}
+
+func (l *wktLexer) number() (float64, error) {
+	l.skipSpaces()
+	start := l.pos
+	for l.pos < len(l.s) && strings.IndexByte("0123456789+-.eE", l.s[l.pos]) >= 0 {
+		l.pos++
+	}
+	v, err := strconv.ParseFloat(l.s[start:l.pos], 64)
+	if err != nil {
+		return 0, errInvalidWKT
+	}
+	return v, nil
+}
+
+func (l *wktLexer) point() (p GeoPoint, err error) {
+	if p.X, err = l.number(); err != nil {
+		return
+	}
+	p.Y, err = l.number()
+	return
+}
+
+// points parses `(x y, x y, ...)`.
+func (l *wktLexer) points() ([]GeoPoint, error) {
+	if err := l.expect('('); err != nil {
+		return nil, err
+	}
+	var points []GeoPoint
+	for {
+		p, err := l.point()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, p)
+		if !l.consume(',') {
+			break
+		}
+	}
+	return points, l.expect(')')
+}
+
+// rings parses `((x y, ...), (x y, ...), ...)`.
+func (l *wktLexer) rings() ([][]GeoPoint, error) {
+	if err := l.expect('('); err != nil {
+		return nil, err
+	}
+	var rings [][]GeoPoint
+	for {
+		ring, err := l.points()
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, ring)
+		if !l.consume(',') {
+			break
+		}
+	}
+	return rings, l.expect(')')
+}
+
+// elements parses the elements of a multi geometry or a collection, which are enclosed in parentheses and
+// separated by commas.
+func (l *wktLexer) elements(allowEmpty bool, parseElem func() (*Geometry, error)) ([]*Geometry, error) {
+	if err := l.expect('('); err != nil {
+		return nil, err
+	}
+	var elems []*Geometry
+	if allowEmpty && l.consume(')') {
+		return elems, nil
+	}
+	for {
+		elem, err := parseElem()
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+		if !l.consume(',') {
+			break
+		}
+	}
+	return elems, l.expect(')')
+}
+
+func (l *wktLexer) parseGeometry(depth int) (g *Geometry, err error) {
+	if depth > maxGeometryDepth {
+		return nil, errGeometryTooDeep
+	}
+	switch l.word() {
+	case "POINT":
+		g = &Geometry{Type: ast.GeometryTypePoint}
+		var p GeoPoint
+		if err = l.expect('('); err != nil {
+			return nil, err
+		}
+		if p, err = l.point(); err != nil {
+			return nil, err
+		}
+		g.Points = []GeoPoint{p}
+		err = l.expect(')')
+	case "LINESTRING":
+		g = &Geometry{Type: ast.GeometryTypeLineString}
+		g.Points, err = l.points()
+	case "POLYGON":
+		g = &Geometry{Type: ast.GeometryTypePolygon}
+		g.Rings, err = l.rings()
+	case "MULTIPOINT":
+		g = &Geometry{Type: ast.GeometryTypeMultiPoint}
+		// The points can be either `MULTIPOINT(0 0, 1 1)` or `MULTIPOINT((0 0), (1 1))`.
+		g.Geoms, err = l.elements(false, func() (*Geometry, error) {
+			parenthesized := l.consume('(')
+			p, err := l.point()
+			if err != nil {
+				return nil, err
+			}
+			if parenthesized {
+				if err = l.expect(')'); err != nil {
+					return nil, err
+				}
+			}
+			return NewPointGeometry(p.X, p.Y), nil
+		})
+	case "MULTILINESTRING":
+		g = &Geometry{Type: ast.GeometryTypeMultiLineString}
+		g.Geoms, err = l.elements(false, func() (*Geometry, error) {
+			points, err := l.points()
+			return &Geometry{Type: ast.GeometryTypeLineString, Points: points}, err
+		})
+	case "MULTIPOLYGON":
+		g = &Geometry{Type: ast.GeometryTypeMultiPolygon}
+		g.Geoms, err = l.elements(false, func() (*Geometry, error) {
+			rings, err := l.rings()
+			return &Geometry{Type: ast.GeometryTypePolygon, Rings: rings}, err
+		})
+	case "GEOMETRYCOLLECTION", "GEOMCOLLECTION":
+		g = &Geometry{Type: ast.GeometryTypeGeometryCollection}
+		if l.peek() != '(' {
+			if l.word() != "EMPTY" {
+				return nil, errInvalidWKT
+			}
+			return g, nil
+		}
+		g.Geoms, err = l.elements(true, func() (*Geometry, error) {
+			return l.parseGeometry(depth + 1)
+		})
+	default:
+		return nil, errInvalidWKT
+	}
+	if err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// TypeName returns the name of the geometry type in upper case, which is used by ST_GeometryType.
+func (g *Geometry) TypeName() string {
+	return strings.ToUpper(g.Type.String())
+}
+
+func (g *Geometry) wktName() string {
+	if g.Type == ast.GeometryTypeGeometryCollection {
+		return "GEOMETRYCOLLECTION"
+	}
+	return g.TypeName()
+}
+
+// WKT returns the WKT of the geometry.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder) {
+	sb.WriteString(g.wktName())
+	switch g.Type {
+	case ast.GeometryTypePoint, ast.GeometryTypeLineString:
+		writeWKTPoints(sb, g.Points)
+	case ast.GeometryTypePolygon:
+		writeWKTRings(sb, g.Rings)
+	case ast.GeometryTypeGeometryCollection:
+		if len(g.Geoms) == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteByte('(')
+		for i, elem := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			elem.writeWKT(sb)
+		}
+		sb.WriteByte(')')
+	default:
+		sb.WriteByte('(')
+		for i, elem := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			switch elem.Type {
+			case ast.GeometryTypePolygon:
+				writeWKTRings(sb, elem.Rings)
+			default:
+				writeWKTPoints(sb, elem.Points)
+			}
+		}
+		sb.WriteByte(')')
+	}
+}
+
+func writeWKTPoints(sb *strings.Builder, points []GeoPoint) {
+	sb.WriteByte('(')
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(formatGeoCoord(p.X))
+		sb.WriteByte(' ')
+		sb.WriteString(formatGeoCoord(p.Y))
+	}
+	sb.WriteByte(')')
+}
+
+func writeWKTRings(sb *strings.Builder, rings [][]GeoPoint) {
+	sb.WriteByte('(')
+	for i, ring := range rings {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeWKTPoints(sb, ring)
+	}
+	sb.WriteByte(')')
+}
+
+// formatGeoCoord formats a coordinate in the shortest representation, the exponent format is only used for
+// very large or very small numbers.
+func formatGeoCoord(f float64) string {
+	if abs := math.Abs(f); abs == 0 || abs >= 1e-5 && abs < 1e17 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	mantissa, exp, _ := strings.Cut(strconv.FormatFloat(f, 'e', -1, 64), "e")
+	e, _ := strconv.Atoi(exp)
+	return mantissa + "e" + strconv.Itoa(e)
+}
+
+// GeoJSON options of ST_AsGeoJSON.
+const (
+	GeoJSONOptionBoundingBox = 1 << iota
+	GeoJSONOptionShortCRS
+	GeoJSONOptionLongCRS
+)
+
+// GeoJSON returns the GeoJSON of the geometry. The coordinates are rounded to maxDecimalDigits, and the options
+// decide whether a bounding box or a CRS is added.
+func (g *Geometry) GeoJSON(maxDecimalDigits int, options int) (BinaryJSON, error) {
+	obj := g.geoJSONObject(maxDecimalDigits)
+	if options&GeoJSONOptionBoundingBox != 0 {
+		if minP, maxP, ok := g.MBR(); ok {
+			obj["bbox"] = []interface{}{
+				roundGeoCoord(minP.X, maxDecimalDigits), roundGeoCoord(minP.Y, maxDecimalDigits),
+				roundGeoCoord(maxP.X, maxDecimalDigits), roundGeoCoord(maxP.Y, maxDecimalDigits),
+			}
+		}
+	}
+	if g.SRID != 0 && options&(GeoJSONOptionShortCRS|GeoJSONOptionLongCRS) != 0 {
+		name := "EPSG:" + strconv.FormatUint(uint64(g.SRID), 10)
+		if options&GeoJSONOptionLongCRS != 0 {
+			name = "urn:ogc:def:crs:EPSG::" + strconv.FormatUint(uint64(g.SRID), 10)
+		}
+		obj["crs"] = map[string]interface{}{
+			"type":       "name",
+			"properties": map[string]interface{}{"name": name},
+		}
+	}
+	return CreateBinaryJSONWithCheck(obj)
+}
+
+var geoJSONTypeNames = []string{
+	ast.GeometryTypePoint:              "Point",
+	ast.GeometryTypeLineString:         "LineString",
+	ast.GeometryTypePolygon:            "Polygon",
+	ast.GeometryTypeMultiPoint:         "MultiPoint",
+	ast.GeometryTypeMultiLineString:    "MultiLineString",
+	ast.GeometryTypeMultiPolygon:       "MultiPolygon",
+	ast.GeometryTypeGeometryCollection: "GeometryCollection",
+}
+
+func (g *Geometry) geoJSONObject(digits int) map[string]interface{} {
+	obj := map[string]interface{}{"type": geoJSONTypeNames[g.Type]}
+	if g.Type == ast.GeometryTypeGeometryCollection {
+		geoms := make([]interface{}, 0, len(g.Geoms))
+		for _, elem := range g.Geoms {
+			geoms = append(geoms, elem.geoJSONObject(digits))
+		}
+		obj["geometries"] = geoms
+	} else {
+		obj["coordinates"] = g.geoJSONCoordinates(digits)
+	}
+	return obj
+}
+
+func (g *Geometry) geoJSONCoordinates(digits int) interface{} {
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		return geoJSONPoint(g.Points[0], digits)
+	case ast.GeometryTypeLineString:
+		return geoJSONPoints(g.Points, digits)
+	case ast.GeometryTypePolygon:
+		rings := make([]interface{}, 0, len(g.Rings))
+		for _, ring := range g.Rings {
+			rings = append(rings, geoJSONPoints(ring, digits))
+		}
+		return rings
+	}
+	elems := make([]interface{}, 0, len(g.Geoms))
+	for _, elem := range g.Geoms {
+		elems = append(elems, elem.geoJSONCoordinates(digits))
+	}
+	return elems
+}
+
+func geoJSONPoint(p GeoPoint, digits int) []interface{} {
+	return []interface{}{roundGeoCoord(p.X, digits), roundGeoCoord(p.Y, digits)}
+}
+
+func geoJSONPoints(points []GeoPoint, digits int) []interface{} {
+	res := make([]interface{}, 0, len(points))
+	for _, p := range points {
+		res = append(res, geoJSONPoint(p, digits))
+	}
+	return res
+}
+
+func roundGeoCoord(f float64, digits int) float64 {
+	// A float64 has no more than 17 significant digits, there is nothing to round.
+	if digits > 17 {
+		return f
+	}
+	p := math.Pow10(digits)
+	if r := math.Round(f*p) / p; !math.IsInf(r, 0) && !math.IsNaN(r) {
+		return r
+	}
+	return f
+}
+
+// ParseGeometryGeoJSON parses a geometry from a GeoJSON object, which can be a geometry, a Feature or a
+// FeatureCollection. The SRID of the result is 0. It returns nil if the geometry of a Feature is null.
+func ParseGeometryGeoJSON(data []byte) (*Geometry, error) {
+	var obj interface{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return nil, errInvalidGeoJSON
+	}
+	g, err := parseGeoJSONObject(obj, 0)
+	if err != nil || g == nil {
+		return nil, err
+	}
+	if err = g.validate(0); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+func parseGeoJSONObject(v interface{}, depth int) (*Geometry, error) {
+	if depth > maxGeometryDepth {
+		return nil, errGeometryTooDeep
+	}
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, errInvalidGeoJSON
+	}
+	tp, _ := obj["type"].(string)
+	switch tp {
+	case "Feature":
+		geom, ok := obj["geometry"]
+		if !ok {
+			return nil, errInvalidGeoJSON
+		}
+		if geom == nil {
+			return nil, nil
+		}
+		return parseGeoJSONObject(geom, depth)
+	case "FeatureCollection", "GeometryCollection":
+		member := "geometries"
+		if tp == "FeatureCollection" {
+			member = "features"
+		}
+		elems, ok := obj[member].([]interface{})
+		if !ok {
+			return nil, errInvalidGeoJSON
+		}
+		g := &Geometry{Type: ast.GeometryTypeGeometryCollection}
+		for _, elem := range elems {
+			child, err := parseGeoJSONObject(elem, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				g.Geoms = append(g.Geoms, child)
+			}
+		}
+		return g, nil
+	}
+
+	for gt, name := range geoJSONTypeNames {
+		if name == tp && name != "" {
+			g := &Geometry{Type: GeometryType(gt)}
+			return g, g.setGeoJSONCoordinates(obj["coordinates"])
+		}
+	}
+	return nil, errInvalidGeoJSON
+}
+
+func (g *Geometry) setGeoJSONCoordinates(v interface{}) (err error) {
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		p, err := parseGeoJSONPoint(v)
+		g.Points = []GeoPoint{p}
+		return err
+	case ast.GeometryTypeLineString:
+		g.Points, err = parseGeoJSONPoints(v)
+		return err
+	case ast.GeometryTypePolygon:
+		arr, ok := v.([]interface{})
+		if !ok {
+			return errInvalidGeoJSON
+		}
+		g.Rings = make([][]GeoPoint, len(arr))
+		for i, ring := range arr {
+			if g.Rings[i], err = parseGeoJSONPoints(ring); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	arr, ok := v.([]interface{})
+	if !ok {
+		return errInvalidGeoJSON
+	}
+	elemType := g.Type - ast.GeometryTypeMultiPoint + ast.GeometryTypePoint
+	g.Geoms = make([]*Geometry, len(arr))
+	for i, elem := range arr {
+		g.Geoms[i] = &Geometry{Type: elemType}
+		if err = g.Geoms[i].setGeoJSONCoordinates(elem); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseGeoJSONPoint(v interface{}) (GeoPoint, error) {
+	// The position may have more than 2 elements, the extra dimensions are ignored.
+	arr, ok := v.([]interface{})
+	if !ok || len(arr) < 2 {
+		return GeoPoint{}, errInvalidGeoJSON
+	}
+	x, ok1 := arr[0].(float64)
+	y, ok2 := arr[1].(float64)
+	if !ok1 || !ok2 {
+		return GeoPoint{}, errInvalidGeoJSON
+	}
+	return GeoPoint{x, y}, nil
+}
+
+func parseGeoJSONPoints(v interface{}) ([]GeoPoint, error) {
+	arr, ok := v.([]interface{})
+	if !ok {
+		return nil, errInvalidGeoJSON
+	}
+	points := make([]GeoPoint, len(arr))
+	for i, p := range arr {
+		var err error
+		if points[i], err = parseGeoJSONPoint(p); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// MBR returns the minimum bounding rectangle of the geometry, ok is false if the geometry is empty.
+func (g *Geometry) MBR() (minP, maxP GeoPoint, ok bool) {
+	minP = GeoPoint{math.Inf(1), math.Inf(1)}
+	maxP = GeoPoint{math.Inf(-1), math.Inf(-1)}
+	g.walkPoints(func(p GeoPoint) {
+		minP.X, minP.Y = math.Min(minP.X, p.X), math.Min(minP.Y, p.Y)
+		maxP.X, maxP.Y = math.Max(maxP.X, p.X), math.Max(maxP.Y, p.Y)
+		ok = true
+	})
+	return
+}
+
+func (g *Geometry) walkPoints(fn func(GeoPoint)) {
+	for _, p := range g.Points {
+		fn(p)
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			fn(p)
+		}
+	}
+	for _, elem := range g.Geoms {
+		elem.walkPoints(fn)
+	}
+}
+
+// primitives returns the points, linestrings and polygons in the geometry.
+func (g *Geometry) primitives() []*Geometry {
+	switch g.Type {
+	case ast.GeometryTypePoint, ast.GeometryTypeLineString, ast.GeometryTypePolygon:
+		return []*Geometry{g}
+	}
+	var res []*Geometry
+	for _, elem := range g.Geoms {
+		res = append(res, elem.primitives()...)
+	}
+	return res
+}
+
+// segments returns the segments of the boundary of a primitive, a point is a segment of zero length.
+func (g *Geometry) segments() [][2]GeoPoint {
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		return [][2]GeoPoint{{g.Points[0], g.Points[0]}}
+	case ast.GeometryTypeLineString:
+		return pathSegments(nil, g.Points)
+	}
+	var res [][2]GeoPoint
+	for _, ring := range g.Rings {
+		res = pathSegments(res, ring)
+	}
+	return res
+}
+
+func pathSegments(res [][2]GeoPoint, points []GeoPoint) [][2]GeoPoint {
+	for i := 1; i < len(points); i++ {
+		res = append(res, [2]GeoPoint{points[i-1], points[i]})
+	}
+	return res
+}
+
+// The location of a point relative to a geometry.
+const (
+	geoExterior = iota
+	geoBoundary
+	geoInterior
+)
+
+func cross(o, a, b GeoPoint) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+func onSegment(p, a, b GeoPoint) bool {
+	return cross(a, b, p) == 0 &&
+		math.Min(a.X, b.X) <= p.X && p.X <= math.Max(a.X, b.X) &&
+		math.Min(a.Y, b.Y) <= p.Y && p.Y <= math.Max(a.Y, b.Y)
+}
+
+func sign(f float64) int {
+	switch {
+	case f > 0:
+		return 1
+	case f < 0:
+		return -1
+	}
+	return 0
+}
+
+// segmentsIntersect returns whether segment ab and segment cd have any common point.
+func segmentsIntersect(a, b, c, d GeoPoint) bool {
+	d1, d2 := sign(cross(c, d, a)), sign(cross(c, d, b))
+	d3, d4 := sign(cross(a, b, c)), sign(cross(a, b, d))
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return onSegment(a, c, d) || onSegment(b, c, d) || onSegment(c, a, b) || onSegment(d, a, b)
+}
+
+func locateInRing(p GeoPoint, ring []GeoPoint) int {
+	inside := false
+	for i := 1; i < len(ring); i++ {
+		a, b := ring[i-1], ring[i]
+		if onSegment(p, a, b) {
+			return geoBoundary
+		}
+		if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+			inside = !inside
+		}
+	}
+	if inside {
+		return geoInterior
+	}
+	return geoExterior
+}
+
+func locateInPolygon(p GeoPoint, rings [][]GeoPoint) int {
+	loc := locateInRing(p, rings[0])
+	if loc != geoInterior {
+		return loc
+	}
+	for _, hole := range rings[1:] {
+		switch locateInRing(p, hole) {
+		case geoInterior:
+			return geoExterior
+		case geoBoundary:
+			return geoBoundary
+		}
+	}
+	return geoInterior
+}
+
+func locateOnLine(p GeoPoint, points []GeoPoint) int {
+	for i := 1; i < len(points); i++ {
+		if onSegment(p, points[i-1], points[i]) {
+			// The end points of an open linestring are its boundary.
+			first, last := points[0], points[len(points)-1]
+			if first != last && (p == first || p == last) {
+				return geoBoundary
+			}
+			return geoInterior
+		}
+	}
+	return geoExterior
+}
+
+// locate returns the location of a point relative to a primitive.
+func (g *Geometry) locate(p GeoPoint) int {
+	switch g.Type {
+	case ast.GeometryTypePoint:
+		if g.Points[0] == p {
+			return geoInterior
+		}
+		return geoExterior
+	case ast.GeometryTypeLineString:
+		return locateOnLine(p, g.Points)
+	}
+	return locateInPolygon(p, g.Rings)
+}
+
+// Intersects returns whether the two geometries have any common point.
+func (g *Geometry) Intersects(other *Geometry) bool {
+	for _, a := range g.primitives() {
+		for _, b := range other.primitives() {
+			if primitivesIntersect(a, b) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func primitivesIntersect(a, b *Geometry) bool {
+	for _, s1 := range a.segments() {
+		for _, s2 := range b.segments() {
+			if segmentsIntersect(s1[0], s1[1], s2[0], s2[1]) {
+				return true
+			}
+		}
+	}
+	// The boundaries don't intersect, but a polygon may contain the other geometry.
+	if a.Type == ast.GeometryTypePolygon && a.locate(b.firstPoint()) != geoExterior {
+		return true
+	}
+	return b.Type == ast.GeometryTypePolygon && b.locate(a.firstPoint()) != geoExterior
+}
+
+func (g *Geometry) firstPoint() GeoPoint {
+	if g.Type == ast.GeometryTypePolygon {
+		return g.Rings[0][0]
+	}
+	return g.Points[0]
+}
+
+// Contains returns whether the geometry contains the other one, that is, no points of the other geometry
+// lie in the exterior of the geometry, and at least one point of the interior of the other geometry lies
+// in the interior of the geometry. Every element of the other geometry must be covered by a single element of
+// the geometry.
+func (g *Geometry) Contains(other *Geometry) bool {
+	as, bs := g.primitives(), other.primitives()
+	if len(as) == 0 || len(bs) == 0 {
+		return false
+	}
+	hasInterior := false
+	for _, b := range bs {
+		covered := false
+		for _, a := range as {
+			var interior bool
+			if covered, interior = covers(a, b); covered {
+				hasInterior = hasInterior || interior
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+	return hasInterior
+}
+
+// covers returns whether no points of primitive b lie in the exterior of primitive a, and whether any point of b
+// lies in the interior of a.
+func covers(a, b *Geometry) (covered, interior bool) {
+	if b.Type == ast.GeometryTypePoint {
+		loc := a.locate(b.Points[0])
+		return loc != geoExterior, loc == geoInterior
+	}
+	switch a.Type {
+	case ast.GeometryTypeLineString:
+		if b.Type != ast.GeometryTypeLineString {
+			return false, false
+		}
+		for _, s := range b.segments() {
+			if !lineCoversSegment(a.Points, s[0], s[1]) {
+				return false, false
+			}
+		}
+		// A segment of positive length covered by a linestring always has points in its interior.
+		return true, true
+	case ast.GeometryTypePolygon:
+		for _, s := range b.segments() {
+			segCovered, segInterior := polygonCoversSegment(a.Rings, s[0], s[1])
+			if !segCovered {
+				return false, false
+			}
+			interior = interior || segInterior
+		}
+		if b.Type == ast.GeometryTypePolygon {
+			// The boundary of b is covered, but b still isn't covered if a hole of a lies inside b.
+			for _, hole := range a.Rings[1:] {
+				for _, p := range hole {
+					if locateInPolygon(p, b.Rings) == geoInterior {
+						return false, false
+					}
+				}
+			}
+			interior = true
+		}
+		return true, interior
+	}
+	return false, false
+}
+
+// segmentParam returns the parameter t of point p on segment ab, where p = a + t * (b - a).
+func segmentParam(p, a, b GeoPoint) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if math.Abs(dx) >= math.Abs(dy) {
+		return (p.X - a.X) / dx
+	}
+	return (p.Y - a.Y) / dy
+}
+
+func lineCoversSegment(line []GeoPoint, p, q GeoPoint) bool {
+	if p == q {
+		return locateOnLine(p, line) != geoExterior
+	}
+	// Collect the ranges of the collinear segments of the line on segment pq, and check whether they
+	// cover [0, 1].
+	var ranges [][2]float64
+	for i := 1; i < len(line); i++ {
+		a, b := line[i-1], line[i]
+		if cross(p, q, a) != 0 || cross(p, q, b) != 0 {
+			continue
+		}
+		t1, t2 := segmentParam(a, p, q), segmentParam(b, p, q)
+		if t1 > t2 {
+			t1, t2 = t2, t1
+		}
+		ranges = append(ranges, [2]float64{t1, t2})
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	covered := 0.0
+	for _, r := range ranges {
+		if r[0] > covered {
+			return false
+		}
+		covered = math.Max(covered, r[1])
+		if covered >= 1 {
+			return true
+		}
+	}
+	return false
+}
+
+func polygonCoversSegment(rings [][]GeoPoint, p, q GeoPoint) (covered, interior bool) {
+	if p == q {
+		loc := locateInPolygon(p, rings)
+		return loc != geoExterior, loc == geoInterior
+	}
+	// Split the segment at its intersections with the boundary, every part is either inside or outside the
+	// polygon, and the location of a part is the location of its midpoint.
+	params := []float64{0, 1}
+	for _, ring := range rings {
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			if !segmentsIntersect(p, q, a, b) {
+				continue
+			}
+			for _, v := range []GeoPoint{a, b} {
+				if onSegment(v, p, q) {
+					params = append(params, segmentParam(v, p, q))
+				}
+			}
+			if cp, cq := cross(a, b, p), cross(a, b, q); cp != cq {
+				if t := cp / (cp - cq); t >= 0 && t <= 1 {
+					params = append(params, t)
+				}
+			}
+		}
+	}
+	sort.Float64s(params)
+	for i := 1; i < len(params); i++ {
+		if params[i] == params[i-1] {
+			continue
+		}
+		t := (params[i-1] + params[i]) / 2
+		mid := GeoPoint{p.X + t*(q.X-p.X), p.Y + t*(q.Y-p.Y)}
+		switch locateInPolygon(mid, rings) {
+		case geoExterior:
+			return false, false
+		case geoInterior:
+			interior = true
+		}
+	}
+	return true, interior
+}
+
+// Distance returns the minimum cartesian distance between the two geometries, ok is false if any of them is
+// empty.
+func (g *Geometry) Distance(other *Geometry) (dist float64, ok bool) {
+	dist = math.Inf(1)
+	for _, a := range g.primitives() {
+		for _, b := range other.primitives() {
+			ok = true
+			if primitivesIntersect(a, b) {
+				return 0, true
+			}
+			for _, s1 := range a.segments() {
+				for _, s2 := range b.segments() {
+					dist = math.Min(dist, segmentsDistance(s1[0], s1[1], s2[0], s2[1]))
+				}
+			}
+		}
+	}
+	return dist, ok
+}
+
+func pointSegmentDistance(p, a, b GeoPoint) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(a.X+t*dx), p.Y-(a.Y+t*dy))
+}
+
+// segmentsDistance returns the distance between two segments which don't intersect.
+func segmentsDistance(a, b, c, d GeoPoint) float64 {
+	return math.Min(
+		math.Min(pointSegmentDistance(a, c, d), pointSegmentDistance(b, c, d)),
+		math.Min(pointSegmentDistance(c, a, b), pointSegmentDistance(d, a, b)),
+	)
+}
@@ -0,0 +1,228 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/hex"
+	"testing"
+
+	ast "github.com/pingcap/tidb/parser/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeometryWKT(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		expected string
+		tp       GeometryType
+	}{
+		{"POINT(1 2)", "POINT(1 2)", ast.GeometryTypePoint},
+		{" point ( -1.5   2e3 ) ", "POINT(-1.5 2000)", ast.GeometryTypePoint},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)", ast.GeometryTypeLineString},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,3 2,3 3,2 2))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,3 2,3 3,2 2))", ast.GeometryTypePolygon},
+		{"MULTIPOINT(0 0, 1 1)", "MULTIPOINT((0 0),(1 1))", ast.GeometryTypeMultiPoint},
+		{"MULTIPOINT((0 0), (1 1))", "MULTIPOINT((0 0),(1 1))", ast.GeometryTypeMultiPoint},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))", ast.GeometryTypeMultiLineString},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((5 5,6 5,6 6,5 5)))", ast.GeometryTypeMultiPolygon},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1),GEOMETRYCOLLECTION EMPTY)", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1),GEOMETRYCOLLECTION EMPTY)", ast.GeometryTypeGeometryCollection},
+		{"GEOMCOLLECTION()", "GEOMETRYCOLLECTION EMPTY", ast.GeometryTypeGeometryCollection},
+		{"POINT(1e20 1e-7)", "POINT(1e20 1e-7)", ast.GeometryTypePoint},
+	}
+	for _, tt := range tests {
+		g, err := ParseGeometryWKT(tt.wkt)
+		require.NoError(t, err, tt.wkt)
+		require.Equal(t, tt.tp, g.Type, tt.wkt)
+		require.Equal(t, tt.expected, g.WKT(), tt.wkt)
+
+		// The geometry is the same after it's encoded and decoded.
+		g.SRID = 4326
+		decoded, err := DecodeGeometry(g.Encode())
+		require.NoError(t, err, tt.wkt)
+		require.Equal(t, g, decoded, tt.wkt)
+	}
+
+	for _, wkt := range []string{
+		"", "POINT", "POINT()", "POINT(1)", "POINT(1 2 3)", "POINT(1 2", "POINT(1 2) x", "POINT(a b)",
+		"LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1))", "POLYGON((0 0,1 0,1 1,0 1))", "MULTIPOINT()",
+		"GEOMETRYCOLLECTION", "CIRCLE(0 0)",
+	} {
+		_, err := ParseGeometryWKT(wkt)
+		require.Error(t, err, wkt)
+	}
+}
+
+func TestGeometryWKB(t *testing.T) {
+	// POINT(1 -1) in big-endian byte order.
+	wkb, err := hex.DecodeString("00000000013FF0000000000000BFF0000000000000")
+	require.NoError(t, err)
+	g, err := ParseGeometryWKB(wkb)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 -1)", g.WKT())
+	require.Equal(t, "0101000000000000000000f03f000000000000f0bf", hex.EncodeToString(g.WKB()))
+
+	g.SRID = 4326
+	require.Equal(t, "e61000000101000000000000000000f03f000000000000f0bf", hex.EncodeToString(g.Encode()))
+
+	for _, s := range []string{
+		"", "01", "0101000000000000000000F03F", "0201000000000000000000F03F000000000000F0BF",
+		"0109000000000000000000F03F000000000000F0BF", "0101000000000000000000F03F000000000000F0BF00",
+		// A linestring which claims to have 2^32-1 points.
+		"0102000000FFFFFFFF",
+	} {
+		wkb, err := hex.DecodeString(s)
+		require.NoError(t, err)
+		_, err = ParseGeometryWKB(wkb)
+		require.Error(t, err, s)
+	}
+	_, err = DecodeGeometry([]byte{1, 2})
+	require.Error(t, err)
+}
+
+func TestGeometryGeoJSON(t *testing.T) {
+	tests := []struct {
+		wkt      string
+		srid     uint32
+		digits   int
+		options  int
+		expected string
+	}{
+		{"POINT(1.2345 2)", 0, 20, 0, `{"type": "Point", "coordinates": [1.2345, 2]}`},
+		{"POINT(1.2345 2)", 0, 2, 0, `{"type": "Point", "coordinates": [1.23, 2]}`},
+		{"LINESTRING(0 0,1 2)", 4326, 20, GeoJSONOptionBoundingBox | GeoJSONOptionShortCRS,
+			`{"type": "LineString", "coordinates": [[0, 0], [1, 2]], "bbox": [0, 0, 1, 2], "crs": {"type": "name", "properties": {"name": "EPSG:4326"}}}`},
+		{"POLYGON((0 0,1 0,1 1,0 0))", 4326, 20, GeoJSONOptionLongCRS,
+			`{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]], "crs": {"type": "name", "properties": {"name": "urn:ogc:def:crs:EPSG::4326"}}}`},
+		{"MULTIPOINT(0 0,1 1)", 0, 20, GeoJSONOptionShortCRS, `{"type": "MultiPoint", "coordinates": [[0, 0], [1, 1]]}`},
+		{"GEOMETRYCOLLECTION(POINT(1 2),MULTILINESTRING((0 0,1 1)))", 0, 20, 0,
+			`{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [1, 2]}, {"type": "MultiLineString", "coordinates": [[[0, 0], [1, 1]]]}]}`},
+		{"GEOMETRYCOLLECTION EMPTY", 0, 20, GeoJSONOptionBoundingBox, `{"type": "GeometryCollection", "geometries": []}`},
+	}
+	for _, tt := range tests {
+		g, err := ParseGeometryWKT(tt.wkt)
+		require.NoError(t, err)
+		g.SRID = tt.srid
+		j, err := g.GeoJSON(tt.digits, tt.options)
+		require.NoError(t, err)
+		expected, err := ParseBinaryJSONFromString(tt.expected)
+		require.NoError(t, err)
+		require.Zero(t, CompareBinaryJSON(expected, j), "%s: %s", tt.wkt, j)
+
+		if tt.digits > 17 {
+			// The GeoJSON is parsed back to the same geometry.
+			parsed, err := ParseGeometryGeoJSON([]byte(j.String()))
+			require.NoError(t, err)
+			parsed.SRID = tt.srid
+			require.Equal(t, g.WKT(), parsed.WKT())
+		}
+	}
+
+	g, err := ParseGeometryGeoJSON([]byte(`{"type": "Feature", "geometry": {"type": "Point", "coordinates": [1, 2, 3]}, "properties": {}}`))
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	g, err = ParseGeometryGeoJSON([]byte(`{"type": "FeatureCollection", "features": [{"type": "Feature", "geometry": null}, {"type": "Feature", "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}]}`))
+	require.NoError(t, err)
+	require.Equal(t, "GEOMETRYCOLLECTION(LINESTRING(0 0,1 1))", g.WKT())
+	g, err = ParseGeometryGeoJSON([]byte(`{"type": "Feature", "geometry": null}`))
+	require.NoError(t, err)
+	require.Nil(t, g)
+
+	for _, s := range []string{
+		``, `[]`, `{"type": "Circle"}`, `{"type": "Point"}`, `{"type": "Point", "coordinates": [1]}`,
+		`{"type": "Point", "coordinates": ["1", 2]}`, `{"type": "LineString", "coordinates": [[0, 0]]}`,
+		`{"type": "GeometryCollection"}`, `{"type": "Feature"}`,
+	} {
+		_, err := ParseGeometryGeoJSON([]byte(s))
+		require.Error(t, err, s)
+	}
+}
+
+func TestGeometryRelations(t *testing.T) {
+	tests := []struct {
+		g1, g2     string
+		contains   bool
+		intersects bool
+		distance   float64
+	}{
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POINT(5 5)", true, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POINT(10 5)", false, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POINT(13 14)", false, false, 5},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", "POINT(5 5)", false, false, 1},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "LINESTRING(1 1,10 10)", true, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "LINESTRING(0 0,10 0)", false, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "LINESTRING(5 5,15 5)", false, true, 0},
+		// The concave polygon doesn't contain the segment between its two arms.
+		{"POLYGON((0 0,10 0,10 10,8 10,8 2,2 2,2 10,0 10,0 0))", "LINESTRING(1 5,9 5)", false, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POLYGON((1 1,2 1,2 2,1 2,1 1))", true, true, 0},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(4 4,6 4,6 6,4 6,4 4))", "POLYGON((1 1,9 1,9 9,1 9,1 1))", false, true, 0},
+		{"POLYGON((0 0,1 0,1 1,0 1,0 0))", "POLYGON((3 0,4 0,4 1,3 1,3 0))", false, false, 2},
+		{"LINESTRING(0 0,5 0,10 0)", "LINESTRING(2 0,7 0)", true, true, 0},
+		{"LINESTRING(0 0,5 0,10 0)", "LINESTRING(2 0,7 1)", false, true, 0},
+		{"LINESTRING(0 0,10 0)", "POINT(0 0)", false, true, 0},
+		{"LINESTRING(0 0,10 0)", "POINT(5 0)", true, true, 0},
+		{"LINESTRING(0 0,10 0)", "POINT(5 3)", false, false, 3},
+		{"LINESTRING(0 0,10 0)", "LINESTRING(0 1,10 2)", false, false, 1},
+		{"POINT(1 1)", "POINT(1 1)", true, true, 0},
+		{"POINT(1 1)", "POINT(4 5)", false, false, 5},
+		{"MULTIPOINT(1 1,2 2)", "POINT(2 2)", true, true, 0},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 1,0 0)),((5 5,6 5,6 6,5 6,5 5)))", "MULTIPOINT(0.5 0.5,5.5 5.5)", true, true, 0},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 1,0 0)),((5 5,6 5,6 6,5 6,5 5)))", "POINT(3 0.5)", false, false, 2},
+		{"GEOMETRYCOLLECTION(POINT(1 1),POLYGON((0 0,10 0,10 10,0 10,0 0)))", "POINT(3 3)", true, true, 0},
+	}
+	for _, tt := range tests {
+		g1, err := ParseGeometryWKT(tt.g1)
+		require.NoError(t, err)
+		g2, err := ParseGeometryWKT(tt.g2)
+		require.NoError(t, err)
+		require.Equal(t, tt.contains, g1.Contains(g2), "%s contains %s", tt.g1, tt.g2)
+		require.Equal(t, tt.intersects, g1.Intersects(g2), "%s intersects %s", tt.g1, tt.g2)
+		require.Equal(t, tt.intersects, g2.Intersects(g1), "%s intersects %s", tt.g2, tt.g1)
+		dist, ok := g1.Distance(g2)
+		require.True(t, ok)
+		require.InDelta(t, tt.distance, dist, 1e-9, "distance between %s and %s", tt.g1, tt.g2)
+	}
+
+	empty, err := ParseGeometryWKT("GEOMETRYCOLLECTION EMPTY")
+	require.NoError(t, err)
+	p := NewPointGeometry(1, 1)
+	require.False(t, empty.Contains(p))
+	require.False(t, p.Contains(empty))
+	require.False(t, p.Intersects(empty))
+	_, ok := p.Distance(empty)
+	require.False(t, ok)
+}
+
+func TestNewGeometry(t *testing.T) {
+	p1, p2, p3 := NewPointGeometry(0, 0), NewPointGeometry(1, 0), NewPointGeometry(1, 1)
+	line, err := NewGeometry(ast.GeometryTypeLineString, []*Geometry{p1, p2, p3})
+	require.NoError(t, err)
+	require.Equal(t, "LINESTRING(0 0,1 0,1 1)", line.WKT())
+	_, err = NewGeometry(ast.GeometryTypePolygon, []*Geometry{line})
+	require.Error(t, err)
+	ring, err := NewGeometry(ast.GeometryTypeLineString, []*Geometry{p1, p2, p3, p1})
+	require.NoError(t, err)
+	polygon, err := NewGeometry(ast.GeometryTypePolygon, []*Geometry{ring})
+	require.NoError(t, err)
+	require.Equal(t, "POLYGON((0 0,1 0,1 1,0 0))", polygon.WKT())
+	_, err = NewGeometry(ast.GeometryTypeMultiPoint, []*Geometry{p1, line})
+	require.Error(t, err)
+	collection, err := NewGeometry(ast.GeometryTypeGeometryCollection, []*Geometry{p1, line, polygon})
+	require.NoError(t, err)
+	require.Equal(t, "GEOMETRYCOLLECTION(POINT(0 0),LINESTRING(0 0,1 0,1 1),POLYGON((0 0,1 0,1 1,0 0)))", collection.WKT())
+	minP, maxP, ok := collection.MBR()
+	require.True(t, ok)
+	require.Equal(t, GeoPoint{0, 0}, minP)
+	require.Equal(t, GeoPoint{1, 1}, maxP)
+}
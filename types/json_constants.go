@@ -238,6 +238,12 @@ var (
 	ErrJSONObjectKeyTooLong = dbterror.ClassTypes.NewStdErr(mysql.ErrJSONObjectKeyTooLong, mysql.MySQLErrName[mysql.ErrJSONObjectKeyTooLong])
 	// ErrInvalidJSONPathArrayCell means invalid JSON path for an array cell.
 	ErrInvalidJSONPathArrayCell = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONPathArrayCell)
+	// ErrInvalidJSONType means the JSON argument is not of the required JSON type.
+	ErrInvalidJSONType = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONType)
+	// ErrInvalidJSONSchema means the JSON schema is invalid or unsupported.
+	ErrInvalidJSONSchema = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONSchema)
+	// ErrMissingJSONValue means no value is found by the path of json_value.
+	ErrMissingJSONValue = dbterror.ClassJSON.NewStd(mysql.ErrMissingJSONValue)
 	// ErrUnsupportedSecondArgumentType means unsupported second argument type in json_objectagg
 	ErrUnsupportedSecondArgumentType = dbterror.ClassJSON.NewStd(mysql.ErrUnsupportedSecondArgumentType)
 )
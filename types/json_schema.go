@@ -0,0 +1,739 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// jsonSchemaMaxDepth limits the nesting of the validation, so the schemas referencing themselves without consuming the
+// document, like `{"allOf": [{"$ref": "#"}]}`, can't recurse forever.
+const jsonSchemaMaxDepth = 1000
+
+// The types of the JSON Schema "type" keyword.
+const (
+	jsonSchemaTypeNull uint8 = 1 << iota
+	jsonSchemaTypeBoolean
+	jsonSchemaTypeObject
+	jsonSchemaTypeArray
+	jsonSchemaTypeNumber
+	jsonSchemaTypeString
+	jsonSchemaTypeInteger
+)
+
+var jsonSchemaTypeNames = map[string]uint8{
+	"null":    jsonSchemaTypeNull,
+	"boolean": jsonSchemaTypeBoolean,
+	"object":  jsonSchemaTypeObject,
+	"array":   jsonSchemaTypeArray,
+	"number":  jsonSchemaTypeNumber,
+	"string":  jsonSchemaTypeString,
+	"integer": jsonSchemaTypeInteger,
+}
+
+// JSONSchema is a compiled JSON Schema, it supports the keywords of draft 4 like MySQL does. Only the references in
+// the same schema, like `{"$ref": "#/definitions/name"}`, are supported.
+type JSONSchema struct {
+	root *jsonSchemaNode
+}
+
+// JSONSchemaValidationError describes why a JSON document is invalid against a JSON schema.
+type JSONSchemaValidationError struct {
+	// SchemaLocation is the JSON pointer of the failed sub-schema, like `#/properties/a`.
+	SchemaLocation string
+	// DocumentLocation is the JSON pointer of the invalid value in the document, like `#/a`.
+	DocumentLocation string
+	// Keyword is the failed keyword of the sub-schema.
+	Keyword string
+}
+
+// Reason returns the readable reason of the failure, in the same format as MySQL.
+func (e *JSONSchemaValidationError) Reason() string {
+	return fmt.Sprintf("The JSON document location '%s' failed requirement '%s' at JSON Schema location '%s'",
+		e.DocumentLocation, e.Keyword, e.SchemaLocation)
+}
+
+type jsonSchemaPatternProperty struct {
+	pattern *regexp.Regexp
+	schema  *jsonSchemaNode
+}
+
+type jsonSchemaNode struct {
+	location string
+
+	refPath string
+	ref     *jsonSchemaNode
+
+	types uint8
+	enum  []BinaryJSON
+	allOf []*jsonSchemaNode
+	anyOf []*jsonSchemaNode
+	oneOf []*jsonSchemaNode
+	not   *jsonSchemaNode
+
+	multipleOf       *float64
+	maximum          *float64
+	minimum          *float64
+	exclusiveMaximum bool
+	exclusiveMinimum bool
+
+	maxLength int64
+	minLength int64
+	pattern   *regexp.Regexp
+
+	items                  *jsonSchemaNode
+	itemsList              []*jsonSchemaNode
+	additionalItems        *jsonSchemaNode
+	disallowAdditionalItem bool
+	maxItems               int64
+	minItems               int64
+	uniqueItems            bool
+
+	maxProperties          int64
+	minProperties          int64
+	required               []string
+	properties             map[string]*jsonSchemaNode
+	patternProperties      []jsonSchemaPatternProperty
+	additionalProperties   *jsonSchemaNode
+	disallowAdditionalProp bool
+	propertyDependencies   map[string][]string
+	schemaDependencies     map[string]*jsonSchemaNode
+}
+
+type jsonSchemaCompiler struct {
+	root  BinaryJSON
+	nodes map[string]*jsonSchemaNode
+	refs  []*jsonSchemaNode
+}
+
+// ParseJSONSchema compiles the JSON schema. The schema must be a JSON object.
+func ParseJSONSchema(schema BinaryJSON) (*JSONSchema, error) {
+	c := &jsonSchemaCompiler{root: schema, nodes: make(map[string]*jsonSchemaNode)}
+	root, err := c.compile(schema, "#")
+	if err != nil {
+		return nil, err
+	}
+	// Compiling the referenced schemas may append more references.
+	for i := 0; i < len(c.refs); i++ {
+		if err := c.resolve(c.refs[i]); err != nil {
+			return nil, err
+		}
+	}
+	for _, node := range c.refs {
+		visited := make(map[*jsonSchemaNode]struct{})
+		for n := node; n.ref != nil; n = n.ref {
+			if _, ok := visited[n]; ok {
+				return nil, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the reference at '%s' refers to itself", node.location))
+			}
+			visited[n] = struct{}{}
+		}
+	}
+	return &JSONSchema{root: root}, nil
+}
+
+func invalidJSONSchemaKeyword(location, keyword, expected string) error {
+	return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the value of '%s' at '%s' must be %s", keyword, location, expected))
+}
+
+func (c *jsonSchemaCompiler) compile(schema BinaryJSON, location string) (*jsonSchemaNode, error) {
+	if node, ok := c.nodes[location]; ok {
+		return node, nil
+	}
+	if schema.TypeCode != JSONTypeCodeObject {
+		return nil, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the schema at '%s' must be an object", location))
+	}
+	node := &jsonSchemaNode{location: location, maxLength: -1, maxItems: -1, maxProperties: -1}
+	c.nodes[location] = node
+	if ref, ok := schema.objectSearchKey([]byte("$ref")); ok {
+		// The other keywords are ignored if there is a reference.
+		if ref.TypeCode != JSONTypeCodeString {
+			return nil, invalidJSONSchemaKeyword(location, "$ref", "a string")
+		}
+		node.refPath = string(ref.GetString())
+		c.refs = append(c.refs, node)
+		return node, nil
+	}
+	for i := 0; i < schema.GetElemCount(); i++ {
+		keyword := string(schema.objectGetKey(i))
+		value := schema.objectGetVal(i)
+		if err := c.compileKeyword(node, keyword, value); err != nil {
+			return nil, err
+		}
+	}
+	return node, nil
+}
+
+func (c *jsonSchemaCompiler) compileKeyword(node *jsonSchemaNode, keyword string, value BinaryJSON) error {
+	location := node.location + "/" + escapeJSONPointerToken(keyword)
+	var err error
+	switch keyword {
+	case "type":
+		node.types, err = compileJSONSchemaType(node.location, value)
+	case "enum":
+		if value.TypeCode != JSONTypeCodeArray || value.GetElemCount() == 0 {
+			return invalidJSONSchemaKeyword(node.location, keyword, "a non-empty array")
+		}
+		for i := 0; i < value.GetElemCount(); i++ {
+			node.enum = append(node.enum, value.ArrayGetElem(i))
+		}
+	case "allOf", "anyOf", "oneOf":
+		var schemas []*jsonSchemaNode
+		schemas, err = c.compileSchemaArray(node.location, keyword, value)
+		switch keyword {
+		case "allOf":
+			node.allOf = schemas
+		case "anyOf":
+			node.anyOf = schemas
+		default:
+			node.oneOf = schemas
+		}
+	case "not":
+		node.not, err = c.compile(value, location)
+	case "multipleOf":
+		node.multipleOf, err = compileJSONSchemaNumber(node.location, keyword, value)
+		if err == nil && *node.multipleOf <= 0 {
+			return invalidJSONSchemaKeyword(node.location, keyword, "a positive number")
+		}
+	case "maximum":
+		node.maximum, err = compileJSONSchemaNumber(node.location, keyword, value)
+	case "minimum":
+		node.minimum, err = compileJSONSchemaNumber(node.location, keyword, value)
+	case "exclusiveMaximum":
+		node.exclusiveMaximum, err = compileJSONSchemaBool(node.location, keyword, value)
+	case "exclusiveMinimum":
+		node.exclusiveMinimum, err = compileJSONSchemaBool(node.location, keyword, value)
+	case "maxLength":
+		node.maxLength, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "minLength":
+		node.minLength, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "pattern":
+		node.pattern, err = compileJSONSchemaPattern(node.location, keyword, value)
+	case "items":
+		if value.TypeCode == JSONTypeCodeArray {
+			node.itemsList, err = c.compileSchemaArray(node.location, keyword, value)
+		} else {
+			node.items, err = c.compile(value, location)
+		}
+	case "additionalItems":
+		if value.TypeCode == JSONTypeCodeLiteral {
+			var allowed bool
+			allowed, err = compileJSONSchemaBool(node.location, keyword, value)
+			node.disallowAdditionalItem = !allowed
+		} else {
+			node.additionalItems, err = c.compile(value, location)
+		}
+	case "maxItems":
+		node.maxItems, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "minItems":
+		node.minItems, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "uniqueItems":
+		node.uniqueItems, err = compileJSONSchemaBool(node.location, keyword, value)
+	case "maxProperties":
+		node.maxProperties, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "minProperties":
+		node.minProperties, err = compileJSONSchemaCount(node.location, keyword, value)
+	case "required":
+		node.required, err = compileJSONSchemaStrings(node.location, keyword, value)
+	case "properties":
+		if value.TypeCode != JSONTypeCodeObject {
+			return invalidJSONSchemaKeyword(node.location, keyword, "an object")
+		}
+		node.properties = make(map[string]*jsonSchemaNode, value.GetElemCount())
+		for i := 0; i < value.GetElemCount(); i++ {
+			name := string(value.objectGetKey(i))
+			node.properties[name], err = c.compile(value.objectGetVal(i), location+"/"+escapeJSONPointerToken(name))
+			if err != nil {
+				return err
+			}
+		}
+	case "patternProperties":
+		if value.TypeCode != JSONTypeCodeObject {
+			return invalidJSONSchemaKeyword(node.location, keyword, "an object")
+		}
+		for i := 0; i < value.GetElemCount(); i++ {
+			pattern := string(value.objectGetKey(i))
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("invalid pattern '%s' at '%s'", pattern, location))
+			}
+			schema, err := c.compile(value.objectGetVal(i), location+"/"+escapeJSONPointerToken(pattern))
+			if err != nil {
+				return err
+			}
+			node.patternProperties = append(node.patternProperties, jsonSchemaPatternProperty{pattern: re, schema: schema})
+		}
+	case "additionalProperties":
+		if value.TypeCode == JSONTypeCodeLiteral {
+			var allowed bool
+			allowed, err = compileJSONSchemaBool(node.location, keyword, value)
+			node.disallowAdditionalProp = !allowed
+		} else {
+			node.additionalProperties, err = c.compile(value, location)
+		}
+	case "dependencies":
+		if value.TypeCode != JSONTypeCodeObject {
+			return invalidJSONSchemaKeyword(node.location, keyword, "an object")
+		}
+		node.propertyDependencies = make(map[string][]string)
+		node.schemaDependencies = make(map[string]*jsonSchemaNode)
+		for i := 0; i < value.GetElemCount(); i++ {
+			name := string(value.objectGetKey(i))
+			dependency := value.objectGetVal(i)
+			if dependency.TypeCode == JSONTypeCodeArray {
+				node.propertyDependencies[name], err = compileJSONSchemaStrings(node.location, keyword, dependency)
+			} else {
+				node.schemaDependencies[name], err = c.compile(dependency, location+"/"+escapeJSONPointerToken(name))
+			}
+			if err != nil {
+				return err
+			}
+		}
+	case "definitions":
+		if value.TypeCode != JSONTypeCodeObject {
+			return invalidJSONSchemaKeyword(node.location, keyword, "an object")
+		}
+		for i := 0; i < value.GetElemCount(); i++ {
+			name := string(value.objectGetKey(i))
+			if _, err := c.compile(value.objectGetVal(i), location+"/"+escapeJSONPointerToken(name)); err != nil {
+				return err
+			}
+		}
+	}
+	// The other keywords, like "title", "description", "default" and "format", don't affect the validation.
+	return err
+}
+
+func (c *jsonSchemaCompiler) compileSchemaArray(location, keyword string, value BinaryJSON) ([]*jsonSchemaNode, error) {
+	if value.TypeCode != JSONTypeCodeArray || value.GetElemCount() == 0 {
+		return nil, invalidJSONSchemaKeyword(location, keyword, "a non-empty array of schemas")
+	}
+	schemas := make([]*jsonSchemaNode, 0, value.GetElemCount())
+	for i := 0; i < value.GetElemCount(); i++ {
+		schema, err := c.compile(value.ArrayGetElem(i), location+"/"+keyword+"/"+strconv.Itoa(i))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// resolve finds the schema referenced by the JSON pointer of the node.
+func (c *jsonSchemaCompiler) resolve(node *jsonSchemaNode) error {
+	if !strings.HasPrefix(node.refPath, "#") {
+		return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the reference '%s' at '%s' isn't supported, only the references in the same schema are supported", node.refPath, node.location))
+	}
+	value := c.root
+	if pointer := node.refPath[1:]; pointer != "" {
+		if !strings.HasPrefix(pointer, "/") {
+			return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("invalid reference '%s' at '%s'", node.refPath, node.location))
+		}
+		for _, token := range strings.Split(pointer[1:], "/") {
+			token = unescapeJSONPointerToken(token)
+			found := false
+			switch value.TypeCode {
+			case JSONTypeCodeObject:
+				value, found = value.objectSearchKey([]byte(token))
+			case JSONTypeCodeArray:
+				if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < value.GetElemCount() {
+					value, found = value.ArrayGetElem(idx), true
+				}
+			}
+			if !found {
+				return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("can't resolve the reference '%s' at '%s'", node.refPath, node.location))
+			}
+		}
+	}
+	target, err := c.compile(value, node.refPath)
+	if err != nil {
+		return err
+	}
+	node.ref = target
+	return nil
+}
+
+func compileJSONSchemaType(location string, value BinaryJSON) (uint8, error) {
+	var names []BinaryJSON
+	switch value.TypeCode {
+	case JSONTypeCodeString:
+		names = []BinaryJSON{value}
+	case JSONTypeCodeArray:
+		for i := 0; i < value.GetElemCount(); i++ {
+			names = append(names, value.ArrayGetElem(i))
+		}
+	}
+	if len(names) == 0 {
+		return 0, invalidJSONSchemaKeyword(location, "type", "a type name or an array of type names")
+	}
+	var types uint8
+	for _, name := range names {
+		if name.TypeCode != JSONTypeCodeString {
+			return 0, invalidJSONSchemaKeyword(location, "type", "a type name or an array of type names")
+		}
+		tp, ok := jsonSchemaTypeNames[string(name.GetString())]
+		if !ok {
+			return 0, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("unknown type '%s' at '%s'", name.GetString(), location))
+		}
+		types |= tp
+	}
+	return types, nil
+}
+
+func compileJSONSchemaNumber(location, keyword string, value BinaryJSON) (*float64, error) {
+	f, ok := jsonSchemaNumberValue(value)
+	if !ok {
+		return nil, invalidJSONSchemaKeyword(location, keyword, "a number")
+	}
+	return &f, nil
+}
+
+func compileJSONSchemaBool(location, keyword string, value BinaryJSON) (bool, error) {
+	if value.TypeCode != JSONTypeCodeLiteral || value.Value[0] == JSONLiteralNil {
+		return false, invalidJSONSchemaKeyword(location, keyword, "a boolean")
+	}
+	return value.Value[0] == JSONLiteralTrue, nil
+}
+
+func compileJSONSchemaCount(location, keyword string, value BinaryJSON) (int64, error) {
+	switch value.TypeCode {
+	case JSONTypeCodeInt64:
+		if v := value.GetInt64(); v >= 0 {
+			return v, nil
+		}
+	case JSONTypeCodeUint64:
+		if v := value.GetUint64(); v <= math.MaxInt64 {
+			return int64(v), nil
+		}
+	}
+	return 0, invalidJSONSchemaKeyword(location, keyword, "a non-negative integer")
+}
+
+func compileJSONSchemaPattern(location, keyword string, value BinaryJSON) (*regexp.Regexp, error) {
+	if value.TypeCode != JSONTypeCodeString {
+		return nil, invalidJSONSchemaKeyword(location, keyword, "a string")
+	}
+	re, err := regexp.Compile(string(value.GetString()))
+	if err != nil {
+		return nil, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("invalid pattern '%s' at '%s'", value.GetString(), location))
+	}
+	return re, nil
+}
+
+func compileJSONSchemaStrings(location, keyword string, value BinaryJSON) ([]string, error) {
+	if value.TypeCode != JSONTypeCodeArray {
+		return nil, invalidJSONSchemaKeyword(location, keyword, "an array of strings")
+	}
+	result := make([]string, 0, value.GetElemCount())
+	for i := 0; i < value.GetElemCount(); i++ {
+		elem := value.ArrayGetElem(i)
+		if elem.TypeCode != JSONTypeCodeString {
+			return nil, invalidJSONSchemaKeyword(location, keyword, "an array of strings")
+		}
+		result = append(result, string(elem.GetString()))
+	}
+	return result, nil
+}
+
+func escapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescapeJSONPointerToken(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
+
+func jsonSchemaNumberValue(value BinaryJSON) (float64, bool) {
+	switch value.TypeCode {
+	case JSONTypeCodeInt64:
+		return float64(value.GetInt64()), true
+	case JSONTypeCodeUint64:
+		return float64(value.GetUint64()), true
+	case JSONTypeCodeFloat64:
+		return value.GetFloat64(), true
+	}
+	return 0, false
+}
+
+// jsonSchemaTypeOf returns the JSON Schema type of the value. The dates, times and opaque values are strings in the
+// JSON text.
+func jsonSchemaTypeOf(value BinaryJSON) uint8 {
+	switch value.TypeCode {
+	case JSONTypeCodeObject:
+		return jsonSchemaTypeObject
+	case JSONTypeCodeArray:
+		return jsonSchemaTypeArray
+	case JSONTypeCodeLiteral:
+		if value.Value[0] == JSONLiteralNil {
+			return jsonSchemaTypeNull
+		}
+		return jsonSchemaTypeBoolean
+	case JSONTypeCodeInt64, JSONTypeCodeUint64:
+		return jsonSchemaTypeInteger
+	case JSONTypeCodeFloat64:
+		return jsonSchemaTypeNumber
+	}
+	return jsonSchemaTypeString
+}
+
+// Validate validates the document against the schema. It returns nil if the document is valid.
+func (s *JSONSchema) Validate(doc BinaryJSON) (*JSONSchemaValidationError, error) {
+	return s.root.validate(doc, "#", 0)
+}
+
+func (n *jsonSchemaNode) fail(docLocation, keyword string) *JSONSchemaValidationError {
+	return &JSONSchemaValidationError{SchemaLocation: n.location, DocumentLocation: docLocation, Keyword: keyword}
+}
+
+func (n *jsonSchemaNode) validate(doc BinaryJSON, docLocation string, depth int) (*JSONSchemaValidationError, error) {
+	if depth > jsonSchemaMaxDepth {
+		return nil, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the schema at '%s' is nested too deep", n.location))
+	}
+	for n.ref != nil {
+		n = n.ref
+	}
+	tp := jsonSchemaTypeOf(doc)
+	if n.types != 0 && n.types&tp == 0 && !(tp == jsonSchemaTypeInteger && n.types&jsonSchemaTypeNumber != 0) {
+		return n.fail(docLocation, "type"), nil
+	}
+	if len(n.enum) > 0 {
+		found := false
+		for _, v := range n.enum {
+			if CompareBinaryJSON(doc, v) == 0 {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return n.fail(docLocation, "enum"), nil
+		}
+	}
+
+	var (
+		verr *JSONSchemaValidationError
+		err  error
+	)
+	switch tp {
+	case jsonSchemaTypeInteger, jsonSchemaTypeNumber:
+		verr = n.validateNumber(doc, docLocation)
+	case jsonSchemaTypeString:
+		verr, err = n.validateString(doc, docLocation)
+	case jsonSchemaTypeArray:
+		verr, err = n.validateArray(doc, docLocation, depth)
+	case jsonSchemaTypeObject:
+		verr, err = n.validateObject(doc, docLocation, depth)
+	}
+	if verr != nil || err != nil {
+		return verr, err
+	}
+
+	for _, schema := range n.allOf {
+		if verr, err := schema.validate(doc, docLocation, depth+1); verr != nil || err != nil {
+			return verr, err
+		}
+	}
+	if len(n.anyOf) > 0 {
+		matched := false
+		for _, schema := range n.anyOf {
+			verr, err := schema.validate(doc, docLocation, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if verr == nil {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return n.fail(docLocation, "anyOf"), nil
+		}
+	}
+	if len(n.oneOf) > 0 {
+		matched := 0
+		for _, schema := range n.oneOf {
+			verr, err := schema.validate(doc, docLocation, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			if verr == nil {
+				matched++
+			}
+		}
+		if matched != 1 {
+			return n.fail(docLocation, "oneOf"), nil
+		}
+	}
+	if n.not != nil {
+		verr, err := n.not.validate(doc, docLocation, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if verr == nil {
+			return n.fail(docLocation, "not"), nil
+		}
+	}
+	return nil, nil
+}
+
+func (n *jsonSchemaNode) validateNumber(doc BinaryJSON, docLocation string) *JSONSchemaValidationError {
+	v, _ := jsonSchemaNumberValue(doc)
+	if n.multipleOf != nil {
+		q := v / *n.multipleOf
+		if math.IsInf(q, 0) || math.Abs(q-math.Round(q)) > 1e-9 {
+			return n.fail(docLocation, "multipleOf")
+		}
+	}
+	if n.maximum != nil && (v > *n.maximum || (n.exclusiveMaximum && v == *n.maximum)) {
+		return n.fail(docLocation, "maximum")
+	}
+	if n.minimum != nil && (v < *n.minimum || (n.exclusiveMinimum && v == *n.minimum)) {
+		return n.fail(docLocation, "minimum")
+	}
+	return nil
+}
+
+func (n *jsonSchemaNode) validateString(doc BinaryJSON, docLocation string) (*JSONSchemaValidationError, error) {
+	if n.maxLength < 0 && n.minLength == 0 && n.pattern == nil {
+		return nil, nil
+	}
+	var s string
+	if doc.TypeCode == JSONTypeCodeString {
+		s = string(doc.GetString())
+	} else {
+		var err error
+		if s, err = doc.Unquote(); err != nil {
+			return nil, err
+		}
+	}
+	length := int64(utf8.RuneCountInString(s))
+	if n.maxLength >= 0 && length > n.maxLength {
+		return n.fail(docLocation, "maxLength"), nil
+	}
+	if length < n.minLength {
+		return n.fail(docLocation, "minLength"), nil
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		return n.fail(docLocation, "pattern"), nil
+	}
+	return nil, nil
+}
+
+func (n *jsonSchemaNode) validateArray(doc BinaryJSON, docLocation string, depth int) (*JSONSchemaValidationError, error) {
+	count := doc.GetElemCount()
+	if n.maxItems >= 0 && int64(count) > n.maxItems {
+		return n.fail(docLocation, "maxItems"), nil
+	}
+	if int64(count) < n.minItems {
+		return n.fail(docLocation, "minItems"), nil
+	}
+	if n.uniqueItems {
+		for i := 0; i < count; i++ {
+			for j := i + 1; j < count; j++ {
+				if CompareBinaryJSON(doc.ArrayGetElem(i), doc.ArrayGetElem(j)) == 0 {
+					return n.fail(docLocation, "uniqueItems"), nil
+				}
+			}
+		}
+	}
+	for i := 0; i < count; i++ {
+		schema := n.items
+		if n.items == nil && n.itemsList != nil {
+			if i < len(n.itemsList) {
+				schema = n.itemsList[i]
+			} else if n.disallowAdditionalItem {
+				return n.fail(docLocation, "additionalItems"), nil
+			} else {
+				schema = n.additionalItems
+			}
+		}
+		if schema == nil {
+			continue
+		}
+		if verr, err := schema.validate(doc.ArrayGetElem(i), docLocation+"/"+strconv.Itoa(i), depth+1); verr != nil || err != nil {
+			return verr, err
+		}
+	}
+	return nil, nil
+}
+
+func (n *jsonSchemaNode) validateObject(doc BinaryJSON, docLocation string, depth int) (*JSONSchemaValidationError, error) {
+	count := doc.GetElemCount()
+	if n.maxProperties >= 0 && int64(count) > n.maxProperties {
+		return n.fail(docLocation, "maxProperties"), nil
+	}
+	if int64(count) < n.minProperties {
+		return n.fail(docLocation, "minProperties"), nil
+	}
+	for _, name := range n.required {
+		if _, ok := doc.objectSearchKey([]byte(name)); !ok {
+			return n.fail(docLocation, "required"), nil
+		}
+	}
+	for i := 0; i < count; i++ {
+		name := string(doc.objectGetKey(i))
+		value := doc.objectGetVal(i)
+		location := docLocation + "/" + escapeJSONPointerToken(name)
+		matched := false
+		if schema, ok := n.properties[name]; ok {
+			matched = true
+			if verr, err := schema.validate(value, location, depth+1); verr != nil || err != nil {
+				return verr, err
+			}
+		}
+		for _, pp := range n.patternProperties {
+			if !pp.pattern.MatchString(name) {
+				continue
+			}
+			matched = true
+			if verr, err := pp.schema.validate(value, location, depth+1); verr != nil || err != nil {
+				return verr, err
+			}
+		}
+		if matched {
+			continue
+		}
+		if n.disallowAdditionalProp {
+			return n.fail(docLocation, "additionalProperties"), nil
+		}
+		if n.additionalProperties != nil {
+			if verr, err := n.additionalProperties.validate(value, location, depth+1); verr != nil || err != nil {
+				return verr, err
+			}
+		}
+	}
+	for name, dependencies := range n.propertyDependencies {
+		if _, ok := doc.objectSearchKey([]byte(name)); !ok {
+			continue
+		}
+		for _, dependency := range dependencies {
+			if _, ok := doc.objectSearchKey([]byte(dependency)); !ok {
+				return n.fail(docLocation, "dependencies"), nil
+			}
+		}
+	}
+	for name, schema := range n.schemaDependencies {
+		if _, ok := doc.objectSearchKey([]byte(name)); !ok {
+			continue
+		}
+		if verr, err := schema.validate(doc, docLocation, depth+1); verr != nil || err != nil {
+			return verr, err
+		}
+	}
+	return nil, nil
+}
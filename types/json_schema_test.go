@@ -0,0 +1,105 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONSchemaValidate(t *testing.T) {
+	tests := []struct {
+		schema      string
+		doc         string
+		docLocation string
+		schemaLoc   string
+		keyword     string
+	}{
+		{`{}`, `[1, "a", null]`, "", "", ""},
+		{`{"type": "object"}`, `{}`, "", "", ""},
+		{`{"type": "object"}`, `[]`, "#", "#", "type"},
+		{`{"type": "number"}`, `1`, "", "", ""},
+		{`{"type": "integer"}`, `1.5`, "#", "#", "type"},
+		{`{"type": ["string", "null"]}`, `null`, "", "", ""},
+		{`{"enum": [1, "a", [2]]}`, `[2]`, "", "", ""},
+		{`{"enum": [1, "a", [2]]}`, `2`, "#", "#", "enum"},
+		{`{"minimum": 1, "maximum": 3, "exclusiveMaximum": true}`, `3`, "#", "#", "maximum"},
+		{`{"minimum": 1, "maximum": 3}`, `0.5`, "#", "#", "minimum"},
+		{`{"multipleOf": 0.5}`, `2.5`, "", "", ""},
+		{`{"multipleOf": 2}`, `3`, "#", "#", "multipleOf"},
+		{`{"minLength": 2, "maxLength": 3}`, `"你好"`, "", "", ""},
+		{`{"maxLength": 3}`, `"abcd"`, "#", "#", "maxLength"},
+		{`{"pattern": "^[a-z]+$"}`, `"a1"`, "#", "#", "pattern"},
+		{`{"items": {"type": "integer"}}`, `[1, 2, "3"]`, "#/2", "#/items", "type"},
+		{`{"items": [{"type": "string"}], "additionalItems": false}`, `["a", 1]`, "#", "#", "additionalItems"},
+		{`{"items": [{"type": "string"}], "additionalItems": {"type": "integer"}}`, `["a", 1]`, "", "", ""},
+		{`{"minItems": 1, "maxItems": 2}`, `[]`, "#", "#", "minItems"},
+		{`{"uniqueItems": true}`, `[1, 2, 1]`, "#", "#", "uniqueItems"},
+		{`{"required": ["a"], "properties": {"a": {"type": "string"}}}`, `{"b": 1}`, "#", "#", "required"},
+		{`{"properties": {"a/b": {"type": "string"}}}`, `{"a/b": 1}`, "#/a~1b", "#/properties/a~1b", "type"},
+		{`{"patternProperties": {"^x": {"type": "integer"}}, "additionalProperties": false}`, `{"x1": 1, "x2": 2}`, "", "", ""},
+		{`{"patternProperties": {"^x": {"type": "integer"}}, "additionalProperties": false}`, `{"x1": 1, "y": 2}`, "#", "#", "additionalProperties"},
+		{`{"maxProperties": 1}`, `{"a": 1, "b": 2}`, "#", "#", "maxProperties"},
+		{`{"dependencies": {"a": ["b"]}}`, `{"a": 1}`, "#", "#", "dependencies"},
+		{`{"dependencies": {"a": {"required": ["c"]}}}`, `{"a": 1}`, "#", "#/dependencies/a", "required"},
+		{`{"anyOf": [{"type": "string"}, {"type": "integer"}]}`, `true`, "#", "#", "anyOf"},
+		{`{"oneOf": [{"type": "number"}, {"type": "integer"}]}`, `1`, "#", "#", "oneOf"},
+		{`{"allOf": [{"type": "number"}, {"minimum": 2}]}`, `1`, "#", "#/allOf/1", "minimum"},
+		{`{"not": {"type": "null"}}`, `null`, "#", "#", "not"},
+		{`{"definitions": {"pos": {"minimum": 0}}, "items": {"$ref": "#/definitions/pos"}}`, `[1, -1]`, "#/1", "#/definitions/pos", "minimum"},
+		{`{"properties": {"next": {"$ref": "#"}}, "required": ["v"]}`, `{"v": 1, "next": {"v": 2, "next": {}}}`, "#/next/next", "#", "required"},
+	}
+	for _, tt := range tests {
+		schema, err := ParseJSONSchema(mustParseBinaryFromString(t, tt.schema))
+		require.NoError(t, err, tt.schema)
+		verr, err := schema.Validate(mustParseBinaryFromString(t, tt.doc))
+		require.NoError(t, err, tt.schema)
+		if tt.keyword == "" {
+			require.Nil(t, verr, "%s %s", tt.schema, tt.doc)
+			continue
+		}
+		require.NotNil(t, verr, "%s %s", tt.schema, tt.doc)
+		require.Equal(t, tt.docLocation, verr.DocumentLocation, tt.schema)
+		require.Equal(t, tt.schemaLoc, verr.SchemaLocation, tt.schema)
+		require.Equal(t, tt.keyword, verr.Keyword, tt.schema)
+	}
+
+	schema, err := ParseJSONSchema(mustParseBinaryFromString(t, `{"type": "object"}`))
+	require.NoError(t, err)
+	verr, err := schema.Validate(mustParseBinaryFromString(t, `1`))
+	require.NoError(t, err)
+	require.Equal(t, "The JSON document location '#' failed requirement 'type' at JSON Schema location '#'", verr.Reason())
+}
+
+func TestParseInvalidJSONSchema(t *testing.T) {
+	for _, schema := range []string{
+		`[]`,
+		`{"type": "int"}`,
+		`{"type": 1}`,
+		`{"minLength": -1}`,
+		`{"maximum": "1"}`,
+		`{"multipleOf": 0}`,
+		`{"pattern": "("}`,
+		`{"required": [1]}`,
+		`{"properties": {"a": 1}}`,
+		`{"$ref": "http://json-schema.org/draft-04/schema#"}`,
+		`{"$ref": "#/definitions/no"}`,
+		`{"definitions": {"a": {"$ref": "#/definitions/b"}, "b": {"$ref": "#/definitions/a"}}, "$ref": "#/definitions/a"}`,
+	} {
+		_, err := ParseJSONSchema(mustParseBinaryFromString(t, schema))
+		require.True(t, ErrInvalidJSONSchema.Equal(err), schema)
+	}
+}
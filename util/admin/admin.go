@@ -69,6 +69,15 @@ const (
 // It returns nil if the count from the index is equal to the count from the table columns,
 // otherwise it returns an error and the corresponding index's offset.
 func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices []string) (byte, int, error) {
+	return checkIndicesCount(ctx, dbName, tableName, "", indices)
+}
+
+// CheckPartitionIndicesCount is like CheckIndicesCount, but only counts the rows of the given partition.
+func CheckPartitionIndicesCount(ctx sessionctx.Context, dbName, tableName, partitionName string, indices []string) (byte, int, error) {
+	return checkIndicesCount(ctx, dbName, tableName, partitionName, indices)
+}
+
+func checkIndicesCount(ctx sessionctx.Context, dbName, tableName, partitionName string, indices []string) (byte, int, error) {
 	// Here we need check all indexes, includes invisible index
 	ctx.GetSessionVars().OptimizerUseInvisibleIndexes = true
 	defer func() {
@@ -89,12 +98,16 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 
 	// Add `` for some names like `table name`.
 	exec := ctx.(sqlexec.RestrictedSQLExecutor)
-	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX()", dbName, tableName)
+	source, args := "%n.%n", []interface{}{dbName, tableName}
+	if partitionName != "" {
+		source, args = "%n.%n PARTITION(%n)", append(args, partitionName)
+	}
+	tblCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM "+source+" USE INDEX()", args...)
 	if err != nil {
 		return 0, 0, errors.Trace(err)
 	}
 	for i, idx := range indices {
-		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM %n.%n USE INDEX(%n)", dbName, tableName, idx)
+		idxCnt, err := getCount(exec, snapshot, "SELECT COUNT(*) FROM "+source+" USE INDEX(%n)", append(args, idx)...)
 		if err != nil {
 			return 0, i, errors.Trace(err)
 		}
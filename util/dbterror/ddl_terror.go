@@ -49,6 +49,9 @@ var (
 
 	// ErrCantDropColWithIndex means can't drop the column with index. We don't support dropping column with index covered now.
 	ErrCantDropColWithIndex = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "drop column with index"), nil))
+	// ErrCantDropColWithClusteredPK means can't drop the column covered by the clustered primary key. The other indices
+	// covering the column are rewritten without it, but the clustered primary key can't be rewritten.
+	ErrCantDropColWithClusteredPK = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "drop column %s covered by the clustered primary key"), nil))
 	// ErrCantDropColWithAutoInc means can't drop column with auto_increment
 	ErrCantDropColWithAutoInc = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "can't remove column with auto_increment when @@tidb_allow_remove_auto_inc disabled"), nil))
 	// ErrCantDropColWithCheckConstraint means can't drop column with check constraint
@@ -119,6 +122,20 @@ var (
 	ErrDependentByGeneratedColumn = ClassDDL.NewStd(mysql.ErrDependentByGeneratedColumn)
 	// ErrJSONUsedAsKey forbids to use JSON as key or index.
 	ErrJSONUsedAsKey = ClassDDL.NewStd(mysql.ErrJSONUsedAsKey)
+	// ErrSpatialMustHaveGeomCol is used when a SPATIAL index is built on a non-geometry column.
+	ErrSpatialMustHaveGeomCol = ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrSpatialFunctionalIndex is used when a SPATIAL index has an expression key part.
+	ErrSpatialFunctionalIndex = ClassDDL.NewStd(mysql.ErrSpatialFunctionalIndex)
+	// ErrSpatialCantHaveNull is used when a SPATIAL index is built on a nullable column.
+	ErrSpatialCantHaveNull = ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrBadFtColumn is used when a FULLTEXT index is built on a non-text column.
+	ErrBadFtColumn = ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrFulltextFunctionalIndex is used when a FULLTEXT index has an expression key part.
+	ErrFulltextFunctionalIndex = ClassDDL.NewStd(mysql.ErrFulltextFunctionalIndex)
+	// ErrPluginIsNotLoaded is used when a FULLTEXT index uses an unknown parser.
+	ErrPluginIsNotLoaded = ClassDDL.NewStd(mysql.ErrPluginIsNotLoaded)
+	// ErrTooManyKeyParts is used when an index has more columns than allowed, e.g. a SPATIAL index with more than one column.
+	ErrTooManyKeyParts = ClassDDL.NewStd(mysql.ErrTooManyKeyParts)
 	// ErrBlobCantHaveDefault forbids to give not null default value to TEXT/BLOB/JSON.
 	ErrBlobCantHaveDefault = ClassDDL.NewStd(mysql.ErrBlobCantHaveDefault)
 	// ErrTooLongIndexComment means the comment for index is too long.
@@ -358,8 +375,6 @@ var (
 	ErrOptOnTemporaryTable = ClassDDL.NewStd(mysql.ErrOptOnTemporaryTable)
 	// ErrOptOnCacheTable returns when exec unsupported opt at cache mode
 	ErrOptOnCacheTable = ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
-	// ErrUnsupportedOnCommitPreserve returns when exec unsupported opt on commit preserve
-	ErrUnsupportedOnCommitPreserve = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("TiDB doesn't support ON COMMIT PRESERVE ROWS for now", nil))
 	// ErrUnsupportedClusteredSecondaryKey returns when exec unsupported clustered secondary key
 	ErrUnsupportedClusteredSecondaryKey = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("CLUSTERED/NONCLUSTERED keyword is only supported for primary key", nil))
 
@@ -399,6 +414,8 @@ var (
 	ErrCannotPauseDDLJob = ClassDDL.NewStd(mysql.ErrCannotPauseDDLJob)
 	// ErrCannotResumeDDLJob returns  when the State is not qualified to be resumed.
 	ErrCannotResumeDDLJob = ClassDDL.NewStd(mysql.ErrCannotResumeDDLJob)
+	// ErrCannotAlterDDLJob returns when the job can't be altered by `ADMIN ALTER DDL JOBS`.
+	ErrCannotAlterDDLJob = ClassDDL.NewStd(mysql.ErrCannotAlterDDLJob)
 	// ErrDDLSetting returns when failing to enable/disable DDL.
 	ErrDDLSetting = ClassDDL.NewStd(mysql.ErrDDLSetting)
 	// ErrIngestFailed returns when the DDL ingest job is failed.
@@ -467,6 +484,15 @@ var (
 	ErrCheckConstraintUsingFKReferActionColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintClauseUsingFKReferActionColumn)
 	// ErrNonBooleanExprForCheckConstraint is returned for non bool expression.
 	ErrNonBooleanExprForCheckConstraint = ClassDDL.NewStd(mysql.ErrNonBooleanExprForCheckConstraint)
+
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view or temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgInWrongSchema is returned when the trigger and its table are in different schemas.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema is returned when creating a trigger on a system table.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrReferencedTrgDoesNotExist is returned when the trigger in FOLLOWS or PRECEDES clause does not exist.
+	ErrReferencedTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrReferencedTrgDoesNotExist)
 )
 
 // ReorgRetryableErrCodes is the error codes that are retryable for reorganization.
@@ -67,6 +67,8 @@ var (
 	ErrBRIEExportFailed               = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEExportFailed)
 	ErrBRJobNotFound                  = dbterror.ClassExecutor.NewStd(mysql.ErrBRJobNotFound)
 	ErrCTEMaxRecursionDepth           = dbterror.ClassExecutor.NewStd(mysql.ErrCTEMaxRecursionDepth)
+	ErrMissingJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue            = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
 	ErrNotSupportedWithSem            = dbterror.ClassOptimizer.NewStd(mysql.ErrNotSupportedWithSem)
 	ErrPluginIsNotLoaded              = dbterror.ClassExecutor.NewStd(mysql.ErrPluginIsNotLoaded)
 	ErrSetPasswordAuthPlugin          = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
@@ -99,4 +101,40 @@ var (
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
 	ErrLoadDataPreCheckFailed         = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataPreCheckFailed)
+
+	ErrSpLilabelMismatch       = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpLabelRedefine         = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelRedefine)
+	ErrSpLabelMismatch         = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelMismatch)
+	ErrSpWrongNoOfArgs         = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpCursorMismatch        = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen     = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen         = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpUndeclaredVar         = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpWrongNoOfFetchArgs    = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData           = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpDupParam              = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar                = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs               = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpVarcondAfterCurshndlr = dbterror.ClassExecutor.NewStd(mysql.ErrSpVarcondAfterCurshndlr)
+	ErrSpCursorAfterHandler    = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAfterHandler)
+	ErrSpCaseNotFound          = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrSpNotVarArg             = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpRecursionLimit        = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrSpNoRetset              = dbterror.ClassExecutor.NewStd(mysql.ErrSpNoRetset)
+
+	ErrTrgCantChangeRow             = dbterror.ClassExecutor.NewStd(mysql.ErrTrgCantChangeRow)
+	ErrTrgNoSuchRowInTrg            = dbterror.ClassExecutor.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+	ErrCommitNotAllowedInSfOrTrg    = dbterror.ClassExecutor.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+	ErrNoSuchDefiner                    = dbterror.ClassExecutor.NewStd(mysql.ErrNoSuchUser)
+
+	ErrMaterializedViewNotIncremental = dbterror.ClassExecutor.NewStd(mysql.ErrMaterializedViewNotIncremental)
 )
@@ -0,0 +1,347 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+type termOp byte
+
+// The operators of the terms in a boolean mode search string.
+const (
+	opOptional termOp = iota
+	// opRequired is `+word`, the word must be present.
+	opRequired
+	// opExcluded is `-word`, the word must not be present.
+	opExcluded
+	// opIncrease is `>word`, the word increases the relevance more.
+	opIncrease
+	// opDecrease is `<word`, the word increases the relevance less.
+	opDecrease
+	// opNegate is `~word`, the word decreases the relevance but doesn't exclude the row.
+	opNegate
+)
+
+// term is a word, a phrase or a group of terms in a search string.
+type term struct {
+	op termOp
+	// tokens are the tokens of a word or a phrase, they must occur consecutively.
+	tokens []string
+	// prefix indicates the last token is a prefix, which is `word*`.
+	prefix bool
+	// group is the terms in the parentheses.
+	group []*term
+}
+
+// Query is a parsed search string of MATCH ... AGAINST.
+type Query struct {
+	tokenizer   *Tokenizer
+	booleanMode bool
+	// words are the distinct tokens of a natural language mode search string.
+	words []string
+	// terms are the terms of a boolean mode search string.
+	terms []*term
+}
+
+// ParseQuery parses the search string of MATCH ... AGAINST in the natural language mode or the boolean mode. Like
+// MySQL, it never fails, the malformed operators are ignored.
+func ParseQuery(t *Tokenizer, s string, booleanMode bool) *Query {
+	q := &Query{tokenizer: t, booleanMode: booleanMode}
+	if booleanMode {
+		p := &queryParser{t: t, s: s}
+		q.terms = p.parseGroup()
+		return q
+	}
+	seen := make(map[string]struct{})
+	for _, token := range t.Tokenize(s) {
+		if _, ok := seen[token]; !ok {
+			seen[token] = struct{}{}
+			q.words = append(q.words, token)
+		}
+	}
+	return q
+}
+
+type queryParser struct {
+	t   *Tokenizer
+	s   string
+	pos int
+}
+
+func (p *queryParser) peek() rune {
+	if p.pos >= len(p.s) {
+		return utf8.RuneError
+	}
+	r, _ := utf8.DecodeRuneInString(p.s[p.pos:])
+	return r
+}
+
+func (p *queryParser) next() {
+	_, size := utf8.DecodeRuneInString(p.s[p.pos:])
+	p.pos += size
+}
+
+// parseGroup parses the terms until the end of the string or the `)` closing the group.
+func (p *queryParser) parseGroup() []*term {
+	var terms []*term
+	for p.pos < len(p.s) {
+		r := p.peek()
+		op := opOptional
+		switch r {
+		case ')':
+			p.next()
+			return terms
+		case '+':
+			op = opRequired
+		case '-':
+			op = opExcluded
+		case '>':
+			op = opIncrease
+		case '<':
+			op = opDecrease
+		case '~':
+			op = opNegate
+		}
+		if op != opOptional {
+			p.next()
+			r = p.peek()
+		}
+		var t *term
+		switch {
+		case r == '(':
+			p.next()
+			if group := p.parseGroup(); len(group) > 0 {
+				t = &term{group: group}
+			}
+		case r == '"':
+			p.next()
+			end := strings.IndexByte(p.s[p.pos:], '"')
+			if end < 0 {
+				end = len(p.s) - p.pos
+			}
+			phrase := p.s[p.pos : p.pos+end]
+			p.pos += end
+			if p.pos < len(p.s) {
+				// Skip the closing quote.
+				p.pos++
+			}
+			if tokens := p.t.Tokenize(phrase); len(tokens) > 0 {
+				t = &term{tokens: tokens}
+			}
+		case isWordChar(r):
+			start := p.pos
+			for p.pos < len(p.s) && isWordChar(p.peek()) {
+				p.next()
+			}
+			word := p.s[start:p.pos]
+			if p.peek() == '*' {
+				p.next()
+				t = p.prefixTerm(word)
+			} else if tokens := p.t.appendWordTokens(nil, word); len(tokens) > 0 {
+				t = &term{tokens: tokens}
+			}
+		default:
+			if op == opOptional {
+				p.next()
+			}
+		}
+		if t != nil {
+			t.op = op
+			terms = append(terms, t)
+		}
+	}
+	return terms
+}
+
+// prefixTerm builds the term of `word*`. For the n-gram parser, the word longer than the n-grams is searched as a
+// phrase of its n-grams.
+func (p *queryParser) prefixTerm(word string) *term {
+	word = p.t.normalize(word)
+	if p.t.ngram && utf8.RuneCountInString(word) >= NgramTokenSize {
+		return &term{tokens: p.t.appendWordTokens(nil, word)}
+	}
+	return &term{tokens: []string{word}, prefix: true}
+}
+
+// document is the tokens of a text matched by a Query.
+type document struct {
+	tokens []string
+	freqs  map[string]int
+}
+
+func newDocument(tokens []string) *document {
+	freqs := make(map[string]int, len(tokens))
+	for _, token := range tokens {
+		freqs[token]++
+	}
+	return &document{tokens: tokens, freqs: freqs}
+}
+
+// occurrences returns how many times the consecutive tokens occur in the document.
+func (d *document) occurrences(tokens []string, prefix bool) int {
+	if len(tokens) == 1 && !prefix {
+		return d.freqs[tokens[0]]
+	}
+	count := 0
+	for i := 0; i+len(tokens) <= len(d.tokens); i++ {
+		matched := true
+		for j, token := range tokens {
+			if prefix && j == len(tokens)-1 {
+				matched = strings.HasPrefix(d.tokens[i+j], token)
+			} else {
+				matched = d.tokens[i+j] == token
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched {
+			count++
+		}
+	}
+	return count
+}
+
+// termRelevance is the relevance of a term which occurs count times.
+func termRelevance(count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return 1 + math.Log(float64(count))
+}
+
+// Relevance returns the relevance of the tokens of a text to the query. The text matches the query if the relevance
+// is positive. The relevance of a term only depends on how many times it occurs in the text, the frequencies of the
+// terms in the other rows aren't considered.
+func (q *Query) Relevance(tokens []string) float64 {
+	doc := newDocument(tokens)
+	if q.booleanMode {
+		_, relevance := evalGroup(q.terms, doc)
+		return relevance
+	}
+	relevance := 0.0
+	for _, word := range q.words {
+		relevance += termRelevance(doc.freqs[word])
+	}
+	return relevance
+}
+
+// evalGroup returns whether the document matches the terms and the relevance. A document matches the terms if all
+// the required terms are present, no excluded term is present, and at least one optional term is present when there
+// are no required terms.
+func evalGroup(terms []*term, doc *document) (bool, float64) {
+	hasRequired, hasOptional := false, false
+	relevance, factor := 0.0, 1.0
+	for _, t := range terms {
+		var present bool
+		var r float64
+		if t.group != nil {
+			present, r = evalGroup(t.group, doc)
+		} else {
+			r = termRelevance(doc.occurrences(t.tokens, t.prefix))
+			present = r > 0
+		}
+		switch t.op {
+		case opRequired:
+			if !present {
+				return false, 0
+			}
+			hasRequired = true
+			relevance += r
+		case opExcluded:
+			if present {
+				return false, 0
+			}
+		case opNegate:
+			if present {
+				factor /= 2
+			}
+		default:
+			if !present {
+				continue
+			}
+			hasOptional = true
+			switch t.op {
+			case opIncrease:
+				r *= 2
+			case opDecrease:
+				r /= 2
+			}
+			relevance += r
+		}
+	}
+	if !hasRequired && !hasOptional {
+		return false, 0
+	}
+	return true, relevance * factor
+}
+
+// TextRelevance returns the relevance of the text to the query, the text is split by the tokenizer of the query.
+func (q *Query) TextRelevance(text string) float64 {
+	return q.Relevance(q.tokenizer.Tokenize(text))
+}
+
+// IndexTerm is a token, or a prefix of tokens, to look up in a FULLTEXT index.
+type IndexTerm struct {
+	Token  string
+	Prefix bool
+}
+
+// IndexTerms returns the terms to look up in a FULLTEXT index for the rows which may match the query. The matched rows
+// contain all the terms if intersection is true, or any of the terms otherwise. ok is false if the rows can't be found
+// by the terms, for example, the query has only excluded terms.
+func (q *Query) IndexTerms() (terms []IndexTerm, intersection bool, ok bool) {
+	if !q.booleanMode {
+		for _, word := range q.words {
+			terms = append(terms, IndexTerm{Token: word})
+		}
+		return terms, false, len(terms) > 0
+	}
+	seen := make(map[IndexTerm]struct{})
+	add := func(t IndexTerm) {
+		if _, ok := seen[t]; !ok {
+			seen[t] = struct{}{}
+			terms = append(terms, t)
+		}
+	}
+	hasRequired := false
+	for _, t := range q.terms {
+		if t.op != opRequired {
+			continue
+		}
+		hasRequired = true
+		for i, token := range t.tokens {
+			add(IndexTerm{Token: token, Prefix: t.prefix && i == len(t.tokens)-1})
+		}
+	}
+	if hasRequired {
+		// The required groups can't be looked up, but the other required terms are enough.
+		return terms, true, len(terms) > 0
+	}
+	for _, t := range q.terms {
+		switch t.op {
+		case opExcluded, opNegate:
+			continue
+		}
+		if t.group != nil {
+			return nil, false, false
+		}
+		add(IndexTerm{Token: t.tokens[0], Prefix: t.prefix && len(t.tokens) == 1})
+	}
+	return terms, false, len(terms) > 0
+}
@@ -0,0 +1,109 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNaturalLanguageMode(t *testing.T) {
+	tk := NewTokenizer("", "utf8mb4_general_ci")
+	doc := tk.Tokenize("MySQL is a database, TiDB is a distributed database")
+	tbl := []struct {
+		query     string
+		relevance float64
+	}{
+		{"database", 1 + math.Log(2)},
+		{"Database database", 1 + math.Log(2)},
+		{"distributed database", 2 + math.Log(2)},
+		{"postgres", 0},
+		{"the is a", 0},
+		{"postgres tidb", 1},
+	}
+	for _, tt := range tbl {
+		require.InDelta(t, tt.relevance, ParseQuery(tk, tt.query, false).Relevance(doc), 1e-9, tt.query)
+	}
+}
+
+func TestBooleanMode(t *testing.T) {
+	tk := NewTokenizer("", "utf8mb4_general_ci")
+	doc := tk.Tokenize("MySQL is a database, TiDB is a distributed database")
+	tbl := []struct {
+		query     string
+		relevance float64
+	}{
+		{"+mysql +tidb", 2},
+		{"+mysql +postgres", 0},
+		{"+mysql -tidb", 0},
+		{"mysql -postgres", 1},
+		{"-mysql", 0},
+		{"postgres oracle", 0},
+		{">mysql <tidb", 2.5},
+		{"+mysql ~tidb", 0.5},
+		{"~tidb", 0},
+		{`"distributed database"`, 1},
+		{`"database distributed"`, 0},
+		{"distrib*", 1},
+		{"+mysql +(oracle tidb)", 2},
+		{"+mysql +(oracle postgres)", 0},
+		{"+mysql -(oracle tidb)", 0},
+		{"+(mysql", 1},
+		{`"mysql`, 1},
+		{"+ - mysql", 1},
+	}
+	for _, tt := range tbl {
+		require.InDelta(t, tt.relevance, ParseQuery(tk, tt.query, true).Relevance(doc), 1e-9, tt.query)
+	}
+
+	tk = NewTokenizer(ParserNgram, "utf8mb4_general_ci")
+	doc = tk.Tokenize("分布式数据库")
+	require.Greater(t, ParseQuery(tk, "+数据库", true).Relevance(doc), 0.0)
+	require.Greater(t, ParseQuery(tk, "+分布式*", true).Relevance(doc), 0.0)
+	require.Greater(t, ParseQuery(tk, "分*", true).Relevance(doc), 0.0)
+	require.Equal(t, 0.0, ParseQuery(tk, "+库数", true).Relevance(doc))
+}
+
+func TestIndexTerms(t *testing.T) {
+	tk := NewTokenizer("", "utf8mb4_general_ci")
+	tbl := []struct {
+		query        string
+		booleanMode  bool
+		terms        []IndexTerm
+		intersection bool
+		ok           bool
+	}{
+		{"mysql tidb mysql", false, []IndexTerm{{Token: "mysql"}, {Token: "tidb"}}, false, true},
+		{"the is", false, nil, false, false},
+		{"+mysql +tidb oracle -postgres", true, []IndexTerm{{Token: "mysql"}, {Token: "tidb"}}, true, true},
+		{`+"distributed database" +tid*`, true, []IndexTerm{{Token: "distributed"}, {Token: "database"}, {Token: "tid", Prefix: true}}, true, true},
+		{"mysql >tidb -postgres ~oracle", true, []IndexTerm{{Token: "mysql"}, {Token: "tidb"}}, false, true},
+		{`"distributed database" tid*`, true, []IndexTerm{{Token: "distributed"}, {Token: "tid", Prefix: true}}, false, true},
+		{"+(mysql tidb)", true, nil, true, false},
+		{"mysql (tidb oracle)", true, nil, false, false},
+		{"-mysql", true, nil, false, false},
+	}
+	for _, tt := range tbl {
+		terms, intersection, ok := ParseQuery(tk, tt.query, tt.booleanMode).IndexTerms()
+		require.Equal(t, tt.ok, ok, tt.query)
+		if !ok {
+			continue
+		}
+		require.Equal(t, tt.terms, terms, tt.query)
+		require.Equal(t, tt.intersection, intersection, tt.query)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/charset"
+)
+
+const (
+	// ParserNgram is the name of the n-gram parser, which splits the words into n-grams. It's used for the CJK text,
+	// whose words aren't separated by spaces.
+	ParserNgram = "ngram"
+	// NgramTokenSize is the length of the n-grams, it's the same as the default ngram_token_size of MySQL.
+	NgramTokenSize = 2
+	// MinTokenSize is the min length of the words indexed by the default parser, it's the same as the default
+	// innodb_ft_min_token_size of MySQL.
+	MinTokenSize = 3
+	// MaxTokenSize is the max length of the words indexed by the default parser, it's the same as the default
+	// innodb_ft_max_token_size of MySQL.
+	MaxTokenSize = 84
+)
+
+// stopwords are the default stopwords of InnoDB, they aren't indexed by the default parser.
+var stopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {}, "de": {}, "en": {},
+	"for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {}, "la": {}, "of": {}, "on": {}, "or": {},
+	"that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "what": {}, "when": {}, "where": {}, "who": {}, "will": {},
+	"with": {}, "und": {}, "www": {},
+}
+
+// IsSupportedParser returns whether the full-text parser is supported, the empty name is the default parser.
+func IsSupportedParser(name string) bool {
+	return name == "" || strings.EqualFold(name, ParserNgram)
+}
+
+// Tokenizer splits the text into the tokens stored in a FULLTEXT index.
+type Tokenizer struct {
+	ngram         bool
+	caseSensitive bool
+}
+
+// NewTokenizer creates a Tokenizer of the full-text parser for the text in the collation. The tokens are
+// case-insensitive unless the collation is binary or a _bin collation.
+func NewTokenizer(parser, collation string) *Tokenizer {
+	return &Tokenizer{
+		ngram:         strings.EqualFold(parser, ParserNgram),
+		caseSensitive: collation == charset.CollationBin || strings.HasSuffix(collation, "_bin"),
+	}
+}
+
+// Tokenize returns the tokens of the text in order, a token appears as many times as it occurs in the text.
+func (t *Tokenizer) Tokenize(text string) []string {
+	var tokens []string
+	forEachWord(text, func(word string) {
+		tokens = t.appendWordTokens(tokens, word)
+	})
+	return tokens
+}
+
+// appendWordTokens appends the tokens of a word to tokens.
+func (t *Tokenizer) appendWordTokens(tokens []string, word string) []string {
+	word = t.normalize(word)
+	length := utf8.RuneCountInString(word)
+	if !t.ngram {
+		if length < MinTokenSize || length > MaxTokenSize {
+			return tokens
+		}
+		if _, ok := stopwords[strings.ToLower(word)]; ok {
+			return tokens
+		}
+		return append(tokens, word)
+	}
+	// A word shorter than the n-grams has no token.
+	if length < NgramTokenSize {
+		return tokens
+	}
+	runes := []rune(word)
+	for i := 0; i+NgramTokenSize <= len(runes); i++ {
+		tokens = append(tokens, string(runes[i:i+NgramTokenSize]))
+	}
+	return tokens
+}
+
+func (t *Tokenizer) normalize(word string) string {
+	if t.caseSensitive {
+		return word
+	}
+	return strings.ToLower(word)
+}
+
+// isWordChar returns whether the rune is a part of a word, the words are separated by the other runes.
+func isWordChar(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.Is(unicode.Mn, r)
+}
+
+func forEachWord(text string, fn func(word string)) {
+	start := -1
+	for i, r := range text {
+		if isWordChar(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 {
+			fn(text[start:i])
+			start = -1
+		}
+	}
+	if start >= 0 {
+		fn(text[start:])
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	tbl := []struct {
+		parser    string
+		collation string
+		text      string
+		tokens    []string
+	}{
+		{"", "utf8mb4_general_ci", "", nil},
+		{"", "utf8mb4_general_ci", "The Quick brown-fox, jumps over the lazy dog.", []string{"quick", "brown", "fox", "jumps", "over", "lazy", "dog"}},
+		{"", "utf8mb4_bin", "Database DATABASE database", []string{"Database", "DATABASE", "database"}},
+		{"", "utf8mb4_general_ci", "go is an OK db_name", []string{"db_name"}},
+		{"", "utf8mb4_general_ci", "café naïve", []string{"café", "naïve"}},
+		{ParserNgram, "utf8mb4_general_ci", "分布式数据库", []string{"分布", "布式", "式数", "数据", "据库"}},
+		{ParserNgram, "utf8mb4_general_ci", "TiDB 是 数据库", []string{"ti", "id", "db", "数据", "据库"}},
+		{"NGRAM", "utf8mb4_general_ci", "ab", []string{"ab"}},
+	}
+	for _, tt := range tbl {
+		require.Equal(t, tt.tokens, NewTokenizer(tt.parser, tt.collation).Tokenize(tt.text), tt.text)
+	}
+}
+
+func TestIsSupportedParser(t *testing.T) {
+	require.True(t, IsSupportedParser(""))
+	require.True(t, IsSupportedParser("ngram"))
+	require.True(t, IsSupportedParser("NGram"))
+	require.False(t, IsSupportedParser("mecab"))
+}
@@ -859,6 +859,8 @@ const (
 	LabelForMemDB int = -28
 	// LabelForCursorFetch represents the label of the execution of cursor fetch
 	LabelForCursorFetch int = -29
+	// LabelForInstancePlanCache represents the label of the instance-level plan cache memory usage
+	LabelForInstancePlanCache int = -30
 )
 
 // MetricsTypes is used to get label for metrics
@@ -34,3 +34,23 @@ type PlanCacheMatchOpts struct {
 	// Below are some variables that can affect the plan
 	ForeignKeyChecks bool
 }
+
+// InstancePlanCacheStats records the status and the eviction statistics of the instance-level plan cache.
+type InstancePlanCacheStats struct {
+	// PlanNum is the number of the cached plans.
+	PlanNum int64
+	// MemUsage is the memory usage of the cached plans in bytes.
+	MemUsage int64
+	// MemLimit is the memory limit of the cache in bytes.
+	MemLimit int64
+	// Hits is the number of the lookups which get a cached plan.
+	Hits int64
+	// Misses is the number of the lookups which don't get a cached plan.
+	Misses int64
+	// Puts is the number of the plans put into the cache.
+	Puts int64
+	// Evictions is the number of the plans evicted because of the memory limit.
+	Evictions int64
+	// EvictedMem is the memory released by the evictions in bytes.
+	EvictedMem int64
+}
@@ -205,6 +205,14 @@ type RecordSet interface {
 	Close() error
 }
 
+// MultiRecordSet is the result of a statement that returns more than one result set, like
+// calling a stored procedure. The RecordSet methods read the first result set.
+type MultiRecordSet interface {
+	RecordSet
+	// RecordSets returns all the result sets in order.
+	RecordSets() []RecordSet
+}
+
 // MultiQueryNoDelayResult is an interface for one no-delay result for one statement in multi-queries.
 type MultiQueryNoDelayResult interface {
 	// AffectedRows return affected row for one statement in multi-queries.
@@ -380,6 +380,7 @@ type BindableStmt struct {
 	Charset   string
 	Collation string
 	Users     map[string]struct{} // which users have processed this stmt
+	ExecCount int64
 }
 
 // GetMoreThanCntBindableStmt gets users' select/update/delete SQLs that occurred more than the specified count.
@@ -408,6 +409,7 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 							Charset:   ssElement.charset,
 							Collation: ssElement.collation,
 							Users:     make(map[string]struct{}),
+							ExecCount: ssElement.execCount,
 						}
 						maps.Copy(stmt.Users, ssElement.authUsers)
 						// If it is SQL command prepare / execute, the ssElement.sampleSQL is `execute ...`, we should get the original select query.
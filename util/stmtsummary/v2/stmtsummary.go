@@ -359,6 +359,7 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 						Charset:   record.Charset,
 						Collation: record.Collation,
 						Users:     make(map[string]struct{}),
+						ExecCount: record.ExecCount,
 					}
 					maps.Copy(stmt.Users, record.AuthUsers)
 